@@ -0,0 +1,105 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ops for alert history errors.
+var (
+	OpFindCheckStatuses      = "FindCheckStatuses"
+	OpFindNotificationEvents = "FindNotificationEvents"
+)
+
+// DefaultAlertHistoryPeriod is the time range of the alert history when no
+// start is given.
+const DefaultAlertHistoryPeriod = 24 * time.Hour
+
+// CheckStatus is a status written by a check to the monitoring system bucket.
+type CheckStatus struct {
+	Time              time.Time         `json:"time"`
+	CheckID           ID                `json:"checkID"`
+	CheckName         string            `json:"checkName"`
+	CheckType         string            `json:"checkType,omitempty"`
+	Level             string            `json:"level"`
+	Message           string            `json:"message,omitempty"`
+	SourceMeasurement string            `json:"sourceMeasurement,omitempty"`
+	SourceTime        time.Time         `json:"sourceTime,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+}
+
+// NotificationEvent is a notification logged by a notification rule to the
+// monitoring system bucket, whether it was sent or not.
+type NotificationEvent struct {
+	Time         time.Time `json:"time"`
+	StatusTime   time.Time `json:"statusTime,omitempty"`
+	RuleID       ID        `json:"ruleID"`
+	RuleName     string    `json:"ruleName"`
+	EndpointID   ID        `json:"endpointID,omitempty"`
+	EndpointName string    `json:"endpointName,omitempty"`
+	CheckID      ID        `json:"checkID,omitempty"`
+	CheckName    string    `json:"checkName,omitempty"`
+	Level        string    `json:"level"`
+	Message      string    `json:"message,omitempty"`
+	Sent         bool      `json:"sent"`
+	// SilenceID is the silence that suppressed the notification, if any.
+	SilenceID ID                `json:"silenceID,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// AlertHistoryService represents a service for reading the statuses of the
+// checks and the notifications of the notification rules.
+type AlertHistoryService interface {
+	// FindCheckStatuses returns the statuses that match filter, the most
+	// recent first, and the total count of matching statuses.
+	FindCheckStatuses(ctx context.Context, filter AlertHistoryFilter, opt ...FindOptions) ([]*CheckStatus, int, error)
+	// FindNotificationEvents returns the notifications that match filter,
+	// the most recent first, and the total count of matching notifications.
+	FindNotificationEvents(ctx context.Context, filter AlertHistoryFilter, opt ...FindOptions) ([]*NotificationEvent, int, error)
+}
+
+// AlertHistoryFilter represents a set of filters that restrict the returned
+// statuses and notifications.
+type AlertHistoryFilter struct {
+	OrgID   ID
+	CheckID *ID
+	RuleID  *ID
+	// Start and Stop bound the time range, which defaults to the last
+	// DefaultAlertHistoryPeriod.
+	Start time.Time
+	Stop  time.Time
+	// Levels restricts the results to the levels when set.
+	Levels []string
+	// Tags must all be present in the results.
+	Tags []Tag
+}
+
+// QueryParams implements PagingFilter.
+func (f AlertHistoryFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if !f.Start.IsZero() {
+		qp["start"] = []string{f.Start.Format(time.RFC3339Nano)}
+	}
+	if !f.Stop.IsZero() {
+		qp["stop"] = []string{f.Stop.Format(time.RFC3339Nano)}
+	}
+	if len(f.Levels) > 0 {
+		qp["level"] = f.Levels
+	}
+	for _, t := range f.Tags {
+		qp["tag"] = append(qp["tag"], t.QueryParam())
+	}
+	return qp
+}
+
+// TimeRange returns the time range of the filter at now, with its defaults.
+func (f AlertHistoryFilter) TimeRange(now time.Time) (start, stop time.Time) {
+	start, stop = f.Start, f.Stop
+	if stop.IsZero() {
+		stop = now
+	}
+	if start.IsZero() {
+		start = stop.Add(-DefaultAlertHistoryPeriod)
+	}
+	return start, stop
+}
@@ -33,6 +33,16 @@ func (b BackupService) CreateBackup(ctx context.Context) (int, []string, error)
 	return b.s.CreateBackup(ctx)
 }
 
+func (b BackupService) CreateIncrementalBackup(ctx context.Context, exclude []influxdb.BackupFile) (int, []influxdb.BackupFile, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := IsAllowedAll(ctx, influxdb.ReadAllPermissions()); err != nil {
+		return 0, nil, err
+	}
+	return b.s.CreateIncrementalBackup(ctx, exclude)
+}
+
 func (b BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
@@ -0,0 +1,117 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// DBRPMappingService wraps a influxdb.DBRPMappingService and authorizes actions
+// against it appropriately. A dbrp mapping gives the influxdb 1.x api access to
+// its bucket: reading a mapping requires read access to the bucket, and changing
+// it requires write access.
+type DBRPMappingService struct {
+	s influxdb.DBRPMappingService
+}
+
+// NewDBRPMappingService constructs an instance of an authorizing dbrp mapping service.
+func NewDBRPMappingService(s influxdb.DBRPMappingService) *DBRPMappingService {
+	return &DBRPMappingService{
+		s: s,
+	}
+}
+
+func authorizeDBRPMapping(ctx context.Context, a influxdb.Action, m *influxdb.DBRPMapping) error {
+	p, err := newBucketPermission(a, m.OrganizationID, m.BucketID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *p)
+}
+
+// FindBy checks to see if the authorizer on context has read access to the bucket of the dbrp mapping.
+func (s *DBRPMappingService) FindBy(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	m, err := s.s.FindBy(ctx, orgID, cluster, db, rp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeDBRPMapping(ctx, influxdb.ReadAction, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Find returns the first dbrp mapping that matches filter and that the authorizer on context has read access to.
+func (s *DBRPMappingService) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	ms, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 1 {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "dbrp mapping not found",
+		}
+	}
+
+	return ms[0], nil
+}
+
+// FindMany retrieves all dbrp mappings that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	ms, _, err := s.s.FindMany(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	mappings := ms[:0]
+	for _, m := range ms {
+		err := authorizeDBRPMapping(ctx, influxdb.ReadAction, m)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		mappings = append(mappings, m)
+	}
+
+	return mappings, len(mappings), nil
+}
+
+// Create checks to see if the authorizer on context has write access to the bucket of the dbrp mapping.
+func (s *DBRPMappingService) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
+	if err := authorizeDBRPMapping(ctx, influxdb.WriteAction, m); err != nil {
+		return err
+	}
+
+	return s.s.Create(ctx, m)
+}
+
+// Delete checks to see if the authorizer on context has write access to the bucket of the dbrp mapping.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) error {
+	m, err := s.s.FindBy(ctx, orgID, cluster, db, rp)
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			// deleting a mapping that does not exist is not an error.
+			return nil
+		}
+		return err
+	}
+
+	if err := authorizeDBRPMapping(ctx, influxdb.WriteAction, m); err != nil {
+		return err
+	}
+
+	return s.s.Delete(ctx, orgID, cluster, db, rp)
+}
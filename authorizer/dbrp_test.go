@@ -0,0 +1,107 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestDBRPMappingService_FindMany(t *testing.T) {
+	m := mock.NewDBRPMappingService()
+	m.FindManyFn = func(context.Context, influxdb.DBRPMappingFilter, ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+		return []*influxdb.DBRPMapping{
+			{Cluster: "c", Database: "db1", RetentionPolicy: "rp", OrganizationID: 10, BucketID: 1},
+			{Cluster: "c", Database: "db2", RetentionPolicy: "rp", OrganizationID: 10, BucketID: 2},
+		}, 2, nil
+	}
+	s := authorizer.NewDBRPMappingService(m)
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				OrgID: influxdbtesting.IDPtr(10),
+				ID:    influxdbtesting.IDPtr(1),
+			},
+		},
+	}})
+	ms, n, err := s.FindMany(ctx, influxdb.DBRPMappingFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ms[0].Database != "db1" {
+		t.Fatalf("expected only the mapping of the readable bucket, got %v", ms)
+	}
+}
+
+func TestDBRPMappingService_Create(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+						ID:    influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to write the bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+						ID:    influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewDBRPMappingService(mock.NewDBRPMappingService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.Create(ctx, &influxdb.DBRPMapping{
+				Cluster:         "c",
+				Database:        "db",
+				RetentionPolicy: "rp",
+				OrganizationID:  10,
+				BucketID:        1,
+			})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.DownsamplePolicyService = (*DownsamplePolicyService)(nil)
+
+// DownsamplePolicyService wraps a influxdb.DownsamplePolicyService and authorizes actions
+// against it appropriately. A downsample policy belongs to its source bucket: reading it
+// requires read access to the source bucket, and changing it requires write access to both
+// the source and the destination buckets.
+type DownsamplePolicyService struct {
+	s influxdb.DownsamplePolicyService
+}
+
+// NewDownsamplePolicyService constructs an instance of an authorizing downsample policy service.
+func NewDownsamplePolicyService(s influxdb.DownsamplePolicyService) *DownsamplePolicyService {
+	return &DownsamplePolicyService{
+		s: s,
+	}
+}
+
+func authorizeReadDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy) error {
+	perm, err := newBucketPermission(influxdb.ReadAction, p.OrgID, p.BucketID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *perm)
+}
+
+func authorizeWriteDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy) error {
+	if err := authorizeWriteBucket(ctx, p.OrgID, p.BucketID); err != nil {
+		return err
+	}
+
+	return authorizeWriteBucket(ctx, p.OrgID, p.DestinationBucketID)
+}
+
+// FindDownsamplePolicyByID checks to see if the authorizer on context has read access to the source bucket of the policy.
+func (s *DownsamplePolicyService) FindDownsamplePolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+	p, err := s.s.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadDownsamplePolicy(ctx, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FindDownsamplePolicies retrieves all downsample policies that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *DownsamplePolicyService) FindDownsamplePolicies(ctx context.Context, filter influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error) {
+	// TODO: we'll likely want to push this operation into the database eventually since fetching the whole list of data
+	// will likely be expensive.
+	ps, _, err := s.s.FindDownsamplePolicies(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	policies := ps[:0]
+	for _, p := range ps {
+		err := authorizeReadDownsamplePolicy(ctx, p)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		policies = append(policies, p)
+	}
+
+	return policies, len(policies), nil
+}
+
+// CreateDownsamplePolicy checks to see if the authorizer on context has write access to the source and destination buckets.
+func (s *DownsamplePolicyService) CreateDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+	if err := authorizeWriteDownsamplePolicy(ctx, p); err != nil {
+		return err
+	}
+
+	return s.s.CreateDownsamplePolicy(ctx, p, userID)
+}
+
+// UpdateDownsamplePolicy checks to see if the authorizer on context has write access to the source and destination buckets.
+func (s *DownsamplePolicyService) UpdateDownsamplePolicy(ctx context.Context, id influxdb.ID, upd influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+	p, err := s.s.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteDownsamplePolicy(ctx, p); err != nil {
+		return nil, err
+	}
+
+	if upd.DestinationBucketID != nil {
+		if err := authorizeWriteBucket(ctx, p.OrgID, *upd.DestinationBucketID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.s.UpdateDownsamplePolicy(ctx, id, upd)
+}
+
+// DeleteDownsamplePolicy checks to see if the authorizer on context has write access to the source and destination buckets.
+func (s *DownsamplePolicyService) DeleteDownsamplePolicy(ctx context.Context, id influxdb.ID) error {
+	p, err := s.s.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWriteDownsamplePolicy(ctx, p); err != nil {
+		return err
+	}
+
+	return s.s.DeleteDownsamplePolicy(ctx, id)
+}
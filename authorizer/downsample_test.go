@@ -0,0 +1,142 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestDownsamplePolicyService_FindDownsamplePolicyByID(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		id         influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read the source bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+				id: 1,
+			},
+		},
+		{
+			name: "unauthorized to read the source bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(3),
+					},
+				},
+				id: 1,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock.NewDownsamplePolicyService()
+			m.FindDownsamplePolicyByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+				return &influxdb.DownsamplePolicy{
+					ID:                  id,
+					OrgID:               10,
+					BucketID:            2,
+					DestinationBucketID: 3,
+				}, nil
+			}
+			s := authorizer.NewDownsamplePolicyService(m)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.FindDownsamplePolicyByID(ctx, tt.args.id)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestDownsamplePolicyService_CreateDownsamplePolicy(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	writeBucket := func(id influxdb.ID) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "write",
+			Resource: influxdb.Resource{
+				Type: influxdb.BucketsResourceType,
+				ID:   influxdbtesting.IDPtr(id),
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write both buckets",
+			args: args{
+				permissions: []influxdb.Permission{writeBucket(2), writeBucket(3)},
+			},
+		},
+		{
+			name: "unauthorized to write the destination bucket",
+			args: args{
+				permissions: []influxdb.Permission{writeBucket(2)},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000003 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewDownsamplePolicyService(mock.NewDownsamplePolicyService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			err := s.CreateDownsamplePolicy(ctx, &influxdb.DownsamplePolicy{
+				OrgID:               10,
+				BucketID:            2,
+				DestinationBucketID: 3,
+			}, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
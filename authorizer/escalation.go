@@ -0,0 +1,139 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.EscalationPolicyService = (*EscalationPolicyService)(nil)
+
+// EscalationPolicyService wraps a influxdb.EscalationPolicyService and
+// authorizes actions against it appropriately. Escalation policies belong to
+// their notification rule: reading them requires read access to the rule, and
+// changing them or acknowledging their escalations requires write access.
+type EscalationPolicyService struct {
+	s influxdb.EscalationPolicyService
+}
+
+// NewEscalationPolicyService constructs an instance of an authorizing
+// escalation policy service.
+func NewEscalationPolicyService(s influxdb.EscalationPolicyService) *EscalationPolicyService {
+	return &EscalationPolicyService{
+		s: s,
+	}
+}
+
+func authorizeEscalationPolicy(ctx context.Context, a influxdb.Action, ruleID, orgID influxdb.ID) error {
+	p, err := influxdb.NewPermissionAtID(ruleID, a, influxdb.NotificationRuleResourceType, orgID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *p)
+}
+
+// FindEscalationPolicyByID checks to see if the authorizer on context has read access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) FindEscalationPolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.EscalationPolicy, error) {
+	p, err := s.s.FindEscalationPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeEscalationPolicy(ctx, influxdb.ReadAction, p.RuleID, p.OrgID); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FindEscalationPolicies retrieves all escalation policies that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *EscalationPolicyService) FindEscalationPolicies(ctx context.Context, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+	ps, _, err := s.s.FindEscalationPolicies(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	policies := ps[:0]
+	for _, p := range ps {
+		err := authorizeEscalationPolicy(ctx, influxdb.ReadAction, p.RuleID, p.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		policies = append(policies, p)
+	}
+
+	return policies, len(policies), nil
+}
+
+// CreateEscalationPolicy checks to see if the authorizer on context has write access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) CreateEscalationPolicy(ctx context.Context, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+	if err := authorizeEscalationPolicy(ctx, influxdb.WriteAction, p.RuleID, p.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.CreateEscalationPolicy(ctx, p, userID)
+}
+
+// UpdateEscalationPolicy checks to see if the authorizer on context has write access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) UpdateEscalationPolicy(ctx context.Context, id influxdb.ID, upd influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+	p, err := s.s.FindEscalationPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeEscalationPolicy(ctx, influxdb.WriteAction, p.RuleID, p.OrgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateEscalationPolicy(ctx, id, upd)
+}
+
+// DeleteEscalationPolicy checks to see if the authorizer on context has write access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) DeleteEscalationPolicy(ctx context.Context, id influxdb.ID) error {
+	p, err := s.s.FindEscalationPolicyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeEscalationPolicy(ctx, influxdb.WriteAction, p.RuleID, p.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteEscalationPolicy(ctx, id)
+}
+
+// FindEscalations checks to see if the authorizer on context has read access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) FindEscalations(ctx context.Context, policyID influxdb.ID) ([]*influxdb.Escalation, error) {
+	p, err := s.s.FindEscalationPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeEscalationPolicy(ctx, influxdb.ReadAction, p.RuleID, p.OrgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindEscalations(ctx, policyID)
+}
+
+// AcknowledgeEscalation checks to see if the authorizer on context has write access to the notification rule of the escalation policy.
+func (s *EscalationPolicyService) AcknowledgeEscalation(ctx context.Context, policyID influxdb.ID, key string, userID influxdb.ID) (*influxdb.Escalation, error) {
+	p, err := s.s.FindEscalationPolicyByID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeEscalationPolicy(ctx, influxdb.WriteAction, p.RuleID, p.OrgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.AcknowledgeEscalation(ctx, policyID, key, userID)
+}
@@ -0,0 +1,94 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestEscalationPolicyService_AcknowledgeEscalation(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the notification rule",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+						ID:    influxdbtesting.IDPtr(2),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to write another notification rule",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+						ID:    influxdbtesting.IDPtr(3),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationRules/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the notification rule only",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationRules/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock.NewEscalationPolicyService()
+			m.FindEscalationPolicyByIDFn = func(context.Context, influxdb.ID) (*influxdb.EscalationPolicy, error) {
+				return &influxdb.EscalationPolicy{ID: 1, OrgID: 10, RuleID: 2}, nil
+			}
+			s := authorizer.NewEscalationPolicyService(m)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.AcknowledgeEscalation(ctx, 1, "0000000000000001", 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.IncidentService = (*IncidentService)(nil)
+
+// IncidentService wraps a influxdb.IncidentService and authorizes actions
+// against it appropriately. Incidents belong to their check: reading them
+// requires read access to the check, and acknowledging or commenting them
+// requires write access.
+type IncidentService struct {
+	s influxdb.IncidentService
+}
+
+// NewIncidentService constructs an instance of an authorizing incident service.
+func NewIncidentService(s influxdb.IncidentService) *IncidentService {
+	return &IncidentService{
+		s: s,
+	}
+}
+
+func authorizeIncident(ctx context.Context, a influxdb.Action, i *influxdb.Incident) error {
+	p, err := influxdb.NewPermissionAtID(i.CheckID, a, influxdb.ChecksResourceType, i.OrgID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *p)
+}
+
+// FindIncidentByID checks to see if the authorizer on context has read access to the check of the incident.
+func (s *IncidentService) FindIncidentByID(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	i, err := s.s.FindIncidentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeIncident(ctx, influxdb.ReadAction, i); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// FindIncidents retrieves all incidents that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *IncidentService) FindIncidents(ctx context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+	is, _, err := s.s.FindIncidents(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	incidents := is[:0]
+	for _, i := range is {
+		err := authorizeIncident(ctx, influxdb.ReadAction, i)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		incidents = append(incidents, i)
+	}
+
+	return incidents, len(incidents), nil
+}
+
+// AcknowledgeIncident checks to see if the authorizer on context has write access to the check of the incident.
+func (s *IncidentService) AcknowledgeIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID) (*influxdb.Incident, error) {
+	if err := s.authorizeWrite(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.AcknowledgeIncident(ctx, id, userID)
+}
+
+// UnacknowledgeIncident checks to see if the authorizer on context has write access to the check of the incident.
+func (s *IncidentService) UnacknowledgeIncident(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	if err := s.authorizeWrite(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UnacknowledgeIncident(ctx, id)
+}
+
+// CommentIncident checks to see if the authorizer on context has write access to the check of the incident.
+func (s *IncidentService) CommentIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID, text string) (*influxdb.Incident, error) {
+	if err := s.authorizeWrite(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.CommentIncident(ctx, id, userID, text)
+}
+
+func (s *IncidentService) authorizeWrite(ctx context.Context, id influxdb.ID) error {
+	i, err := s.s.FindIncidentByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return authorizeIncident(ctx, influxdb.WriteAction, i)
+}
@@ -0,0 +1,103 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestIncidentService_FindIncidents(t *testing.T) {
+	m := mock.NewIncidentService()
+	m.FindIncidentsFn = func(context.Context, influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+		return []*influxdb.Incident{
+			{ID: 1, OrgID: 10, CheckID: 2},
+			{ID: 2, OrgID: 10, CheckID: 3},
+		}, 2, nil
+	}
+	s := authorizer.NewIncidentService(m)
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{
+		{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  influxdb.ChecksResourceType,
+				OrgID: influxdbtesting.IDPtr(10),
+				ID:    influxdbtesting.IDPtr(2),
+			},
+		},
+	}})
+	is, n, err := s.FindIncidents(ctx, influxdb.IncidentFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || is[0].ID != 1 {
+		t.Fatalf("expected only the incident of the readable check, got %v", is)
+	}
+}
+
+func TestIncidentService_AcknowledgeIncident(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the checks of the organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to write the check",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.ChecksResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/checks/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock.NewIncidentService()
+			m.FindIncidentByIDFn = func(context.Context, influxdb.ID) (*influxdb.Incident, error) {
+				return &influxdb.Incident{ID: 1, OrgID: 10, CheckID: 2}, nil
+			}
+			s := authorizer.NewIncidentService(m)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.AcknowledgeIncident(ctx, 1, 6)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
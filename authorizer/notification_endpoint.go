@@ -162,3 +162,35 @@ func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Con
 
 	return s.s.DeleteNotificationEndpoint(ctx, id)
 }
+
+var _ influxdb.NotificationEndpointTestService = (*NotificationEndpointTestService)(nil)
+
+// NotificationEndpointTestService wraps a influxdb.NotificationEndpointTestService and authorizes
+// actions against it appropriately. Sending a test notification uses the secrets of the endpoint,
+// so it requires write access to the endpoint.
+type NotificationEndpointTestService struct {
+	s             influxdb.NotificationEndpointTestService
+	endpointStore influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointTestService constructs an instance of an authorizing notification endpoint test service.
+func NewNotificationEndpointTestService(s influxdb.NotificationEndpointTestService, endpointStore influxdb.NotificationEndpointService) *NotificationEndpointTestService {
+	return &NotificationEndpointTestService{
+		s:             s,
+		endpointStore: endpointStore,
+	}
+}
+
+// TestNotificationEndpoint checks to see if the authorizer on context has write access to the notification endpoint provided.
+func (s *NotificationEndpointTestService) TestNotificationEndpoint(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+	edp, err := s.endpointStore.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeWriteNotificationEndpoint(ctx, edp.GetOrgID(), edp.GetID()); err != nil {
+		return nil, err
+	}
+
+	return s.s.TestNotificationEndpoint(ctx, id)
+}
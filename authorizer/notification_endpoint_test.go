@@ -675,3 +675,74 @@ func TestNotificationEndpointService_CreateNotificationEndpoint(t *testing.T) {
 func idPtr(id influxdb.ID) *influxdb.ID {
 	return &id
 }
+
+func TestNotificationEndpointTestService_TestNotificationEndpoint(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to test notification endpoint",
+			args: args{
+				permission: influxdb.Permission{
+					Action: influxdb.WriteAction,
+					Resource: influxdb.Resource{
+						Type: influxdb.NotificationEndpointResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to test notification endpoint",
+			args: args{
+				permission: influxdb.Permission{
+					Action: influxdb.ReadAction,
+					Resource: influxdb.Resource{
+						Type: influxdb.NotificationEndpointResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationEndpoints/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mock.NotificationEndpointService{
+				FindNotificationEndpointByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+					return &endpoint.Slack{
+						Base: endpoint.Base{
+							ID:    idPtr(id),
+							OrgID: idPtr(10),
+						},
+					}, nil
+				},
+			}
+			s := authorizer.NewNotificationEndpointTestService(mock.NewNotificationEndpointTestService(), store)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.TestNotificationEndpoint(ctx, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
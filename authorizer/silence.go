@@ -0,0 +1,110 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.SilenceService = (*SilenceService)(nil)
+
+// SilenceService wraps a influxdb.SilenceService and authorizes actions
+// against it appropriately. Silences mute the notification rules of their
+// organization: reading them requires read access to the notification rules
+// of the organization, and changing them requires write access.
+type SilenceService struct {
+	s influxdb.SilenceService
+}
+
+// NewSilenceService constructs an instance of an authorizing silence service.
+func NewSilenceService(s influxdb.SilenceService) *SilenceService {
+	return &SilenceService{
+		s: s,
+	}
+}
+
+func authorizeSilence(ctx context.Context, a influxdb.Action, orgID influxdb.ID) error {
+	p, err := influxdb.NewPermission(a, influxdb.NotificationRuleResourceType, orgID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *p)
+}
+
+// FindSilenceByID checks to see if the authorizer on context has read access to the notification rules of the organization of the silence.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeSilence(ctx, influxdb.ReadAction, sil.OrgID); err != nil {
+		return nil, err
+	}
+
+	return sil, nil
+}
+
+// FindSilences retrieves all silences that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+	ss, _, err := s.s.FindSilences(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	silences := ss[:0]
+	for _, sil := range ss {
+		err := authorizeSilence(ctx, influxdb.ReadAction, sil.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		silences = append(silences, sil)
+	}
+
+	return silences, len(silences), nil
+}
+
+// CreateSilence checks to see if the authorizer on context has write access to the notification rules of the organization.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence, userID influxdb.ID) error {
+	if err := authorizeSilence(ctx, influxdb.WriteAction, sil.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.CreateSilence(ctx, sil, userID)
+}
+
+// UpdateSilence checks to see if the authorizer on context has write access to the notification rules of the organization of the silence.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeSilence(ctx, influxdb.WriteAction, sil.OrgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateSilence(ctx, id, upd)
+}
+
+// DeleteSilence checks to see if the authorizer on context has write access to the notification rules of the organization of the silence.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeSilence(ctx, influxdb.WriteAction, sil.OrgID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteSilence(ctx, id)
+}
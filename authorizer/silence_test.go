@@ -0,0 +1,100 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestSilenceService_FindSilences(t *testing.T) {
+	readRules := func(orgID influxdb.ID) influxdb.Permission {
+		return influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  influxdb.NotificationRuleResourceType,
+				OrgID: influxdbtesting.IDPtr(orgID),
+			},
+		}
+	}
+
+	m := mock.NewSilenceService()
+	m.FindSilencesFn = func(context.Context, influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+		return []*influxdb.Silence{
+			{ID: 1, OrgID: 10},
+			{ID: 2, OrgID: 11},
+		}, 2, nil
+	}
+	s := authorizer.NewSilenceService(m)
+
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{[]influxdb.Permission{readRules(10)}})
+	ss, n, err := s.FindSilences(ctx, influxdb.SilenceFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ss[0].ID != 1 {
+		t.Fatalf("expected only the silence of the readable organization, got %v", ss)
+	}
+}
+
+func TestSilenceService_CreateSilence(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the notification rules of the organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized to write the notification rules of the organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.NotificationRuleResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/notificationRules is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewSilenceService(mock.NewSilenceService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.CreateSilence(ctx, &influxdb.Silence{OrgID: 10}, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
@@ -318,6 +318,178 @@ func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID influxdb.ID
 	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
 }
 
+func (ts *taskServiceValidator) BackfillTask(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status != string(influxdb.TaskActive) {
+		return nil, ErrInactiveTask
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.WriteAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "BackfillTask"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.BackfillTask(ctx, taskID, bc)
+}
+
+func (ts *taskServiceValidator) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.ReadAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindBackfills"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindBackfills(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.ReadAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindBackfillByID"), zap.Stringer("task_id", taskID), zap.Stringer("backfill_id", id),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindBackfillByID(ctx, taskID, id)
+}
+
+func (ts *taskServiceValidator) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.WriteAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "CancelBackfill"), zap.Stringer("task_id", taskID), zap.Stringer("backfill_id", id),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.CancelBackfill(ctx, taskID, id)
+}
+
+func (ts *taskServiceValidator) FindTaskRevisions(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.ReadAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindTaskRevisions"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindTaskRevisions(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) FindTaskRevision(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.ReadAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "FindTaskRevision"), zap.Stringer("task_id", taskID), zap.Int("revision", revision),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskRevision(ctx, taskID, revision)
+}
+
+func (ts *taskServiceValidator) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int, restoreStatus bool) (*influxdb.Task, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := influxdb.NewPermissionAtID(taskID, influxdb.WriteAction, influxdb.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "RollbackTask"), zap.Stringer("task_id", taskID), zap.Int("revision", revision),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.RollbackTask(ctx, taskID, revision, restoreStatus)
+}
+
 func (ts *taskServiceValidator) validatePermission(ctx context.Context, perm influxdb.Permission, loggerFields ...zap.Field) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
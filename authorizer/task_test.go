@@ -74,6 +74,15 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`,
 		Log:          []influxdb.Log{log},
 	}
 
+	backfill := influxdb.Backfill{
+		ID:     runID,
+		TaskID: taskID,
+		Status: influxdb.BackfillRunning,
+	}
+
+	revision := *influxdb.NewTaskRevision(&task)
+	revision.Revision = 1
+
 	return &mock.TaskService{
 		FindTaskByIDFn: func(context.Context, influxdb.ID) (*influxdb.Task, error) {
 			return &task, nil
@@ -109,6 +118,24 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`,
 		ForceRunFn: func(context.Context, influxdb.ID, int64) (*influxdb.Run, error) {
 			return &run, nil
 		},
+		BackfillTaskFn: func(context.Context, influxdb.ID, influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+			return &backfill, nil
+		},
+		FindBackfillsFn: func(context.Context, influxdb.ID) ([]*influxdb.Backfill, int, error) {
+			return []*influxdb.Backfill{&backfill}, 1, nil
+		},
+		CancelBackfillFn: func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Backfill, error) {
+			return &backfill, nil
+		},
+		FindTaskRevisionsFn: func(context.Context, influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+			return []*influxdb.TaskRevision{&revision}, 1, nil
+		},
+		FindTaskRevisionFn: func(context.Context, influxdb.ID, int) (*influxdb.TaskRevision, error) {
+			return &revision, nil
+		},
+		RollbackTaskFn: func(context.Context, influxdb.ID, int, bool) (*influxdb.Task, error) {
+			return &task, nil
+		},
 	}
 }
 
@@ -556,6 +583,120 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`
 				return err
 			},
 		},
+		{
+			name: "BackfillTask with read auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.BackfillTask(ctx, taskID, influxdb.BackfillCreate{})
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "BackfillTask with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.BackfillTask(ctx, taskID, influxdb.BackfillCreate{})
+				return err
+			},
+		},
+		{
+			name: "FindBackfills with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindBackfills(ctx, taskID)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "FindBackfills with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindBackfills(ctx, taskID)
+				return err
+			},
+		},
+		{
+			name: "CancelBackfill with read auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.CancelBackfill(ctx, taskID, runID)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "CancelBackfill with org auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.CancelBackfill(ctx, taskID, runID)
+				return err
+			},
+		},
+		{
+			name: "FindTaskRevisions with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindTaskRevisions(ctx, taskID)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "FindTaskRevisions with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindTaskRevisions(ctx, taskID)
+				return err
+			},
+		},
+		{
+			name: "FindTaskRevision with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.FindTaskRevision(ctx, taskID, 1)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "FindTaskRevision with org auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.FindTaskRevision(ctx, taskID, 1)
+				return err
+			},
+		},
+		{
+			name: "RollbackTask with read auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.RollbackTask(ctx, taskID, 1, false)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "RollbackTask with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.RollbackTask(ctx, taskID, 1, false)
+				return err
+			},
+		},
 	}
 
 	for _, test := range tests {
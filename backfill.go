@@ -0,0 +1,107 @@
+package influxdb
+
+import (
+	"fmt"
+	"time"
+)
+
+// ops for backfill errors.
+var (
+	OpBackfillTask     = "BackfillTask"
+	OpFindBackfills    = "FindBackfills"
+	OpFindBackfillByID = "FindBackfillByID"
+	OpCancelBackfill   = "CancelBackfill"
+	OpUpdateBackfill   = "UpdateBackfill"
+)
+
+const (
+	// MaxBackfillRuns is the maximum number of schedule points a backfill
+	// can run.
+	MaxBackfillRuns = 10000
+
+	// DefaultBackfillConcurrency is the number of runs of a backfill run at
+	// the same time when its max concurrency is not set.
+	DefaultBackfillConcurrency = 1
+
+	// MaxBackfillConcurrency bounds the number of runs of a backfill run at
+	// the same time.
+	MaxBackfillConcurrency = 10
+)
+
+// BackfillStatus is whether a backfill is running, has completed, was
+// canceled or failed, when the server stopped while it was running.
+type BackfillStatus string
+
+// The statuses of the backfills.
+const (
+	BackfillRunning   BackfillStatus = "running"
+	BackfillCompleted BackfillStatus = "completed"
+	BackfillCanceled  BackfillStatus = "canceled"
+	BackfillFailed    BackfillStatus = "failed"
+)
+
+// Backfill runs a task for every point of its schedule in a range of time,
+// as if the task had been active then.
+type Backfill struct {
+	ID     ID `json:"id,omitempty"`
+	TaskID ID `json:"taskID"`
+	// Start and Stop bound the schedule points of the backfill, Start
+	// inclusive and Stop exclusive.
+	Start          time.Time      `json:"start"`
+	Stop           time.Time      `json:"stop"`
+	MaxConcurrency int            `json:"maxConcurrency"`
+	Status         BackfillStatus `json:"status"`
+	// Total is the number of schedule points of the backfill.
+	Total      int        `json:"total"`
+	Succeeded  int        `json:"succeeded"`
+	Failed     int        `json:"failed"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Running reports whether the backfill is still running.
+func (b *Backfill) Running() bool {
+	return b.Status == BackfillRunning
+}
+
+// BackfillCreate is the set of values to create a backfill of a task.
+type BackfillCreate struct {
+	Start time.Time `json:"start"`
+	Stop  time.Time `json:"stop"`
+	// MaxConcurrency is the number of runs of the backfill run at the same
+	// time, DefaultBackfillConcurrency when not set.
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// Validate validates the range and the concurrency of the backfill.
+func (b BackfillCreate) Validate() error {
+	switch {
+	case b.Start.IsZero() || b.Stop.IsZero():
+		return &Error{
+			Code: EInvalid,
+			Msg:  "backfill start and stop are required",
+		}
+	case !b.Start.Before(b.Stop):
+		return &Error{
+			Code: EInvalid,
+			Msg:  "backfill start must be before stop",
+		}
+	case b.MaxConcurrency < 0 || b.MaxConcurrency > MaxBackfillConcurrency:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("backfill max concurrency must be between 1 and %d", MaxBackfillConcurrency),
+		}
+	}
+	return nil
+}
+
+// BackfillUpdate is the progress of a backfill recorded by the executor
+// running it.
+type BackfillUpdate struct {
+	Succeeded *int
+	Failed    *int
+	// Status finishes the backfill when it is not BackfillRunning. A
+	// backfill that is finished is not updated anymore.
+	Status *BackfillStatus
+}
@@ -3,6 +3,7 @@ package influxdb
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // BackupService represents the data backup functions of InfluxDB.
@@ -10,6 +11,10 @@ type BackupService interface {
 	// CreateBackup creates a local copy (hard links) of the TSM data for all orgs and buckets.
 	// The return values are used to download each backup file.
 	CreateBackup(context.Context) (backupID int, backupFiles []string, err error)
+	// CreateIncrementalBackup creates a local copy (hard links) of the TSM files, tombstones
+	// and closed WAL segments that are not listed in exclude. The returned files are all the
+	// files of the storage engine; only those not excluded can be downloaded.
+	CreateIncrementalBackup(ctx context.Context, exclude []BackupFile) (backupID int, backupFiles []BackupFile, err error)
 	// FetchBackupFile downloads one backup file, data or metadata.
 	FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error
 	// InternalBackupPath is a utility to determine the on-disk location of a backup fileset.
@@ -21,3 +26,65 @@ type KVBackupService interface {
 	// Backup creates a live backup copy of the metadata database.
 	Backup(ctx context.Context, w io.Writer) error
 }
+
+// BackupFile identifies a file of a backup. Storage files are immutable
+// once written, except for tombstones which only grow, so the name and
+// size of a file identify its content.
+type BackupFile struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// BackupFileType is the kind of data held by a backup file.
+type BackupFileType string
+
+// Types of backup files.
+const (
+	BackupFileTypeTSM         BackupFileType = "tsm"
+	BackupFileTypeTombstone   BackupFileType = "tombstone"
+	BackupFileTypeWAL         BackupFileType = "wal"
+	BackupFileTypeKV          BackupFileType = "kv"
+	BackupFileTypeCredentials BackupFileType = "credentials"
+)
+
+// BackupManifestFilename is the name of the manifest file written to the
+// directory of every backup.
+const BackupManifestFilename = "manifest.json"
+
+// BackupManifestVersion is the current version of the manifest format.
+const BackupManifestVersion = 1
+
+// BackupManifest lists the files that make up a backup. An incremental
+// backup only downloads the files that are not part of the manifest it is
+// based on and refers to the others by their path, so restoring a manifest
+// restores the state of the server at the time that backup was taken.
+type BackupManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Base is the path of the directory of the backup this one is based on,
+	// relative to the directory of the manifest. It is empty for full backups.
+	Base  string               `json:"base,omitempty"`
+	Files []BackupManifestFile `json:"files"`
+}
+
+// BackupManifestFile is a file of a backup manifest.
+type BackupManifestFile struct {
+	BackupFile
+	Type BackupFileType `json:"type"`
+	// Path is the location of the file relative to the directory of the manifest.
+	Path string `json:"path"`
+	// Checksum is the hex encoded SHA-256 checksum of the file.
+	Checksum string `json:"checksum"`
+}
+
+// StorageFiles returns the files of the manifest that belong to the storage engine.
+func (m *BackupManifest) StorageFiles() []BackupFile {
+	var files []BackupFile
+	for _, f := range m.Files {
+		switch f.Type {
+		case BackupFileTypeTSM, BackupFileTypeTombstone, BackupFileTypeWAL:
+			files = append(files, f.BackupFile)
+		}
+	}
+	return files
+}
@@ -41,6 +41,7 @@ type Bucket struct {
 	Description         string        `json:"description"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	Schema              *BucketSchema `json:"schema,omitempty"`
 	CRUDLog
 }
 
@@ -104,6 +105,10 @@ type BucketUpdate struct {
 	Name            *string        `json:"name,omitempty"`
 	Description     *string        `json:"description,omitempty"`
 	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+
+	// Schema replaces the schema of the bucket. A schema without any
+	// measurement removes the schema of the bucket.
+	Schema *BucketSchema `json:"schema,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
@@ -0,0 +1,168 @@
+package influxdb
+
+import "fmt"
+
+// SchemaFieldType is the type of a field in a bucket schema.
+type SchemaFieldType string
+
+// Field types of bucket schemas.
+const (
+	SchemaFieldTypeFloat    SchemaFieldType = "float"
+	SchemaFieldTypeInteger  SchemaFieldType = "integer"
+	SchemaFieldTypeUnsigned SchemaFieldType = "unsigned"
+	SchemaFieldTypeString   SchemaFieldType = "string"
+	SchemaFieldTypeBoolean  SchemaFieldType = "boolean"
+)
+
+// Valid returns an error if the field type is unknown.
+func (t SchemaFieldType) Valid() error {
+	switch t {
+	case SchemaFieldTypeFloat, SchemaFieldTypeInteger, SchemaFieldTypeUnsigned, SchemaFieldTypeString, SchemaFieldTypeBoolean:
+		return nil
+	}
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid field type %q, expected one of float, integer, unsigned, string or boolean", t),
+	}
+}
+
+// BucketSchema is the explicit schema of a bucket. When a bucket has a schema,
+// only the measurements, tag keys and fields it lists can be written to the
+// bucket, and every field must have the type it declares. Points that do not
+// match the schema are dropped from the write.
+type BucketSchema struct {
+	Measurements []MeasurementSchema `json:"measurements"`
+}
+
+// MeasurementSchema lists the tag keys and fields allowed in a measurement.
+type MeasurementSchema struct {
+	Name   string        `json:"name"`
+	Tags   []string      `json:"tags,omitempty"`
+	Fields []FieldSchema `json:"fields"`
+}
+
+// FieldSchema is the name and type of a field of a measurement.
+type FieldSchema struct {
+	Name string          `json:"name"`
+	Type SchemaFieldType `json:"type"`
+}
+
+// Measurement returns the schema of the named measurement, or nil if the
+// measurement is not part of the schema.
+func (s *BucketSchema) Measurement(name string) *MeasurementSchema {
+	for i := range s.Measurements {
+		if s.Measurements[i].Name == name {
+			return &s.Measurements[i]
+		}
+	}
+	return nil
+}
+
+// Valid returns an error if the schema declares a measurement, tag key or field
+// twice, or if a name is reserved.
+func (s *BucketSchema) Valid() error {
+	if len(s.Measurements) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "bucket schema requires at least one measurement",
+		}
+	}
+
+	measurements := make(map[string]bool, len(s.Measurements))
+	for _, m := range s.Measurements {
+		if m.Name == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "bucket schema measurement requires a name",
+			}
+		}
+		if measurements[m.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("bucket schema declares measurement %q more than once", m.Name),
+			}
+		}
+		measurements[m.Name] = true
+
+		if err := m.valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MeasurementSchema) valid() error {
+	if len(m.Fields) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("measurement %q requires at least one field", m.Name),
+		}
+	}
+
+	tags := make(map[string]bool, len(m.Tags))
+	for _, k := range m.Tags {
+		if err := validSchemaKey(m.Name, "tag key", k); err != nil {
+			return err
+		}
+		if tags[k] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q declares tag key %q more than once", m.Name, k),
+			}
+		}
+		tags[k] = true
+	}
+
+	fields := make(map[string]bool, len(m.Fields))
+	for _, f := range m.Fields {
+		if err := validSchemaKey(m.Name, "field", f.Name); err != nil {
+			return err
+		}
+		if fields[f.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement %q declares field %q more than once", m.Name, f.Name),
+			}
+		}
+		fields[f.Name] = true
+
+		if err := f.Type.Valid(); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("field %q of measurement %q", f.Name, m.Name),
+				Err:  err,
+			}
+		}
+	}
+	return nil
+}
+
+// reservedSchemaKeys are the names that cannot be used as tag keys or fields,
+// as they are either rejected by the storage engine or used by flux for the
+// columns of the data it reads.
+var reservedSchemaKeys = map[string]bool{
+	"time":         true,
+	"_measurement": true,
+	"_field":       true,
+	"_start":       true,
+	"_stop":        true,
+	"_time":        true,
+	"_value":       true,
+}
+
+// validSchemaKey returns an error if a tag key or field name cannot be written.
+func validSchemaKey(measurement, kind, key string) error {
+	if key == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("measurement %q declares an empty %s", measurement, kind),
+		}
+	}
+	if reservedSchemaKeys[key] {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("measurement %q declares %s %q, which is reserved", measurement, kind, key),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+)
+
+func TestBucketSchema_Valid(t *testing.T) {
+	valid := func() *influxdb.BucketSchema {
+		return &influxdb.BucketSchema{
+			Measurements: []influxdb.MeasurementSchema{
+				{
+					Name: "cpu",
+					Tags: []string{"host", "region"},
+					Fields: []influxdb.FieldSchema{
+						{Name: "usage", Type: influxdb.SchemaFieldTypeFloat},
+						{Name: "cores", Type: influxdb.SchemaFieldTypeInteger},
+					},
+				},
+				{
+					Name:   "mem",
+					Fields: []influxdb.FieldSchema{{Name: "free", Type: influxdb.SchemaFieldTypeUnsigned}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		update  func(s *influxdb.BucketSchema)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(s *influxdb.BucketSchema) {},
+		},
+		{
+			name:    "no measurements",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements = nil },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate measurement",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[1].Name = "cpu" },
+			wantErr: true,
+		},
+		{
+			name:    "measurement without fields",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[1].Fields = nil },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate tag key",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[0].Tags[1] = "host" },
+			wantErr: true,
+		},
+		{
+			name:    "reserved tag key",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[0].Tags[1] = "_measurement" },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate field",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[0].Fields[1].Name = "usage" },
+			wantErr: true,
+		},
+		{
+			name:    "reserved field",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[0].Fields[1].Name = "time" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown field type",
+			update:  func(s *influxdb.BucketSchema) { s.Measurements[0].Fields[1].Type = "double" },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.update(s)
+			err := s.Valid()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package influxdb
+
+import "context"
+
+// Number of values reported for each tag key by the cardinality of a bucket.
+const (
+	DefaultCardinalityTopN = 10
+	MaxCardinalityTopN     = 1000
+)
+
+// BucketCardinality breaks the series cardinality of a bucket down by measurement
+// and tag key, to find the tags responsible for a high cardinality.
+type BucketCardinality struct {
+	OrgID    ID `json:"orgID"`
+	BucketID ID `json:"bucketID"`
+	// Series is the number of series of the bucket.
+	Series int64 `json:"series"`
+	// Measurements are sorted by descending number of series.
+	Measurements []MeasurementCardinality `json:"measurements"`
+	// TagKeys are sorted by descending number of values. The field keys are
+	// reported as the values of the _field tag key.
+	TagKeys []TagKeyCardinality `json:"tagKeys"`
+}
+
+// MeasurementCardinality is the number of series of a measurement.
+type MeasurementCardinality struct {
+	Name   string `json:"name"`
+	Series int64  `json:"series"`
+}
+
+// TagKeyCardinality is the number of values of a tag key and of the series
+// having it, with the values having the most series.
+type TagKeyCardinality struct {
+	Key string `json:"key"`
+	// Values is the number of distinct values of the tag key.
+	Values int64 `json:"values"`
+	// Series is the number of series having the tag key.
+	Series int64 `json:"series"`
+	// TopValues are the values having the most series, in descending order.
+	TopValues []TagValueCardinality `json:"topValues"`
+}
+
+// TagValueCardinality is the number of series having a tag value.
+type TagValueCardinality struct {
+	Value  string `json:"value"`
+	Series int64  `json:"series"`
+}
+
+// CardinalityService reports the series cardinality of buckets from the index
+// of a running server.
+type CardinalityService interface {
+	// BucketCardinality returns the cardinality of a bucket, with the topN values
+	// having the most series for each tag key.
+	BucketCardinality(ctx context.Context, orgID, bucketID ID, topN int) (*BucketCardinality, error)
+}
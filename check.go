@@ -3,6 +3,7 @@ package influxdb
 import (
 	"context"
 	"encoding/json"
+	"time"
 )
 
 // consts for checks config.
@@ -148,3 +149,27 @@ func (f CheckFilter) QueryParams() map[string][]string {
 
 	return qp
 }
+
+// CheckDryRunMaxRuns is the maximum number of scheduled runs of a check
+// replayed by a dry run, a day of checks that run every minute.
+const CheckDryRunMaxRuns = 1440
+
+// ops for check dry run errors.
+var (
+	OpDryRunCheck     = "DryRunCheck"
+	OpDryRunCheckByID = "DryRunCheckByID"
+)
+
+// CheckDryRunService represents a service for backtesting checks. A dry run
+// replays the runs a check would have had between start and stop over the
+// data stored then, with the permissions of the authorizer of the context,
+// and returns the statuses the check would have written without writing them.
+// When start or stop is zero, the dry run covers the last day.
+type CheckDryRunService interface {
+	// DryRunCheck replays the runs of a check definition, which does not need
+	// to be created.
+	DryRunCheck(ctx context.Context, chk Check, start, stop time.Time) ([]*CheckStatus, error)
+
+	// DryRunCheckByID replays the runs of an existing check.
+	DryRunCheckByID(ctx context.Context, id ID, start, stop time.Time) ([]*CheckStatus, error)
+}
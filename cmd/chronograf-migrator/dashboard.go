@@ -379,7 +379,7 @@ func convertQueries(qs []chronograf.DashboardQuery) []influxdb.DashboardQuery {
 type dbrpMapper struct {
 }
 
-func (m dbrpMapper) FindBy(ctx context.Context, cluster string, db string, rp string) (*influxdb.DBRPMapping, error) {
+func (m dbrpMapper) FindBy(ctx context.Context, orgID influxdb.ID, cluster string, db string, rp string) (*influxdb.DBRPMapping, error) {
 	return nil, errors.New("mapping not found")
 }
 func (m dbrpMapper) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
@@ -392,6 +392,6 @@ func (m dbrpMapper) FindMany(ctx context.Context, filter influxdb.DBRPMappingFil
 func (m dbrpMapper) Create(ctx context.Context, dbrpMap *influxdb.DBRPMapping) error {
 	return errors.New("dbrpMapper does not support creating new mappings")
 }
-func (m dbrpMapper) Delete(ctx context.Context, cluster string, db string, rp string) error {
+func (m dbrpMapper) Delete(ctx context.Context, orgID influxdb.ID, cluster string, db string, rp string) error {
 	return errors.New("dbrpMapper does not support deleteing mappings")
 }
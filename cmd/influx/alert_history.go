@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+type alertHistorySVCFn func() (influxdb.AlertHistoryService, error)
+
+// alertHistoryFlags are the filters shared by the check and notification rule
+// history commands.
+type alertHistoryFlags struct {
+	id     string
+	start  string
+	stop   string
+	levels []string
+	tags   []string
+	limit  int
+	offset int
+}
+
+func (f *alertHistoryFlags) register(cmd *cobra.Command, resource string) {
+	cmd.Flags().StringVarP(&f.id, "id", "i", "", fmt.Sprintf("The %s ID (required)", resource))
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVar(&f.start, "start", "", "Earliest time to include as an RFC3339 time or a duration before now, such as 1h; defaults to 24h before stop")
+	cmd.Flags().StringVar(&f.stop, "stop", "", "Latest time to include as an RFC3339 time or a duration before now; defaults to now")
+	cmd.Flags().StringSliceVar(&f.levels, "level", nil, "Only include these levels: crit, warn, info, ok or unknown")
+	cmd.Flags().StringArrayVar(&f.tags, "tag", nil, "Only include results with this tag, as key=value")
+	cmd.Flags().IntVar(&f.limit, "limit", influxdb.DefaultPageSize, fmt.Sprintf("Maximum number of results, at most %d", influxdb.MaxPageSize))
+	cmd.Flags().IntVar(&f.offset, "offset", 0, "Number of most recent results to skip")
+}
+
+// filter returns the filter and paging options of the flags at now.
+func (f *alertHistoryFlags) filter(resource string, now time.Time) (influxdb.ID, influxdb.AlertHistoryFilter, influxdb.FindOptions, error) {
+	var (
+		filter influxdb.AlertHistoryFilter
+		opts   = influxdb.FindOptions{Limit: f.limit, Offset: f.offset}
+		id     influxdb.ID
+		err    error
+	)
+	if err := id.DecodeFromString(f.id); err != nil {
+		return id, filter, opts, fmt.Errorf("failed to decode %s id %q: %v", resource, f.id, err)
+	}
+	if f.start != "" {
+		if filter.Start, err = parseHistoryTime("start", now, f.start); err != nil {
+			return id, filter, opts, err
+		}
+	}
+	if f.stop != "" {
+		if filter.Stop, err = parseHistoryTime("stop", now, f.stop); err != nil {
+			return id, filter, opts, err
+		}
+	}
+	filter.Levels = f.levels
+	for _, t := range f.tags {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return id, filter, opts, fmt.Errorf("invalid tag %q, expected key=value", t)
+		}
+		filter.Tags = append(filter.Tags, influxdb.Tag{Key: kv[0], Value: kv[1]})
+	}
+	return id, filter, opts, nil
+}
+
+// parseHistoryTime parses a time, either as an RFC3339 time or as a duration
+// before now.
+func parseHistoryTime(flag string, now time.Time, v string) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s time %q, expected an RFC3339 time or a duration: %v", flag, v, err)
+	}
+	return t, nil
+}
+
+// formatHistoryTags formats tags as sorted key=value pairs.
+func formatHistoryTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func newAlertHistorySVC() (influxdb.AlertHistoryService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &http.AlertHistoryService{Client: httpClient}, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdAlertHistory(t *testing.T) {
+	now := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	id := influxdb.ID(3)
+
+	execute := func(t *testing.T, svc influxdb.AlertHistoryService, args ...string) error {
+		svcFn := func() (influxdb.AlertHistoryService, error) { return svc, nil }
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(
+			func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+				b := newCmdCheckBuilder(svcFn, nil, opt)
+				b.globalFlags = f
+				b.now = func() time.Time { return now }
+				return b.cmd()
+			},
+			func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+				b := newCmdRuleBuilder(svcFn, opt)
+				b.globalFlags = f
+				b.now = func() time.Time { return now }
+				return b.cmd()
+			},
+		)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	t.Run("check history", func(t *testing.T) {
+		svc := mock.NewAlertHistoryService()
+		var filter influxdb.AlertHistoryFilter
+		var opts influxdb.FindOptions
+		svc.FindCheckStatusesFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+			filter, opts = f, opt[0]
+			return []*influxdb.CheckStatus{{Time: now, CheckID: id, Level: "crit"}}, 1, nil
+		}
+
+		require.NoError(t, execute(t, svc,
+			"check", "history",
+			"--id="+id.String(),
+			"--start=2h",
+			"--stop=2020-03-02T21:00:00Z",
+			"--level=crit,warn",
+			"--tag=host=web-1",
+			"--limit=5",
+			"--offset=10",
+		))
+		require.NotNil(t, filter.CheckID)
+		assert.Equal(t, id, *filter.CheckID)
+		assert.Equal(t, now.Add(-2*time.Hour), filter.Start)
+		assert.Equal(t, time.Date(2020, time.March, 2, 21, 0, 0, 0, time.UTC), filter.Stop)
+		assert.Equal(t, []string{"crit", "warn"}, filter.Levels)
+		assert.Equal(t, []influxdb.Tag{{Key: "host", Value: "web-1"}}, filter.Tags)
+		assert.Equal(t, influxdb.FindOptions{Limit: 5, Offset: 10}, opts)
+	})
+
+	t.Run("rule history", func(t *testing.T) {
+		svc := mock.NewAlertHistoryService()
+		var filter influxdb.AlertHistoryFilter
+		svc.FindNotificationEventsFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+			filter = f
+			return []*influxdb.NotificationEvent{{Time: now, RuleID: id, Message: "a\nb"}}, 1, nil
+		}
+
+		require.NoError(t, execute(t, svc, "rule", "history", "--id="+id.String()))
+		require.NotNil(t, filter.RuleID)
+		assert.Equal(t, id, *filter.RuleID)
+		assert.Nil(t, filter.CheckID)
+		assert.True(t, filter.Start.IsZero())
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		svc := mock.NewAlertHistoryService()
+		require.Error(t, execute(t, svc, "check", "history", "--id="+id.String(), "--tag=host"))
+		assert.Equal(t, 0, svc.FindCheckStatusesCalls.Count())
+	})
+}
@@ -2,9 +2,16 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/bolt"
@@ -21,8 +28,12 @@ func cmdBackup(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 		`Backs up data and meta data for the running InfluxDB instance.
 Downloaded files are written to the directory indicated by --path.
 The target directory, and any parent directories, are created automatically.
-Data file have extension .tsm; meta data is written to %s in the same directory.`,
-		bolt.DefaultFilename)
+Data file have extension .tsm; meta data is written to %s in the same directory.
+A manifest listing every file of the backup and its checksum is written to %s.
+
+With --incremental-from, only the data files that are not part of the backup
+in the given directory are downloaded; the manifest refers to the others.`,
+		bolt.DefaultFilename, influxdb.BackupManifestFilename)
 
 	opts := flagOpts{
 		{
@@ -36,11 +47,14 @@ Data file have extension .tsm; meta data is written to %s in the same directory.
 	}
 	opts.mustRegister(cmd)
 
+	cmd.Flags().StringVar(&backupFlags.IncrementalFrom, "incremental-from", "", "directory path of a previous backup to base an incremental backup on")
+
 	return cmd
 }
 
 var backupFlags struct {
-	Path string
+	Path            string
+	IncrementalFrom string
 }
 
 func init() {
@@ -55,8 +69,9 @@ func init() {
 
 func newBackupService() (influxdb.BackupService, error) {
 	return &http.BackupService{
-		Addr:  flags.Host,
-		Token: flags.Token,
+		Addr:               flags.Host,
+		Token:              flags.Token,
+		InsecureSkipVerify: flags.skipVerify,
 	}, nil
 }
 
@@ -71,6 +86,15 @@ func backupF(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("must specify path")
 	}
 
+	var base *influxdb.BackupManifest
+	if backupFlags.IncrementalFrom != "" {
+		m, err := readBackupManifest(backupFlags.IncrementalFrom)
+		if err != nil {
+			return fmt.Errorf("failed to read base backup: %v", err)
+		}
+		base = m
+	}
+
 	err := os.MkdirAll(backupFlags.Path, 0777)
 	if err != nil && !os.IsExist(err) {
 		return err
@@ -81,29 +105,153 @@ func backupF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	id, backupFilenames, err := backupService.CreateBackup(ctx)
-	if err != nil {
-		return err
+	manifest := &influxdb.BackupManifest{
+		Version:   influxdb.BackupManifestVersion,
+		CreatedAt: time.Now().UTC(),
 	}
 
-	fmt.Printf("Backup ID %d contains %d files\n", id, len(backupFilenames))
-
-	for _, backupFilename := range backupFilenames {
-		dest := filepath.Join(backupFlags.Path, backupFilename)
-		w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	var (
+		id    int
+		files []influxdb.BackupFile
+	)
+	if base == nil {
+		var filenames []string
+		id, filenames, err = backupService.CreateBackup(ctx)
 		if err != nil {
 			return err
 		}
-		err = backupService.FetchBackupFile(ctx, id, backupFilename, w)
+		for _, name := range filenames {
+			files = append(files, influxdb.BackupFile{Name: name})
+		}
+	} else {
+		id, files, err = backupService.CreateIncrementalBackup(ctx, base.StorageFiles())
 		if err != nil {
-			return multierr.Append(fmt.Errorf("error fetching file %s: %v", backupFilename, err), w.Close())
+			return err
+		}
+		if manifest.Base, err = filepath.Rel(backupFlags.Path, backupFlags.IncrementalFrom); err != nil {
+			return err
+		}
+	}
+
+	baseFiles := make(map[influxdb.BackupFile]influxdb.BackupManifestFile)
+	if base != nil {
+		for _, f := range base.Files {
+			baseFiles[f.BackupFile] = f
 		}
-		if err = w.Close(); err != nil {
+	}
+
+	var fetched int
+	for _, file := range files {
+		typ := backupFileType(file.Name)
+
+		if bf, ok := baseFiles[file]; ok && typ != influxdb.BackupFileTypeKV && typ != influxdb.BackupFileTypeCredentials {
+			// The file is part of the base backup; refer to it instead of downloading it again.
+			path, err := filepath.Rel(backupFlags.Path, filepath.Join(backupFlags.IncrementalFrom, bf.Path))
+			if err != nil {
+				return err
+			}
+			bf.Path = path
+			manifest.Files = append(manifest.Files, bf)
+			continue
+		}
+
+		mf, err := fetchBackupFile(ctx, backupService, id, file.Name, typ)
+		if err != nil {
 			return err
 		}
+		manifest.Files = append(manifest.Files, mf)
+		fetched++
+	}
+
+	if err := writeBackupManifest(backupFlags.Path, manifest); err != nil {
+		return err
 	}
 
+	fmt.Printf("Backup ID %d contains %d files, %d downloaded\n", id, len(manifest.Files), fetched)
+
 	fmt.Printf("Backup complete")
 
 	return nil
 }
+
+// fetchBackupFile downloads a backup file to the backup directory and
+// returns its manifest entry.
+func fetchBackupFile(ctx context.Context, backupService influxdb.BackupService, id int, name string, typ influxdb.BackupFileType) (influxdb.BackupManifestFile, error) {
+	// The files of time partitions keep their directories in the backup.
+	dest := filepath.Join(backupFlags.Path, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dest), 0777); err != nil {
+		return influxdb.BackupManifestFile{}, err
+	}
+	w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return influxdb.BackupManifestFile{}, err
+	}
+
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(w, h)}
+	err = backupService.FetchBackupFile(ctx, id, name, cw)
+	if err != nil {
+		return influxdb.BackupManifestFile{}, multierr.Append(fmt.Errorf("error fetching file %s: %v", name, err), w.Close())
+	}
+	if err = w.Close(); err != nil {
+		return influxdb.BackupManifestFile{}, err
+	}
+
+	return influxdb.BackupManifestFile{
+		BackupFile: influxdb.BackupFile{Name: name, Size: cw.n},
+		Type:       typ,
+		Path:       name,
+		Checksum:   hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func backupFileType(name string) influxdb.BackupFileType {
+	switch {
+	case name == bolt.DefaultFilename:
+		return influxdb.BackupFileTypeKV
+	case name == http.DefaultConfigsFile:
+		return influxdb.BackupFileTypeCredentials
+	case strings.HasSuffix(name, ".tombstone"):
+		return influxdb.BackupFileTypeTombstone
+	case strings.HasSuffix(name, ".wal"):
+		return influxdb.BackupFileTypeWAL
+	default:
+		return influxdb.BackupFileTypeTSM
+	}
+}
+
+func readBackupManifest(dir string) (*influxdb.BackupManifest, error) {
+	f, err := os.Open(filepath.Join(dir, influxdb.BackupManifestFilename))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var m influxdb.BackupManifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, err
+	}
+	if m.Version != influxdb.BackupManifestVersion {
+		return nil, fmt.Errorf("unsupported backup manifest version %d", m.Version)
+	}
+	return &m, nil
+}
+
+func writeBackupManifest(dir string, m *influxdb.BackupManifest) error {
+	b, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, influxdb.BackupManifestFilename), b, 0666)
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/influxdata/influxdb"
@@ -22,7 +24,8 @@ type cmdBucketBuilder struct {
 	genericCLIOpts
 	*globalFlags
 
-	svcFn bucketSVCsFn
+	svcFn            bucketSVCsFn
+	cardinalitySVCFn func() (influxdb.CardinalityService, error)
 
 	id          string
 	headers     bool
@@ -30,12 +33,15 @@ type cmdBucketBuilder struct {
 	description string
 	org         organization
 	retention   time.Duration
+	schemaFile  string
+	limit       int
 }
 
 func newCmdBucketBuilder(svcsFn bucketSVCsFn, opts genericCLIOpts) *cmdBucketBuilder {
 	return &cmdBucketBuilder{
-		genericCLIOpts: opts,
-		svcFn:          svcsFn,
+		genericCLIOpts:   opts,
+		svcFn:            svcsFn,
+		cardinalitySVCFn: newCardinalitySVC,
 	}
 }
 
@@ -45,6 +51,7 @@ func (b *cmdBucketBuilder) cmd() *cobra.Command {
 	cmd.TraverseChildren = true
 	cmd.Run = seeHelp
 	cmd.AddCommand(
+		b.cmdCardinality(),
 		b.cmdCreate(),
 		b.cmdDelete(),
 		b.cmdFind(),
@@ -72,6 +79,7 @@ func (b *cmdBucketBuilder) cmdCreate() *cobra.Command {
 
 	cmd.Flags().StringVarP(&b.description, "description", "d", "", "Description of bucket that will be created")
 	cmd.Flags().DurationVarP(&b.retention, "retention", "r", 0, "Duration bucket will retain data. 0 is infinite. Default is 0.")
+	cmd.Flags().StringVar(&b.schemaFile, "schema", "", "Path to a JSON file with the explicit schema of the bucket")
 	b.org.register(cmd, false)
 
 	return cmd
@@ -96,6 +104,11 @@ func (b *cmdBucketBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
 	if err != nil {
 		return err
 	}
+	if b.schemaFile != "" {
+		if bkt.Schema, err = readBucketSchema(b.schemaFile); err != nil {
+			return err
+		}
+	}
 
 	if err := bktSVC.CreateBucket(context.Background(), bkt); err != nil {
 		return fmt.Errorf("failed to create bucket: %v", err)
@@ -114,6 +127,119 @@ func (b *cmdBucketBuilder) cmdCreateRunEFn(*cobra.Command, []string) error {
 	return nil
 }
 
+func (b *cmdBucketBuilder) cmdCardinality() *cobra.Command {
+	cmd := b.newCmd("cardinality", b.cmdCardinalityRunEFn)
+	cmd.Short = "Show the series cardinality of a bucket"
+	cmd.Long = `Show the number of series of a bucket by measurement and by tag key,
+with the tag values having the most series, as currently indexed by the server.`
+
+	opts := flagOpts{
+		{
+			DestP:  &b.name,
+			Flag:   "name",
+			Short:  'n',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The bucket name, requires the org",
+		},
+	}
+	opts.mustRegister(cmd)
+
+	b.org.register(cmd, false)
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The bucket ID")
+	cmd.Flags().IntVar(&b.limit, "limit", influxdb.DefaultCardinalityTopN, "The number of tag values with the most series to show for each tag key")
+	cmd.Flags().BoolVar(&b.headers, "headers", true, "To print the table headers; defaults true")
+
+	return cmd
+}
+
+func (b *cmdBucketBuilder) cmdCardinalityRunEFn(cmd *cobra.Command, args []string) error {
+	if b.id == "" && b.name == "" {
+		return fmt.Errorf("please specify one of id or name")
+	}
+
+	bktSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+	cardSVC, err := b.cardinalitySVCFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var bkt *influxdb.Bucket
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode bucket id %q: %v", b.id, err)
+		}
+		if bkt, err = bktSVC.FindBucketByID(ctx, *id); err != nil {
+			return fmt.Errorf("failed to find bucket with id %q: %v", b.id, err)
+		}
+	} else {
+		if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+			return err
+		}
+		orgID, err := b.org.getID(orgSVC)
+		if err != nil {
+			return err
+		}
+		if bkt, err = bktSVC.FindBucketByName(ctx, orgID, b.name); err != nil {
+			return fmt.Errorf("failed to find bucket %q: %v", b.name, err)
+		}
+	}
+
+	card, err := cardSVC.BucketCardinality(ctx, bkt.OrgID, bkt.ID, b.limit)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the cardinality of bucket %q: %v", bkt.Name, err)
+	}
+
+	w := b.newTabWriter()
+	w.HideHeaders(!b.headers)
+	w.WriteHeaders("Measurement", "Series")
+	for _, m := range card.Measurements {
+		w.Write(map[string]interface{}{
+			"Measurement": m.Name,
+			"Series":      m.Series,
+		})
+	}
+	w.Write(map[string]interface{}{
+		"Measurement": "(total)",
+		"Series":      card.Series,
+	})
+	w.Flush()
+	fmt.Fprintln(b.w)
+
+	w = b.newTabWriter()
+	w.HideHeaders(!b.headers)
+	w.WriteHeaders("TagKey", "Values", "Series")
+	for _, k := range card.TagKeys {
+		w.Write(map[string]interface{}{
+			"TagKey": k.Key,
+			"Values": k.Values,
+			"Series": k.Series,
+		})
+	}
+	w.Flush()
+	fmt.Fprintln(b.w)
+
+	w = b.newTabWriter()
+	w.HideHeaders(!b.headers)
+	w.WriteHeaders("TagKey", "TagValue", "Series")
+	for _, k := range card.TagKeys {
+		for _, v := range k.TopValues {
+			w.Write(map[string]interface{}{
+				"TagKey":   k.Key,
+				"TagValue": v.Value,
+				"Series":   v.Series,
+			})
+		}
+	}
+	w.Flush()
+
+	return nil
+}
+
 func (b *cmdBucketBuilder) cmdDelete() *cobra.Command {
 	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
 	cmd.Short = "Delete bucket"
@@ -254,6 +380,7 @@ func (b *cmdBucketBuilder) cmdUpdate() *cobra.Command {
 	cmd.Flags().StringVarP(&b.description, "description", "d", "", "Description of bucket that will be created")
 	cmd.MarkFlagRequired("id")
 	cmd.Flags().DurationVarP(&b.retention, "retention", "r", 0, "Duration bucket will retain data. 0 is infinite. Default is 0.")
+	cmd.Flags().StringVar(&b.schemaFile, "schema", "", "Path to a JSON file with the new explicit schema of the bucket; a schema without measurements removes it")
 
 	return cmd
 }
@@ -279,6 +406,11 @@ func (b *cmdBucketBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) er
 	if b.retention != 0 {
 		update.RetentionPeriod = &b.retention
 	}
+	if b.schemaFile != "" {
+		if update.Schema, err = readBucketSchema(b.schemaFile); err != nil {
+			return err
+		}
+	}
 
 	bkt, err := bktSVC.UpdateBucket(context.Background(), id, update)
 	if err != nil {
@@ -298,6 +430,20 @@ func (b *cmdBucketBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) er
 	return nil
 }
 
+// readBucketSchema reads a bucket schema from a JSON file.
+func readBucketSchema(path string) (*influxdb.BucketSchema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bucket schema: %v", err)
+	}
+
+	var schema influxdb.BucketSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode bucket schema %q: %v", path, err)
+	}
+	return &schema, nil
+}
+
 func newBucketSVCs() (influxdb.BucketService, influxdb.OrganizationService, error) {
 	httpClient, err := newHTTPClient()
 	if err != nil {
@@ -308,3 +454,11 @@ func newBucketSVCs() (influxdb.BucketService, influxdb.OrganizationService, erro
 
 	return &http.BucketService{Client: httpClient}, orgSvc, nil
 }
+
+func newCardinalitySVC() (influxdb.CardinalityService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &http.CardinalityService{Client: httpClient}, nil
+}
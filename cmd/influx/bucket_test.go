@@ -31,6 +31,13 @@ func TestCmdBucket(t *testing.T) {
 	}
 
 	t.Run("create", func(t *testing.T) {
+		schemaFile, err := ioutil.TempFile("", "bucket_schema")
+		require.NoError(t, err)
+		defer os.Remove(schemaFile.Name())
+		_, err = schemaFile.WriteString(`{"measurements": [{"name": "cpu", "tags": ["host"], "fields": [{"name": "usage", "type": "float"}]}]}`)
+		require.NoError(t, err)
+		require.NoError(t, schemaFile.Close())
+
 		tests := []struct {
 			name           string
 			expectedBucket influxdb.Bucket
@@ -90,12 +97,31 @@ func TestCmdBucket(t *testing.T) {
 					OrgID:           orgID,
 				},
 			},
+			{
+				name: "with schema",
+				flags: []string{
+					"--name=new name",
+					"--org=org name",
+					"--schema=" + schemaFile.Name(),
+				},
+				expectedBucket: influxdb.Bucket{
+					Name:  "new name",
+					OrgID: orgID,
+					Schema: &influxdb.BucketSchema{
+						Measurements: []influxdb.MeasurementSchema{{
+							Name:   "cpu",
+							Tags:   []string{"host"},
+							Fields: []influxdb.FieldSchema{{Name: "usage", Type: influxdb.SchemaFieldTypeFloat}},
+						}},
+					},
+				},
+			},
 		}
 
 		cmdFn := func(expectedBkt influxdb.Bucket) func(*globalFlags, genericCLIOpts) *cobra.Command {
 			svc := mock.NewBucketService()
 			svc.CreateBucketFn = func(ctx context.Context, bucket *influxdb.Bucket) error {
-				if expectedBkt != *bucket {
+				if !reflect.DeepEqual(expectedBkt, *bucket) {
 					return fmt.Errorf("unexpected bucket;\n\twant= %+v\n\tgot=  %+v", expectedBkt, *bucket)
 				}
 				return nil
@@ -414,6 +440,82 @@ func TestCmdBucket(t *testing.T) {
 			t.Run(tt.name, fn)
 		}
 	})
+
+	t.Run("cardinality", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			flags []string
+		}{
+			{
+				name:  "id",
+				flags: []string{"--id=" + influxdb.ID(2).String(), "--limit=5"},
+			},
+			{
+				name:  "name",
+				flags: []string{"--name=telegraf", "--org-id=" + orgID.String(), "--limit=5"},
+			},
+		}
+
+		cmdFn := func() func(*globalFlags, genericCLIOpts) *cobra.Command {
+			svc := mock.NewBucketService()
+			svc.FindBucketByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+				return &influxdb.Bucket{ID: id, OrgID: orgID, Name: "telegraf"}, nil
+			}
+			svc.FindBucketByNameFn = func(ctx context.Context, id influxdb.ID, name string) (*influxdb.Bucket, error) {
+				if id != orgID || name != "telegraf" {
+					return nil, fmt.Errorf("unexpected bucket %q of org %s", name, id)
+				}
+				return &influxdb.Bucket{ID: 2, OrgID: orgID, Name: name}, nil
+			}
+
+			cardSVC := &mock.CardinalityService{
+				BucketCardinalityFn: func(ctx context.Context, oID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+					if oID != orgID || bucketID != 2 || topN != 5 {
+						return nil, fmt.Errorf("unexpected cardinality request for org %s, bucket %s, top %d", oID, bucketID, topN)
+					}
+					return &influxdb.BucketCardinality{
+						Series:       4,
+						Measurements: []influxdb.MeasurementCardinality{{Name: "cpu", Series: 4}},
+						TagKeys: []influxdb.TagKeyCardinality{{
+							Key:       "container_id",
+							Values:    4,
+							Series:    4,
+							TopValues: []influxdb.TagValueCardinality{{Value: "a1b2c3", Series: 1}},
+						}},
+					}, nil
+				},
+			}
+
+			return func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+				builder := newCmdBucketBuilder(fakeSVCFn(svc), opt)
+				builder.cardinalitySVCFn = func() (influxdb.CardinalityService, error) {
+					return cardSVC, nil
+				}
+				return builder.cmd()
+			}
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				defer addEnvVars(t, envVarsZeroMap)()
+
+				var buf bytes.Buffer
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(&buf),
+				)
+
+				cmd := builder.cmd(cmdFn())
+				cmd.SetArgs(append([]string{"bucket", "cardinality"}, tt.flags...))
+				require.NoError(t, cmd.Execute())
+
+				assert.Contains(t, buf.String(), "container_id")
+				assert.Contains(t, buf.String(), "a1b2c3")
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
 }
 
 func strPtr(s string) *string {
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/spf13/cobra"
+)
+
+func cmdCheck(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdCheckBuilder(newAlertHistorySVC, newCheckDryRunSVC, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdCheckBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn       alertHistorySVCFn
+	dryRunSvcFn checkDryRunSVCFn
+	now         func() time.Time
+
+	history alertHistoryFlags
+	dryRun  struct {
+		id    string
+		file  string
+		start string
+		stop  string
+	}
+}
+
+type checkDryRunSVCFn func() (influxdb.CheckDryRunService, error)
+
+func newCmdCheckBuilder(svcFn alertHistorySVCFn, dryRunSvcFn checkDryRunSVCFn, opt genericCLIOpts) *cmdCheckBuilder {
+	return &cmdCheckBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcFn,
+		dryRunSvcFn:    dryRunSvcFn,
+		now:            time.Now,
+	}
+}
+
+func (b *cmdCheckBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("check", nil)
+	cmd.Short = "Check management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdDryRun(),
+		b.cmdHistory(),
+	)
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdHistory() *cobra.Command {
+	cmd := b.newCmd("history", b.cmdHistoryRunEFn)
+	cmd.Short = "List the statuses written by a check, the most recent first"
+	b.history.register(cmd, "check")
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdHistoryRunEFn(cmd *cobra.Command, args []string) error {
+	id, filter, opts, err := b.history.filter("check", b.now())
+	if err != nil {
+		return err
+	}
+	filter.CheckID = &id
+
+	svc, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	statuses, _, err := svc.FindCheckStatuses(context.Background(), filter, opts)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve statuses of check %q: %v", id, err)
+	}
+
+	b.writeStatuses(statuses)
+	return nil
+}
+
+func (b *cmdCheckBuilder) cmdDryRun() *cobra.Command {
+	cmd := b.newCmd("dry-run", b.cmdDryRunRunEFn)
+	cmd.Short = "Run a check over past data and list the statuses it would have written, without writing them"
+	cmd.Long = `Run a check over past data and list the statuses it would have written.
+
+The check is either an existing check, given by its ID, or a check definition
+in a JSON file, which does not need to be created first. Nothing is written
+to the monitoring bucket.`
+	cmd.Flags().StringVarP(&b.dryRun.id, "id", "i", "", "The check ID")
+	cmd.Flags().StringVarP(&b.dryRun.file, "file", "f", "", "Path to a JSON check definition")
+	cmd.Flags().StringVar(&b.dryRun.start, "start", "", "The earliest time to run the check at, as an RFC3339 time or a duration before now, defaults to 24 hours before stop")
+	cmd.Flags().StringVar(&b.dryRun.stop, "stop", "", "The latest time to run the check at, as an RFC3339 time or a duration before now, defaults to now")
+	return cmd
+}
+
+func (b *cmdCheckBuilder) cmdDryRunRunEFn(cmd *cobra.Command, args []string) error {
+	if (b.dryRun.id == "") == (b.dryRun.file == "") {
+		return fmt.Errorf("must provide exactly one of --id or --file")
+	}
+
+	now := b.now()
+	var start, stop time.Time
+	var err error
+	if b.dryRun.start != "" {
+		if start, err = parseHistoryTime("start", now, b.dryRun.start); err != nil {
+			return err
+		}
+	}
+	if b.dryRun.stop != "" {
+		if stop, err = parseHistoryTime("stop", now, b.dryRun.stop); err != nil {
+			return err
+		}
+	}
+
+	svc, err := b.dryRunSvcFn()
+	if err != nil {
+		return err
+	}
+
+	var statuses []*influxdb.CheckStatus
+	if b.dryRun.id != "" {
+		var id influxdb.ID
+		if err := id.DecodeFromString(b.dryRun.id); err != nil {
+			return err
+		}
+		statuses, err = svc.DryRunCheckByID(context.Background(), id, start, stop)
+		if err != nil {
+			return fmt.Errorf("failed to dry run check %q: %v", id, err)
+		}
+	} else {
+		chk, err := readCheckFile(b.dryRun.file)
+		if err != nil {
+			return err
+		}
+		statuses, err = svc.DryRunCheck(context.Background(), chk, start, stop)
+		if err != nil {
+			return fmt.Errorf("failed to dry run check %q: %v", chk.GetName(), err)
+		}
+	}
+
+	b.writeStatuses(statuses)
+	return nil
+}
+
+func (b *cmdCheckBuilder) writeStatuses(statuses []*influxdb.CheckStatus) {
+	w := b.newTabWriter()
+	w.WriteHeaders("Time", "Level", "Check", "Message", "Tags")
+	for _, s := range statuses {
+		w.Write(map[string]interface{}{
+			"Time":    s.Time.Format(time.RFC3339),
+			"Level":   s.Level,
+			"Check":   s.CheckName,
+			"Message": s.Message,
+			"Tags":    formatHistoryTags(s.Tags),
+		})
+	}
+	w.Flush()
+}
+
+func readCheckFile(file string) (influxdb.Check, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read check definition %q: %v", file, err)
+	}
+	chk, err := check.UnmarshalJSON(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid check definition %q: %v", file, err)
+	}
+	return chk, nil
+}
+
+func newCheckDryRunSVC() (influxdb.CheckDryRunService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &http.CheckDryRunService{Client: httpClient}, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdCheckDryRun(t *testing.T) {
+	now := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	id := influxdb.ID(3)
+
+	execute := func(t *testing.T, svc influxdb.CheckDryRunService, args ...string) (string, error) {
+		dryRunSvcFn := func() (influxdb.CheckDryRunService, error) { return svc, nil }
+		outBuf := new(bytes.Buffer)
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(outBuf),
+		)
+		cmd := builder.cmd(func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+			b := newCmdCheckBuilder(nil, dryRunSvcFn, opt)
+			b.globalFlags = f
+			b.now = func() time.Time { return now }
+			return b.cmd()
+		})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return outBuf.String(), err
+	}
+
+	t.Run("existing check", func(t *testing.T) {
+		svc := mock.NewCheckDryRunService()
+		var gotID influxdb.ID
+		var start, stop time.Time
+		svc.DryRunCheckByIDFn = func(ctx context.Context, id influxdb.ID, b, e time.Time) ([]*influxdb.CheckStatus, error) {
+			gotID, start, stop = id, b, e
+			return []*influxdb.CheckStatus{{Time: now, CheckName: "cpu", Level: "crit", Tags: map[string]string{"host": "web-1"}}}, nil
+		}
+
+		out, err := execute(t, svc, "check", "dry-run", "--id="+id.String(), "--start=6h", "--stop=2020-03-02T21:00:00Z")
+		require.NoError(t, err)
+		assert.Equal(t, id, gotID)
+		assert.Equal(t, now.Add(-6*time.Hour), start)
+		assert.Equal(t, time.Date(2020, time.March, 2, 21, 0, 0, 0, time.UTC), stop)
+		assert.Contains(t, out, "host=web-1")
+	})
+
+	t.Run("check definition", func(t *testing.T) {
+		dir := newTempDir(t)
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "check.json")
+		require.NoError(t, ioutil.WriteFile(file, []byte(`{
+			"type": "deadman",
+			"name": "cpu",
+			"orgID": "0000000000000002",
+			"every": "1m",
+			"query": {"text": "from(bucket: \"telegraf\") |> range(start: -1m)"},
+			"level": "CRIT"
+		}`), 0600))
+
+		svc := mock.NewCheckDryRunService()
+		var name string
+		svc.DryRunCheckFn = func(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+			name = chk.GetName()
+			assert.True(t, start.IsZero())
+			return nil, nil
+		}
+
+		_, err := execute(t, svc, "check", "dry-run", "--file="+file)
+		require.NoError(t, err)
+		assert.Equal(t, "cpu", name)
+	})
+
+	t.Run("requires id or file", func(t *testing.T) {
+		svc := mock.NewCheckDryRunService()
+		_, err := execute(t, svc, "check", "dry-run")
+		require.Error(t, err)
+		_, err = execute(t, svc, "check", "dry-run", "--id="+id.String(), "--file=check.json")
+		require.Error(t, err)
+		assert.Equal(t, 0, svc.DryRunCheckByIDCalls.Count())
+	})
+}
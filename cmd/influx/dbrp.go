@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// defaultDBRPCluster is the cluster of the mappings created by the cli, the
+// influxdb 1.x api looks mappings up by database and retention policy only.
+const defaultDBRPCluster = "default"
+
+type dbrpSVCsFn func() (influxdb.DBRPMappingService, influxdb.BucketService, influxdb.OrganizationService, error)
+
+func cmdDBRP(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdDBRPBuilder(newDBRPSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdDBRPBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn dbrpSVCsFn
+
+	org       organization
+	cluster   string
+	db        string
+	rp        string
+	bucketID  string
+	isDefault bool
+}
+
+func newCmdDBRPBuilder(svcsFn dbrpSVCsFn, opt genericCLIOpts) *cmdDBRPBuilder {
+	return &cmdDBRPBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdDBRPBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("dbrp", nil)
+	cmd.Short = "Mappings of InfluxDB 1.x databases and retention policies to buckets"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdFind(),
+	)
+	return cmd
+}
+
+func (b *cmdDBRPBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Map a database and retention policy to a bucket"
+
+	cmd.Flags().StringVar(&b.cluster, "cluster", defaultDBRPCluster, "Cluster of the mapping")
+	cmd.Flags().StringVar(&b.db, "db", "", "InfluxDB 1.x database (required)")
+	cmd.MarkFlagRequired("db")
+	cmd.Flags().StringVar(&b.rp, "rp", "", "InfluxDB 1.x retention policy (required)")
+	cmd.MarkFlagRequired("rp")
+	cmd.Flags().StringVar(&b.bucketID, "bucket-id", "", "ID of the bucket the database and retention policy map to (required)")
+	cmd.MarkFlagRequired("bucket-id")
+	cmd.Flags().BoolVar(&b.isDefault, "default", false, "Use the mapping when no retention policy is given for the database")
+
+	return cmd
+}
+
+func (b *cmdDBRPBuilder) cmdCreateRunEFn(cmd *cobra.Command, args []string) error {
+	dbrpSVC, bucketSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var bucketID influxdb.ID
+	if err := bucketID.DecodeFromString(b.bucketID); err != nil {
+		return fmt.Errorf("failed to decode bucket id %q: %v", b.bucketID, err)
+	}
+
+	ctx := context.Background()
+	bkt, err := bucketSVC.FindBucketByID(ctx, bucketID)
+	if err != nil {
+		return fmt.Errorf("failed to find bucket with id %q: %v", bucketID, err)
+	}
+
+	m := &influxdb.DBRPMapping{
+		Cluster:         b.cluster,
+		Database:        b.db,
+		RetentionPolicy: b.rp,
+		Default:         b.isDefault,
+		OrganizationID:  bkt.OrgID,
+		BucketID:        bkt.ID,
+	}
+	if err := dbrpSVC.Create(ctx, m); err != nil {
+		return fmt.Errorf("failed to create dbrp mapping: %v", err)
+	}
+
+	return b.printDBRPs(m)
+}
+
+func (b *cmdDBRPBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete the mapping of a database and retention policy"
+
+	b.org.register(cmd, false)
+	cmd.Flags().StringVar(&b.cluster, "cluster", defaultDBRPCluster, "Cluster of the mapping")
+	cmd.Flags().StringVar(&b.db, "db", "", "InfluxDB 1.x database (required)")
+	cmd.MarkFlagRequired("db")
+	cmd.Flags().StringVar(&b.rp, "rp", "", "InfluxDB 1.x retention policy (required)")
+	cmd.MarkFlagRequired("rp")
+
+	return cmd
+}
+
+func (b *cmdDBRPBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+
+	dbrpSVC, _, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	m, err := dbrpSVC.FindBy(ctx, orgID, b.cluster, b.db, b.rp)
+	if err != nil {
+		return fmt.Errorf("failed to find dbrp mapping of %s/%s: %v", b.db, b.rp, err)
+	}
+	if err := dbrpSVC.Delete(ctx, orgID, b.cluster, b.db, b.rp); err != nil {
+		return fmt.Errorf("failed to delete dbrp mapping of %s/%s: %v", b.db, b.rp, err)
+	}
+
+	return b.printDBRPs(m)
+}
+
+func (b *cmdDBRPBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn)
+	cmd.Short = "List the mappings of databases and retention policies"
+	cmd.Aliases = []string{"find", "ls"}
+
+	b.org.register(cmd, false)
+	cmd.Flags().StringVar(&b.db, "db", "", "Only list the mappings of the database")
+
+	return cmd
+}
+
+func (b *cmdDBRPBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+
+	dbrpSVC, _, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	filter := influxdb.DBRPMappingFilter{
+		OrganizationID: &orgID,
+	}
+	if b.db != "" {
+		filter.Database = &b.db
+	}
+
+	ms, _, err := dbrpSVC.FindMany(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve dbrp mappings: %v", err)
+	}
+
+	return b.printDBRPs(ms...)
+}
+
+func (b *cmdDBRPBuilder) printDBRPs(ms ...*influxdb.DBRPMapping) error {
+	w := b.newTabWriter()
+	w.WriteHeaders("Database", "RetentionPolicy", "Default", "BucketID", "OrganizationID", "Cluster")
+	for _, m := range ms {
+		w.Write(map[string]interface{}{
+			"Database":        m.Database,
+			"RetentionPolicy": m.RetentionPolicy,
+			"Default":         m.Default,
+			"BucketID":        m.BucketID.String(),
+			"OrganizationID":  m.OrganizationID.String(),
+			"Cluster":         m.Cluster,
+		})
+	}
+	w.Flush()
+
+	return nil
+}
+
+func newDBRPSVCs() (influxdb.DBRPMappingService, influxdb.BucketService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	orgSVC := &http.OrganizationService{Client: httpClient}
+	return &http.DBRPMappingService{Client: httpClient}, &http.BucketService{Client: httpClient}, orgSVC, nil
+}
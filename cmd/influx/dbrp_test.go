@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdDBRP(t *testing.T) {
+	fakeSVCFn := func(svc influxdb.DBRPMappingService) dbrpSVCsFn {
+		return func() (influxdb.DBRPMappingService, influxdb.BucketService, influxdb.OrganizationService, error) {
+			return svc, &mock.BucketService{
+				FindBucketByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+					return &influxdb.Bucket{ID: id, OrgID: 9000, Name: "telegraf"}, nil
+				},
+			}, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: 9000, Name: *filter.Name}, nil
+				},
+			}, nil
+		}
+	}
+
+	execute := func(t *testing.T, svc influxdb.DBRPMappingService, args ...string) error {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+			b := newCmdDBRPBuilder(fakeSVCFn(svc), opt)
+			b.globalFlags = f
+			return b.cmd()
+		})
+		cmd.SetArgs(append([]string{"dbrp"}, args...))
+		return cmd.Execute()
+	}
+
+	t.Run("create", func(t *testing.T) {
+		var created *influxdb.DBRPMapping
+		svc := mock.NewDBRPMappingService()
+		svc.CreateFn = func(ctx context.Context, m *influxdb.DBRPMapping) error {
+			created = m
+			return nil
+		}
+
+		err := execute(t, svc, "create", "--db=telegraf", "--rp=autogen", "--default", "--bucket-id="+influxdb.ID(1).String())
+		require.NoError(t, err)
+
+		assert.Equal(t, &influxdb.DBRPMapping{
+			Cluster:         defaultDBRPCluster,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  9000,
+			BucketID:        1,
+		}, created)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		var deleted []string
+		svc := mock.NewDBRPMappingService()
+		svc.FindByFn = func(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+			return &influxdb.DBRPMapping{Cluster: cluster, Database: db, RetentionPolicy: rp, OrganizationID: orgID, BucketID: 1}, nil
+		}
+		svc.DeleteFn = func(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) error {
+			deleted = []string{orgID.String(), cluster, db, rp}
+			return nil
+		}
+
+		err := execute(t, svc, "delete", "--org=myorg", "--db=telegraf", "--rp=autogen")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{influxdb.ID(9000).String(), defaultDBRPCluster, "telegraf", "autogen"}, deleted)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var filter influxdb.DBRPMappingFilter
+		svc := mock.NewDBRPMappingService()
+		svc.FindManyFn = func(ctx context.Context, f influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+			filter = f
+			return nil, 0, nil
+		}
+
+		err := execute(t, svc, "list", "--org-id="+influxdb.ID(9000).String(), "--db=telegraf")
+		require.NoError(t, err)
+
+		orgID, db := influxdb.ID(9000), "telegraf"
+		assert.Equal(t, influxdb.DBRPMappingFilter{OrganizationID: &orgID, Database: &db}, filter)
+	})
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+func cmdEndpoint(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdEndpointBuilder(newNotificationEndpointTestSVC, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type notificationEndpointTestSVCFn func() (influxdb.NotificationEndpointTestService, error)
+
+type cmdEndpointBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	testSvcFn notificationEndpointTestSVCFn
+
+	id string
+}
+
+func newCmdEndpointBuilder(testSvcFn notificationEndpointTestSVCFn, opt genericCLIOpts) *cmdEndpointBuilder {
+	return &cmdEndpointBuilder{
+		genericCLIOpts: opt,
+		testSvcFn:      testSvcFn,
+	}
+}
+
+func (b *cmdEndpointBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("endpoint", nil)
+	cmd.Short = "Notification endpoint management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdTest(),
+	)
+	return cmd
+}
+
+func (b *cmdEndpointBuilder) cmdTest() *cobra.Command {
+	cmd := b.newCmd("test", b.cmdTestRunEFn)
+	cmd.Short = "Send a test notification to a notification endpoint"
+	cmd.Long = `Send a test notification to a notification endpoint.
+
+The notification is sent by the server, with the secrets of the endpoint, to
+confirm its credentials and routing work. The status code the endpoint
+responded with is listed, along with its response body when it rejected the
+notification. The incident triggered on a pagerduty endpoint is resolved right
+away, and the mail of an smtp endpoint is sent to its from address.`
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The notification endpoint ID (required)")
+	cmd.MarkFlagRequired("id")
+	return cmd
+}
+
+func (b *cmdEndpointBuilder) cmdTestRunEFn(cmd *cobra.Command, args []string) error {
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return err
+	}
+
+	svc, err := b.testSvcFn()
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.TestNotificationEndpoint(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("failed to test notification endpoint %q: %v", id, err)
+	}
+
+	w := b.newTabWriter()
+	w.WriteHeaders("ID", "Sent", "Status Code", "Error")
+	w.Write(map[string]interface{}{
+		"ID":          id.String(),
+		"Sent":        result.Sent,
+		"Status Code": result.StatusCode,
+		"Error":       result.Error,
+	})
+	w.Flush()
+
+	if !result.Sent {
+		return fmt.Errorf("test notification to endpoint %q was not sent", id)
+	}
+	return nil
+}
+
+func newNotificationEndpointTestSVC() (influxdb.NotificationEndpointTestService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return http.NewNotificationEndpointService(httpClient), nil
+}
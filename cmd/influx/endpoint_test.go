@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdEndpointTest(t *testing.T) {
+	id := influxdb.ID(3)
+
+	execute := func(t *testing.T, svc influxdb.NotificationEndpointTestService, args ...string) (string, error) {
+		testSvcFn := func() (influxdb.NotificationEndpointTestService, error) { return svc, nil }
+		outBuf := new(bytes.Buffer)
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(outBuf),
+		)
+		cmd := builder.cmd(func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+			b := newCmdEndpointBuilder(testSvcFn, opt)
+			b.globalFlags = f
+			return b.cmd()
+		})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return outBuf.String(), err
+	}
+
+	t.Run("sent", func(t *testing.T) {
+		svc := mock.NewNotificationEndpointTestService()
+		var gotID influxdb.ID
+		svc.TestNotificationEndpointFn = func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+			gotID = id
+			return &influxdb.NotificationEndpointTestResult{Sent: true, StatusCode: 202}, nil
+		}
+
+		out, err := execute(t, svc, "endpoint", "test", "--id="+id.String())
+		require.NoError(t, err)
+		assert.Equal(t, id, gotID)
+		assert.Contains(t, out, "202")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		svc := mock.NewNotificationEndpointTestService()
+		svc.TestNotificationEndpointFn = func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+			return &influxdb.NotificationEndpointTestResult{StatusCode: 403, Error: "invalid_token"}, nil
+		}
+
+		out, err := execute(t, svc, "endpoint", "test", "--id="+id.String())
+		require.Error(t, err)
+		assert.Contains(t, out, "403")
+		assert.Contains(t, out, "invalid_token")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := execute(t, mock.NewNotificationEndpointTestService(), "endpoint", "test", "--id=nope")
+		require.Error(t, err)
+	})
+}
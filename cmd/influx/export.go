@@ -0,0 +1,125 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/kit/signals"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+)
+
+var exportBucketFlags struct {
+	http.ExportRequest
+	File string
+	Gzip bool
+}
+
+func cmdExport(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("export", nil)
+	cmd.Short = "Export data from InfluxDB"
+	cmd.Run = seeHelp
+	cmd.AddCommand(cmdExportBucket(f, opt))
+	return cmd
+}
+
+func cmdExportBucket(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("bucket", exportBucketF)
+	cmd.Short = "Export the data of a bucket"
+	cmd.Long = `Export the data of a bucket as line protocol or annotated CSV,
+optionally bounded in time and filtered with a predicate. The output can be
+written back with "influx import".`
+
+	opts := flagOpts{
+		{
+			DestP: &exportBucketFlags.OrgID,
+			Flag:  "org-id",
+			Desc:  "The ID of the organization that owns the bucket",
+		},
+		{
+			DestP: &exportBucketFlags.Org,
+			Flag:  "org",
+			Short: 'o',
+			Desc:  "The name of the organization that owns the bucket",
+		},
+		{
+			DestP: &exportBucketFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of the bucket to export",
+		},
+		{
+			DestP:  &exportBucketFlags.Bucket,
+			Flag:   "bucket",
+			Short:  'b',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The name of the bucket to export",
+		},
+	}
+	opts.mustRegister(cmd)
+
+	cmd.Flags().StringVar(&exportBucketFlags.Start, "start", "", "the start time in RFC3339Nano format, exp 2009-01-02T23:00:00Z")
+	cmd.Flags().StringVar(&exportBucketFlags.Stop, "stop", "", "the stop time in RFC3339Nano format, exp 2009-01-02T23:00:00Z")
+	cmd.Flags().StringVarP(&exportBucketFlags.Predicate, "predicate", "p", "", "sql like predicate string, exp 'tag1=\"v1\" and (tag2=123)'")
+	cmd.Flags().StringVar(&exportBucketFlags.Format, "format", string(influxdb.ExportFormatLineProtocol), "The format of the exported data, lp (line protocol) or csv (annotated CSV)")
+	cmd.Flags().StringVarP(&exportBucketFlags.File, "file", "f", "", "Path of the file to write to; defaults to stdout")
+	cmd.Flags().BoolVar(&exportBucketFlags.Gzip, "gzip", false, "Compress the output with gzip; implied by a file with the .gz extension")
+
+	return cmd
+}
+
+func exportBucketF(cmd *cobra.Command, args []string) error {
+	if exportBucketFlags.Org == "" && exportBucketFlags.OrgID == "" {
+		return fmt.Errorf("please specify one of org or org-id")
+	}
+
+	if exportBucketFlags.Bucket == "" && exportBucketFlags.BucketID == "" {
+		return fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	if err := influxdb.ExportFormat(exportBucketFlags.Format).Valid(); err != nil {
+		return err
+	}
+
+	var (
+		w    io.Writer = cmd.OutOrStdout()
+		file *os.File
+	)
+	if exportBucketFlags.File != "" {
+		var err error
+		if file, err = os.Create(exportBucketFlags.File); err != nil {
+			return fmt.Errorf("failed to create %q: %v", exportBucketFlags.File, err)
+		}
+		w = file
+	}
+
+	var gw *gzip.Writer
+	if exportBucketFlags.Gzip || strings.HasSuffix(exportBucketFlags.File, ".gz") {
+		gw = gzip.NewWriter(w)
+		w = gw
+	}
+
+	s := &http.ExportService{
+		Addr:               flags.Host,
+		Token:              flags.Token,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	ctx := signals.WithStandardSignals(context.Background())
+	err := s.ExportBucket(ctx, exportBucketFlags.ExportRequest, w)
+	if gw != nil {
+		err = multierr.Append(err, gw.Close())
+	}
+	if file != nil {
+		err = multierr.Append(err, file.Close())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export data: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/kit/signals"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
+	"github.com/influxdata/influxdb/write"
+	"github.com/spf13/cobra"
+)
+
+var importFlags struct {
+	OrgID    string
+	Org      string
+	BucketID string
+	Bucket   string
+	File     string
+	Format   string
+	Resume   bool
+}
+
+func cmdImport(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("import", importF)
+	cmd.Short = "Import data into a bucket"
+	cmd.Long = `Import line protocol or annotated CSV, as written by "influx export bucket",
+into a bucket. Gzip compressed files are detected automatically.
+
+The progress of the import is recorded in a file next to the imported file
+with the .progress extension. If an import is interrupted, run it again with
+--resume to continue where it stopped.`
+
+	opts := flagOpts{
+		{
+			DestP: &importFlags.OrgID,
+			Flag:  "org-id",
+			Desc:  "The ID of the organization that owns the bucket",
+		},
+		{
+			DestP: &importFlags.Org,
+			Flag:  "org",
+			Short: 'o',
+			Desc:  "The name of the organization that owns the bucket",
+		},
+		{
+			DestP: &importFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of destination bucket",
+		},
+		{
+			DestP:  &importFlags.Bucket,
+			Flag:   "bucket",
+			Short:  'b',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The name of destination bucket",
+		},
+		{
+			DestP:    &importFlags.File,
+			Flag:     "file",
+			Short:    'f',
+			Desc:     "Path of the file to import",
+			Required: true,
+		},
+	}
+	opts.mustRegister(cmd)
+
+	cmd.Flags().StringVar(&importFlags.Format, "format", "", "The format of the file, lp (line protocol) or csv (annotated CSV); defaults to csv for files with the .csv or .csv.gz extension and lp otherwise")
+	cmd.Flags().BoolVar(&importFlags.Resume, "resume", false, "Resume an interrupted import of the file")
+
+	return cmd
+}
+
+// importProgress is the content of the progress file of an import.
+type importProgress struct {
+	// Written is the number of bytes of line protocol written to the bucket.
+	Written int64 `json:"written"`
+}
+
+func importF(cmd *cobra.Command, args []string) error {
+	if importFlags.Org != "" && importFlags.OrgID != "" {
+		return fmt.Errorf("please specify one of org or org-id")
+	}
+
+	if importFlags.Bucket != "" && importFlags.BucketID != "" {
+		return fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	format := platform.ExportFormat(importFlags.Format)
+	if format == "" {
+		format = platform.ExportFormatLineProtocol
+		if strings.HasSuffix(importFlags.File, ".csv") || strings.HasSuffix(importFlags.File, ".csv.gz") {
+			format = platform.ExportFormatCSV
+		}
+	}
+	if err := format.Valid(); err != nil {
+		return err
+	}
+
+	ctx := signals.WithStandardSignals(context.Background())
+
+	orgID, bucketID, err := findImportBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(importFlags.File)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %v", importFlags.File, err)
+	}
+	defer f.Close()
+
+	r, err := decompressedReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %v", importFlags.File, err)
+	}
+
+	if format == platform.ExportFormatCSV {
+		pr, pw := io.Pipe()
+		go func(r io.Reader) {
+			pw.CloseWithError(csvToLineProtocol(r, pw))
+		}(r)
+		defer pr.Close()
+		r = pr
+	}
+
+	svc := &http.WriteService{
+		Addr:               flags.Host,
+		Token:              flags.Token,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+	if err := importData(ctx, svc, orgID, bucketID, r, importFlags.File+".progress", importFlags.Resume); err != nil {
+		if err == context.Canceled {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// importData writes the line protocol read from r to the bucket, recording
+// the number of bytes written in the progress file at progressPath. With
+// resume, the bytes recorded by a previous import are skipped. The progress
+// file is removed once all of r is written.
+func importData(ctx context.Context, svc platform.WriteService, orgID, bucketID platform.ID, r io.Reader, progressPath string, resume bool) error {
+	var (
+		progress importProgress
+		err      error
+	)
+	if resume {
+		if progress, err = readImportProgress(progressPath); err != nil {
+			return fmt.Errorf("failed to read import progress: %v", err)
+		}
+		// The line protocol is generated the same way on every run, so
+		// skipping the bytes written before resumes at the first line
+		// that was not written.
+		if _, err := io.CopyN(ioutil.Discard, r, progress.Written); err != nil {
+			return fmt.Errorf("failed to resume import: %v", err)
+		}
+	}
+
+	var progressErr error
+	s := write.Batcher{
+		Service: svc,
+		OnFlush: func(written int64) {
+			if progressErr == nil {
+				progressErr = writeImportProgress(progressPath, importProgress{Written: progress.Written + written})
+			}
+		},
+	}
+
+	if err := s.Write(ctx, orgID, bucketID, r); err != nil {
+		if err == context.Canceled {
+			return err
+		}
+		return fmt.Errorf("failed to import data: %v; run the import again with --resume to continue", err)
+	}
+
+	if progressErr != nil {
+		return fmt.Errorf("failed to record import progress: %v", progressErr)
+	}
+	if err := os.Remove(progressPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func findImportBucket(ctx context.Context) (orgID, bucketID platform.ID, err error) {
+	bs, err := newBucketService()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var filter platform.BucketFilter
+	if importFlags.BucketID != "" {
+		filter.ID, err = platform.IDFromString(importFlags.BucketID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to decode bucket-id: %v", err)
+		}
+	}
+	if importFlags.Bucket != "" {
+		filter.Name = &importFlags.Bucket
+	}
+
+	if importFlags.OrgID != "" {
+		filter.OrganizationID, err = platform.IDFromString(importFlags.OrgID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to decode org-id id: %v", err)
+		}
+	}
+	if importFlags.Org != "" {
+		filter.Org = &importFlags.Org
+	}
+
+	buckets, n, err := bs.FindBuckets(ctx, filter)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve buckets: %v", err)
+	}
+
+	if n == 0 {
+		if importFlags.Bucket != "" {
+			return 0, 0, fmt.Errorf("bucket %q was not found", importFlags.Bucket)
+		}
+		return 0, 0, fmt.Errorf("bucket with id %q does not exist", importFlags.BucketID)
+	}
+
+	return buckets[0].OrgID, buckets[0].ID, nil
+}
+
+func readImportProgress(path string) (importProgress, error) {
+	var progress importProgress
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return progress, nil
+	} else if err != nil {
+		return progress, err
+	}
+	err = json.Unmarshal(b, &progress)
+	return progress, err
+}
+
+func writeImportProgress(path string, progress importProgress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so an interrupted write does not
+	// leave a corrupt progress file behind.
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// decompressedReader returns a reader of r that decompresses its content
+// if it is gzip compressed.
+func decompressedReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == io.EOF {
+		return br, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+// csvToLineProtocol converts annotated CSV to line protocol. Every row must
+// have the _measurement, _field, _value and _time columns; the other string
+// columns of the group key are written as tags.
+func csvToLineProtocol(r io.Reader, w io.Writer) error {
+	dec := csv.NewResultDecoder(csv.ResultDecoderConfig{})
+	result, err := dec.Decode(r)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	err = result.Tables().Do(func(tbl flux.Table) error {
+		return tableToLineProtocol(tbl, bw)
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func tableToLineProtocol(tbl flux.Table, w io.Writer) error {
+	var (
+		measurementIdx = -1
+		fieldIdx       = -1
+		valueIdx       = -1
+		timeIdx        = -1
+		tagIdxs        []int
+	)
+
+	key := tbl.Key()
+	for j, c := range tbl.Cols() {
+		switch c.Label {
+		case "_measurement":
+			measurementIdx = j
+		case "_field":
+			fieldIdx = j
+		case "_value":
+			valueIdx = j
+		case "_time":
+			timeIdx = j
+		case "_start", "_stop":
+		default:
+			if c.Type == flux.TString && key.HasCol(c.Label) {
+				tagIdxs = append(tagIdxs, j)
+			}
+		}
+	}
+	if measurementIdx < 0 || fieldIdx < 0 || valueIdx < 0 || timeIdx < 0 {
+		return fmt.Errorf("table must have the _measurement, _field, _value and _time columns")
+	}
+
+	cols := tbl.Cols()
+	sort.Slice(tagIdxs, func(i, j int) bool {
+		return cols[tagIdxs[i]].Label < cols[tagIdxs[j]].Label
+	})
+
+	var (
+		line []byte
+		tags = make(models.Tags, len(tagIdxs))
+	)
+	return tbl.Do(func(cr flux.ColReader) error {
+		measurements, fields, times := cr.Strings(measurementIdx), cr.Strings(fieldIdx), cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if measurements.IsNull(i) || fields.IsNull(i) || times.IsNull(i) || isNull(cr, valueIdx, i) {
+				continue
+			}
+
+			line = append(line[:0], models.EscapeMeasurement(measurements.Value(i))...)
+			tags = tags[:0]
+			for _, j := range tagIdxs {
+				if v := cr.Strings(j); !v.IsNull(i) && len(v.Value(i)) > 0 {
+					tags = append(tags, models.NewTag([]byte(cols[j].Label), v.Value(i)))
+				}
+			}
+			line = tags.AppendHashKey(line)
+			line = append(line, ' ')
+			line = append(line, escape.Bytes(fields.Value(i))...)
+			line = append(line, '=')
+
+			switch cols[valueIdx].Type {
+			case flux.TFloat:
+				line = strconv.AppendFloat(line, cr.Floats(valueIdx).Value(i), 'f', -1, 64)
+			case flux.TInt:
+				line = strconv.AppendInt(line, cr.Ints(valueIdx).Value(i), 10)
+				line = append(line, 'i')
+			case flux.TUInt:
+				line = strconv.AppendUint(line, cr.UInts(valueIdx).Value(i), 10)
+				line = append(line, 'u')
+			case flux.TBool:
+				line = strconv.AppendBool(line, cr.Bools(valueIdx).Value(i))
+			case flux.TString:
+				line = append(line, '"')
+				line = append(line, models.EscapeStringField(cr.Strings(valueIdx).ValueString(i))...)
+				line = append(line, '"')
+			default:
+				return fmt.Errorf("unsupported _value type %s", cols[valueIdx].Type)
+			}
+
+			line = append(line, ' ')
+			line = strconv.AppendInt(line, times.Value(i), 10)
+			line = append(line, '\n')
+			if _, err := w.Write(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func isNull(cr flux.ColReader, j, i int) bool {
+	switch cr.Cols()[j].Type {
+	case flux.TFloat:
+		return cr.Floats(j).IsNull(i)
+	case flux.TInt:
+		return cr.Ints(j).IsNull(i)
+	case flux.TUInt:
+		return cr.UInts(j).IsNull(i)
+	case flux.TBool:
+		return cr.Bools(j).IsNull(i)
+	case flux.TString:
+		return cr.Strings(j).IsNull(i)
+	default:
+		return true
+	}
+}
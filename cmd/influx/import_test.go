@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_csvToLineProtocol(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "value types",
+			csv: `#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,double,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,host
+,,0,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:00Z,1.5,usage,cpu,a
+,,0,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:10Z,,usage,cpu,a
+
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,long,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,host
+,,1,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:00Z,2,count,cpu,a
+
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,unsignedLong,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,host
+,,2,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:00Z,3,total,cpu,a
+
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,boolean,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,host
+,,3,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:00Z,true,up,cpu,a
+
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,string,string,string,string
+#group,false,false,true,true,false,false,true,true,true
+#default,_result,,,,,,,,
+,result,table,_start,_stop,_time,_value,_field,_measurement,host
+,,4,2020-01-01T00:00:00Z,2020-01-02T00:00:00Z,2020-01-01T00:00:00Z,"say ""hi""",msg,cpu,a
+`,
+			want: `cpu,host=a usage=1.5 1577836800000000000
+cpu,host=a count=2i 1577836800000000000
+cpu,host=a total=3u 1577836800000000000
+cpu,host=a up=true 1577836800000000000
+cpu,host=a msg="say \"hi\"" 1577836800000000000
+`,
+		},
+		{
+			name: "tags are sorted and escaped, empty tags are skipped",
+			csv: `#datatype,string,long,dateTime:RFC3339,double,string,string,string,string,string
+#group,false,false,false,false,true,true,true,true,false
+#default,_result,,,,,,,,
+,result,table,_time,_value,_field,_measurement,zone,region,other
+,,0,2020-01-01T00:00:00Z,1,usage,cpu load,us west,,x
+`,
+			want: `cpu\ load,zone=us\ west usage=1 1577836800000000000
+`,
+		},
+		{
+			name: "missing columns",
+			csv: `#datatype,string,long,dateTime:RFC3339,double,string
+#group,false,false,false,false,true
+#default,_result,,,,
+,result,table,_time,_value,_measurement
+,,0,2020-01-01T00:00:00Z,1,cpu
+`,
+			wantErr: "table must have the _measurement, _field, _value and _time columns",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := csvToLineProtocol(strings.NewReader(tt.csv), &buf)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func Test_importData(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+		lp       = "m f=1 1\nm f=2 2\nm f=3 3\n"
+	)
+
+	newWriteService := func(written *[]string, err error) *mock.WriteService {
+		return &mock.WriteService{
+			WriteF: func(ctx context.Context, org, bucket influxdb.ID, r io.Reader) error {
+				if err != nil {
+					return err
+				}
+				b, rerr := ioutil.ReadAll(r)
+				if rerr != nil {
+					return rerr
+				}
+				*written = append(*written, strings.Split(strings.TrimSpace(string(b)), "\n")...)
+				return nil
+			},
+		}
+	}
+
+	t.Run("writes all lines and removes the progress file", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		progressPath := filepath.Join(dir, "data.lp.progress")
+
+		var written []string
+		err := importData(context.Background(), newWriteService(&written, nil), orgID, bucketID, strings.NewReader(lp), progressPath, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"m f=1 1", "m f=2 2", "m f=3 3"}, written)
+		_, err = os.Stat(progressPath)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("resume skips the bytes written before", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		progressPath := filepath.Join(dir, "data.lp.progress")
+		require.NoError(t, writeImportProgress(progressPath, importProgress{Written: int64(len("m f=1 1\n"))}))
+
+		var written []string
+		err := importData(context.Background(), newWriteService(&written, nil), orgID, bucketID, strings.NewReader(lp), progressPath, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"m f=2 2", "m f=3 3"}, written)
+		_, err = os.Stat(progressPath)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("without resume the progress is ignored", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		progressPath := filepath.Join(dir, "data.lp.progress")
+		require.NoError(t, writeImportProgress(progressPath, importProgress{Written: int64(len("m f=1 1\n"))}))
+
+		var written []string
+		err := importData(context.Background(), newWriteService(&written, nil), orgID, bucketID, strings.NewReader(lp), progressPath, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"m f=1 1", "m f=2 2", "m f=3 3"}, written)
+	})
+
+	t.Run("failed write keeps the progress", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		progressPath := filepath.Join(dir, "data.lp.progress")
+		want := importProgress{Written: int64(len("m f=1 1\n"))}
+		require.NoError(t, writeImportProgress(progressPath, want))
+
+		var written []string
+		err := importData(context.Background(), newWriteService(&written, errors.New("unavailable")), orgID, bucketID, strings.NewReader(lp), progressPath, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--resume")
+
+		got, err := readImportProgress(progressPath)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
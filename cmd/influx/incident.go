@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+type incidentSVCsFn func() (influxdb.IncidentService, influxdb.OrganizationService, error)
+
+func cmdIncident(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdIncidentBuilder(newIncidentSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdIncidentBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn incidentSVCsFn
+
+	id             string
+	checkID        string
+	status         string
+	text           string
+	acknowledged   bool
+	unacknowledged bool
+	org            organization
+}
+
+func newCmdIncidentBuilder(svcsFn incidentSVCsFn, opt genericCLIOpts) *cmdIncidentBuilder {
+	return &cmdIncidentBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdIncidentBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("incident", nil)
+	cmd.Short = "Check incident management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdAck(),
+		b.cmdComment(),
+		b.cmdFind(),
+		b.cmdUnack(),
+	)
+	return cmd
+}
+
+func (b *cmdIncidentBuilder) registerIDFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The incident ID (required)")
+	cmd.MarkFlagRequired("id")
+}
+
+func (b *cmdIncidentBuilder) cmdAck() *cobra.Command {
+	cmd := b.newCmd("ack", b.cmdAckRunEFn)
+	cmd.Short = "Acknowledge an open incident"
+	cmd.Aliases = []string{"acknowledge"}
+	b.registerIDFlag(cmd)
+	return cmd
+}
+
+func (b *cmdIncidentBuilder) cmdAckRunEFn(cmd *cobra.Command, args []string) error {
+	incSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+	id, err := b.incidentID()
+	if err != nil {
+		return err
+	}
+
+	// the incident is acknowledged by the user of the token.
+	i, err := incSVC.AcknowledgeIncident(context.Background(), id, 0)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge incident with id %q: %v", id, err)
+	}
+
+	return b.printIncidents(i)
+}
+
+func (b *cmdIncidentBuilder) cmdUnack() *cobra.Command {
+	cmd := b.newCmd("unack", b.cmdUnackRunEFn)
+	cmd.Short = "Remove the acknowledgement of an incident"
+	cmd.Aliases = []string{"unacknowledge"}
+	b.registerIDFlag(cmd)
+	return cmd
+}
+
+func (b *cmdIncidentBuilder) cmdUnackRunEFn(cmd *cobra.Command, args []string) error {
+	incSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+	id, err := b.incidentID()
+	if err != nil {
+		return err
+	}
+
+	i, err := incSVC.UnacknowledgeIncident(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("failed to unacknowledge incident with id %q: %v", id, err)
+	}
+
+	return b.printIncidents(i)
+}
+
+func (b *cmdIncidentBuilder) cmdComment() *cobra.Command {
+	cmd := b.newCmd("comment", b.cmdCommentRunEFn)
+	cmd.Short = "Comment an incident"
+	b.registerIDFlag(cmd)
+	cmd.Flags().StringVar(&b.text, "text", "", "Text of the comment (required)")
+	cmd.MarkFlagRequired("text")
+	return cmd
+}
+
+func (b *cmdIncidentBuilder) cmdCommentRunEFn(cmd *cobra.Command, args []string) error {
+	incSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+	id, err := b.incidentID()
+	if err != nil {
+		return err
+	}
+
+	// the incident is commented by the user of the token.
+	i, err := incSVC.CommentIncident(context.Background(), id, 0, b.text)
+	if err != nil {
+		return fmt.Errorf("failed to comment incident with id %q: %v", id, err)
+	}
+
+	return b.printIncidents(i)
+}
+
+func (b *cmdIncidentBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn)
+	cmd.Short = "List check incidents, the most recently opened first"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVar(&b.checkID, "check-id", "", "Only list the incidents of the check")
+	cmd.Flags().StringVar(&b.status, "status", "", "Only list the open or the closed incidents")
+	cmd.Flags().BoolVar(&b.acknowledged, "acknowledged", false, "Only list the acknowledged incidents")
+	cmd.Flags().BoolVar(&b.unacknowledged, "unacknowledged", false, "Only list the unacknowledged incidents")
+	b.org.register(cmd, false)
+
+	return cmd
+}
+
+func (b *cmdIncidentBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+	if b.acknowledged && b.unacknowledged {
+		return fmt.Errorf("must specify at most one of acknowledged and unacknowledged")
+	}
+
+	incSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+	filter := influxdb.IncidentFilter{OrgID: &orgID}
+	if b.checkID != "" {
+		id, err := influxdb.IDFromString(b.checkID)
+		if err != nil {
+			return fmt.Errorf("invalid check id %q: %v", b.checkID, err)
+		}
+		filter.CheckID = id
+	}
+	switch status := influxdb.IncidentStatus(b.status); status {
+	case "":
+	case influxdb.IncidentOpen, influxdb.IncidentClosed:
+		filter.Status = &status
+	default:
+		return fmt.Errorf("invalid status %q, expected %q or %q", b.status, influxdb.IncidentOpen, influxdb.IncidentClosed)
+	}
+	if b.acknowledged || b.unacknowledged {
+		filter.Acknowledged = &b.acknowledged
+	}
+
+	is, _, err := incSVC.FindIncidents(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve incidents: %v", err)
+	}
+
+	return b.printIncidents(is...)
+}
+
+func (b *cmdIncidentBuilder) incidentID() (influxdb.ID, error) {
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return 0, fmt.Errorf("failed to decode incident id %q: %v", b.id, err)
+	}
+	return id, nil
+}
+
+func (b *cmdIncidentBuilder) printIncidents(is ...*influxdb.Incident) error {
+	w := b.newTabWriter()
+	w.WriteHeaders("ID", "Check", "Level", "Status", "Opened", "Closed", "Acknowledged", "Comments")
+	for _, i := range is {
+		var closed, acknowledged string
+		if i.ClosedAt != nil {
+			closed = i.ClosedAt.Format(time.RFC3339)
+		}
+		if i.AcknowledgedAt != nil {
+			acknowledged = i.AcknowledgedAt.Format(time.RFC3339)
+		}
+		w.Write(map[string]interface{}{
+			"ID":           i.ID.String(),
+			"Check":        i.CheckName,
+			"Level":        i.Level,
+			"Status":       string(i.Status),
+			"Opened":       i.OpenedAt.Format(time.RFC3339),
+			"Closed":       closed,
+			"Acknowledged": acknowledged,
+			"Comments":     len(i.Comments),
+		})
+	}
+	w.Flush()
+
+	return nil
+}
+
+func newIncidentSVCs() (influxdb.IncidentService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	orgSvc := &http.OrganizationService{Client: httpClient}
+
+	return &http.IncidentService{Client: httpClient}, orgSvc, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdIncident(t *testing.T) {
+	orgID := influxdb.ID(9000)
+	opened := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+
+	fakeSVCFn := func(svc influxdb.IncidentService) incidentSVCsFn {
+		return func() (influxdb.IncidentService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	execute := func(t *testing.T, svc influxdb.IncidentService, args ...string) error {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+			b := newCmdIncidentBuilder(fakeSVCFn(svc), opt)
+			b.globalFlags = f
+			return b.cmd()
+		})
+		cmd.SetArgs(append([]string{"incident"}, args...))
+		return cmd.Execute()
+	}
+
+	newIncident := func(id influxdb.ID) *influxdb.Incident {
+		return &influxdb.Incident{
+			ID:       id,
+			OrgID:    orgID,
+			CheckID:  3,
+			Level:    "crit",
+			Status:   influxdb.IncidentOpen,
+			OpenedAt: opened,
+		}
+	}
+
+	t.Run("list", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.IncidentFilter
+		}{
+			{
+				name:     "all",
+				flags:    []string{"--org-id=" + orgID.String()},
+				expected: influxdb.IncidentFilter{OrgID: &orgID},
+			},
+			{
+				name: "open unacknowledged of a check",
+				flags: []string{
+					"--check-id=" + influxdb.ID(3).String(),
+					"--status=open",
+					"--unacknowledged",
+					"--org-id=" + orgID.String(),
+				},
+				expected: func() influxdb.IncidentFilter {
+					checkID := influxdb.ID(3)
+					status := influxdb.IncidentOpen
+					acknowledged := false
+					return influxdb.IncidentFilter{
+						OrgID:        &orgID,
+						CheckID:      &checkID,
+						Status:       &status,
+						Acknowledged: &acknowledged,
+					}
+				}(),
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				svc := mock.NewIncidentService()
+				var filter influxdb.IncidentFilter
+				svc.FindIncidentsFn = func(ctx context.Context, f influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+					filter = f
+					return []*influxdb.Incident{newIncident(1)}, 1, nil
+				}
+
+				require.NoError(t, execute(t, svc, append([]string{"list"}, tt.flags...)...))
+				assert.Equal(t, tt.expected, filter)
+			}
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("list with invalid status", func(t *testing.T) {
+		svc := mock.NewIncidentService()
+		err := execute(t, svc, "list", "--status=resolved", "--org-id="+orgID.String())
+		require.Error(t, err)
+		assert.Equal(t, 0, svc.FindIncidentsCalls.Count())
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		svc := mock.NewIncidentService()
+		var acked influxdb.ID
+		svc.AcknowledgeIncidentFn = func(ctx context.Context, id, userID influxdb.ID) (*influxdb.Incident, error) {
+			acked = id
+			i := newIncident(id)
+			now := opened.Add(time.Minute)
+			i.AcknowledgedAt = &now
+			return i, nil
+		}
+
+		require.NoError(t, execute(t, svc, "ack", "--id="+influxdb.ID(1).String()))
+		assert.Equal(t, influxdb.ID(1), acked)
+	})
+
+	t.Run("unack", func(t *testing.T) {
+		svc := mock.NewIncidentService()
+		var unacked influxdb.ID
+		svc.UnacknowledgeIncidentFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+			unacked = id
+			return newIncident(id), nil
+		}
+
+		require.NoError(t, execute(t, svc, "unack", "--id="+influxdb.ID(1).String()))
+		assert.Equal(t, influxdb.ID(1), unacked)
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		svc := mock.NewIncidentService()
+		var text string
+		svc.CommentIncidentFn = func(ctx context.Context, id, userID influxdb.ID, txt string) (*influxdb.Incident, error) {
+			text = txt
+			return newIncident(id), nil
+		}
+
+		require.NoError(t, execute(t, svc, "comment", "--id="+influxdb.ID(1).String(), "--text=restarting the host"))
+		assert.Equal(t, "restarting the host", text)
+	})
+}
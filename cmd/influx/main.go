@@ -204,16 +204,25 @@ func influxCmd(opts ...genericCLIOptFn) *cobra.Command {
 		cmdAuth,
 		cmdBackup,
 		cmdBucket,
+		cmdCheck,
+		cmdDBRP,
 		cmdDelete,
+		cmdEndpoint,
+		cmdExport,
+		cmdImport,
+		cmdIncident,
 		cmdOrganization,
 		cmdPing,
 		cmdPkg,
 		cmdConfig,
 		cmdQuery,
+		cmdRestore,
+		cmdRule,
 		cmdTranspile,
 		cmdREPL,
 		cmdSecret,
 		cmdSetup,
+		cmdSilence,
 		cmdTask,
 		cmdUser,
 		cmdWrite,
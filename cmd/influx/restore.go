@@ -0,0 +1,681 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/kit/signals"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/wal"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/influxdata/influxdb/tsdb/value"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// restoreBatchSize is the number of lines written to the server per request.
+const restoreBatchSize = 5000
+
+func cmdRestore(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("restore", restoreF)
+	cmd.Short = "Restore the data of an organization or bucket from a backup"
+	cmd.Long = fmt.Sprintf(
+		`Restores the data of an organization or a bucket from the backup in the
+directory indicated by --path into the running InfluxDB instance. The backup
+must have a %s, as written by "influx backup"; restoring an incremental
+backup restores the data as of the time that backup was taken.
+
+The data is written to the organization and bucket it was backed up from
+unless --new-org-id, --new-bucket-id or --new-bucket is given. When restoring
+a whole organization into another one, each bucket is restored into the bucket
+of the same name, which is created if needed. All existing data of a bucket
+is deleted before the data of the backup is restored into it.
+
+To replace all data and metadata of a stopped instance, use "influxd restore".`,
+		influxdb.BackupManifestFilename)
+
+	opts := flagOpts{
+		{
+			DestP:    &restoreFlags.Path,
+			Flag:     "path",
+			Short:    'p',
+			EnvVar:   "PATH",
+			Desc:     "directory path of the backup to restore",
+			Required: true,
+		},
+		{
+			DestP:    &restoreFlags.OrgID,
+			Flag:     "org-id",
+			Desc:     "The ID of the organization to restore",
+			Required: true,
+		},
+		{
+			DestP: &restoreFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of the bucket to restore; all buckets of the organization are restored if not set",
+		},
+	}
+	opts.mustRegister(cmd)
+
+	cmd.Flags().StringVar(&restoreFlags.NewOrgID, "new-org-id", "", "The ID of the organization to restore into")
+	cmd.Flags().StringVar(&restoreFlags.NewBucketID, "new-bucket-id", "", "The ID of an existing bucket to restore into; requires --bucket-id")
+	cmd.Flags().StringVar(&restoreFlags.NewBucket, "new-bucket", "", "The name of a bucket to create and restore into; requires --bucket-id")
+
+	return cmd
+}
+
+var restoreFlags struct {
+	Path        string
+	OrgID       string
+	BucketID    string
+	NewOrgID    string
+	NewBucketID string
+	NewBucket   string
+}
+
+func restoreF(cmd *cobra.Command, args []string) error {
+	ctx := signals.WithStandardSignals(context.Background())
+
+	if flags.local {
+		return fmt.Errorf("local flag not supported for restore command")
+	}
+
+	if restoreFlags.Path == "" {
+		return fmt.Errorf("must specify path")
+	}
+
+	if restoreFlags.NewBucketID != "" && restoreFlags.NewBucket != "" {
+		return fmt.Errorf("must specify new-bucket-id, or new-bucket not both")
+	}
+
+	if restoreFlags.BucketID == "" && (restoreFlags.NewBucketID != "" || restoreFlags.NewBucket != "") {
+		return fmt.Errorf("must specify bucket-id to restore into a new bucket")
+	}
+
+	orgID, err := influxdb.IDFromString(restoreFlags.OrgID)
+	if err != nil {
+		return fmt.Errorf("invalid org ID provided: %v", err)
+	}
+
+	r := &backupRestorer{
+		dir:     restoreFlags.Path,
+		orgID:   *orgID,
+		targets: make(map[influxdb.ID]*restoreTarget),
+		writeService: &http.WriteService{
+			Addr:               flags.Host,
+			Token:              flags.Token,
+			InsecureSkipVerify: flags.skipVerify,
+		},
+		deleteService: httpDeleteService{
+			s: &http.DeleteService{
+				Addr:               flags.Host,
+				Token:              flags.Token,
+				InsecureSkipVerify: flags.skipVerify,
+			},
+		},
+	}
+
+	if restoreFlags.BucketID != "" {
+		if r.bucketID, err = influxdb.IDFromString(restoreFlags.BucketID); err != nil {
+			return fmt.Errorf("invalid bucket ID provided: %v", err)
+		}
+	}
+
+	r.newOrgID = r.orgID
+	if restoreFlags.NewOrgID != "" {
+		newOrgID, err := influxdb.IDFromString(restoreFlags.NewOrgID)
+		if err != nil {
+			return fmt.Errorf("invalid new org ID provided: %v", err)
+		}
+		r.newOrgID = *newOrgID
+	}
+
+	if restoreFlags.NewBucketID != "" {
+		if r.newBucketID, err = influxdb.IDFromString(restoreFlags.NewBucketID); err != nil {
+			return fmt.Errorf("invalid new bucket ID provided: %v", err)
+		}
+	}
+	r.newBucket = restoreFlags.NewBucket
+
+	if r.bucketService, _, err = newBucketSVCs(); err != nil {
+		return err
+	}
+
+	if r.manifest, err = readBackupManifest(r.dir); err != nil {
+		return fmt.Errorf("failed to read backup manifest: %v", err)
+	}
+
+	if err := r.restore(ctx); err != nil {
+		return err
+	}
+
+	for _, t := range r.targets {
+		fmt.Printf("Restored %d points to bucket %s of organization %s\n", t.points, t.bucketID, t.orgID)
+	}
+
+	return nil
+}
+
+// backupRestorer writes the data of an organization, or one of its buckets,
+// in a backup to a running server.
+type backupRestorer struct {
+	dir      string
+	manifest *influxdb.BackupManifest
+
+	orgID    influxdb.ID
+	bucketID *influxdb.ID
+
+	newOrgID    influxdb.ID
+	newBucketID *influxdb.ID
+	newBucket   string
+
+	bucketService influxdb.BucketService
+	writeService  influxdb.WriteService
+	deleteService influxdb.DeleteService
+
+	// dirs are the directories of the backup and of the backups it is
+	// based on; they are read on demand.
+	dirs []string
+
+	// kvService reads the metadata of the backup; it is opened on demand.
+	kvService *kv.Service
+	kvClose   func() error
+
+	targets map[influxdb.ID]*restoreTarget
+}
+
+// restoreTarget is the bucket the data of a bucket of the backup is restored into.
+type restoreTarget struct {
+	orgID, bucketID influxdb.ID
+
+	buf    bytes.Buffer
+	lines  int
+	points int
+}
+
+func (r *backupRestorer) restore(ctx context.Context) error {
+	tmpDir, err := ioutil.TempDir("", "influx-restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	defer func() {
+		if r.kvClose != nil {
+			r.kvClose()
+		}
+	}()
+
+	var tsmFiles, walFiles []string
+	for _, f := range r.manifest.Files {
+		switch f.Type {
+		case influxdb.BackupFileTypeTSM, influxdb.BackupFileTypeTombstone, influxdb.BackupFileTypeWAL:
+		default:
+			continue
+		}
+
+		// TSM files and their tombstones are linked next to each other so that
+		// the tombstones are applied when reading. WAL segments are copied as
+		// the WAL reader truncates corrupt segments.
+		path, err := r.stageFile(f, tmpDir, f.Type == influxdb.BackupFileTypeWAL)
+		if err != nil {
+			return err
+		}
+
+		switch f.Type {
+		case influxdb.BackupFileTypeTSM:
+			tsmFiles = append(tsmFiles, path)
+		case influxdb.BackupFileTypeWAL:
+			walFiles = append(walFiles, path)
+		}
+	}
+
+	// TSM file names start with their generation, so older data is written
+	// first, whichever time partition the files are in.
+	sort.Slice(tsmFiles, func(i, j int) bool {
+		bi, bj := filepath.Base(tsmFiles[i]), filepath.Base(tsmFiles[j])
+		if bi != bj {
+			return bi < bj
+		}
+		return tsmFiles[i] < tsmFiles[j]
+	})
+	for _, path := range tsmFiles {
+		if err := r.restoreTSMFile(ctx, path); err != nil {
+			return fmt.Errorf("failed to restore TSM file %s: %v", filepath.Base(path), err)
+		}
+	}
+
+	if err := r.restoreWAL(ctx, walFiles); err != nil {
+		return fmt.Errorf("failed to restore WAL segments: %v", err)
+	}
+
+	for _, t := range r.targets {
+		if err := r.flush(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stageFile verifies the checksum of a backup file and links or copies it into dir.
+func (r *backupRestorer) stageFile(mf influxdb.BackupManifestFile, dir string, copyFile bool) (string, error) {
+	// The file is staged under its name, which must not leave dir.
+	if name := path.Clean(mf.Name); name != mf.Name || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", fmt.Errorf("invalid backup file name %q", mf.Name)
+	}
+	src, err := r.filePath(mf)
+	if err != nil {
+		return "", err
+	}
+	// The files of time partitions are staged in directories of their own, as
+	// files of different partitions may have the same name.
+	dst := filepath.Join(dir, filepath.FromSlash(mf.Name))
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("error opening backup file %s: %v", mf.Name, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	w := io.Writer(h)
+
+	var out *os.File
+	if copyFile {
+		if out, err = os.Create(dst); err != nil {
+			return "", err
+		}
+		defer out.Close()
+		w = io.MultiWriter(out, h)
+	}
+
+	if _, err := io.Copy(w, f); err != nil {
+		return "", err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != mf.Checksum {
+		return "", fmt.Errorf("checksum mismatch for backup file %s: expected %s, got %s", mf.Name, mf.Checksum, sum)
+	}
+
+	if copyFile {
+		return dst, out.Close()
+	}
+	return dst, os.Symlink(src, dst)
+}
+
+// filePath returns the absolute path of a file of the manifest, which must be
+// in the directory of the backup or of one of the backups it is based on.
+func (r *backupRestorer) filePath(mf influxdb.BackupManifestFile) (string, error) {
+	if filepath.IsAbs(mf.Path) {
+		return "", fmt.Errorf("invalid path %q of backup file %s", mf.Path, mf.Name)
+	}
+
+	if r.dirs == nil {
+		dirs, err := backupDirs(r.dir, r.manifest)
+		if err != nil {
+			return "", err
+		}
+		r.dirs = dirs
+	}
+
+	p, err := filepath.Abs(filepath.Join(r.dir, mf.Path))
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range r.dirs {
+		rel, err := filepath.Rel(dir, p)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("invalid path %q of backup file %s", mf.Path, mf.Name)
+}
+
+// backupDirs returns the absolute paths of the directory of the backup in dir
+// and of the directories of the backups it is based on.
+func backupDirs(dir string, m *influxdb.BackupManifest) ([]string, error) {
+	var dirs []string
+	for {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dirs {
+			if d == abs {
+				return nil, fmt.Errorf("backup %s is based on itself", dir)
+			}
+		}
+		dirs = append(dirs, abs)
+
+		if m.Base == "" {
+			return dirs, nil
+		}
+		dir = filepath.Join(dir, m.Base)
+		if m, err = readBackupManifest(dir); err != nil {
+			return nil, fmt.Errorf("failed to read base backup %s: %v", dir, err)
+		}
+	}
+}
+
+func (r *backupRestorer) restoreTSMFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	tr, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer tr.Close()
+
+	iter := tr.Iterator(nil)
+	for iter.Next() {
+		key := iter.Key()
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+
+		t, err := r.target(ctx, seriesKey)
+		if err != nil {
+			return err
+		} else if t == nil {
+			continue
+		}
+
+		values, err := tr.ReadAll(key)
+		if err != nil {
+			return err
+		}
+
+		if err := r.write(ctx, t, seriesKey, field, values); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// restoreWAL writes the data of the WAL segments. Deletes recorded in the WAL
+// are applied to the data written before them in the WAL; the data of TSM
+// files already reflects them through tombstones.
+func (r *backupRestorer) restoreWAL(ctx context.Context, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	values := make(map[string][]value.Value)
+	err := wal.NewWALReader(files).Read(func(entry wal.WALEntry) error {
+		switch entry := entry.(type) {
+		case *wal.WriteWALEntry:
+			for k, vs := range entry.Values {
+				values[k] = append(values[k], vs...)
+			}
+
+		case *wal.DeleteBucketRangeWALEntry:
+			var pred tsm1.Predicate
+			if len(entry.Predicate) > 0 {
+				p, err := tsm1.UnmarshalPredicate(entry.Predicate)
+				if err != nil {
+					return err
+				}
+				pred = p
+			}
+
+			name := tsdb.EncodeName(entry.OrgID, entry.BucketID)
+			for k, vs := range values {
+				seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey([]byte(k))
+				if !bytes.Equal(models.ParseName(seriesKey), name[:]) {
+					continue
+				}
+				if pred != nil && !pred.Matches(seriesKey) {
+					continue
+				}
+				values[k] = tsm1.Values(vs).Exclude(entry.Min, entry.Max)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey([]byte(k))
+
+		t, err := r.target(ctx, seriesKey)
+		if err != nil {
+			return err
+		} else if t == nil {
+			continue
+		}
+
+		if err := r.write(ctx, t, seriesKey, field, values[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// target returns the bucket to restore a series into, or nil if the series
+// is not restored.
+func (r *backupRestorer) target(ctx context.Context, seriesKey []byte) (*restoreTarget, error) {
+	name := models.ParseName(seriesKey)
+	if len(name) != influxdb.IDLength {
+		return nil, nil
+	}
+
+	orgID, bucketID := tsdb.DecodeNameSlice(name)
+	if orgID != r.orgID || (r.bucketID != nil && bucketID != *r.bucketID) {
+		return nil, nil
+	}
+
+	if t, ok := r.targets[bucketID]; ok {
+		return t, nil
+	}
+
+	newBucketID, err := r.resolveBucket(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The data of the bucket is replaced rather than merged with the data
+	// of the backup, which restores the bucket as of the time of the backup.
+	if err := r.deleteService.DeleteBucketRangePredicate(ctx, r.newOrgID, newBucketID, models.MinNanoTime, models.MaxNanoTime, nil); err != nil {
+		return nil, fmt.Errorf("failed to delete the data of bucket %s: %v", newBucketID, err)
+	}
+
+	t := &restoreTarget{orgID: r.newOrgID, bucketID: newBucketID}
+	r.targets[bucketID] = t
+	return t, nil
+}
+
+// resolveBucket returns the ID of the bucket of the target organization the
+// given bucket of the backup is restored into, creating it if needed.
+func (r *backupRestorer) resolveBucket(ctx context.Context, bucketID influxdb.ID) (influxdb.ID, error) {
+	if r.newBucketID != nil {
+		return *r.newBucketID, nil
+	}
+
+	if r.newBucket != "" {
+		return r.findOrCreateBucket(ctx, r.newBucket)
+	}
+
+	if r.newOrgID == r.orgID {
+		if _, err := r.bucketService.FindBucketByID(ctx, bucketID); err != nil {
+			return 0, fmt.Errorf("bucket %s does not exist; use --new-bucket to restore it into a new bucket: %v", bucketID, err)
+		}
+		return bucketID, nil
+	}
+
+	kvService, err := r.backupKVService(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err := kvService.FindBucketByID(ctx, bucketID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find bucket %s in backup: %v", bucketID, err)
+	}
+	return r.findOrCreateBucket(ctx, b.Name)
+}
+
+func (r *backupRestorer) findOrCreateBucket(ctx context.Context, name string) (influxdb.ID, error) {
+	b, err := r.bucketService.FindBucket(ctx, influxdb.BucketFilter{
+		Name:           &name,
+		OrganizationID: &r.newOrgID,
+	})
+	if err == nil {
+		return b.ID, nil
+	} else if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return 0, err
+	}
+
+	b = &influxdb.Bucket{
+		OrgID: r.newOrgID,
+		Name:  name,
+	}
+	if err := r.bucketService.CreateBucket(ctx, b); err != nil {
+		return 0, fmt.Errorf("failed to create bucket %q: %v", name, err)
+	}
+	return b.ID, nil
+}
+
+// backupKVService opens a copy of the metadata database of the backup.
+func (r *backupRestorer) backupKVService(ctx context.Context) (*kv.Service, error) {
+	if r.kvService != nil {
+		return r.kvService, nil
+	}
+
+	var boltFile *influxdb.BackupManifestFile
+	for i, f := range r.manifest.Files {
+		if f.Type == influxdb.BackupFileTypeKV {
+			boltFile = &r.manifest.Files[i]
+		}
+	}
+	if boltFile == nil {
+		return nil, fmt.Errorf("no %s file in backup", bolt.DefaultFilename)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "influx-restore-kv")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.stageFile(*boltFile, tmpDir, true); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	store := bolt.NewKVStore(zap.NewNop(), filepath.Join(tmpDir, boltFile.Name))
+	if err := store.Open(ctx); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	r.kvService = kv.NewService(zap.NewNop(), store)
+	r.kvClose = func() error {
+		err := store.Close()
+		os.RemoveAll(tmpDir)
+		return err
+	}
+	return r.kvService, nil
+}
+
+// write converts values of a series field to line protocol and writes them
+// to the target bucket in batches.
+func (r *backupRestorer) write(ctx context.Context, t *restoreTarget, seriesKey, field []byte, values []value.Value) error {
+	_, tags := models.ParseKeyBytes(seriesKey)
+
+	var (
+		measurement string
+		pointTags   = make(models.Tags, 0, len(tags))
+	)
+	for _, tag := range tags {
+		switch string(tag.Key) {
+		case models.MeasurementTagKey:
+			measurement = string(tag.Value)
+		case models.FieldKeyTagKey:
+		default:
+			pointTags = append(pointTags, tag)
+		}
+	}
+
+	for _, v := range values {
+		p, err := models.NewPoint(
+			measurement,
+			pointTags,
+			models.Fields{string(field): v.Value()},
+			time.Unix(0, v.UnixNano()),
+		)
+		if err != nil {
+			return err
+		}
+
+		t.buf.WriteString(p.String())
+		t.buf.WriteByte('\n')
+		t.lines++
+		t.points++
+
+		if t.lines >= restoreBatchSize {
+			if err := r.flush(ctx, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *backupRestorer) flush(ctx context.Context, t *restoreTarget) error {
+	if t.lines == 0 {
+		return nil
+	}
+
+	if err := r.writeService.Write(ctx, t.orgID, t.bucketID, &t.buf); err != nil {
+		return fmt.Errorf("failed to write to bucket %s: %v", t.bucketID, err)
+	}
+
+	t.buf.Reset()
+	t.lines = 0
+	return nil
+}
+
+// httpDeleteService deletes data through the delete API of the server.
+type httpDeleteService struct {
+	s *http.DeleteService
+}
+
+func (d httpDeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
+	if pred != nil {
+		return fmt.Errorf("delete predicates are not supported")
+	}
+	return d.s.DeleteBucketRangePredicate(ctx, http.DeleteRequest{
+		OrgID:    orgID.String(),
+		BucketID: bucketID.String(),
+		Start:    time.Unix(0, min).UTC().Format(time.RFC3339Nano),
+		Stop:     time.Unix(0, max).UTC().Format(time.RFC3339Nano),
+	})
+}
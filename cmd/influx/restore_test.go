@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_readBackupManifest(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+
+		m := &influxdb.BackupManifest{
+			Version: influxdb.BackupManifestVersion,
+			Base:    "../base",
+			Files: []influxdb.BackupManifestFile{
+				{
+					BackupFile: influxdb.BackupFile{Name: "000000001-000000001.tsm", Size: 10},
+					Type:       influxdb.BackupFileTypeTSM,
+					Path:       "../base/000000001-000000001.tsm",
+					Checksum:   "abc",
+				},
+			},
+		}
+		require.NoError(t, writeBackupManifest(dir, m))
+
+		got, err := readBackupManifest(dir)
+		require.NoError(t, err)
+		assert.Equal(t, m, got)
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+
+		_, err := readBackupManifest(dir)
+		require.Error(t, err)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		require.NoError(t, writeBackupManifest(dir, &influxdb.BackupManifest{Version: influxdb.BackupManifestVersion + 1}))
+
+		_, err := readBackupManifest(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported backup manifest version")
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, influxdb.BackupManifestFilename), []byte("{"), 0666))
+
+		_, err := readBackupManifest(dir)
+		require.Error(t, err)
+	})
+}
+
+func TestBackupRestorer_resolveBucket(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		newOrgID = influxdb.ID(2)
+		bucketID = influxdb.ID(3)
+	)
+
+	newBucketService := func(existing ...*influxdb.Bucket) (*mock.BucketService, *[]*influxdb.Bucket) {
+		var created []*influxdb.Bucket
+		svc := mock.NewBucketService()
+		svc.FindBucketByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+			for _, b := range existing {
+				if b.ID == id {
+					return b, nil
+				}
+			}
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "bucket not found"}
+		}
+		svc.FindBucketFn = func(ctx context.Context, filter influxdb.BucketFilter) (*influxdb.Bucket, error) {
+			for _, b := range existing {
+				if b.Name == *filter.Name && b.OrgID == *filter.OrganizationID {
+					return b, nil
+				}
+			}
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "bucket not found"}
+		}
+		svc.CreateBucketFn = func(ctx context.Context, b *influxdb.Bucket) error {
+			b.ID = 100
+			created = append(created, b)
+			return nil
+		}
+		return svc, &created
+	}
+
+	t.Run("new bucket id", func(t *testing.T) {
+		svc, created := newBucketService()
+		newBucketID := influxdb.ID(4)
+		r := &backupRestorer{orgID: orgID, newOrgID: orgID, newBucketID: &newBucketID, bucketService: svc}
+
+		id, err := r.resolveBucket(context.Background(), bucketID)
+		require.NoError(t, err)
+		assert.Equal(t, newBucketID, id)
+		assert.Empty(t, *created)
+	})
+
+	t.Run("new bucket name of an existing bucket", func(t *testing.T) {
+		svc, created := newBucketService(&influxdb.Bucket{ID: 5, OrgID: newOrgID, Name: "restored"})
+		r := &backupRestorer{orgID: orgID, newOrgID: newOrgID, newBucket: "restored", bucketService: svc}
+
+		id, err := r.resolveBucket(context.Background(), bucketID)
+		require.NoError(t, err)
+		assert.Equal(t, influxdb.ID(5), id)
+		assert.Empty(t, *created)
+	})
+
+	t.Run("new bucket name is created", func(t *testing.T) {
+		svc, created := newBucketService()
+		r := &backupRestorer{orgID: orgID, newOrgID: newOrgID, newBucket: "restored", bucketService: svc}
+
+		id, err := r.resolveBucket(context.Background(), bucketID)
+		require.NoError(t, err)
+		assert.Equal(t, influxdb.ID(100), id)
+		require.Len(t, *created, 1)
+		assert.Equal(t, &influxdb.Bucket{ID: 100, OrgID: newOrgID, Name: "restored"}, (*created)[0])
+	})
+
+	t.Run("same organization restores into the same bucket", func(t *testing.T) {
+		svc, _ := newBucketService(&influxdb.Bucket{ID: bucketID, OrgID: orgID, Name: "telegraf"})
+		r := &backupRestorer{orgID: orgID, newOrgID: orgID, bucketService: svc}
+
+		id, err := r.resolveBucket(context.Background(), bucketID)
+		require.NoError(t, err)
+		assert.Equal(t, bucketID, id)
+	})
+
+	t.Run("same organization requires the bucket to exist", func(t *testing.T) {
+		svc, _ := newBucketService()
+		r := &backupRestorer{orgID: orgID, newOrgID: orgID, bucketService: svc}
+
+		_, err := r.resolveBucket(context.Background(), bucketID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --new-bucket")
+	})
+
+	t.Run("new organization restores into the bucket of the same name", func(t *testing.T) {
+		dir, cleanup := mustTempDir(t)
+		defer cleanup()
+		backupBucketID := writeBackupKV(t, dir, "telegraf")
+
+		svc, created := newBucketService()
+		r := &backupRestorer{
+			dir:           dir,
+			manifest:      mustReadBackupManifest(t, dir),
+			orgID:         orgID,
+			newOrgID:      newOrgID,
+			bucketService: svc,
+		}
+		defer func() {
+			if r.kvClose != nil {
+				r.kvClose()
+			}
+		}()
+
+		id, err := r.resolveBucket(context.Background(), backupBucketID)
+		require.NoError(t, err)
+		assert.Equal(t, influxdb.ID(100), id)
+		require.Len(t, *created, 1)
+		assert.Equal(t, "telegraf", (*created)[0].Name)
+		assert.Equal(t, newOrgID, (*created)[0].OrgID)
+	})
+}
+
+func TestBackupRestorer_restore(t *testing.T) {
+	const (
+		orgID      = influxdb.ID(1)
+		bucketID   = influxdb.ID(2)
+		otherOrgID = influxdb.ID(3)
+	)
+
+	dir, cleanup := mustTempDir(t)
+	defer cleanup()
+	writeBackupTSM(t, dir, "000000001-000000001.tsm", map[influxdb.ID]influxdb.ID{
+		orgID:      bucketID,
+		otherOrgID: 4,
+	})
+
+	written := map[influxdb.ID][]string{}
+	var deleted []influxdb.ID
+	r := &backupRestorer{
+		dir:      dir,
+		manifest: mustReadBackupManifest(t, dir),
+		orgID:    orgID,
+		newOrgID: orgID,
+		newBucketID: func() *influxdb.ID {
+			id := influxdb.ID(5)
+			return &id
+		}(),
+		targets: make(map[influxdb.ID]*restoreTarget),
+		writeService: &mock.WriteService{
+			WriteF: func(ctx context.Context, org, bucket influxdb.ID, r io.Reader) error {
+				assert.Equal(t, orgID, org)
+				b, err := ioutil.ReadAll(r)
+				if err != nil {
+					return err
+				}
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				written[bucket] = append(written[bucket], lines...)
+				return nil
+			},
+		},
+		deleteService: &mock.DeleteService{
+			DeleteBucketRangePredicateF: func(ctx context.Context, org, bucket influxdb.ID, min, max int64, pred influxdb.Predicate) error {
+				// The data of the bucket is deleted before it is written.
+				assert.Equal(t, orgID, org)
+				assert.Empty(t, written[bucket])
+				assert.Equal(t, int64(models.MinNanoTime), min)
+				assert.Equal(t, int64(models.MaxNanoTime), max)
+				deleted = append(deleted, bucket)
+				return nil
+			},
+		},
+	}
+	require.NoError(t, r.restore(context.Background()))
+	assert.Equal(t, []influxdb.ID{5}, deleted)
+
+	sort.Strings(written[5])
+	assert.Equal(t, map[influxdb.ID][]string{
+		5: {
+			"cpu,host=a value=1 10",
+			"cpu,host=a value=2 20",
+		},
+	}, written)
+}
+
+func TestBackupRestorer_restorePartitions(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+	)
+
+	// The files of different time partitions have the same name.
+	dir, cleanup := mustTempDir(t)
+	defer cleanup()
+	for _, p := range []tsm1.Partition{
+		{OrgID: orgID, BucketID: bucketID, Min: 0, Max: 10},
+		{OrgID: orgID, BucketID: bucketID, Min: 10, Max: 20},
+	} {
+		name := filepath.ToSlash(filepath.Join(p.Dir(), "000000001-000000001.tsm"))
+		writeBackupTSM(t, dir, name, map[influxdb.ID]influxdb.ID{orgID: bucketID})
+	}
+
+	var written []string
+	r := &backupRestorer{
+		dir:      dir,
+		manifest: mustReadBackupManifest(t, dir),
+		orgID:    orgID,
+		newOrgID: orgID,
+		newBucketID: func() *influxdb.ID {
+			id := influxdb.ID(5)
+			return &id
+		}(),
+		targets: make(map[influxdb.ID]*restoreTarget),
+		writeService: &mock.WriteService{
+			WriteF: func(ctx context.Context, org, bucket influxdb.ID, r io.Reader) error {
+				b, err := ioutil.ReadAll(r)
+				if err != nil {
+					return err
+				}
+				written = append(written, strings.Split(strings.TrimSpace(string(b)), "\n")...)
+				return nil
+			},
+		},
+		deleteService: mock.NewDeleteService(),
+	}
+	require.NoError(t, r.restore(context.Background()))
+
+	// Both files are restored.
+	sort.Strings(written)
+	assert.Equal(t, []string{
+		"cpu,host=a value=1 10",
+		"cpu,host=a value=1 10",
+		"cpu,host=a value=2 20",
+		"cpu,host=a value=2 20",
+	}, written)
+}
+
+func TestBackupRestorer_stageFile(t *testing.T) {
+	root, cleanup := mustTempDir(t)
+	defer cleanup()
+
+	// An incremental backup refers to the files of the backup it is based on.
+	base, dir := filepath.Join(root, "base"), filepath.Join(root, "incremental")
+	writeBackupTSM(t, base, "000000001-000000001.tsm", map[influxdb.ID]influxdb.ID{1: 2})
+	require.NoError(t, os.MkdirAll(dir, 0777))
+	m := mustReadBackupManifest(t, base)
+	m.Base = "../base"
+	m.Files[0].Path = "../base/000000001-000000001.tsm"
+	require.NoError(t, writeBackupManifest(dir, m))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "outside.tsm"), nil, 0666))
+
+	stage := func(name, path string) error {
+		r := &backupRestorer{dir: dir, manifest: mustReadBackupManifest(t, dir)}
+		mf := r.manifest.Files[0]
+		mf.Name, mf.Path = name, path
+		_, err := r.stageFile(mf, filepath.Join(root, "staged"), true)
+		return err
+	}
+
+	require.NoError(t, stage("000000001-000000001.tsm", "../base/000000001-000000001.tsm"))
+
+	for _, name := range []string{"/etc/passwd", "../outside.tsm", "a/../../outside.tsm", "..", "./a.tsm"} {
+		err := stage(name, "../base/000000001-000000001.tsm")
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), "invalid backup file name", name)
+		}
+	}
+
+	for _, path := range []string{filepath.Join(root, "outside.tsm"), "../outside.tsm", "../base/../outside.tsm", "../base"} {
+		err := stage("000000001-000000001.tsm", path)
+		if assert.Error(t, err, path) {
+			assert.Contains(t, err.Error(), "invalid path", path)
+		}
+	}
+}
+
+func mustTempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "influx-restore-test")
+	require.NoError(t, err)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func mustReadBackupManifest(t *testing.T, dir string) *influxdb.BackupManifest {
+	t.Helper()
+	m, err := readBackupManifest(dir)
+	require.NoError(t, err)
+	return m
+}
+
+// addBackupFile adds the file at name in dir to the manifest in dir.
+func addBackupFile(t *testing.T, dir, name string, typ influxdb.BackupFileType) {
+	t.Helper()
+
+	m, err := readBackupManifest(dir)
+	if os.IsNotExist(err) {
+		m, err = &influxdb.BackupManifest{Version: influxdb.BackupManifestVersion}, nil
+	}
+	require.NoError(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	sum := sha256.Sum256(b)
+
+	m.Files = append(m.Files, influxdb.BackupManifestFile{
+		BackupFile: influxdb.BackupFile{Name: name, Size: int64(len(b))},
+		Type:       typ,
+		Path:       name,
+		Checksum:   hex.EncodeToString(sum[:]),
+	})
+	require.NoError(t, writeBackupManifest(dir, m))
+}
+
+// writeBackupKV writes a metadata database with a bucket of the given name to
+// the backup in dir and returns the ID of the bucket.
+func writeBackupKV(t *testing.T, dir, bucket string) influxdb.ID {
+	t.Helper()
+	ctx := context.Background()
+
+	store := bolt.NewKVStore(zap.NewNop(), filepath.Join(dir, bolt.DefaultFilename))
+	require.NoError(t, store.Open(ctx))
+
+	svc := kv.NewService(zap.NewNop(), store)
+	require.NoError(t, svc.Initialize(ctx))
+
+	org := &influxdb.Organization{Name: "backup"}
+	require.NoError(t, svc.CreateOrganization(ctx, org))
+	b := &influxdb.Bucket{OrgID: org.ID, Name: bucket}
+	require.NoError(t, svc.CreateBucket(ctx, b))
+	require.NoError(t, store.Close())
+
+	addBackupFile(t, dir, bolt.DefaultFilename, influxdb.BackupFileTypeKV)
+	return b.ID
+}
+
+// writeBackupTSM writes a TSM file with a cpu series to each of the buckets
+// in the backup in dir.
+func writeBackupTSM(t *testing.T, dir, name string, buckets map[influxdb.ID]influxdb.ID) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0777))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := tsm1.NewTSMWriter(f)
+	require.NoError(t, err)
+
+	var keys []string
+	for orgID, bucketID := range buckets {
+		n := tsdb.EncodeName(orgID, bucketID)
+		tags := models.NewTags(map[string]string{
+			models.MeasurementTagKey: "cpu",
+			"host":                   "a",
+			models.FieldKeyTagKey:    "value",
+		})
+		keys = append(keys, tsm1.SeriesFieldKey(string(models.MakeKey(n[:], tags)), "value"))
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		require.NoError(t, w.Write([]byte(k), tsm1.Values{tsm1.NewValue(10, 1.0), tsm1.NewValue(20, 2.0)}))
+	}
+	require.NoError(t, w.WriteIndex())
+	require.NoError(t, w.Close())
+
+	addBackupFile(t, dir, name, influxdb.BackupFileTypeTSM)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func cmdRule(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdRuleBuilder(newAlertHistorySVC, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdRuleBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn alertHistorySVCFn
+	now   func() time.Time
+
+	history alertHistoryFlags
+}
+
+func newCmdRuleBuilder(svcFn alertHistorySVCFn, opt genericCLIOpts) *cmdRuleBuilder {
+	return &cmdRuleBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcFn,
+		now:            time.Now,
+	}
+}
+
+func (b *cmdRuleBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("rule", nil)
+	cmd.Short = "Notification rule management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdHistory(),
+	)
+	return cmd
+}
+
+func (b *cmdRuleBuilder) cmdHistory() *cobra.Command {
+	cmd := b.newCmd("history", b.cmdHistoryRunEFn)
+	cmd.Short = "List the notifications logged by a notification rule, the most recent first"
+	b.history.register(cmd, "notification rule")
+	return cmd
+}
+
+func (b *cmdRuleBuilder) cmdHistoryRunEFn(cmd *cobra.Command, args []string) error {
+	id, filter, opts, err := b.history.filter("notification rule", b.now())
+	if err != nil {
+		return err
+	}
+	filter.RuleID = &id
+
+	svc, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	events, _, err := svc.FindNotificationEvents(context.Background(), filter, opts)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve notifications of notification rule %q: %v", id, err)
+	}
+
+	w := b.newTabWriter()
+	w.WriteHeaders("Time", "Level", "Check", "Endpoint", "Sent", "SilenceID", "Message", "Tags")
+	for _, e := range events {
+		var silenceID string
+		if e.SilenceID.Valid() {
+			silenceID = e.SilenceID.String()
+		}
+		// digests hold the messages of their statuses, one per line.
+		msg := strings.Replace(e.Message, "\n", "; ", -1)
+		w.Write(map[string]interface{}{
+			"Time":      e.Time.Format(time.RFC3339),
+			"Level":     e.Level,
+			"Check":     e.CheckName,
+			"Endpoint":  e.EndpointName,
+			"Sent":      e.Sent,
+			"SilenceID": silenceID,
+			"Message":   msg,
+			"Tags":      formatHistoryTags(e.Tags),
+		})
+	}
+	w.Flush()
+
+	return nil
+}
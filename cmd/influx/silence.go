@@ -0,0 +1,416 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/task/options"
+	"github.com/spf13/cobra"
+)
+
+type silenceSVCsFn func() (influxdb.SilenceService, influxdb.OrganizationService, error)
+
+func cmdSilence(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdSilenceBuilder(newSilenceSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdSilenceBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn silenceSVCsFn
+	now   func() time.Time
+
+	id          string
+	name        string
+	description string
+	start       string
+	end         string
+	every       string
+	until       string
+	tagRules    []string
+	checkIDs    []string
+	ruleIDs     []string
+	active      bool
+	org         organization
+}
+
+func newCmdSilenceBuilder(svcsFn silenceSVCsFn, opt genericCLIOpts) *cmdSilenceBuilder {
+	return &cmdSilenceBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+		now:            time.Now,
+	}
+}
+
+func (b *cmdSilenceBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("silence", nil)
+	cmd.Short = "Notification silence management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdFind(),
+		b.cmdUpdate(),
+	)
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) registerWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "Description of the silence")
+	cmd.Flags().StringVar(&b.start, "start", "", "Start of the silence window as an RFC3339 time; defaults to now")
+	cmd.Flags().StringVar(&b.end, "end", "", "End of the silence window as an RFC3339 time or a duration after its start, such as 2h")
+	cmd.Flags().StringVar(&b.every, "every", "", "Repeat the silence window, such as 1w for a weekly maintenance; 0s removes the repetition")
+	cmd.Flags().StringVar(&b.until, "until", "", "End of the repetition of the silence window as an RFC3339 time")
+	cmd.Flags().StringArrayVar(&b.tagRules, "tag-rule", nil, "Tag rule the silenced statuses must match, as key=value, key!=value, key=~regex or key!~regex")
+	cmd.Flags().StringSliceVar(&b.checkIDs, "check-id", nil, "ID of a check whose statuses are silenced")
+	cmd.Flags().StringSliceVar(&b.ruleIDs, "rule-id", nil, "ID of a notification rule whose notifications are silenced")
+}
+
+func (b *cmdSilenceBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn)
+	cmd.Short = "Create notification silence"
+
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "Name of the silence (required)")
+	cmd.MarkFlagRequired("name")
+	b.registerWindowFlags(cmd)
+	cmd.MarkFlagRequired("end")
+	b.org.register(cmd, false)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdCreateRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+
+	silSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	s := &influxdb.Silence{
+		Name:        b.name,
+		Description: b.description,
+		StartTime:   b.now().UTC(),
+	}
+	if s.OrgID, err = b.org.getID(orgSVC); err != nil {
+		return err
+	}
+	if b.start != "" {
+		if s.StartTime, err = parseSilenceTime("start", b.start); err != nil {
+			return err
+		}
+	}
+	if s.EndTime, err = parseSilenceEnd(s.StartTime, b.end); err != nil {
+		return err
+	}
+	if b.every != "" {
+		if s.Every, err = parseSilenceEvery(b.every); err != nil {
+			return err
+		}
+	}
+	if b.until != "" {
+		until, err := parseSilenceTime("until", b.until)
+		if err != nil {
+			return err
+		}
+		s.Until = &until
+	}
+	if s.TagRules, err = parseSilenceTagRules(b.tagRules); err != nil {
+		return err
+	}
+	if s.CheckIDs, err = parseSilenceIDs("check", b.checkIDs); err != nil {
+		return err
+	}
+	if s.RuleIDs, err = parseSilenceIDs("notification rule", b.ruleIDs); err != nil {
+		return err
+	}
+
+	if err := silSVC.CreateSilence(context.Background(), s, 0); err != nil {
+		return fmt.Errorf("failed to create silence: %v", err)
+	}
+
+	return b.printSilences(s)
+}
+
+func (b *cmdSilenceBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn)
+	cmd.Short = "Delete notification silence"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The silence ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode silence id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	s, err := silSVC.FindSilenceByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find silence with id %q: %v", id, err)
+	}
+	if err := silSVC.DeleteSilence(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete silence with id %q: %v", id, err)
+	}
+
+	return b.printSilences(s)
+}
+
+func (b *cmdSilenceBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn)
+	cmd.Short = "List notification silences"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().BoolVar(&b.active, "active", false, "Only list the silences active now")
+	b.org.register(cmd, false)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	if err := b.org.validOrgFlags(b.globalFlags); err != nil {
+		return err
+	}
+
+	silSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+	filter := influxdb.SilenceFilter{OrgID: &orgID}
+	if b.active {
+		now := b.now().UTC()
+		filter.ActiveAt = &now
+	}
+
+	ss, _, err := silSVC.FindSilences(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve silences: %v", err)
+	}
+
+	return b.printSilences(ss...)
+}
+
+func (b *cmdSilenceBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn)
+	cmd.Short = "Update notification silence"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The silence ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "New name of the silence")
+	b.registerWindowFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode silence id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	flags := cmd.Flags()
+	var upd influxdb.SilenceUpdate
+	if flags.Changed("name") {
+		upd.Name = &b.name
+	}
+	if flags.Changed("description") {
+		upd.Description = &b.description
+	}
+	if flags.Changed("start") {
+		start, err := parseSilenceTime("start", b.start)
+		if err != nil {
+			return err
+		}
+		upd.StartTime = &start
+	}
+	if flags.Changed("end") {
+		start := upd.StartTime
+		if start == nil {
+			// a relative end is relative to the current start of the silence.
+			s, err := silSVC.FindSilenceByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to find silence with id %q: %v", id, err)
+			}
+			start = &s.StartTime
+		}
+		end, err := parseSilenceEnd(*start, b.end)
+		if err != nil {
+			return err
+		}
+		upd.EndTime = &end
+	}
+	if flags.Changed("every") {
+		if upd.Every, err = parseSilenceEvery(b.every); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("until") {
+		until, err := parseSilenceTime("until", b.until)
+		if err != nil {
+			return err
+		}
+		upd.Until = &until
+	}
+	if flags.Changed("tag-rule") {
+		rules, err := parseSilenceTagRules(b.tagRules)
+		if err != nil {
+			return err
+		}
+		upd.TagRules = &rules
+	}
+	if flags.Changed("check-id") {
+		ids, err := parseSilenceIDs("check", b.checkIDs)
+		if err != nil {
+			return err
+		}
+		upd.CheckIDs = &ids
+	}
+	if flags.Changed("rule-id") {
+		ids, err := parseSilenceIDs("notification rule", b.ruleIDs)
+		if err != nil {
+			return err
+		}
+		upd.RuleIDs = &ids
+	}
+
+	s, err := silSVC.UpdateSilence(ctx, id, upd)
+	if err != nil {
+		return fmt.Errorf("failed to update silence with id %q: %v", id, err)
+	}
+
+	return b.printSilences(s)
+}
+
+func (b *cmdSilenceBuilder) printSilences(ss ...*influxdb.Silence) error {
+	w := b.newTabWriter()
+	w.WriteHeaders("ID", "Name", "Start", "End", "Every", "Until", "OrganizationID")
+	for _, s := range ss {
+		var every, until string
+		if s.Every != nil {
+			every = s.Every.String()
+		}
+		if s.Until != nil {
+			until = s.Until.Format(time.RFC3339)
+		}
+		w.Write(map[string]interface{}{
+			"ID":             s.ID.String(),
+			"Name":           s.Name,
+			"Start":          s.StartTime.Format(time.RFC3339),
+			"End":            s.EndTime.Format(time.RFC3339),
+			"Every":          every,
+			"Until":          until,
+			"OrganizationID": s.OrgID.String(),
+		})
+	}
+	w.Flush()
+
+	return nil
+}
+
+func parseSilenceTime(flag, v string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s time %q, expected an RFC3339 time: %v", flag, v, err)
+	}
+	return t, nil
+}
+
+// parseSilenceEnd parses the end of a silence window, either as a time or as
+// a duration after the start of the window.
+func parseSilenceEnd(start time.Time, v string) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return start.Add(d), nil
+	}
+	return parseSilenceTime("end", v)
+}
+
+func parseSilenceEvery(v string) (*options.Duration, error) {
+	var every options.Duration
+	if err := every.Parse(v); err != nil {
+		return nil, fmt.Errorf("invalid repetition %q: %v", v, err)
+	}
+	return &every, nil
+}
+
+// parseSilenceTagRules parses tag rules in the key=value, key!=value,
+// key=~regex and key!~regex forms.
+func parseSilenceTagRules(rules []string) ([]influxdb.TagRule, error) {
+	var trs []influxdb.TagRule
+	for _, r := range rules {
+		i := strings.IndexAny(r, "=!")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid tag rule %q, expected key=value, key!=value, key=~regex or key!~regex", r)
+		}
+		key, rest := r[:i], r[i:]
+
+		var op influxdb.Operator
+		switch {
+		case strings.HasPrefix(rest, "=~"):
+			op, rest = influxdb.RegexEqual, rest[2:]
+		case strings.HasPrefix(rest, "!~"):
+			op, rest = influxdb.NotRegexEqual, rest[2:]
+		case strings.HasPrefix(rest, "!="):
+			op, rest = influxdb.NotEqual, rest[2:]
+		case strings.HasPrefix(rest, "="):
+			op, rest = influxdb.Equal, rest[1:]
+		default:
+			return nil, fmt.Errorf("invalid tag rule %q, expected key=value, key!=value, key=~regex or key!~regex", r)
+		}
+		trs = append(trs, influxdb.TagRule{
+			Tag:      influxdb.Tag{Key: key, Value: rest},
+			Operator: op,
+		})
+	}
+	return trs, nil
+}
+
+func parseSilenceIDs(resource string, vs []string) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	for _, v := range vs {
+		id, err := influxdb.IDFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s id %q: %v", resource, v, err)
+		}
+		ids = append(ids, *id)
+	}
+	return ids, nil
+}
+
+func newSilenceSVCs() (influxdb.SilenceService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	orgSvc := &http.OrganizationService{Client: httpClient}
+
+	return &http.SilenceService{Client: httpClient}, orgSvc, nil
+}
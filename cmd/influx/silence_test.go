@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/options"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdSilence(t *testing.T) {
+	orgID := influxdb.ID(9000)
+	now := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+
+	fakeSVCFn := func(svc influxdb.SilenceService) silenceSVCsFn {
+		return func() (influxdb.SilenceService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	execute := func(t *testing.T, svc influxdb.SilenceService, args ...string) error {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+			b := newCmdSilenceBuilder(fakeSVCFn(svc), opt)
+			b.globalFlags = f
+			b.now = func() time.Time { return now }
+			return b.cmd()
+		})
+		cmd.SetArgs(append([]string{"silence"}, args...))
+		return cmd.Execute()
+	}
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.Silence
+		}{
+			{
+				name:  "relative end",
+				flags: []string{"--name=deploy", "--end=2h", "--org-id=" + orgID.String()},
+				expected: influxdb.Silence{
+					OrgID:     orgID,
+					Name:      "deploy",
+					StartTime: now,
+					EndTime:   now.Add(2 * time.Hour),
+				},
+			},
+			{
+				name: "weekly maintenance",
+				flags: []string{
+					"--name=maintenance",
+					"--description=weekly",
+					"--start=2020-03-07T02:00:00Z",
+					"--end=2020-03-07T04:00:00Z",
+					"--every=1w",
+					"--tag-rule=host=~^web-",
+					"--tag-rule=env!=dev",
+					"--check-id=" + influxdb.ID(3).String(),
+					"--org-id=" + orgID.String(),
+				},
+				expected: influxdb.Silence{
+					OrgID:       orgID,
+					Name:        "maintenance",
+					Description: "weekly",
+					StartTime:   time.Date(2020, time.March, 7, 2, 0, 0, 0, time.UTC),
+					EndTime:     time.Date(2020, time.March, 7, 4, 0, 0, 0, time.UTC),
+					Every:       options.MustParseDuration("1w"),
+					TagRules: []influxdb.TagRule{
+						{Tag: influxdb.Tag{Key: "host", Value: "^web-"}, Operator: influxdb.RegexEqual},
+						{Tag: influxdb.Tag{Key: "env", Value: "dev"}, Operator: influxdb.NotEqual},
+					},
+					CheckIDs: []influxdb.ID{3},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				svc := mock.NewSilenceService()
+				var got *influxdb.Silence
+				svc.CreateSilenceFn = func(ctx context.Context, s *influxdb.Silence, userID influxdb.ID) error {
+					got = s
+					return nil
+				}
+
+				require.NoError(t, execute(t, svc, append([]string{"create"}, tt.flags...)...))
+				require.NotNil(t, got)
+				assert.Equal(t, tt.expected, *got)
+			}
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("create with invalid tag rule", func(t *testing.T) {
+		svc := mock.NewSilenceService()
+		err := execute(t, svc, "create", "--name=deploy", "--end=2h", "--tag-rule=host", "--org-id="+orgID.String())
+		require.Error(t, err)
+	})
+
+	t.Run("list active", func(t *testing.T) {
+		svc := mock.NewSilenceService()
+		var filter influxdb.SilenceFilter
+		svc.FindSilencesFn = func(ctx context.Context, f influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+			filter = f
+			return nil, 0, nil
+		}
+
+		require.NoError(t, execute(t, svc, "list", "--active", "--org-id="+orgID.String()))
+		require.NotNil(t, filter.OrgID)
+		assert.Equal(t, orgID, *filter.OrgID)
+		require.NotNil(t, filter.ActiveAt)
+		assert.Equal(t, now, *filter.ActiveAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		start := time.Date(2020, time.March, 7, 2, 0, 0, 0, time.UTC)
+		svc := mock.NewSilenceService()
+		svc.FindSilenceByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+			return &influxdb.Silence{ID: id, OrgID: orgID, StartTime: start}, nil
+		}
+		var upd influxdb.SilenceUpdate
+		svc.UpdateSilenceFn = func(ctx context.Context, id influxdb.ID, u influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+			upd = u
+			s := &influxdb.Silence{ID: id, OrgID: orgID, StartTime: start}
+			u.Apply(s)
+			return s, nil
+		}
+
+		require.NoError(t, execute(t, svc, "update", "--id="+influxdb.ID(1).String(), "--end=3h", "--every=0s"))
+		assert.Nil(t, upd.Name)
+		require.NotNil(t, upd.EndTime)
+		assert.Equal(t, start.Add(3*time.Hour), *upd.EndTime)
+		require.NotNil(t, upd.Every)
+		assert.True(t, upd.Every.IsZero())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc := mock.NewSilenceService()
+		svc.FindSilenceByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+			return &influxdb.Silence{ID: id, OrgID: orgID}, nil
+		}
+		var deleted influxdb.ID
+		svc.DeleteSilenceFn = func(ctx context.Context, id influxdb.ID) error {
+			deleted = id
+			return nil
+		}
+
+		require.NoError(t, execute(t, svc, "delete", "--id="+influxdb.ID(1).String()))
+		assert.Equal(t, influxdb.ID(1), deleted)
+	})
+}
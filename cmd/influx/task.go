@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/andreyvit/diff"
 	"github.com/influxdata/flux/repl"
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/cmd/influx/internal"
@@ -29,6 +31,8 @@ func cmdTask(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 	cmd.AddCommand(
 		taskLogCmd(opt),
 		taskRunCmd(opt),
+		taskBackfillCmd(opt),
+		taskRevisionCmd(opt),
 		taskCreateCmd(opt),
 		taskDeleteCmd(opt),
 		taskFindCmd(opt),
@@ -39,7 +43,8 @@ func cmdTask(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 }
 
 var taskCreateFlags struct {
-	org organization
+	org       organization
+	dependsOn []string
 }
 
 func taskCreateCmd(opt genericCLIOpts) *cobra.Command {
@@ -48,6 +53,7 @@ func taskCreateCmd(opt genericCLIOpts) *cobra.Command {
 	cmd.Short = "Create task"
 
 	taskCreateFlags.org.register(cmd, false)
+	cmd.Flags().StringSliceVar(&taskCreateFlags.dependsOn, "depends-on", nil, "IDs of the upstream tasks the task runs after")
 
 	return cmd
 }
@@ -72,9 +78,15 @@ func taskCreateF(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error parsing flux script: %s", err)
 	}
 
+	dependsOn, err := decodeTaskIDs(taskCreateFlags.dependsOn)
+	if err != nil {
+		return err
+	}
+
 	tc := influxdb.TaskCreate{
 		Flux:         flux,
 		Organization: taskCreateFlags.org.name,
+		DependsOn:    dependsOn,
 	}
 	if taskCreateFlags.org.id != "" || taskCreateFlags.org.name != "" {
 		svc, err := newOrganizationService()
@@ -229,9 +241,23 @@ func taskFindF(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// decodeTaskIDs decodes the task IDs of a flag.
+func decodeTaskIDs(ids []string) ([]influxdb.ID, error) {
+	decoded := make([]influxdb.ID, 0, len(ids))
+	for _, s := range ids {
+		var id influxdb.ID
+		if err := id.DecodeFromString(s); err != nil {
+			return nil, fmt.Errorf("invalid task ID %q: %v", s, err)
+		}
+		decoded = append(decoded, id)
+	}
+	return decoded, nil
+}
+
 var taskUpdateFlags struct {
-	id     string
-	status string
+	id        string
+	status    string
+	dependsOn []string
 }
 
 func taskUpdateCmd(opt genericCLIOpts) *cobra.Command {
@@ -240,6 +266,7 @@ func taskUpdateCmd(opt genericCLIOpts) *cobra.Command {
 
 	cmd.Flags().StringVarP(&taskUpdateFlags.id, "id", "i", "", "task ID (required)")
 	cmd.Flags().StringVarP(&taskUpdateFlags.status, "status", "", "", "update task status")
+	cmd.Flags().StringSliceVar(&taskUpdateFlags.dependsOn, "depends-on", nil, "replace the IDs of the upstream tasks the task runs after")
 	cmd.MarkFlagRequired("id")
 
 	return cmd
@@ -265,6 +292,13 @@ func taskUpdateF(cmd *cobra.Command, args []string) error {
 	if taskUpdateFlags.status != "" {
 		update.Status = &taskUpdateFlags.status
 	}
+	if cmd.Flags().Changed("depends-on") {
+		dependsOn, err := decodeTaskIDs(taskUpdateFlags.dependsOn)
+		if err != nil {
+			return err
+		}
+		update.DependsOn = &dependsOn
+	}
 
 	if len(args) > 0 {
 		flux, err := repl.LoadQuery(args[0])
@@ -534,6 +568,8 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 		"StartedAt",
 		"FinishedAt",
 		"RequestedAt",
+		"Attempt",
+		"RetryOf",
 	)
 
 	for _, r := range runs {
@@ -542,6 +578,16 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 		finishedAt := r.FinishedAt.Format(time.RFC3339Nano)
 		requestedAt := r.RequestedAt.Format(time.RFC3339Nano)
 
+		// runs from before the attempts were recorded were first attempts.
+		attempt := r.Attempt
+		if attempt < 1 {
+			attempt = 1
+		}
+		var retryOf string
+		if r.RetryOf.Valid() {
+			retryOf = r.RetryOf.String()
+		}
+
 		w.Write(map[string]interface{}{
 			"ID":           r.ID,
 			"TaskID":       r.TaskID,
@@ -550,6 +596,8 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 			"StartedAt":    startedAt,
 			"FinishedAt":   finishedAt,
 			"RequestedAt":  requestedAt,
+			"Attempt":      attempt,
+			"RetryOf":      retryOf,
 		})
 	}
 	w.Flush()
@@ -602,3 +650,480 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func taskBackfillCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("backfill", nil)
+	cmd.Run = seeHelp
+	cmd.Short = "Run a task for every point of its schedule in a range of time"
+	cmd.AddCommand(
+		taskBackfillCreateCmd(opt),
+		taskBackfillFindCmd(opt),
+		taskBackfillCancelCmd(opt),
+	)
+
+	return cmd
+}
+
+var taskBackfillCreateFlags struct {
+	taskID         string
+	start          string
+	stop           string
+	maxConcurrency int
+}
+
+func taskBackfillCreateCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("create", taskBackfillCreateF)
+	cmd.Short = "Backfill a task from start, inclusive, to stop, exclusive"
+
+	cmd.Flags().StringVarP(&taskBackfillCreateFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskBackfillCreateFlags.start, "start", "", "", "start of the range, RFC3339 (required)")
+	cmd.Flags().StringVarP(&taskBackfillCreateFlags.stop, "stop", "", "", "stop of the range, RFC3339 (required)")
+	cmd.Flags().IntVarP(&taskBackfillCreateFlags.maxConcurrency, "max-concurrency", "", influxdb.DefaultBackfillConcurrency, "number of runs of the backfill run at the same time")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("stop")
+
+	return cmd
+}
+
+func taskBackfillCreateF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID influxdb.ID
+	if err := taskID.DecodeFromString(taskBackfillCreateFlags.taskID); err != nil {
+		return err
+	}
+
+	bc := influxdb.BackfillCreate{
+		MaxConcurrency: taskBackfillCreateFlags.maxConcurrency,
+	}
+	if bc.Start, err = time.Parse(time.RFC3339, taskBackfillCreateFlags.start); err != nil {
+		return fmt.Errorf("invalid start: %v", err)
+	}
+	if bc.Stop, err = time.Parse(time.RFC3339, taskBackfillCreateFlags.stop); err != nil {
+		return fmt.Errorf("invalid stop: %v", err)
+	}
+
+	b, err := s.BackfillTask(context.Background(), taskID, bc)
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(b)
+	return nil
+}
+
+var taskBackfillFindFlags struct {
+	taskID string
+	id     string
+}
+
+func taskBackfillFindCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("list", taskBackfillFindF)
+	cmd.Short = "List backfills for a task"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&taskBackfillFindFlags.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskBackfillFindFlags.id, "id", "i", "", "backfill id")
+	cmd.MarkFlagRequired("task-id")
+
+	return cmd
+}
+
+func taskBackfillFindF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID influxdb.ID
+	if err := taskID.DecodeFromString(taskBackfillFindFlags.taskID); err != nil {
+		return err
+	}
+
+	var backfills []*influxdb.Backfill
+	if taskBackfillFindFlags.id != "" {
+		var id influxdb.ID
+		if err := id.DecodeFromString(taskBackfillFindFlags.id); err != nil {
+			return err
+		}
+		b, err := s.FindBackfillByID(context.Background(), taskID, id)
+		if err != nil {
+			return err
+		}
+		backfills = append(backfills, b)
+	} else {
+		backfills, _, err = s.FindBackfills(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	writeBackfills(backfills...)
+	return nil
+}
+
+var taskBackfillCancelFlags struct {
+	taskID string
+	id     string
+}
+
+func taskBackfillCancelCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("cancel", taskBackfillCancelF)
+	cmd.Short = "Cancel a running backfill"
+
+	cmd.Flags().StringVarP(&taskBackfillCancelFlags.taskID, "task-id", "", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskBackfillCancelFlags.id, "id", "i", "", "backfill id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func taskBackfillCancelF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID, id influxdb.ID
+	if err := taskID.DecodeFromString(taskBackfillCancelFlags.taskID); err != nil {
+		return err
+	}
+	if err := id.DecodeFromString(taskBackfillCancelFlags.id); err != nil {
+		return err
+	}
+
+	b, err := s.CancelBackfill(context.Background(), taskID, id)
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(b)
+	return nil
+}
+
+func writeBackfills(backfills ...*influxdb.Backfill) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Start",
+		"Stop",
+		"Status",
+		"Total",
+		"Succeeded",
+		"Failed",
+	)
+	for _, b := range backfills {
+		w.Write(map[string]interface{}{
+			"ID":        b.ID,
+			"TaskID":    b.TaskID,
+			"Start":     b.Start.Format(time.RFC3339),
+			"Stop":      b.Stop.Format(time.RFC3339),
+			"Status":    b.Status,
+			"Total":     b.Total,
+			"Succeeded": b.Succeeded,
+			"Failed":    b.Failed,
+		})
+	}
+	w.Flush()
+}
+
+func taskRevisionCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("revision", nil)
+	cmd.Run = seeHelp
+	cmd.Short = "Inspect and roll back to previous revisions of a task"
+	cmd.AddCommand(
+		taskRevisionFindCmd(opt),
+		taskRevisionDiffCmd(opt),
+		taskRevisionRollbackCmd(opt),
+	)
+
+	return cmd
+}
+
+var taskRevisionFindFlags struct {
+	taskID   string
+	revision int
+}
+
+func taskRevisionFindCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("list", taskRevisionFindF)
+	cmd.Short = "List revisions for a task"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&taskRevisionFindFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().IntVarP(&taskRevisionFindFlags.revision, "revision", "r", 0, "revision number")
+	cmd.MarkFlagRequired("task-id")
+
+	return cmd
+}
+
+func taskRevisionFindF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID influxdb.ID
+	if err := taskID.DecodeFromString(taskRevisionFindFlags.taskID); err != nil {
+		return err
+	}
+
+	var revisions []*influxdb.TaskRevision
+	if taskRevisionFindFlags.revision != 0 {
+		r, err := s.FindTaskRevision(context.Background(), taskID, taskRevisionFindFlags.revision)
+		if err != nil {
+			return err
+		}
+		revisions = append(revisions, r)
+	} else {
+		revisions, _, err = s.FindTaskRevisions(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"TaskID",
+		"Revision",
+		"Name",
+		"Status",
+		"Every",
+		"Cron",
+		"AuthorID",
+		"CreatedAt",
+	)
+	for _, r := range revisions {
+		authorID := ""
+		if r.AuthorID.Valid() {
+			authorID = r.AuthorID.String()
+		}
+		w.Write(map[string]interface{}{
+			"TaskID":    r.TaskID,
+			"Revision":  r.Revision,
+			"Name":      r.Name,
+			"Status":    r.Status,
+			"Every":     r.Every,
+			"Cron":      r.Cron,
+			"AuthorID":  authorID,
+			"CreatedAt": r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	return nil
+}
+
+var taskRevisionDiffFlags struct {
+	taskID   string
+	revision int
+	to       int
+}
+
+func taskRevisionDiffCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("diff", taskRevisionDiffF)
+	cmd.Short = "Show the changes from a revision to another revision or to the current task"
+
+	cmd.Flags().StringVarP(&taskRevisionDiffFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().IntVarP(&taskRevisionDiffFlags.revision, "revision", "r", 0, "revision number (required)")
+	cmd.Flags().IntVarP(&taskRevisionDiffFlags.to, "to", "", 0, "revision number to compare with, defaults to the current task")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("revision")
+
+	return cmd
+}
+
+// taskVersion is the part of a task or of a revision that the diff of
+// revisions shows.
+type taskVersion struct {
+	title   string
+	options [][2]string
+	flux    string
+}
+
+func newRevisionVersion(r *influxdb.TaskRevision) taskVersion {
+	offset := ""
+	if r.Offset != 0 {
+		offset = r.Offset.String()
+	}
+	return taskVersion{
+		title: fmt.Sprintf("revision %d", r.Revision),
+		options: [][2]string{
+			{"name", r.Name},
+			{"description", r.Description},
+			{"status", r.Status},
+			{"every", r.Every},
+			{"cron", r.Cron},
+			{"location", r.Location},
+			{"offset", offset},
+			{"dependsOn", fmt.Sprint(r.DependsOn)},
+		},
+		flux: r.Flux,
+	}
+}
+
+func newTaskVersion(t *http.Task) (taskVersion, error) {
+	offset := ""
+	if t.Offset != "" {
+		d, err := time.ParseDuration(t.Offset)
+		if err != nil {
+			return taskVersion{}, err
+		}
+		offset = d.String()
+	}
+	return taskVersion{
+		title: fmt.Sprintf("current task (%s)", t.ID),
+		options: [][2]string{
+			{"name", t.Name},
+			{"description", t.Description},
+			{"status", t.Status},
+			{"every", t.Every},
+			{"cron", t.Cron},
+			{"location", t.Location},
+			{"offset", offset},
+			{"dependsOn", fmt.Sprint(t.DependsOn)},
+		},
+		flux: t.Flux,
+	}, nil
+}
+
+func taskRevisionDiffF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID influxdb.ID
+	if err := taskID.DecodeFromString(taskRevisionDiffFlags.taskID); err != nil {
+		return err
+	}
+
+	r, err := s.FindTaskRevision(context.Background(), taskID, taskRevisionDiffFlags.revision)
+	if err != nil {
+		return err
+	}
+	from := newRevisionVersion(r)
+
+	var to taskVersion
+	if taskRevisionDiffFlags.to != 0 {
+		r, err := s.FindTaskRevision(context.Background(), taskID, taskRevisionDiffFlags.to)
+		if err != nil {
+			return err
+		}
+		to = newRevisionVersion(r)
+	} else {
+		t, err := s.FindTaskByID(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+		if to, err = newTaskVersion(t); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("--- %s\n", from.title)
+	fmt.Printf("+++ %s\n", to.title)
+	for i, o := range from.options {
+		if o[1] != to.options[i][1] {
+			fmt.Printf("-%s: %s\n", o[0], o[1])
+			fmt.Printf("+%s: %s\n", o[0], to.options[i][1])
+		}
+	}
+	if from.flux != to.flux {
+		fmt.Println(strings.Join(diff.LineDiffAsLines(from.flux, to.flux), "\n"))
+	}
+	return nil
+}
+
+var taskRevisionRollbackFlags struct {
+	taskID        string
+	revision      int
+	restoreStatus bool
+}
+
+func taskRevisionRollbackCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("rollback", taskRevisionRollbackF)
+	cmd.Short = "Roll back a task to a revision"
+
+	cmd.Flags().StringVarP(&taskRevisionRollbackFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().IntVarP(&taskRevisionRollbackFlags.revision, "revision", "r", 0, "revision number (required)")
+	cmd.Flags().BoolVar(&taskRevisionRollbackFlags.restoreStatus, "restore-status", false, "restore the status of the task to the status of the revision")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("revision")
+
+	return cmd
+}
+
+func taskRevisionRollbackF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client:             client,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+
+	var taskID influxdb.ID
+	if err := taskID.DecodeFromString(taskRevisionRollbackFlags.taskID); err != nil {
+		return err
+	}
+
+	t, err := s.RollbackTask(context.Background(), taskID, taskRevisionRollbackFlags.revision, taskRevisionRollbackFlags.restoreStatus)
+	if err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Name",
+		"OrganizationID",
+		"Organization",
+		"Status",
+		"Every",
+		"Cron",
+	)
+	w.Write(map[string]interface{}{
+		"ID":             t.ID.String(),
+		"Name":           t.Name,
+		"OrganizationID": t.OrganizationID.String(),
+		"Organization":   t.Organization,
+		"Status":         t.Status,
+		"Every":          t.Every,
+		"Cron":           t.Cron,
+	})
+	w.Flush()
+	return nil
+}
@@ -209,19 +209,7 @@ func IndexTSMFile(index *tsi1.Index, path string, batchSize int, log *zap.Logger
 }
 
 func collectTSMFiles(path string) ([]string, error) {
-	fis, err := ioutil.ReadDir(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var paths []string
-	for _, fi := range fis {
-		if filepath.Ext(fi.Name()) != "."+tsm1.TSMFileExtension {
-			continue
-		}
-		paths = append(paths, filepath.Join(path, fi.Name()))
-	}
-	return paths, nil
+	return tsm1.TSMFiles(path)
 }
 
 func collectWALFiles(path string) ([]string, error) {
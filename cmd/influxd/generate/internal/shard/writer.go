@@ -172,7 +172,7 @@ func (w *Writer) closeTSM() {
 }
 
 func (w *Writer) readExisting() error {
-	files, err := filepath.Glob(filepath.Join(w.path, fmt.Sprintf("*.%s", tsm1.TSMFileExtension)))
+	files, err := tsm1.TSMFiles(w.path)
 	if err != nil {
 		return err
 	}
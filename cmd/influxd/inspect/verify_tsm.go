@@ -3,7 +3,6 @@ package inspect
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/influxdata/influxdb/kit/cli"
 	"github.com/influxdata/influxdb/tsdb/tsm1"
@@ -61,7 +60,7 @@ func verifyTSMF(cmd *cobra.Command, args []string) error {
 		}
 
 		if fi.IsDir() {
-			files, _ := filepath.Glob(filepath.Join(arg, "*."+tsm1.TSMFileExtension))
+			files, _ := tsm1.TSMFiles(arg)
 			verify.Paths = append(verify.Paths, files...)
 		} else {
 			verify.Paths = append(verify.Paths, arg)
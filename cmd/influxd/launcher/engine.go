@@ -30,6 +30,7 @@ type Engine interface {
 	storage.BucketDeleter
 	prom.PrometheusCollector
 	influxdb.BackupService
+	influxdb.CardinalityService
 
 	SeriesCardinality() int64
 
@@ -114,6 +115,11 @@ func (t *TemporaryEngine) SeriesCardinality() int64 {
 	return t.engine.SeriesCardinality()
 }
 
+// BucketCardinality returns the series cardinality of a bucket.
+func (t *TemporaryEngine) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+	return t.engine.BucketCardinality(ctx, orgID, bucketID, topN)
+}
+
 // DeleteBucketRangePredicate will delete a bucket from the range and predicate.
 func (t *TemporaryEngine) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
 	return t.engine.DeleteBucketRangePredicate(ctx, orgID, bucketID, min, max, pred)
@@ -171,6 +177,10 @@ func (t *TemporaryEngine) CreateBackup(ctx context.Context) (int, []string, erro
 	return t.engine.CreateBackup(ctx)
 }
 
+func (t *TemporaryEngine) CreateIncrementalBackup(ctx context.Context, exclude []influxdb.BackupFile) (int, []influxdb.BackupFile, error) {
+	return t.engine.CreateIncrementalBackup(ctx, exclude)
+}
+
 func (t *TemporaryEngine) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
 	return t.engine.FetchBackupFile(ctx, backupID, backupFile, w)
 }
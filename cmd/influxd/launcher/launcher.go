@@ -34,11 +34,16 @@ import (
 	"github.com/influxdata/influxdb/kv"
 	influxlogger "github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/nats"
+	"github.com/influxdata/influxdb/notification/escalation"
+	"github.com/influxdata/influxdb/notification/history"
+	"github.com/influxdata/influxdb/notification/incident"
 	"github.com/influxdata/influxdb/pkger"
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/query/control"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/incidents"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/silences"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/source"
 	"github.com/influxdata/influxdb/storage"
@@ -174,6 +179,18 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: filepath.Join(dir, "engine"),
 			Desc:    "path to persistent engine files",
 		},
+		{
+			DestP:   &l.StorageConfig.MaxSeriesPerBucket,
+			Flag:    "storage-max-series-per-bucket",
+			Default: 0,
+			Desc:    "maximum number of series of a bucket, points creating new series beyond it are rejected (0 for no limit)",
+		},
+		{
+			DestP:   &l.StorageConfig.MaxSeriesPerOrg,
+			Flag:    "storage-max-series-per-org",
+			Default: 0,
+			Desc:    "maximum number of series of an organization, points creating new series beyond it are rejected (0 for no limit)",
+		},
 		{
 			DestP:   &l.secretStore,
 			Flag:    "secret-store",
@@ -482,17 +499,20 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		SessionLength: time.Duration(m.sessionLength) * time.Minute,
 	}
 
+	var kvStore kv.Store
 	flushers := flushers{}
 	switch m.storeType {
 	case BoltStore:
 		store := bolt.NewKVStore(m.log.With(zap.String("service", "kvstore-bolt")), m.boltPath)
 		store.WithDB(m.boltClient.DB())
+		kvStore = store
 		m.kvService = kv.NewService(m.log.With(zap.String("store", "kv")), store, serviceConfig)
 		if m.testing {
 			flushers = append(flushers, store)
 		}
 	case MemoryStore:
 		store := inmem.NewKVStore()
+		kvStore = store
 		m.kvService = kv.NewService(m.log.With(zap.String("store", "kv")), store, serviceConfig)
 		if m.testing {
 			flushers = append(flushers, store)
@@ -508,6 +528,12 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	dbrpMappingSvc := kv.NewDBRPMappingService(kvStore)
+	if err := dbrpMappingSvc.Initialize(ctx); err != nil {
+		m.log.Error("Failed to initialize dbrp mapping service", zap.Error(err))
+		return err
+	}
+
 	m.reg = prom.NewRegistry(m.log.With(zap.String("service", "prom_registry")))
 	m.reg.MustRegister(
 		prometheus.NewGoCollector(),
@@ -537,6 +563,9 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		secretSvc                 platform.SecretService                   = m.kvService
 		lookupSvc                 platform.LookupService                   = m.kvService
 		notificationEndpointStore platform.NotificationEndpointService     = m.kvService
+		silenceSvc                platform.SilenceService                  = m.kvService
+		escalationSvc             platform.EscalationStore                 = m.kvService
+		incidentSvc               platform.IncidentStore                   = m.kvService
 	)
 
 	switch m.secretStore {
@@ -565,11 +594,11 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 	if m.testing {
 		// the testing engine will write/read into a temporary directory
-		engine := NewTemporaryEngine(m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc))
+		engine := NewTemporaryEngine(m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc), storage.WithSchemaEnforcement(bucketSvc))
 		flushers = append(flushers, engine)
 		m.engine = engine
 	} else {
-		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc))
+		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc), storage.WithSchemaEnforcement(bucketSvc))
 	}
 	m.engine.WithLogger(m.log)
 	if err := m.engine.Open(ctx); err != nil {
@@ -611,7 +640,11 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		MemoryBytesQuotaPerQuery: int64(memoryBytesQuotaPerQuery),
 		QueueSize:                QueueSize,
 		Logger:                   m.log.With(zap.String("service", "storage-reads")),
-		ExecutorDependencies:     []flux.Dependency{deps},
+		ExecutorDependencies: []flux.Dependency{
+			deps,
+			silences.Dependency{SilenceService: authorizer.NewSilenceService(silenceSvc)},
+			incidents.Dependency{IncidentService: authorizer.NewIncidentService(incidentSvc)},
+		},
 	})
 	if err != nil {
 		m.log.Error("Failed to create query controller", zap.Error(err))
@@ -657,10 +690,16 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		taskCoord := coordinator.NewCoordinator(
 			coordLogger,
 			sch,
-			executor)
+			executor,
+			coordinator.WithRunFinderOpt(combinedTaskService))
+		// the tasks that depend on other tasks are run when these succeed.
+		executor.SetRunSucceededFunc(taskCoord.RunSucceeded)
 
 		taskSvc = middleware.New(combinedTaskService, taskCoord)
 		m.taskControlService = combinedTaskService
+		if err := taskbackend.FailInterruptedBackfills(ctx, taskSvc, combinedTaskService, combinedTaskService, coordLogger); err != nil {
+			m.log.Error("Failed to fail interrupted backfills", zap.Error(err))
+		}
 		if err := taskbackend.TaskNotifyCoordinatorOfExisting(
 			ctx,
 			taskSvc,
@@ -670,6 +709,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 				_, err := executor.ResumeCurrentRun(ctx, taskID, runID)
 				return err
 			},
+			executor.ResumeRetry,
 			coordLogger); err != nil {
 			m.log.Error("Failed to resume existing tasks", zap.Error(err))
 		}
@@ -687,6 +727,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		notificationRuleSvc = middleware.NewNotificationRuleStore(m.kvService, m.kvService, coordinator)
 	}
 
+	var (
+		downsamplePolicySvc platform.DownsamplePolicyService
+		apiBucketSvc        platform.BucketService
+	)
+	{
+		coordinator := coordinator.NewCoordinator(m.log, m.scheduler, m.executor)
+		downsamplePolicySvc = middleware.NewDownsamplePolicyService(m.kvService, m.kvService, coordinator)
+		// Deleting a bucket deletes the tasks of its downsample policies.
+		apiBucketSvc = middleware.NewBucketService(bucketSvc, m.kvService, coordinator)
+	}
+
 	// NATS streaming server
 	natsOpts := nats.NewDefaultServerOptions()
 
@@ -765,6 +816,30 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		Addr: m.httpBindAddress,
 	}
 
+	historySvc := history.NewService(m.log.With(zap.String("service", "alert-history")), query.QueryServiceBridge{AsyncQueryService: m.queryController}, m.kvService, checkSvc)
+
+	escalationEvaluator := escalation.NewEvaluator(m.log.With(zap.String("service", "escalation")), escalationSvc, notificationRuleSvc, notificationEndpointStore, silenceSvc, incidentSvc, historySvc, bucketSvc, pointsWriter, endpoints.NewSender(secretSvc, nil), escalation.DefaultInterval)
+	m.wg.Add(1)
+	go func(log *zap.Logger) {
+		defer m.wg.Done()
+		log = log.With(zap.String("service", "escalation"))
+		if err := escalationEvaluator.Run(ctx); err != nil {
+			log.Error("Failed escalation service", zap.Error(err))
+		}
+		log.Info("Stopping")
+	}(m.log)
+
+	incidentTracker := incident.NewTracker(m.log.With(zap.String("service", "incident")), orgSvc, incidentSvc, historySvc, incident.DefaultInterval)
+	m.wg.Add(1)
+	go func(log *zap.Logger) {
+		defer m.wg.Done()
+		log = log.With(zap.String("service", "incident"))
+		if err := incidentTracker.Run(ctx); err != nil {
+			log.Error("Failed incident service", zap.Error(err))
+		}
+		log.Info("Stopping")
+	}(m.log)
+
 	m.apibackend = &http.APIBackend{
 		AssetsPath:           m.assetsPath,
 		HTTPErrorHandler:     kithttp.ErrorHandler(0),
@@ -774,11 +849,15 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
 		DeleteService:        deleteService,
+		ExportService:        readservice.NewExportService(readservice.NewStore(m.engine)),
+		CardinalityService:   m.engine,
 		BackupService:        backupService,
 		KVBackupService:      m.kvService,
 		AuthorizationService: authSvc,
 		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
-		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
+		BucketService:                   storage.NewBucketService(apiBucketSvc, m.engine),
+		DownsamplePolicyService:         downsamplePolicySvc,
+		DBRPMappingService:              dbrpMappingSvc,
 		SessionService:                  sessionSvc,
 		UserService:                     userSvc,
 		OrganizationService:             orgSvc,
@@ -799,10 +878,16 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		TelegrafService:                 telegrafSvc,
 		NotificationRuleStore:           notificationRuleSvc,
 		NotificationEndpointService:     endpoints.NewService(notificationEndpointStore, secretSvc, userResourceSvc, orgSvc),
+		NotificationEndpointTestService: endpoints.NewTester(notificationEndpointStore, secretSvc, nil),
 		CheckService:                    checkSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
 		SecretService:                   secretSvc,
+		SilenceService:                  silenceSvc,
+		EscalationPolicyService:         escalationSvc,
+		IncidentService:                 incidentSvc,
+		AlertHistoryService:             historySvc,
+		CheckDryRunService:              historySvc,
 		LookupService:                   lookupSvc,
 		DocumentService:                 m.kvService,
 		OrgLookupService:                m.kvService,
@@ -1,12 +1,17 @@
 package restore
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/cmd/influxd/inspect"
 	"github.com/influxdata/influxdb/http"
@@ -34,6 +39,10 @@ NOTES:
 
 * The influxd server should not be running when using the restore tool
   as it replaces all data and metadata.
+* Backups with a manifest, including incremental backups, restore the TSM
+  files, tombstones and WAL segments listed in it after verifying their
+  checksums. Use "influx restore" to restore a single organization or
+  bucket into a running server.
 `,
 	Args: cobra.ExactArgs(0),
 	RunE: restoreE,
@@ -126,9 +135,11 @@ func restoreE(cmd *cobra.Command, args []string) error {
 	if flags.rebuildTSI {
 		sFilePath := filepath.Join(flags.enginePath, storage.DefaultSeriesFileDirectoryName)
 		indexPath := filepath.Join(flags.enginePath, storage.DefaultIndexDirectoryName)
+		dataPath := filepath.Join(flags.enginePath, storage.DefaultEngineDirectoryName)
+		walPath := filepath.Join(flags.enginePath, storage.DefaultWALDirectoryName)
 
 		rebuild := inspect.NewBuildTSICommand()
-		rebuild.SetArgs([]string{"--sfile-path", sFilePath, "--tsi-path", indexPath})
+		rebuild.SetArgs([]string{"--sfile-path", sFilePath, "--tsi-path", indexPath, "--tsm-path", dataPath, "--wal-path", walPath})
 		rebuild.Execute()
 	}
 
@@ -231,11 +242,106 @@ func restoreBolt() error {
 }
 
 func restoreEngine() error {
-	dataDir := filepath.Join(flags.enginePath, "/data")
+	dataDir := filepath.Join(flags.enginePath, storage.DefaultEngineDirectoryName)
 	if err := os.MkdirAll(dataDir, 0777); err != nil {
 		return err
 	}
 
+	manifest, err := readManifest()
+	if os.IsNotExist(err) {
+		return restoreEngineFiles(dataDir)
+	} else if err != nil {
+		return fmt.Errorf("failed to read backup manifest: %v", err)
+	}
+
+	walDir := filepath.Join(flags.enginePath, storage.DefaultWALDirectoryName)
+	if err := os.MkdirAll(walDir, 0777); err != nil {
+		return err
+	}
+
+	var tsmCount, walCount int
+	for _, f := range manifest.Files {
+		var dir string
+		switch f.Type {
+		case influxdb.BackupFileTypeTSM, influxdb.BackupFileTypeTombstone:
+			dir = dataDir
+		case influxdb.BackupFileTypeWAL:
+			dir = walDir
+		default:
+			continue
+		}
+
+		// The file is restored under its name, which must not leave dir.
+		if name := path.Clean(f.Name); name != f.Name || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid backup file name %q", f.Name)
+		}
+		if err := restoreManifestFile(f, filepath.Join(dir, filepath.FromSlash(f.Name))); err != nil {
+			return err
+		}
+
+		switch f.Type {
+		case influxdb.BackupFileTypeTSM:
+			tsmCount++
+		case influxdb.BackupFileTypeWAL:
+			walCount++
+		}
+	}
+
+	fmt.Printf("Restored %d TSM files to %v\n", tsmCount, dataDir)
+	fmt.Printf("Restored %d WAL segments to %v\n", walCount, walDir)
+	return nil
+}
+
+// readManifest reads the manifest of the backup, if any.
+func readManifest() (*influxdb.BackupManifest, error) {
+	f, err := os.Open(filepath.Join(flags.backupPath, influxdb.BackupManifestFilename))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var m influxdb.BackupManifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return nil, err
+	}
+	if m.Version != influxdb.BackupManifestVersion {
+		return nil, fmt.Errorf("unsupported backup manifest version %d", m.Version)
+	}
+	return &m, nil
+}
+
+// restoreManifestFile copies a file of the manifest, which may belong to a
+// backup an incremental backup is based on, to target and verifies its checksum.
+func restoreManifestFile(mf influxdb.BackupManifestFile, target string) error {
+	f, err := os.Open(filepath.Join(flags.backupPath, mf.Path))
+	if err != nil {
+		return fmt.Errorf("error opening backup file %s: %v", mf.Name, err)
+	}
+	defer f.Close()
+
+	// The files of time partitions are restored to their directories.
+	if err := os.MkdirAll(filepath.Dir(target), 0777); err != nil {
+		return err
+	}
+	w, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), f); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != mf.Checksum {
+		return fmt.Errorf("checksum mismatch for backup file %s: expected %s, got %s", mf.Name, mf.Checksum, sum)
+	}
+	return w.Close()
+}
+
+// restoreEngineFiles restores the TSM files of a backup without a manifest.
+func restoreEngineFiles(dataDir string) error {
 	count := 0
 	err := filepath.Walk(flags.backupPath, func(path string, info os.FileInfo, err error) error {
 		if strings.Contains(path, ".tsm") {
@@ -245,7 +351,15 @@ func restoreEngine() error {
 			}
 			defer f.Close()
 
-			tsmPath := filepath.Join(dataDir, filepath.Base(path))
+			// The files of time partitions are restored to their directories.
+			rel, err := filepath.Rel(flags.backupPath, path)
+			if err != nil {
+				return err
+			}
+			tsmPath := filepath.Join(dataDir, rel)
+			if err := os.MkdirAll(filepath.Dir(tsmPath), 0777); err != nil {
+				return err
+			}
 			w, err := os.OpenFile(tsmPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
 			if err != nil {
 				return err
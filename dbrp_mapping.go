@@ -8,18 +8,19 @@ import (
 )
 
 // DBRPMappingService provides a mapping of cluster, database and retention policy to an organization ID and bucket ID.
+// The names of the mappings are unique within an organization, different organizations may map the same names.
 type DBRPMappingService interface {
-	// FindBy returns the dbrp mapping the for cluster, db and rp.
-	FindBy(ctx context.Context, cluster, db, rp string) (*DBRPMapping, error)
+	// FindBy returns the dbrp mapping the for cluster, db and rp of the organization.
+	FindBy(ctx context.Context, orgID ID, cluster, db, rp string) (*DBRPMapping, error)
 	// Find returns the first dbrp mapping the matches the filter.
 	Find(ctx context.Context, filter DBRPMappingFilter) (*DBRPMapping, error)
 	// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
 	FindMany(ctx context.Context, filter DBRPMappingFilter, opt ...FindOptions) ([]*DBRPMapping, int, error)
 	// Create creates a new dbrp mapping, if a different mapping exists an error is returned.
 	Create(ctx context.Context, dbrpMap *DBRPMapping) error
-	// Delete removes a dbrp mapping.
+	// Delete removes a dbrp mapping of the organization.
 	// Deleting a mapping that does not exists is not an error.
-	Delete(ctx context.Context, cluster, db, rp string) error
+	Delete(ctx context.Context, orgID ID, cluster, db, rp string) error
 }
 
 // DBRPMapping represents a mapping of a cluster, database and retention policy to an organization ID and bucket ID.
@@ -103,8 +104,9 @@ func (m *DBRPMapping) Equal(o *DBRPMapping) bool {
 		m.BucketID == o.BucketID
 }
 
-// DBRPMappingFilter represents a set of filters that restrict the returned results by cluster, database and retention policy.
+// DBRPMappingFilter represents a set of filters that restrict the returned results by organization, cluster, database and retention policy.
 type DBRPMappingFilter struct {
+	OrganizationID  *ID
 	Cluster         *string
 	Database        *string
 	RetentionPolicy *string
@@ -115,7 +117,13 @@ func (f DBRPMappingFilter) String() string {
 	var s strings.Builder
 	s.WriteString("{")
 
-	s.WriteString("cluster:")
+	s.WriteString("org:")
+	if f.OrganizationID != nil {
+		s.WriteString(f.OrganizationID.String())
+	} else {
+		s.WriteString("<nil>")
+	}
+	s.WriteString(" cluster:")
 	if f.Cluster != nil {
 		s.WriteString(*f.Cluster)
 	} else {
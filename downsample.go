@@ -0,0 +1,215 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// DownsampleTaskType is the type of the tasks that carry out downsampling policies.
+const DownsampleTaskType = "downsample"
+
+// Task metadata keys set on the task of a downsampling policy.
+const (
+	DownsampleTaskBucketIDKey = "bucketID"
+	DownsampleTaskPolicyIDKey = "downsamplePolicyID"
+)
+
+// ops for downsample policy errors.
+var (
+	OpFindDownsamplePolicyByID = "FindDownsamplePolicyByID"
+	OpFindDownsamplePolicies   = "FindDownsamplePolicies"
+	OpCreateDownsamplePolicy   = "CreateDownsamplePolicy"
+	OpUpdateDownsamplePolicy   = "UpdateDownsamplePolicy"
+	OpDeleteDownsamplePolicy   = "DeleteDownsamplePolicy"
+)
+
+// DownsamplePolicy periodically aggregates the data of a bucket into
+// another bucket. The policy belongs to its source bucket: it is carried out
+// by a task that the server manages for it, and it is deleted along with the
+// source or destination bucket.
+type DownsamplePolicy struct {
+	ID                  ID                   `json:"id,omitempty"`
+	OrgID               ID                   `json:"orgID,omitempty"`
+	BucketID            ID                   `json:"bucketID"`
+	DestinationBucketID ID                   `json:"destinationBucketID"`
+	Description         string               `json:"description,omitempty"`
+	Every               options.Duration     `json:"every"`
+	Aggregates          DownsampleAggregates `json:"aggregates"`
+	TagFilter           []Tag                `json:"tagFilter,omitempty"`
+	OwnerID             ID                   `json:"ownerID,omitempty"`
+	TaskID              ID                   `json:"taskID,omitempty"`
+	CRUDLog
+}
+
+// DownsampleAggregates are the aggregate functions applied to the fields of
+// each type. The fields of a type without an aggregate are not downsampled.
+type DownsampleAggregates struct {
+	Float    string `json:"float,omitempty"`
+	Integer  string `json:"integer,omitempty"`
+	Unsigned string `json:"unsigned,omitempty"`
+	String   string `json:"string,omitempty"`
+	Boolean  string `json:"boolean,omitempty"`
+}
+
+var (
+	numericAggregates  = []string{"count", "first", "last", "max", "mean", "median", "min", "spread", "stddev", "sum"}
+	selectorAggregates = []string{"count", "first", "last"}
+)
+
+// downsampleFieldType is a field type with the aggregates that can be
+// applied to it.
+type downsampleFieldType struct {
+	name       string
+	aggregate  string
+	aggregates []string
+}
+
+func (a DownsampleAggregates) fieldTypes() []downsampleFieldType {
+	return []downsampleFieldType{
+		{name: "float", aggregate: a.Float, aggregates: numericAggregates},
+		{name: "integer", aggregate: a.Integer, aggregates: numericAggregates},
+		{name: "unsigned", aggregate: a.Unsigned, aggregates: numericAggregates},
+		{name: "string", aggregate: a.String, aggregates: selectorAggregates},
+		{name: "boolean", aggregate: a.Boolean, aggregates: selectorAggregates},
+	}
+}
+
+// Valid returns an error if an aggregate cannot be applied to its field type
+// or if there is no aggregate at all.
+func (a DownsampleAggregates) Valid() error {
+	var n int
+	for _, ft := range a.fieldTypes() {
+		if ft.aggregate == "" {
+			continue
+		}
+		if !containsString(ft.aggregates, ft.aggregate) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("aggregate %q cannot be applied to %s fields, expected one of %v", ft.aggregate, ft.name, ft.aggregates),
+			}
+		}
+		n++
+	}
+	if n == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy requires an aggregate for at least one field type",
+		}
+	}
+	return nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid returns an error if the policy is invalid.
+func (p *DownsamplePolicy) Valid() error {
+	if !p.BucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy requires a source bucket",
+		}
+	}
+	if !p.DestinationBucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy requires a destination bucket",
+		}
+	}
+	if p.BucketID == p.DestinationBucketID {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy source and destination buckets must be different",
+		}
+	}
+	if p.Every.IsZero() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy requires a window",
+		}
+	}
+	if d, err := p.Every.DurationFrom(time.Unix(0, 0)); err != nil || d <= 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample policy window must be positive",
+			Err:  err,
+		}
+	}
+	for _, t := range p.TagFilter {
+		if t.Key == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "downsample policy tag filter requires a key",
+			}
+		}
+	}
+	return p.Aggregates.Valid()
+}
+
+// DownsamplePolicyService represents a service for managing the downsampling
+// policies of buckets.
+type DownsamplePolicyService interface {
+	// FindDownsamplePolicyByID returns a single downsample policy by ID.
+	FindDownsamplePolicyByID(ctx context.Context, id ID) (*DownsamplePolicy, error)
+
+	// FindDownsamplePolicies returns a list of downsample policies that match
+	// filter and the total count of matching policies.
+	FindDownsamplePolicies(ctx context.Context, filter DownsamplePolicyFilter) ([]*DownsamplePolicy, int, error)
+
+	// CreateDownsamplePolicy creates a new downsample policy, and the task
+	// that carries it out, and sets p.ID with the new identifier.
+	CreateDownsamplePolicy(ctx context.Context, p *DownsamplePolicy, userID ID) error
+
+	// UpdateDownsamplePolicy updates a single downsample policy and its task
+	// with changeset. Returns the new policy state after update.
+	UpdateDownsamplePolicy(ctx context.Context, id ID, upd DownsamplePolicyUpdate) (*DownsamplePolicy, error)
+
+	// DeleteDownsamplePolicy removes a downsample policy, and its task, by ID.
+	DeleteDownsamplePolicy(ctx context.Context, id ID) error
+}
+
+// DownsamplePolicyFilter represents a set of filters that restrict the
+// returned downsample policies.
+type DownsamplePolicyFilter struct {
+	OrgID *ID
+	// BucketID matches the policies whose source or destination is the bucket.
+	BucketID *ID
+}
+
+// DownsamplePolicyUpdate represents updates to a downsample policy.
+// Only fields which are set are updated.
+type DownsamplePolicyUpdate struct {
+	DestinationBucketID *ID                   `json:"destinationBucketID,omitempty"`
+	Description         *string               `json:"description,omitempty"`
+	Every               *options.Duration     `json:"every,omitempty"`
+	Aggregates          *DownsampleAggregates `json:"aggregates,omitempty"`
+	TagFilter           *[]Tag                `json:"tagFilter,omitempty"`
+}
+
+// Apply applies the update to the policy.
+func (u DownsamplePolicyUpdate) Apply(p *DownsamplePolicy) {
+	if u.DestinationBucketID != nil {
+		p.DestinationBucketID = *u.DestinationBucketID
+	}
+	if u.Description != nil {
+		p.Description = *u.Description
+	}
+	if u.Every != nil {
+		p.Every = *u.Every
+	}
+	if u.Aggregates != nil {
+		p.Aggregates = *u.Aggregates
+	}
+	if u.TagFilter != nil {
+		p.TagFilter = *u.TagFilter
+	}
+}
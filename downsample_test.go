@@ -0,0 +1,73 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestDownsamplePolicy_Valid(t *testing.T) {
+	valid := func() *influxdb.DownsamplePolicy {
+		return &influxdb.DownsamplePolicy{
+			BucketID:            2,
+			DestinationBucketID: 3,
+			Every:               *options.MustParseDuration("1h"),
+			Aggregates:          influxdb.DownsampleAggregates{Integer: "sum"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		update  func(p *influxdb.DownsamplePolicy)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(p *influxdb.DownsamplePolicy) {},
+		},
+		{
+			name:    "missing destination",
+			update:  func(p *influxdb.DownsamplePolicy) { p.DestinationBucketID = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "same source and destination",
+			update:  func(p *influxdb.DownsamplePolicy) { p.DestinationBucketID = p.BucketID },
+			wantErr: true,
+		},
+		{
+			name:    "missing window",
+			update:  func(p *influxdb.DownsamplePolicy) { p.Every = options.Duration{} },
+			wantErr: true,
+		},
+		{
+			name:    "no aggregates",
+			update:  func(p *influxdb.DownsamplePolicy) { p.Aggregates = influxdb.DownsampleAggregates{} },
+			wantErr: true,
+		},
+		{
+			name:    "numeric aggregate on strings",
+			update:  func(p *influxdb.DownsamplePolicy) { p.Aggregates.String = "mean" },
+			wantErr: true,
+		},
+		{
+			name:    "tag filter without key",
+			update:  func(p *influxdb.DownsamplePolicy) { p.TagFilter = []influxdb.Tag{{Value: "a"}} },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.update(p)
+			err := p.Valid()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,432 @@
+package endpoints
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	notificationflux "github.com/influxdata/influxdb/notification/flux"
+)
+
+// DefaultPagerDutyURL is the events API that PagerDuty notifications are sent to.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// maxResponseBody is the most of the response body of an endpoint that is
+// returned with the result of a notification.
+const maxResponseBody = 4 << 10
+
+// Sender sends notifications to notification endpoints from the server,
+// rather than from the flux of a notification rule. The notifications are
+// built from a record with the columns of a status, such as _check_name,
+// _level and _message, and of the notification, such as
+// _notification_rule_name. The secrets of the endpoints are resolved through
+// the secret service.
+type Sender struct {
+	secretSVC influxdb.SecretService
+	client    *http.Client
+
+	// PagerDutyURL is the events API that the notifications of PagerDuty
+	// endpoints are sent to.
+	PagerDutyURL string
+}
+
+// NewSender constructs a new Sender. The notifications are sent with client,
+// or with a client timing out after 10 seconds when it is nil.
+func NewSender(secretSVC influxdb.SecretService, client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{
+		secretSVC:    secretSVC,
+		client:       client,
+		PagerDutyURL: DefaultPagerDutyURL,
+	}
+}
+
+// Send sends the record r to the notification endpoint. It returns an error
+// when the endpoint can't be reached or does not accept the notification.
+func (s *Sender) Send(ctx context.Context, edp influxdb.NotificationEndpoint, r map[string]string) error {
+	result, err := s.send(ctx, edp, r)
+	if err != nil {
+		return err
+	}
+	if result.Sent {
+		return nil
+	}
+	msg := fmt.Sprintf("notification endpoint %q did not accept the notification", edp.GetName())
+	if result.StatusCode != 0 {
+		msg = fmt.Sprintf("notification endpoint %q responded with status %d", edp.GetName(), result.StatusCode)
+	}
+	return &influxdb.Error{
+		Code: influxdb.EUnavailable,
+		Msg:  msg,
+		Err:  fmt.Errorf("%s", result.Error),
+	}
+}
+
+// send sends the record r to the notification endpoint and returns how the
+// endpoint responded.
+func (s *Sender) send(ctx context.Context, edp influxdb.NotificationEndpoint, r map[string]string) (*influxdb.NotificationEndpointTestResult, error) {
+	if e, ok := edp.(*endpoint.SMTP); ok {
+		return s.sendMail(ctx, e, r)
+	}
+	req, err := s.newRequest(ctx, edp, r)
+	if err != nil {
+		return nil, err
+	}
+	return s.do(edp, req)
+}
+
+// resolve resolves the incident that the record r triggered on a PagerDuty
+// endpoint and returns how the endpoint responded.
+func (s *Sender) resolve(ctx context.Context, e *endpoint.PagerDuty, r map[string]string) (*influxdb.NotificationEndpointTestResult, error) {
+	req, err := s.newPagerDutyRequest(ctx, e, r, "resolve")
+	if err != nil {
+		return nil, err
+	}
+	return s.do(e, req)
+}
+
+// do sends the request to the notification endpoint and returns how the
+// endpoint responded.
+func (s *Sender) do(edp influxdb.NotificationEndpoint, req *http.Request) (*influxdb.NotificationEndpointTestResult, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &influxdb.NotificationEndpointTestResult{Error: err.Error()}, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, &influxdb.Error{
+			Msg: "failed to read the response of the endpoint",
+			Err: err,
+		}
+	}
+
+	result := &influxdb.NotificationEndpointTestResult{
+		Sent:       resp.StatusCode/100 == 2,
+		StatusCode: resp.StatusCode,
+	}
+	if !result.Sent {
+		result.Error = string(body)
+	} else if _, ok := edp.(*endpoint.Slack); ok {
+		// the slack API responds with errors in ok responses.
+		var slackResp struct {
+			OK    *bool  `json:"ok"`
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &slackResp) == nil && slackResp.OK != nil && !*slackResp.OK {
+			result.Sent = false
+			result.Error = string(body)
+		}
+	}
+	return result, nil
+}
+
+func (s *Sender) newRequest(ctx context.Context, edp influxdb.NotificationEndpoint, r map[string]string) (*http.Request, error) {
+	switch e := edp.(type) {
+	case *endpoint.HTTP:
+		return s.newHTTPRequest(ctx, e, r)
+	case *endpoint.Slack:
+		return s.newSlackRequest(ctx, e, r)
+	case *endpoint.PagerDuty:
+		return s.newPagerDutyRequest(ctx, e, r, "trigger")
+	default:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("notifications from the server are not supported by %s endpoints", edp.Type()),
+		}
+	}
+}
+
+func (s *Sender) loadSecret(ctx context.Context, edp influxdb.NotificationEndpoint, fld influxdb.SecretField) (string, error) {
+	v, err := s.secretSVC.LoadSecret(ctx, edp.GetOrgID(), fld.Key)
+	if err != nil {
+		return "", &influxdb.Error{
+			Code: influxdb.ErrorCode(err),
+			Msg:  fmt.Sprintf("failed to load secret %q of the endpoint", fld.Key),
+			Err:  err,
+		}
+	}
+	return v, nil
+}
+
+func (s *Sender) newHTTPRequest(ctx context.Context, e *endpoint.HTTP, r map[string]string) (*http.Request, error) {
+	method := e.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var body io.Reader
+	if method != http.MethodGet {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		if e.ContentTemplate != "" {
+			content, err := renderContentTemplate(ctx, e.ContentTemplate, r)
+			if err != nil {
+				return nil, err
+			}
+			b = []byte(content)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, e.URL, body)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid http endpoint request",
+			Err:  err,
+		}
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	names := make([]string, 0, len(e.Headers))
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		req.Header.Set(name, e.Headers[name])
+	}
+	for _, name := range e.SecretHeaderNames() {
+		v, err := s.loadSecret(ctx, e, e.SecretHeaders[name])
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(name, v)
+	}
+
+	switch e.AuthMethod {
+	case "bearer":
+		token, err := s.loadSecret(ctx, e, e.Token)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "basic":
+		username, err := s.loadSecret(ctx, e, e.Username)
+		if err != nil {
+			return nil, err
+		}
+		password, err := s.loadSecret(ctx, e, e.Password)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(username, password)
+	}
+	return req, nil
+}
+
+func (s *Sender) newSlackRequest(ctx context.Context, e *endpoint.Slack, r map[string]string) (*http.Request, error) {
+	data := map[string]interface{}{
+		"text": r["_message"],
+		"attachments": []map[string]interface{}{{
+			"color":     slackColor(r["_level"]),
+			"text":      r["_message"],
+			"mrkdwn_in": []string{"text"},
+		}},
+		"as_user": false,
+	}
+	req, err := newJSONRequest(ctx, e.URL, data)
+	if err != nil {
+		return nil, err
+	}
+	if e.Token.Key != "" {
+		token, err := s.loadSecret(ctx, e, e.Token)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// newPagerDutyRequest returns the request of the event with action, trigger
+// or resolve, of the incident of the record r.
+func (s *Sender) newPagerDutyRequest(ctx context.Context, e *endpoint.PagerDuty, r map[string]string, action string) (*http.Request, error) {
+	routingKey, err := s.loadSecret(ctx, e, e.RoutingKey)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": action,
+		"dedup_key":    dedupKey(r),
+	}
+	if action == "trigger" {
+		data["client"] = "influxdata"
+		data["client_url"] = e.ClientURL
+		data["payload"] = map[string]interface{}{
+			"summary":   r["_message"],
+			"timestamp": r["_time"],
+			"source":    r["_notification_rule_name"],
+			"severity":  pagerDutySeverity(r["_level"]),
+			"group":     r["_source_measurement"],
+			"class":     r["_check_name"],
+		}
+	}
+	req, err := newJSONRequest(ctx, s.PagerDutyURL, data)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	return req, nil
+}
+
+func newJSONRequest(ctx context.Context, url string, data interface{}) (*http.Request, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid endpoint request",
+			Err:  err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(ctx), nil
+}
+
+func slackColor(level string) string {
+	switch strings.ToLower(level) {
+	case "crit":
+		return "danger"
+	case "warn":
+		return "warning"
+	default:
+		return "good"
+	}
+}
+
+// pagerDutySeverity maps the levels like pagerduty.severityFromLevel.
+func pagerDutySeverity(level string) string {
+	switch strings.ToLower(level) {
+	case "crit":
+		return "critical"
+	case "warn":
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// dedupKey identifies the record regardless of when it was sent and at which
+// level, so that pagerduty groups the notifications of the same statuses.
+func dedupKey(r map[string]string) string {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		switch k {
+		case "_time", "_level", "_message", "_source_timestamp":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\n", k, r[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// renderContentTemplate interpolates the fields of the record r in the flux
+// string template of an http endpoint, the way the notification rules do for
+// the fields of the statuses. The template is evaluated by flux, so that it
+// renders the same as in the notification rules.
+func renderContentTemplate(ctx context.Context, template string, r map[string]string) (string, error) {
+	record := make(map[string]values.Value, len(r))
+	for k, v := range r {
+		record[k] = values.NewString(v)
+	}
+	script := "content = " + ast.Format(notificationflux.String(template))
+	_, scope, err := flux.Eval(ctx, script, func(scope values.Scope) {
+		scope.Set("r", values.NewObjectWithValues(record))
+	})
+	if err != nil {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to render the content template of the http endpoint",
+			Err:  err,
+		}
+	}
+	content, ok := scope.Lookup("content")
+	if !ok || content.Type().Nature() != semantic.String {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "the content template of the http endpoint is not a string",
+		}
+	}
+	return content.Str(), nil
+}
+
+// sendMail sends the record r as a mail through the smtp endpoint, with the
+// sendMail function of the flux smtp package used by the notification rules.
+// The endpoints have no recipients, so the mail is sent to the from address
+// of the endpoint.
+func (s *Sender) sendMail(ctx context.Context, e *endpoint.SMTP, r map[string]string) (*influxdb.NotificationEndpointTestResult, error) {
+	password := ""
+	if e.Password.Key != "" {
+		var err error
+		if password, err = s.loadSecret(ctx, e, e.Password); err != nil {
+			return nil, err
+		}
+	}
+	mail := values.NewObjectWithValues(map[string]values.Value{
+		"host":     values.NewString(e.Host),
+		"port":     values.NewInt(int64(e.Port)),
+		"tls":      values.NewString(e.TLSMode),
+		"username": values.NewString(e.Username),
+		"password": values.NewString(password),
+		"from":     values.NewString(e.From),
+		"to":       values.NewString(e.From),
+		"subject":  values.NewString(fmt.Sprintf("%s is %s", r["_check_name"], r["_level"])),
+		"body":     values.NewString(r["_message"]),
+	})
+
+	ctx = flux.NewDefaultDependencies().Inject(ctx)
+	_, _, err := flux.Eval(ctx, sendMailScript, func(scope values.Scope) {
+		scope.Set("mail", mail)
+	})
+	if err != nil {
+		return &influxdb.NotificationEndpointTestResult{Error: err.Error()}, nil
+	}
+	return &influxdb.NotificationEndpointTestResult{Sent: true}, nil
+}
+
+const sendMailScript = `import "influxdata/influxdb/smtp"
+
+sent = smtp.sendMail(
+    host: mail.host,
+    port: mail.port,
+    tls: mail.tls,
+    username: mail.username,
+    password: mail.password,
+    from: mail.from,
+    to: mail.to,
+    subject: mail.subject,
+    body: mail.body,
+)
+`
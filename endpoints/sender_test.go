@@ -0,0 +1,102 @@
+package endpoints_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/endpoints"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	id, orgID := influxdb.ID(1), influxdb.ID(2)
+	secretSVC := mock.NewSecretService()
+	secretSVC.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+		return "abc", nil
+	}
+	status := map[string]string{
+		"_check_id":               "0000000000000003",
+		"_check_name":             "cpu",
+		"_level":                  "crit",
+		"_message":                "cpu is crit",
+		"_notification_rule_name": "cpu rule",
+		"_time":                   "2020-03-02T22:00:00Z",
+		"host":                    "web-1",
+	}
+
+	t.Run("pagerduty severity and dedup key", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusAccepted, "")
+		defer srv.Close()
+
+		sender := endpoints.NewSender(secretSVC, nil)
+		sender.PagerDutyURL = srv.URL
+		edp := &endpoint.PagerDuty{
+			Base:       endpoint.Base{ID: &id, OrgID: &orgID, Name: "pager"},
+			RoutingKey: influxdb.SecretField{Key: "routing-key"},
+		}
+		require.NoError(t, sender.Send(context.Background(), edp, status))
+
+		warn := make(map[string]string)
+		for k, v := range status {
+			warn[k] = v
+		}
+		warn["_level"], warn["_time"] = "warn", "2020-03-02T22:01:00Z"
+		require.NoError(t, sender.Send(context.Background(), edp, warn))
+
+		require.Len(t, *reqs, 2)
+		var events [2]struct {
+			DedupKey string `json:"dedup_key"`
+			Payload  struct {
+				Severity string `json:"severity"`
+			} `json:"payload"`
+		}
+		for i := range events {
+			require.NoError(t, json.Unmarshal([]byte((*reqs)[i].body), &events[i]))
+		}
+		assert.Equal(t, "critical", events[0].Payload.Severity)
+		assert.Equal(t, "warning", events[1].Payload.Severity)
+		assert.NotEmpty(t, events[0].DedupKey)
+		assert.Equal(t, events[0].DedupKey, events[1].DedupKey)
+	})
+
+	t.Run("http content template", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusOK, "")
+		defer srv.Close()
+
+		sender := endpoints.NewSender(secretSVC, nil)
+		edp := &endpoint.HTTP{
+			Base:            endpoint.Base{ID: &id, OrgID: &orgID, Name: "hook"},
+			URL:             srv.URL,
+			Method:          http.MethodPost,
+			AuthMethod:      "none",
+			ContentTemplate: `{"check": "${r._check_name + r.host}", "level": "${string(v: r._level)}"}`,
+		}
+		require.NoError(t, sender.Send(context.Background(), edp, status))
+		require.Len(t, *reqs, 1)
+		assert.Equal(t, `{"check": "cpuweb-1", "level": "crit"}`, (*reqs)[0].body)
+
+		edp.ContentTemplate = "${r.host"
+		err := sender.Send(context.Background(), edp, status)
+		assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+		assert.Len(t, *reqs, 1)
+	})
+
+	t.Run("rejected notification", func(t *testing.T) {
+		srv, _ := newStandIn(t, http.StatusInternalServerError, "boom")
+		defer srv.Close()
+
+		sender := endpoints.NewSender(secretSVC, nil)
+		edp := &endpoint.Slack{Base: endpoint.Base{ID: &id, OrgID: &orgID, Name: "ops"}, URL: srv.URL}
+		err := sender.Send(context.Background(), edp, status)
+		require.Error(t, err)
+		assert.Equal(t, influxdb.EUnavailable, influxdb.ErrorCode(err))
+		assert.Contains(t, err.Error(), "500")
+	})
+}
@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+)
+
+const testNotificationName = "Test notification"
+
+var _ influxdb.NotificationEndpointTestService = (*Tester)(nil)
+
+// Tester sends test notifications to notification endpoints, the way the
+// notification rules would.
+type Tester struct {
+	*Sender
+	endpointStore influxdb.NotificationEndpointService
+	now           func() time.Time
+}
+
+// NewTester constructs a new Tester. The test notifications are sent with
+// client, or with a client timing out after 10 seconds when it is nil.
+func NewTester(store influxdb.NotificationEndpointService, secretSVC influxdb.SecretService, client *http.Client) *Tester {
+	return &Tester{
+		Sender:        NewSender(secretSVC, client),
+		endpointStore: store,
+		now:           time.Now,
+	}
+}
+
+// TestNotificationEndpoint sends a synthetic notification to the notification
+// endpoint with id and returns how the endpoint responded. The incident
+// triggered on a PagerDuty endpoint is resolved right away, so that it does
+// not page anyone.
+func (t *Tester) TestNotificationEndpoint(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+	edp, err := t.endpointStore.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r := t.testRecord(edp)
+	result, err := t.send(ctx, edp, r)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpTestNotificationEndpoint,
+			Err: err,
+		}
+	}
+	if e, ok := edp.(*endpoint.PagerDuty); ok && result.Sent {
+		resolved, err := t.resolve(ctx, e, r)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Op:  influxdb.OpTestNotificationEndpoint,
+				Err: err,
+			}
+		}
+		if !resolved.Sent {
+			resolved.Error = fmt.Sprintf("the test incident was triggered but could not be resolved: %s", resolved.Error)
+			return resolved, nil
+		}
+	}
+	return result, nil
+}
+
+// testRecord returns the fields of the synthetic status notified to edp.
+func (t *Tester) testRecord(edp influxdb.NotificationEndpoint) map[string]string {
+	return map[string]string{
+		"_check_name":                 testNotificationName,
+		"_level":                      "ok",
+		"_message":                    fmt.Sprintf("This is a test notification from InfluxDB to the notification endpoint %s.", edp.GetName()),
+		"_notification_endpoint_id":   edp.GetID().String(),
+		"_notification_endpoint_name": edp.GetName(),
+		"_notification_rule_name":     testNotificationName,
+		"_time":                       t.now().UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,343 @@
+package endpoints_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/endpoints"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	method string
+	header http.Header
+	body   string
+}
+
+// newStandIn returns a server responding with status and body, and the
+// requests it received.
+func newStandIn(t *testing.T, status int, body string) (*httptest.Server, *[]recordedRequest) {
+	var reqs []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		reqs = append(reqs, recordedRequest{method: r.Method, header: r.Header, body: string(b)})
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	return srv, &reqs
+}
+
+// smtpStandIn is an SMTP server accepting any mail, and recording the
+// commands and the mail it received.
+type smtpStandIn struct {
+	net.Listener
+	mu   sync.Mutex
+	cmds []string
+	mail strings.Builder
+	done chan struct{}
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpStandIn{Listener: l, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(textproto.NewConn(conn))
+	}()
+	return s
+}
+
+func (s *smtpStandIn) serve(c *textproto.Conn) {
+	c.PrintfLine("220 localhost ready")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.cmds = append(s.cmds, line)
+		s.mu.Unlock()
+		switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+		case "EHLO":
+			c.PrintfLine("250-localhost\r\n250 AUTH PLAIN")
+		case "AUTH":
+			c.PrintfLine("235 Authenticated")
+		case "DATA":
+			c.PrintfLine("354 Go ahead")
+			b, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.mail.Write(b)
+			s.mu.Unlock()
+			c.PrintfLine("250 OK")
+		case "QUIT":
+			c.PrintfLine("221 Bye")
+			return
+		default:
+			c.PrintfLine("250 OK")
+		}
+	}
+}
+
+// Close stops the server once the connection it accepted is closed.
+func (s *smtpStandIn) Close() error {
+	err := s.Listener.Close()
+	<-s.done
+	return err
+}
+
+func (s *smtpStandIn) commands() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var cmds []string
+	for _, cmd := range s.cmds {
+		if !strings.HasPrefix(cmd, "EHLO") {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+func (s *smtpStandIn) data() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mail.String()
+}
+
+func newTester(edp influxdb.NotificationEndpoint, secrets map[string]string) *endpoints.Tester {
+	store := mock.NewNotificationEndpointService()
+	store.FindNotificationEndpointByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+		if id != edp.GetID() {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "notification endpoint not found"}
+		}
+		return edp, nil
+	}
+	secretSVC := mock.NewSecretService()
+	secretSVC.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+		v, ok := secrets[k]
+		if !ok {
+			return "", &influxdb.Error{Code: influxdb.ENotFound, Msg: "secret not found"}
+		}
+		return v, nil
+	}
+	return endpoints.NewTester(store, secretSVC, nil)
+}
+
+func TestTester_TestNotificationEndpoint(t *testing.T) {
+	id, orgID := influxdb.ID(1), influxdb.ID(2)
+	base := endpoint.Base{ID: &id, OrgID: &orgID, Name: "ops", Status: influxdb.Active}
+
+	t.Run("http endpoint with secrets and template", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusNoContent, "")
+		defer srv.Close()
+
+		tester := newTester(&endpoint.HTTP{
+			Base:            base,
+			URL:             srv.URL,
+			Method:          http.MethodPut,
+			AuthMethod:      "basic",
+			Username:        influxdb.SecretField{Key: "user-key"},
+			Password:        influxdb.SecretField{Key: "pass-key"},
+			Headers:         map[string]string{"X-Source": "influxdb"},
+			SecretHeaders:   map[string]influxdb.SecretField{"X-Api-Key": {Key: "api-key"}},
+			ContentTemplate: `{"text": "${r._message}", "level": "${ string(v: r._level) }"}`,
+		}, map[string]string{"user-key": "u", "pass-key": "p", "api-key": "s3cr3t"})
+
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, &influxdb.NotificationEndpointTestResult{Sent: true, StatusCode: http.StatusNoContent}, result)
+
+		require.Len(t, *reqs, 1)
+		req := (*reqs)[0]
+		assert.Equal(t, http.MethodPut, req.method)
+		assert.Equal(t, "influxdb", req.header.Get("X-Source"))
+		assert.Equal(t, "s3cr3t", req.header.Get("X-Api-Key"))
+		assert.Equal(t, "Basic dTpw", req.header.Get("Authorization"))
+		assert.Equal(t, `{"text": "This is a test notification from InfluxDB to the notification endpoint ops.", "level": "ok"}`, req.body)
+	})
+
+	t.Run("http endpoint rejecting the notification", func(t *testing.T) {
+		srv, _ := newStandIn(t, http.StatusUnauthorized, "bad credentials")
+		defer srv.Close()
+
+		tester := newTester(&endpoint.HTTP{Base: base, URL: srv.URL, Method: http.MethodPost, AuthMethod: "none"}, nil)
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, &influxdb.NotificationEndpointTestResult{StatusCode: http.StatusUnauthorized, Error: "bad credentials"}, result)
+	})
+
+	t.Run("unreachable endpoint", func(t *testing.T) {
+		srv, _ := newStandIn(t, http.StatusOK, "")
+		srv.Close()
+
+		tester := newTester(&endpoint.HTTP{Base: base, URL: srv.URL, Method: http.MethodPost, AuthMethod: "none"}, nil)
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.False(t, result.Sent)
+		assert.Zero(t, result.StatusCode)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		tester := newTester(&endpoint.HTTP{
+			Base:       base,
+			URL:        "http://localhost",
+			Method:     http.MethodPost,
+			AuthMethod: "bearer",
+			Token:      influxdb.SecretField{Key: "token-key"},
+		}, nil)
+		_, err := tester.TestNotificationEndpoint(context.Background(), id)
+		assert.Equal(t, influxdb.ENotFound, influxdb.ErrorCode(err))
+	})
+
+	t.Run("slack endpoint", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusOK, `{"ok": false, "error": "channel_not_found"}`)
+		defer srv.Close()
+
+		tester := newTester(&endpoint.Slack{
+			Base:  base,
+			URL:   srv.URL,
+			Token: influxdb.SecretField{Key: "slack-key"},
+		}, map[string]string{"slack-key": "xoxb"})
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, &influxdb.NotificationEndpointTestResult{
+			StatusCode: http.StatusOK,
+			Error:      `{"ok": false, "error": "channel_not_found"}`,
+		}, result)
+
+		require.Len(t, *reqs, 1)
+		assert.Equal(t, "Bearer xoxb", (*reqs)[0].header.Get("Authorization"))
+	})
+
+	t.Run("pagerduty endpoint", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusAccepted, `{"status": "success"}`)
+		defer srv.Close()
+
+		tester := newTester(&endpoint.PagerDuty{
+			Base:       base,
+			ClientURL:  "http://localhost:9999",
+			RoutingKey: influxdb.SecretField{Key: "routing-key"},
+		}, map[string]string{"routing-key": "abc"})
+		tester.PagerDutyURL = srv.URL
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.True(t, result.Sent)
+
+		require.Len(t, *reqs, 2)
+		var events [2]struct {
+			RoutingKey  string `json:"routing_key"`
+			EventAction string `json:"event_action"`
+			DedupKey    string `json:"dedup_key"`
+			ClientURL   string `json:"client_url"`
+			Payload     *struct {
+				Severity  string    `json:"severity"`
+				Timestamp time.Time `json:"timestamp"`
+			} `json:"payload"`
+		}
+		for i := range events {
+			require.NoError(t, json.Unmarshal([]byte((*reqs)[i].body), &events[i]))
+		}
+		trigger, resolve := events[0], events[1]
+		assert.Equal(t, "abc", trigger.RoutingKey)
+		assert.Equal(t, "trigger", trigger.EventAction)
+		assert.Equal(t, "http://localhost:9999", trigger.ClientURL)
+		require.NotNil(t, trigger.Payload)
+		assert.Equal(t, "info", trigger.Payload.Severity)
+		assert.False(t, trigger.Payload.Timestamp.IsZero())
+		assert.Equal(t, "abc", resolve.RoutingKey)
+		assert.Equal(t, "resolve", resolve.EventAction)
+		assert.NotEmpty(t, trigger.DedupKey)
+		assert.Equal(t, trigger.DedupKey, resolve.DedupKey)
+		assert.Nil(t, resolve.Payload)
+	})
+
+	t.Run("pagerduty endpoint rejecting the trigger", func(t *testing.T) {
+		srv, reqs := newStandIn(t, http.StatusBadRequest, `{"status": "invalid event"}`)
+		defer srv.Close()
+
+		tester := newTester(&endpoint.PagerDuty{
+			Base:       base,
+			RoutingKey: influxdb.SecretField{Key: "routing-key"},
+		}, map[string]string{"routing-key": "abc"})
+		tester.PagerDutyURL = srv.URL
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.False(t, result.Sent)
+		assert.Len(t, *reqs, 1)
+	})
+
+	t.Run("smtp endpoint", func(t *testing.T) {
+		srv := newSMTPStandIn(t)
+		defer srv.Close()
+
+		tester := newTester(&endpoint.SMTP{
+			Base:     base,
+			Host:     "127.0.0.1",
+			Port:     srv.Addr().(*net.TCPAddr).Port,
+			TLSMode:  endpoint.SMTPTLSNone,
+			Username: "alerts",
+			Password: influxdb.SecretField{Key: "smtp-key"},
+			From:     "alerts@example.com",
+		}, map[string]string{"smtp-key": "s3cr3t"})
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, &influxdb.NotificationEndpointTestResult{Sent: true}, result)
+
+		srv.Close()
+		assert.Equal(t, []string{
+			"AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00alerts\x00s3cr3t")),
+			"MAIL FROM:<alerts@example.com>",
+			"RCPT TO:<alerts@example.com>",
+			"DATA",
+			"QUIT",
+		}, srv.commands())
+		assert.Contains(t, srv.data(), "Subject: Test notification is ok")
+	})
+
+	t.Run("unreachable smtp endpoint", func(t *testing.T) {
+		srv := newSMTPStandIn(t)
+		srv.Close()
+
+		tester := newTester(&endpoint.SMTP{
+			Base:    base,
+			Host:    "127.0.0.1",
+			Port:    srv.Addr().(*net.TCPAddr).Port,
+			TLSMode: endpoint.SMTPTLSNone,
+			From:    "alerts@example.com",
+		}, nil)
+		result, err := tester.TestNotificationEndpoint(context.Background(), id)
+		require.NoError(t, err)
+		assert.False(t, result.Sent)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		tester := newTester(&endpoint.HTTP{Base: base}, nil)
+		_, err := tester.TestNotificationEndpoint(context.Background(), influxdb.ID(3))
+		assert.Equal(t, influxdb.ENotFound, influxdb.ErrorCode(err))
+	})
+}
@@ -25,6 +25,7 @@ const (
 	EUnauthorized        = "unauthorized"
 	EMethodNotAllowed    = "method not allowed"
 	ETooLarge            = "request too large"
+	ESeriesLimitExceeded = "series limit exceeded" // write would create series beyond the limits
 )
 
 // Error is the error struct of platform.
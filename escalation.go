@@ -0,0 +1,230 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// ops for escalation policy errors.
+var (
+	OpFindEscalationPolicyByID = "FindEscalationPolicyByID"
+	OpFindEscalationPolicies   = "FindEscalationPolicies"
+	OpCreateEscalationPolicy   = "CreateEscalationPolicy"
+	OpUpdateEscalationPolicy   = "UpdateEscalationPolicy"
+	OpDeleteEscalationPolicy   = "DeleteEscalationPolicy"
+	OpFindEscalations          = "FindEscalations"
+	OpAcknowledgeEscalation    = "AcknowledgeEscalation"
+)
+
+// EscalationPolicy escalates the statuses matched by a notification rule to
+// more endpoints the longer they persist, such as notifying slack at warn,
+// paging pagerduty when still crit after 15 minutes and paging a manager
+// after 45 minutes.
+type EscalationPolicy struct {
+	ID          ID     `json:"id,omitempty"`
+	OrgID       ID     `json:"orgID"`
+	RuleID      ID     `json:"ruleID"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Steps are ordered by their delays.
+	Steps   []EscalationStep `json:"steps"`
+	OwnerID ID               `json:"ownerID,omitempty"`
+	CRUDLog
+}
+
+// EscalationStep notifies an endpoint once a status has persisted at or above
+// a level for a delay.
+type EscalationStep struct {
+	// Delay is how long the status must have persisted, zero notifies the
+	// endpoint as soon as the status is seen.
+	Delay options.Duration `json:"delay"`
+	// Level is the least severe level the status must have persisted at,
+	// any level other than ok when empty.
+	Level      string `json:"level,omitempty"`
+	EndpointID ID     `json:"endpointID"`
+}
+
+// statusLevels ranks the levels of the statuses by severity.
+var statusLevels = map[string]int{
+	"ok":   0,
+	"info": 1,
+	"warn": 2,
+	"crit": 3,
+}
+
+// StatusLevelRank returns the severity of the level of a status, the
+// unknown levels ranking below ok.
+func StatusLevelRank(level string) int {
+	if r, ok := statusLevels[strings.ToLower(level)]; ok {
+		return r
+	}
+	return -1
+}
+
+// Valid returns an error if the escalation policy is invalid.
+func (p *EscalationPolicy) Valid() error {
+	if p.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "escalation policy name is empty",
+		}
+	}
+	if !p.RuleID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "escalation policy requires a notification rule",
+		}
+	}
+	if len(p.Steps) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "escalation policy requires at least one step",
+		}
+	}
+	var prior time.Duration
+	for i, s := range p.Steps {
+		if !s.EndpointID.Valid() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("escalation step %d requires a notification endpoint", i+1),
+			}
+		}
+		if s.Level != "" && StatusLevelRank(s.Level) <= 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("escalation step %d has invalid level %q, expected one of info, warn or crit", i+1, s.Level),
+			}
+		}
+		delay, err := s.Delay.DurationFrom(time.Time{})
+		if len(s.Delay.Node.Values) == 0 || err != nil || delay < 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("escalation step %d requires a delay, such as 0s or 15m", i+1),
+				Err:  err,
+			}
+		}
+		if delay < prior {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "escalation steps must be ordered by their delays",
+			}
+		}
+		prior = delay
+	}
+	return nil
+}
+
+// Escalation is the state of the escalation of the statuses of a check with
+// the same tags. It starts when a status other than ok is seen and ends when
+// the check returns to ok.
+type Escalation struct {
+	PolicyID ID `json:"policyID"`
+	// Key identifies the check and the tags of the escalated statuses.
+	Key       string            `json:"key"`
+	CheckID   ID                `json:"checkID"`
+	CheckName string            `json:"checkName,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	// Level is the level of the latest status.
+	Level string `json:"level"`
+	// Since is the time of the first status of the escalation.
+	Since time.Time `json:"since"`
+	// NotifiedSteps are the indexes of the steps notified so far.
+	NotifiedSteps []int `json:"notifiedSteps"`
+	// AcknowledgedAt stops the escalation when set: no further step is
+	// notified until the check returns to ok.
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy ID         `json:"acknowledgedBy,omitempty"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// Notified reports whether the step with index i was notified.
+func (e *Escalation) Notified(i int) bool {
+	for _, n := range e.NotifiedSteps {
+		if n == i {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusKey returns the key identifying the statuses of the check with the
+// tags, such as the statuses escalated together.
+func StatusKey(checkID ID, tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, statusKeyEscaper.Replace(k)+"="+statusKeyEscaper.Replace(v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(append([]string{checkID.String()}, pairs...), ",")
+}
+
+var statusKeyEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`)
+
+// EscalationPolicyService represents a service for managing the escalation
+// policies of the notification rules and the state of their escalations.
+type EscalationPolicyService interface {
+	// FindEscalationPolicyByID returns a single escalation policy by ID.
+	FindEscalationPolicyByID(ctx context.Context, id ID) (*EscalationPolicy, error)
+	// FindEscalationPolicies returns a list of escalation policies that match
+	// filter and the total count of matching escalation policies.
+	FindEscalationPolicies(ctx context.Context, filter EscalationPolicyFilter) ([]*EscalationPolicy, int, error)
+	// CreateEscalationPolicy creates a new escalation policy and sets p.ID
+	// with the new identifier.
+	CreateEscalationPolicy(ctx context.Context, p *EscalationPolicy, userID ID) error
+	// UpdateEscalationPolicy updates a single escalation policy with
+	// changeset. Returns the new escalation policy state after update.
+	UpdateEscalationPolicy(ctx context.Context, id ID, upd EscalationPolicyUpdate) (*EscalationPolicy, error)
+	// DeleteEscalationPolicy removes an escalation policy and its
+	// escalations by ID.
+	DeleteEscalationPolicy(ctx context.Context, id ID) error
+	// FindEscalations returns the ongoing escalations of the escalation
+	// policy.
+	FindEscalations(ctx context.Context, policyID ID) ([]*Escalation, error)
+	// AcknowledgeEscalation stops the escalation with key of the escalation
+	// policy until its check returns to ok.
+	AcknowledgeEscalation(ctx context.Context, policyID ID, key string, userID ID) (*Escalation, error)
+}
+
+// EscalationStore is the storage of the escalations, updated by the
+// evaluation of the escalation policies.
+type EscalationStore interface {
+	EscalationPolicyService
+	// PutEscalation creates or replaces an escalation.
+	PutEscalation(ctx context.Context, e *Escalation) error
+	// DeleteEscalation removes the escalation with key of the escalation
+	// policy.
+	DeleteEscalation(ctx context.Context, policyID ID, key string) error
+}
+
+// EscalationPolicyFilter represents a set of filters that restrict the
+// returned escalation policies.
+type EscalationPolicyFilter struct {
+	OrgID  *ID
+	RuleID *ID
+}
+
+// EscalationPolicyUpdate represents updates to an escalation policy.
+// Only fields which are set are updated.
+type EscalationPolicyUpdate struct {
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Steps       *[]EscalationStep `json:"steps,omitempty"`
+}
+
+// Apply applies the update to the escalation policy.
+func (u EscalationPolicyUpdate) Apply(p *EscalationPolicy) {
+	if u.Name != nil {
+		p.Name = *u.Name
+	}
+	if u.Description != nil {
+		p.Description = *u.Description
+	}
+	if u.Steps != nil {
+		p.Steps = *u.Steps
+	}
+}
@@ -0,0 +1,56 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestEscalationPolicy_Valid(t *testing.T) {
+	step := func(delay, level string) influxdb.EscalationStep {
+		s := influxdb.EscalationStep{Level: level, EndpointID: 2}
+		if delay != "" {
+			s.Delay = *options.MustParseDuration(delay)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		steps []influxdb.EscalationStep
+		valid bool
+	}{
+		{name: "ordered steps", steps: []influxdb.EscalationStep{step("0s", "warn"), step("15m", "crit"), step("45m", "crit")}, valid: true},
+		{name: "same delays", steps: []influxdb.EscalationStep{step("0s", ""), step("0s", "crit")}, valid: true},
+		{name: "no steps"},
+		{name: "unordered steps", steps: []influxdb.EscalationStep{step("15m", "crit"), step("0s", "warn")}},
+		{name: "missing delay", steps: []influxdb.EscalationStep{step("", "warn")}},
+		{name: "negative delay", steps: []influxdb.EscalationStep{step("-5m", "warn")}},
+		{name: "ok level", steps: []influxdb.EscalationStep{step("0s", "ok")}},
+		{name: "missing endpoint", steps: []influxdb.EscalationStep{{Delay: *options.MustParseDuration("0s")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &influxdb.EscalationPolicy{Name: "cpu", RuleID: 1, Steps: tt.steps}
+			err := p.Valid()
+			if tt.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.valid && influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Errorf("expected an invalid error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStatusKey(t *testing.T) {
+	a := influxdb.StatusKey(1, map[string]string{"host": "web-1", "env": "prod"})
+	if exp := "0000000000000001,env=prod,host=web-1"; a != exp {
+		t.Errorf("expected key %q, got %q", exp, a)
+	}
+	b := influxdb.StatusKey(1, map[string]string{"host": "web-1,env=prod"})
+	if a == b {
+		t.Errorf("keys of different tags are the same: %q", a)
+	}
+}
@@ -0,0 +1,45 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+)
+
+// ExportFormat is the encoding of exported data.
+type ExportFormat string
+
+// Formats of exported data.
+const (
+	ExportFormatLineProtocol ExportFormat = "lp"
+	ExportFormatCSV          ExportFormat = "csv"
+)
+
+// Valid returns an error if the format is not supported.
+func (f ExportFormat) Valid() error {
+	switch f {
+	case ExportFormatLineProtocol, ExportFormatCSV:
+		return nil
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "export format must be one of " + string(ExportFormatLineProtocol) + " or " + string(ExportFormatCSV),
+		}
+	}
+}
+
+// ExportFilter selects the data of a bucket to export.
+type ExportFilter struct {
+	OrgID    ID
+	BucketID ID
+	// Start and Stop bound the time of the exported points to [Start, Stop).
+	Start int64
+	Stop  int64
+	// Predicate filters the series to export, using the syntax of delete predicates.
+	Predicate string
+}
+
+// ExportService exports the data of a bucket.
+type ExportService interface {
+	// ExportBucket streams the data of a bucket matching the filter to w in the given format.
+	ExportBucket(ctx context.Context, filter ExportFilter, format ExportFormat, w io.Writer) error
+}
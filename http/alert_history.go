@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/pkg/httpc"
+)
+
+var _ influxdb.AlertHistoryService = (*AlertHistoryService)(nil)
+
+type checkStatusesResponse struct {
+	Links    *influxdb.PagingLinks   `json:"links"`
+	Statuses []*influxdb.CheckStatus `json:"statuses"`
+}
+
+type notificationEventsResponse struct {
+	Links         *influxdb.PagingLinks         `json:"links"`
+	Notifications []*influxdb.NotificationEvent `json:"notifications"`
+}
+
+// decodeAlertHistoryFilter decodes the time range, level and tag filters of
+// the statuses and notifications, and their paging options.
+func decodeAlertHistoryFilter(r *http.Request) (*influxdb.AlertHistoryFilter, *influxdb.FindOptions, error) {
+	f := &influxdb.AlertHistoryFilter{}
+	qp := r.URL.Query()
+
+	if start := qp.Get("start"); start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "start is invalid, expected an RFC3339 time",
+				Err:  err,
+			}
+		}
+		f.Start = t
+	}
+	if stop := qp.Get("stop"); stop != "" {
+		t, err := time.Parse(time.RFC3339, stop)
+		if err != nil {
+			return nil, nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "stop is invalid, expected an RFC3339 time",
+				Err:  err,
+			}
+		}
+		f.Stop = t
+	}
+	f.Levels = qp["level"]
+
+	for _, s := range qp["tag"] {
+		kv := strings.SplitN(s, ":", 2)
+		if len(kv) != 2 {
+			return nil, nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "tag must be in form key:value",
+			}
+		}
+		t := influxdb.Tag{Key: kv[0], Value: kv[1]}
+		if err := t.Valid(); err != nil {
+			return nil, nil, err
+		}
+		f.Tags = append(f.Tags, t)
+	}
+
+	opts, err := decodeFindOptions(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, opts, nil
+}
+
+// AlertHistoryService is a client to read the statuses of the checks and the
+// notifications of the notification rules over HTTP.
+type AlertHistoryService struct {
+	Client *httpc.Client
+}
+
+// FindCheckStatuses returns the statuses of the check of the filter, the most
+// recent first. The count is the number of statuses returned.
+func (s *AlertHistoryService) FindCheckStatuses(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if filter.CheckID == nil {
+		return nil, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   influxdb.OpFindCheckStatuses,
+			Msg:  "check id is required",
+		}
+	}
+
+	var resp checkStatusesResponse
+	err := s.Client.
+		Get(path.Join(prefixChecks, filter.CheckID.String(), "statuses")).
+		QueryParams(alertHistoryParams(filter, opt...)...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Statuses, len(resp.Statuses), nil
+}
+
+// FindNotificationEvents returns the notifications of the notification rule
+// of the filter, the most recent first. The count is the number of
+// notifications returned.
+func (s *AlertHistoryService) FindNotificationEvents(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if filter.RuleID == nil {
+		return nil, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   influxdb.OpFindNotificationEvents,
+			Msg:  "notification rule id is required",
+		}
+	}
+
+	var resp notificationEventsResponse
+	err := s.Client.
+		Get(path.Join(prefixNotificationRules, filter.RuleID.String(), "notifications")).
+		QueryParams(alertHistoryParams(filter, opt...)...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Notifications, len(resp.Notifications), nil
+}
+
+func alertHistoryParams(filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) [][2]string {
+	params := findOptionParams(opt...)
+	for k, vs := range filter.QueryParams() {
+		for _, v := range vs {
+			params = append(params, [2]string{k, v})
+		}
+	}
+	return params
+}
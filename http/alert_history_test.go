@@ -0,0 +1,134 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/pkg/testttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestService_handleGetCheckStatuses(t *testing.T) {
+	checkID, orgID := influxdb.ID(1), influxdb.ID(2)
+	start := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+	checkBackend := NewMockCheckBackend(t)
+	checkBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	checkBackend.CheckService = &mock.CheckService{
+		FindCheckByIDFn: func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			return &check.Deadman{Base: check.Base{ID: id, OrgID: orgID}}, nil
+		},
+	}
+	var filter influxdb.AlertHistoryFilter
+	var opts influxdb.FindOptions
+	historySvc := mock.NewAlertHistoryService()
+	historySvc.FindCheckStatusesFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+		filter, opts = f, opt[0]
+		return []*influxdb.CheckStatus{
+			{Time: start, CheckID: checkID, Level: "crit", Tags: map[string]string{"host": "web-1"}},
+		}, 1, nil
+	}
+	checkBackend.AlertHistoryService = historySvc
+
+	testttp.
+		Get(t, "/api/v2/checks/"+checkID.String()+"/statuses?start=2020-03-02T00:00:00Z&level=crit&level=warn&tag=host:web-1&limit=1").
+		Do(NewCheckHandler(zaptest.NewLogger(t), checkBackend)).
+		ExpectStatus(http.StatusOK).
+		Expect(func(resp *testttp.Resp) {
+			var got checkStatusesResponse
+			require.NoError(t, json.NewDecoder(resp.Rec.Body).Decode(&got))
+			require.Len(t, got.Statuses, 1)
+			assert.Equal(t, "web-1", got.Statuses[0].Tags["host"])
+			assert.Equal(t, "/api/v2/checks/0000000000000001/statuses?descending=false&level=crit&level=warn&limit=1&offset=1&start=2020-03-02T00%3A00%3A00Z&tag=host%3Aweb-1", got.Links.Next)
+		})
+
+	assert.Equal(t, orgID, filter.OrgID)
+	require.NotNil(t, filter.CheckID)
+	assert.Equal(t, checkID, *filter.CheckID)
+	assert.Equal(t, start, filter.Start)
+	assert.True(t, filter.Stop.IsZero())
+	assert.Equal(t, []string{"crit", "warn"}, filter.Levels)
+	assert.Equal(t, []influxdb.Tag{{Key: "host", Value: "web-1"}}, filter.Tags)
+	assert.Equal(t, 1, opts.Limit)
+
+	t.Run("invalid start", func(t *testing.T) {
+		testttp.
+			Get(t, "/api/v2/checks/"+checkID.String()+"/statuses?start=yesterday").
+			Do(NewCheckHandler(zaptest.NewLogger(t), checkBackend)).
+			ExpectStatus(http.StatusBadRequest)
+	})
+}
+
+func TestService_handleGetNotificationRuleNotifications(t *testing.T) {
+	ruleID, orgID := influxdb.ID(1), influxdb.ID(2)
+
+	ruleBackend := NewMockNotificationRuleBackend(t)
+	ruleBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	ruleBackend.NotificationRuleStore = &mock.NotificationRuleStore{
+		FindNotificationRuleByIDF: func(ctx context.Context, id influxdb.ID) (influxdb.NotificationRule, error) {
+			return &rule.Slack{Base: rule.Base{ID: id, OrgID: orgID}}, nil
+		},
+	}
+	var filter influxdb.AlertHistoryFilter
+	historySvc := mock.NewAlertHistoryService()
+	historySvc.FindNotificationEventsFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+		filter = f
+		return nil, 0, nil
+	}
+	ruleBackend.AlertHistoryService = historySvc
+
+	testttp.
+		Get(t, "/api/v2/notificationRules/"+ruleID.String()+"/notifications").
+		Do(NewNotificationRuleHandler(zaptest.NewLogger(t), ruleBackend)).
+		ExpectStatus(http.StatusOK).
+		ExpectBody(func(body *bytes.Buffer) {
+			var got notificationEventsResponse
+			require.NoError(t, json.Unmarshal(body.Bytes(), &got))
+			assert.NotNil(t, got.Notifications)
+			assert.Empty(t, got.Notifications)
+		})
+
+	assert.Equal(t, orgID, filter.OrgID)
+	require.NotNil(t, filter.RuleID)
+	assert.Equal(t, ruleID, *filter.RuleID)
+}
+
+func TestAlertHistoryService(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"links": {}, "statuses": [{"checkID": "0000000000000001", "level": "ok"}]}`))
+	}))
+	defer server.Close()
+
+	s := &AlertHistoryService{Client: mustNewHTTPClient(t, server.URL, "")}
+	checkID := influxdb.ID(1)
+	statuses, n, err := s.FindCheckStatuses(context.Background(), influxdb.AlertHistoryFilter{
+		CheckID: &checkID,
+		Levels:  []string{"ok"},
+	}, influxdb.FindOptions{Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	assert.Equal(t, "ok", statuses[0].Level)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "/api/v2/checks/0000000000000001/statuses", got.URL.Path)
+	assert.Equal(t, "ok", got.URL.Query().Get("level"))
+	assert.Equal(t, "5", got.URL.Query().Get("limit"))
+
+	_, _, err = s.FindNotificationEvents(context.Background(), influxdb.AlertHistoryFilter{})
+	assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+}
@@ -52,10 +52,14 @@ type APIBackend struct {
 
 	PointsWriter                    storage.PointsWriter
 	DeleteService                   influxdb.DeleteService
+	ExportService                   influxdb.ExportService
+	CardinalityService              influxdb.CardinalityService
 	BackupService                   influxdb.BackupService
 	KVBackupService                 influxdb.KVBackupService
 	AuthorizationService            influxdb.AuthorizationService
 	BucketService                   influxdb.BucketService
+	DownsamplePolicyService         influxdb.DownsamplePolicyService
+	DBRPMappingService              influxdb.DBRPMappingService
 	SessionService                  influxdb.SessionService
 	UserService                     influxdb.UserService
 	OrganizationService             influxdb.OrganizationService
@@ -83,6 +87,12 @@ type APIBackend struct {
 	DocumentService                 influxdb.DocumentService
 	NotificationRuleStore           influxdb.NotificationRuleStore
 	NotificationEndpointService     influxdb.NotificationEndpointService
+	NotificationEndpointTestService influxdb.NotificationEndpointTestService
+	SilenceService                  influxdb.SilenceService
+	EscalationPolicyService         influxdb.EscalationPolicyService
+	IncidentService                 influxdb.IncidentService
+	AlertHistoryService             influxdb.AlertHistoryService
+	CheckDryRunService              influxdb.CheckDryRunService
 }
 
 // PrometheusCollectors exposes the prometheus collectors associated with an APIBackend.
@@ -129,6 +139,7 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 
 	bucketBackend := NewBucketBackend(b.Logger.With(zap.String("handler", "bucket")), b)
 	bucketBackend.BucketService = authorizer.NewBucketService(b.BucketService, noAuthUserResourceMappingService)
+	bucketBackend.DownsamplePolicyService = authorizer.NewDownsamplePolicyService(b.DownsamplePolicyService)
 	h.Mount(prefixBuckets, NewBucketHandler(b.Logger, bucketBackend))
 
 	checkBackend := NewCheckBackend(b.Logger.With(zap.String("handler", "check")), b)
@@ -142,9 +153,20 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	dashboardBackend.DashboardService = authorizer.NewDashboardService(b.DashboardService)
 	h.Mount(prefixDashboards, NewDashboardHandler(b.Logger, dashboardBackend))
 
+	dbrpMappingBackend := NewDBRPMappingBackend(b.Logger.With(zap.String("handler", "dbrp")), b)
+	dbrpMappingBackend.DBRPMappingService = authorizer.NewDBRPMappingService(b.DBRPMappingService)
+	h.Mount(prefixDBRPs, NewDBRPMappingHandler(b.Logger, dbrpMappingBackend))
+
 	deleteBackend := NewDeleteBackend(b.Logger.With(zap.String("handler", "delete")), b)
 	h.Mount(prefixDelete, NewDeleteHandler(b.Logger, deleteBackend))
 
+	escalationPolicyBackend := NewEscalationPolicyBackend(b.Logger.With(zap.String("handler", "escalationPolicy")), b)
+	escalationPolicyBackend.EscalationPolicyService = authorizer.NewEscalationPolicyService(b.EscalationPolicyService)
+	h.Mount(prefixEscalationPolicies, NewEscalationPolicyHandler(b.Logger, escalationPolicyBackend))
+
+	exportBackend := NewExportBackend(b.Logger.With(zap.String("handler", "export")), b)
+	h.Mount(prefixExport, NewExportHandler(b.Logger, exportBackend))
+
 	documentBackend := NewDocumentBackend(b.Logger.With(zap.String("handler", "document")), b)
 	documentBackend.DocumentService = authorizer.NewDocumentService(b.DocumentService)
 	h.Mount(prefixDocuments, NewDocumentHandler(documentBackend))
@@ -152,11 +174,17 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	fluxBackend := NewFluxBackend(b.Logger.With(zap.String("handler", "query")), b)
 	h.Mount(prefixQuery, NewFluxHandler(b.Logger, fluxBackend))
 
+	incidentBackend := NewIncidentBackend(b.Logger.With(zap.String("handler", "incident")), b)
+	incidentBackend.IncidentService = authorizer.NewIncidentService(b.IncidentService)
+	h.Mount(prefixIncidents, NewIncidentHandler(b.Logger, incidentBackend))
+
 	h.Mount(prefixLabels, NewLabelHandler(b.Logger, b.LabelService, b.HTTPErrorHandler))
 
 	notificationEndpointBackend := NewNotificationEndpointBackend(b.Logger.With(zap.String("handler", "notificationEndpoint")), b)
 	notificationEndpointBackend.NotificationEndpointService = authorizer.NewNotificationEndpointService(b.NotificationEndpointService,
 		b.UserResourceMappingService, b.OrganizationService)
+	notificationEndpointBackend.NotificationEndpointTestService = authorizer.NewNotificationEndpointTestService(b.NotificationEndpointTestService,
+		b.NotificationEndpointService)
 	h.Mount(prefixNotificationEndpoints, NewNotificationEndpointHandler(notificationEndpointBackend.Logger(), notificationEndpointBackend))
 
 	notificationRuleBackend := NewNotificationRuleBackend(b.Logger.With(zap.String("handler", "notification_rule")), b)
@@ -180,6 +208,10 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	h.Mount(prefixSignIn, sessionHandler)
 	h.Mount(prefixSignOut, sessionHandler)
 
+	silenceBackend := NewSilenceBackend(b.Logger.With(zap.String("handler", "silence")), b)
+	silenceBackend.SilenceService = authorizer.NewSilenceService(b.SilenceService)
+	h.Mount(prefixSilences, NewSilenceHandler(b.Logger, silenceBackend))
+
 	setupBackend := NewSetupBackend(b.Logger.With(zap.String("handler", "setup")), b)
 	h.Mount(prefixSetup, NewSetupHandler(b.Logger, setupBackend))
 
@@ -224,6 +256,12 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 		WithParserMaxValues(b.WriteParserMaxValues),
 	))
 
+	legacyWriteBackend := NewLegacyWriteBackend(b.Logger.With(zap.String("handler", "legacy_write")), b)
+	h.Mount(prefixLegacyWrite, NewLegacyWriteHandler(b.Logger, legacyWriteBackend))
+
+	legacyQueryBackend := NewLegacyQueryBackend(b.Logger.With(zap.String("handler", "legacy_query")), b)
+	h.Mount(prefixLegacyQuery, NewLegacyQueryHandler(b.Logger, legacyQueryBackend))
+
 	for _, o := range opts {
 		o(h)
 	}
@@ -233,13 +271,16 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
-	"authorizations": "/api/v2/authorizations",
-	"backup":         "/api/v2/backup",
-	"buckets":        "/api/v2/buckets",
-	"dashboards":     "/api/v2/dashboards",
+	"authorizations":     "/api/v2/authorizations",
+	"backup":             "/api/v2/backup",
+	"buckets":            "/api/v2/buckets",
+	"dashboards":         "/api/v2/dashboards",
+	"dbrps":              "/api/v2/dbrps",
+	"escalationPolicies": "/api/v2/escalationPolicies",
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
+	"incidents":             "/api/v2/incidents",
 	"labels":                "/api/v2/labels",
 	"variables":             "/api/v2/variables",
 	"me":                    "/api/v2/me",
@@ -255,6 +296,7 @@ var apiLinks = map[string]interface{}{
 	"setup":    "/api/v2/setup",
 	"signin":   "/api/v2/signin",
 	"signout":  "/api/v2/signout",
+	"silences": "/api/v2/silences",
 	"sources":  "/api/v2/sources",
 	"scrapers": "/api/v2/scrapers",
 	"swagger":  "/api/v2/swagger.json",
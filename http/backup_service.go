@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/httprouter"
@@ -62,7 +64,12 @@ const (
 	prefixBackup        = "/api/v2/backup"
 	backupIDParamName   = "backup_id"
 	backupFileParamName = "backup_file"
-	backupFilePath      = prefixBackup + "/:" + backupIDParamName + "/file/:" + backupFileParamName
+
+	// The files of time partitions are in directories of their own, so the
+	// name of a backup file may span several path segments.
+	backupFilePath = prefixBackup + "/:" + backupIDParamName + "/file/*" + backupFileParamName
+
+	backupIncrementalPath = prefixBackup + "/incremental"
 
 	httpClientTimeout = time.Hour
 )
@@ -82,6 +89,7 @@ func NewBackupHandler(b *BackupBackend) *BackupHandler {
 	}
 
 	h.HandlerFunc(http.MethodPost, prefixBackup, h.handleCreate)
+	h.HandlerFunc(http.MethodPost, backupIncrementalPath, h.handleCreateIncremental)
 	h.HandlerFunc(http.MethodGet, backupFilePath, h.handleFetchFile)
 
 	return h
@@ -106,36 +114,71 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 
 	internalBackupPath := h.BackupService.InternalBackupPath(id)
 
-	boltPath := filepath.Join(internalBackupPath, bolt.DefaultFilename)
-	boltFile, err := os.OpenFile(boltPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	metaFiles, err := h.backupMetadata(ctx, internalBackupPath)
 	if err != nil {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
 
-	if err = h.KVBackupService.Backup(ctx, boltFile); err != nil {
+	for _, f := range metaFiles {
+		files = append(files, f.Name)
+	}
+
+	b := backup{
+		ID:    id,
+		Files: files,
+	}
+	if err = json.NewEncoder(w).Encode(&b); err != nil {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
+}
+
+type incrementalBackupRequest struct {
+	Exclude []influxdb.BackupFile `json:"exclude"`
+}
 
-	files = append(files, bolt.DefaultFilename)
+type incrementalBackup struct {
+	ID    int                   `json:"id,omitempty"`
+	Files []influxdb.BackupFile `json:"files,omitempty"`
+}
 
-	credsExist, err := h.backupCredentials(internalBackupPath)
+func (h *BackupHandler) handleCreateIncremental(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "BackupHandler.handleCreateIncremental")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	var req incrementalBackupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid incremental backup request",
+			Err:  err,
+		}, w)
+		return
+	}
 
+	id, files, err := h.BackupService.CreateIncrementalBackup(ctx, req.Exclude)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
 
-	if credsExist {
-		files = append(files, DefaultConfigsFile)
+	internalBackupPath := h.BackupService.InternalBackupPath(id)
+
+	metaFiles, err := h.backupMetadata(ctx, internalBackupPath)
+	if err != nil {
+		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
+		h.HandleHTTPError(ctx, err, w)
+		return
 	}
 
-	b := backup{
+	b := incrementalBackup{
 		ID:    id,
-		Files: files,
+		Files: append(files, metaFiles...),
 	}
 	if err = json.NewEncoder(w).Encode(&b); err != nil {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
@@ -144,6 +187,42 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// backupMetadata writes the metadata database and the cli credentials, if any,
+// to the backup directory and returns the files written.
+func (h *BackupHandler) backupMetadata(ctx context.Context, internalBackupPath string) ([]influxdb.BackupFile, error) {
+	boltPath := filepath.Join(internalBackupPath, bolt.DefaultFilename)
+	boltFile, err := os.OpenFile(boltPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	if err != nil {
+		return nil, err
+	}
+	defer boltFile.Close()
+
+	if err = h.KVBackupService.Backup(ctx, boltFile); err != nil {
+		return nil, err
+	}
+
+	fi, err := boltFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	files := []influxdb.BackupFile{{Name: bolt.DefaultFilename, Size: fi.Size()}}
+
+	credsExist, err := h.backupCredentials(internalBackupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if credsExist {
+		fi, err := os.Stat(filepath.Join(internalBackupPath, DefaultConfigsFile))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, influxdb.BackupFile{Name: DefaultConfigsFile, Size: fi.Size()})
+	}
+
+	return files, nil
+}
+
 func (h *BackupHandler) backupCredentials(internalBackupPath string) (bool, error) {
 	credBackupPath := filepath.Join(internalBackupPath, DefaultConfigsFile)
 
@@ -176,7 +255,7 @@ func (h *BackupHandler) handleFetchFile(w http.ResponseWriter, r *http.Request)
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
-	backupFile := params.ByName("backup_file")
+	backupFile := strings.TrimPrefix(params.ByName(backupFileParamName), "/")
 
 	if err = h.BackupService.FetchBackupFile(ctx, backupID, backupFile, w); err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -227,6 +306,48 @@ func (s *BackupService) CreateBackup(ctx context.Context) (int, []string, error)
 	return b.ID, b.Files, nil
 }
 
+func (s *BackupService) CreateIncrementalBackup(ctx context.Context, exclude []influxdb.BackupFile) (int, []influxdb.BackupFile, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, backupIncrementalPath)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body, err := json.Marshal(incrementalBackupRequest{Exclude: exclude})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	SetToken(s.Token, req)
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	hc.Timeout = httpClientTimeout
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return 0, nil, err
+	}
+
+	var b incrementalBackup
+	if err = json.NewDecoder(resp.Body).Decode(&b); err != nil {
+		return 0, nil, err
+	}
+
+	return b.ID, b.Files, nil
+}
+
 func (s *BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
@@ -28,6 +28,8 @@ type BucketBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	DownsamplePolicyService    influxdb.DownsamplePolicyService
+	CardinalityService         influxdb.CardinalityService
 }
 
 // NewBucketBackend returns a new instance of BucketBackend.
@@ -42,6 +44,8 @@ func NewBucketBackend(log *zap.Logger, b *APIBackend) *BucketBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		DownsamplePolicyService:    b.DownsamplePolicyService,
+		CardinalityService:         b.CardinalityService,
 	}
 }
 
@@ -57,6 +61,8 @@ type BucketHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	DownsamplePolicyService    influxdb.DownsamplePolicyService
+	CardinalityService         influxdb.CardinalityService
 }
 
 const (
@@ -84,6 +90,8 @@ func NewBucketHandler(log *zap.Logger, b *BucketBackend) *BucketHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		DownsamplePolicyService:    b.DownsamplePolicyService,
+		CardinalityService:         b.CardinalityService,
 	}
 
 	h.HandlerFunc("POST", prefixBuckets, h.handlePostBucket)
@@ -127,18 +135,27 @@ func NewBucketHandler(log *zap.Logger, b *BucketBackend) *BucketHandler {
 	h.HandlerFunc("POST", bucketsIDLabelsPath, newPostLabelHandler(labelBackend))
 	h.HandlerFunc("DELETE", bucketsIDLabelsIDPath, newDeleteLabelHandler(labelBackend))
 
+	h.HandlerFunc("GET", bucketsIDDownsamplePoliciesPath, h.handleGetDownsamplePolicies)
+	h.HandlerFunc("POST", bucketsIDDownsamplePoliciesPath, h.handlePostDownsamplePolicy)
+	h.HandlerFunc("GET", bucketsIDDownsamplePoliciesIDPath, h.handleGetDownsamplePolicy)
+	h.HandlerFunc("PATCH", bucketsIDDownsamplePoliciesIDPath, h.handlePatchDownsamplePolicy)
+	h.HandlerFunc("DELETE", bucketsIDDownsamplePoliciesIDPath, h.handleDeleteDownsamplePolicy)
+
+	h.HandlerFunc("GET", bucketsIDCardinalityPath, h.handleGetBucketCardinality)
+
 	return h
 }
 
 // bucket is used for serialization/deserialization with duration string syntax.
 type bucket struct {
-	ID                  influxdb.ID     `json:"id,omitempty"`
-	OrgID               influxdb.ID     `json:"orgID,omitempty"`
-	Type                string          `json:"type"`
-	Description         string          `json:"description,omitempty"`
-	Name                string          `json:"name"`
-	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
-	RetentionRules      []retentionRule `json:"retentionRules"`
+	ID                  influxdb.ID            `json:"id,omitempty"`
+	OrgID               influxdb.ID            `json:"orgID,omitempty"`
+	Type                string                 `json:"type"`
+	Description         string                 `json:"description,omitempty"`
+	Name                string                 `json:"name"`
+	RetentionPolicyName string                 `json:"rp,omitempty"` // This to support v1 sources
+	RetentionRules      []retentionRule        `json:"retentionRules"`
+	Schema              *influxdb.BucketSchema `json:"schema,omitempty"`
 	influxdb.CRUDLog
 }
 
@@ -186,6 +203,7 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		Schema:              b.Schema,
 		CRUDLog:             b.CRUDLog,
 	}, nil
 }
@@ -212,15 +230,17 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 		Description:         pb.Description,
 		RetentionPolicyName: pb.RetentionPolicyName,
 		RetentionRules:      rules,
+		Schema:              pb.Schema,
 		CRUDLog:             pb.CRUDLog,
 	}
 }
 
 // bucketUpdate is used for serialization/deserialization with retention rules.
 type bucketUpdate struct {
-	Name           *string         `json:"name,omitempty"`
-	Description    *string         `json:"description,omitempty"`
-	RetentionRules []retentionRule `json:"retentionRules,omitempty"`
+	Name           *string                `json:"name,omitempty"`
+	Description    *string                `json:"description,omitempty"`
+	RetentionRules []retentionRule        `json:"retentionRules,omitempty"`
+	Schema         *influxdb.BucketSchema `json:"schema,omitempty"`
 }
 
 func (b *bucketUpdate) OK() error {
@@ -230,6 +250,13 @@ func (b *bucketUpdate) OK() error {
 			return err
 		}
 	}
+
+	// A schema without measurements removes the schema of the bucket.
+	if b.Schema != nil && len(b.Schema.Measurements) > 0 {
+		if err := b.Schema.Valid(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -248,6 +275,7 @@ func (b *bucketUpdate) toInfluxDB() *influxdb.BucketUpdate {
 		Name:            b.Name,
 		Description:     b.Description,
 		RetentionPeriod: &d,
+		Schema:          b.Schema,
 	}
 }
 
@@ -260,6 +288,7 @@ func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
 		Name:           pb.Name,
 		Description:    pb.Description,
 		RetentionRules: []retentionRule{},
+		Schema:         pb.Schema,
 	}
 
 	if pb.RetentionPeriod != nil {
@@ -281,13 +310,14 @@ type bucketResponse struct {
 func NewBucketResponse(b *influxdb.Bucket, labels []*influxdb.Label) *bucketResponse {
 	res := &bucketResponse{
 		Links: map[string]string{
-			"labels":  fmt.Sprintf("/api/v2/buckets/%s/labels", b.ID),
-			"logs":    fmt.Sprintf("/api/v2/buckets/%s/logs", b.ID),
-			"members": fmt.Sprintf("/api/v2/buckets/%s/members", b.ID),
-			"org":     fmt.Sprintf("/api/v2/orgs/%s", b.OrgID),
-			"owners":  fmt.Sprintf("/api/v2/buckets/%s/owners", b.ID),
-			"self":    fmt.Sprintf("/api/v2/buckets/%s", b.ID),
-			"write":   fmt.Sprintf("/api/v2/write?org=%s&bucket=%s", b.OrgID, b.ID),
+			"downsamplePolicies": fmt.Sprintf("/api/v2/buckets/%s/downsamplePolicies", b.ID),
+			"labels":             fmt.Sprintf("/api/v2/buckets/%s/labels", b.ID),
+			"logs":               fmt.Sprintf("/api/v2/buckets/%s/logs", b.ID),
+			"members":            fmt.Sprintf("/api/v2/buckets/%s/members", b.ID),
+			"org":                fmt.Sprintf("/api/v2/orgs/%s", b.OrgID),
+			"owners":             fmt.Sprintf("/api/v2/buckets/%s/owners", b.ID),
+			"self":               fmt.Sprintf("/api/v2/buckets/%s", b.ID),
+			"write":              fmt.Sprintf("/api/v2/write?org=%s&bucket=%s", b.OrgID, b.ID),
 		},
 		bucket: *newBucket(b),
 		Labels: []influxdb.Label{},
@@ -336,11 +366,12 @@ func (h *BucketHandler) handlePostBucket(w http.ResponseWriter, r *http.Request)
 }
 
 type postBucketRequest struct {
-	OrgID               influxdb.ID     `json:"orgID,omitempty"`
-	Name                string          `json:"name"`
-	Description         string          `json:"description"`
-	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
-	RetentionRules      []retentionRule `json:"retentionRules"`
+	OrgID               influxdb.ID            `json:"orgID,omitempty"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	RetentionPolicyName string                 `json:"rp,omitempty"` // This to support v1 sources
+	RetentionRules      []retentionRule        `json:"retentionRules"`
+	Schema              *influxdb.BucketSchema `json:"schema,omitempty"`
 }
 
 func (b *postBucketRequest) OK() error {
@@ -369,6 +400,12 @@ func (b *postBucketRequest) OK() error {
 		}
 	}
 
+	if b.Schema != nil {
+		if err := b.Schema.Valid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -386,6 +423,7 @@ func (b postBucketRequest) toInfluxDB() *influxdb.Bucket {
 		Type:                influxdb.BucketTypeUser,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     dur,
+		Schema:              b.Schema,
 	}
 }
 
@@ -35,6 +35,7 @@ func NewMockBucketBackend(t *testing.T) *BucketBackend {
 		LabelService:               mock.NewLabelService(),
 		UserService:                mock.NewUserService(),
 		OrganizationService:        mock.NewOrganizationService(),
+		DownsamplePolicyService:    mock.NewDownsamplePolicyService(),
 	}
 }
 
@@ -114,6 +115,7 @@ func TestService_handleGetBuckets(t *testing.T) {
         "org": "/api/v2/orgs/50f7ba1150f7ba11",
         "self": "/api/v2/buckets/0b501e7e557ab1ed",
         "logs": "/api/v2/buckets/0b501e7e557ab1ed/logs",
+        "downsamplePolicies": "/api/v2/buckets/0b501e7e557ab1ed/downsamplePolicies",
         "labels": "/api/v2/buckets/0b501e7e557ab1ed/labels",
         "owners": "/api/v2/buckets/0b501e7e557ab1ed/owners",
         "members": "/api/v2/buckets/0b501e7e557ab1ed/members",
@@ -141,6 +143,7 @@ func TestService_handleGetBuckets(t *testing.T) {
         "org": "/api/v2/orgs/7e55e118dbabb1ed",
         "self": "/api/v2/buckets/c0175f0077a77005",
         "logs": "/api/v2/buckets/c0175f0077a77005/logs",
+        "downsamplePolicies": "/api/v2/buckets/c0175f0077a77005/downsamplePolicies",
         "labels": "/api/v2/buckets/c0175f0077a77005/labels",
         "members": "/api/v2/buckets/c0175f0077a77005/members",
         "owners": "/api/v2/buckets/c0175f0077a77005/owners",
@@ -284,6 +287,7 @@ func TestService_handleGetBucket(t *testing.T) {
 		    "org": "/api/v2/orgs/020f755c3c082000",
 		    "self": "/api/v2/buckets/020f755c3c082000",
 		    "logs": "/api/v2/buckets/020f755c3c082000/logs",
+		    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
 		    "labels": "/api/v2/buckets/020f755c3c082000/labels",
 		    "members": "/api/v2/buckets/020f755c3c082000/members",
 		    "owners": "/api/v2/buckets/020f755c3c082000/owners",
@@ -417,6 +421,7 @@ func TestService_handlePostBucket(t *testing.T) {
     "org": "/api/v2/orgs/6f626f7274697320",
     "self": "/api/v2/buckets/020f755c3c082000",
     "logs": "/api/v2/buckets/020f755c3c082000/logs",
+    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
     "labels": "/api/v2/buckets/020f755c3c082000/labels",
     "members": "/api/v2/buckets/020f755c3c082000/members",
     "owners": "/api/v2/buckets/020f755c3c082000/owners",
@@ -459,6 +464,91 @@ func TestService_handlePostBucket(t *testing.T) {
 				statusCode: http.StatusUnprocessableEntity,
 			},
 		},
+		{
+			name: "create a new bucket with a schema",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					CreateBucketFn: func(ctx context.Context, c *platform.Bucket) error {
+						c.ID = platformtesting.MustIDBase16("020f755c3c082000")
+						return nil
+					},
+				},
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, f platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{ID: platformtesting.MustIDBase16("6f626f7274697320")}, nil
+					},
+				},
+			},
+			args: args{
+				bucket: &platform.Bucket{
+					Name:  "hello",
+					OrgID: platformtesting.MustIDBase16("6f626f7274697320"),
+					Schema: &platform.BucketSchema{
+						Measurements: []platform.MeasurementSchema{{
+							Name:   "cpu",
+							Tags:   []string{"host"},
+							Fields: []platform.FieldSchema{{Name: "usage", Type: platform.SchemaFieldTypeFloat}},
+						}},
+					},
+				},
+			},
+			wants: wants{
+				statusCode:  http.StatusCreated,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "org": "/api/v2/orgs/6f626f7274697320",
+    "self": "/api/v2/buckets/020f755c3c082000",
+    "logs": "/api/v2/buckets/020f755c3c082000/logs",
+    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
+    "labels": "/api/v2/buckets/020f755c3c082000/labels",
+    "members": "/api/v2/buckets/020f755c3c082000/members",
+    "owners": "/api/v2/buckets/020f755c3c082000/owners",
+    "write": "/api/v2/write?org=6f626f7274697320&bucket=020f755c3c082000"
+  },
+  "createdAt": "0001-01-01T00:00:00Z",
+  "updatedAt": "0001-01-01T00:00:00Z",
+  "id": "020f755c3c082000",
+  "orgID": "6f626f7274697320",
+  "type": "user",
+  "name": "hello",
+  "retentionRules": [],
+  "schema": {
+    "measurements": [
+      {"name": "cpu", "tags": ["host"], "fields": [{"name": "usage", "type": "float"}]}
+    ]
+  },
+  "labels": []
+}
+`,
+			},
+		},
+		{
+			name: "create a new bucket with an invalid schema",
+			fields: fields{
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, f platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{ID: platformtesting.MustIDBase16("6f626f7274697320")}, nil
+					},
+				},
+			},
+			args: args{
+				bucket: &platform.Bucket{
+					Name:  "hello",
+					OrgID: platformtesting.MustIDBase16("6f626f7274697320"),
+					Schema: &platform.BucketSchema{
+						Measurements: []platform.MeasurementSchema{{
+							Name:   "cpu",
+							Fields: []platform.FieldSchema{{Name: "usage", Type: "double"}},
+						}},
+					},
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -673,6 +763,7 @@ func TestService_handlePatchBucket(t *testing.T) {
     "org": "/api/v2/orgs/020f755c3c082000",
     "self": "/api/v2/buckets/020f755c3c082000",
     "logs": "/api/v2/buckets/020f755c3c082000/logs",
+    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
     "labels": "/api/v2/buckets/020f755c3c082000/labels",
     "members": "/api/v2/buckets/020f755c3c082000/members",
     "owners": "/api/v2/buckets/020f755c3c082000/owners",
@@ -802,6 +893,7 @@ func TestService_handlePatchBucket(t *testing.T) {
     "org": "/api/v2/orgs/020f755c3c082000",
     "self": "/api/v2/buckets/020f755c3c082000",
     "logs": "/api/v2/buckets/020f755c3c082000/logs",
+    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
     "labels": "/api/v2/buckets/020f755c3c082000/labels",
     "members": "/api/v2/buckets/020f755c3c082000/members",
     "owners": "/api/v2/buckets/020f755c3c082000/owners",
@@ -862,6 +954,7 @@ func TestService_handlePatchBucket(t *testing.T) {
     "org": "/api/v2/orgs/020f755c3c082000",
     "self": "/api/v2/buckets/020f755c3c082000",
     "logs": "/api/v2/buckets/020f755c3c082000/logs",
+    "downsamplePolicies": "/api/v2/buckets/020f755c3c082000/downsamplePolicies",
     "labels": "/api/v2/buckets/020f755c3c082000/labels",
     "members": "/api/v2/buckets/020f755c3c082000/members",
     "owners": "/api/v2/buckets/020f755c3c082000/owners",
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/pkg/httpc"
+)
+
+const (
+	bucketsIDCardinalityPath = "/api/v2/buckets/:id/cardinality"
+)
+
+// handleGetBucketCardinality is the HTTP handler for the GET /api/v2/buckets/:id/cardinality route.
+func (h *BucketHandler) handleGetBucketCardinality(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "BucketHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucketID, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	topN, err := decodeCardinalityLimit(r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	// Finding the bucket checks that the cardinality can be read.
+	b, err := h.BucketService.FindBucketByID(ctx, bucketID)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if orgID := r.URL.Query().Get("orgID"); orgID != "" && orgID != b.OrgID.String() {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "bucket not found",
+		})
+		return
+	}
+
+	card, err := h.CardinalityService.BucketCardinality(ctx, b.OrgID, b.ID, topN)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	h.api.Respond(w, http.StatusOK, newBucketCardinalityResponse(card))
+}
+
+// decodeCardinalityLimit returns the number of values to report for each tag key.
+func decodeCardinalityLimit(r *http.Request) (int, error) {
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		return influxdb.DefaultCardinalityTopN, nil
+	}
+
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 || n > influxdb.MaxCardinalityTopN {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("limit must be between 1 and %d", influxdb.MaxCardinalityTopN),
+		}
+	}
+	return n, nil
+}
+
+type bucketCardinalityResponse struct {
+	*influxdb.BucketCardinality
+	Links map[string]string `json:"links"`
+}
+
+func newBucketCardinalityResponse(card *influxdb.BucketCardinality) *bucketCardinalityResponse {
+	return &bucketCardinalityResponse{
+		BucketCardinality: card,
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/buckets/%s/cardinality", card.BucketID),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", card.BucketID),
+		},
+	}
+}
+
+// CardinalityService reads the series cardinality of buckets over HTTP.
+type CardinalityService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.CardinalityService = (*CardinalityService)(nil)
+
+// BucketCardinality returns the series cardinality of a bucket, with the topN
+// values having the most series for each tag key.
+func (s *CardinalityService) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	params := [][2]string{{"limit", strconv.Itoa(topN)}}
+	if orgID.Valid() {
+		params = append(params, [2]string{"orgID", orgID.String()})
+	}
+
+	var card influxdb.BucketCardinality
+	err := s.Client.
+		Get(path.Join(bucketIDPath(bucketID), "cardinality")).
+		QueryParams(params...).
+		DecodeJSON(&card).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCardinalityService(t *testing.T) {
+	exp := &influxdb.BucketCardinality{
+		OrgID:        10,
+		BucketID:     2,
+		Series:       3,
+		Measurements: []influxdb.MeasurementCardinality{{Name: "cpu", Series: 3}},
+		TagKeys: []influxdb.TagKeyCardinality{{
+			Key:       "host",
+			Values:    3,
+			Series:    3,
+			TopValues: []influxdb.TagValueCardinality{{Value: "a", Series: 1}},
+		}},
+	}
+
+	bucketBackend := NewMockBucketBackend(t)
+	bucketBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	bucketBackend.BucketService = &mock.BucketService{
+		FindBucketByIDFn: func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+			return &influxdb.Bucket{ID: id, OrgID: 10, Name: "raw"}, nil
+		},
+	}
+	bucketBackend.CardinalityService = &mock.CardinalityService{
+		BucketCardinalityFn: func(_ context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+			if orgID != 10 || bucketID != 2 || topN != 1 {
+				t.Errorf("unexpected cardinality request for org %s, bucket %s, top %d", orgID, bucketID, topN)
+			}
+			return exp, nil
+		},
+	}
+	server := httptest.NewServer(NewBucketHandler(zaptest.NewLogger(t), bucketBackend))
+	defer server.Close()
+
+	s := &CardinalityService{Client: mustNewHTTPClient(t, server.URL, "")}
+
+	card, err := s.BucketCardinality(context.Background(), 10, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(card, exp) {
+		t.Fatalf("got cardinality %+v, expected %+v", card, exp)
+	}
+
+	// The bucket must belong to the org.
+	if _, err := s.BucketCardinality(context.Background(), 11, 2, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected bucket not found, got %v", err)
+	}
+}
+
+func TestService_handleGetBucketCardinality_InvalidLimit(t *testing.T) {
+	bucketBackend := NewMockBucketBackend(t)
+	bucketBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	h := NewBucketHandler(zaptest.NewLogger(t), bucketBackend)
+
+	for _, limit := range []string{"0", "1001", "ten"} {
+		r := httptest.NewRequest("GET", "http://any.url/api/v2/buckets/0000000000000002/cardinality?limit="+limit, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status %d for limit %s: %s", w.Code, limit, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/pkg/testttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestService_handlePostCheckDryRun(t *testing.T) {
+	checkID, orgID := influxdb.ID(1), influxdb.ID(2)
+	start := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	newBackend := func(t *testing.T, dryRunFn func(context.Context, influxdb.Check, time.Time, time.Time) ([]*influxdb.CheckStatus, error)) (*CheckBackend, *mock.CheckService) {
+		checkSvc := mock.NewCheckService()
+		checkSvc.FindCheckByIDFn = func(ctx context.Context, id influxdb.ID) (influxdb.Check, error) {
+			return &check.Deadman{Base: check.Base{ID: id, OrgID: orgID, Name: "existing"}}, nil
+		}
+		checkSvc.CreateCheckFn = func(ctx context.Context, c influxdb.CheckCreate, userID influxdb.ID) error {
+			t.Fatal("a dry run must not create the check")
+			return nil
+		}
+		dryRunSvc := mock.NewCheckDryRunService()
+		dryRunSvc.DryRunCheckFn = dryRunFn
+
+		checkBackend := NewMockCheckBackend(t)
+		checkBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+		checkBackend.CheckService = checkSvc
+		checkBackend.CheckDryRunService = dryRunSvc
+		return checkBackend, checkSvc
+	}
+
+	t.Run("existing check", func(t *testing.T) {
+		var name string
+		var gotStart, gotStop time.Time
+		checkBackend, _ := newBackend(t, func(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+			name, gotStart, gotStop = chk.GetName(), start, stop
+			return []*influxdb.CheckStatus{{Time: stop, CheckID: chk.GetID(), Level: "crit"}}, nil
+		})
+
+		testttp.
+			Post(t, "/api/v2/checks/"+checkID.String()+"/dryRun?start=2020-03-02T00:00:00Z&stop=2020-03-02T01:00:00Z", nil).
+			Do(NewCheckHandler(zaptest.NewLogger(t), checkBackend)).
+			ExpectStatus(http.StatusOK).
+			Expect(func(resp *testttp.Resp) {
+				var got checkDryRunResponse
+				require.NoError(t, json.NewDecoder(resp.Rec.Body).Decode(&got))
+				require.Len(t, got.Statuses, 1)
+				assert.Equal(t, "crit", got.Statuses[0].Level)
+			})
+		assert.Equal(t, "existing", name)
+		assert.Equal(t, start, gotStart)
+		assert.Equal(t, stop, gotStop)
+	})
+
+	t.Run("check definition", func(t *testing.T) {
+		var name string
+		checkBackend, _ := newBackend(t, func(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+			name = chk.GetName()
+			return nil, nil
+		})
+
+		chk := &check.Deadman{
+			Base: check.Base{
+				Name:  "new",
+				OrgID: orgID,
+				Every: &notification.Duration{},
+				Query: influxdb.DashboardQuery{Text: `from(bucket: "telegraf") |> range(start: -1m)`},
+			},
+			Level: notification.Critical,
+		}
+		testttp.
+			PostJSON(t, "/api/v2/checks?dryRun=true", chk).
+			Do(NewCheckHandler(zaptest.NewLogger(t), checkBackend)).
+			ExpectStatus(http.StatusOK).
+			ExpectBody(func(body *bytes.Buffer) {
+				assert.JSONEq(t, `{"statuses": []}`, body.String())
+			})
+		assert.Equal(t, "new", name)
+	})
+
+	t.Run("invalid stop", func(t *testing.T) {
+		checkBackend, _ := newBackend(t, nil)
+		testttp.
+			Post(t, "/api/v2/checks/"+checkID.String()+"/dryRun?stop=now", nil).
+			Do(NewCheckHandler(zaptest.NewLogger(t), checkBackend)).
+			ExpectStatus(http.StatusBadRequest)
+	})
+}
+
+func TestCheckDryRunService(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"statuses": [{"checkID": "0000000000000001", "level": "warn"}]}`))
+	}))
+	defer server.Close()
+
+	s := &CheckDryRunService{Client: mustNewHTTPClient(t, server.URL, "")}
+	start := time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+
+	statuses, err := s.DryRunCheckByID(context.Background(), influxdb.ID(1), start, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "warn", statuses[0].Level)
+	require.NotNil(t, got)
+	assert.Equal(t, "/api/v2/checks/0000000000000001/dryRun", got.URL.Path)
+	assert.Equal(t, "2020-03-02T00:00:00Z", got.URL.Query().Get("start"))
+	assert.Empty(t, got.URL.Query().Get("stop"))
+
+	_, err = s.DryRunCheck(context.Background(), &check.Deadman{Base: check.Base{Name: "new"}}, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "/api/v2/checks", got.URL.Path)
+	assert.Equal(t, "true", got.URL.Query().Get("dryRun"))
+	assert.Equal(t, "2020-03-02T01:00:00Z", got.URL.Query().Get("stop"))
+}
@@ -31,6 +31,8 @@ type CheckBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	AlertHistoryService        influxdb.AlertHistoryService
+	CheckDryRunService         influxdb.CheckDryRunService
 }
 
 // NewCheckBackend returns a new instance of CheckBackend.
@@ -45,6 +47,8 @@ func NewCheckBackend(log *zap.Logger, b *APIBackend) *CheckBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		AlertHistoryService:        b.AlertHistoryService,
+		CheckDryRunService:         b.CheckDryRunService,
 	}
 }
 
@@ -60,12 +64,16 @@ type CheckHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	AlertHistoryService        influxdb.AlertHistoryService
+	CheckDryRunService         influxdb.CheckDryRunService
 }
 
 const (
 	prefixChecks          = "/api/v2/checks"
 	checksIDPath          = "/api/v2/checks/:id"
 	checksIDQueryPath     = "/api/v2/checks/:id/query"
+	checksIDStatusesPath  = "/api/v2/checks/:id/statuses"
+	checksIDDryRunPath    = "/api/v2/checks/:id/dryRun"
 	checksIDMembersPath   = "/api/v2/checks/:id/members"
 	checksIDMembersIDPath = "/api/v2/checks/:id/members/:userID"
 	checksIDOwnersPath    = "/api/v2/checks/:id/owners"
@@ -87,11 +95,15 @@ func NewCheckHandler(log *zap.Logger, b *CheckBackend) *CheckHandler {
 		UserService:                b.UserService,
 		TaskService:                b.TaskService,
 		OrganizationService:        b.OrganizationService,
+		AlertHistoryService:        b.AlertHistoryService,
+		CheckDryRunService:         b.CheckDryRunService,
 	}
 	h.HandlerFunc("POST", prefixChecks, h.handlePostCheck)
 	h.HandlerFunc("GET", prefixChecks, h.handleGetChecks)
 	h.HandlerFunc("GET", checksIDPath, h.handleGetCheck)
 	h.HandlerFunc("GET", checksIDQueryPath, h.handleGetCheckQuery)
+	h.HandlerFunc("GET", checksIDStatusesPath, h.handleGetCheckStatuses)
+	h.HandlerFunc("POST", checksIDDryRunPath, h.handlePostCheckDryRun)
 	h.HandlerFunc("DELETE", checksIDPath, h.handleDeleteCheck)
 	h.HandlerFunc("PUT", checksIDPath, h.handlePutCheck)
 	h.HandlerFunc("PATCH", checksIDPath, h.handlePatchCheck)
@@ -310,6 +322,119 @@ func (h *CheckHandler) handleGetCheckQuery(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func (h *CheckHandler) handleGetCheckStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	filter, opts, err := decodeAlertHistoryFilter(r)
+	if err != nil {
+		h.log.Debug("Failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	chk, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	checkID := chk.GetID()
+	filter.OrgID = chk.GetOrgID()
+	filter.CheckID = &checkID
+
+	statuses, _, err := h.AlertHistoryService.FindCheckStatuses(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Check statuses retrieved", zap.String("checkID", checkID.String()), zap.Int("statuses", len(statuses)))
+
+	resp := checkStatusesResponse{
+		Links:    newPagingLinks(path.Join(prefixChecks, checkID.String(), "statuses"), *opts, filter, len(statuses)),
+		Statuses: statuses,
+	}
+	if resp.Statuses == nil {
+		resp.Statuses = []*influxdb.CheckStatus{}
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type checkDryRunResponse struct {
+	Statuses []*influxdb.CheckStatus `json:"statuses"`
+}
+
+// decodeCheckDryRunRange decodes the time range of a check dry run.
+func decodeCheckDryRunRange(r *http.Request) (start, stop time.Time, err error) {
+	qp := r.URL.Query()
+	if v := qp.Get("start"); v != "" {
+		if start, err = time.Parse(time.RFC3339, v); err != nil {
+			return start, stop, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "start is invalid, expected an RFC3339 time",
+				Err:  err,
+			}
+		}
+	}
+	if v := qp.Get("stop"); v != "" {
+		if stop, err = time.Parse(time.RFC3339, v); err != nil {
+			return start, stop, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "stop is invalid, expected an RFC3339 time",
+				Err:  err,
+			}
+		}
+	}
+	return start, stop, nil
+}
+
+func (h *CheckHandler) handlePostCheckDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetCheckRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	chk, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.dryRunCheck(w, r, chk)
+}
+
+// dryRunCheck responds with the statuses chk would have written over the
+// time range of the request.
+func (h *CheckHandler) dryRunCheck(w http.ResponseWriter, r *http.Request, chk influxdb.Check) {
+	ctx := r.Context()
+	start, stop, err := decodeCheckDryRunRange(r)
+	if err != nil {
+		h.log.Debug("Failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	statuses, err := h.CheckDryRunService.DryRunCheck(ctx, chk, start, stop)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Check dry run", zap.String("checkName", chk.GetName()), zap.Int("statuses", len(statuses)))
+
+	resp := checkDryRunResponse{Statuses: statuses}
+	if resp.Statuses == nil {
+		resp.Statuses = []*influxdb.CheckStatus{}
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
 type fluxResp struct {
 	Flux string `json:"flux"`
 }
@@ -540,6 +665,11 @@ func (h *CheckHandler) handlePostCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("dryRun") == "true" {
+		h.dryRunCheck(w, r, chk.Check)
+		return
+	}
+
 	auth, err := pctx.GetAuthorizer(ctx)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
@@ -831,7 +961,8 @@ func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
 }
 
 // TODO(gavincabbage): These structures should be in a common place, like other models,
-// 		but the common influxdb.Check is an interface that is not appropriate for an API client.
+//
+//	but the common influxdb.Check is an interface that is not appropriate for an API client.
 type Checks struct {
 	Checks []*Check              `json:"checks"`
 	Links  *influxdb.PagingLinks `json:"links"`
@@ -902,3 +1033,58 @@ type CheckThreshold struct {
 	Max    float64 `json:"max,omitempty"`
 	Within bool    `json:"within"`
 }
+
+// CheckDryRunService is a client to backtest checks over HTTP.
+type CheckDryRunService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.CheckDryRunService = (*CheckDryRunService)(nil)
+
+// DryRunCheck returns the statuses the check definition would have written
+// between start and stop.
+func (s *CheckDryRunService) DryRunCheck(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	params := append(checkDryRunParams(start, stop), [2]string{"dryRun", "true"})
+	var resp checkDryRunResponse
+	err := s.Client.
+		PostJSON(chk, prefixChecks).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Statuses, nil
+}
+
+// DryRunCheckByID returns the statuses the check would have written between
+// start and stop.
+func (s *CheckDryRunService) DryRunCheckByID(ctx context.Context, id influxdb.ID, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var resp checkDryRunResponse
+	err := s.Client.
+		Post(nil, checkIDPath(id), "dryRun").
+		QueryParams(checkDryRunParams(start, stop)...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Statuses, nil
+}
+
+func checkDryRunParams(start, stop time.Time) [][2]string {
+	var params [][2]string
+	if !start.IsZero() {
+		params = append(params, [2]string{"start", start.Format(time.RFC3339)})
+	}
+	if !stop.IsZero() {
+		params = append(params, [2]string{"stop", stop.Format(time.RFC3339)})
+	}
+	return params
+}
@@ -0,0 +1,305 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+// DBRPMappingBackend is all services and associated parameters required to construct
+// the DBRPMappingHandler.
+type DBRPMappingBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	DBRPMappingService influxdb.DBRPMappingService
+	BucketService      influxdb.BucketService
+}
+
+// NewDBRPMappingBackend returns a new instance of DBRPMappingBackend.
+func NewDBRPMappingBackend(log *zap.Logger, b *APIBackend) *DBRPMappingBackend {
+	return &DBRPMappingBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+}
+
+// DBRPMappingHandler is the handler for the dbrp mapping service. The mappings
+// route the database and retention policy of the influxdb 1.x api to buckets.
+type DBRPMappingHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	api *kithttp.API
+	log *zap.Logger
+
+	DBRPMappingService influxdb.DBRPMappingService
+	BucketService      influxdb.BucketService
+}
+
+const (
+	prefixDBRPs = "/api/v2/dbrps"
+)
+
+// NewDBRPMappingHandler returns a new instance of DBRPMappingHandler.
+func NewDBRPMappingHandler(log *zap.Logger, b *DBRPMappingBackend) *DBRPMappingHandler {
+	h := &DBRPMappingHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		api:              kithttp.NewAPI(kithttp.WithLog(log)),
+		log:              log,
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+
+	h.HandlerFunc("POST", prefixDBRPs, h.handlePostDBRP)
+	h.HandlerFunc("GET", prefixDBRPs, h.handleGetDBRPs)
+	h.HandlerFunc("DELETE", prefixDBRPs, h.handleDeleteDBRP)
+
+	return h
+}
+
+type dbrpResponse struct {
+	*influxdb.DBRPMapping
+	Links map[string]string `json:"links"`
+}
+
+func newDBRPResponse(m *influxdb.DBRPMapping) *dbrpResponse {
+	return &dbrpResponse{
+		DBRPMapping: m,
+		Links: map[string]string{
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", m.BucketID),
+			"org":    fmt.Sprintf("/api/v2/orgs/%s", m.OrganizationID),
+		},
+	}
+}
+
+type dbrpsResponse struct {
+	Links map[string]string `json:"links"`
+	DBRPs []*dbrpResponse   `json:"dbrps"`
+}
+
+// handlePostDBRP is the HTTP handler for the POST /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handlePostDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var m influxdb.DBRPMapping
+	if err := h.api.DecodeJSON(r.Body, &m); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if err := m.Validate(); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	// the mapping may only route to a bucket of its own organization.
+	b, err := h.BucketService.FindBucketByID(ctx, m.BucketID)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if b.OrgID != m.OrganizationID {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "bucket does not belong to the organization",
+		})
+		return
+	}
+
+	if err := h.DBRPMappingService.Create(ctx, &m); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("DBRP mapping created", zap.String("dbrp", fmt.Sprint(m)))
+
+	h.api.Respond(w, http.StatusCreated, newDBRPResponse(&m))
+}
+
+// handleGetDBRPs is the HTTP handler for the GET /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handleGetDBRPs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := decodeDBRPMappingFilter(r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	ms, _, err := h.DBRPMappingService.FindMany(ctx, filter)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	res := &dbrpsResponse{
+		Links: map[string]string{
+			"self": prefixDBRPs,
+		},
+		DBRPs: make([]*dbrpResponse, 0, len(ms)),
+	}
+	for _, m := range ms {
+		res.DBRPs = append(res.DBRPs, newDBRPResponse(m))
+	}
+	h.api.Respond(w, http.StatusOK, res)
+}
+
+func decodeDBRPMappingFilter(r *http.Request) (influxdb.DBRPMappingFilter, error) {
+	var filter influxdb.DBRPMappingFilter
+	q := r.URL.Query()
+	if orgID := q.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		filter.OrganizationID = id
+	}
+	if cluster := q.Get("cluster"); cluster != "" {
+		filter.Cluster = &cluster
+	}
+	if db := q.Get("db"); db != "" {
+		filter.Database = &db
+	}
+	if rp := q.Get("rp"); rp != "" {
+		filter.RetentionPolicy = &rp
+	}
+	if d := q.Get("default"); d != "" {
+		isDefault, err := strconv.ParseBool(d)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "default must be true or false",
+				Err:  err,
+			}
+		}
+		filter.Default = &isDefault
+	}
+	return filter, nil
+}
+
+// handleDeleteDBRP is the HTTP handler for the DELETE /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handleDeleteDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	cluster, db, rp := q.Get("cluster"), q.Get("db"), q.Get("rp")
+	if q.Get("orgID") == "" || cluster == "" || db == "" || rp == "" {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID, cluster, db and rp are required",
+		})
+		return
+	}
+	orgID, err := influxdb.IDFromString(q.Get("orgID"))
+	if err != nil {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID is invalid",
+			Err:  err,
+		})
+		return
+	}
+
+	if err := h.DBRPMappingService.Delete(ctx, *orgID, cluster, db, rp); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("DBRP mapping deleted", zap.Stringer("orgID", orgID), zap.String("cluster", cluster), zap.String("db", db), zap.String("rp", rp))
+
+	h.api.Respond(w, http.StatusNoContent, nil)
+}
+
+// DBRPMappingService connects to Influx via HTTP using tokens to manage dbrp mappings.
+type DBRPMappingService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// FindBy returns the dbrp mapping for the cluster, db and rp of the organization.
+func (s *DBRPMappingService) FindBy(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	return s.Find(ctx, influxdb.DBRPMappingFilter{
+		OrganizationID:  &orgID,
+		Cluster:         &cluster,
+		Database:        &db,
+		RetentionPolicy: &rp,
+	})
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	ms, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "dbrp mapping not found",
+		}
+	}
+	return ms[0], nil
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	var params [][2]string
+	if filter.OrganizationID != nil {
+		params = append(params, [2]string{"orgID", filter.OrganizationID.String()})
+	}
+	if filter.Cluster != nil {
+		params = append(params, [2]string{"cluster", *filter.Cluster})
+	}
+	if filter.Database != nil {
+		params = append(params, [2]string{"db", *filter.Database})
+	}
+	if filter.RetentionPolicy != nil {
+		params = append(params, [2]string{"rp", *filter.RetentionPolicy})
+	}
+	if filter.Default != nil {
+		params = append(params, [2]string{"default", strconv.FormatBool(*filter.Default)})
+	}
+
+	var resp dbrpsResponse
+	err := s.Client.
+		Get(prefixDBRPs).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ms := make([]*influxdb.DBRPMapping, 0, len(resp.DBRPs))
+	for _, m := range resp.DBRPs {
+		ms = append(ms, m.DBRPMapping)
+	}
+	return ms, len(ms), nil
+}
+
+// Create creates a new dbrp mapping.
+func (s *DBRPMappingService) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
+	return s.Client.
+		PostJSON(m, prefixDBRPs).
+		Do(ctx)
+}
+
+// Delete removes a dbrp mapping of the organization.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) error {
+	return s.Client.
+		Delete(prefixDBRPs).
+		QueryParams([2]string{"orgID", orgID.String()}, [2]string{"cluster", cluster}, [2]string{"db", db}, [2]string{"rp", rp}).
+		Do(ctx)
+}
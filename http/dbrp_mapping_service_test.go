@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDBRPMappingService(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewDBRPMappingService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	buckets := mock.NewBucketService()
+	buckets.FindBucketByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+		return &influxdb.Bucket{ID: id, OrgID: 10, Name: "telegraf"}, nil
+	}
+
+	backend := &DBRPMappingBackend{
+		HTTPErrorHandler:   kithttp.ErrorHandler(0),
+		log:                zaptest.NewLogger(t),
+		DBRPMappingService: svc,
+		BucketService:      buckets,
+	}
+	server := httptest.NewServer(NewDBRPMappingHandler(zaptest.NewLogger(t), backend))
+	defer server.Close()
+
+	s := &DBRPMappingService{Client: mustNewHTTPClient(t, server.URL, "")}
+
+	m := &influxdb.DBRPMapping{
+		Cluster:         "default",
+		Database:        "telegraf",
+		RetentionPolicy: "autogen",
+		Default:         true,
+		OrganizationID:  10,
+		BucketID:        1,
+	}
+	if err := s.Create(ctx, m); err != nil {
+		t.Fatal(err)
+	}
+
+	db := "telegraf"
+	isDefault := true
+	orgID := influxdb.ID(10)
+	got, err := s.Find(ctx, influxdb.DBRPMappingFilter{OrganizationID: &orgID, Database: &db, Default: &isDefault})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(m) {
+		t.Fatalf("unexpected mapping %+v", got)
+	}
+
+	other := *m
+	other.RetentionPolicy = "other"
+	other.OrganizationID = 11
+	if err := s.Create(ctx, &other); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for a bucket of another organization, got %v", err)
+	}
+
+	otherOrgID := influxdb.ID(11)
+	if ms, _, err := s.FindMany(ctx, influxdb.DBRPMappingFilter{OrganizationID: &otherOrgID}); err != nil || len(ms) != 0 {
+		t.Fatalf("expected no mappings of another organization, got %v: %v", ms, err)
+	}
+
+	if err := s.Delete(ctx, 10, "default", "telegraf", "autogen"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindBy(ctx, 10, "default", "telegraf", "autogen"); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected deleted mapping to be not found, got %v", err)
+	}
+
+	if err := s.Delete(ctx, 10, "default", "", "autogen"); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error without a database, got %v", err)
+	}
+}
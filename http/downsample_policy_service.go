@@ -0,0 +1,183 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+const (
+	bucketsIDDownsamplePoliciesPath   = "/api/v2/buckets/:id/downsamplePolicies"
+	bucketsIDDownsamplePoliciesIDPath = "/api/v2/buckets/:id/downsamplePolicies/:policyID"
+)
+
+type downsamplePolicyResponse struct {
+	*influxdb.DownsamplePolicy
+	Links map[string]string `json:"links"`
+}
+
+func newDownsamplePolicyResponse(p *influxdb.DownsamplePolicy) *downsamplePolicyResponse {
+	return &downsamplePolicyResponse{
+		DownsamplePolicy: p,
+		Links: map[string]string{
+			"self":        fmt.Sprintf("/api/v2/buckets/%s/downsamplePolicies/%s", p.BucketID, p.ID),
+			"bucket":      fmt.Sprintf("/api/v2/buckets/%s", p.BucketID),
+			"destination": fmt.Sprintf("/api/v2/buckets/%s", p.DestinationBucketID),
+			"task":        fmt.Sprintf("/api/v2/tasks/%s", p.TaskID),
+		},
+	}
+}
+
+type downsamplePoliciesResponse struct {
+	Links    map[string]string           `json:"links"`
+	Policies []*downsamplePolicyResponse `json:"downsamplePolicies"`
+}
+
+// handleGetDownsamplePolicies is the HTTP handler for the GET /api/v2/buckets/:id/downsamplePolicies route.
+func (h *BucketHandler) handleGetDownsamplePolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bucketID, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	ps, _, err := h.DownsamplePolicyService.FindDownsamplePolicies(ctx, influxdb.DownsamplePolicyFilter{BucketID: &bucketID})
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	res := &downsamplePoliciesResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/buckets/%s/downsamplePolicies", bucketID),
+		},
+		Policies: make([]*downsamplePolicyResponse, 0, len(ps)),
+	}
+	for _, p := range ps {
+		res.Policies = append(res.Policies, newDownsamplePolicyResponse(p))
+	}
+	h.api.Respond(w, http.StatusOK, res)
+}
+
+// handlePostDownsamplePolicy is the HTTP handler for the POST /api/v2/buckets/:id/downsamplePolicies route.
+func (h *BucketHandler) handlePostDownsamplePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bucketID, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, bucketID)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var p influxdb.DownsamplePolicy
+	if err := h.api.DecodeJSON(r.Body, &p); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	p.ID = 0
+	p.TaskID = 0
+	p.OrgID = b.OrgID
+	p.BucketID = b.ID
+
+	if err := h.DownsamplePolicyService.CreateDownsamplePolicy(ctx, &p, auth.GetUserID()); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Downsample policy created", zap.String("policy", fmt.Sprint(p)))
+
+	h.api.Respond(w, http.StatusCreated, newDownsamplePolicyResponse(&p))
+}
+
+// handleGetDownsamplePolicy is the HTTP handler for the GET /api/v2/buckets/:id/downsamplePolicies/:policyID route.
+func (h *BucketHandler) handleGetDownsamplePolicy(w http.ResponseWriter, r *http.Request) {
+	p, err := h.findBucketDownsamplePolicy(r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	h.api.Respond(w, http.StatusOK, newDownsamplePolicyResponse(p))
+}
+
+// handlePatchDownsamplePolicy is the HTTP handler for the PATCH /api/v2/buckets/:id/downsamplePolicies/:policyID route.
+func (h *BucketHandler) handlePatchDownsamplePolicy(w http.ResponseWriter, r *http.Request) {
+	p, err := h.findBucketDownsamplePolicy(r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var upd influxdb.DownsamplePolicyUpdate
+	if err := h.api.DecodeJSON(r.Body, &upd); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	p, err = h.DownsamplePolicyService.UpdateDownsamplePolicy(r.Context(), p.ID, upd)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Downsample policy updated", zap.String("policy", fmt.Sprint(p)))
+
+	h.api.Respond(w, http.StatusOK, newDownsamplePolicyResponse(p))
+}
+
+// handleDeleteDownsamplePolicy is the HTTP handler for the DELETE /api/v2/buckets/:id/downsamplePolicies/:policyID route.
+func (h *BucketHandler) handleDeleteDownsamplePolicy(w http.ResponseWriter, r *http.Request) {
+	p, err := h.findBucketDownsamplePolicy(r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	if err := h.DownsamplePolicyService.DeleteDownsamplePolicy(r.Context(), p.ID); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Downsample policy deleted", zap.String("policyID", p.ID.String()))
+
+	h.api.Respond(w, http.StatusNoContent, nil)
+}
+
+// findBucketDownsamplePolicy returns the downsample policy of the request path,
+// which must belong to the bucket of the request path.
+func (h *BucketHandler) findBucketDownsamplePolicy(r *http.Request) (*influxdb.DownsamplePolicy, error) {
+	ctx := r.Context()
+	bucketID, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	id, err := decodeIDFromCtx(ctx, "policyID")
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.DownsamplePolicyService.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.BucketID != bucketID {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Op:   influxdb.OpFindDownsamplePolicyByID,
+			Msg:  "downsample policy not found",
+		}
+	}
+	return p, nil
+}
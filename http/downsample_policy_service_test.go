@@ -0,0 +1,87 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestService_handlePostDownsamplePolicy(t *testing.T) {
+	var created *influxdb.DownsamplePolicy
+	bucketBackend := NewMockBucketBackend(t)
+	bucketBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	bucketBackend.BucketService = &mock.BucketService{
+		FindBucketByIDFn: func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+			return &influxdb.Bucket{ID: id, OrgID: 10, Name: "raw"}, nil
+		},
+	}
+	bucketBackend.DownsamplePolicyService = &mock.DownsamplePolicyService{
+		CreateDownsamplePolicyFn: func(_ context.Context, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+			if userID != 6 {
+				t.Errorf("expected policy created by user 6, got %s", userID)
+			}
+			p.ID = 1
+			p.TaskID = 4
+			created = p
+			return nil
+		},
+	}
+	h := NewBucketHandler(zaptest.NewLogger(t), bucketBackend)
+
+	body := `{"bucketID": "0000000000000009", "destinationBucketID": "0000000000000003", "every": "1h", "aggregates": {"float": "mean"}}`
+	r := httptest.NewRequest("POST", "http://any.url/api/v2/buckets/0000000000000002/downsamplePolicies", bytes.NewBufferString(body))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: 6}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	if created.OrgID != 10 || created.BucketID != 2 || created.DestinationBucketID != 3 ||
+		created.Every.String() != "1h" || created.Aggregates.Float != "mean" {
+		t.Fatalf("unexpected policy created: %+v", created)
+	}
+
+	var res struct {
+		ID     influxdb.ID       `json:"id"`
+		TaskID influxdb.ID       `json:"taskID"`
+		Links  map[string]string `json:"links"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.ID != 1 || res.TaskID != 4 {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+	if got := res.Links["task"]; got != "/api/v2/tasks/0000000000000004" {
+		t.Fatalf("unexpected task link %q", got)
+	}
+}
+
+func TestService_handleGetDownsamplePolicy_OtherBucket(t *testing.T) {
+	bucketBackend := NewMockBucketBackend(t)
+	bucketBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	bucketBackend.DownsamplePolicyService = &mock.DownsamplePolicyService{
+		FindDownsamplePolicyByIDFn: func(_ context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+			return &influxdb.DownsamplePolicy{ID: id, BucketID: 5, DestinationBucketID: 3}, nil
+		},
+	}
+	h := NewBucketHandler(zaptest.NewLogger(t), bucketBackend)
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/buckets/0000000000000002/downsamplePolicies/0000000000000001", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+	}
+}
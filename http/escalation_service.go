@@ -0,0 +1,439 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+// EscalationPolicyBackend is all services and associated parameters required
+// to construct the EscalationPolicyHandler.
+type EscalationPolicyBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	EscalationPolicyService influxdb.EscalationPolicyService
+	OrganizationService     influxdb.OrganizationService
+}
+
+// NewEscalationPolicyBackend returns a new instance of EscalationPolicyBackend.
+func NewEscalationPolicyBackend(log *zap.Logger, b *APIBackend) *EscalationPolicyBackend {
+	return &EscalationPolicyBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		EscalationPolicyService: b.EscalationPolicyService,
+		OrganizationService:     b.OrganizationService,
+	}
+}
+
+// EscalationPolicyHandler is the handler for the escalation policy service.
+type EscalationPolicyHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	api *kithttp.API
+	log *zap.Logger
+
+	EscalationPolicyService influxdb.EscalationPolicyService
+	OrganizationService     influxdb.OrganizationService
+}
+
+const (
+	prefixEscalationPolicies            = "/api/v2/escalationPolicies"
+	escalationPoliciesIDPath            = "/api/v2/escalationPolicies/:id"
+	escalationPoliciesIDEscalationsPath = "/api/v2/escalationPolicies/:id/escalations"
+	escalationPoliciesIDAcknowledgePath = "/api/v2/escalationPolicies/:id/acknowledge"
+)
+
+// NewEscalationPolicyHandler returns a new instance of EscalationPolicyHandler.
+func NewEscalationPolicyHandler(log *zap.Logger, b *EscalationPolicyBackend) *EscalationPolicyHandler {
+	h := &EscalationPolicyHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		api:              kithttp.NewAPI(kithttp.WithLog(log)),
+		log:              log,
+
+		EscalationPolicyService: b.EscalationPolicyService,
+		OrganizationService:     b.OrganizationService,
+	}
+
+	h.HandlerFunc("POST", prefixEscalationPolicies, h.handlePostEscalationPolicy)
+	h.HandlerFunc("GET", prefixEscalationPolicies, h.handleGetEscalationPolicies)
+	h.HandlerFunc("GET", escalationPoliciesIDPath, h.handleGetEscalationPolicy)
+	h.HandlerFunc("PATCH", escalationPoliciesIDPath, h.handlePatchEscalationPolicy)
+	h.HandlerFunc("DELETE", escalationPoliciesIDPath, h.handleDeleteEscalationPolicy)
+	h.HandlerFunc("GET", escalationPoliciesIDEscalationsPath, h.handleGetEscalations)
+	h.HandlerFunc("POST", escalationPoliciesIDAcknowledgePath, h.handlePostAcknowledgeEscalation)
+
+	return h
+}
+
+type escalationPolicyResponse struct {
+	*influxdb.EscalationPolicy
+	Links map[string]string `json:"links"`
+}
+
+func newEscalationPolicyResponse(p *influxdb.EscalationPolicy) *escalationPolicyResponse {
+	return &escalationPolicyResponse{
+		EscalationPolicy: p,
+		Links: map[string]string{
+			"self":        fmt.Sprintf("/api/v2/escalationPolicies/%s", p.ID),
+			"escalations": fmt.Sprintf("/api/v2/escalationPolicies/%s/escalations", p.ID),
+			"rule":        fmt.Sprintf("/api/v2/notificationRules/%s", p.RuleID),
+			"org":         fmt.Sprintf("/api/v2/orgs/%s", p.OrgID),
+		},
+	}
+}
+
+type escalationPoliciesResponse struct {
+	Links              map[string]string           `json:"links"`
+	EscalationPolicies []*escalationPolicyResponse `json:"escalationPolicies"`
+}
+
+type escalationsResponse struct {
+	Links       map[string]string      `json:"links"`
+	Escalations []*influxdb.Escalation `json:"escalations"`
+}
+
+type acknowledgeEscalationRequest struct {
+	Key string `json:"key"`
+}
+
+// handlePostEscalationPolicy is the HTTP handler for the POST /api/v2/escalationPolicies route.
+func (h *EscalationPolicyHandler) handlePostEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var p influxdb.EscalationPolicy
+	if err := h.api.DecodeJSON(r.Body, &p); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if !p.OrgID.Valid() {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "organization id must be provided",
+		})
+		return
+	}
+	p.ID = 0
+
+	if err := h.EscalationPolicyService.CreateEscalationPolicy(ctx, &p, auth.GetUserID()); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Escalation policy created", zap.String("escalationPolicy", fmt.Sprint(p)))
+
+	h.api.Respond(w, http.StatusCreated, newEscalationPolicyResponse(&p))
+}
+
+// handleGetEscalationPolicies is the HTTP handler for the GET /api/v2/escalationPolicies route.
+func (h *EscalationPolicyHandler) handleGetEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := h.decodeEscalationPolicyFilter(ctx, r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	ps, _, err := h.EscalationPolicyService.FindEscalationPolicies(ctx, filter)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	res := &escalationPoliciesResponse{
+		Links: map[string]string{
+			"self": prefixEscalationPolicies,
+		},
+		EscalationPolicies: make([]*escalationPolicyResponse, 0, len(ps)),
+	}
+	for _, p := range ps {
+		res.EscalationPolicies = append(res.EscalationPolicies, newEscalationPolicyResponse(p))
+	}
+	h.api.Respond(w, http.StatusOK, res)
+}
+
+func (h *EscalationPolicyHandler) decodeEscalationPolicyFilter(ctx context.Context, r *http.Request) (influxdb.EscalationPolicyFilter, error) {
+	var filter influxdb.EscalationPolicyFilter
+	q := r.URL.Query()
+	if orgID := q.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		filter.OrgID = id
+	} else if org := q.Get("org"); org != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+		if err != nil {
+			return filter, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	if ruleID := q.Get("ruleID"); ruleID != "" {
+		id, err := influxdb.IDFromString(ruleID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "ruleID is invalid",
+				Err:  err,
+			}
+		}
+		filter.RuleID = id
+	}
+	return filter, nil
+}
+
+// handleGetEscalationPolicy is the HTTP handler for the GET /api/v2/escalationPolicies/:id route.
+func (h *EscalationPolicyHandler) handleGetEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	p, err := h.EscalationPolicyService.FindEscalationPolicyByID(ctx, id)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	h.api.Respond(w, http.StatusOK, newEscalationPolicyResponse(p))
+}
+
+// handlePatchEscalationPolicy is the HTTP handler for the PATCH /api/v2/escalationPolicies/:id route.
+func (h *EscalationPolicyHandler) handlePatchEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var upd influxdb.EscalationPolicyUpdate
+	if err := h.api.DecodeJSON(r.Body, &upd); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	p, err := h.EscalationPolicyService.UpdateEscalationPolicy(ctx, id, upd)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Escalation policy updated", zap.String("escalationPolicy", fmt.Sprint(p)))
+
+	h.api.Respond(w, http.StatusOK, newEscalationPolicyResponse(p))
+}
+
+// handleDeleteEscalationPolicy is the HTTP handler for the DELETE /api/v2/escalationPolicies/:id route.
+func (h *EscalationPolicyHandler) handleDeleteEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	if err := h.EscalationPolicyService.DeleteEscalationPolicy(ctx, id); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Escalation policy deleted", zap.String("escalationPolicyID", id.String()))
+
+	h.api.Respond(w, http.StatusNoContent, nil)
+}
+
+// handleGetEscalations is the HTTP handler for the GET /api/v2/escalationPolicies/:id/escalations route.
+func (h *EscalationPolicyHandler) handleGetEscalations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	es, err := h.EscalationPolicyService.FindEscalations(ctx, id)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if es == nil {
+		es = []*influxdb.Escalation{}
+	}
+
+	h.api.Respond(w, http.StatusOK, &escalationsResponse{
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/escalationPolicies/%s/escalations", id),
+			"policy": fmt.Sprintf("/api/v2/escalationPolicies/%s", id),
+		},
+		Escalations: es,
+	})
+}
+
+// handlePostAcknowledgeEscalation is the HTTP handler for the POST /api/v2/escalationPolicies/:id/acknowledge route.
+func (h *EscalationPolicyHandler) handlePostAcknowledgeEscalation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var req acknowledgeEscalationRequest
+	if err := h.api.DecodeJSON(r.Body, &req); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if req.Key == "" {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "escalation key must be provided",
+		})
+		return
+	}
+
+	e, err := h.EscalationPolicyService.AcknowledgeEscalation(ctx, id, req.Key, auth.GetUserID())
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Escalation acknowledged", zap.String("escalationPolicyID", id.String()), zap.String("key", req.Key))
+
+	h.api.Respond(w, http.StatusOK, e)
+}
+
+// EscalationPolicyService connects to Influx via HTTP using tokens to manage
+// escalation policies.
+type EscalationPolicyService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.EscalationPolicyService = (*EscalationPolicyService)(nil)
+
+// FindEscalationPolicyByID returns a single escalation policy by ID.
+func (s *EscalationPolicyService) FindEscalationPolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.EscalationPolicy, error) {
+	var resp escalationPolicyResponse
+	err := s.Client.
+		Get(prefixEscalationPolicies, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.EscalationPolicy, nil
+}
+
+// FindEscalationPolicies returns a list of escalation policies that match
+// filter and the total count of matching escalation policies.
+func (s *EscalationPolicyService) FindEscalationPolicies(ctx context.Context, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+	var params [][2]string
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+	if filter.RuleID != nil {
+		params = append(params, [2]string{"ruleID", filter.RuleID.String()})
+	}
+
+	var resp escalationPoliciesResponse
+	err := s.Client.
+		Get(prefixEscalationPolicies).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ps := make([]*influxdb.EscalationPolicy, 0, len(resp.EscalationPolicies))
+	for _, pr := range resp.EscalationPolicies {
+		ps = append(ps, pr.EscalationPolicy)
+	}
+	return ps, len(ps), nil
+}
+
+// CreateEscalationPolicy creates a new escalation policy and sets p.ID with
+// the new identifier.
+func (s *EscalationPolicyService) CreateEscalationPolicy(ctx context.Context, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+	var resp escalationPolicyResponse
+	err := s.Client.
+		PostJSON(p, prefixEscalationPolicies).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	*p = *resp.EscalationPolicy
+	return nil
+}
+
+// UpdateEscalationPolicy updates a single escalation policy with changeset.
+// Returns the new escalation policy state after update.
+func (s *EscalationPolicyService) UpdateEscalationPolicy(ctx context.Context, id influxdb.ID, upd influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+	var resp escalationPolicyResponse
+	err := s.Client.
+		PatchJSON(upd, prefixEscalationPolicies, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.EscalationPolicy, nil
+}
+
+// DeleteEscalationPolicy removes an escalation policy by ID.
+func (s *EscalationPolicyService) DeleteEscalationPolicy(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixEscalationPolicies, id.String()).
+		Do(ctx)
+}
+
+// FindEscalations returns the ongoing escalations of the escalation policy.
+func (s *EscalationPolicyService) FindEscalations(ctx context.Context, policyID influxdb.ID) ([]*influxdb.Escalation, error) {
+	var resp escalationsResponse
+	err := s.Client.
+		Get(prefixEscalationPolicies, policyID.String(), "escalations").
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Escalations, nil
+}
+
+// AcknowledgeEscalation stops the escalation with key of the escalation
+// policy until its check returns to ok.
+func (s *EscalationPolicyService) AcknowledgeEscalation(ctx context.Context, policyID influxdb.ID, key string, userID influxdb.ID) (*influxdb.Escalation, error) {
+	var e influxdb.Escalation
+	err := s.Client.
+		PostJSON(acknowledgeEscalationRequest{Key: key}, prefixEscalationPolicies, policyID.String(), "acknowledge").
+		DecodeJSON(&e).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/options"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestEscalationPolicyService(t *testing.T) {
+	since := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	policies := map[influxdb.ID]*influxdb.EscalationPolicy{}
+	escalation := &influxdb.Escalation{
+		PolicyID:      1,
+		Key:           "0000000000000002,host=web-1",
+		CheckID:       2,
+		Level:         "crit",
+		Since:         since,
+		NotifiedSteps: []int{0},
+	}
+
+	svc := mock.NewEscalationPolicyService()
+	svc.CreateEscalationPolicyFn = func(_ context.Context, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+		if userID != 6 {
+			t.Errorf("expected escalation policy created by user 6, got %s", userID)
+		}
+		p.ID = 1
+		p.OwnerID = userID
+		policies[p.ID] = p
+		return nil
+	}
+	svc.FindEscalationPoliciesFn = func(_ context.Context, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+		if filter.RuleID == nil || *filter.RuleID != 3 {
+			t.Errorf("unexpected rule filter %v", filter.RuleID)
+		}
+		var ps []*influxdb.EscalationPolicy
+		for _, p := range policies {
+			ps = append(ps, p)
+		}
+		return ps, len(ps), nil
+	}
+	svc.UpdateEscalationPolicyFn = func(_ context.Context, id influxdb.ID, upd influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+		p, ok := policies[id]
+		if !ok {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "escalation policy not found"}
+		}
+		upd.Apply(p)
+		return p, nil
+	}
+	svc.DeleteEscalationPolicyFn = func(_ context.Context, id influxdb.ID) error {
+		delete(policies, id)
+		return nil
+	}
+	svc.FindEscalationsFn = func(context.Context, influxdb.ID) ([]*influxdb.Escalation, error) {
+		return []*influxdb.Escalation{escalation}, nil
+	}
+	svc.AcknowledgeEscalationFn = func(_ context.Context, policyID influxdb.ID, key string, userID influxdb.ID) (*influxdb.Escalation, error) {
+		if key != escalation.Key {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "escalation not found"}
+		}
+		escalation.AcknowledgedAt = &since
+		escalation.AcknowledgedBy = userID
+		return escalation, nil
+	}
+
+	backend := &EscalationPolicyBackend{
+		HTTPErrorHandler:        kithttp.ErrorHandler(0),
+		log:                     zaptest.NewLogger(t),
+		EscalationPolicyService: svc,
+	}
+	h := NewEscalationPolicyHandler(zaptest.NewLogger(t), backend)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: 6}))
+		h.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	s := &EscalationPolicyService{Client: mustNewHTTPClient(t, server.URL, "")}
+	ctx := context.Background()
+
+	p := &influxdb.EscalationPolicy{
+		OrgID:  10,
+		RuleID: 3,
+		Name:   "cpu",
+		Steps: []influxdb.EscalationStep{
+			{Delay: *options.MustParseDuration("0s"), Level: "warn", EndpointID: 4},
+			{Delay: *options.MustParseDuration("15m"), Level: "crit", EndpointID: 5},
+		},
+	}
+	if err := s.CreateEscalationPolicy(ctx, p, 6); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 1 || p.OwnerID != 6 {
+		t.Fatalf("unexpected escalation policy created %+v", p)
+	}
+
+	ruleID := influxdb.ID(3)
+	ps, n, err := s.FindEscalationPolicies(ctx, influxdb.EscalationPolicyFilter{RuleID: &ruleID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ps[0].Steps[1].Delay.String() != "15m" || ps[0].Steps[1].EndpointID != 5 {
+		t.Fatalf("unexpected escalation policies %+v", ps)
+	}
+
+	name := "cpu escalation"
+	upd, err := s.UpdateEscalationPolicy(ctx, p.ID, influxdb.EscalationPolicyUpdate{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upd.Name != name {
+		t.Fatalf("unexpected escalation policy updated %+v", upd)
+	}
+
+	es, err := s.FindEscalations(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 || es[0].Key != escalation.Key || !es[0].Since.Equal(since) {
+		t.Fatalf("unexpected escalations %+v", es)
+	}
+
+	e, err := s.AcknowledgeEscalation(ctx, p.ID, escalation.Key, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.AcknowledgedAt == nil || e.AcknowledgedBy != 6 {
+		t.Fatalf("unexpected escalation acknowledged %+v", e)
+	}
+	if _, err := s.AcknowledgeEscalation(ctx, p.ID, "unknown", 6); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected unknown escalation to be not found, got %v", err)
+	}
+
+	if err := s.DeleteEscalationPolicy(ctx, p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateEscalationPolicy(ctx, p.ID, influxdb.EscalationPolicyUpdate{Name: &name}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected deleted escalation policy to be not found, got %v", err)
+	}
+}
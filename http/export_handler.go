@@ -0,0 +1,309 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	http "net/http"
+	"time"
+
+	"github.com/NYTimes/gziphandler"
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+// ExportBackend is all services and associated parameters required to construct
+// the ExportHandler.
+type ExportBackend struct {
+	log *zap.Logger
+	influxdb.HTTPErrorHandler
+
+	ExportService       influxdb.ExportService
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewExportBackend returns a new instance of ExportBackend.
+func NewExportBackend(log *zap.Logger, b *APIBackend) *ExportBackend {
+	return &ExportBackend{
+		log: log,
+
+		HTTPErrorHandler:    b.HTTPErrorHandler,
+		ExportService:       b.ExportService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// ExportHandler streams the data of a bucket as line protocol or annotated CSV.
+type ExportHandler struct {
+	influxdb.HTTPErrorHandler
+	*httprouter.Router
+
+	log *zap.Logger
+
+	ExportService       influxdb.ExportService
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+}
+
+const (
+	prefixExport = "/api/v2/export"
+)
+
+// NewExportHandler creates a new handler at /api/v2/export to receive export requests.
+func NewExportHandler(log *zap.Logger, b *ExportBackend) *ExportHandler {
+	h := &ExportHandler{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Router:           NewRouter(b.HTTPErrorHandler),
+		log:              log,
+
+		ExportService:       b.ExportService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	// exports can optionally be gzip encoded
+	h.Handler("POST", prefixExport, gziphandler.GzipHandler(http.HandlerFunc(h.handleExport)))
+	return h
+}
+
+func (h *ExportHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "ExportHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	er, err := decodeExportRequest(ctx, r, h.OrganizationService, h.BucketService)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	p, err := influxdb.NewPermissionAtID(er.Bucket.ID, influxdb.ReadAction, influxdb.BucketsResourceType, er.Org.ID)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   "http/handleExport",
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if !a.Allowed(*p) {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Op:   "http/handleExport",
+			Msg:  "insufficient permissions to export",
+		}, w)
+		return
+	}
+
+	switch er.Format {
+	case influxdb.ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+
+	ew := &exportResponseWriter{w: w}
+	err = h.ExportService.ExportBucket(ctx, influxdb.ExportFilter{
+		OrgID:     er.Org.ID,
+		BucketID:  er.Bucket.ID,
+		Start:     er.Start,
+		Stop:      er.Stop,
+		Predicate: er.Predicate,
+	}, er.Format, ew)
+	if err != nil {
+		if !ew.written {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		// The status has been sent already; the client sees a truncated response.
+		h.log.Error("Failed to export bucket",
+			zap.String("orgID", er.Org.ID.String()),
+			zap.String("bucketID", er.Bucket.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// exportResponseWriter records whether the response has been started.
+type exportResponseWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.w.Write(p)
+}
+
+func decodeExportRequest(ctx context.Context, r *http.Request, orgSvc influxdb.OrganizationService, bucketSvc influxdb.BucketService) (*exportRequest, error) {
+	er := new(exportRequest)
+	if err := json.NewDecoder(r.Body).Decode(er); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid request; error parsing request json",
+			Err:  err,
+		}
+	}
+
+	var err error
+	if er.Org, err = queryOrganization(ctx, r, orgSvc); err != nil {
+		return nil, err
+	}
+
+	if er.Bucket, err = queryBucket(ctx, r, bucketSvc); err != nil {
+		return nil, err
+	}
+
+	if er.Bucket.OrgID != er.Org.ID {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "bucket not found",
+		}
+	}
+	return er, nil
+}
+
+type exportRequest struct {
+	Org       *influxdb.Organization
+	Bucket    *influxdb.Bucket
+	Start     int64
+	Stop      int64
+	Predicate string
+	Format    influxdb.ExportFormat
+}
+
+func (er *exportRequest) UnmarshalJSON(b []byte) error {
+	var erd ExportRequest
+	if err := json.Unmarshal(b, &erd); err != nil {
+		return err
+	}
+
+	*er = exportRequest{
+		Start:     math.MinInt64,
+		Stop:      math.MaxInt64,
+		Predicate: erd.Predicate,
+		Format:    influxdb.ExportFormat(erd.Format),
+	}
+
+	if er.Format == "" {
+		er.Format = influxdb.ExportFormatLineProtocol
+	}
+	if err := er.Format.Valid(); err != nil {
+		return err
+	}
+
+	if erd.Start != "" {
+		start, err := time.Parse(time.RFC3339Nano, erd.Start)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   "http/Export",
+				Msg:  "invalid RFC3339Nano for field start, please format your time with RFC3339Nano format, example: 2009-01-02T23:00:00Z",
+			}
+		}
+		er.Start = start.UnixNano()
+	}
+
+	if erd.Stop != "" {
+		stop, err := time.Parse(time.RFC3339Nano, erd.Stop)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   "http/Export",
+				Msg:  "invalid RFC3339Nano for field stop, please format your time with RFC3339Nano format, example: 2009-01-01T23:00:00Z",
+			}
+		}
+		er.Stop = stop.UnixNano()
+	}
+	return nil
+}
+
+// ExportRequest is the request sent over http to export the data of a bucket.
+type ExportRequest struct {
+	OrgID     string `json:"-"`
+	Org       string `json:"-"` // org name
+	BucketID  string `json:"-"`
+	Bucket    string `json:"-"`
+	Start     string `json:"start,omitempty"`
+	Stop      string `json:"stop,omitempty"`
+	Predicate string `json:"predicate,omitempty"`
+	Format    string `json:"format,omitempty"`
+}
+
+// ExportService sends export requests over HTTP.
+type ExportService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// ExportBucket streams the exported data of a bucket to w.
+func (s *ExportService) ExportBucket(ctx context.Context, er ExportRequest, w io.Writer) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, prefixExport)
+	if err != nil {
+		return err
+	}
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(er); err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", u.String(), buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	SetToken(s.Token, req)
+
+	params := req.URL.Query()
+	if er.OrgID != "" {
+		params.Set("orgID", er.OrgID)
+	} else if er.Org != "" {
+		params.Set("org", er.Org)
+	}
+
+	if er.BucketID != "" {
+		params.Set("bucketID", er.BucketID)
+	} else if er.Bucket != "" {
+		params.Set("bucket", er.Bucket)
+	}
+	req.URL.RawQuery = params.Encode()
+	req = req.WithContext(ctx)
+
+	// The response is gzip encoded by the server and transparently
+	// decompressed by the client transport.
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	influxtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExportHandler_handleExport(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+	)
+
+	readBucket := func(orgID, bucketID influxdb.ID) influxdb.Permission {
+		return influxdb.Permission{
+			Action: influxdb.ReadAction,
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				OrgID: influxtesting.IDPtr(orgID),
+				ID:    influxtesting.IDPtr(bucketID),
+			},
+		}
+	}
+
+	type wants struct {
+		err    string
+		code   string
+		filter influxdb.ExportFilter
+		format influxdb.ExportFormat
+		body   string
+	}
+
+	tests := []struct {
+		name        string
+		req         ExportRequest
+		permission  influxdb.Permission
+		bucketOrgID influxdb.ID
+		wants       wants
+	}{
+		{
+			name: "line protocol of the whole bucket",
+			req: ExportRequest{
+				OrgID:    orgID.String(),
+				BucketID: bucketID.String(),
+			},
+			permission:  readBucket(orgID, bucketID),
+			bucketOrgID: orgID,
+			wants: wants{
+				filter: influxdb.ExportFilter{
+					OrgID:    orgID,
+					BucketID: bucketID,
+					Start:    math.MinInt64,
+					Stop:     math.MaxInt64,
+				},
+				format: influxdb.ExportFormatLineProtocol,
+				body:   "m,t=a f=1 10\n",
+			},
+		},
+		{
+			name: "csv of a time range matching a predicate",
+			req: ExportRequest{
+				OrgID:     orgID.String(),
+				BucketID:  bucketID.String(),
+				Start:     "2020-01-01T00:00:00Z",
+				Stop:      "2020-01-02T00:00:00Z",
+				Predicate: `host="a"`,
+				Format:    string(influxdb.ExportFormatCSV),
+			},
+			permission:  readBucket(orgID, bucketID),
+			bucketOrgID: orgID,
+			wants: wants{
+				filter: influxdb.ExportFilter{
+					OrgID:     orgID,
+					BucketID:  bucketID,
+					Start:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+					Stop:      time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).UnixNano(),
+					Predicate: `host="a"`,
+				},
+				format: influxdb.ExportFormatCSV,
+				body:   "m,t=a f=1 10\n",
+			},
+		},
+		{
+			name: "insufficient permissions",
+			req: ExportRequest{
+				OrgID:    orgID.String(),
+				BucketID: bucketID.String(),
+			},
+			permission:  readBucket(orgID, 3),
+			bucketOrgID: orgID,
+			wants: wants{
+				code: influxdb.EForbidden,
+				err:  "insufficient permissions to export",
+			},
+		},
+		{
+			name: "bucket of another organization",
+			req: ExportRequest{
+				OrgID:    orgID.String(),
+				BucketID: bucketID.String(),
+			},
+			permission:  readBucket(orgID, bucketID),
+			bucketOrgID: 4,
+			wants: wants{
+				code: influxdb.ENotFound,
+				err:  "bucket not found",
+			},
+		},
+		{
+			name: "invalid start",
+			req: ExportRequest{
+				OrgID:    orgID.String(),
+				BucketID: bucketID.String(),
+				Start:    "yesterday",
+			},
+			permission:  readBucket(orgID, bucketID),
+			bucketOrgID: orgID,
+			wants: wants{
+				code: influxdb.EInvalid,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				filter influxdb.ExportFilter
+				format influxdb.ExportFormat
+			)
+			backend := &ExportBackend{
+				log:              zaptest.NewLogger(t),
+				HTTPErrorHandler: kithttp.ErrorHandler(0),
+				ExportService: &mock.ExportService{
+					ExportBucketFn: func(ctx context.Context, f influxdb.ExportFilter, fmt influxdb.ExportFormat, w io.Writer) error {
+						filter, format = f, fmt
+						_, err := io.WriteString(w, "m,t=a f=1 10\n")
+						return err
+					},
+				},
+				BucketService: &mock.BucketService{
+					FindBucketFn: func(ctx context.Context, f influxdb.BucketFilter) (*influxdb.Bucket, error) {
+						return &influxdb.Bucket{ID: *f.ID, OrgID: tt.bucketOrgID}, nil
+					},
+				},
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, f influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+						return &influxdb.Organization{ID: *f.ID}, nil
+					},
+				},
+			}
+			h := NewExportHandler(zaptest.NewLogger(t), backend)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{
+					Status:      influxdb.Active,
+					Permissions: []influxdb.Permission{tt.permission},
+				}))
+				h.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			s := &ExportService{Addr: server.URL}
+			var buf bytes.Buffer
+			err := s.ExportBucket(context.Background(), tt.req, &buf)
+
+			if tt.wants.code != "" {
+				if got := influxdb.ErrorCode(err); got != tt.wants.code {
+					t.Fatalf("unexpected error code: got %q (%v), want %q", got, err, tt.wants.code)
+				}
+				if tt.wants.err != "" && influxdb.ErrorMessage(err) != tt.wants.err {
+					t.Fatalf("unexpected error: got %q, want %q", influxdb.ErrorMessage(err), tt.wants.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if filter != tt.wants.filter {
+				t.Errorf("unexpected filter: got %+v, want %+v", filter, tt.wants.filter)
+			}
+			if format != tt.wants.format {
+				t.Errorf("unexpected format: got %q, want %q", format, tt.wants.format)
+			}
+			if got := buf.String(); got != tt.wants.body {
+				t.Errorf("unexpected body: got %q, want %q", got, tt.wants.body)
+			}
+		})
+	}
+}
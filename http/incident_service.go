@@ -0,0 +1,372 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+// IncidentBackend is all services and associated parameters required to
+// construct the IncidentHandler.
+type IncidentBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	IncidentService     influxdb.IncidentService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewIncidentBackend returns a new instance of IncidentBackend.
+func NewIncidentBackend(log *zap.Logger, b *APIBackend) *IncidentBackend {
+	return &IncidentBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		IncidentService:     b.IncidentService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// IncidentHandler is the handler for the incident service.
+type IncidentHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	api *kithttp.API
+	log *zap.Logger
+
+	IncidentService     influxdb.IncidentService
+	OrganizationService influxdb.OrganizationService
+}
+
+const (
+	prefixIncidents              = "/api/v2/incidents"
+	incidentsIDPath              = "/api/v2/incidents/:id"
+	incidentsIDAcknowledgePath   = "/api/v2/incidents/:id/acknowledge"
+	incidentsIDUnacknowledgePath = "/api/v2/incidents/:id/unacknowledge"
+	incidentsIDCommentsPath      = "/api/v2/incidents/:id/comments"
+)
+
+// NewIncidentHandler returns a new instance of IncidentHandler.
+func NewIncidentHandler(log *zap.Logger, b *IncidentBackend) *IncidentHandler {
+	h := &IncidentHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		api:              kithttp.NewAPI(kithttp.WithLog(log)),
+		log:              log,
+
+		IncidentService:     b.IncidentService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("GET", prefixIncidents, h.handleGetIncidents)
+	h.HandlerFunc("GET", incidentsIDPath, h.handleGetIncident)
+	h.HandlerFunc("POST", incidentsIDAcknowledgePath, h.handlePostIncidentAcknowledge)
+	h.HandlerFunc("POST", incidentsIDUnacknowledgePath, h.handlePostIncidentUnacknowledge)
+	h.HandlerFunc("POST", incidentsIDCommentsPath, h.handlePostIncidentComment)
+
+	return h
+}
+
+type incidentResponse struct {
+	*influxdb.Incident
+	Links map[string]string `json:"links"`
+}
+
+func newIncidentResponse(i *influxdb.Incident) *incidentResponse {
+	return &incidentResponse{
+		Incident: i,
+		Links: map[string]string{
+			"self":  fmt.Sprintf("/api/v2/incidents/%s", i.ID),
+			"check": fmt.Sprintf("/api/v2/checks/%s", i.CheckID),
+			"org":   fmt.Sprintf("/api/v2/orgs/%s", i.OrgID),
+		},
+	}
+}
+
+type incidentsResponse struct {
+	Links     map[string]string   `json:"links"`
+	Incidents []*incidentResponse `json:"incidents"`
+}
+
+type incidentCommentRequest struct {
+	Text string `json:"text"`
+}
+
+// handleGetIncidents is the HTTP handler for the GET /api/v2/incidents route.
+func (h *IncidentHandler) handleGetIncidents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := h.decodeIncidentFilter(ctx, r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	is, _, err := h.IncidentService.FindIncidents(ctx, filter)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	res := &incidentsResponse{
+		Links: map[string]string{
+			"self": prefixIncidents,
+		},
+		Incidents: make([]*incidentResponse, 0, len(is)),
+	}
+	for _, i := range is {
+		res.Incidents = append(res.Incidents, newIncidentResponse(i))
+	}
+	h.api.Respond(w, http.StatusOK, res)
+}
+
+func (h *IncidentHandler) decodeIncidentFilter(ctx context.Context, r *http.Request) (influxdb.IncidentFilter, error) {
+	var filter influxdb.IncidentFilter
+	q := r.URL.Query()
+	if orgID := q.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		filter.OrgID = id
+	} else if org := q.Get("org"); org != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+		if err != nil {
+			return filter, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	if checkID := q.Get("checkID"); checkID != "" {
+		id, err := influxdb.IDFromString(checkID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "checkID is invalid",
+				Err:  err,
+			}
+		}
+		filter.CheckID = id
+	}
+
+	if status := q.Get("status"); status != "" {
+		s := influxdb.IncidentStatus(status)
+		if s != influxdb.IncidentOpen && s != influxdb.IncidentClosed {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "status must be open or closed",
+			}
+		}
+		filter.Status = &s
+	}
+
+	if acked := q.Get("acknowledged"); acked != "" {
+		b, err := strconv.ParseBool(acked)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "acknowledged must be true or false",
+				Err:  err,
+			}
+		}
+		filter.Acknowledged = &b
+	}
+	return filter, nil
+}
+
+// handleGetIncident is the HTTP handler for the GET /api/v2/incidents/:id route.
+func (h *IncidentHandler) handleGetIncident(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	i, err := h.IncidentService.FindIncidentByID(ctx, id)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	h.api.Respond(w, http.StatusOK, newIncidentResponse(i))
+}
+
+// handlePostIncidentAcknowledge is the HTTP handler for the POST /api/v2/incidents/:id/acknowledge route.
+func (h *IncidentHandler) handlePostIncidentAcknowledge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	i, err := h.IncidentService.AcknowledgeIncident(ctx, id, auth.GetUserID())
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Incident acknowledged", zap.String("incidentID", id.String()))
+
+	h.api.Respond(w, http.StatusOK, newIncidentResponse(i))
+}
+
+// handlePostIncidentUnacknowledge is the HTTP handler for the POST /api/v2/incidents/:id/unacknowledge route.
+func (h *IncidentHandler) handlePostIncidentUnacknowledge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	i, err := h.IncidentService.UnacknowledgeIncident(ctx, id)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Incident unacknowledged", zap.String("incidentID", id.String()))
+
+	h.api.Respond(w, http.StatusOK, newIncidentResponse(i))
+}
+
+// handlePostIncidentComment is the HTTP handler for the POST /api/v2/incidents/:id/comments route.
+func (h *IncidentHandler) handlePostIncidentComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var req incidentCommentRequest
+	if err := h.api.DecodeJSON(r.Body, &req); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	i, err := h.IncidentService.CommentIncident(ctx, id, auth.GetUserID(), req.Text)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Incident commented", zap.String("incidentID", id.String()))
+
+	h.api.Respond(w, http.StatusCreated, newIncidentResponse(i))
+}
+
+// IncidentService connects to Influx via HTTP using tokens to manage incidents.
+type IncidentService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.IncidentService = (*IncidentService)(nil)
+
+// FindIncidentByID returns a single incident by ID.
+func (s *IncidentService) FindIncidentByID(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	var resp incidentResponse
+	err := s.Client.
+		Get(prefixIncidents, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Incident, nil
+}
+
+// FindIncidents returns a list of incidents that match filter and the total
+// count of matching incidents.
+func (s *IncidentService) FindIncidents(ctx context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+	var params [][2]string
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+	if filter.CheckID != nil {
+		params = append(params, [2]string{"checkID", filter.CheckID.String()})
+	}
+	if filter.Status != nil {
+		params = append(params, [2]string{"status", string(*filter.Status)})
+	}
+	if filter.Acknowledged != nil {
+		params = append(params, [2]string{"acknowledged", strconv.FormatBool(*filter.Acknowledged)})
+	}
+
+	var resp incidentsResponse
+	err := s.Client.
+		Get(prefixIncidents).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	is := make([]*influxdb.Incident, 0, len(resp.Incidents))
+	for _, ir := range resp.Incidents {
+		is = append(is, ir.Incident)
+	}
+	return is, len(is), nil
+}
+
+// AcknowledgeIncident records that the user is handling the open incident.
+func (s *IncidentService) AcknowledgeIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID) (*influxdb.Incident, error) {
+	var resp incidentResponse
+	err := s.Client.
+		Post(nil, prefixIncidents, id.String(), "acknowledge").
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Incident, nil
+}
+
+// UnacknowledgeIncident removes the acknowledgement of the incident.
+func (s *IncidentService) UnacknowledgeIncident(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	var resp incidentResponse
+	err := s.Client.
+		Post(nil, prefixIncidents, id.String(), "unacknowledge").
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Incident, nil
+}
+
+// CommentIncident adds a comment of the user to the incident.
+func (s *IncidentService) CommentIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID, text string) (*influxdb.Incident, error) {
+	var resp incidentResponse
+	err := s.Client.
+		PostJSON(incidentCommentRequest{Text: text}, prefixIncidents, id.String(), "comments").
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Incident, nil
+}
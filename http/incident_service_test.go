@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestIncidentService(t *testing.T) {
+	opened := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	inc := &influxdb.Incident{
+		ID:           1,
+		OrgID:        10,
+		CheckID:      2,
+		Key:          "0000000000000002,host=web-1",
+		Tags:         map[string]string{"host": "web-1"},
+		Level:        "crit",
+		Status:       influxdb.IncidentOpen,
+		OpenedAt:     opened,
+		LastStatusAt: opened,
+	}
+
+	svc := mock.NewIncidentService()
+	svc.FindIncidentsFn = func(_ context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+		if filter.OrgID == nil || *filter.OrgID != 10 {
+			t.Errorf("unexpected org filter %v", filter.OrgID)
+		}
+		if filter.Status == nil || *filter.Status != influxdb.IncidentOpen {
+			t.Errorf("unexpected status filter %v", filter.Status)
+		}
+		if filter.Acknowledged == nil || *filter.Acknowledged {
+			t.Errorf("unexpected acknowledged filter %v", filter.Acknowledged)
+		}
+		return []*influxdb.Incident{inc}, 1, nil
+	}
+	find := func(_ context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+		if id != inc.ID {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "incident not found"}
+		}
+		return inc, nil
+	}
+	svc.FindIncidentByIDFn = find
+	svc.AcknowledgeIncidentFn = func(ctx context.Context, id influxdb.ID, userID influxdb.ID) (*influxdb.Incident, error) {
+		i, err := find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		i.AcknowledgedAt = &opened
+		i.AcknowledgedBy = userID
+		return i, nil
+	}
+	svc.UnacknowledgeIncidentFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+		i, err := find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		i.AcknowledgedAt = nil
+		i.AcknowledgedBy = 0
+		return i, nil
+	}
+	svc.CommentIncidentFn = func(ctx context.Context, id influxdb.ID, userID influxdb.ID, text string) (*influxdb.Incident, error) {
+		i, err := find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		i.Comments = append(i.Comments, influxdb.IncidentComment{UserID: userID, Text: text, CreatedAt: opened})
+		return i, nil
+	}
+
+	backend := &IncidentBackend{
+		HTTPErrorHandler: kithttp.ErrorHandler(0),
+		log:              zaptest.NewLogger(t),
+		IncidentService:  svc,
+	}
+	h := NewIncidentHandler(zaptest.NewLogger(t), backend)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: 6}))
+		h.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	s := &IncidentService{Client: mustNewHTTPClient(t, server.URL, "")}
+	ctx := context.Background()
+
+	orgID, open, no := influxdb.ID(10), influxdb.IncidentOpen, false
+	is, n, err := s.FindIncidents(ctx, influxdb.IncidentFilter{OrgID: &orgID, Status: &open, Acknowledged: &no})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || is[0].Key != inc.Key || !is[0].OpenedAt.Equal(opened) || is[0].Tags["host"] != "web-1" {
+		t.Fatalf("unexpected incidents %+v", is)
+	}
+
+	acked, err := s.AcknowledgeIncident(ctx, inc.ID, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acked.Acknowledged() || acked.AcknowledgedBy != 6 {
+		t.Fatalf("unexpected acknowledged incident %+v", acked)
+	}
+
+	commented, err := s.CommentIncident(ctx, inc.ID, 6, "restarting web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commented.Comments) != 1 || commented.Comments[0].Text != "restarting web-1" || commented.Comments[0].UserID != 6 {
+		t.Fatalf("unexpected commented incident %+v", commented)
+	}
+
+	unacked, err := s.UnacknowledgeIncident(ctx, inc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unacked.Acknowledged() {
+		t.Fatalf("unexpected unacknowledged incident %+v", unacked)
+	}
+
+	if _, err := s.FindIncidentByID(ctx, 2); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected unknown incident to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/influxdb"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+)
+
+// The handlers of the influxdb 1.x compatible api live outside of /api/v2, they
+// authenticate their own requests and report errors in the 1.x format.
+const (
+	prefixLegacyWrite = "/write"
+	prefixLegacyQuery = "/query"
+)
+
+// legacyErrorHandler encodes errors in the format of the influxdb 1.x http api,
+// which is what 1.x clients expect to find in the response body.
+type legacyErrorHandler struct{}
+
+// HandleHTTPError writes err as {"error": "<message>"} with the status code of
+// its influxdb error code.
+func (legacyErrorHandler) HandleHTTPError(ctx context.Context, err error, w http.ResponseWriter) {
+	if err == nil {
+		return
+	}
+
+	w.Header().Set(kithttp.PlatformErrorCodeHeader, influxdb.ErrorCode(err))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(kithttp.StatusCode(err))
+
+	var e struct {
+		Error string `json:"error"`
+	}
+	if err, ok := err.(*influxdb.Error); ok {
+		e.Error = err.Error()
+	} else {
+		e.Error = "An internal error has occurred"
+	}
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+// legacyAuthenticator resolves the authorization of influxdb 1.x requests. These
+// carry the token as a Token authorization header, as the basic auth password or
+// as the p query parameter. The username is ignored.
+type legacyAuthenticator struct {
+	AuthorizationService influxdb.AuthorizationService
+	UserService          influxdb.UserService
+}
+
+func (a legacyAuthenticator) authorize(ctx context.Context, r *http.Request) (*influxdb.Authorization, error) {
+	token := legacyToken(r)
+	if token == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  "unable to parse authentication credentials",
+		}
+	}
+
+	auth, err := a.AuthorizationService.FindAuthorizationByToken(ctx, token)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  "authorization failed",
+			Err:  err,
+		}
+	}
+
+	if !auth.IsActive() {
+		return nil, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "authorization is inactive",
+		}
+	}
+
+	u, err := a.UserService.FindUserByID(ctx, auth.GetUserID())
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  "authorization failed",
+			Err:  err,
+		}
+	}
+
+	if u.Status == influxdb.Inactive {
+		return nil, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "User is inactive",
+		}
+	}
+
+	return auth, nil
+}
+
+func legacyToken(r *http.Request) string {
+	if token, err := GetToken(r); err == nil {
+		return token
+	}
+	if _, p, ok := r.BasicAuth(); ok {
+		return p
+	}
+	// the query handler reads the form encoded body itself, it must not be
+	// consumed here.
+	return r.URL.Query().Get("p")
+}
+
+// findDBRPMapping returns the mapping of the db and rp of the organization.
+// When rp is empty the default retention policy of the db is used.
+func findDBRPMapping(ctx context.Context, svc influxdb.DBRPMappingService, orgID influxdb.ID, db, rp string) (*influxdb.DBRPMapping, error) {
+	if db == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "database is required",
+		}
+	}
+
+	filter := influxdb.DBRPMappingFilter{
+		OrganizationID: &orgID,
+		Database:       &db,
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	} else {
+		isDefault := true
+		filter.Default = &isDefault
+	}
+
+	m, err := svc.Find(ctx, filter)
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			msg := fmt.Sprintf("database not found: %q", db)
+			if rp != "" {
+				msg = fmt.Sprintf("retention policy not found: %q", rp)
+			}
+			return nil, &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  msg,
+			}
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// orgDBRPMappingService restricts the mappings found by the transpiler of a
+// 1.x query to those of the organization of the query, the databases of other
+// organizations may have the same names.
+type orgDBRPMappingService struct {
+	influxdb.DBRPMappingService
+	orgID influxdb.ID
+}
+
+func (s orgDBRPMappingService) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	filter.OrganizationID = &s.orgID
+	return s.DBRPMappingService.Find(ctx, filter)
+}
+
+func (s orgDBRPMappingService) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	filter.OrganizationID = &s.orgID
+	return s.DBRPMappingService.FindMany(ctx, filter, opt...)
+}
@@ -0,0 +1,265 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NYTimes/gziphandler"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/kit/tracing"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"go.uber.org/zap"
+)
+
+// DefaultLegacyChunkSize is the number of values per chunk of a chunked query
+// response when the request does not specify a chunk_size.
+const DefaultLegacyChunkSize = 10000
+
+// LegacyQueryBackend is all services and associated parameters required to construct
+// the LegacyQueryHandler.
+type LegacyQueryBackend struct {
+	log                *zap.Logger
+	QueryEventRecorder metric.EventRecorder
+
+	ProxyQueryService    query.ProxyQueryService
+	AuthorizationService influxdb.AuthorizationService
+	UserService          influxdb.UserService
+	DBRPMappingService   influxdb.DBRPMappingService
+}
+
+// NewLegacyQueryBackend returns a new instance of LegacyQueryBackend.
+func NewLegacyQueryBackend(log *zap.Logger, b *APIBackend) *LegacyQueryBackend {
+	return &LegacyQueryBackend{
+		log:                log,
+		QueryEventRecorder: b.QueryEventRecorder,
+
+		ProxyQueryService:    b.InfluxQLService,
+		AuthorizationService: b.AuthorizationService,
+		UserService:          b.UserService,
+		DBRPMappingService:   b.DBRPMappingService,
+	}
+}
+
+// LegacyQueryHandler serves InfluxQL queries at the influxdb 1.x /query endpoint.
+// The queries are transpiled to flux, resolving db and rp through the dbrp mappings.
+type LegacyQueryHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	Now                func() time.Time
+	ProxyQueryService  query.ProxyQueryService
+	DBRPMappingService influxdb.DBRPMappingService
+	EventRecorder      metric.EventRecorder
+
+	authenticator legacyAuthenticator
+}
+
+// Prefix provides the route prefix.
+func (*LegacyQueryHandler) Prefix() string {
+	return prefixLegacyQuery
+}
+
+// NewLegacyQueryHandler returns a new handler at /query for influxdb 1.x queries.
+func NewLegacyQueryHandler(log *zap.Logger, b *LegacyQueryBackend) *LegacyQueryHandler {
+	h := &LegacyQueryHandler{
+		Router:           NewRouter(legacyErrorHandler{}),
+		HTTPErrorHandler: legacyErrorHandler{},
+		log:              log,
+
+		Now:                time.Now,
+		ProxyQueryService:  b.ProxyQueryService,
+		DBRPMappingService: b.DBRPMappingService,
+		EventRecorder:      b.QueryEventRecorder,
+
+		authenticator: legacyAuthenticator{
+			AuthorizationService: b.AuthorizationService,
+			UserService:          b.UserService,
+		},
+	}
+
+	// query reponses can optionally be gzip encoded
+	qh := gziphandler.GzipHandler(http.HandlerFunc(h.handleQuery))
+	h.Handler("GET", prefixLegacyQuery, qh)
+	h.Handler("POST", prefixLegacyQuery, qh)
+	return h
+}
+
+func (h *LegacyQueryHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	const op = "http/handleLegacyQuery"
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyQueryHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	log := h.log.With(logger.TraceFields(ctx)...)
+	if id, _, found := tracing.InfoFromContext(ctx); found {
+		w.Header().Set(traceIDHeader, id)
+	}
+
+	var orgID influxdb.ID
+	sw := kithttp.NewStatusResponseWriter(w)
+	w = sw
+	defer func() {
+		h.EventRecorder.Record(ctx, metric.Event{
+			OrgID:         orgID,
+			Endpoint:      r.URL.Path,
+			ResponseBytes: sw.ResponseBytes(),
+			Status:        sw.Code(),
+		})
+	}()
+
+	auth, err := h.authenticator.authorize(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeLegacyQueryRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	// The query runs in the organization of the authorization, the databases it
+	// references are resolved among the mappings of that organization only.
+	orgID = auth.OrgID
+	if req.Database != "" {
+		if _, err := findDBRPMapping(ctx, h.DBRPMappingService, orgID, req.Database, req.RetentionPolicy); err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+	}
+
+	now := h.Now()
+	compiler := influxql.NewCompiler(orgDBRPMappingService{DBRPMappingService: h.DBRPMappingService, orgID: orgID})
+	compiler.DB = req.Database
+	compiler.RP = req.RetentionPolicy
+	compiler.Query = req.Query
+	compiler.Now = &now
+
+	pr := &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  auth,
+			OrganizationID: orgID,
+			Compiler:       compiler,
+			Source:         r.Header.Get("User-Agent"),
+		},
+		Dialect: req.Dialect,
+	}
+
+	ctx = pcontext.SetAuthorizer(ctx, auth)
+	req.Dialect.SetHeaders(w)
+
+	cw := iocounter.Writer{Writer: w}
+	if _, err := h.ProxyQueryService.Query(ctx, &cw, pr); err != nil {
+		if cw.Count() == 0 {
+			// Only record the error headers IFF nothing has been written to w.
+			h.HandleHTTPError(ctx, &influxdb.Error{
+				Op:  op,
+				Err: err,
+			}, w)
+			return
+		}
+		_ = tracing.LogError(span, err)
+		log.Info("Error writing response to client",
+			zap.String("handler", "influxql"),
+			zap.Error(err),
+		)
+	}
+}
+
+type legacyQueryRequest struct {
+	Query           string
+	Database        string
+	RetentionPolicy string
+	Dialect         *influxql.Dialect
+}
+
+// legacyEpochs maps the epoch parameter of the influxdb 1.x query api to time formats.
+var legacyEpochs = map[string]influxql.TimeFormat{
+	"":   influxql.RFC3339Nano,
+	"h":  influxql.Hour,
+	"m":  influxql.Minute,
+	"s":  influxql.Second,
+	"ms": influxql.Millisecond,
+	"u":  influxql.Microsecond,
+	"µ":  influxql.Microsecond,
+	"ns": influxql.Nanosecond,
+}
+
+// decodeLegacyQueryRequest reads the parameters of a query from either the url
+// or a form encoded body, as the influxdb 1.x api accepts both.
+func decodeLegacyQueryRequest(r *http.Request) (*legacyQueryRequest, error) {
+	const op = "http/decodeLegacyQueryRequest"
+
+	q := r.FormValue("q")
+	if q == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   op,
+			Msg:  `missing required parameter "q"`,
+		}
+	}
+
+	tf, ok := legacyEpochs[r.FormValue("epoch")]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   op,
+			Msg:  "invalid epoch; valid epochs are h, m, s, ms, u, and ns",
+		}
+	}
+
+	dialect := &influxql.Dialect{
+		TimeFormat: tf,
+		Encoding:   influxql.JSON,
+	}
+
+	switch legacyAcceptedMediaType(r) {
+	case "application/csv", "text/csv":
+		dialect.Encoding = influxql.CSV
+	default:
+		if r.FormValue("pretty") == "true" {
+			dialect.Encoding = influxql.JSONPretty
+		}
+	}
+
+	if r.FormValue("chunked") == "true" {
+		dialect.ChunkSize = DefaultLegacyChunkSize
+		if s := r.FormValue("chunk_size"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Op:   op,
+					Msg:  "chunk_size must be a positive integer",
+				}
+			}
+			dialect.ChunkSize = n
+		}
+	}
+
+	return &legacyQueryRequest{
+		Query:           q,
+		Database:        r.FormValue("db"),
+		RetentionPolicy: r.FormValue("rp"),
+		Dialect:         dialect,
+	}, nil
+}
+
+func legacyAcceptedMediaType(r *http.Request) string {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Accept"))
+	if err != nil {
+		return ""
+	}
+	return mt
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	querymock "github.com/influxdata/influxdb/query/mock"
+	influxtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLegacyQueryHandler_handleQuery(t *testing.T) {
+	now := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	type wants struct {
+		code        int
+		body        string
+		contentType string
+		orgID       influxdb.ID
+		compiler    *influxql.Compiler
+		dialect     *influxql.Dialect
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		params url.Values
+		accept string
+		wants  wants
+	}{
+		{
+			name:   "get query with default database",
+			method: "GET",
+			params: url.Values{"q": {"SELECT * FROM cpu"}, "db": {"mydb"}, "p": {"mytoken"}},
+			wants: wants{
+				code:        200,
+				body:        "results",
+				contentType: "application/json",
+				orgID:       influxtesting.MustIDBase16("043e0780ee2b1000"),
+				compiler:    &influxql.Compiler{DB: "mydb", Query: "SELECT * FROM cpu", Now: &now},
+				dialect:     &influxql.Dialect{Encoding: influxql.JSON},
+			},
+		},
+		{
+			name:   "post chunked query with epoch",
+			method: "POST",
+			params: url.Values{"q": {"SELECT * FROM cpu"}, "db": {"mydb"}, "rp": {"autogen"}, "p": {"mytoken"}, "epoch": {"ms"}, "chunked": {"true"}, "chunk_size": {"100"}},
+			wants: wants{
+				code:        200,
+				body:        "results",
+				contentType: "application/json",
+				orgID:       influxtesting.MustIDBase16("043e0780ee2b1000"),
+				compiler:    &influxql.Compiler{DB: "mydb", RP: "autogen", Query: "SELECT * FROM cpu", Now: &now},
+				dialect:     &influxql.Dialect{Encoding: influxql.JSON, TimeFormat: influxql.Millisecond, ChunkSize: 100},
+			},
+		},
+		{
+			name:   "csv query without database runs in the authorization org",
+			method: "GET",
+			params: url.Values{"q": {`SELECT * FROM "mydb"."autogen"."cpu"`}, "p": {"othertoken"}},
+			accept: "application/csv",
+			wants: wants{
+				code:        200,
+				body:        "results",
+				contentType: "text/csv",
+				orgID:       influxtesting.MustIDBase16("0000000000000001"),
+				compiler:    &influxql.Compiler{Query: `SELECT * FROM "mydb"."autogen"."cpu"`, Now: &now},
+				dialect:     &influxql.Dialect{Encoding: influxql.CSV},
+			},
+		},
+		{
+			name:   "missing query is invalid",
+			method: "GET",
+			params: url.Values{"db": {"mydb"}, "p": {"mytoken"}},
+			wants: wants{
+				code:        400,
+				body:        `{"error":"missing required parameter \"q\""}` + "\n",
+				contentType: "application/json; charset=utf-8",
+			},
+		},
+		{
+			name:   "invalid epoch is rejected",
+			method: "GET",
+			params: url.Values{"q": {"SELECT * FROM cpu"}, "db": {"mydb"}, "p": {"mytoken"}, "epoch": {"d"}},
+			wants: wants{
+				code:        400,
+				body:        `{"error":"invalid epoch; valid epochs are h, m, s, ms, u, and ns"}` + "\n",
+				contentType: "application/json; charset=utf-8",
+			},
+		},
+		{
+			name:   "database of another organization is not found",
+			method: "GET",
+			params: url.Values{"q": {"SELECT * FROM cpu"}, "db": {"mydb"}, "p": {"othertoken"}},
+			wants: wants{
+				code:        404,
+				body:        `{"error":"database not found: \"mydb\""}` + "\n",
+				contentType: "application/json; charset=utf-8",
+			},
+		},
+		{
+			name:   "invalid token is unauthorized",
+			method: "GET",
+			params: url.Values{"q": {"SELECT * FROM cpu"}, "db": {"mydb"}, "p": {"badtoken"}},
+			wants: wants{
+				code:        401,
+				body:        `{"error":"authorization failed: authorization not found"}` + "\n",
+				contentType: "application/json; charset=utf-8",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the token of another organization, owning no mapping of mydb.
+			tokens := map[string]influxdb.ID{
+				"mytoken":    influxtesting.MustIDBase16("043e0780ee2b1000"),
+				"othertoken": influxtesting.MustIDBase16("0000000000000001"),
+			}
+			auths := mock.NewAuthorizationService()
+			auths.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*influxdb.Authorization, error) {
+				orgID, ok := tokens[token]
+				if !ok {
+					return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "authorization not found"}
+				}
+				return &influxdb.Authorization{OrgID: orgID, Status: influxdb.Active}, nil
+			}
+			users := mock.NewUserService()
+			users.FindUserByIDFn = func(context.Context, influxdb.ID) (*influxdb.User, error) {
+				return &influxdb.User{Status: influxdb.Active}, nil
+			}
+			dbrps := mock.NewDBRPMappingService()
+			dbrps.FindFn = func(ctx context.Context, f influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+				m := testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000")
+				if f.OrganizationID == nil || *f.OrganizationID != m.OrganizationID {
+					return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "dbrp mapping not found"}
+				}
+				return m, nil
+			}
+
+			var req *query.ProxyRequest
+			queries := &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, r *query.ProxyRequest) (flux.Statistics, error) {
+					req = r
+					_, err := io.WriteString(w, "results")
+					return flux.Statistics{}, err
+				},
+			}
+
+			b := &APIBackend{
+				AuthorizationService: auths,
+				UserService:          users,
+				DBRPMappingService:   dbrps,
+				InfluxQLService:      queries,
+				QueryEventRecorder:   &metric.NopEventRecorder{},
+			}
+			handler := NewLegacyQueryHandler(zaptest.NewLogger(t), NewLegacyQueryBackend(zaptest.NewLogger(t), b))
+			handler.Now = func() time.Time { return now }
+
+			r := httptest.NewRequest(tt.method, "http://localhost:9999/query?"+tt.params.Encode(), nil)
+			if tt.method == "POST" {
+				// 1.x clients pass the credentials in the url and the query in the form encoded body.
+				body := url.Values{}
+				for k, v := range tt.params {
+					body[k] = v
+				}
+				delete(body, "p")
+				u := "http://localhost:9999/query"
+				if p := tt.params.Get("p"); p != "" {
+					u += "?" + url.Values{"p": {p}}.Encode()
+				}
+				r = httptest.NewRequest(tt.method, u, strings.NewReader(body.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got, want := w.Code, tt.wants.code; got != want {
+				t.Errorf("unexpected status code: got %d want %d", got, want)
+			}
+			if got, want := w.Body.String(), tt.wants.body; got != want {
+				t.Errorf("unexpected body: got %s want %s", got, want)
+			}
+			if got, want := w.Header().Get("Content-Type"), tt.wants.contentType; got != want {
+				t.Errorf("unexpected content type: got %s want %s", got, want)
+			}
+
+			if tt.wants.compiler == nil {
+				if req != nil {
+					t.Errorf("unexpected query request %v", req)
+				}
+				return
+			}
+
+			if got, want := req.Request.OrganizationID, tt.wants.orgID; got != want {
+				t.Errorf("unexpected organization: got %s want %s", got, want)
+			}
+			c := req.Request.Compiler.(*influxql.Compiler)
+			if c.DB != tt.wants.compiler.DB || c.RP != tt.wants.compiler.RP || c.Query != tt.wants.compiler.Query || !c.Now.Equal(*tt.wants.compiler.Now) {
+				t.Errorf("unexpected compiler: got %+v want %+v", c, tt.wants.compiler)
+			}
+			if got, want := *req.Dialect.(*influxql.Dialect), *tt.wants.dialect; got != want {
+				t.Errorf("unexpected dialect: got %+v want %+v", got, want)
+			}
+		})
+	}
+}
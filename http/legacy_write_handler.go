@@ -0,0 +1,273 @@
+package http
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/kit/tracing"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// LegacyWriteBackend is all services and associated parameters required to construct
+// the LegacyWriteHandler.
+type LegacyWriteBackend struct {
+	log                *zap.Logger
+	WriteEventRecorder metric.EventRecorder
+
+	MaxBatchSizeBytes    int64
+	WriteParserMaxBytes  int
+	WriteParserMaxLines  int
+	WriteParserMaxValues int
+
+	PointsWriter         storage.PointsWriter
+	AuthorizationService influxdb.AuthorizationService
+	UserService          influxdb.UserService
+	DBRPMappingService   influxdb.DBRPMappingService
+}
+
+// NewLegacyWriteBackend returns a new instance of LegacyWriteBackend.
+func NewLegacyWriteBackend(log *zap.Logger, b *APIBackend) *LegacyWriteBackend {
+	return &LegacyWriteBackend{
+		log:                log,
+		WriteEventRecorder: b.WriteEventRecorder,
+
+		MaxBatchSizeBytes:    b.MaxBatchSizeBytes,
+		WriteParserMaxBytes:  b.WriteParserMaxBytes,
+		WriteParserMaxLines:  b.WriteParserMaxLines,
+		WriteParserMaxValues: b.WriteParserMaxValues,
+
+		PointsWriter:         b.PointsWriter,
+		AuthorizationService: b.AuthorizationService,
+		UserService:          b.UserService,
+		DBRPMappingService:   b.DBRPMappingService,
+	}
+}
+
+// LegacyWriteHandler receives line protocol at the influxdb 1.x /write endpoint and
+// writes it to the bucket mapped to the db and rp of the request.
+type LegacyWriteHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	PointsWriter       storage.PointsWriter
+	DBRPMappingService influxdb.DBRPMappingService
+	EventRecorder      metric.EventRecorder
+
+	authenticator     legacyAuthenticator
+	maxBatchSizeBytes int64
+	parserOptions     []models.ParserOption
+}
+
+// Prefix provides the route prefix.
+func (*LegacyWriteHandler) Prefix() string {
+	return prefixLegacyWrite
+}
+
+// NewLegacyWriteHandler creates a new handler at /write to receive influxdb 1.x writes.
+func NewLegacyWriteHandler(log *zap.Logger, b *LegacyWriteBackend) *LegacyWriteHandler {
+	h := &LegacyWriteHandler{
+		Router:           NewRouter(legacyErrorHandler{}),
+		HTTPErrorHandler: legacyErrorHandler{},
+		log:              log,
+
+		PointsWriter:       b.PointsWriter,
+		DBRPMappingService: b.DBRPMappingService,
+		EventRecorder:      b.WriteEventRecorder,
+
+		authenticator: legacyAuthenticator{
+			AuthorizationService: b.AuthorizationService,
+			UserService:          b.UserService,
+		},
+		maxBatchSizeBytes: b.MaxBatchSizeBytes,
+	}
+
+	if b.WriteParserMaxBytes > 0 {
+		h.parserOptions = append(h.parserOptions, models.WithParserMaxBytes(b.WriteParserMaxBytes))
+	}
+	if b.WriteParserMaxLines > 0 {
+		h.parserOptions = append(h.parserOptions, models.WithParserMaxLines(b.WriteParserMaxLines))
+	}
+	if b.WriteParserMaxValues > 0 {
+		h.parserOptions = append(h.parserOptions, models.WithParserMaxValues(b.WriteParserMaxValues))
+	}
+
+	h.HandlerFunc("POST", prefixLegacyWrite, h.handleWrite)
+	return h
+}
+
+func (h *LegacyWriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyWriteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	var (
+		orgID        influxdb.ID
+		requestBytes int
+		sw           = kithttp.NewStatusResponseWriter(w)
+		handleError  = func(err error, code, message string) {
+			h.HandleHTTPError(ctx, &influxdb.Error{
+				Code: code,
+				Op:   "http/handleLegacyWrite",
+				Msg:  message,
+				Err:  err,
+			}, w)
+		}
+	)
+	w = sw
+	defer func() {
+		h.EventRecorder.Record(ctx, metric.Event{
+			OrgID:         orgID,
+			Endpoint:      r.URL.Path,
+			RequestBytes:  requestBytes,
+			ResponseBytes: sw.ResponseBytes(),
+			Status:        sw.Code(),
+		})
+	}()
+
+	auth, err := h.authenticator.authorize(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeLegacyWriteRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	log := h.log.With(zap.String("db", req.Database), zap.String("rp", req.RetentionPolicy))
+
+	// the names of the databases are resolved in the organization of the
+	// authorization, those of other organizations are never written to.
+	mapping, err := findDBRPMapping(ctx, h.DBRPMappingService, auth.OrgID, req.Database, req.RetentionPolicy)
+	if err != nil {
+		log.Info("Failed to find dbrp mapping", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	orgID = mapping.OrganizationID
+	span.LogKV("org_id", orgID, "bucket_id", mapping.BucketID)
+
+	p, err := influxdb.NewPermissionAtID(mapping.BucketID, influxdb.WriteAction, influxdb.BucketsResourceType, mapping.OrganizationID)
+	if err != nil {
+		handleError(err, influxdb.EInternal, fmt.Sprintf("unable to create permission for bucket: %v", err))
+		return
+	}
+
+	if !auth.Allowed(*p) {
+		handleError(err, influxdb.EForbidden, "insufficient permissions for write")
+		return
+	}
+
+	data, err := readWriteRequest(ctx, r.Body, r.Header.Get("Content-Encoding"), h.maxBatchSizeBytes)
+	if err != nil {
+		log.Error("Error reading body", zap.Error(err))
+
+		code := influxdb.EInternal
+		if errors.Is(err, ErrMaxBatchSizeExceeded) {
+			code = influxdb.ETooLarge
+		} else if errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) {
+			code = influxdb.EInvalid
+		}
+
+		handleError(err, code, "unable to read data")
+		return
+	}
+
+	requestBytes = len(data)
+	if requestBytes == 0 {
+		handleError(err, influxdb.EInvalid, "writing requires points")
+		return
+	}
+
+	encoded := tsdb.EncodeName(mapping.OrganizationID, mapping.BucketID)
+	mm := models.EscapeMeasurement(encoded[:])
+
+	options := make([]models.ParserOption, 0, len(h.parserOptions)+1)
+	options = append(options, h.parserOptions...)
+	if req.Precision != nil {
+		options = append(options, req.Precision)
+	}
+
+	points, err := models.ParsePointsWithOptions(data, mm, options...)
+	span.LogKV("values_total", len(points))
+	if err != nil {
+		log.Error("Error parsing points", zap.Error(err))
+
+		code := influxdb.EInvalid
+		if errors.Is(err, models.ErrLimitMaxBytesExceeded) ||
+			errors.Is(err, models.ErrLimitMaxLinesExceeded) ||
+			errors.Is(err, models.ErrLimitMaxValuesExceeded) {
+			code = influxdb.ETooLarge
+		}
+
+		handleError(err, code, "")
+		return
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
+		log.Error("Error writing points", zap.Error(err))
+		handleError(err, influxdb.EInternal, "unexpected error writing points to database")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type legacyWriteRequest struct {
+	Database        string
+	RetentionPolicy string
+	Precision       models.ParserOption
+}
+
+// legacyPrecisions maps the precisions of the influxdb 1.x write api to the
+// precisions understood by the points parser.
+var legacyPrecisions = map[string]string{
+	"":   "ns",
+	"n":  "ns",
+	"ns": "ns",
+	"u":  "us",
+	"us": "us",
+	"ms": "ms",
+	"s":  "s",
+	"m":  "m",
+	"h":  "h",
+}
+
+func decodeLegacyWriteRequest(r *http.Request) (*legacyWriteRequest, error) {
+	qp := r.URL.Query()
+
+	p, ok := legacyPrecisions[qp.Get("precision")]
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   "http/decodeLegacyWriteRequest",
+			Msg:  "invalid precision; valid precision units are n, ns, u, us, ms, s, m, and h",
+		}
+	}
+
+	var precision models.ParserOption
+	if p != "ns" {
+		precision = models.WithParserPrecision(p)
+	}
+
+	return &legacyWriteRequest{
+		Database:        qp.Get("db"),
+		RetentionPolicy: qp.Get("rp"),
+		Precision:       precision,
+	}, nil
+}
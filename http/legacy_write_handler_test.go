@@ -0,0 +1,253 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/mock"
+	influxtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLegacyWriteHandler_handleWrite(t *testing.T) {
+	// state is the internal state of the authorization and dbrp mapping services
+	type state struct {
+		auth       *influxdb.Authorization // authorization to return for the token
+		mapping    *influxdb.DBRPMapping   // mapping to return from the dbrp mapping service
+		mappingErr error                   // err to return from the dbrp mapping service
+		writeErr   error                   // err to return from the points writer
+	}
+
+	// want is the expected output of the HTTP endpoint
+	type wants struct {
+		body   string
+		code   int
+		filter influxdb.DBRPMappingFilter
+	}
+
+	// request is sent to the HTTP endpoint
+	type request struct {
+		query     string
+		basicAuth bool
+		body      string
+	}
+
+	tests := []struct {
+		name    string
+		request request
+		state   state
+		wants   wants
+	}{
+		{
+			name: "write with token in password parameter",
+			request: request{
+				query: "db=mydb&rp=autogen&u=me&p=mytoken",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				mapping: testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code:   204,
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), RetentionPolicy: strPtr("autogen")},
+			},
+		},
+		{
+			name: "write with basic auth to default retention policy",
+			request: request{
+				query:     "db=mydb&precision=s",
+				basicAuth: true,
+				body:      "m1,t1=v1 f1=1 1000",
+			},
+			state: state{
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				mapping: testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code:   204,
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), Default: boolPtr(true)},
+			},
+		},
+		{
+			name: "write with hour precision",
+			request: request{
+				query: "db=mydb&p=mytoken&precision=h",
+				body:  "m1,t1=v1 f1=1 438000",
+			},
+			state: state{
+				auth:    bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				mapping: testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code:   204,
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), Default: boolPtr(true)},
+			},
+		},
+		{
+			name: "missing credentials are unauthorized",
+			request: request{
+				query: "db=mydb",
+				body:  "m1,t1=v1 f1=1",
+			},
+			wants: wants{
+				code: 401,
+				body: `{"error":"unable to parse authentication credentials"}` + "\n",
+			},
+		},
+		{
+			name: "unknown database returns 404",
+			request: request{
+				query: "db=mydb&p=mytoken",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth:       bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				mappingErr: &influxdb.Error{Code: influxdb.ENotFound, Msg: "dbrp mapping not found"},
+			},
+			wants: wants{
+				code:   404,
+				body:   `{"error":"database not found: \"mydb\""}` + "\n",
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), Default: boolPtr(true)},
+			},
+		},
+		{
+			name: "missing database is invalid",
+			request: request{
+				query: "p=mytoken",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth: bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 400,
+				body: `{"error":"database is required"}` + "\n",
+			},
+		},
+		{
+			name: "invalid precision is rejected",
+			request: request{
+				query: "db=mydb&p=mytoken&precision=d",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth: bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code: 400,
+				body: `{"error":"invalid precision; valid precision units are n, ns, u, us, ms, s, m, and h"}` + "\n",
+			},
+		},
+		{
+			name: "forbidden to write with insufficient permission",
+			request: request{
+				query: "db=mydb&p=mytoken",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth:    bucketWritePermission("043e0780ee2b1000", "000000000000000a"),
+				mapping: testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000"),
+			},
+			wants: wants{
+				code:   403,
+				body:   `{"error":"insufficient permissions for write"}` + "\n",
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), Default: boolPtr(true)},
+			},
+		},
+		{
+			name: "points writer error is an internal error",
+			request: request{
+				query: "db=mydb&p=mytoken",
+				body:  "m1,t1=v1 f1=1",
+			},
+			state: state{
+				auth:     bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+				mapping:  testDBRPMapping("mydb", "autogen", "043e0780ee2b1000", "04504b356e23b000"),
+				writeErr: fmt.Errorf("error"),
+			},
+			wants: wants{
+				code:   500,
+				body:   `{"error":"unexpected error writing points to database: error"}` + "\n",
+				filter: influxdb.DBRPMappingFilter{OrganizationID: influxtesting.IDPtr(influxtesting.MustIDBase16("043e0780ee2b1000")), Database: strPtr("mydb"), Default: boolPtr(true)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auths := mock.NewAuthorizationService()
+			auths.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*influxdb.Authorization, error) {
+				if token != "mytoken" || tt.state.auth == nil {
+					return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "authorization not found"}
+				}
+				return tt.state.auth, nil
+			}
+			users := mock.NewUserService()
+			users.FindUserByIDFn = func(context.Context, influxdb.ID) (*influxdb.User, error) {
+				return &influxdb.User{Status: influxdb.Active}, nil
+			}
+			var filter influxdb.DBRPMappingFilter
+			dbrps := mock.NewDBRPMappingService()
+			dbrps.FindFn = func(ctx context.Context, f influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+				filter = f
+				return tt.state.mapping, tt.state.mappingErr
+			}
+
+			b := &APIBackend{
+				AuthorizationService: auths,
+				UserService:          users,
+				DBRPMappingService:   dbrps,
+				PointsWriter:         &mock.PointsWriter{Err: tt.state.writeErr},
+				WriteEventRecorder:   &metric.NopEventRecorder{},
+			}
+			handler := NewLegacyWriteHandler(zaptest.NewLogger(t), NewLegacyWriteBackend(zaptest.NewLogger(t), b))
+
+			r := httptest.NewRequest(
+				"POST",
+				"http://localhost:9999/write?"+tt.request.query,
+				strings.NewReader(tt.request.body),
+			)
+			if tt.request.basicAuth {
+				r.SetBasicAuth("me", "mytoken")
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got, want := w.Code, tt.wants.code; got != want {
+				t.Errorf("unexpected status code: got %d want %d", got, want)
+			}
+
+			if got, want := w.Body.String(), tt.wants.body; got != want {
+				t.Errorf("unexpected body: got %s want %s", got, want)
+			}
+
+			if got, want := filter.String(), tt.wants.filter.String(); got != want {
+				t.Errorf("unexpected dbrp mapping filter: got %s want %s", got, want)
+			}
+		})
+	}
+}
+
+func testDBRPMapping(db, rp, org, bucket string) *influxdb.DBRPMapping {
+	return &influxdb.DBRPMapping{
+		Cluster:         "cluster",
+		Database:        db,
+		RetentionPolicy: rp,
+		Default:         true,
+		OrganizationID:  influxtesting.MustIDBase16(org),
+		BucketID:        influxtesting.MustIDBase16(bucket),
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -22,11 +22,12 @@ type NotificationEndpointBackend struct {
 	influxdb.HTTPErrorHandler
 	log *zap.Logger
 
-	NotificationEndpointService influxdb.NotificationEndpointService
-	UserResourceMappingService  influxdb.UserResourceMappingService
-	LabelService                influxdb.LabelService
-	UserService                 influxdb.UserService
-	OrganizationService         influxdb.OrganizationService
+	NotificationEndpointService     influxdb.NotificationEndpointService
+	NotificationEndpointTestService influxdb.NotificationEndpointTestService
+	UserResourceMappingService      influxdb.UserResourceMappingService
+	LabelService                    influxdb.LabelService
+	UserService                     influxdb.UserService
+	OrganizationService             influxdb.OrganizationService
 }
 
 // NewNotificationEndpointBackend returns a new instance of NotificationEndpointBackend.
@@ -35,11 +36,12 @@ func NewNotificationEndpointBackend(log *zap.Logger, b *APIBackend) *Notificatio
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              log,
 
-		NotificationEndpointService: b.NotificationEndpointService,
-		UserResourceMappingService:  b.UserResourceMappingService,
-		LabelService:                b.LabelService,
-		UserService:                 b.UserService,
-		OrganizationService:         b.OrganizationService,
+		NotificationEndpointService:     b.NotificationEndpointService,
+		NotificationEndpointTestService: b.NotificationEndpointTestService,
+		UserResourceMappingService:      b.UserResourceMappingService,
+		LabelService:                    b.LabelService,
+		UserService:                     b.UserService,
+		OrganizationService:             b.OrganizationService,
 	}
 }
 
@@ -53,16 +55,18 @@ type NotificationEndpointHandler struct {
 	influxdb.HTTPErrorHandler
 	log *zap.Logger
 
-	NotificationEndpointService influxdb.NotificationEndpointService
-	UserResourceMappingService  influxdb.UserResourceMappingService
-	LabelService                influxdb.LabelService
-	UserService                 influxdb.UserService
-	OrganizationService         influxdb.OrganizationService
+	NotificationEndpointService     influxdb.NotificationEndpointService
+	NotificationEndpointTestService influxdb.NotificationEndpointTestService
+	UserResourceMappingService      influxdb.UserResourceMappingService
+	LabelService                    influxdb.LabelService
+	UserService                     influxdb.UserService
+	OrganizationService             influxdb.OrganizationService
 }
 
 const (
 	prefixNotificationEndpoints          = "/api/v2/notificationEndpoints"
 	notificationEndpointsIDPath          = "/api/v2/notificationEndpoints/:id"
+	notificationEndpointsIDTestPath      = "/api/v2/notificationEndpoints/:id/test"
 	notificationEndpointsIDMembersPath   = "/api/v2/notificationEndpoints/:id/members"
 	notificationEndpointsIDMembersIDPath = "/api/v2/notificationEndpoints/:id/members/:userID"
 	notificationEndpointsIDOwnersPath    = "/api/v2/notificationEndpoints/:id/owners"
@@ -78,11 +82,12 @@ func NewNotificationEndpointHandler(log *zap.Logger, b *NotificationEndpointBack
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              log,
 
-		NotificationEndpointService: b.NotificationEndpointService,
-		UserResourceMappingService:  b.UserResourceMappingService,
-		LabelService:                b.LabelService,
-		UserService:                 b.UserService,
-		OrganizationService:         b.OrganizationService,
+		NotificationEndpointService:     b.NotificationEndpointService,
+		NotificationEndpointTestService: b.NotificationEndpointTestService,
+		UserResourceMappingService:      b.UserResourceMappingService,
+		LabelService:                    b.LabelService,
+		UserService:                     b.UserService,
+		OrganizationService:             b.OrganizationService,
 	}
 	h.HandlerFunc("POST", prefixNotificationEndpoints, h.handlePostNotificationEndpoint)
 	h.HandlerFunc("GET", prefixNotificationEndpoints, h.handleGetNotificationEndpoints)
@@ -90,6 +95,7 @@ func NewNotificationEndpointHandler(log *zap.Logger, b *NotificationEndpointBack
 	h.HandlerFunc("DELETE", notificationEndpointsIDPath, h.handleDeleteNotificationEndpoint)
 	h.HandlerFunc("PUT", notificationEndpointsIDPath, h.handlePutNotificationEndpoint)
 	h.HandlerFunc("PATCH", notificationEndpointsIDPath, h.handlePatchNotificationEndpoint)
+	h.HandlerFunc("POST", notificationEndpointsIDTestPath, h.handlePostNotificationEndpointTest)
 
 	memberBackend := MemberBackend{
 		HTTPErrorHandler:           b.HTTPErrorHandler,
@@ -549,6 +555,29 @@ func (h *NotificationEndpointHandler) handleDeleteNotificationEndpoint(w http.Re
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handlePostNotificationEndpointTest sends a test notification to the
+// notification endpoint and responds with how the endpoint responded.
+func (h *NotificationEndpointHandler) handlePostNotificationEndpointTest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetNotificationEndpointRequest(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	result, err := h.NotificationEndpointTestService.TestNotificationEndpoint(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("NotificationEndpoint tested", zap.String("notificationEndpointID", fmt.Sprint(id)), zap.Bool("sent", result.Sent))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, result); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
 // NotificationEndpointService is an http client for the influxdb.NotificationEndpointService server implementation.
 type NotificationEndpointService struct {
 	Client *httpc.Client
@@ -569,7 +598,10 @@ func NewNotificationEndpointService(client *httpc.Client) *NotificationEndpointS
 	}
 }
 
-var _ influxdb.NotificationEndpointService = (*NotificationEndpointService)(nil)
+var (
+	_ influxdb.NotificationEndpointService     = (*NotificationEndpointService)(nil)
+	_ influxdb.NotificationEndpointTestService = (*NotificationEndpointService)(nil)
+)
 
 // FindNotificationEndpointByID returns a single notification endpoint by ID.
 func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
@@ -691,6 +723,22 @@ func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Con
 	return nil, 0, err
 }
 
+// TestNotificationEndpoint sends a test notification to the notification endpoint with id.
+func (s *NotificationEndpointService) TestNotificationEndpoint(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+	if !id.Valid() {
+		return nil, fmt.Errorf("invalid ID: please provide a valid ID")
+	}
+	var result influxdb.NotificationEndpointTestResult
+	err := s.Client.
+		Post(nil, prefixNotificationEndpoints, id.String(), "test").
+		DecodeJSON(&result).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 type notificationEndpointEncoder struct {
 	ne influxdb.NotificationEndpoint
 }
@@ -28,13 +28,14 @@ import (
 // NewMockNotificationEndpointBackend returns a NotificationEndpointBackend with mock services.
 func NewMockNotificationEndpointBackend(t *testing.T) *NotificationEndpointBackend {
 	return &NotificationEndpointBackend{
-		log:                         zaptest.NewLogger(t),
-		HTTPErrorHandler:            kithttp.ErrorHandler(0),
-		NotificationEndpointService: &mock.NotificationEndpointService{},
-		UserResourceMappingService:  mock.NewUserResourceMappingService(),
-		LabelService:                mock.NewLabelService(),
-		UserService:                 mock.NewUserService(),
-		OrganizationService:         mock.NewOrganizationService(),
+		log:                             zaptest.NewLogger(t),
+		HTTPErrorHandler:                kithttp.ErrorHandler(0),
+		NotificationEndpointService:     &mock.NotificationEndpointService{},
+		NotificationEndpointTestService: mock.NewNotificationEndpointTestService(),
+		UserResourceMappingService:      mock.NewUserResourceMappingService(),
+		LabelService:                    mock.NewLabelService(),
+		UserService:                     mock.NewUserService(),
+		OrganizationService:             mock.NewOrganizationService(),
 	}
 }
 
@@ -1135,3 +1136,54 @@ func authCtxFn(userID influxdb.ID) func(context.Context) context.Context {
 		return pcontext.SetAuthorizer(ctx, &influxdb.Session{UserID: userID})
 	}
 }
+
+func TestService_handlePostNotificationEndpointTest(t *testing.T) {
+	endpointID := influxdb.ID(1)
+
+	backend := NewMockNotificationEndpointBackend(t)
+	testSvc := mock.NewNotificationEndpointTestService()
+	testSvc.TestNotificationEndpointFn = func(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+		if id != endpointID {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "notification endpoint not found"}
+		}
+		return &influxdb.NotificationEndpointTestResult{StatusCode: http.StatusForbidden, Error: "invalid_token"}, nil
+	}
+	backend.NotificationEndpointTestService = testSvc
+
+	testttp.
+		Post(t, "/api/v2/notificationEndpoints/"+endpointID.String()+"/test", nil).
+		Do(NewNotificationEndpointHandler(zaptest.NewLogger(t), backend)).
+		ExpectStatus(http.StatusOK).
+		ExpectBody(func(body *bytes.Buffer) {
+			if eq, diff, _ := jsonEqual(body.String(), `{"sent": false, "statusCode": 403, "error": "invalid_token"}`); !eq {
+				t.Errorf("unexpected result -got/+want\n%s", diff)
+			}
+		})
+
+	testttp.
+		Post(t, "/api/v2/notificationEndpoints/0000000000000002/test", nil).
+		Do(NewNotificationEndpointHandler(zaptest.NewLogger(t), backend)).
+		ExpectStatus(http.StatusNotFound)
+}
+
+func TestNotificationEndpointService_TestNotificationEndpoint(t *testing.T) {
+	var got *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"sent": true, "statusCode": 204}`))
+	}))
+	defer server.Close()
+
+	s := NewNotificationEndpointService(mustNewHTTPClient(t, server.URL, ""))
+	result, err := s.TestNotificationEndpoint(context.Background(), influxdb.ID(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (influxdb.NotificationEndpointTestResult{Sent: true, StatusCode: 204}); *result != want {
+		t.Errorf("unexpected result: got %+v, want %+v", *result, want)
+	}
+	if got.Method != http.MethodPost || got.URL.Path != "/api/v2/notificationEndpoints/0000000000000001/test" {
+		t.Errorf("unexpected request %s %s", got.Method, got.URL.Path)
+	}
+}
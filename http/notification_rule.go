@@ -36,6 +36,7 @@ type NotificationRuleBackend struct {
 	UserService                 influxdb.UserService
 	OrganizationService         influxdb.OrganizationService
 	TaskService                 influxdb.TaskService
+	AlertHistoryService         influxdb.AlertHistoryService
 }
 
 // NewNotificationRuleBackend returns a new instance of NotificationRuleBackend.
@@ -51,6 +52,7 @@ func NewNotificationRuleBackend(log *zap.Logger, b *APIBackend) *NotificationRul
 		UserService:                 b.UserService,
 		OrganizationService:         b.OrganizationService,
 		TaskService:                 b.TaskService,
+		AlertHistoryService:         b.AlertHistoryService,
 	}
 }
 
@@ -67,18 +69,20 @@ type NotificationRuleHandler struct {
 	UserService                 influxdb.UserService
 	OrganizationService         influxdb.OrganizationService
 	TaskService                 influxdb.TaskService
+	AlertHistoryService         influxdb.AlertHistoryService
 }
 
 const (
-	prefixNotificationRules          = "/api/v2/notificationRules"
-	notificationRulesIDPath          = "/api/v2/notificationRules/:id"
-	notificationRulesIDQueryPath     = "/api/v2/notificationRules/:id/query"
-	notificationRulesIDMembersPath   = "/api/v2/notificationRules/:id/members"
-	notificationRulesIDMembersIDPath = "/api/v2/notificationRules/:id/members/:userID"
-	notificationRulesIDOwnersPath    = "/api/v2/notificationRules/:id/owners"
-	notificationRulesIDOwnersIDPath  = "/api/v2/notificationRules/:id/owners/:userID"
-	notificationRulesIDLabelsPath    = "/api/v2/notificationRules/:id/labels"
-	notificationRulesIDLabelsIDPath  = "/api/v2/notificationRules/:id/labels/:lid"
+	prefixNotificationRules              = "/api/v2/notificationRules"
+	notificationRulesIDPath              = "/api/v2/notificationRules/:id"
+	notificationRulesIDQueryPath         = "/api/v2/notificationRules/:id/query"
+	notificationRulesIDNotificationsPath = "/api/v2/notificationRules/:id/notifications"
+	notificationRulesIDMembersPath       = "/api/v2/notificationRules/:id/members"
+	notificationRulesIDMembersIDPath     = "/api/v2/notificationRules/:id/members/:userID"
+	notificationRulesIDOwnersPath        = "/api/v2/notificationRules/:id/owners"
+	notificationRulesIDOwnersIDPath      = "/api/v2/notificationRules/:id/owners/:userID"
+	notificationRulesIDLabelsPath        = "/api/v2/notificationRules/:id/labels"
+	notificationRulesIDLabelsIDPath      = "/api/v2/notificationRules/:id/labels/:lid"
 )
 
 // NewNotificationRuleHandler returns a new instance of NotificationRuleHandler.
@@ -95,11 +99,13 @@ func NewNotificationRuleHandler(log *zap.Logger, b *NotificationRuleBackend) *No
 		UserService:                 b.UserService,
 		OrganizationService:         b.OrganizationService,
 		TaskService:                 b.TaskService,
+		AlertHistoryService:         b.AlertHistoryService,
 	}
 	h.HandlerFunc("POST", prefixNotificationRules, h.handlePostNotificationRule)
 	h.HandlerFunc("GET", prefixNotificationRules, h.handleGetNotificationRules)
 	h.HandlerFunc("GET", notificationRulesIDPath, h.handleGetNotificationRule)
 	h.HandlerFunc("GET", notificationRulesIDQueryPath, h.handleGetNotificationRuleQuery)
+	h.HandlerFunc("GET", notificationRulesIDNotificationsPath, h.handleGetNotificationRuleNotifications)
 	h.HandlerFunc("DELETE", notificationRulesIDPath, h.handleDeleteNotificationRule)
 	h.HandlerFunc("PUT", notificationRulesIDPath, h.handlePutNotificationRule)
 	h.HandlerFunc("PATCH", notificationRulesIDPath, h.handlePatchNotificationRule)
@@ -319,6 +325,48 @@ func (h *NotificationRuleHandler) handleGetNotificationRuleQuery(w http.Response
 	}
 }
 
+func (h *NotificationRuleHandler) handleGetNotificationRuleNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeGetNotificationRuleRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	filter, opts, err := decodeAlertHistoryFilter(r)
+	if err != nil {
+		h.log.Debug("Failed to decode request", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	nr, err := h.NotificationRuleStore.FindNotificationRuleByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	ruleID := nr.GetID()
+	filter.OrgID = nr.GetOrgID()
+	filter.RuleID = &ruleID
+
+	events, _, err := h.AlertHistoryService.FindNotificationEvents(ctx, *filter, *opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Notification rule notifications retrieved", zap.String("notificationRuleID", ruleID.String()), zap.Int("notifications", len(events)))
+
+	resp := notificationEventsResponse{
+		Links:         newPagingLinks(path.Join(prefixNotificationRules, ruleID.String(), "notifications"), *opts, filter, len(events)),
+		Notifications: events,
+	}
+	if resp.Notifications == nil {
+		resp.Notifications = []*influxdb.NotificationEvent{}
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, resp); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
 func (h *NotificationRuleHandler) handleGetNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := decodeGetNotificationRuleRequest(ctx, r)
@@ -30,6 +30,12 @@ func NewPlatformHandler(b *APIBackend, opts ...APIHandlerOptFn) *PlatformHandler
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/swagger.json")
 
+	// the influxdb 1.x compatible endpoints authenticate their own requests
+	// as 1.x clients provide their credentials in other ways.
+	h.RegisterNoAuthRoute("POST", prefixLegacyWrite)
+	h.RegisterNoAuthRoute("GET", prefixLegacyQuery)
+	h.RegisterNoAuthRoute("POST", prefixLegacyQuery)
+
 	assetHandler := NewAssetHandler()
 	assetHandler.Path = b.AssetsPath
 
@@ -53,6 +59,8 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
 	// of the platform API.
 	if !strings.HasPrefix(r.URL.Path, "/v1") &&
+		r.URL.Path != prefixLegacyWrite &&
+		r.URL.Path != prefixLegacyQuery &&
 		!strings.HasPrefix(r.URL.Path, "/api/v2") &&
 		!strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.AssetHandler.ServeHTTP(w, r)
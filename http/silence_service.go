@@ -0,0 +1,330 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+// SilenceBackend is all services and associated parameters required to construct
+// the SilenceHandler.
+type SilenceBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	SilenceService      influxdb.SilenceService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewSilenceBackend returns a new instance of SilenceBackend.
+func NewSilenceBackend(log *zap.Logger, b *APIBackend) *SilenceBackend {
+	return &SilenceBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		SilenceService:      b.SilenceService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// SilenceHandler is the handler for the silence service.
+type SilenceHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	api *kithttp.API
+	log *zap.Logger
+
+	SilenceService      influxdb.SilenceService
+	OrganizationService influxdb.OrganizationService
+}
+
+const (
+	prefixSilences = "/api/v2/silences"
+	silencesIDPath = "/api/v2/silences/:id"
+)
+
+// NewSilenceHandler returns a new instance of SilenceHandler.
+func NewSilenceHandler(log *zap.Logger, b *SilenceBackend) *SilenceHandler {
+	h := &SilenceHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		api:              kithttp.NewAPI(kithttp.WithLog(log)),
+		log:              log,
+
+		SilenceService:      b.SilenceService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("POST", prefixSilences, h.handlePostSilence)
+	h.HandlerFunc("GET", prefixSilences, h.handleGetSilences)
+	h.HandlerFunc("GET", silencesIDPath, h.handleGetSilence)
+	h.HandlerFunc("PATCH", silencesIDPath, h.handlePatchSilence)
+	h.HandlerFunc("DELETE", silencesIDPath, h.handleDeleteSilence)
+
+	return h
+}
+
+type silenceResponse struct {
+	*influxdb.Silence
+	Links map[string]string `json:"links"`
+}
+
+func newSilenceResponse(s *influxdb.Silence) *silenceResponse {
+	return &silenceResponse{
+		Silence: s,
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/silences/%s", s.ID),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", s.OrgID),
+		},
+	}
+}
+
+type silencesResponse struct {
+	Links    map[string]string  `json:"links"`
+	Silences []*silenceResponse `json:"silences"`
+}
+
+// handlePostSilence is the HTTP handler for the POST /api/v2/silences route.
+func (h *SilenceHandler) handlePostSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var s influxdb.Silence
+	if err := h.api.DecodeJSON(r.Body, &s); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	if !s.OrgID.Valid() {
+		h.api.Err(w, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "organization id must be provided",
+		})
+		return
+	}
+	s.ID = 0
+
+	if err := h.SilenceService.CreateSilence(ctx, &s, auth.GetUserID()); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Silence created", zap.String("silence", fmt.Sprint(s)))
+
+	h.api.Respond(w, http.StatusCreated, newSilenceResponse(&s))
+}
+
+// handleGetSilences is the HTTP handler for the GET /api/v2/silences route.
+func (h *SilenceHandler) handleGetSilences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := h.decodeSilenceFilter(ctx, r)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	ss, _, err := h.SilenceService.FindSilences(ctx, filter)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	res := &silencesResponse{
+		Links: map[string]string{
+			"self": prefixSilences,
+		},
+		Silences: make([]*silenceResponse, 0, len(ss)),
+	}
+	for _, s := range ss {
+		res.Silences = append(res.Silences, newSilenceResponse(s))
+	}
+	h.api.Respond(w, http.StatusOK, res)
+}
+
+func (h *SilenceHandler) decodeSilenceFilter(ctx context.Context, r *http.Request) (influxdb.SilenceFilter, error) {
+	var filter influxdb.SilenceFilter
+	q := r.URL.Query()
+	if orgID := q.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "orgID is invalid",
+				Err:  err,
+			}
+		}
+		filter.OrgID = id
+	} else if org := q.Get("org"); org != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+		if err != nil {
+			return filter, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	if at := q.Get("activeAt"); at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return filter, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "activeAt must be an RFC3339 time",
+				Err:  err,
+			}
+		}
+		filter.ActiveAt = &t
+	}
+	return filter, nil
+}
+
+// handleGetSilence is the HTTP handler for the GET /api/v2/silences/:id route.
+func (h *SilenceHandler) handleGetSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	s, err := h.SilenceService.FindSilenceByID(ctx, id)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	h.api.Respond(w, http.StatusOK, newSilenceResponse(s))
+}
+
+// handlePatchSilence is the HTTP handler for the PATCH /api/v2/silences/:id route.
+func (h *SilenceHandler) handlePatchSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	var upd influxdb.SilenceUpdate
+	if err := h.api.DecodeJSON(r.Body, &upd); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	s, err := h.SilenceService.UpdateSilence(ctx, id, upd)
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Silence updated", zap.String("silence", fmt.Sprint(s)))
+
+	h.api.Respond(w, http.StatusOK, newSilenceResponse(s))
+}
+
+// handleDeleteSilence is the HTTP handler for the DELETE /api/v2/silences/:id route.
+func (h *SilenceHandler) handleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := decodeIDFromCtx(ctx, "id")
+	if err != nil {
+		h.api.Err(w, err)
+		return
+	}
+
+	if err := h.SilenceService.DeleteSilence(ctx, id); err != nil {
+		h.api.Err(w, err)
+		return
+	}
+	h.log.Debug("Silence deleted", zap.String("silenceID", id.String()))
+
+	h.api.Respond(w, http.StatusNoContent, nil)
+}
+
+// SilenceService connects to Influx via HTTP using tokens to manage silences.
+type SilenceService struct {
+	Client *httpc.Client
+}
+
+var _ influxdb.SilenceService = (*SilenceService)(nil)
+
+// FindSilenceByID returns a single silence by ID.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	var resp silenceResponse
+	err := s.Client.
+		Get(prefixSilences, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Silence, nil
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+	var params [][2]string
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+	if filter.ActiveAt != nil {
+		params = append(params, [2]string{"activeAt", filter.ActiveAt.Format(time.RFC3339)})
+	}
+
+	var resp silencesResponse
+	err := s.Client.
+		Get(prefixSilences).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ss := make([]*influxdb.Silence, 0, len(resp.Silences))
+	for _, sr := range resp.Silences {
+		ss = append(ss, sr.Silence)
+	}
+	return ss, len(ss), nil
+}
+
+// CreateSilence creates a new silence and sets sil.ID with the new identifier.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence, userID influxdb.ID) error {
+	var resp silenceResponse
+	err := s.Client.
+		PostJSON(sil, prefixSilences).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	*sil = *resp.Silence
+	return nil
+}
+
+// UpdateSilence updates a single silence with changeset.
+// Returns the new silence state after update.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	var resp silenceResponse
+	err := s.Client.
+		PatchJSON(upd, prefixSilences, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Silence, nil
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixSilences, id.String()).
+		Do(ctx)
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/options"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSilenceService(t *testing.T) {
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	silences := map[influxdb.ID]*influxdb.Silence{}
+
+	svc := mock.NewSilenceService()
+	svc.CreateSilenceFn = func(_ context.Context, s *influxdb.Silence, userID influxdb.ID) error {
+		if userID != 6 {
+			t.Errorf("expected silence created by user 6, got %s", userID)
+		}
+		s.ID = 1
+		s.OwnerID = userID
+		silences[s.ID] = s
+		return nil
+	}
+	svc.FindSilencesFn = func(_ context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+		if filter.OrgID == nil || *filter.OrgID != 10 {
+			t.Errorf("unexpected org filter %v", filter.OrgID)
+		}
+		if filter.ActiveAt == nil || !filter.ActiveAt.Equal(start) {
+			t.Errorf("unexpected time filter %v", filter.ActiveAt)
+		}
+		var ss []*influxdb.Silence
+		for _, s := range silences {
+			ss = append(ss, s)
+		}
+		return ss, len(ss), nil
+	}
+	svc.UpdateSilenceFn = func(_ context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+		s, ok := silences[id]
+		if !ok {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "silence not found"}
+		}
+		upd.Apply(s)
+		return s, nil
+	}
+	svc.DeleteSilenceFn = func(_ context.Context, id influxdb.ID) error {
+		delete(silences, id)
+		return nil
+	}
+
+	backend := &SilenceBackend{
+		HTTPErrorHandler: kithttp.ErrorHandler(0),
+		log:              zaptest.NewLogger(t),
+		SilenceService:   svc,
+	}
+	h := NewSilenceHandler(zaptest.NewLogger(t), backend)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Session{UserID: 6}))
+		h.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	s := &SilenceService{Client: mustNewHTTPClient(t, server.URL, "")}
+	ctx := context.Background()
+
+	sil := &influxdb.Silence{
+		OrgID:     10,
+		Name:      "deploy",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Every:     options.MustParseDuration("1w"),
+		TagRules: []influxdb.TagRule{
+			{Tag: influxdb.Tag{Key: "host", Value: "web-1"}, Operator: influxdb.Equal},
+		},
+	}
+	if err := s.CreateSilence(ctx, sil, 6); err != nil {
+		t.Fatal(err)
+	}
+	if sil.ID != 1 || sil.OwnerID != 6 {
+		t.Fatalf("unexpected silence created %+v", sil)
+	}
+
+	orgID := influxdb.ID(10)
+	ss, n, err := s.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID, ActiveAt: &start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ss[0].Every.String() != "1w" || ss[0].TagRules[0].Value != "web-1" {
+		t.Fatalf("unexpected silences %+v", ss)
+	}
+
+	name := "nightly deploy"
+	upd, err := s.UpdateSilence(ctx, sil.ID, influxdb.SilenceUpdate{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upd.Name != name {
+		t.Fatalf("unexpected silence updated %+v", upd)
+	}
+
+	if err := s.DeleteSilence(ctx, sil.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateSilence(ctx, sil.ID, influxdb.SilenceUpdate{Name: &name}); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected deleted silence to be not found, got %v", err)
+	}
+
+	if err := s.CreateSilence(ctx, &influxdb.Silence{Name: "no org"}, 6); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error without organization, got %v", err)
+	}
+}
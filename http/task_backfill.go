@@ -0,0 +1,283 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+const (
+	tasksIDBackfillsPath           = "/api/v2/tasks/:id/backfills"
+	tasksIDBackfillsIDPath         = "/api/v2/tasks/:id/backfills/:bid"
+	tasksIDBackfillsIDCancelPath   = "/api/v2/tasks/:id/backfills/:bid/cancel"
+	maxBackfillRequestContentBytes = 1000
+)
+
+type backfillResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.Backfill
+}
+
+func newBackfillResponse(b influxdb.Backfill) backfillResponse {
+	return backfillResponse{
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/tasks/%s/backfills/%s", b.TaskID, b.ID),
+			"task":   fmt.Sprintf("/api/v2/tasks/%s", b.TaskID),
+			"cancel": fmt.Sprintf("/api/v2/tasks/%s/backfills/%s/cancel", b.TaskID, b.ID),
+		},
+		Backfill: b,
+	}
+}
+
+type backfillsResponse struct {
+	Links     map[string]string  `json:"links"`
+	Backfills []backfillResponse `json:"backfills"`
+}
+
+func newBackfillsResponse(bs []*influxdb.Backfill, taskID influxdb.ID) backfillsResponse {
+	r := backfillsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Backfills: make([]backfillResponse, 0, len(bs)),
+	}
+	for _, b := range bs {
+		r.Backfills = append(r.Backfills, newBackfillResponse(*b))
+	}
+	return r
+}
+
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeTaskID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var bc influxdb.BackfillCreate
+	if r.ContentLength > maxBackfillRequestContentBytes {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "backfill request is too large",
+		}, w)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&bc); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	b, err := h.TaskService.BackfillTask(ctx, taskID, bc)
+	if err != nil {
+		h.HandleHTTPError(ctx, backfillTaskError(err, "failed to backfill task"), w)
+		return
+	}
+	h.log.Debug("Task backfill created", zap.Stringer("taskID", taskID), zap.Stringer("backfillID", b.ID))
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetBackfills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeTaskID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	bs, _, err := h.TaskService.FindBackfills(ctx, taskID)
+	if err != nil {
+		h.HandleHTTPError(ctx, backfillTaskError(err, "failed to find backfills"), w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillsResponse(bs, taskID)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskService.FindBackfillByID(ctx, taskID, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, backfillTaskError(err, "failed to find backfill"), w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, id, err := decodeBackfillID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskService.CancelBackfill(ctx, taskID, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, backfillTaskError(err, "failed to cancel backfill"), w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+// backfillTaskError wraps the error of the task service, reporting a missing
+// task as not found.
+func backfillTaskError(err error, msg string) error {
+	e := &influxdb.Error{
+		Err: err,
+		Msg: msg,
+	}
+	if err == influxdb.ErrTaskNotFound {
+		e.Code = influxdb.ENotFound
+	}
+	return e
+}
+
+func decodeTaskID(ctx context.Context) (influxdb.ID, error) {
+	tid := httprouter.ParamsFromContext(ctx).ByName("id")
+	if tid == "" {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(tid); err != nil {
+		return 0, err
+	}
+	return i, nil
+}
+
+func decodeBackfillID(ctx context.Context) (influxdb.ID, influxdb.ID, error) {
+	taskID, err := decodeTaskID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	bid := httprouter.ParamsFromContext(ctx).ByName("bid")
+	if bid == "" {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a backfill ID",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(bid); err != nil {
+		return 0, 0, err
+	}
+	return taskID, i, nil
+}
+
+// BackfillTask creates a backfill running the task for every point of its
+// schedule in the range.
+func (t TaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var br backfillResponse
+	err := t.Client.
+		PostJSON(bc, taskIDBackfillsPath(taskID)).
+		DecodeJSON(&br).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+// FindBackfills returns the backfills of a task, the most recently created
+// first.
+func (t TaskService) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var br backfillsResponse
+	err := t.Client.
+		Get(taskIDBackfillsPath(taskID)).
+		DecodeJSON(&br).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bs := make([]*influxdb.Backfill, 0, len(br.Backfills))
+	for i := range br.Backfills {
+		bs = append(bs, &br.Backfills[i].Backfill)
+	}
+	return bs, len(bs), nil
+}
+
+// FindBackfillByID returns a single backfill of a task.
+func (t TaskService) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var br backfillResponse
+	err := t.Client.
+		Get(taskIDBackfillsPath(taskID), id.String()).
+		DecodeJSON(&br).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+// CancelBackfill stops a running backfill.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var br backfillResponse
+	err := t.Client.
+		Post(nil, taskIDBackfillsPath(taskID), id.String(), "cancel").
+		DecodeJSON(&br).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+func taskIDBackfillsPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "backfills")
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTaskHandler_handlePostBackfill(t *testing.T) {
+	type fields struct {
+		taskService influxdb.TaskService
+	}
+	type args struct {
+		taskID influxdb.ID
+		body   string
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	created, _ := time.Parse(time.RFC3339, "2020-03-02T00:00:00Z")
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "create a backfill",
+			fields: fields{
+				taskService: &mock.TaskService{
+					BackfillTaskFn: func(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+						return &influxdb.Backfill{
+							ID:             2,
+							TaskID:         taskID,
+							Start:          bc.Start,
+							Stop:           bc.Stop,
+							MaxConcurrency: bc.MaxConcurrency,
+							Status:         influxdb.BackfillRunning,
+							Total:          60,
+							CreatedAt:      created,
+							UpdatedAt:      created,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				taskID: 1,
+				body:   `{"start": "2020-03-01T00:00:00Z", "stop": "2020-03-01T01:00:00Z", "maxConcurrency": 2}`,
+			},
+			wants: wants{
+				statusCode:  http.StatusCreated,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/backfills/0000000000000002",
+    "task": "/api/v2/tasks/0000000000000001",
+    "cancel": "/api/v2/tasks/0000000000000001/backfills/0000000000000002/cancel"
+  },
+  "id": "0000000000000002",
+  "taskID": "0000000000000001",
+  "start": "2020-03-01T00:00:00Z",
+  "stop": "2020-03-01T01:00:00Z",
+  "maxConcurrency": 2,
+  "status": "running",
+  "total": 60,
+  "succeeded": 0,
+  "failed": 0,
+  "createdAt": "2020-03-02T00:00:00Z",
+  "updatedAt": "2020-03-02T00:00:00Z"
+}`,
+			},
+		},
+		{
+			name: "invalid backfill",
+			fields: fields{
+				taskService: &mock.TaskService{
+					BackfillTaskFn: func(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+						return nil, bc.Validate()
+					},
+				},
+			},
+			args: args{
+				taskID: 1,
+				body:   `{"start": "2020-03-01T01:00:00Z", "stop": "2020-03-01T00:00:00Z"}`,
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "malformed request",
+			fields: fields{
+				taskService: &mock.TaskService{},
+			},
+			args: args{
+				taskID: 1,
+				body:   `{"start": 1}`,
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(tt.args.body))
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.args.taskID.String(),
+					},
+				}))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{Permissions: influxdb.OperPermissions()}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			taskBackend.TaskService = tt.fields.taskService
+			h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+			h.handlePostBackfill(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handlePostBackfill() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("%q. handlePostBackfill() = %v, want %v", tt.name, content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handlePostBackfill(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handlePostBackfill() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
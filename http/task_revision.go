@@ -0,0 +1,264 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"go.uber.org/zap"
+)
+
+const (
+	tasksIDRevisionsPath               = "/api/v2/tasks/:id/revisions"
+	tasksIDRevisionsNumberPath         = "/api/v2/tasks/:id/revisions/:revision"
+	tasksIDRevisionsNumberRollbackPath = "/api/v2/tasks/:id/revisions/:revision/rollback"
+)
+
+// revisionResponse represents the offset of the revision like the offset of
+// a task.
+type revisionResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.TaskRevision
+	Offset string `json:"offset,omitempty"`
+}
+
+func newRevisionResponse(r influxdb.TaskRevision) revisionResponse {
+	offset := ""
+	if r.Offset != 0 {
+		offset = customParseDuration(r.Offset)
+	}
+	return revisionResponse{
+		Links: map[string]string{
+			"self":     fmt.Sprintf("/api/v2/tasks/%s/revisions/%d", r.TaskID, r.Revision),
+			"task":     fmt.Sprintf("/api/v2/tasks/%s", r.TaskID),
+			"rollback": fmt.Sprintf("/api/v2/tasks/%s/revisions/%d/rollback", r.TaskID, r.Revision),
+		},
+		TaskRevision: r,
+		Offset:       offset,
+	}
+}
+
+func (r revisionResponse) toInfluxDB() (*influxdb.TaskRevision, error) {
+	rev := r.TaskRevision
+	if r.Offset != "" {
+		d, err := time.ParseDuration(r.Offset)
+		if err != nil {
+			return nil, err
+		}
+		rev.Offset = d
+	}
+	return &rev, nil
+}
+
+type revisionsResponse struct {
+	Links     map[string]string  `json:"links"`
+	Revisions []revisionResponse `json:"revisions"`
+}
+
+func newRevisionsResponse(rs []*influxdb.TaskRevision, taskID influxdb.ID) revisionsResponse {
+	r := revisionsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/revisions", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Revisions: make([]revisionResponse, 0, len(rs)),
+	}
+	for _, rev := range rs {
+		r.Revisions = append(r.Revisions, newRevisionResponse(*rev))
+	}
+	return r
+}
+
+func (h *TaskHandler) handleGetRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, err := decodeTaskID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	rs, _, err := h.TaskService.FindTaskRevisions(ctx, taskID)
+	if err != nil {
+		h.HandleHTTPError(ctx, taskRevisionError(err, "failed to find task revisions"), w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newRevisionsResponse(rs, taskID)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleGetRevision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, revision, err := decodeRevision(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	rev, err := h.TaskService.FindTaskRevision(ctx, taskID, revision)
+	if err != nil {
+		h.HandleHTTPError(ctx, taskRevisionError(err, "failed to find task revision"), w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newRevisionResponse(*rev)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleRollbackTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, revision, err := decodeRevision(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	restoreStatus, err := decodeRestoreStatus(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	task, err := h.TaskService.RollbackTask(ctx, taskID, revision, restoreStatus)
+	if err != nil {
+		h.HandleHTTPError(ctx, taskRevisionError(err, "failed to roll back task"), w)
+		return
+	}
+
+	labels, err := h.LabelService.FindResourceLabels(ctx, influxdb.LabelMappingFilter{ResourceID: task.ID, ResourceType: influxdb.TasksResourceType})
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Err: err,
+			Msg: "failed to find resource labels",
+		}, w)
+		return
+	}
+	h.log.Debug("Task rolled back", zap.Stringer("taskID", taskID), zap.Int("revision", revision))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task, labels)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+// decodeRestoreStatus returns the restoreStatus query parameter of the
+// rollback, false when it is missing.
+func decodeRestoreStatus(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("restoreStatus")
+	if v == "" {
+		return false, nil
+	}
+	restoreStatus, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "restoreStatus must be true or false",
+		}
+	}
+	return restoreStatus, nil
+}
+
+// taskRevisionError wraps the error of the task service, reporting a missing
+// task as not found.
+func taskRevisionError(err error, msg string) error {
+	e := &influxdb.Error{
+		Err: err,
+		Msg: msg,
+	}
+	if err == influxdb.ErrTaskNotFound {
+		e.Code = influxdb.ENotFound
+	}
+	return e
+}
+
+func decodeRevision(ctx context.Context) (influxdb.ID, int, error) {
+	taskID, err := decodeTaskID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	revision, err := strconv.Atoi(httprouter.ParamsFromContext(ctx).ByName("revision"))
+	if err != nil || revision < 1 {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a revision number",
+		}
+	}
+	return taskID, revision, nil
+}
+
+// FindTaskRevisions returns the revisions of a task, the most recent first.
+func (t TaskService) FindTaskRevisions(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var rr revisionsResponse
+	err := t.Client.
+		Get(taskIDRevisionsPath(taskID)).
+		DecodeJSON(&rr).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rs := make([]*influxdb.TaskRevision, 0, len(rr.Revisions))
+	for _, r := range rr.Revisions {
+		rev, err := r.toInfluxDB()
+		if err != nil {
+			return nil, 0, err
+		}
+		rs = append(rs, rev)
+	}
+	return rs, len(rs), nil
+}
+
+// FindTaskRevision returns a single revision of a task by its number.
+func (t TaskService) FindTaskRevision(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var rr revisionResponse
+	err := t.Client.
+		Get(taskIDRevisionsPath(taskID), strconv.Itoa(revision)).
+		DecodeJSON(&rr).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rr.toInfluxDB()
+}
+
+// RollbackTask restores a task to one of its revisions, and to its status
+// when restoreStatus is set.
+func (t TaskService) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int, restoreStatus bool) (*Task, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var tr taskResponse
+	err := t.Client.
+		Post(nil, taskIDRevisionsPath(taskID), strconv.Itoa(revision), "rollback").
+		QueryParams([2]string{"restoreStatus", strconv.FormatBool(restoreStatus)}).
+		DecodeJSON(&tr).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tr.Task, nil
+}
+
+func taskIDRevisionsPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "revisions")
+}
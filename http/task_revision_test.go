@@ -0,0 +1,212 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	kithttp "github.com/influxdata/influxdb/kit/transport/http"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTaskHandler_handleGetRevisions(t *testing.T) {
+	type fields struct {
+		taskService influxdb.TaskService
+	}
+	type args struct {
+		taskID influxdb.ID
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	created, _ := time.Parse(time.RFC3339, "2020-03-02T00:00:00Z")
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "get revisions",
+			fields: fields{
+				taskService: &mock.TaskService{
+					FindTaskRevisionsFn: func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+						return []*influxdb.TaskRevision{
+							{
+								TaskID:    taskID,
+								Revision:  1,
+								Flux:      "option task = {name: \"rollup\", every: 1h, offset: 5m}",
+								Name:      "rollup",
+								Status:    "active",
+								Every:     "1h",
+								Offset:    5 * time.Minute,
+								AuthorID:  3,
+								CreatedAt: created,
+							},
+						}, 1, nil
+					},
+				},
+			},
+			args: args{
+				taskID: 1,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/revisions",
+    "task": "/api/v2/tasks/0000000000000001"
+  },
+  "revisions": [
+    {
+      "links": {
+        "self": "/api/v2/tasks/0000000000000001/revisions/1",
+        "task": "/api/v2/tasks/0000000000000001",
+        "rollback": "/api/v2/tasks/0000000000000001/revisions/1/rollback"
+      },
+      "taskID": "0000000000000001",
+      "revision": 1,
+      "flux": "option task = {name: \"rollup\", every: 1h, offset: 5m}",
+      "name": "rollup",
+      "status": "active",
+      "every": "1h",
+      "offset": "5m",
+      "authorID": "0000000000000003",
+      "createdAt": "2020-03-02T00:00:00Z"
+    }
+  ]
+}`,
+			},
+		},
+		{
+			name: "task not found",
+			fields: fields{
+				taskService: &mock.TaskService{
+					FindTaskRevisionsFn: func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+						return nil, 0, influxdb.ErrTaskNotFound
+					},
+				},
+			},
+			args: args{
+				taskID: 1,
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.args.taskID.String(),
+					},
+				}))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{Permissions: influxdb.OperPermissions()}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			taskBackend.TaskService = tt.fields.taskService
+			h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+			h.handleGetRevisions(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handleGetRevisions() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("%q. handleGetRevisions() = %v, want %v", tt.name, content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handleGetRevisions(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handleGetRevisions() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskHandler_handleRollbackTask(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		statusCode    int
+		restoreStatus bool
+	}{
+		{
+			name:       "rollback leaving the status",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:          "rollback restoring the status",
+			query:         "?restoreStatus=true",
+			statusCode:    http.StatusOK,
+			restoreStatus: true,
+		},
+		{
+			name:       "invalid restore status",
+			query:      "?restoreStatus=maybe",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var restoreStatus bool
+			taskService := &mock.TaskService{
+				RollbackTaskFn: func(ctx context.Context, taskID influxdb.ID, revision int, rs bool) (*influxdb.Task, error) {
+					restoreStatus = rs
+					return &influxdb.Task{ID: taskID, OrganizationID: 2, Name: "rollup", Status: "active", Every: "1h"}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", "http://any.url"+tt.query, nil)
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{Key: "id", Value: influxdb.ID(1).String()},
+					{Key: "revision", Value: "1"},
+				}))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{Permissions: influxdb.OperPermissions()}))
+			w := httptest.NewRecorder()
+			taskBackend := NewMockTaskBackend(t)
+			taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			taskBackend.TaskService = taskService
+			h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+			h.handleRollbackTask(w, r)
+
+			res := w.Result()
+			if res.StatusCode != tt.statusCode {
+				t.Errorf("%q. handleRollbackTask() = %v, want %v", tt.name, res.StatusCode, tt.statusCode)
+			}
+			if restoreStatus != tt.restoreStatus {
+				t.Errorf("%q. handleRollbackTask() restoreStatus = %v, want %v", tt.name, restoreStatus, tt.restoreStatus)
+			}
+		})
+	}
+}
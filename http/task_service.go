@@ -137,6 +137,15 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("POST", tasksIDBackfillsPath, h.handlePostBackfill)
+	h.HandlerFunc("GET", tasksIDBackfillsPath, h.handleGetBackfills)
+	h.HandlerFunc("GET", tasksIDBackfillsIDPath, h.handleGetBackfill)
+	h.HandlerFunc("POST", tasksIDBackfillsIDCancelPath, h.handleCancelBackfill)
+
+	h.HandlerFunc("GET", tasksIDRevisionsPath, h.handleGetRevisions)
+	h.HandlerFunc("GET", tasksIDRevisionsNumberPath, h.handleGetRevision)
+	h.HandlerFunc("POST", tasksIDRevisionsNumberRollbackPath, h.handleRollbackTask)
+
 	labelBackend := &LabelBackend{
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              b.log.With(zap.String("handler", "label")),
@@ -163,7 +172,9 @@ type Task struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Location        string                 `json:"location,omitempty"`
 	Offset          string                 `json:"offset,omitempty"`
+	DependsOn       []influxdb.ID          `json:"dependsOn,omitempty"`
 	LatestCompleted string                 `json:"latestCompleted,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
@@ -208,7 +219,9 @@ func NewFrontEndTask(t influxdb.Task) Task {
 		Flux:            t.Flux,
 		Every:           t.Every,
 		Cron:            t.Cron,
+		Location:        t.Location,
 		Offset:          offset,
+		DependsOn:       t.DependsOn,
 		LatestCompleted: latestCompleted,
 		LastRunStatus:   t.LastRunStatus,
 		LastRunError:    t.LastRunError,
@@ -341,6 +354,8 @@ type httpRun struct {
 	StartedAt    *time.Time     `json:"startedAt,omitempty"`
 	FinishedAt   *time.Time     `json:"finishedAt,omitempty"`
 	RequestedAt  *time.Time     `json:"requestedAt,omitempty"`
+	Attempt      int            `json:"attempt,omitempty"`
+	RetryOf      *influxdb.ID   `json:"retryOf,omitempty"`
 	Log          []influxdb.Log `json:"log,omitempty"`
 }
 
@@ -351,6 +366,7 @@ func newRunResponse(r influxdb.Run) runResponse {
 		Status:       r.Status,
 		Log:          r.Log,
 		ScheduledFor: &r.ScheduledFor,
+		Attempt:      r.Attempt,
 	}
 
 	if !r.StartedAt.IsZero() {
@@ -362,6 +378,9 @@ func newRunResponse(r influxdb.Run) runResponse {
 	if !r.RequestedAt.IsZero() {
 		run.RequestedAt = &r.RequestedAt
 	}
+	if r.RetryOf.Valid() {
+		run.RetryOf = &r.RetryOf
+	}
 
 	return runResponse{
 		Links: map[string]string{
@@ -376,10 +395,11 @@ func newRunResponse(r influxdb.Run) runResponse {
 
 func convertRun(r httpRun) *influxdb.Run {
 	run := &influxdb.Run{
-		ID:     r.ID,
-		TaskID: r.TaskID,
-		Status: r.Status,
-		Log:    r.Log,
+		ID:      r.ID,
+		TaskID:  r.TaskID,
+		Status:  r.Status,
+		Attempt: r.Attempt,
+		Log:     r.Log,
 	}
 
 	if r.StartedAt != nil {
@@ -398,6 +418,10 @@ func convertRun(r httpRun) *influxdb.Run {
 		run.ScheduledFor = *r.ScheduledFor
 	}
 
+	if r.RetryOf != nil {
+		run.RetryOf = *r.RetryOf
+	}
+
 	return run
 }
 
@@ -8,6 +8,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb"
@@ -284,6 +286,9 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		options = append(options, req.Precision)
 	}
 
+	var stats models.ParserStats
+	options = append(options, models.WithParserStats(&stats))
+
 	points, err := models.ParsePointsWithOptions(data, mm, options...)
 	span.LogKV("values_total", len(points))
 	span.Finish()
@@ -302,6 +307,23 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
+		// The points that were not dropped have been written.
+		if influxdb.ErrorCode(err) == influxdb.ESeriesLimitExceeded {
+			log.Info("Points creating new series dropped from write", zap.Error(err))
+			if e, ok := err.(*influxdb.Error); ok {
+				if pwe, ok := e.Err.(tsdb.PartialWriteError); ok {
+					err = &influxdb.Error{Code: e.Code, Msg: e.Msg, Err: newPartialWriteLinesError(pwe, points, stats.Lines)}
+				}
+			}
+			handleError(err, influxdb.ESeriesLimitExceeded, "failure writing points to database")
+			return
+		}
+		if pwe, ok := err.(tsdb.PartialWriteError); ok {
+			log.Info("Points dropped from write", zap.Int("dropped", pwe.Dropped), zap.String("reason", pwe.Reason))
+			handleError(newPartialWriteLinesError(pwe, points, stats.Lines), partialWriteCode(pwe), "failure writing points to database")
+			return
+		}
+
 		log.Error("Error writing points", zap.Error(err))
 		handleError(err, influxdb.EInternal, "unexpected error writing points to database")
 		return
@@ -310,6 +332,78 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// partialWriteCode returns the error code of a partial write. Points that
+// conflict with the data already written are unprocessable, while any invalid
+// point, such as one not matching the schema of the bucket, makes the request
+// invalid.
+func partialWriteCode(pwe tsdb.PartialWriteError) string {
+	if len(pwe.DroppedPoints) == 0 {
+		return influxdb.EUnprocessableEntity
+	}
+	for _, p := range pwe.DroppedPoints {
+		if !p.Conflict {
+			return influxdb.EInvalid
+		}
+	}
+	return influxdb.EUnprocessableEntity
+}
+
+// partialWriteLinesError is a partial write error that describes why the
+// points of each line of the request were dropped.
+type partialWriteLinesError struct {
+	dropped int
+	lines   []string
+}
+
+// newPartialWriteLinesError returns the error of the partial write pwe of the
+// points parsed from the request, where lines holds the line of each point.
+// It returns pwe if it does not describe the points dropped.
+func newPartialWriteLinesError(pwe tsdb.PartialWriteError, points []models.Point, lines []int) error {
+	if len(pwe.DroppedPoints) == 0 || len(points) != len(lines) {
+		return pwe
+	}
+
+	pointLines := make(map[models.Point]int, len(points))
+	for i, p := range points {
+		pointLines[p] = lines[i]
+	}
+
+	type lineReason struct {
+		line   int
+		reason string
+	}
+	var (
+		seen    = make(map[lineReason]bool, len(pwe.DroppedPoints))
+		reasons []lineReason
+	)
+	for _, p := range pwe.DroppedPoints {
+		// The fields of a line are written as points of their own, which are
+		// usually dropped for the same reason.
+		lr := lineReason{line: pointLines[p.Point], reason: p.Reason}
+		if !seen[lr] {
+			seen[lr] = true
+			reasons = append(reasons, lr)
+		}
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasons[i].line < reasons[j].line
+	})
+
+	e := &partialWriteLinesError{dropped: pwe.Dropped}
+	for _, lr := range reasons {
+		if lr.line == 0 {
+			e.lines = append(e.lines, lr.reason)
+			continue
+		}
+		e.lines = append(e.lines, fmt.Sprintf("line %d: %s", lr.line, lr.reason))
+	}
+	return e
+}
+
+func (e *partialWriteLinesError) Error() string {
+	return fmt.Sprintf("partial write: dropped=%d\n%s", e.dropped, strings.Join(e.lines, "\n"))
+}
+
 func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest, error) {
 	qp := r.URL.Query()
 	p := qp.Get("precision")
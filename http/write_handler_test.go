@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -16,7 +17,9 @@ import (
 	httpmock "github.com/influxdata/influxdb/http/mock"
 	kithttp "github.com/influxdata/influxdb/kit/transport/http"
 	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
 	influxtesting "github.com/influxdata/influxdb/testing"
+	"github.com/influxdata/influxdb/tsdb"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -142,6 +145,52 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 				body: `{"code":"internal error","message":"unexpected error writing points to database: error"}`,
 			},
 		},
+		{
+			name: "points dropped by the points writer is a partial write",
+			request: request{
+				org:    "043e0780ee2b1000",
+				bucket: "04504b356e23b000",
+				body:   "m1,t1=v1 f1=1",
+				auth:   bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+				writeErr: tsdb.PartialWriteError{
+					Reason:  `schema violation: measurement "m1" is not in the bucket schema`,
+					Dropped: 1,
+				},
+			},
+			wants: wants{
+				code: 422,
+				body: `{"code":"unprocessable entity","message":"failure writing points to database: partial write: schema violation: measurement \"m1\" is not in the bucket schema dropped=1"}`,
+			},
+		},
+		{
+			name: "points dropped beyond the series limits",
+			request: request{
+				org:    "043e0780ee2b1000",
+				bucket: "04504b356e23b000",
+				body:   "m1,t1=v1 f1=1",
+				auth:   bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"),
+			},
+			state: state{
+				org:    testOrg("043e0780ee2b1000"),
+				bucket: testBucket("043e0780ee2b1000", "04504b356e23b000"),
+				writeErr: &influxdb.Error{
+					Code: influxdb.ESeriesLimitExceeded,
+					Msg:  "points creating new series beyond the series limits were dropped",
+					Err: tsdb.PartialWriteError{
+						Reason:  "max series per bucket exceeded: bucket 04504b356e23b000 has 10 series, the limit is 10",
+						Dropped: 1,
+					},
+				},
+			},
+			wants: wants{
+				code: 422,
+				body: `{"code":"series limit exceeded","message":"failure writing points to database: points creating new series beyond the series limits were dropped: partial write: max series per bucket exceeded: bucket 04504b356e23b000 has 10 series, the limit is 10 dropped=1"}`,
+			},
+		},
 		{
 			name: "empty request body returns 400 error",
 			request: request{
@@ -376,6 +425,90 @@ func TestWriteHandler_handleWrite(t *testing.T) {
 	}
 }
 
+func TestWriteHandler_handleWrite_partialWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code int
+		want string
+	}{
+		{
+			name: "invalid points make the request invalid",
+			body: "m1,t1=v1 f1=1\nm1,t1=bad f1=1,f2=2\n\nm1,t1=conflict f1=1\nm1,t1=v1 f1=1",
+			code: 400,
+			want: `{"code":"invalid","message":"failure writing points to database: partial write: dropped=3\nline 2: invalid point\nline 4: conflicting point"}`,
+		},
+		{
+			name: "conflicting points are unprocessable",
+			body: "m1,t1=v1 f1=1\nm1,t1=conflict f1=1",
+			code: 422,
+			want: `{"code":"unprocessable entity","message":"failure writing points to database: partial write: dropped=1\nline 2: conflicting point"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := mock.NewOrganizationService()
+			orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+				return testOrg("043e0780ee2b1000"), nil
+			}
+			buckets := mock.NewBucketService()
+			buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+				return testBucket("043e0780ee2b1000", "04504b356e23b000"), nil
+			}
+
+			b := &APIBackend{
+				HTTPErrorHandler:    DefaultErrorHandler,
+				Logger:              zaptest.NewLogger(t),
+				OrganizationService: orgs,
+				BucketService:       buckets,
+				PointsWriter:        dropPointsWriter{},
+				WriteEventRecorder:  &metric.NopEventRecorder{},
+			}
+			writeHandler := NewWriteHandler(zaptest.NewLogger(t), NewWriteBackend(zaptest.NewLogger(t), b))
+			handler := httpmock.NewAuthMiddlewareHandler(writeHandler, bucketWritePermission("043e0780ee2b1000", "04504b356e23b000"))
+
+			r := httptest.NewRequest("POST", "http://localhost:9999/api/v2/write", strings.NewReader(tt.body))
+			params := r.URL.Query()
+			params.Set("org", "043e0780ee2b1000")
+			params.Set("bucket", "04504b356e23b000")
+			r.URL.RawQuery = params.Encode()
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if got, want := w.Code, tt.code; got != want {
+				t.Errorf("unexpected status code: got %d want %d", got, want)
+			}
+			if got, want := w.Body.String(), tt.want; got != want {
+				t.Errorf("unexpected body: got %s want %s", got, want)
+			}
+		})
+	}
+}
+
+// dropPointsWriter drops the points with the tag value bad as invalid and
+// those with the tag value conflict as conflicting.
+type dropPointsWriter struct{}
+
+func (dropPointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	var pwe tsdb.PartialWriteError
+	for _, p := range points {
+		switch {
+		case bytes.Contains(p.Key(), []byte("=bad")):
+			pwe.DroppedPoints = append(pwe.DroppedPoints, tsdb.DroppedPoint{Point: p, Reason: "invalid point"})
+		case bytes.Contains(p.Key(), []byte("=conflict")):
+			pwe.DroppedPoints = append(pwe.DroppedPoints, tsdb.DroppedPoint{Point: p, Reason: "conflicting point", Conflict: true})
+		default:
+			continue
+		}
+		pwe.Dropped++
+		pwe.Reason = pwe.DroppedPoints[0].Reason
+	}
+	if pwe.Dropped > 0 {
+		return pwe
+	}
+	return nil
+}
+
 var DefaultErrorHandler = kithttp.ErrorHandler(0)
 
 func bucketWritePermission(org, bucket string) *influxdb.Authorization {
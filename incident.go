@@ -0,0 +1,125 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ops for incident errors.
+var (
+	OpFindIncidentByID      = "FindIncidentByID"
+	OpFindIncidents         = "FindIncidents"
+	OpAcknowledgeIncident   = "AcknowledgeIncident"
+	OpUnacknowledgeIncident = "UnacknowledgeIncident"
+	OpCommentIncident       = "CommentIncident"
+	OpPutIncident           = "PutIncident"
+)
+
+// IncidentStatus is whether an incident is open or closed.
+type IncidentStatus string
+
+// The statuses of the incidents.
+const (
+	IncidentOpen   IncidentStatus = "open"
+	IncidentClosed IncidentStatus = "closed"
+)
+
+// Incident tracks the statuses of a check with the same tags from when the
+// check transitions into a level other than ok until it returns to ok, and
+// whether someone is handling it.
+type Incident struct {
+	ID      ID `json:"id,omitempty"`
+	OrgID   ID `json:"orgID"`
+	CheckID ID `json:"checkID"`
+	// Key identifies the check and the tags of the statuses of the incident.
+	Key       string            `json:"key"`
+	CheckName string            `json:"checkName,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	// Level is the level of the latest status of the incident.
+	Level  string         `json:"level"`
+	Status IncidentStatus `json:"status"`
+	// OpenedAt is the time of the first status of the incident.
+	OpenedAt time.Time `json:"openedAt"`
+	// ClosedAt is the time of the ok status that closed the incident.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+	// LastStatusAt is the time of the latest status of the incident.
+	LastStatusAt   time.Time         `json:"lastStatusAt"`
+	AcknowledgedAt *time.Time        `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy ID                `json:"acknowledgedBy,omitempty"`
+	Comments       []IncidentComment `json:"comments,omitempty"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// IncidentComment is a comment left on an incident.
+type IncidentComment struct {
+	UserID    ID        `json:"userID,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Acknowledged reports whether someone is handling the incident.
+func (i *Incident) Acknowledged() bool {
+	return i.AcknowledgedAt != nil
+}
+
+// Open reports whether the incident is open.
+func (i *Incident) Open() bool {
+	return i.Status == IncidentOpen
+}
+
+// IncidentService represents a service for tracking the handling of the
+// incidents. The incidents are opened and closed by the statuses of the
+// checks, not through the service.
+type IncidentService interface {
+	// FindIncidentByID returns a single incident by ID.
+	FindIncidentByID(ctx context.Context, id ID) (*Incident, error)
+	// FindIncidents returns a list of incidents that match filter and the
+	// total count of matching incidents, the most recently opened first.
+	FindIncidents(ctx context.Context, filter IncidentFilter) ([]*Incident, int, error)
+	// AcknowledgeIncident records that the user is handling the open
+	// incident.
+	AcknowledgeIncident(ctx context.Context, id ID, userID ID) (*Incident, error)
+	// UnacknowledgeIncident removes the acknowledgement of the incident.
+	UnacknowledgeIncident(ctx context.Context, id ID) (*Incident, error)
+	// CommentIncident adds a comment of the user to the incident.
+	CommentIncident(ctx context.Context, id ID, userID ID, text string) (*Incident, error)
+}
+
+// IncidentStore is the storage of the incidents, updated by the tracking of
+// the statuses of the checks.
+type IncidentStore interface {
+	IncidentService
+	// PutIncident creates an incident and sets i.ID when its ID is not set,
+	// or replaces it.
+	PutIncident(ctx context.Context, i *Incident) error
+}
+
+// IncidentFilter represents a set of filters that restrict the returned
+// incidents.
+type IncidentFilter struct {
+	OrgID        *ID
+	CheckID      *ID
+	Key          *string
+	Status       *IncidentStatus
+	Acknowledged *bool
+}
+
+// Matches reports whether the incident matches the filter.
+func (f IncidentFilter) Matches(i *Incident) bool {
+	if f.OrgID != nil && i.OrgID != *f.OrgID {
+		return false
+	}
+	if f.CheckID != nil && i.CheckID != *f.CheckID {
+		return false
+	}
+	if f.Key != nil && i.Key != *f.Key {
+		return false
+	}
+	if f.Status != nil && i.Status != *f.Status {
+		return false
+	}
+	if f.Acknowledged != nil && i.Acknowledged() != *f.Acknowledged {
+		return false
+	}
+	return true
+}
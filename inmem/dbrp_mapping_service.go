@@ -15,12 +15,12 @@ var (
 	}
 )
 
-func encodeDBRPMappingKey(cluster, db, rp string) string {
-	return path.Join(cluster, db, rp)
+func encodeDBRPMappingKey(orgID influxdb.ID, cluster, db, rp string) string {
+	return path.Join(orgID.String(), cluster, db, rp)
 }
 
-func (s *Service) loadDBRPMapping(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
-	i, ok := s.dbrpMappingKV.Load(encodeDBRPMappingKey(cluster, db, rp))
+func (s *Service) loadDBRPMapping(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	i, ok := s.dbrpMappingKV.Load(encodeDBRPMappingKey(orgID, cluster, db, rp))
 	if !ok {
 		return nil, errDBRPMappingNotFound
 	}
@@ -33,9 +33,9 @@ func (s *Service) loadDBRPMapping(ctx context.Context, cluster, db, rp string) (
 	return &m, nil
 }
 
-// FindBy returns a single dbrp mapping by cluster, db and rp.
-func (s *Service) FindBy(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
-	return s.loadDBRPMapping(ctx, cluster, db, rp)
+// FindBy returns a single dbrp mapping by organization, cluster, db and rp.
+func (s *Service) FindBy(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	return s.loadDBRPMapping(ctx, orgID, cluster, db, rp)
 }
 
 func (s *Service) forEachDBRPMapping(ctx context.Context, fn func(m *influxdb.DBRPMapping) bool) error {
@@ -77,11 +77,6 @@ func (s *Service) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (
 		}
 	}
 
-	// filter by dbrpMapping id
-	if filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
-		return s.FindBy(ctx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
-	}
-
 	mappings, n, err := s.FindMany(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -98,16 +93,20 @@ func (s *Service) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (
 // Additional options provide pagination & sorting.
 func (s *Service) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
 	// filter by dbrpMapping id
-	if filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
-		m, err := s.FindBy(ctx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		m, err := s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
 		if err != nil {
 			return nil, 0, err
 		}
+		if filter.Default != nil && *filter.Default != m.Default {
+			return []*influxdb.DBRPMapping{}, 0, nil
+		}
 		return []*influxdb.DBRPMapping{m}, 1, nil
 	}
 
 	filterFunc := func(mapping *influxdb.DBRPMapping) bool {
-		return (filter.Cluster == nil || (*filter.Cluster) == mapping.Cluster) &&
+		return (filter.OrganizationID == nil || (*filter.OrganizationID) == mapping.OrganizationID) &&
+			(filter.Cluster == nil || (*filter.Cluster) == mapping.Cluster) &&
 			(filter.Database == nil || (*filter.Database) == mapping.Database) &&
 			(filter.RetentionPolicy == nil || (*filter.RetentionPolicy) == mapping.RetentionPolicy) &&
 			(filter.Default == nil || (*filter.Default) == mapping.Default)
@@ -126,7 +125,7 @@ func (s *Service) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
 	if err := m.Validate(); err != nil {
 		return nil
 	}
-	existing, err := s.loadDBRPMapping(ctx, m.Cluster, m.Database, m.RetentionPolicy)
+	existing, err := s.loadDBRPMapping(ctx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
 	if err != nil {
 		if err == errDBRPMappingNotFound {
 			return s.PutDBRPMapping(ctx, m)
@@ -146,13 +145,13 @@ func (s *Service) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
 
 // PutDBRPMapping sets dbrpMapping with the current ID.
 func (s *Service) PutDBRPMapping(ctx context.Context, m *influxdb.DBRPMapping) error {
-	k := encodeDBRPMappingKey(m.Cluster, m.Database, m.RetentionPolicy)
+	k := encodeDBRPMappingKey(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
 	s.dbrpMappingKV.Store(k, *m)
 	return nil
 }
 
-// Delete removes a dbrp mapping
-func (s *Service) Delete(ctx context.Context, cluster, db, rp string) error {
-	s.dbrpMappingKV.Delete(encodeDBRPMappingKey(cluster, db, rp))
+// Delete removes a dbrp mapping of the organization.
+func (s *Service) Delete(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) error {
+	s.dbrpMappingKV.Delete(encodeDBRPMappingKey(orgID, cluster, db, rp))
 	return nil
 }
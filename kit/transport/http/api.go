@@ -242,4 +242,5 @@ var statusCodePlatformError = map[string]int{
 	influxdb.EUnauthorized:        http.StatusUnauthorized,
 	influxdb.EMethodNotAllowed:    http.StatusMethodNotAllowed,
 	influxdb.ETooLarge:            http.StatusRequestEntityTooLarge,
+	influxdb.ESeriesLimitExceeded: http.StatusUnprocessableEntity,
 }
@@ -21,13 +21,9 @@ func (h ErrorHandler) HandleHTTPError(ctx context.Context, err error, w http.Res
 	}
 
 	code := influxdb.ErrorCode(err)
-	httpCode, ok := statusCodePlatformError[code]
-	if !ok {
-		httpCode = http.StatusBadRequest
-	}
 	w.Header().Set(PlatformErrorCodeHeader, code)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(httpCode)
+	w.WriteHeader(StatusCode(err))
 	var e struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
@@ -41,3 +37,12 @@ func (h ErrorHandler) HandleHTTPError(ctx context.Context, err error, w http.Res
 	b, _ := json.Marshal(e)
 	_, _ = w.Write(b)
 }
+
+// StatusCode returns the http status code matching the influxdb error code of err.
+func StatusCode(err error) int {
+	httpCode, ok := statusCodePlatformError[influxdb.ErrorCode(err)]
+	if !ok {
+		httpCode = http.StatusBadRequest
+	}
+	return httpCode
+}
@@ -0,0 +1,333 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/task/backend/scheduler"
+)
+
+func newBackfillStore() *StoreBase {
+	const resource = "backfill"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var b influxdb.Backfill
+		return key, &b, json.Unmarshal(val, &b)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		b, ok := v.(*influxdb.Backfill)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   backfillPK(b.TaskID, b.ID),
+			Body: b,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("backfillsv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// backfillPK keys the backfills by their task first, so that the backfills of
+// a task can be found by prefix.
+func backfillPK(taskID, id influxdb.ID) EncodeFn {
+	return Encode(EncID(taskID), EncID(id))
+}
+
+// BackfillTask creates a backfill running the task for every point of its
+// schedule from bc.Start to bc.Stop. The backfill is run by the coordinator
+// of the tasks, not by the service.
+func (s *Service) BackfillTask(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var b *influxdb.Backfill
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.backfillTask(ctx, tx, taskID, bc)
+		if err != nil {
+			return err
+		}
+		b = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpBackfillTask,
+			Err: err,
+		}
+	}
+	return b, nil
+}
+
+func (s *Service) backfillTask(ctx context.Context, tx Tx, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	if err := bc.Validate(); err != nil {
+		return nil, err
+	}
+
+	t, err := s.findTaskByID(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := backfillPoints(t, bc.Start, bc.Stop)
+	if err != nil {
+		return nil, err
+	}
+
+	if bc.MaxConcurrency == 0 {
+		bc.MaxConcurrency = influxdb.DefaultBackfillConcurrency
+	}
+	now := s.Now()
+	b := &influxdb.Backfill{
+		ID:             s.IDGenerator.ID(),
+		TaskID:         taskID,
+		Start:          bc.Start.UTC(),
+		Stop:           bc.Stop.UTC(),
+		MaxConcurrency: bc.MaxConcurrency,
+		Status:         influxdb.BackfillRunning,
+		Total:          len(points),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.putBackfill(ctx, tx, b, PutNew()); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// backfillPoints returns the points of the schedule of the task from start,
+// inclusive, to stop, exclusive. It fails when there are none or more than
+// influxdb.MaxBackfillRuns of them.
+func backfillPoints(t *influxdb.Task, start, stop time.Time) ([]time.Time, error) {
+	cron := t.EffectiveCron()
+	if cron == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "task has no schedule to backfill",
+		}
+	}
+
+	loc, err := t.ScheduleLocation()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task location",
+			Err:  err,
+		}
+	}
+
+	points, err := scheduler.BetweenIn(cron, loc, start, stop, influxdb.MaxBackfillRuns+1)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task schedule",
+			Err:  err,
+		}
+	}
+	switch {
+	case len(points) == 0:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "backfill range has no schedule points of the task",
+		}
+	case len(points) > influxdb.MaxBackfillRuns:
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("backfill range has more than %d schedule points of the task", influxdb.MaxBackfillRuns),
+		}
+	}
+	return points, nil
+}
+
+// FindBackfills returns the backfills of the task, the most recently created
+// first.
+func (s *Service) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var bs []*influxdb.Backfill
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+			return err
+		}
+		v, err := s.findBackfills(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		bs = v
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindBackfills,
+			Err: err,
+		}
+	}
+	return bs, len(bs), nil
+}
+
+func (s *Service) findBackfills(ctx context.Context, tx Tx, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	prefix, err := EncID(taskID)()
+	if err != nil {
+		return nil, err
+	}
+
+	bs := []*influxdb.Backfill{}
+	err = s.backfillStore.Find(ctx, tx, FindOpts{
+		Prefix: prefix,
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			b, ok := decodedVal.(*influxdb.Backfill)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			bs = append(bs, b)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(bs, func(i, j int) bool {
+		return bs[i].CreatedAt.After(bs[j].CreatedAt)
+	})
+	return bs, nil
+}
+
+// FindBackfillByID returns a single backfill of the task.
+func (s *Service) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var b *influxdb.Backfill
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findBackfillByID(ctx, tx, taskID, id)
+		if err != nil {
+			return err
+		}
+		b = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindBackfillByID,
+			Err: err,
+		}
+	}
+	return b, nil
+}
+
+func (s *Service) findBackfillByID(ctx context.Context, tx Tx, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	v, err := s.backfillStore.FindEnt(ctx, tx, Entity{PK: backfillPK(taskID, id)})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*influxdb.Backfill), nil
+}
+
+// CancelBackfill cancels the running backfill. The runs of the backfill are
+// stopped by the coordinator of the tasks, not by the service.
+func (s *Service) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	b, err := s.updateBackfill(ctx, taskID, id, func(b *influxdb.Backfill) error {
+		if !b.Running() {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("backfill is %s", b.Status),
+			}
+		}
+		s.finishBackfill(b, influxdb.BackfillCanceled)
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpCancelBackfill,
+			Err: err,
+		}
+	}
+	return b, nil
+}
+
+// UpdateBackfill records the progress of the backfill. The status of a
+// backfill that is finished is left unchanged.
+func (s *Service) UpdateBackfill(ctx context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	b, err := s.updateBackfill(ctx, taskID, id, func(b *influxdb.Backfill) error {
+		if upd.Succeeded != nil {
+			b.Succeeded = *upd.Succeeded
+		}
+		if upd.Failed != nil {
+			b.Failed = *upd.Failed
+		}
+		if upd.Status != nil && *upd.Status != influxdb.BackfillRunning && b.Running() {
+			s.finishBackfill(b, *upd.Status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateBackfill,
+			Err: err,
+		}
+	}
+	return b, nil
+}
+
+func (s *Service) finishBackfill(b *influxdb.Backfill, status influxdb.BackfillStatus) {
+	now := s.Now()
+	b.Status = status
+	b.FinishedAt = &now
+}
+
+// updateBackfill applies fn to the backfill and stores it.
+func (s *Service) updateBackfill(ctx context.Context, taskID, id influxdb.ID, fn func(*influxdb.Backfill) error) (*influxdb.Backfill, error) {
+	var b *influxdb.Backfill
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.findBackfillByID(ctx, tx, taskID, id)
+		if err != nil {
+			return err
+		}
+		b = v
+
+		if err := fn(b); err != nil {
+			return err
+		}
+		b.UpdatedAt = s.Now()
+		return s.putBackfill(ctx, tx, b, PutUpdate())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) putBackfill(ctx context.Context, tx Tx, b *influxdb.Backfill, opts ...PutOptionFn) error {
+	return s.backfillStore.Put(ctx, tx, Entity{
+		PK:   backfillPK(b.TaskID, b.ID),
+		Body: b,
+	}, opts...)
+}
+
+// deleteBackfills deletes the backfills of the task.
+func (s *Service) deleteBackfills(ctx context.Context, tx Tx, taskID influxdb.ID) error {
+	bs, err := s.findBackfills(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bs {
+		if err := s.backfillStore.DeleteEnt(ctx, tx, Entity{PK: backfillPK(b.TaskID, b.ID)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
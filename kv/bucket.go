@@ -472,6 +472,15 @@ func (s *Service) createBucket(ctx context.Context, tx Tx, b *influxdb.Bucket) (
 		return err
 	}
 
+	if b.Schema != nil {
+		if err := b.Schema.Valid(); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpCreateBucket,
+				Err: err,
+			}
+		}
+	}
+
 	if b.ID, err = s.generateBucketID(ctx, tx); err != nil {
 		return err
 	}
@@ -686,6 +695,19 @@ func (s *Service) updateBucket(ctx context.Context, tx Tx, id influxdb.ID, upd i
 		b.Description = *upd.Description
 	}
 
+	if upd.Schema != nil {
+		if len(upd.Schema.Measurements) == 0 {
+			b.Schema = nil
+		} else if err := upd.Schema.Valid(); err != nil {
+			return nil, &influxdb.Error{
+				Op:  influxdb.OpUpdateBucket,
+				Err: err,
+			}
+		} else {
+			b.Schema = upd.Schema
+		}
+	}
+
 	if upd.Name != nil {
 		b0, err := s.findBucketByName(ctx, tx, b.OrgID, *upd.Name)
 		if err == nil && b0.ID != id {
@@ -821,6 +843,10 @@ func (s *Service) deleteBucket(ctx context.Context, tx Tx, id influxdb.ID) error
 		return err
 	}
 
+	if err := s.deleteBucketDownsamplePolicies(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return nil
 }
 
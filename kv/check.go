@@ -493,6 +493,10 @@ func (s *Service) DeleteCheck(ctx context.Context, id influxdb.ID) error {
 			return err
 		}
 
+		if err := s.deleteIncidents(ctx, tx, influxdb.IncidentFilter{CheckID: &id}); err != nil {
+			return err
+		}
+
 		return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
 			ResourceID:   id,
 			ResourceType: influxdb.ChecksResourceType,
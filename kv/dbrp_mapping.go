@@ -0,0 +1,237 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	dbrpMappingBucket = []byte("dbrpmappingsv1")
+
+	errDBRPMappingNotFound = &influxdb.Error{
+		Code: influxdb.ENotFound,
+		Msg:  "dbrp mapping not found",
+	}
+)
+
+var _ influxdb.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// DBRPMappingService is a kv backed implementation of the influxdb.DBRPMappingService.
+// It lives alongside Service rather than on it, as the generic Find/Create/Delete
+// method names of the DBRPMappingService interface would collide with other services.
+type DBRPMappingService struct {
+	kv Store
+}
+
+// NewDBRPMappingService returns a DBRPMappingService backed by the provided store.
+func NewDBRPMappingService(st Store) *DBRPMappingService {
+	return &DBRPMappingService{kv: st}
+}
+
+// Initialize creates the buckets needed by the dbrp mapping service.
+func (s *DBRPMappingService) Initialize(ctx context.Context) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		_, err := tx.Bucket(dbrpMappingBucket)
+		return err
+	})
+}
+
+// encodeDBRPMappingKey keys the mappings by organization first, the names of
+// the mappings of one organization never collide with those of another.
+func encodeDBRPMappingKey(orgID influxdb.ID, cluster, db, rp string) []byte {
+	// dbrp names are validated to never contain a '/', which makes it a safe delimiter.
+	return append(encodeDBRPMappingPrefix(orgID), cluster+"/"+db+"/"+rp...)
+}
+
+func encodeDBRPMappingPrefix(orgID influxdb.ID) []byte {
+	return []byte(orgID.String() + "/")
+}
+
+// FindBy returns a single dbrp mapping by organization, cluster, db and rp.
+func (s *DBRPMappingService) FindBy(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	var m *influxdb.DBRPMapping
+	err := s.kv.View(ctx, func(tx Tx) error {
+		var err error
+		m, err = s.findBy(ctx, tx, orgID, cluster, db, rp)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *DBRPMappingService) findBy(ctx context.Context, tx Tx, orgID influxdb.ID, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(encodeDBRPMappingKey(orgID, cluster, db, rp))
+	if IsNotFound(err) {
+		return nil, errDBRPMappingNotFound
+	}
+	if err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	var m influxdb.DBRPMapping
+	if err := json.Unmarshal(v, &m); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Err:  err,
+		}
+	}
+	return &m, nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	if filter.Cluster == nil && filter.Database == nil && filter.RetentionPolicy == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "no filter parameters provided",
+		}
+	}
+
+	mappings, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 1 {
+		return nil, errDBRPMappingNotFound
+	}
+
+	return mappings[0], nil
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	if filter.OrganizationID != nil && filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		m, err := s.FindBy(ctx, *filter.OrganizationID, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.Default != nil && *filter.Default != m.Default {
+			return []*influxdb.DBRPMapping{}, 0, nil
+		}
+		return []*influxdb.DBRPMapping{m}, 1, nil
+	}
+
+	matches := func(m *influxdb.DBRPMapping) bool {
+		return (filter.OrganizationID == nil || *filter.OrganizationID == m.OrganizationID) &&
+			(filter.Cluster == nil || *filter.Cluster == m.Cluster) &&
+			(filter.Database == nil || *filter.Database == m.Database) &&
+			(filter.RetentionPolicy == nil || *filter.RetentionPolicy == m.RetentionPolicy) &&
+			(filter.Default == nil || *filter.Default == m.Default)
+	}
+
+	// when the organization is known the scan can be narrowed down to its key
+	// prefix, and further down to the one of the cluster and database.
+	var prefix []byte
+	if filter.OrganizationID != nil {
+		prefix = encodeDBRPMappingPrefix(*filter.OrganizationID)
+		if filter.Cluster != nil {
+			prefix = append(prefix, *filter.Cluster+"/"...)
+			if filter.Database != nil {
+				prefix = append(prefix, *filter.Database+"/"...)
+			}
+		}
+	}
+
+	mappings := []*influxdb.DBRPMapping{}
+	err := s.kv.View(ctx, func(tx Tx) error {
+		b, err := tx.Bucket(dbrpMappingBucket)
+		if err != nil {
+			return err
+		}
+
+		cur, err := b.Cursor()
+		if err != nil {
+			return err
+		}
+
+		for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+			m := &influxdb.DBRPMapping{}
+			if err := json.Unmarshal(v, m); err != nil {
+				return &influxdb.Error{
+					Code: influxdb.EInternal,
+					Err:  err,
+				}
+			}
+			if matches(m) {
+				mappings = append(mappings, m)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return mappings, len(mappings), nil
+}
+
+// Create creates a new dbrp mapping. Creating a mapping identical to an existing
+// one is a no-op, while creating a different mapping for the same names in the
+// same organization is a conflict.
+func (s *DBRPMappingService) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		existing, err := s.findBy(ctx, tx, m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return err
+		}
+
+		if existing != nil && !existing.Equal(m) {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  "dbrp mapping already exists",
+			}
+		}
+
+		return s.put(ctx, tx, m)
+	})
+}
+
+func (s *DBRPMappingService) put(ctx context.Context, tx Tx, m *influxdb.DBRPMapping) error {
+	v, err := json.Marshal(m)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInternal,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	return b.Put(encodeDBRPMappingKey(m.OrganizationID, m.Cluster, m.Database, m.RetentionPolicy), v)
+}
+
+// Delete removes a dbrp mapping of the organization. Deleting a mapping that does not exist is not an error.
+func (s *DBRPMappingService) Delete(ctx context.Context, orgID influxdb.ID, cluster, db, rp string) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		b, err := tx.Bucket(dbrpMappingBucket)
+		if err != nil {
+			return err
+		}
+
+		err = b.Delete(encodeDBRPMappingKey(orgID, cluster, db, rp))
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+		return nil
+	})
+}
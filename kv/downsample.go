@@ -0,0 +1,325 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/task/downsample"
+)
+
+var _ influxdb.DownsamplePolicyService = (*Service)(nil)
+
+func newDownsamplePolicyStore() *StoreBase {
+	const resource = "downsample policy"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var p influxdb.DownsamplePolicy
+		return key, &p, json.Unmarshal(val, &p)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		p, ok := v.(*influxdb.DownsamplePolicy)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(p.ID),
+			Body: p,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("downsamplepoliciesv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// FindDownsamplePolicyByID retrieves a downsample policy by id.
+func (s *Service) FindDownsamplePolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var p *influxdb.DownsamplePolicy
+	err := s.kv.View(ctx, func(tx Tx) error {
+		pol, err := s.findDownsamplePolicyByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		p = pol
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) findDownsamplePolicyByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+	v, err := s.downsamplePolicyStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindDownsamplePolicyByID,
+			Err: err,
+		}
+	}
+	return v.(*influxdb.DownsamplePolicy), nil
+}
+
+// FindDownsamplePolicies returns the downsample policies that match the filter.
+func (s *Service) FindDownsamplePolicies(ctx context.Context, filter influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var ps []*influxdb.DownsamplePolicy
+	err := s.kv.View(ctx, func(tx Tx) error {
+		pols, err := s.findDownsamplePolicies(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ps = pols
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindDownsamplePolicies,
+			Err: err,
+		}
+	}
+	return ps, len(ps), nil
+}
+
+func (s *Service) findDownsamplePolicies(ctx context.Context, tx Tx, filter influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, error) {
+	ps := []*influxdb.DownsamplePolicy{}
+	err := s.downsamplePolicyStore.Find(ctx, tx, FindOpts{
+		FilterEntFn: func(k []byte, v interface{}) bool {
+			p, ok := v.(*influxdb.DownsamplePolicy)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return false
+			}
+			return filterDownsamplePolicy(p, filter)
+		},
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			p, ok := decodedVal.(*influxdb.DownsamplePolicy)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			ps = append(ps, p)
+			return nil
+		},
+	})
+	return ps, err
+}
+
+func filterDownsamplePolicy(p *influxdb.DownsamplePolicy, filter influxdb.DownsamplePolicyFilter) bool {
+	if filter.OrgID != nil && p.OrgID != *filter.OrgID {
+		return false
+	}
+	if filter.BucketID != nil && p.BucketID != *filter.BucketID && p.DestinationBucketID != *filter.BucketID {
+		return false
+	}
+	return true
+}
+
+// CreateDownsamplePolicy creates a downsample policy and the task that
+// carries it out, and sets p.ID.
+func (s *Service) CreateDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createDownsamplePolicy(ctx, tx, p, userID)
+	})
+}
+
+func (s *Service) createDownsamplePolicy(ctx context.Context, tx Tx, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+	if err := p.Valid(); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateDownsamplePolicy,
+			Err: err,
+		}
+	}
+
+	orgID, err := s.findDownsamplePolicyBuckets(ctx, tx, p)
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateDownsamplePolicy,
+			Err: err,
+		}
+	}
+
+	if p.OrgID.Valid() && p.OrgID != orgID {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "downsample policy buckets do not belong to the organization",
+			Op:   influxdb.OpCreateDownsamplePolicy,
+		}
+	}
+
+	p.ID = s.IDGenerator.ID()
+	p.OrgID = orgID
+	p.OwnerID = userID
+	now := s.Now()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	script, err := downsample.GenerateFlux(p)
+	if err != nil {
+		return err
+	}
+
+	t, err := s.createTask(ctx, tx, influxdb.TaskCreate{
+		Type:           influxdb.DownsampleTaskType,
+		Flux:           script,
+		Description:    p.Description,
+		OwnerID:        p.OwnerID,
+		OrganizationID: p.OrgID,
+		Metadata:       downsampleTaskMetadata(p),
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "could not create task from downsample policy",
+			Op:   influxdb.OpCreateDownsamplePolicy,
+			Err:  err,
+		}
+	}
+	p.TaskID = t.ID
+
+	return s.putDownsamplePolicy(ctx, tx, p, PutNew())
+}
+
+// findDownsamplePolicyBuckets checks that the source and destination buckets
+// of the policy exist and belong to the same organization, whose ID is returned.
+func (s *Service) findDownsamplePolicyBuckets(ctx context.Context, tx Tx, p *influxdb.DownsamplePolicy) (influxdb.ID, error) {
+	src, err := s.findBucketByID(ctx, tx, p.BucketID)
+	if err != nil {
+		return 0, err
+	}
+	dst, err := s.findBucketByID(ctx, tx, p.DestinationBucketID)
+	if err != nil {
+		return 0, err
+	}
+	if src.OrgID != dst.OrgID {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "downsample policy source and destination buckets must belong to the same organization",
+		}
+	}
+	return src.OrgID, nil
+}
+
+func downsampleTaskMetadata(p *influxdb.DownsamplePolicy) map[string]interface{} {
+	return map[string]interface{}{
+		influxdb.DownsampleTaskBucketIDKey: p.BucketID.String(),
+		influxdb.DownsampleTaskPolicyIDKey: p.ID.String(),
+	}
+}
+
+func (s *Service) putDownsamplePolicy(ctx context.Context, tx Tx, p *influxdb.DownsamplePolicy, opts ...PutOptionFn) error {
+	return s.downsamplePolicyStore.Put(ctx, tx, Entity{
+		PK:   EncID(p.ID),
+		Body: p,
+	}, opts...)
+}
+
+// UpdateDownsamplePolicy updates a downsample policy and regenerates its task.
+func (s *Service) UpdateDownsamplePolicy(ctx context.Context, id influxdb.ID, upd influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var p *influxdb.DownsamplePolicy
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		pol, err := s.updateDownsamplePolicy(ctx, tx, id, upd)
+		if err != nil {
+			return err
+		}
+		p = pol
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateDownsamplePolicy,
+			Err: err,
+		}
+	}
+	return p, nil
+}
+
+func (s *Service) updateDownsamplePolicy(ctx context.Context, tx Tx, id influxdb.ID, upd influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+	p, err := s.findDownsamplePolicyByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	upd.Apply(p)
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	if _, err := s.findDownsamplePolicyBuckets(ctx, tx, p); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = s.Now()
+
+	script, err := downsample.GenerateFlux(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.updateTask(ctx, tx, p.TaskID, influxdb.TaskUpdate{
+		Flux:        &script,
+		Description: &p.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.putDownsamplePolicy(ctx, tx, p, PutUpdate()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DeleteDownsamplePolicy deletes a downsample policy and its task.
+func (s *Service) DeleteDownsamplePolicy(ctx context.Context, id influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		p, err := s.findDownsamplePolicyByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return s.deleteDownsamplePolicy(ctx, tx, p)
+	})
+}
+
+func (s *Service) deleteDownsamplePolicy(ctx context.Context, tx Tx, p *influxdb.DownsamplePolicy) error {
+	if err := s.deleteTask(ctx, tx, p.TaskID); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return &influxdb.Error{
+			Op:  influxdb.OpDeleteDownsamplePolicy,
+			Err: err,
+		}
+	}
+
+	if err := s.downsamplePolicyStore.DeleteEnt(ctx, tx, Entity{PK: EncID(p.ID)}); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpDeleteDownsamplePolicy,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+// deleteBucketDownsamplePolicies deletes the downsample policies that read
+// from or write to the bucket, along with their tasks.
+func (s *Service) deleteBucketDownsamplePolicies(ctx context.Context, tx Tx, bucketID influxdb.ID) error {
+	ps, err := s.findDownsamplePolicies(ctx, tx, influxdb.DownsamplePolicyFilter{BucketID: &bucketID})
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		if err := s.deleteDownsamplePolicy(ctx, tx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
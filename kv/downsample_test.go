@@ -0,0 +1,112 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestService_DownsamplePolicy(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	src := &influxdb.Bucket{OrgID: ts.Org.ID, Name: "raw"}
+	dst := &influxdb.Bucket{OrgID: ts.Org.ID, Name: "downsampled"}
+	for _, b := range []*influxdb.Bucket{src, dst} {
+		if err := ts.Service.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := &influxdb.DownsamplePolicy{
+		BucketID:            src.ID,
+		DestinationBucketID: dst.ID,
+		Every:               *options.MustParseDuration("1h"),
+		Aggregates:          influxdb.DownsampleAggregates{Float: "mean"},
+	}
+	if err := ts.Service.CreateDownsamplePolicy(ctx, p, ts.User.ID); err != nil {
+		t.Fatal(err)
+	}
+	if p.OrgID != ts.Org.ID {
+		t.Fatalf("expected policy in org %s, got %s", ts.Org.ID, p.OrgID)
+	}
+
+	task, err := ts.Service.FindTaskByID(ctx, p.TaskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Type != influxdb.DownsampleTaskType {
+		t.Fatalf("expected task type %q, got %q", influxdb.DownsampleTaskType, task.Type)
+	}
+	if task.OwnerID != ts.User.ID {
+		t.Fatalf("expected task owned by %s, got %s", ts.User.ID, task.OwnerID)
+	}
+	if got := task.Metadata[influxdb.DownsampleTaskBucketIDKey]; got != src.ID.String() {
+		t.Fatalf("expected task bucket metadata %s, got %v", src.ID, got)
+	}
+	if task.Every != "1h" {
+		t.Fatalf("expected task every 1h, got %s", task.Every)
+	}
+
+	upd := influxdb.DownsamplePolicyUpdate{Every: options.MustParseDuration("1d")}
+	if _, err := ts.Service.UpdateDownsamplePolicy(ctx, p.ID, upd); err != nil {
+		t.Fatal(err)
+	}
+	task, err = ts.Service.FindTaskByID(ctx, p.TaskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Every != "1d" {
+		t.Fatalf("expected updated task every 1d, got %s", task.Every)
+	}
+
+	if err := ts.Service.DeleteBucket(ctx, dst.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Service.FindDownsamplePolicyByID(ctx, p.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected policy to be deleted with its bucket, got %v", err)
+	}
+	if _, err := ts.Service.FindTaskByID(ctx, p.TaskID); err != influxdb.ErrTaskNotFound {
+		t.Fatalf("expected task to be deleted with its bucket, got %v", err)
+	}
+}
+
+func TestService_CreateDownsamplePolicy_OtherOrg(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	other := &influxdb.Organization{Name: "other"}
+	if err := ts.Service.CreateOrganization(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	src := &influxdb.Bucket{OrgID: ts.Org.ID, Name: "raw"}
+	dst := &influxdb.Bucket{OrgID: other.ID, Name: "downsampled"}
+	for _, b := range []*influxdb.Bucket{src, dst} {
+		if err := ts.Service.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := ts.Service.CreateDownsamplePolicy(ctx, &influxdb.DownsamplePolicy{
+		BucketID:            src.ID,
+		DestinationBucketID: dst.ID,
+		Every:               *options.MustParseDuration("1h"),
+		Aggregates:          influxdb.DownsampleAggregates{Float: "mean"},
+	}, ts.User.ID)
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+}
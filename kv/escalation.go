@@ -0,0 +1,416 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+var _ influxdb.EscalationStore = (*Service)(nil)
+
+func newEscalationPolicyStore() *StoreBase {
+	const resource = "escalation policy"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var p influxdb.EscalationPolicy
+		return key, &p, json.Unmarshal(val, &p)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		p, ok := v.(*influxdb.EscalationPolicy)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(p.ID),
+			Body: p,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("escalationpoliciesv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// newEscalationStore stores the escalations under the ID of their policy
+// followed by their key, so that the escalations of a policy share a prefix.
+func newEscalationStore() *StoreBase {
+	const resource = "escalation"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var e influxdb.Escalation
+		return key, &e, json.Unmarshal(val, &e)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		e, ok := v.(*influxdb.Escalation)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   escalationPK(e.PolicyID, e.Key),
+			Body: e,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("escalationsv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+func escalationPK(policyID influxdb.ID, key string) EncodeFn {
+	return Encode(EncID(policyID), EncString(key))
+}
+
+// FindEscalationPolicyByID retrieves an escalation policy by id.
+func (s *Service) FindEscalationPolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.EscalationPolicy, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var p *influxdb.EscalationPolicy
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findEscalationPolicyByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		p = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) findEscalationPolicyByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.EscalationPolicy, error) {
+	v, err := s.escalationPolicyStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindEscalationPolicyByID,
+			Err: err,
+		}
+	}
+	return v.(*influxdb.EscalationPolicy), nil
+}
+
+// FindEscalationPolicies returns the escalation policies that match the filter.
+func (s *Service) FindEscalationPolicies(ctx context.Context, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var ps []*influxdb.EscalationPolicy
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findEscalationPolicies(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ps = v
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindEscalationPolicies,
+			Err: err,
+		}
+	}
+	return ps, len(ps), nil
+}
+
+func (s *Service) findEscalationPolicies(ctx context.Context, tx Tx, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, error) {
+	ps := []*influxdb.EscalationPolicy{}
+	err := s.escalationPolicyStore.Find(ctx, tx, FindOpts{
+		FilterEntFn: func(k []byte, v interface{}) bool {
+			p, ok := v.(*influxdb.EscalationPolicy)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return false
+			}
+			return filterEscalationPolicy(p, filter)
+		},
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			p, ok := decodedVal.(*influxdb.EscalationPolicy)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			ps = append(ps, p)
+			return nil
+		},
+	})
+	return ps, err
+}
+
+func filterEscalationPolicy(p *influxdb.EscalationPolicy, filter influxdb.EscalationPolicyFilter) bool {
+	if filter.OrgID != nil && p.OrgID != *filter.OrgID {
+		return false
+	}
+	if filter.RuleID != nil && p.RuleID != *filter.RuleID {
+		return false
+	}
+	return true
+}
+
+// CreateEscalationPolicy creates an escalation policy and sets p.ID.
+func (s *Service) CreateEscalationPolicy(ctx context.Context, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createEscalationPolicy(ctx, tx, p, userID)
+	})
+}
+
+func (s *Service) createEscalationPolicy(ctx context.Context, tx Tx, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+	if err := p.Valid(); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateEscalationPolicy,
+			Err: err,
+		}
+	}
+
+	if err := s.validEscalationPolicyResources(ctx, tx, p); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateEscalationPolicy,
+			Err: err,
+		}
+	}
+
+	p.ID = s.IDGenerator.ID()
+	p.OwnerID = userID
+	now := s.Now()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	return s.putEscalationPolicy(ctx, tx, p, PutNew())
+}
+
+// validEscalationPolicyResources checks that the rule and the endpoints of
+// the policy belong to its organization.
+func (s *Service) validEscalationPolicyResources(ctx context.Context, tx Tx, p *influxdb.EscalationPolicy) error {
+	nr, err := s.findNotificationRuleByID(ctx, tx, p.RuleID)
+	if err != nil {
+		return err
+	}
+	if nr.GetOrgID() != p.OrgID {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "escalation policy notification rule belongs to another organization",
+		}
+	}
+	for _, step := range p.Steps {
+		edp, err := s.findNotificationEndpointByID(ctx, tx, step.EndpointID)
+		if err != nil {
+			return err
+		}
+		if edp.GetOrgID() != p.OrgID {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "escalation step notification endpoint belongs to another organization",
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) putEscalationPolicy(ctx context.Context, tx Tx, p *influxdb.EscalationPolicy, opts ...PutOptionFn) error {
+	return s.escalationPolicyStore.Put(ctx, tx, Entity{
+		PK:   EncID(p.ID),
+		Body: p,
+	}, opts...)
+}
+
+// UpdateEscalationPolicy updates an escalation policy.
+func (s *Service) UpdateEscalationPolicy(ctx context.Context, id influxdb.ID, upd influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var p *influxdb.EscalationPolicy
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.findEscalationPolicyByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		upd.Apply(v)
+		if err := v.Valid(); err != nil {
+			return err
+		}
+		if err := s.validEscalationPolicyResources(ctx, tx, v); err != nil {
+			return err
+		}
+		v.UpdatedAt = s.Now()
+
+		if err := s.putEscalationPolicy(ctx, tx, v, PutUpdate()); err != nil {
+			return err
+		}
+		p = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateEscalationPolicy,
+			Err: err,
+		}
+	}
+	return p, nil
+}
+
+// DeleteEscalationPolicy deletes an escalation policy and its escalations.
+func (s *Service) DeleteEscalationPolicy(ctx context.Context, id influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := s.deleteEscalationPolicy(ctx, tx, id); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpDeleteEscalationPolicy,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Service) deleteEscalationPolicy(ctx context.Context, tx Tx, id influxdb.ID) error {
+	if err := s.escalationPolicyStore.DeleteEnt(ctx, tx, Entity{PK: EncID(id)}); err != nil {
+		return err
+	}
+	es, err := s.findEscalations(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	for _, e := range es {
+		if err := s.escalationStore.DeleteEnt(ctx, tx, Entity{PK: escalationPK(e.PolicyID, e.Key)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOrganizationEscalationPolicies deletes the escalation policies of
+// the organization.
+func (s *Service) deleteOrganizationEscalationPolicies(ctx context.Context, tx Tx, orgID influxdb.ID) error {
+	ps, err := s.findEscalationPolicies(ctx, tx, influxdb.EscalationPolicyFilter{OrgID: &orgID})
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		if err := s.deleteEscalationPolicy(ctx, tx, p.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteNotificationRuleEscalationPolicies deletes the escalation policies
+// of the notification rule.
+func (s *Service) deleteNotificationRuleEscalationPolicies(ctx context.Context, tx Tx, ruleID influxdb.ID) error {
+	ps, err := s.findEscalationPolicies(ctx, tx, influxdb.EscalationPolicyFilter{RuleID: &ruleID})
+	if err != nil {
+		return err
+	}
+	for _, p := range ps {
+		if err := s.deleteEscalationPolicy(ctx, tx, p.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindEscalations returns the ongoing escalations of the escalation policy.
+func (s *Service) FindEscalations(ctx context.Context, policyID influxdb.ID) ([]*influxdb.Escalation, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var es []*influxdb.Escalation
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findEscalations(ctx, tx, policyID)
+		if err != nil {
+			return err
+		}
+		es = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindEscalations,
+			Err: err,
+		}
+	}
+	return es, nil
+}
+
+func (s *Service) findEscalations(ctx context.Context, tx Tx, policyID influxdb.ID) ([]*influxdb.Escalation, error) {
+	prefix, err := policyID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	es := []*influxdb.Escalation{}
+	err = s.escalationStore.Find(ctx, tx, FindOpts{
+		Prefix: prefix,
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			e, ok := decodedVal.(*influxdb.Escalation)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			es = append(es, e)
+			return nil
+		},
+	})
+	return es, err
+}
+
+// AcknowledgeEscalation stops the escalation with key of the escalation
+// policy until its check returns to ok.
+func (s *Service) AcknowledgeEscalation(ctx context.Context, policyID influxdb.ID, key string, userID influxdb.ID) (*influxdb.Escalation, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var e *influxdb.Escalation
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.escalationStore.FindEnt(ctx, tx, Entity{PK: escalationPK(policyID, key)})
+		if err != nil {
+			return err
+		}
+		e = v.(*influxdb.Escalation)
+		if e.AcknowledgedAt != nil {
+			return nil
+		}
+
+		now := s.Now()
+		e.AcknowledgedAt = &now
+		e.AcknowledgedBy = userID
+		e.UpdatedAt = now
+		return s.putEscalation(ctx, tx, e)
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpAcknowledgeEscalation,
+			Err: err,
+		}
+	}
+	return e, nil
+}
+
+// PutEscalation creates or replaces an escalation.
+func (s *Service) PutEscalation(ctx context.Context, e *influxdb.Escalation) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.putEscalation(ctx, tx, e)
+	})
+}
+
+func (s *Service) putEscalation(ctx context.Context, tx Tx, e *influxdb.Escalation) error {
+	return s.escalationStore.Put(ctx, tx, Entity{
+		PK:   escalationPK(e.PolicyID, e.Key),
+		Body: e,
+	})
+}
+
+// DeleteEscalation removes the escalation with key of the escalation policy.
+func (s *Service) DeleteEscalation(ctx context.Context, policyID influxdb.ID, key string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.escalationStore.DeleteEnt(ctx, tx, Entity{PK: escalationPK(policyID, key)})
+	})
+}
@@ -0,0 +1,119 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestService_EscalationPolicy(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	edp := &endpoint.Slack{
+		Base: endpoint.Base{OrgID: &ts.Org.ID, Name: "ops", Status: influxdb.Active},
+		URL:  "http://localhost:8080",
+	}
+	if err := ts.Service.CreateNotificationEndpoint(ctx, edp, ts.User.ID); err != nil {
+		t.Fatal(err)
+	}
+	nr := &rule.Slack{
+		Base: rule.Base{
+			OrgID:       ts.Org.ID,
+			Name:        "cpu",
+			EndpointID:  edp.GetID(),
+			Every:       mustDuration("1m"),
+			StatusRules: []notification.StatusRule{{CurrentLevel: notification.Critical}},
+		},
+		MessageTemplate: "cpu is ${r._level}",
+	}
+	if err := ts.Service.CreateNotificationRule(ctx, influxdb.NotificationRuleCreate{NotificationRule: nr, Status: influxdb.Active}, ts.User.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &influxdb.EscalationPolicy{
+		OrgID:  ts.Org.ID,
+		RuleID: nr.ID,
+		Name:   "cpu escalation",
+		Steps: []influxdb.EscalationStep{
+			{Delay: *options.MustParseDuration("0s"), EndpointID: edp.GetID()},
+			{Delay: *options.MustParseDuration("15m"), Level: "crit", EndpointID: edp.GetID()},
+		},
+	}
+	if err := ts.Service.CreateEscalationPolicy(ctx, p, ts.User.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !p.ID.Valid() || p.OwnerID != ts.User.ID {
+		t.Fatalf("unexpected escalation policy %+v", p)
+	}
+
+	steps := []influxdb.EscalationStep{{Delay: *options.MustParseDuration("15m"), EndpointID: edp.GetID()}, p.Steps[0]}
+	if _, err := ts.Service.UpdateEscalationPolicy(ctx, p.ID, influxdb.EscalationPolicyUpdate{Steps: &steps}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for unordered steps, got %v", err)
+	}
+
+	since := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	esc := &influxdb.Escalation{
+		PolicyID:      p.ID,
+		Key:           influxdb.StatusKey(1, map[string]string{"host": "web-1"}),
+		CheckID:       1,
+		Level:         "crit",
+		Since:         since,
+		NotifiedSteps: []int{0},
+	}
+	if err := ts.Service.PutEscalation(ctx, esc); err != nil {
+		t.Fatal(err)
+	}
+	acked, err := ts.Service.AcknowledgeEscalation(ctx, p.ID, esc.Key, ts.User.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acked.AcknowledgedAt == nil || acked.AcknowledgedBy != ts.User.ID {
+		t.Fatalf("expected the escalation to be acknowledged, got %+v", acked)
+	}
+	if _, err := ts.Service.AcknowledgeEscalation(ctx, p.ID, "unknown", ts.User.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	es, err := ts.Service.FindEscalations(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 || es[0].AcknowledgedAt == nil {
+		t.Fatalf("expected the acknowledged escalation, got %v", es)
+	}
+
+	if err := ts.Service.DeleteNotificationRule(ctx, nr.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Service.FindEscalationPolicyByID(ctx, p.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected escalation policy to be deleted with its rule, got %v", err)
+	}
+	if es, err := ts.Service.FindEscalations(ctx, p.ID); err != nil || len(es) != 0 {
+		t.Fatalf("expected escalations to be deleted with their policy, got %v, error %v", es, err)
+	}
+}
+
+func mustDuration(d string) *notification.Duration {
+	dur, err := time.ParseDuration(d)
+	if err != nil {
+		panic(err)
+	}
+	nd, err := notification.FromTimeDuration(dur)
+	if err != nil {
+		panic(err)
+	}
+	return &nd
+}
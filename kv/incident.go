@@ -0,0 +1,267 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+var _ influxdb.IncidentStore = (*Service)(nil)
+
+func newIncidentStore() *StoreBase {
+	const resource = "incident"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var i influxdb.Incident
+		return key, &i, json.Unmarshal(val, &i)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		i, ok := v.(*influxdb.Incident)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(i.ID),
+			Body: i,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("incidentsv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// FindIncidentByID retrieves an incident by id.
+func (s *Service) FindIncidentByID(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var i *influxdb.Incident
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findIncidentByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		i = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindIncidentByID,
+			Err: err,
+		}
+	}
+	return i, nil
+}
+
+func (s *Service) findIncidentByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Incident, error) {
+	v, err := s.incidentStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*influxdb.Incident), nil
+}
+
+// FindIncidents returns the incidents that match the filter, the most
+// recently opened first.
+func (s *Service) FindIncidents(ctx context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var is []*influxdb.Incident
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findIncidents(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		is = v
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindIncidents,
+			Err: err,
+		}
+	}
+	return is, len(is), nil
+}
+
+func (s *Service) findIncidents(ctx context.Context, tx Tx, filter influxdb.IncidentFilter) ([]*influxdb.Incident, error) {
+	is := []*influxdb.Incident{}
+	err := s.incidentStore.Find(ctx, tx, FindOpts{
+		FilterEntFn: func(k []byte, v interface{}) bool {
+			i, ok := v.(*influxdb.Incident)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return false
+			}
+			return filter.Matches(i)
+		},
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			i, ok := decodedVal.(*influxdb.Incident)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			is = append(is, i)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(is, func(a, b int) bool {
+		return is[a].OpenedAt.After(is[b].OpenedAt)
+	})
+	return is, nil
+}
+
+// AcknowledgeIncident records that the user is handling the open incident.
+// Acknowledging an acknowledged incident leaves it unchanged.
+func (s *Service) AcknowledgeIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID) (*influxdb.Incident, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	i, err := s.updateIncident(ctx, id, func(i *influxdb.Incident) (bool, error) {
+		if !i.Open() {
+			return false, &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  "incident is closed",
+			}
+		}
+		if i.Acknowledged() {
+			return false, nil
+		}
+		now := s.Now()
+		i.AcknowledgedAt = &now
+		i.AcknowledgedBy = userID
+		return true, nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpAcknowledgeIncident,
+			Err: err,
+		}
+	}
+	return i, nil
+}
+
+// UnacknowledgeIncident removes the acknowledgement of the incident.
+func (s *Service) UnacknowledgeIncident(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	i, err := s.updateIncident(ctx, id, func(i *influxdb.Incident) (bool, error) {
+		if !i.Acknowledged() {
+			return false, nil
+		}
+		i.AcknowledgedAt = nil
+		i.AcknowledgedBy = 0
+		return true, nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUnacknowledgeIncident,
+			Err: err,
+		}
+	}
+	return i, nil
+}
+
+// CommentIncident adds a comment of the user to the incident, whether it is
+// open or closed.
+func (s *Service) CommentIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID, text string) (*influxdb.Incident, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	i, err := s.updateIncident(ctx, id, func(i *influxdb.Incident) (bool, error) {
+		if strings.TrimSpace(text) == "" {
+			return false, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "incident comment is empty",
+			}
+		}
+		i.Comments = append(i.Comments, influxdb.IncidentComment{
+			UserID:    userID,
+			Text:      text,
+			CreatedAt: s.Now(),
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpCommentIncident,
+			Err: err,
+		}
+	}
+	return i, nil
+}
+
+// updateIncident applies fn to the incident with id and stores it when fn
+// reports it changed.
+func (s *Service) updateIncident(ctx context.Context, id influxdb.ID, fn func(*influxdb.Incident) (bool, error)) (*influxdb.Incident, error) {
+	var i *influxdb.Incident
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.findIncidentByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		i = v
+
+		changed, err := fn(i)
+		if err != nil || !changed {
+			return err
+		}
+		i.UpdatedAt = s.Now()
+		return s.putIncident(ctx, tx, i, PutUpdate())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// PutIncident creates an incident and sets i.ID when its ID is not set, or
+// replaces it.
+func (s *Service) PutIncident(ctx context.Context, i *influxdb.Incident) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		if i.ID.Valid() {
+			return s.putIncident(ctx, tx, i)
+		}
+		i.ID = s.IDGenerator.ID()
+		return s.putIncident(ctx, tx, i, PutNew())
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpPutIncident,
+			Err: err,
+		}
+	}
+	return nil
+}
+
+func (s *Service) putIncident(ctx context.Context, tx Tx, i *influxdb.Incident, opts ...PutOptionFn) error {
+	return s.incidentStore.Put(ctx, tx, Entity{
+		PK:   EncID(i.ID),
+		Body: i,
+	}, opts...)
+}
+
+// deleteIncidents deletes the incidents that match the filter.
+func (s *Service) deleteIncidents(ctx context.Context, tx Tx, filter influxdb.IncidentFilter) error {
+	is, err := s.findIncidents(ctx, tx, filter)
+	if err != nil {
+		return err
+	}
+	for _, i := range is {
+		if err := s.incidentStore.DeleteEnt(ctx, tx, Entity{PK: EncID(i.ID)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
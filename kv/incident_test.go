@@ -0,0 +1,100 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+)
+
+func TestService_Incident(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	opened := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	tags := map[string]string{"host": "web-1"}
+	older := &influxdb.Incident{
+		OrgID:        ts.Org.ID,
+		CheckID:      1,
+		Key:          influxdb.StatusKey(1, tags),
+		Tags:         tags,
+		Level:        "ok",
+		Status:       influxdb.IncidentClosed,
+		OpenedAt:     opened.Add(-time.Hour),
+		LastStatusAt: opened.Add(-time.Hour),
+	}
+	inc := &influxdb.Incident{
+		OrgID:        ts.Org.ID,
+		CheckID:      1,
+		Key:          influxdb.StatusKey(1, tags),
+		Tags:         tags,
+		Level:        "crit",
+		Status:       influxdb.IncidentOpen,
+		OpenedAt:     opened,
+		LastStatusAt: opened,
+	}
+	for _, i := range []*influxdb.Incident{older, inc} {
+		if err := ts.Service.PutIncident(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !inc.ID.Valid() || inc.ID == older.ID {
+		t.Fatalf("unexpected incident %+v", inc)
+	}
+
+	is, n, err := ts.Service.FindIncidents(ctx, influxdb.IncidentFilter{OrgID: &ts.Org.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || is[0].ID != inc.ID {
+		t.Fatalf("expected the most recently opened incident first, got %v", is)
+	}
+
+	acked, err := ts.Service.AcknowledgeIncident(ctx, inc.ID, ts.User.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !acked.Acknowledged() || acked.AcknowledgedBy != ts.User.ID {
+		t.Fatalf("unexpected acknowledged incident %+v", acked)
+	}
+	yes := true
+	if _, n, err := ts.Service.FindIncidents(ctx, influxdb.IncidentFilter{Acknowledged: &yes}); err != nil || n != 1 {
+		t.Fatalf("expected one acknowledged incident, got %d, error %v", n, err)
+	}
+	if _, err := ts.Service.AcknowledgeIncident(ctx, older.ID, ts.User.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected conflict acknowledging a closed incident, got %v", err)
+	}
+
+	commented, err := ts.Service.CommentIncident(ctx, inc.ID, ts.User.ID, "looking into it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commented.Comments) != 1 || commented.Comments[0].Text != "looking into it" || !commented.Acknowledged() {
+		t.Fatalf("unexpected commented incident %+v", commented)
+	}
+	if _, err := ts.Service.CommentIncident(ctx, inc.ID, ts.User.ID, " "); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for an empty comment, got %v", err)
+	}
+
+	unacked, err := ts.Service.UnacknowledgeIncident(ctx, inc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unacked.Acknowledged() || len(unacked.Comments) != 1 {
+		t.Fatalf("unexpected unacknowledged incident %+v", unacked)
+	}
+
+	if err := ts.Service.DeleteOrganization(ctx, ts.Org.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Service.FindIncidentByID(ctx, inc.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected incident to be deleted with its organization, got %v", err)
+	}
+}
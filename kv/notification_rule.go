@@ -501,5 +501,5 @@ func (s *Service) deleteNotificationRule(ctx context.Context, tx Tx, id influxdb
 		s.log.Info("Failed to remove user resource mappings for notification rule", zap.Error(err), zap.Stringer("rule_id", id))
 	}
 
-	return nil
+	return s.deleteNotificationRuleEscalationPolicies(ctx, tx, id)
 }
@@ -524,6 +524,15 @@ func (s *Service) DeleteOrganization(ctx context.Context, id influxdb.ID) error
 		if err := s.deleteOrganizationsBuckets(ctx, tx, id); err != nil {
 			return err
 		}
+		if err := s.deleteOrganizationSilences(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := s.deleteOrganizationEscalationPolicies(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := s.deleteIncidents(ctx, tx, influxdb.IncidentFilter{OrgID: &id}); err != nil {
+			return err
+		}
 		if pe := s.deleteOrganization(ctx, tx, id); pe != nil {
 			return pe
 		}
@@ -43,6 +43,14 @@ type Service struct {
 	checkStore    *IndexStore
 	endpointStore *IndexStore
 	variableStore *IndexStore
+
+	downsamplePolicyStore *StoreBase
+	silenceStore          *StoreBase
+	escalationPolicyStore *StoreBase
+	escalationStore       *StoreBase
+	incidentStore         *StoreBase
+	backfillStore         *StoreBase
+	taskRevisionStore     *StoreBase
 }
 
 // NewService returns an instance of a Service.
@@ -60,6 +68,14 @@ func NewService(log *zap.Logger, kv Store, configs ...ServiceConfig) *Service {
 		checkStore:     newCheckStore(),
 		endpointStore:  newEndpointStore(),
 		variableStore:  newVariableStore(),
+
+		downsamplePolicyStore: newDownsamplePolicyStore(),
+		silenceStore:          newSilenceStore(),
+		escalationPolicyStore: newEscalationPolicyStore(),
+		escalationStore:       newEscalationStore(),
+		incidentStore:         newIncidentStore(),
+		backfillStore:         newBackfillStore(),
+		taskRevisionStore:     newTaskRevisionStore(),
 	}
 
 	if len(configs) > 0 {
@@ -170,6 +186,34 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.downsamplePolicyStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.silenceStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.escalationPolicyStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.escalationStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.incidentStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.backfillStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := s.taskRevisionStore.Init(ctx, tx); err != nil {
+			return err
+		}
+
 		return s.initializeUsers(ctx, tx)
 	})
 
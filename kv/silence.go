@@ -0,0 +1,223 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+var _ influxdb.SilenceService = (*Service)(nil)
+
+func newSilenceStore() *StoreBase {
+	const resource = "silence"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var s influxdb.Silence
+		return key, &s, json.Unmarshal(val, &s)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		s, ok := v.(*influxdb.Silence)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(s.ID),
+			Body: s,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("silencesv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// FindSilenceByID retrieves a silence by id.
+func (s *Service) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var sil *influxdb.Silence
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findSilenceByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		sil = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sil, nil
+}
+
+func (s *Service) findSilenceByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Silence, error) {
+	v, err := s.silenceStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindSilenceByID,
+			Err: err,
+		}
+	}
+	return v.(*influxdb.Silence), nil
+}
+
+// FindSilences returns the silences that match the filter.
+func (s *Service) FindSilences(ctx context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var ss []*influxdb.Silence
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findSilences(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ss = v
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindSilences,
+			Err: err,
+		}
+	}
+	return ss, len(ss), nil
+}
+
+func (s *Service) findSilences(ctx context.Context, tx Tx, filter influxdb.SilenceFilter) ([]*influxdb.Silence, error) {
+	ss := []*influxdb.Silence{}
+	err := s.silenceStore.Find(ctx, tx, FindOpts{
+		FilterEntFn: func(k []byte, v interface{}) bool {
+			sil, ok := v.(*influxdb.Silence)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return false
+			}
+			return filterSilence(sil, filter)
+		},
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			sil, ok := decodedVal.(*influxdb.Silence)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			ss = append(ss, sil)
+			return nil
+		},
+	})
+	return ss, err
+}
+
+func filterSilence(s *influxdb.Silence, filter influxdb.SilenceFilter) bool {
+	if filter.OrgID != nil && s.OrgID != *filter.OrgID {
+		return false
+	}
+	if filter.ActiveAt != nil && !s.ActiveAt(*filter.ActiveAt) {
+		return false
+	}
+	return true
+}
+
+// CreateSilence creates a silence and sets sil.ID.
+func (s *Service) CreateSilence(ctx context.Context, sil *influxdb.Silence, userID influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.createSilence(ctx, tx, sil, userID)
+	})
+}
+
+func (s *Service) createSilence(ctx context.Context, tx Tx, sil *influxdb.Silence, userID influxdb.ID) error {
+	if err := sil.Valid(); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateSilence,
+			Err: err,
+		}
+	}
+
+	if _, err := s.findOrganizationByID(ctx, tx, sil.OrgID); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateSilence,
+			Err: err,
+		}
+	}
+
+	sil.ID = s.IDGenerator.ID()
+	sil.OwnerID = userID
+	now := s.Now()
+	sil.CreatedAt = now
+	sil.UpdatedAt = now
+
+	return s.putSilence(ctx, tx, sil, PutNew())
+}
+
+func (s *Service) putSilence(ctx context.Context, tx Tx, sil *influxdb.Silence, opts ...PutOptionFn) error {
+	return s.silenceStore.Put(ctx, tx, Entity{
+		PK:   EncID(sil.ID),
+		Body: sil,
+	}, opts...)
+}
+
+// UpdateSilence updates a silence.
+func (s *Service) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var sil *influxdb.Silence
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		v, err := s.findSilenceByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		upd.Apply(v)
+		if err := v.Valid(); err != nil {
+			return err
+		}
+		v.UpdatedAt = s.Now()
+
+		if err := s.putSilence(ctx, tx, v, PutUpdate()); err != nil {
+			return err
+		}
+		sil = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateSilence,
+			Err: err,
+		}
+	}
+	return sil, nil
+}
+
+// DeleteSilence deletes a silence.
+func (s *Service) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := s.silenceStore.DeleteEnt(ctx, tx, Entity{PK: EncID(id)}); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpDeleteSilence,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+// deleteOrganizationSilences deletes the silences of the organization.
+func (s *Service) deleteOrganizationSilences(ctx context.Context, tx Tx, orgID influxdb.ID) error {
+	ss, err := s.findSilences(ctx, tx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return err
+	}
+	for _, sil := range ss {
+		if err := s.silenceStore.DeleteEnt(ctx, tx, Entity{PK: EncID(sil.ID)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
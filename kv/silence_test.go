@@ -0,0 +1,83 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+)
+
+func TestService_Silence(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	sil := &influxdb.Silence{
+		OrgID:     ts.Org.ID,
+		Name:      "deploy",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	if err := ts.Service.CreateSilence(ctx, sil, ts.User.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !sil.ID.Valid() || sil.OwnerID != ts.User.ID {
+		t.Fatalf("unexpected silence %+v", sil)
+	}
+
+	active := start.Add(time.Minute)
+	ss, n, err := ts.Service.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &ts.Org.ID, ActiveAt: &active})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ss[0].ID != sil.ID {
+		t.Fatalf("expected the silence to be active, got %v", ss)
+	}
+
+	end := start.Add(2 * time.Hour)
+	if _, err := ts.Service.UpdateSilence(ctx, sil.ID, influxdb.SilenceUpdate{EndTime: &end}); err != nil {
+		t.Fatal(err)
+	}
+	later := start.Add(90 * time.Minute)
+	if _, n, err := ts.Service.FindSilences(ctx, influxdb.SilenceFilter{ActiveAt: &later}); err != nil || n != 1 {
+		t.Fatalf("expected the updated silence to be active, got %d silences, error %v", n, err)
+	}
+
+	before := start.Add(-time.Hour)
+	if _, err := ts.Service.UpdateSilence(ctx, sil.ID, influxdb.SilenceUpdate{EndTime: &before}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+
+	if err := ts.Service.DeleteOrganization(ctx, ts.Org.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Service.FindSilenceByID(ctx, sil.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected silence to be deleted with its organization, got %v", err)
+	}
+}
+
+func TestService_CreateSilence_MissingOrg(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	err := ts.Service.CreateSilence(ctx, &influxdb.Silence{
+		OrgID:     ts.Org.ID + 1,
+		Name:      "deploy",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}, ts.User.ID)
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
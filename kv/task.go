@@ -22,6 +22,8 @@ import (
 //   <taskID>/latestCompleted: run data for the latest completed run of a task
 // taskIndexBucket
 //   <orgID>/<taskID>: index for tasks by org
+// taskLatestRevisionBucket
+//   <taskID>: number of the latest revision of a task
 
 // We may want to add a <taskName>/<taskID> index to allow us to look up tasks by task name.
 
@@ -45,9 +47,11 @@ type kvTask struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Location        string                 `json:"location,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
 	Offset          influxdb.Duration      `json:"offset,omitempty"`
+	DependsOn       []influxdb.ID          `json:"dependsOn,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	CreatedAt       time.Time              `json:"createdAt,omitempty"`
@@ -68,9 +72,11 @@ func kvToInfluxTask(k *kvTask) *influxdb.Task {
 		Flux:            k.Flux,
 		Every:           k.Every,
 		Cron:            k.Cron,
+		Location:        k.Location,
 		LastRunStatus:   k.LastRunStatus,
 		LastRunError:    k.LastRunError,
 		Offset:          k.Offset.Duration,
+		DependsOn:       k.DependsOn,
 		LatestCompleted: k.LatestCompleted,
 		LatestScheduled: k.LatestScheduled,
 		CreatedAt:       k.CreatedAt,
@@ -89,6 +95,9 @@ func (s *Service) initializeTasks(ctx context.Context, tx Tx) error {
 	if _, err := tx.Bucket(taskIndexBucket); err != nil {
 		return err
 	}
+	if _, err := tx.Bucket(taskLatestRevisionBucket); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -614,6 +623,7 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 		Flux:            tc.Flux,
 		Every:           opt.Every.String(),
 		Cron:            opt.Cron,
+		Location:        opt.LocationName(),
 		CreatedAt:       createdAt,
 		LatestCompleted: createdAt,
 		LatestScheduled: createdAt,
@@ -628,6 +638,10 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 
 	}
 
+	if task.DependsOn, err = s.validateTaskDependencies(ctx, tx, task, tc.DependsOn); err != nil {
+		return nil, err
+	}
+
 	taskBucket, err := tx.Bucket(taskBucket)
 	if err != nil {
 		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
@@ -665,6 +679,10 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
 	}
 
+	if err := s.createTaskRevision(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
 	if err := s.createTaskURM(ctx, tx, task); err != nil {
 		s.log.Info("Error creating user resource mapping for task", zap.Stringer("taskID", task.ID), zap.Error(err))
 	}
@@ -737,8 +755,12 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 	}
 
 	updatedAt := s.clock.Now().UTC()
+	// the updates of the flux, description, status and upstream tasks are
+	// recorded as a revision of the task.
+	var revised bool
 
 	// update the flux script
+	rescheduled := false
 	if !upd.Options.IsZero() || upd.Flux != nil {
 		if err = upd.UpdateFlux(task.Flux); err != nil {
 			return nil, err
@@ -752,6 +774,7 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		task.Name = options.Name
 		task.Every = options.Every.String()
 		task.Cron = options.Cron
+		task.Location = options.LocationName()
 
 		var off time.Duration
 		if options.Offset != nil {
@@ -762,16 +785,20 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		}
 		task.Offset = off
 		task.UpdatedAt = updatedAt
+		revised = true
+		rescheduled = true
 	}
 
 	if upd.Description != nil {
 		task.Description = *upd.Description
 		task.UpdatedAt = updatedAt
+		revised = true
 	}
 
 	if upd.Status != nil && task.Status != *upd.Status {
 		task.Status = *upd.Status
 		task.UpdatedAt = updatedAt
+		revised = true
 
 		// task is transitioning from inactive to active, ensure scheduled and completed are updated
 		if task.Status == influxdb.TaskStatusActive {
@@ -781,6 +808,24 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		}
 	}
 
+	if upd.DependsOn != nil {
+		if task.DependsOn, err = s.validateTaskDependencies(ctx, tx, task, *upd.DependsOn); err != nil {
+			return nil, err
+		}
+		task.UpdatedAt = updatedAt
+		revised = true
+	} else if rescheduled && len(task.DependsOn) > 0 {
+		// the new schedule must still trigger on the runs of the upstream tasks.
+		if _, err := s.validateTaskDependencies(ctx, tx, task, task.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+	if rescheduled {
+		if err := s.validateTaskDependents(ctx, tx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	if upd.Metadata != nil {
 		task.Metadata = upd.Metadata
 		task.UpdatedAt = updatedAt
@@ -832,6 +877,12 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
 	}
 
+	if revised {
+		if err := s.createTaskRevision(ctx, tx, task); err != nil {
+			return nil, err
+		}
+	}
+
 	uid, _ := icontext.GetUserID(ctx)
 	if err := s.audit.Log(resource.Change{
 		Type:           resource.Update,
@@ -886,6 +937,15 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 		return err
 	}
 
+	// the tasks that depend on the task would never run again.
+	dependent, err := s.findTaskDependent(ctx, tx, task)
+	if err != nil {
+		return err
+	}
+	if dependent != nil {
+		return influxdb.ErrTaskHasDependents(dependent.ID)
+	}
+
 	// remove the orgs index
 	orgKey, err := taskOrgKey(task.OrganizationID, task.ID)
 	if err != nil {
@@ -922,6 +982,13 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 			return influxdb.ErrUnexpectedTaskBucketErr(err)
 		}
 	}
+	if err := s.deleteBackfills(ctx, tx, task.ID); err != nil {
+		return err
+	}
+	if err := s.deleteTaskRevisions(ctx, tx, task.ID); err != nil {
+		return err
+	}
+
 	// remove the task
 	key, err := taskKey(task.ID)
 	if err != nil {
@@ -1142,7 +1209,21 @@ func (s *Service) cancelRun(ctx context.Context, tx Tx, taskID, runID influxdb.I
 func (s *Service) RetryRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	var r *influxdb.Run
 	err := s.kv.Update(ctx, func(tx Tx) error {
-		run, err := s.retryRun(ctx, tx, taskID, runID)
+		run, err := s.retryRun(ctx, tx, taskID, runID, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		r = run
+		return nil
+	})
+	return r, err
+}
+
+// QueueRetry creates and returns the next attempt of a run, to be executed at runAt.
+func (s *Service) QueueRetry(ctx context.Context, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error) {
+	var r *influxdb.Run
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		run, err := s.retryRun(ctx, tx, taskID, runID, runAt)
 		if err != nil {
 			return err
 		}
@@ -1152,18 +1233,26 @@ func (s *Service) RetryRun(ctx context.Context, taskID, runID influxdb.ID) (*inf
 	return r, err
 }
 
-func (s *Service) retryRun(ctx context.Context, tx Tx, taskID, runID influxdb.ID) (*influxdb.Run, error) {
+func (s *Service) retryRun(ctx context.Context, tx Tx, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error) {
 	// find the run
 	r, err := s.findRunByID(ctx, tx, taskID, runID)
 	if err != nil {
 		return nil, err
 	}
 
+	// the retry is the next attempt, with its own logs.
+	if r.Attempt < 1 {
+		r.Attempt = 1
+	}
+	r.Attempt++
+	r.RetryOf = r.ID
 	r.ID = s.IDGenerator.ID()
 	r.Status = influxdb.RunScheduled.String()
+	r.RunAt = runAt
 	r.StartedAt = time.Time{}
 	r.FinishedAt = time.Time{}
 	r.RequestedAt = time.Time{}
+	r.Log = []influxdb.Log{}
 
 	// add a clean copy of the run to the manual runs
 	bucket, err := tx.Bucket(taskRunBucket)
@@ -1176,26 +1265,15 @@ func (s *Service) retryRun(ctx context.Context, tx Tx, taskID, runID influxdb.ID
 		return nil, err
 	}
 
-	runs := []*influxdb.Run{}
-	runsBytes, err := bucket.Get(key)
+	runs, err := s.manualRuns(ctx, tx, taskID)
 	if err != nil {
-		if err != ErrKeyNotFound {
-			return nil, influxdb.ErrRunNotFound
-		}
-		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
-
-	}
-
-	if runsBytes != nil {
-		if err := json.Unmarshal(runsBytes, &runs); err != nil {
-			return nil, influxdb.ErrInternalTaskServiceError(err)
-		}
+		return nil, err
 	}
 
 	runs = append(runs, r)
 
 	// save manual runs
-	runsBytes, err = json.Marshal(runs)
+	runsBytes, err := json.Marshal(runs)
 	if err != nil {
 		return nil, influxdb.ErrInternalTaskServiceError(err)
 	}
@@ -1231,6 +1309,7 @@ func (s *Service) forceRun(ctx context.Context, tx Tx, taskID influxdb.ID, sched
 		Status:       influxdb.RunScheduled.String(),
 		RequestedAt:  time.Now().UTC(),
 		ScheduledFor: t,
+		Attempt:      1,
 		Log:          []influxdb.Log{},
 	}
 
@@ -1294,6 +1373,7 @@ func (s *Service) createRun(ctx context.Context, tx Tx, taskID influxdb.ID, sche
 		ScheduledFor: t,
 		RunAt:        runAt,
 		Status:       influxdb.RunScheduled.String(),
+		Attempt:      1,
 		Log:          []influxdb.Log{},
 	}
 
@@ -0,0 +1,187 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/backend/scheduler"
+)
+
+// dependencyAlignmentPoints is the number of the next schedule points of a
+// task that must be schedule points of its upstream tasks.
+const dependencyAlignmentPoints = 100
+
+// validateTaskDependencies returns the upstream tasks deps of the task without
+// duplicates. It fails when an upstream task is not a task of the organization
+// of the task, when its schedule does not trigger on the schedule points of the
+// task, or when the upstream tasks depend on the task.
+func (s *Service) validateTaskDependencies(ctx context.Context, tx Tx, task *influxdb.Task, deps []influxdb.ID) ([]influxdb.ID, error) {
+	if len(deps) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[influxdb.ID]bool, len(deps))
+	upstreams := make([]influxdb.ID, 0, len(deps))
+	for _, id := range deps {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if id == task.ID {
+			return nil, influxdb.ErrTaskDependencyCycle
+		}
+
+		up, err := s.findTaskByID(ctx, tx, id)
+		if err != nil {
+			if err == influxdb.ErrTaskNotFound {
+				return nil, influxdb.ErrInvalidTaskDependency(id, "task not found")
+			}
+			return nil, err
+		}
+		if up.OrganizationID != task.OrganizationID {
+			return nil, influxdb.ErrInvalidTaskDependency(id, "task belongs to another organization")
+		}
+		aligned, err := s.schedulesAlign(task, up)
+		if err != nil {
+			return nil, err
+		}
+		if !aligned {
+			return nil, influxdb.ErrInvalidTaskDependency(id, "schedule does not trigger on the schedule points of the task")
+		}
+		upstreams = append(upstreams, id)
+	}
+
+	// walk up the dependencies of the upstream tasks looking for the task.
+	visited := make(map[influxdb.ID]bool)
+	next := append([]influxdb.ID(nil), upstreams...)
+	for len(next) > 0 {
+		id := next[len(next)-1]
+		next = next[:len(next)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		up, err := s.findTaskByID(ctx, tx, id)
+		if err != nil {
+			if err == influxdb.ErrTaskNotFound {
+				continue
+			}
+			return nil, err
+		}
+		for _, upID := range up.DependsOn {
+			if upID == task.ID {
+				return nil, influxdb.ErrTaskDependencyCycle
+			}
+			next = append(next, upID)
+		}
+	}
+
+	return upstreams, nil
+}
+
+// validateTaskDependents fails when the schedule of the task does not trigger
+// on the schedule points of a task that depends on it.
+func (s *Service) validateTaskDependents(ctx context.Context, tx Tx, task *influxdb.Task) error {
+	dependents, err := s.findTaskDependents(ctx, tx, task)
+	if err != nil {
+		return err
+	}
+	for _, d := range dependents {
+		aligned, err := s.schedulesAlign(d, task)
+		if err != nil {
+			return err
+		}
+		if !aligned {
+			return influxdb.ErrInvalidTaskDependency(task.ID, fmt.Sprintf("schedule does not trigger on the schedule points of task %s that depends on it", d.ID))
+		}
+	}
+	return nil
+}
+
+// schedulesAlign reports whether the next schedule points of the task are
+// schedule points of the upstream task, whose runs trigger them.
+func (s *Service) schedulesAlign(task, up *influxdb.Task) (bool, error) {
+	loc, err := task.ScheduleLocation()
+	if err != nil {
+		return false, influxdb.ErrTaskOptionParse(err)
+	}
+	upLoc, err := up.ScheduleLocation()
+	if err != nil {
+		return false, influxdb.ErrTaskOptionParse(err)
+	}
+
+	sch, next, err := scheduler.NewScheduleIn(task.EffectiveCron(), loc, s.clock.Now())
+	if err != nil {
+		return false, influxdb.ErrTaskOptionParse(err)
+	}
+	for i := 0; i < dependencyAlignmentPoints; i++ {
+		if next, err = sch.Next(next); err != nil {
+			return false, influxdb.ErrTaskOptionParse(err)
+		}
+		points, err := scheduler.BetweenIn(up.EffectiveCron(), upLoc, next, next.Add(time.Second), 1)
+		if err != nil {
+			return false, influxdb.ErrTaskOptionParse(err)
+		}
+		if len(points) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// findTaskDependent returns a task of the organization of the task that
+// depends on it, or nil when there is none.
+func (s *Service) findTaskDependent(ctx context.Context, tx Tx, task *influxdb.Task) (*influxdb.Task, error) {
+	dependents, err := s.findTaskDependents(ctx, tx, task)
+	if err != nil || len(dependents) == 0 {
+		return nil, err
+	}
+	return dependents[0], nil
+}
+
+// findTaskDependents returns the tasks of the organization of the task that
+// depend on it.
+func (s *Service) findTaskDependents(ctx context.Context, tx Tx, task *influxdb.Task) ([]*influxdb.Task, error) {
+	indexBucket, err := tx.Bucket(taskIndexBucket)
+	if err != nil {
+		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	prefix, err := task.OrganizationID.Encode()
+	if err != nil {
+		return nil, influxdb.ErrInvalidTaskID
+	}
+
+	c, err := indexBucket.ForwardCursor(prefix, WithCursorPrefix(prefix))
+	if err != nil {
+		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	defer c.Close()
+
+	var dependents []*influxdb.Task
+	for k, v := c.Next(); k != nil; k, v = c.Next() {
+		id, err := influxdb.IDFromString(string(v))
+		if err != nil {
+			return nil, influxdb.ErrInvalidTaskID
+		}
+
+		t, err := s.findTaskByID(ctx, tx, *id)
+		if err != nil {
+			if err == influxdb.ErrTaskNotFound {
+				// we might have some crufty index's
+				continue
+			}
+			return nil, err
+		}
+		for _, upID := range t.DependsOn {
+			if upID == task.ID {
+				dependents = append(dependents, t)
+				break
+			}
+		}
+	}
+	return dependents, c.Err()
+}
@@ -0,0 +1,269 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+)
+
+// taskLatestRevisionBucket stores the number of the latest revision of each
+// task, by task ID, so that the next revision is numbered without reading the
+// revisions of the task.
+var taskLatestRevisionBucket = []byte("tasklatestrevisionsv1")
+
+func newTaskRevisionStore() *StoreBase {
+	const resource = "task revision"
+
+	var decEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var r influxdb.TaskRevision
+		return key, &r, json.Unmarshal(val, &r)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, v interface{}) (Entity, error) {
+		r, ok := v.(*influxdb.TaskRevision)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   taskRevisionPK(r.TaskID, r.Revision),
+			Body: r,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("taskrevisionsv1"), EncIDKey, EncBodyJSON, decEntFn, decValToEntFn)
+}
+
+// taskRevisionPK keys the revisions by their task first, so that the
+// revisions of a task can be found by prefix, then by their number.
+func taskRevisionPK(taskID influxdb.ID, revision int) EncodeFn {
+	return Encode(EncID(taskID), func() ([]byte, error) {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, uint64(revision))
+		return b, nil
+	})
+}
+
+// FindTaskRevisions returns the revisions of the task, the most recent first.
+func (s *Service) FindTaskRevisions(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var rs []*influxdb.TaskRevision
+	err := s.kv.View(ctx, func(tx Tx) error {
+		if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+			return err
+		}
+		v, err := s.findTaskRevisions(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		rs = v
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindTaskRevisions,
+			Err: err,
+		}
+	}
+	return rs, len(rs), nil
+}
+
+func (s *Service) findTaskRevisions(ctx context.Context, tx Tx, taskID influxdb.ID) ([]*influxdb.TaskRevision, error) {
+	prefix, err := EncID(taskID)()
+	if err != nil {
+		return nil, err
+	}
+
+	rs := []*influxdb.TaskRevision{}
+	err = s.taskRevisionStore.Find(ctx, tx, FindOpts{
+		Prefix: prefix,
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			r, ok := decodedVal.(*influxdb.TaskRevision)
+			if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+				return err
+			}
+			rs = append(rs, r)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Revision > rs[j].Revision
+	})
+	return rs, nil
+}
+
+// FindTaskRevision returns a single revision of the task by its number.
+func (s *Service) FindTaskRevision(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var r *influxdb.TaskRevision
+	err := s.kv.View(ctx, func(tx Tx) error {
+		v, err := s.findTaskRevision(ctx, tx, taskID, revision)
+		if err != nil {
+			return err
+		}
+		r = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindTaskRevision,
+			Err: err,
+		}
+	}
+	return r, nil
+}
+
+func (s *Service) findTaskRevision(ctx context.Context, tx Tx, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	v, err := s.taskRevisionStore.FindEnt(ctx, tx, Entity{PK: taskRevisionPK(taskID, revision)})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*influxdb.TaskRevision), nil
+}
+
+// RollbackTask updates the task to the flux, description and upstream tasks
+// of the revision, and to its status when restoreStatus is set. The rollback
+// is recorded as a new revision.
+func (s *Service) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int, restoreStatus bool) (*influxdb.Task, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	var t *influxdb.Task
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		r, err := s.findTaskRevision(ctx, tx, taskID, revision)
+		if err != nil {
+			return err
+		}
+		v, err := s.updateTask(ctx, tx, taskID, r.TaskUpdate(restoreStatus))
+		if err != nil {
+			return err
+		}
+		t = v
+		return nil
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpRollbackTask,
+			Err: err,
+		}
+	}
+	return t, nil
+}
+
+// createTaskRevision records the task as its next revision, authored by the
+// user of the authorizer of ctx, and deletes the revision that is no longer
+// among the MaxTaskRevisions most recent ones.
+func (s *Service) createTaskRevision(ctx context.Context, tx Tx, task *influxdb.Task) error {
+	latest, err := s.findLatestTaskRevision(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	r := influxdb.NewTaskRevision(task)
+	r.Revision = latest + 1
+	r.AuthorID, _ = icontext.GetUserID(ctx)
+	r.CreatedAt = s.clock.Now().UTC()
+
+	err = s.taskRevisionStore.Put(ctx, tx, Entity{
+		PK:   taskRevisionPK(r.TaskID, r.Revision),
+		Body: r,
+	}, PutNew())
+	if err != nil {
+		return err
+	}
+	if err := s.putLatestTaskRevision(tx, task.ID, r.Revision); err != nil {
+		return err
+	}
+
+	if pruned := r.Revision - influxdb.MaxTaskRevisions; pruned > 0 {
+		err := s.taskRevisionStore.DeleteEnt(ctx, tx, Entity{PK: taskRevisionPK(r.TaskID, pruned)})
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// findLatestTaskRevision returns the number of the latest revision of the
+// task, 0 when it has none. It is read from the revisions of the task when it
+// was not recorded, for the tasks revised before it was.
+func (s *Service) findLatestTaskRevision(ctx context.Context, tx Tx, taskID influxdb.ID) (int, error) {
+	key, err := taskKey(taskID)
+	if err != nil {
+		return 0, err
+	}
+	b, err := tx.Bucket(taskLatestRevisionBucket)
+	if err != nil {
+		return 0, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	v, err := b.Get(key)
+	if err == nil {
+		return int(binary.BigEndian.Uint64(v)), nil
+	}
+	if !IsNotFound(err) {
+		return 0, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	rs, err := s.findTaskRevisions(ctx, tx, taskID)
+	if err != nil || len(rs) == 0 {
+		return 0, err
+	}
+	return rs[0].Revision, nil
+}
+
+func (s *Service) putLatestTaskRevision(tx Tx, taskID influxdb.ID, revision int) error {
+	key, err := taskKey(taskID)
+	if err != nil {
+		return err
+	}
+	b, err := tx.Bucket(taskLatestRevisionBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(revision))
+	if err := b.Put(key, v); err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
+// deleteTaskRevisions deletes the revisions of the task.
+func (s *Service) deleteTaskRevisions(ctx context.Context, tx Tx, taskID influxdb.ID) error {
+	rs, err := s.findTaskRevisions(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	for _, r := range rs {
+		if err := s.taskRevisionStore.DeleteEnt(ctx, tx, Entity{PK: taskRevisionPK(r.TaskID, r.Revision)}); err != nil {
+			return err
+		}
+	}
+
+	key, err := taskKey(taskID)
+	if err != nil {
+		return err
+	}
+	b, err := tx.Bucket(taskLatestRevisionBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	if err := b.Delete(key); err != nil && !IsNotFound(err) {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.AlertHistoryService = &AlertHistoryService{}
+
+// AlertHistoryService is a mock implementation of influxdb.AlertHistoryService.
+type AlertHistoryService struct {
+	FindCheckStatusesFn         func(context.Context, influxdb.AlertHistoryFilter, ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error)
+	FindCheckStatusesCalls      SafeCount
+	FindNotificationEventsFn    func(context.Context, influxdb.AlertHistoryFilter, ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error)
+	FindNotificationEventsCalls SafeCount
+}
+
+// NewAlertHistoryService returns a mock AlertHistoryService where its methods
+// will return zero values.
+func NewAlertHistoryService() *AlertHistoryService {
+	return &AlertHistoryService{
+		FindCheckStatusesFn: func(context.Context, influxdb.AlertHistoryFilter, ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+			return nil, 0, nil
+		},
+		FindNotificationEventsFn: func(context.Context, influxdb.AlertHistoryFilter, ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+			return nil, 0, nil
+		},
+	}
+}
+
+// FindCheckStatuses returns the statuses that match filter and the total count of matching statuses.
+func (s *AlertHistoryService) FindCheckStatuses(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+	defer s.FindCheckStatusesCalls.IncrFn()()
+	return s.FindCheckStatusesFn(ctx, filter, opt...)
+}
+
+// FindNotificationEvents returns the notifications that match filter and the total count of matching notifications.
+func (s *AlertHistoryService) FindNotificationEvents(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+	defer s.FindNotificationEventsCalls.IncrFn()()
+	return s.FindNotificationEventsFn(ctx, filter, opt...)
+}
@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CardinalityService = &CardinalityService{}
+
+// CardinalityService is a mock implementation of influxdb.CardinalityService.
+type CardinalityService struct {
+	BucketCardinalityFn func(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error)
+}
+
+// NewCardinalityService returns a mock CardinalityService where its methods will
+// return zero values.
+func NewCardinalityService() *CardinalityService {
+	return &CardinalityService{
+		BucketCardinalityFn: func(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+			return &influxdb.BucketCardinality{OrgID: orgID, BucketID: bucketID}, nil
+		},
+	}
+}
+
+// BucketCardinality calls BucketCardinalityFn.
+func (s *CardinalityService) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+	return s.BucketCardinalityFn(ctx, orgID, bucketID, topN)
+}
@@ -0,0 +1,43 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckDryRunService = &CheckDryRunService{}
+
+// CheckDryRunService is a mock implementation of influxdb.CheckDryRunService.
+type CheckDryRunService struct {
+	DryRunCheckFn        func(context.Context, influxdb.Check, time.Time, time.Time) ([]*influxdb.CheckStatus, error)
+	DryRunCheckCalls     SafeCount
+	DryRunCheckByIDFn    func(context.Context, influxdb.ID, time.Time, time.Time) ([]*influxdb.CheckStatus, error)
+	DryRunCheckByIDCalls SafeCount
+}
+
+// NewCheckDryRunService returns a mock CheckDryRunService where its methods
+// will return zero values.
+func NewCheckDryRunService() *CheckDryRunService {
+	return &CheckDryRunService{
+		DryRunCheckFn: func(context.Context, influxdb.Check, time.Time, time.Time) ([]*influxdb.CheckStatus, error) {
+			return nil, nil
+		},
+		DryRunCheckByIDFn: func(context.Context, influxdb.ID, time.Time, time.Time) ([]*influxdb.CheckStatus, error) {
+			return nil, nil
+		},
+	}
+}
+
+// DryRunCheck returns the statuses the check would have written between start and stop.
+func (s *CheckDryRunService) DryRunCheck(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	defer s.DryRunCheckCalls.IncrFn()()
+	return s.DryRunCheckFn(ctx, chk, start, stop)
+}
+
+// DryRunCheckByID returns the statuses the check with id would have written between start and stop.
+func (s *CheckDryRunService) DryRunCheckByID(ctx context.Context, id influxdb.ID, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	defer s.DryRunCheckByIDCalls.IncrFn()()
+	return s.DryRunCheckByIDFn(ctx, id, start, stop)
+}
@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.DownsamplePolicyService = &DownsamplePolicyService{}
+
+// DownsamplePolicyService is a mock implementation of influxdb.DownsamplePolicyService.
+type DownsamplePolicyService struct {
+	FindDownsamplePolicyByIDFn    func(context.Context, influxdb.ID) (*influxdb.DownsamplePolicy, error)
+	FindDownsamplePolicyByIDCalls SafeCount
+	FindDownsamplePoliciesFn      func(context.Context, influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error)
+	FindDownsamplePoliciesCalls   SafeCount
+	CreateDownsamplePolicyFn      func(context.Context, *influxdb.DownsamplePolicy, influxdb.ID) error
+	CreateDownsamplePolicyCalls   SafeCount
+	UpdateDownsamplePolicyFn      func(context.Context, influxdb.ID, influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error)
+	UpdateDownsamplePolicyCalls   SafeCount
+	DeleteDownsamplePolicyFn      func(context.Context, influxdb.ID) error
+	DeleteDownsamplePolicyCalls   SafeCount
+}
+
+// NewDownsamplePolicyService returns a mock DownsamplePolicyService where its methods will return
+// zero values.
+func NewDownsamplePolicyService() *DownsamplePolicyService {
+	return &DownsamplePolicyService{
+		FindDownsamplePolicyByIDFn: func(context.Context, influxdb.ID) (*influxdb.DownsamplePolicy, error) { return nil, nil },
+		FindDownsamplePoliciesFn: func(context.Context, influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error) {
+			return nil, 0, nil
+		},
+		CreateDownsamplePolicyFn: func(context.Context, *influxdb.DownsamplePolicy, influxdb.ID) error { return nil },
+		UpdateDownsamplePolicyFn: func(context.Context, influxdb.ID, influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+			return nil, nil
+		},
+		DeleteDownsamplePolicyFn: func(context.Context, influxdb.ID) error { return nil },
+	}
+}
+
+// FindDownsamplePolicyByID returns a single downsample policy by ID.
+func (s *DownsamplePolicyService) FindDownsamplePolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+	defer s.FindDownsamplePolicyByIDCalls.IncrFn()()
+	return s.FindDownsamplePolicyByIDFn(ctx, id)
+}
+
+// FindDownsamplePolicies returns a list of downsample policies that match filter and the total count of matching policies.
+func (s *DownsamplePolicyService) FindDownsamplePolicies(ctx context.Context, filter influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error) {
+	defer s.FindDownsamplePoliciesCalls.IncrFn()()
+	return s.FindDownsamplePoliciesFn(ctx, filter)
+}
+
+// CreateDownsamplePolicy creates a new downsample policy and sets p.ID with the new identifier.
+func (s *DownsamplePolicyService) CreateDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+	defer s.CreateDownsamplePolicyCalls.IncrFn()()
+	return s.CreateDownsamplePolicyFn(ctx, p, userID)
+}
+
+// UpdateDownsamplePolicy updates a single downsample policy with changeset.
+func (s *DownsamplePolicyService) UpdateDownsamplePolicy(ctx context.Context, id influxdb.ID, upd influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+	defer s.UpdateDownsamplePolicyCalls.IncrFn()()
+	return s.UpdateDownsamplePolicyFn(ctx, id, upd)
+}
+
+// DeleteDownsamplePolicy removes a downsample policy by ID.
+func (s *DownsamplePolicyService) DeleteDownsamplePolicy(ctx context.Context, id influxdb.ID) error {
+	defer s.DeleteDownsamplePolicyCalls.IncrFn()()
+	return s.DeleteDownsamplePolicyFn(ctx, id)
+}
@@ -0,0 +1,89 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.EscalationPolicyService = &EscalationPolicyService{}
+
+// EscalationPolicyService is a mock implementation of influxdb.EscalationPolicyService.
+type EscalationPolicyService struct {
+	FindEscalationPolicyByIDFn    func(context.Context, influxdb.ID) (*influxdb.EscalationPolicy, error)
+	FindEscalationPolicyByIDCalls SafeCount
+	FindEscalationPoliciesFn      func(context.Context, influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error)
+	FindEscalationPoliciesCalls   SafeCount
+	CreateEscalationPolicyFn      func(context.Context, *influxdb.EscalationPolicy, influxdb.ID) error
+	CreateEscalationPolicyCalls   SafeCount
+	UpdateEscalationPolicyFn      func(context.Context, influxdb.ID, influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error)
+	UpdateEscalationPolicyCalls   SafeCount
+	DeleteEscalationPolicyFn      func(context.Context, influxdb.ID) error
+	DeleteEscalationPolicyCalls   SafeCount
+	FindEscalationsFn             func(context.Context, influxdb.ID) ([]*influxdb.Escalation, error)
+	FindEscalationsCalls          SafeCount
+	AcknowledgeEscalationFn       func(context.Context, influxdb.ID, string, influxdb.ID) (*influxdb.Escalation, error)
+	AcknowledgeEscalationCalls    SafeCount
+}
+
+// NewEscalationPolicyService returns a mock EscalationPolicyService where its
+// methods will return zero values.
+func NewEscalationPolicyService() *EscalationPolicyService {
+	return &EscalationPolicyService{
+		FindEscalationPolicyByIDFn: func(context.Context, influxdb.ID) (*influxdb.EscalationPolicy, error) { return nil, nil },
+		FindEscalationPoliciesFn: func(context.Context, influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+			return nil, 0, nil
+		},
+		CreateEscalationPolicyFn: func(context.Context, *influxdb.EscalationPolicy, influxdb.ID) error { return nil },
+		UpdateEscalationPolicyFn: func(context.Context, influxdb.ID, influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+			return nil, nil
+		},
+		DeleteEscalationPolicyFn: func(context.Context, influxdb.ID) error { return nil },
+		FindEscalationsFn:        func(context.Context, influxdb.ID) ([]*influxdb.Escalation, error) { return nil, nil },
+		AcknowledgeEscalationFn: func(context.Context, influxdb.ID, string, influxdb.ID) (*influxdb.Escalation, error) {
+			return nil, nil
+		},
+	}
+}
+
+// FindEscalationPolicyByID returns a single escalation policy by ID.
+func (s *EscalationPolicyService) FindEscalationPolicyByID(ctx context.Context, id influxdb.ID) (*influxdb.EscalationPolicy, error) {
+	defer s.FindEscalationPolicyByIDCalls.IncrFn()()
+	return s.FindEscalationPolicyByIDFn(ctx, id)
+}
+
+// FindEscalationPolicies returns a list of escalation policies that match filter and the total count of matching escalation policies.
+func (s *EscalationPolicyService) FindEscalationPolicies(ctx context.Context, filter influxdb.EscalationPolicyFilter) ([]*influxdb.EscalationPolicy, int, error) {
+	defer s.FindEscalationPoliciesCalls.IncrFn()()
+	return s.FindEscalationPoliciesFn(ctx, filter)
+}
+
+// CreateEscalationPolicy creates a new escalation policy and sets p.ID with the new identifier.
+func (s *EscalationPolicyService) CreateEscalationPolicy(ctx context.Context, p *influxdb.EscalationPolicy, userID influxdb.ID) error {
+	defer s.CreateEscalationPolicyCalls.IncrFn()()
+	return s.CreateEscalationPolicyFn(ctx, p, userID)
+}
+
+// UpdateEscalationPolicy updates a single escalation policy with changeset.
+func (s *EscalationPolicyService) UpdateEscalationPolicy(ctx context.Context, id influxdb.ID, upd influxdb.EscalationPolicyUpdate) (*influxdb.EscalationPolicy, error) {
+	defer s.UpdateEscalationPolicyCalls.IncrFn()()
+	return s.UpdateEscalationPolicyFn(ctx, id, upd)
+}
+
+// DeleteEscalationPolicy removes an escalation policy by ID.
+func (s *EscalationPolicyService) DeleteEscalationPolicy(ctx context.Context, id influxdb.ID) error {
+	defer s.DeleteEscalationPolicyCalls.IncrFn()()
+	return s.DeleteEscalationPolicyFn(ctx, id)
+}
+
+// FindEscalations returns the ongoing escalations of the escalation policy.
+func (s *EscalationPolicyService) FindEscalations(ctx context.Context, policyID influxdb.ID) ([]*influxdb.Escalation, error) {
+	defer s.FindEscalationsCalls.IncrFn()()
+	return s.FindEscalationsFn(ctx, policyID)
+}
+
+// AcknowledgeEscalation stops the escalation with key of the escalation policy.
+func (s *EscalationPolicyService) AcknowledgeEscalation(ctx context.Context, policyID influxdb.ID, key string, userID influxdb.ID) (*influxdb.Escalation, error) {
+	defer s.AcknowledgeEscalationCalls.IncrFn()()
+	return s.AcknowledgeEscalationFn(ctx, policyID, key, userID)
+}
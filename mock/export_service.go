@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.ExportService = (*ExportService)(nil)
+
+// ExportService is a mock implementation of influxdb.ExportService.
+type ExportService struct {
+	ExportBucketFn func(ctx context.Context, filter influxdb.ExportFilter, format influxdb.ExportFormat, w io.Writer) error
+}
+
+// ExportBucket calls ExportBucketFn.
+func (s *ExportService) ExportBucket(ctx context.Context, filter influxdb.ExportFilter, format influxdb.ExportFormat, w io.Writer) error {
+	return s.ExportBucketFn(ctx, filter, format, w)
+}
@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.IncidentService = &IncidentService{}
+
+// IncidentService is a mock implementation of influxdb.IncidentService.
+type IncidentService struct {
+	FindIncidentByIDFn         func(context.Context, influxdb.ID) (*influxdb.Incident, error)
+	FindIncidentByIDCalls      SafeCount
+	FindIncidentsFn            func(context.Context, influxdb.IncidentFilter) ([]*influxdb.Incident, int, error)
+	FindIncidentsCalls         SafeCount
+	AcknowledgeIncidentFn      func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Incident, error)
+	AcknowledgeIncidentCalls   SafeCount
+	UnacknowledgeIncidentFn    func(context.Context, influxdb.ID) (*influxdb.Incident, error)
+	UnacknowledgeIncidentCalls SafeCount
+	CommentIncidentFn          func(context.Context, influxdb.ID, influxdb.ID, string) (*influxdb.Incident, error)
+	CommentIncidentCalls       SafeCount
+}
+
+// NewIncidentService returns a mock IncidentService where its methods will
+// return zero values.
+func NewIncidentService() *IncidentService {
+	return &IncidentService{
+		FindIncidentByIDFn: func(context.Context, influxdb.ID) (*influxdb.Incident, error) { return nil, nil },
+		FindIncidentsFn: func(context.Context, influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+			return nil, 0, nil
+		},
+		AcknowledgeIncidentFn:   func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Incident, error) { return nil, nil },
+		UnacknowledgeIncidentFn: func(context.Context, influxdb.ID) (*influxdb.Incident, error) { return nil, nil },
+		CommentIncidentFn: func(context.Context, influxdb.ID, influxdb.ID, string) (*influxdb.Incident, error) {
+			return nil, nil
+		},
+	}
+}
+
+// FindIncidentByID returns a single incident by ID.
+func (s *IncidentService) FindIncidentByID(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	defer s.FindIncidentByIDCalls.IncrFn()()
+	return s.FindIncidentByIDFn(ctx, id)
+}
+
+// FindIncidents returns a list of incidents that match filter and the total count of matching incidents.
+func (s *IncidentService) FindIncidents(ctx context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+	defer s.FindIncidentsCalls.IncrFn()()
+	return s.FindIncidentsFn(ctx, filter)
+}
+
+// AcknowledgeIncident records that the user is handling the open incident.
+func (s *IncidentService) AcknowledgeIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID) (*influxdb.Incident, error) {
+	defer s.AcknowledgeIncidentCalls.IncrFn()()
+	return s.AcknowledgeIncidentFn(ctx, id, userID)
+}
+
+// UnacknowledgeIncident removes the acknowledgement of the incident.
+func (s *IncidentService) UnacknowledgeIncident(ctx context.Context, id influxdb.ID) (*influxdb.Incident, error) {
+	defer s.UnacknowledgeIncidentCalls.IncrFn()()
+	return s.UnacknowledgeIncidentFn(ctx, id)
+}
+
+// CommentIncident adds a comment of the user to the incident.
+func (s *IncidentService) CommentIncident(ctx context.Context, id influxdb.ID, userID influxdb.ID, text string) (*influxdb.Incident, error) {
+	defer s.CommentIncidentCalls.IncrFn()()
+	return s.CommentIncidentFn(ctx, id, userID, text)
+}
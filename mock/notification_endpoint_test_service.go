@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpointTestService = &NotificationEndpointTestService{}
+
+// NotificationEndpointTestService is a mock implementation of influxdb.NotificationEndpointTestService.
+type NotificationEndpointTestService struct {
+	TestNotificationEndpointFn    func(context.Context, influxdb.ID) (*influxdb.NotificationEndpointTestResult, error)
+	TestNotificationEndpointCalls SafeCount
+}
+
+// NewNotificationEndpointTestService returns a mock NotificationEndpointTestService where its
+// methods will return zero values.
+func NewNotificationEndpointTestService() *NotificationEndpointTestService {
+	return &NotificationEndpointTestService{
+		TestNotificationEndpointFn: func(context.Context, influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+			return &influxdb.NotificationEndpointTestResult{}, nil
+		},
+	}
+}
+
+// TestNotificationEndpoint sends a test notification to the notification endpoint with id.
+func (s *NotificationEndpointTestService) TestNotificationEndpoint(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpointTestResult, error) {
+	defer s.TestNotificationEndpointCalls.IncrFn()()
+	return s.TestNotificationEndpointFn(ctx, id)
+}
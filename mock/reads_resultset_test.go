@@ -82,5 +82,46 @@ m0,tag0=value2,tag1=value1 v0=1 1666666000000
 		expStats := cursors.CursorStats{ScannedValues: 18, ScannedBytes: 18 * 8}
 		checkResult(t, sg, expData, expStats)
 	})
+}
+
+func TestNewResultSetFromSeriesGenerator_CSV(t *testing.T) {
+	spec := mustNewSpecFromToml(t, `
+[[measurements]]
+name = "m0"
+sample = 1.0
+tags = [
+	{ name = "tag0", source = { type = "sequence", start = 0, count = 2 } },
+]
+fields = [
+	{ name = "v0", count = 2, source = 1.0 },
+]`)
+
+	sg := gen.NewSeriesGeneratorFromSpec(spec, gen.TimeRange{
+		Start: time.Unix(1000, 0),
+		End:   time.Unix(2000, 0),
+	})
+
+	rs := mock.NewResultSetFromSeriesGenerator(sg)
+	var sb strings.Builder
+	if err := reads.ResultSetToCSV(&sb, rs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expData = `#datatype,string,long,dateTime:RFC3339Nano,double,string,string,string
+#group,false,false,false,false,true,true,true
+#default,_result,,,,,,
+,result,table,_time,_value,_field,_measurement,tag0
+,,0,1970-01-01T00:16:40Z,1,v0,m0,value0
+,,0,1970-01-01T00:25:00Z,1,v0,m0,value0
 
+#datatype,string,long,dateTime:RFC3339Nano,double,string,string,string
+#group,false,false,false,false,true,true,true
+#default,_result,,,,,,
+,result,table,_time,_value,_field,_measurement,tag0
+,,1,1970-01-01T00:16:40Z,1,v0,m0,value1
+,,1,1970-01-01T00:25:00Z,1,v0,m0,value1
+`
+	if got, exp := sb.String(), expData; !cmp.Equal(got, exp) {
+		t.Errorf("unexpected value -got/+exp\n%s", cmp.Diff(got, exp))
+	}
 }
@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.SilenceService = &SilenceService{}
+
+// SilenceService is a mock implementation of influxdb.SilenceService.
+type SilenceService struct {
+	FindSilenceByIDFn    func(context.Context, influxdb.ID) (*influxdb.Silence, error)
+	FindSilenceByIDCalls SafeCount
+	FindSilencesFn       func(context.Context, influxdb.SilenceFilter) ([]*influxdb.Silence, int, error)
+	FindSilencesCalls    SafeCount
+	CreateSilenceFn      func(context.Context, *influxdb.Silence, influxdb.ID) error
+	CreateSilenceCalls   SafeCount
+	UpdateSilenceFn      func(context.Context, influxdb.ID, influxdb.SilenceUpdate) (*influxdb.Silence, error)
+	UpdateSilenceCalls   SafeCount
+	DeleteSilenceFn      func(context.Context, influxdb.ID) error
+	DeleteSilenceCalls   SafeCount
+}
+
+// NewSilenceService returns a mock SilenceService where its methods will return
+// zero values.
+func NewSilenceService() *SilenceService {
+	return &SilenceService{
+		FindSilenceByIDFn: func(context.Context, influxdb.ID) (*influxdb.Silence, error) { return nil, nil },
+		FindSilencesFn: func(context.Context, influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+			return nil, 0, nil
+		},
+		CreateSilenceFn: func(context.Context, *influxdb.Silence, influxdb.ID) error { return nil },
+		UpdateSilenceFn: func(context.Context, influxdb.ID, influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+			return nil, nil
+		},
+		DeleteSilenceFn: func(context.Context, influxdb.ID) error { return nil },
+	}
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	defer s.FindSilenceByIDCalls.IncrFn()()
+	return s.FindSilenceByIDFn(ctx, id)
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+	defer s.FindSilencesCalls.IncrFn()()
+	return s.FindSilencesFn(ctx, filter)
+}
+
+// CreateSilence creates a new silence and sets s.ID with the new identifier.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence, userID influxdb.ID) error {
+	defer s.CreateSilenceCalls.IncrFn()()
+	return s.CreateSilenceFn(ctx, sil, userID)
+}
+
+// UpdateSilence updates a single silence with changeset.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	defer s.UpdateSilenceCalls.IncrFn()()
+	return s.UpdateSilenceFn(ctx, id, upd)
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	defer s.DeleteSilenceCalls.IncrFn()()
+	return s.DeleteSilenceFn(ctx, id)
+}
@@ -34,6 +34,22 @@ type TaskService struct {
 	RetryRunCalls     SafeCount
 	ForceRunFn        func(context.Context, influxdb.ID, int64) (*influxdb.Run, error)
 	ForceRunCalls     SafeCount
+
+	BackfillTaskFn        func(context.Context, influxdb.ID, influxdb.BackfillCreate) (*influxdb.Backfill, error)
+	BackfillTaskCalls     SafeCount
+	FindBackfillsFn       func(context.Context, influxdb.ID) ([]*influxdb.Backfill, int, error)
+	FindBackfillsCalls    SafeCount
+	FindBackfillByIDFn    func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Backfill, error)
+	FindBackfillByIDCalls SafeCount
+	CancelBackfillFn      func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Backfill, error)
+	CancelBackfillCalls   SafeCount
+
+	FindTaskRevisionsFn    func(context.Context, influxdb.ID) ([]*influxdb.TaskRevision, int, error)
+	FindTaskRevisionsCalls SafeCount
+	FindTaskRevisionFn     func(context.Context, influxdb.ID, int) (*influxdb.TaskRevision, error)
+	FindTaskRevisionCalls  SafeCount
+	RollbackTaskFn         func(context.Context, influxdb.ID, int, bool) (*influxdb.Task, error)
+	RollbackTaskCalls      SafeCount
 }
 
 func NewTaskService() *TaskService {
@@ -71,6 +87,27 @@ func NewTaskService() *TaskService {
 		ForceRunFn: func(ctx context.Context, id influxdb.ID, i int64) (*influxdb.Run, error) {
 			return nil, nil
 		},
+		BackfillTaskFn: func(ctx context.Context, id influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+			return nil, nil
+		},
+		FindBackfillsFn: func(ctx context.Context, id influxdb.ID) ([]*influxdb.Backfill, int, error) {
+			return nil, 0, nil
+		},
+		FindBackfillByIDFn: func(ctx context.Context, id influxdb.ID, id2 influxdb.ID) (*influxdb.Backfill, error) {
+			return nil, nil
+		},
+		CancelBackfillFn: func(ctx context.Context, id influxdb.ID, id2 influxdb.ID) (*influxdb.Backfill, error) {
+			return nil, nil
+		},
+		FindTaskRevisionsFn: func(ctx context.Context, id influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+			return nil, 0, nil
+		},
+		FindTaskRevisionFn: func(ctx context.Context, id influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+			return nil, nil
+		},
+		RollbackTaskFn: func(ctx context.Context, id influxdb.ID, revision int, restoreStatus bool) (*influxdb.Task, error) {
+			return nil, nil
+		},
 	}
 }
 
@@ -129,14 +166,51 @@ func (s *TaskService) ForceRun(ctx context.Context, taskID influxdb.ID, schedule
 	return s.ForceRunFn(ctx, taskID, scheduledFor)
 }
 
+func (s *TaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	defer s.BackfillTaskCalls.IncrFn()()
+	return s.BackfillTaskFn(ctx, taskID, bc)
+}
+
+func (s *TaskService) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error) {
+	defer s.FindBackfillsCalls.IncrFn()()
+	return s.FindBackfillsFn(ctx, taskID)
+}
+
+func (s *TaskService) FindBackfillByID(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	defer s.FindBackfillByIDCalls.IncrFn()()
+	return s.FindBackfillByIDFn(ctx, taskID, id)
+}
+
+func (s *TaskService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	defer s.CancelBackfillCalls.IncrFn()()
+	return s.CancelBackfillFn(ctx, taskID, id)
+}
+
+func (s *TaskService) FindTaskRevisions(ctx context.Context, taskID influxdb.ID) ([]*influxdb.TaskRevision, int, error) {
+	defer s.FindTaskRevisionsCalls.IncrFn()()
+	return s.FindTaskRevisionsFn(ctx, taskID)
+}
+
+func (s *TaskService) FindTaskRevision(ctx context.Context, taskID influxdb.ID, revision int) (*influxdb.TaskRevision, error) {
+	defer s.FindTaskRevisionCalls.IncrFn()()
+	return s.FindTaskRevisionFn(ctx, taskID, revision)
+}
+
+func (s *TaskService) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int, restoreStatus bool) (*influxdb.Task, error) {
+	defer s.RollbackTaskCalls.IncrFn()()
+	return s.RollbackTaskFn(ctx, taskID, revision, restoreStatus)
+}
+
 type TaskControlService struct {
 	CreateRunFn        func(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error)
 	CurrentlyRunningFn func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
 	ManualRunsFn       func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
 	StartManualRunFn   func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
+	QueueRetryFn       func(ctx context.Context, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error)
 	FinishRunFn        func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
 	UpdateRunStateFn   func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, state influxdb.RunStatus) error
 	AddRunLogFn        func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+	UpdateBackfillFn   func(ctx context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error)
 }
 
 func (tcs *TaskControlService) CreateRun(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error) {
@@ -151,6 +225,9 @@ func (tcs *TaskControlService) ManualRuns(ctx context.Context, taskID influxdb.I
 func (tcs *TaskControlService) StartManualRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	return tcs.StartManualRunFn(ctx, taskID, runID)
 }
+func (tcs *TaskControlService) QueueRetry(ctx context.Context, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error) {
+	return tcs.QueueRetryFn(ctx, taskID, runID, runAt)
+}
 func (tcs *TaskControlService) FinishRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	return tcs.FinishRunFn(ctx, taskID, runID)
 }
@@ -160,3 +237,6 @@ func (tcs *TaskControlService) UpdateRunState(ctx context.Context, taskID, runID
 func (tcs *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error {
 	return tcs.AddRunLogFn(ctx, taskID, runID, when, log)
 }
+func (tcs *TaskControlService) UpdateBackfill(ctx context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error) {
+	return tcs.UpdateBackfillFn(ctx, taskID, id, upd)
+}
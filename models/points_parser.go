@@ -29,6 +29,10 @@ var (
 type ParserStats struct {
 	// BytesN reports the number of bytes allocated to parse the request.
 	BytesN int
+
+	// Lines reports the line of the request, starting at 1, each parsed point
+	// was parsed from.
+	Lines []int
 }
 
 type ParserOption func(*pointsParser)
@@ -125,13 +129,23 @@ func (pp *pointsParser) parsePoints(buf []byte) (err error) {
 	}
 
 	pp.points = make([]Point, 0, lineCount+1)
+	if pp.stats != nil {
+		pp.stats.Lines = pp.stats.Lines[:0]
+	}
 
 	var (
 		pos    int
 		block  []byte
 		failed []string
+		line   = 1
+		lineN  int // bytes of buf counted in line
 	)
 	for pos < len(buf) && pp.state == parserStateOK {
+		// Lines are counted from the newlines in buf rather than from the
+		// blocks, as quoted field values may contain newlines.
+		line += bytes.Count(buf[lineN:pos], []byte{'\n'})
+		lineN = pos
+
 		pos, block = scanLine(buf, pos)
 		pos++
 
@@ -157,6 +171,11 @@ func (pp *pointsParser) parsePoints(buf []byte) (err error) {
 		}
 
 		err = pp.parsePointsAppend(block[start:])
+		if pp.stats != nil {
+			for len(pp.stats.Lines) < len(pp.points) {
+				pp.stats.Lines = append(pp.stats.Lines, line)
+			}
+		}
 		if err != nil {
 			if errors.Is(err, errLimit) {
 				break
@@ -343,6 +362,10 @@ func truncateTimeWithPrecision(t time.Time, precision string) time.Time {
 		return t.Truncate(time.Millisecond)
 	case "s":
 		return t.Truncate(time.Second)
+	case "m":
+		return t.Truncate(time.Minute)
+	case "h":
+		return t.Truncate(time.Hour)
 	default:
 		return t
 	}
@@ -2065,6 +2065,18 @@ func TestParsePointsWithPrecision(t *testing.T) {
 			precision: "s",
 			exp:       "mm,\x00=cpu,host=serverA,region=us-east,\xff=value value=1.0 946730096000000000",
 		},
+		{
+			name:      "minute",
+			line:      `cpu,host=serverA,region=us-east value=1.0 15778834`,
+			precision: "m",
+			exp:       "mm,\x00=cpu,host=serverA,region=us-east,\xff=value value=1.0 946730040000000000",
+		},
+		{
+			name:      "hour",
+			line:      `cpu,host=serverA,region=us-east value=1.0 262980`,
+			precision: "h",
+			exp:       "mm,\x00=cpu,host=serverA,region=us-east,\xff=value value=1.0 946728000000000000",
+		},
 	}
 	for _, test := range tests {
 		pts, err := models.ParsePointsWithPrecision([]byte(test.line), []byte("mm"), time.Now().UTC(), test.precision)
@@ -2546,6 +2558,24 @@ func TestParsePointsWithOptions(t *testing.T) {
 	}
 }
 
+func TestParsePointsWithOptions_LinesStats(t *testing.T) {
+	buf := []byte("# comment\ncpu value=1,other=2 1\n\nmem value=\"a\nb\" 1\n  \ndisk value=3 1")
+	encoded := EncodeName(ID(1000), ID(2000))
+	mm := models.EscapeMeasurement(encoded[:])
+
+	var stats models.ParserStats
+	points, err := models.ParsePointsWithOptions(buf, mm, models.WithParserStats(&stats))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != len(stats.Lines) {
+		t.Fatalf("unexpected number of lines: got %d, exp %d", len(stats.Lines), len(points))
+	}
+	if exp := []int{2, 2, 4, 7}; !cmp.Equal(stats.Lines, exp) {
+		t.Errorf("unexpected lines; -got/+exp\n%s", cmp.Diff(stats.Lines, exp))
+	}
+}
+
 func TestNewPointsWithBytesWithCorruptData(t *testing.T) {
 	corrupted := []byte{0, 0, 0, 3, 102, 111, 111, 0, 0, 0, 4, 61, 34, 65, 34, 1, 0, 0, 0, 14, 206, 86, 119, 24, 32, 72, 233, 168, 2, 148}
 	p, err := models.NewPointFromBytes(corrupted)
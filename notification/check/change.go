@@ -0,0 +1,293 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/flux"
+)
+
+var _ influxdb.Check = (*Change)(nil)
+
+// Change types of the change check.
+const (
+	// ChangeAbsolute is the difference between the current and prior values.
+	ChangeAbsolute = "absolute"
+	// ChangePercent is the difference between the current and prior values,
+	// as a percentage of the prior value.
+	ChangePercent = "percent"
+	// ChangeDerivative is the difference between the current and prior
+	// values, per unit of time.
+	ChangeDerivative = "derivative"
+)
+
+// Change is the change check. It compares the aggregated value of the
+// latest interval of the check with the aggregated value a window before,
+// and applies its thresholds to the change between the two.
+type Change struct {
+	Base
+	// Window is how long before the latest interval the prior value is taken.
+	Window *notification.Duration `json:"window,omitempty"`
+	// ChangeType is how the change is measured, one of absolute, percent or
+	// derivative.
+	ChangeType string `json:"changeType"`
+	// Unit is the unit of time of a derivative change.
+	Unit       *notification.Duration `json:"unit,omitempty"`
+	Thresholds []ThresholdConfig      `json:"thresholds"`
+}
+
+// Type returns the type of the check.
+func (c Change) Type() string {
+	return "change"
+}
+
+// Valid returns error if something is invalid.
+func (c Change) Valid() error {
+	if err := c.Base.Valid(); err != nil {
+		return err
+	}
+	if c.Window == nil || len(c.Window.Values) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Change check Window can't be empty",
+		}
+	}
+	switch c.ChangeType {
+	case ChangeAbsolute, ChangePercent:
+	case ChangeDerivative:
+		if c.Unit == nil || len(c.Unit.Values) == 0 {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Change check Unit can't be empty for a derivative change",
+			}
+		}
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid change type %q, expected one of %s, %s or %s", c.ChangeType, ChangeAbsolute, ChangePercent, ChangeDerivative),
+		}
+	}
+	for _, cc := range c.Thresholds {
+		if err := cc.Valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type changeDecode struct {
+	Base
+	Window     *notification.Duration  `json:"window"`
+	ChangeType string                  `json:"changeType"`
+	Unit       *notification.Duration  `json:"unit"`
+	Thresholds []thresholdConfigDecode `json:"thresholds"`
+}
+
+// UnmarshalJSON implement json.Unmarshaler interface.
+func (c *Change) UnmarshalJSON(b []byte) error {
+	raw := new(changeDecode)
+	if err := json.Unmarshal(b, raw); err != nil {
+		return err
+	}
+	thresholds, err := decodeThresholdConfigs(raw.Thresholds)
+	if err != nil {
+		return err
+	}
+	c.Base = raw.Base
+	c.Window = raw.Window
+	c.ChangeType = raw.ChangeType
+	c.Unit = raw.Unit
+	c.Thresholds = thresholds
+	return nil
+}
+
+type changeAlias Change
+
+// MarshalJSON implement json.Marshaler interface.
+func (c Change) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			changeAlias
+			Type string `json:"type"`
+		}{
+			changeAlias: changeAlias(c),
+			Type:        c.Type(),
+		})
+}
+
+// GenerateFlux returns a flux script for the change check provided.
+func (c Change) GenerateFlux() (string, error) {
+	p, err := c.GenerateFluxAST()
+	if err != nil {
+		return "", err
+	}
+
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST returns a flux AST for the change check provided. If there
+// are any errors in the flux that the user provided the function will return
+// an error for each error found when the script is parsed.
+func (c Change) GenerateFluxAST() (*ast.Package, error) {
+	if c.Every == nil || c.Window == nil {
+		return nil, fmt.Errorf("change check requires an every and a window")
+	}
+
+	p := parser.ParseSource(c.Query.Text)
+	c.replaceDurations(p)
+	removeStopFromRange(p)
+	addCreateEmptyFalseToAggregateWindow(p)
+
+	if errs := ast.GetErrors(p); len(errs) != 0 {
+		return nil, multiError(errs)
+	}
+
+	if len(p.Files) != 1 {
+		return nil, fmt.Errorf("expect a single file to be returned from query parsing got %d", len(p.Files))
+	}
+
+	fields := getFields(p)
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("expected a single field but got: %s", fields)
+	}
+
+	f := p.Files[0]
+	assignPipelineToData(f)
+
+	f.Imports = append(f.Imports, flux.Imports("date", "experimental", "influxdata/influxdb/monitor")...)
+	f.Body = append(f.Body, c.generateFluxASTBody()...)
+
+	return p, nil
+}
+
+// replaceDurations makes the query range span the window and the latest
+// interval, aggregated every interval. The start of the range is truncated to
+// the interval, so that its first aggregate window is not partial.
+func (c Change) replaceDurations(pkg *ast.Package) {
+	ast.Visit(pkg, func(n ast.Node) {
+		if e, ok := n.(*ast.Property); ok {
+			switch e.Key.Key() {
+			case "start":
+				span := &ast.DurationLiteral{}
+				span.Values = append(span.Values, c.Window.Values...)
+				span.Values = append(span.Values, c.Every.Values...)
+				every := (ast.DurationLiteral)(*c.Every)
+				e.Value = flux.Call(flux.Member("date", "truncate"), flux.Object(
+					flux.Property("t", flux.Call(flux.Member("experimental", "subDuration"), flux.Object(
+						flux.Property("d", span),
+						flux.Property("from", flux.Call(flux.Identifier("now"), flux.Object())),
+					))),
+					flux.Property("unit", &every),
+				))
+			case "every":
+				every := (ast.DurationLiteral)(*c.Every)
+				e.Value = &every
+			}
+		}
+	})
+}
+
+func (c Change) generateFluxASTBody() []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, c.generateTaskOption())
+	statements = append(statements, c.generateFluxASTCheckDefinition("change"))
+	// The thresholds apply to the change, which replaces the _value.
+	for _, t := range c.Thresholds {
+		statements = append(statements, t.generateFluxASTThresholdFunction("_value"))
+	}
+	statements = append(statements, c.generateFluxASTMessageFunction())
+	return append(statements, c.generateFluxASTChecksFunction())
+}
+
+// generateFluxASTChecksFunction pivots the first and last aggregated values
+// of each series into the _prior and _current columns, timed at the stop of
+// the range, and checks the change between them. A percent change of a prior
+// value of zero is undefined, so those series are not checked.
+func (c Change) generateFluxASTChecksFunction() ast.Statement {
+	marked := func(fn, change string) ast.Expression {
+		return flux.Pipe(
+			flux.Identifier("data"),
+			flux.Call(flux.Identifier(fn), flux.Object()),
+			flux.Call(flux.Identifier("set"), flux.Object(
+				flux.Property("key", flux.String("_change")),
+				flux.Property("value", flux.String(change)),
+			)),
+		)
+	}
+	calls := []*ast.CallExpression{
+		flux.Call(flux.Identifier("map"), flux.Object(
+			flux.Property("fn", flux.Function(flux.FunctionParams("r"),
+				flux.ObjectWith("r", flux.Property("_time", flux.Member("r", "_stop"))),
+			)),
+		)),
+		flux.Call(flux.Identifier("pivot"), flux.Object(
+			flux.Property("rowKey", flux.Array(flux.String("_time"))),
+			flux.Property("columnKey", flux.Array(flux.String("_change"))),
+			flux.Property("valueColumn", flux.String("_value")),
+		)),
+		flux.Call(flux.Identifier("drop"), flux.Object(
+			flux.Property("columns", flux.Array(flux.String("_field"))),
+		)),
+	}
+	if c.ChangeType == ChangePercent {
+		calls = append(calls, flux.Call(flux.Identifier("filter"), flux.Object(
+			flux.Property("fn", flux.Function(flux.FunctionParams("r"),
+				&ast.BinaryExpression{
+					Operator: ast.NotEqualOperator,
+					Left:     flux.Call(flux.Identifier("float"), flux.Object(flux.Property("v", flux.Member("r", "_prior")))),
+					Right:    flux.Float(0),
+				},
+			)),
+		)))
+	}
+	calls = append(calls,
+		flux.Call(flux.Identifier("map"), flux.Object(
+			flux.Property("fn", flux.Function(flux.FunctionParams("r"),
+				flux.ObjectWith("r", flux.Property("_value", c.generateFluxASTChange())),
+			)),
+		)),
+		c.generateFluxASTChecksCall(),
+	)
+	return flux.ExpressionStatement(flux.Pipe(
+		flux.Call(flux.Identifier("union"), flux.Object(
+			flux.Property("tables", flux.Array(marked("first", "_prior"), marked("last", "_current"))),
+		)),
+		calls...,
+	))
+}
+
+// generateFluxASTChange returns the change of the record r, as a float.
+func (c Change) generateFluxASTChange() ast.Expression {
+	float := func(col string) ast.Expression {
+		return flux.Call(flux.Identifier("float"), flux.Object(flux.Property("v", flux.Member("r", col))))
+	}
+	diff := flux.Subtract(float("_current"), float("_prior"))
+	switch c.ChangeType {
+	case ChangePercent:
+		return flux.Multiply(flux.Divide(diff, float("_prior")), flux.Float(100))
+	case ChangeDerivative:
+		// the prior value is a window before the current one.
+		perUnit := float64(c.Unit.TimeDuration()) / float64(c.Window.TimeDuration())
+		return flux.Multiply(diff, flux.Float(perUnit))
+	default:
+		return diff
+	}
+}
+
+func (c Change) generateFluxASTChecksCall() *ast.CallExpression {
+	objectProps := append(([]*ast.Property)(nil), flux.Property("data", flux.Identifier("check")))
+	objectProps = append(objectProps, flux.Property("messageFn", flux.Identifier("messageFn")))
+
+	// This assumes that the ThresholdConfigs we've been provided do not have duplicates.
+	for _, t := range c.Thresholds {
+		lvl := strings.ToLower(t.GetLevel().String())
+		objectProps = append(objectProps, flux.Property(lvl, flux.Identifier(lvl)))
+	}
+
+	return flux.Call(flux.Member("monitor", "check"), flux.Object(objectProps...))
+}
@@ -0,0 +1,119 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChange_GenerateFlux(t *testing.T) {
+	base := check.Base{
+		ID:                    10,
+		Name:                  "disk",
+		Tags:                  []influxdb.Tag{{Key: "aaa", Value: "vaaa"}},
+		Every:                 mustDuration("5m"),
+		StatusMessageTemplate: "disk usage changed by ${r._value}",
+		Query: influxdb.DashboardQuery{
+			Text: `from(bucket: "foo") |> range(start: -1d, stop: now()) |> filter(fn: (r) => r._field == "used_percent") |> aggregateWindow(every: 1m, fn: mean) |> yield()`,
+		},
+	}
+	thresholds := []check.ThresholdConfig{
+		check.Greater{
+			ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical},
+			Value:               10,
+		},
+		check.Greater{
+			ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn},
+			Value:               5,
+		},
+	}
+
+	t.Run("percent", func(t *testing.T) {
+		c := check.Change{
+			Base:       base,
+			Window:     mustDuration("1h"),
+			ChangeType: check.ChangePercent,
+			Thresholds: thresholds,
+		}
+
+		s, err := c.GenerateFlux()
+		assert.NoError(t, err)
+		assert.Equal(t, `package main
+import "date"
+import "experimental"
+import "influxdata/influxdb/monitor"
+
+data = from(bucket: "foo")
+	|> range(start: date.truncate(t: experimental.subDuration(d: 1h5m, from: now()), unit: 5m))
+	|> filter(fn: (r) =>
+		(r._field == "used_percent"))
+	|> aggregateWindow(every: 5m, fn: mean, createEmpty: false)
+
+option task = {name: "disk", every: 5m}
+
+check = {
+	_check_id: "000000000000000a",
+	_check_name: "disk",
+	_type: "change",
+	tags: {aaa: "vaaa"},
+}
+crit = (r) =>
+	(r._value > 10.0)
+warn = (r) =>
+	(r._value > 5.0)
+messageFn = (r) =>
+	("disk usage changed by ${r._value}")
+
+union(tables: [data
+	|> first()
+	|> set(key: "_change", value: "_prior"), data
+	|> last()
+	|> set(key: "_change", value: "_current")])
+	|> map(fn: (r) =>
+		({r with _time: r._stop}))
+	|> pivot(rowKey: ["_time"], columnKey: ["_change"], valueColumn: "_value")
+	|> drop(columns: ["_field"])
+	|> filter(fn: (r) =>
+		(float(v: r._prior) != 0.0))
+	|> map(fn: (r) =>
+		({r with _value: (float(v: r._current) - float(v: r._prior)) / float(v: r._prior) * 100.0}))
+	|> monitor.check(
+		data: check,
+		messageFn: messageFn,
+		crit: crit,
+		warn: warn,
+	)`, s)
+	})
+
+	t.Run("derivative", func(t *testing.T) {
+		c := check.Change{
+			Base:       base,
+			Window:     mustDuration("2h"),
+			ChangeType: check.ChangeDerivative,
+			Unit:       mustDuration("1h"),
+			Thresholds: thresholds,
+		}
+
+		s, err := c.GenerateFlux()
+		assert.NoError(t, err)
+		assert.Contains(t, s, "|> range(start: date.truncate(t: experimental.subDuration(d: 2h5m, from: now()), unit: 5m))")
+		assert.NotContains(t, s, "r._prior) != 0.0")
+		assert.Contains(t, s, "({r with _value: (float(v: r._current) - float(v: r._prior)) * 0.5}))")
+	})
+
+	t.Run("absolute", func(t *testing.T) {
+		c := check.Change{
+			Base:       base,
+			Window:     mustDuration("1h"),
+			ChangeType: check.ChangeAbsolute,
+			Thresholds: thresholds,
+		}
+
+		s, err := c.GenerateFlux()
+		assert.NoError(t, err)
+		assert.Contains(t, s, "({r with _value: float(v: r._current) - float(v: r._prior)}))")
+	})
+}
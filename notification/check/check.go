@@ -205,6 +205,7 @@ var typeToCheck = map[string](func() influxdb.Check){
 	"deadman":   func() influxdb.Check { return &Deadman{} },
 	"threshold": func() influxdb.Check { return &Threshold{} },
 	"custom":    func() influxdb.Check { return &Custom{} },
+	"change":    func() influxdb.Check { return &Change{} },
 }
 
 // UnmarshalJSON will convert
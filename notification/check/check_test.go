@@ -154,6 +154,41 @@ func TestValidCheck(t *testing.T) {
 				Msg:  "range threshold min can't be larger than max",
 			},
 		},
+		{
+			name: "change without window",
+			src: &check.Change{
+				Base:       goodBase,
+				ChangeType: check.ChangeAbsolute,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Change check Window can't be empty",
+			},
+		},
+		{
+			name: "bad change type",
+			src: &check.Change{
+				Base:       goodBase,
+				Window:     mustDuration("1h"),
+				ChangeType: "ratio",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `invalid change type "ratio", expected one of absolute, percent or derivative`,
+			},
+		},
+		{
+			name: "derivative change without unit",
+			src: &check.Change{
+				Base:       goodBase,
+				Window:     mustDuration("1h"),
+				ChangeType: check.ChangeDerivative,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Change check Unit can't be empty for a derivative change",
+			},
+		},
 	}
 	for _, c := range cases {
 		got := c.src.Valid()
@@ -264,6 +299,43 @@ func TestJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "simple change",
+			src: &check.Change{
+				Base: check.Base{
+					ID:      influxTesting.MustIDBase16(id1),
+					Name:    "name1",
+					OwnerID: influxTesting.MustIDBase16(id2),
+					OrgID:   influxTesting.MustIDBase16(id3),
+					Every:   mustDuration("1h"),
+					Query: influxdb.DashboardQuery{
+						BuilderConfig: influxdb.BuilderConfig{
+							Buckets: []string{},
+							Tags: []struct {
+								Key                   string   `json:"key"`
+								Values                []string `json:"values"`
+								AggregateFunctionType string   `json:"aggregateFunctionType"`
+							}{},
+							Functions: []struct {
+								Name string `json:"name"`
+							}{},
+						},
+					},
+					Tags: []influxdb.Tag{},
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Window:     mustDuration("1d"),
+				ChangeType: check.ChangeDerivative,
+				Unit:       mustDuration("1h"),
+				Thresholds: []check.ThresholdConfig{
+					&check.Greater{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn}, Value: 5},
+					&check.Range{ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical}, Min: -10, Max: 10},
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		fn := func(t *testing.T) {
@@ -12,12 +12,14 @@ const (
 	SlackType     = "slack"
 	PagerDutyType = "pagerduty"
 	HTTPType      = "http"
+	SMTPType      = "smtp"
 )
 
 var typeToEndpoint = map[string](func() influxdb.NotificationEndpoint){
 	SlackType:     func() influxdb.NotificationEndpoint { return &Slack{} },
 	PagerDutyType: func() influxdb.NotificationEndpoint { return &PagerDuty{} },
 	HTTPType:      func() influxdb.NotificationEndpoint { return &HTTP{} },
+	SMTPType:      func() influxdb.NotificationEndpoint { return &SMTP{} },
 }
 
 // UnmarshalJSON will convert the bytes to notification endpoint.
@@ -136,6 +136,93 @@ func TestValidEndpoint(t *testing.T) {
 				Msg:  "invalid http username/password for basic auth",
 			},
 		},
+		{
+			name: "invalid http header",
+			src: &endpoint.HTTP{
+				Base:       goodBase,
+				URL:        "localhost",
+				Method:     http.MethodPost,
+				AuthMethod: "none",
+				Headers: map[string]string{
+					"X-Source": "influxdb\r\nX-Other: value",
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `invalid http header "X-Source"`,
+			},
+		},
+		{
+			name: "empty http secret header",
+			src: &endpoint.HTTP{
+				Base:       goodBase,
+				URL:        "localhost",
+				Method:     http.MethodPost,
+				AuthMethod: "none",
+				SecretHeaders: map[string]influxdb.SecretField{
+					"X-Api-Key": {},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `invalid http secret for header "X-Api-Key"`,
+			},
+		},
+		{
+			name: "empty smtp host",
+			src: &endpoint.SMTP{
+				Base: goodBase,
+				Port: 587,
+				From: "alerts@example.com",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "smtp endpoint host is empty",
+			},
+		},
+		{
+			name: "invalid smtp tls mode",
+			src: &endpoint.SMTP{
+				Base:    goodBase,
+				Host:    "smtp.example.com",
+				Port:    587,
+				TLSMode: "ssl",
+				From:    "alerts@example.com",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid smtp tls mode, expected none, starttls or tls",
+			},
+		},
+		{
+			name: "empty smtp password",
+			src: &endpoint.SMTP{
+				Base:     goodBase,
+				Host:     "smtp.example.com",
+				Port:     587,
+				TLSMode:  "starttls",
+				Username: "alerts",
+				From:     "alerts@example.com",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid smtp password for username",
+			},
+		},
+		{
+			name: "invalid smtp from address",
+			src: &endpoint.SMTP{
+				Base:    goodBase,
+				Host:    "smtp.example.com",
+				Port:    587,
+				TLSMode: "starttls",
+				From:    "alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "smtp endpoint from address is invalid: mail: missing '@' or angle-addr",
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -220,10 +307,35 @@ func TestJSON(t *testing.T) {
 					"x-header-1": "header 1",
 					"x-header-2": "header 2",
 				},
-				AuthMethod: "basic",
-				URL:        "http://example.com",
-				Username:   influxdb.SecretField{Key: "username-key"},
-				Password:   influxdb.SecretField{Key: "password-key"},
+				SecretHeaders: map[string]influxdb.SecretField{
+					"x-api-key": {Key: "api-key"},
+				},
+				AuthMethod:      "basic",
+				URL:             "http://example.com",
+				Username:        influxdb.SecretField{Key: "username-key"},
+				Password:        influxdb.SecretField{Key: "password-key"},
+				ContentTemplate: "${r._check_name} is ${r._level}",
+			},
+		},
+		{
+			name: "simple smtp",
+			src: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Host:     "smtp.example.com",
+				Port:     587,
+				TLSMode:  "starttls",
+				Username: "alerts",
+				Password: influxdb.SecretField{Key: "password-key"},
+				From:     "InfluxDB <alerts@example.com>",
 			},
 		},
 	}
@@ -365,6 +477,74 @@ func TestBackFill(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "http with secret headers",
+			src: &endpoint.HTTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+				},
+				AuthMethod: "none",
+				URL:        "http://example.com",
+				SecretHeaders: map[string]influxdb.SecretField{
+					"X-Api-Key": {Value: strPtr("key1")},
+				},
+			},
+			target: &endpoint.HTTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+				},
+				AuthMethod: "none",
+				URL:        "http://example.com",
+				SecretHeaders: map[string]influxdb.SecretField{
+					"X-Api-Key": {
+						Key:   id1 + "-header-x-api-key",
+						Value: strPtr("key1"),
+					},
+				},
+			},
+		},
+		{
+			name: "smtp with password",
+			src: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+				},
+				Host:     "smtp.example.com",
+				Port:     465,
+				TLSMode:  "tls",
+				Username: "alerts",
+				Password: influxdb.SecretField{
+					Value: strPtr("password1"),
+				},
+				From: "alerts@example.com",
+			},
+			target: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+				},
+				Host:     "smtp.example.com",
+				Port:     465,
+				TLSMode:  "tls",
+				Username: "alerts",
+				Password: influxdb.SecretField{
+					Key:   id1 + "-password",
+					Value: strPtr("password1"),
+				},
+				From: "alerts@example.com",
+			},
+		},
 	}
 	for _, c := range cases {
 		c.src.BackfillSecretKeys()
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/influxdata/influxdb"
 )
@@ -16,6 +18,7 @@ const (
 	httpTokenSuffix    = "-token"
 	httpUsernameSuffix = "-username"
 	httpPasswordSuffix = "-password"
+	httpHeaderInfix    = "-header-"
 )
 
 // HTTP is the notification endpoint config of http.
@@ -23,14 +26,22 @@ type HTTP struct {
 	Base
 	// Path is the API path of HTTP
 	URL string `json:"url"`
+	// Headers are added to the requests, they override the default Content-Type.
+	Headers map[string]string `json:"headers,omitempty"`
+	// SecretHeaders are added to the requests with values read from secrets,
+	// for API keys passed in headers.
+	SecretHeaders map[string]influxdb.SecretField `json:"secretHeaders,omitempty"`
 	// Token is the bearer token for authorization
-	Headers         map[string]string    `json:"headers,omitempty"`
-	Token           influxdb.SecretField `json:"token,omitempty"`
-	Username        influxdb.SecretField `json:"username,omitempty"`
-	Password        influxdb.SecretField `json:"password,omitempty"`
-	AuthMethod      string               `json:"authMethod"`
-	Method          string               `json:"method"`
-	ContentTemplate string               `json:"contentTemplate"`
+	Token      influxdb.SecretField `json:"token,omitempty"`
+	Username   influxdb.SecretField `json:"username,omitempty"`
+	Password   influxdb.SecretField `json:"password,omitempty"`
+	AuthMethod string               `json:"authMethod"`
+	Method     string               `json:"method"`
+	// ContentTemplate is the body of the requests, a flux string interpolating
+	// the fields of the status r, such as ${r._check_name}, ${r._level},
+	// ${r._message}, ${r.host} or ${string(v: r._time)}. The statuses are
+	// sent as JSON when it is empty.
+	ContentTemplate string `json:"contentTemplate"`
 }
 
 // BackfillSecretKeys fill back fill the secret field key during the unmarshalling
@@ -45,6 +56,22 @@ func (s *HTTP) BackfillSecretKeys() {
 	if s.Password.Key == "" && s.Password.Value != nil {
 		s.Password.Key = s.idStr() + httpPasswordSuffix
 	}
+	for name, secret := range s.SecretHeaders {
+		if secret.Key == "" && secret.Value != nil {
+			secret.Key = s.idStr() + httpHeaderInfix + strings.ToLower(name)
+			s.SecretHeaders[name] = secret
+		}
+	}
+}
+
+// SecretHeaderNames returns the names of the secret headers in order.
+func (s HTTP) SecretHeaderNames() []string {
+	names := make([]string, 0, len(s.SecretHeaders))
+	for name := range s.SecretHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // SecretFields return available secret fields.
@@ -59,6 +86,11 @@ func (s HTTP) SecretFields() []influxdb.SecretField {
 	if s.Password.Key != "" {
 		arr = append(arr, s.Password)
 	}
+	for _, name := range s.SecretHeaderNames() {
+		if secret := s.SecretHeaders[name]; secret.Key != "" {
+			arr = append(arr, secret)
+		}
+	}
 	return arr
 }
 
@@ -115,10 +147,52 @@ func (s HTTP) Valid() error {
 			Msg:  "invalid http token for bearer auth",
 		}
 	}
+	for name, value := range s.Headers {
+		if !validHTTPHeader(name, value) {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid http header %q", name),
+			}
+		}
+	}
+	for name, secret := range s.SecretHeaders {
+		if !validHTTPHeader(name, "") {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid http header %q", name),
+			}
+		}
+		if _, ok := s.Headers[name]; ok {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("http header %q is both a header and a secret header", name),
+			}
+		}
+		if secret.Key == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid http secret for header %q", name),
+			}
+		}
+	}
 
 	return nil
 }
 
+// validHTTPHeader reports whether a header has a token for name and a value
+// without line breaks, so that it cannot add other headers to the requests.
+func validHTTPHeader(name, value string) bool {
+	if name == "" || strings.ContainsAny(value, "\r\n") {
+		return false
+	}
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
 // MarshalJSON implement json.Marshaler interface.
 func (s HTTP) MarshalJSON() ([]byte, error) {
 	type httpAlias HTTP
@@ -0,0 +1,115 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpoint = &SMTP{}
+
+const smtpPasswordSuffix = "-password"
+
+// TLS modes of the connection to an SMTP server.
+const (
+	SMTPTLSNone     = "none"
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLS         = "tls"
+)
+
+var goodSMTPTLSMode = map[string]bool{
+	SMTPTLSNone:     true,
+	SMTPTLSStartTLS: true,
+	SMTPTLS:         true,
+}
+
+// SMTP is the notification endpoint config of an SMTP server sending email.
+type SMTP struct {
+	Base
+	// Host is the host name of the SMTP server
+	Host string `json:"host"`
+	// Port is the port of the SMTP server, usually 25, 465 or 587
+	Port int `json:"port"`
+	// TLSMode is how the connection is encrypted: none, starttls or tls
+	TLSMode string `json:"tlsMode"`
+	// Username authenticates with the server when not empty
+	Username string               `json:"username,omitempty"`
+	Password influxdb.SecretField `json:"password,omitempty"`
+	// From is the address the mails are sent from
+	From string `json:"from"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *SMTP) BackfillSecretKeys() {
+	if s.Password.Key == "" && s.Password.Value != nil {
+		s.Password.Key = s.idStr() + smtpPasswordSuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (s SMTP) SecretFields() []influxdb.SecretField {
+	arr := []influxdb.SecretField{}
+	if s.Password.Key != "" {
+		arr = append(arr, s.Password)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (s SMTP) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.Host == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp endpoint host is empty",
+		}
+	}
+	if s.Port <= 0 || s.Port > 65535 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp endpoint port %d is invalid", s.Port),
+		}
+	}
+	if !goodSMTPTLSMode[s.TLSMode] {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid smtp tls mode, expected none, starttls or tls",
+		}
+	}
+	if s.Username != "" && s.Password.Key == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid smtp password for username",
+		}
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp endpoint from address is invalid: %s", err.Error()),
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implement json.Marshaler interface.
+func (s SMTP) MarshalJSON() ([]byte, error) {
+	type smtpAlias SMTP
+	return json.Marshal(
+		struct {
+			smtpAlias
+			Type string `json:"type"`
+		}{
+			smtpAlias: smtpAlias(s),
+			Type:      s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s SMTP) Type() string {
+	return SMTPType
+}
@@ -0,0 +1,447 @@
+// Package escalation evaluates the escalation policies of the notification
+// rules. The statuses of the checks are read back from the monitoring bucket,
+// and the steps of a policy are notified from the server once the statuses
+// matched by its rule have persisted long enough at the level of the step.
+// The notifications are logged to the monitoring bucket like those of the
+// rules, and the statuses matched by an active silence are logged as not sent
+// instead of being notified. Acknowledging the escalation, or the incident of
+// its statuses, stops it.
+package escalation
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultInterval is how often the escalation policies are evaluated.
+	DefaultInterval = time.Minute
+
+	// lookback is how far before the longest delay of a policy the statuses
+	// are read, to find when they started to persist.
+	lookback = time.Hour
+
+	// maxStatuses is the most statuses read for a policy, the most recent
+	// first.
+	maxStatuses = 10000
+)
+
+// Notifier sends a notification, given as the columns of a status, to a
+// notification endpoint.
+type Notifier interface {
+	Send(ctx context.Context, edp influxdb.NotificationEndpoint, r map[string]string) error
+}
+
+// Evaluator evaluates the escalation policies and records the state of their
+// escalations.
+type Evaluator struct {
+	log       *zap.Logger
+	policies  influxdb.EscalationStore
+	rules     influxdb.NotificationRuleStore
+	endpoints influxdb.NotificationEndpointService
+	silences  influxdb.SilenceService
+	incidents influxdb.IncidentService
+	history   influxdb.AlertHistoryService
+	buckets   influxdb.BucketService
+	pw        storage.PointsWriter
+	notifier  Notifier
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewEvaluator returns a new evaluator of the escalation policies, evaluating
+// them every interval. The notifications are logged with pw to the monitoring
+// bucket of the organizations.
+func NewEvaluator(
+	log *zap.Logger,
+	policies influxdb.EscalationStore,
+	rules influxdb.NotificationRuleStore,
+	endpoints influxdb.NotificationEndpointService,
+	silences influxdb.SilenceService,
+	incidents influxdb.IncidentService,
+	history influxdb.AlertHistoryService,
+	buckets influxdb.BucketService,
+	pw storage.PointsWriter,
+	notifier Notifier,
+	interval time.Duration,
+) *Evaluator {
+	return &Evaluator{
+		log:       log,
+		policies:  policies,
+		rules:     rules,
+		endpoints: endpoints,
+		silences:  silences,
+		incidents: incidents,
+		history:   history,
+		buckets:   buckets,
+		pw:        pw,
+		notifier:  notifier,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run evaluates the escalation policies every interval until ctx is done.
+func (e *Evaluator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := e.Evaluate(ctx); err != nil {
+				e.log.Error("Failed to evaluate escalation policies", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Evaluate evaluates every escalation policy once. A policy that fails to
+// evaluate does not prevent the evaluation of the others.
+func (e *Evaluator) Evaluate(ctx context.Context) error {
+	ps, _, err := e.policies.FindEscalationPolicies(ctx, influxdb.EscalationPolicyFilter{})
+	if err != nil {
+		return err
+	}
+	now := e.now()
+	orgs := make(map[influxdb.ID]*orgState)
+	for _, p := range ps {
+		st, ok := orgs[p.OrgID]
+		if !ok {
+			if st, err = e.orgState(ctx, p.OrgID, now); err != nil {
+				e.log.Error("Failed to find silences and incidents", zap.Stringer("org_id", p.OrgID), zap.Error(err))
+				continue
+			}
+			orgs[p.OrgID] = st
+		}
+		if err := e.evaluatePolicy(ctx, p, st, now); err != nil {
+			e.log.Error("Failed to evaluate escalation policy", zap.Stringer("policy_id", p.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// orgState is what the policies of an organization are evaluated against.
+type orgState struct {
+	// silences are the silences active at the evaluation.
+	silences []*influxdb.SilenceMatcher
+	// acknowledged are the status keys of the acknowledged open incidents.
+	acknowledged map[string]bool
+}
+
+func (e *Evaluator) orgState(ctx context.Context, orgID influxdb.ID, now time.Time) (*orgState, error) {
+	ss, _, err := e.silences.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	st := &orgState{acknowledged: make(map[string]bool)}
+	for _, s := range ss {
+		if !s.ActiveAt(now) {
+			continue
+		}
+		m, err := influxdb.NewSilenceMatcher(s)
+		if err != nil {
+			return nil, err
+		}
+		st.silences = append(st.silences, m)
+	}
+
+	open, acknowledged := influxdb.IncidentOpen, true
+	is, _, err := e.incidents.FindIncidents(ctx, influxdb.IncidentFilter{
+		OrgID:        &orgID,
+		Status:       &open,
+		Acknowledged: &acknowledged,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range is {
+		st.acknowledged[i.Key] = true
+	}
+	return st, nil
+}
+
+// tagRuler is implemented by the notification rules, whose tag rules match
+// the statuses they notify.
+type tagRuler interface {
+	GetTagRules() []notification.TagRule
+}
+
+func (e *Evaluator) evaluatePolicy(ctx context.Context, p *influxdb.EscalationPolicy, st *orgState, now time.Time) error {
+	nr, err := e.rules.FindNotificationRuleByID(ctx, p.RuleID)
+	if err != nil {
+		return err
+	}
+
+	last := p.Steps[len(p.Steps)-1].Delay
+	maxDelay, err := last.DurationFrom(now)
+	if err != nil {
+		return err
+	}
+	filter := influxdb.AlertHistoryFilter{
+		OrgID: p.OrgID,
+		Start: now.Add(-(maxDelay + lookback)),
+		Stop:  now,
+	}
+	// the equal tag rules of the rule are read with the statuses, the others
+	// are matched when grouping them.
+	if tr, ok := nr.(tagRuler); ok {
+		for _, r := range tr.GetTagRules() {
+			if r.Operator == influxdb.Equal {
+				filter.Tags = append(filter.Tags, r.Tag)
+			}
+		}
+	}
+	statuses, n, err := e.history.FindCheckStatuses(ctx, filter, influxdb.FindOptions{Limit: maxStatuses})
+	if err != nil {
+		return err
+	}
+	if n > len(statuses) {
+		e.log.Warn("Too many statuses to escalate, the oldest are ignored", zap.Stringer("policy_id", p.ID), zap.Int("count", n), zap.Int("read", len(statuses)))
+	}
+
+	es, err := e.policies.FindEscalations(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	ongoing := make(map[string]*influxdb.Escalation, len(es))
+	for _, esc := range es {
+		ongoing[esc.Key] = esc
+	}
+
+	for key, s := range groupSeries(nr, statuses) {
+		esc := ongoing[key]
+		delete(ongoing, key)
+		if err := e.escalate(ctx, p, nr, s, esc, st, now); err != nil {
+			e.log.Error("Failed to escalate statuses", zap.Stringer("policy_id", p.ID), zap.String("key", key), zap.Error(err))
+		}
+	}
+
+	// The escalations without any recent status are over.
+	for key := range ongoing {
+		if err := e.policies.DeleteEscalation(ctx, p.ID, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escalate notifies the steps of the policy that the series s is due for,
+// and records its escalation esc, which is nil for a new escalation. The
+// steps due while a silence matches the series are notified once it ends, and
+// none are notified once the escalation or the incident of the series is
+// acknowledged.
+func (e *Evaluator) escalate(ctx context.Context, p *influxdb.EscalationPolicy, nr influxdb.NotificationRule, s series, esc *influxdb.Escalation, st *orgState, now time.Time) error {
+	latest := s.latest()
+	if influxdb.StatusLevelRank(latest.Level) <= 0 {
+		if esc == nil {
+			return nil
+		}
+		return e.policies.DeleteEscalation(ctx, p.ID, s.key)
+	}
+
+	since, bounded := s.persistedSince(1)
+	if esc == nil || (bounded && since.After(esc.Since)) {
+		// the check returned to ok since the escalation started.
+		esc = &influxdb.Escalation{
+			PolicyID:      p.ID,
+			Key:           s.key,
+			CheckID:       latest.CheckID,
+			Tags:          latest.Tags,
+			Since:         since,
+			NotifiedSteps: []int{},
+		}
+	}
+	esc.CheckName = latest.CheckName
+	esc.Level = latest.Level
+
+	for i, step := range p.Steps {
+		if esc.AcknowledgedAt != nil || st.acknowledged[s.key] {
+			break
+		}
+		if esc.Notified(i) {
+			continue
+		}
+
+		rank := 1
+		if step.Level != "" {
+			rank = influxdb.StatusLevelRank(step.Level)
+		}
+		if influxdb.StatusLevelRank(latest.Level) < rank {
+			continue
+		}
+		stepSince := esc.Since
+		if t, bounded := s.persistedSince(rank); bounded || rank > 1 {
+			stepSince = t
+		}
+		due, err := step.Delay.Add(stepSince)
+		if err != nil {
+			return err
+		}
+		if now.Before(due) {
+			continue
+		}
+
+		notified, err := e.notify(ctx, p, nr, i, latest, st.silences, now)
+		if err != nil {
+			// the step is notified again on the next evaluation.
+			e.log.Error("Failed to notify escalation step", zap.Stringer("policy_id", p.ID), zap.Int("step", i+1), zap.Error(err))
+			continue
+		}
+		if notified {
+			esc.NotifiedSteps = append(esc.NotifiedSteps, i)
+		}
+	}
+
+	esc.UpdatedAt = now
+	return e.policies.PutEscalation(ctx, esc)
+}
+
+// notify sends the notification of the step i of the policy for the status
+// st, unless a silence matches the status, and logs it to the monitoring
+// bucket. It reports whether the step was notified.
+func (e *Evaluator) notify(ctx context.Context, p *influxdb.EscalationPolicy, nr influxdb.NotificationRule, i int, st *influxdb.CheckStatus, silences []*influxdb.SilenceMatcher, now time.Time) (bool, error) {
+	step := p.Steps[i]
+	edp, err := e.endpoints.FindNotificationEndpointByID(ctx, step.EndpointID)
+	if err != nil {
+		return false, err
+	}
+	if edp.GetStatus() != influxdb.Active {
+		return true, nil
+	}
+
+	r := make(map[string]string, len(st.Tags)+12)
+	for k, v := range st.Tags {
+		r[k] = v
+	}
+	r["_check_id"] = st.CheckID.String()
+	r["_check_name"] = st.CheckName
+	r["_level"] = st.Level
+	r["_message"] = st.Message
+	r["_source_measurement"] = st.SourceMeasurement
+	r["_time"] = st.Time.UTC().Format(time.RFC3339)
+	r["_notification_rule_id"] = nr.GetID().String()
+	r["_notification_rule_name"] = nr.GetName()
+	r["_notification_endpoint_id"] = edp.GetID().String()
+	r["_notification_endpoint_name"] = edp.GetName()
+	r["_escalation_policy_id"] = p.ID.String()
+	r["_escalation_step"] = strconv.Itoa(i + 1)
+
+	for _, m := range silences {
+		if m.Matches(nr.GetID(), st.CheckID, st.Tags) {
+			return false, e.logNotification(ctx, p.OrgID, r, st, false, m.Silence.ID, now)
+		}
+	}
+
+	sendErr := e.notifier.Send(ctx, edp, r)
+	if err := e.logNotification(ctx, p.OrgID, r, st, sendErr == nil, 0, now); err != nil {
+		e.log.Error("Failed to log escalation notification", zap.Stringer("policy_id", p.ID), zap.Int("step", i+1), zap.Error(err))
+	}
+	if sendErr != nil {
+		return false, sendErr
+	}
+	e.log.Info("Notified escalation step",
+		zap.Stringer("policy_id", p.ID),
+		zap.Int("step", i+1),
+		zap.Stringer("endpoint_id", edp.GetID()),
+		zap.Stringer("check_id", st.CheckID),
+		zap.String("level", st.Level))
+	return true, nil
+}
+
+// logNotification writes the notification r of the status st to the
+// monitoring bucket of the organization, the way monitor.notify logs the
+// notifications of the rules: the columns of r are tags, but for _message.
+// Notifications suppressed by a silence have its _silence_id.
+func (e *Evaluator) logNotification(ctx context.Context, orgID influxdb.ID, r map[string]string, st *influxdb.CheckStatus, sent bool, silenceID influxdb.ID, now time.Time) error {
+	b, err := e.buckets.FindBucketByName(ctx, orgID, influxdb.MonitoringSystemBucketName)
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]string, len(r)+1)
+	for k, v := range r {
+		if k == "_message" || k == "_time" || v == "" {
+			continue
+		}
+		tags[k] = v
+	}
+	tags["_sent"] = strconv.FormatBool(sent)
+	fields := map[string]interface{}{
+		"_message":          r["_message"],
+		"_status_timestamp": st.Time.UnixNano(),
+	}
+	if silenceID.Valid() {
+		fields["_silence_id"] = silenceID.String()
+	}
+
+	pt, err := models.NewPoint("notifications", models.NewTags(tags), fields, now)
+	if err != nil {
+		return err
+	}
+	points, err := tsdb.ExplodePoints(orgID, b.ID, models.Points{pt})
+	if err != nil {
+		return err
+	}
+	return e.pw.WritePoints(ctx, points)
+}
+
+// series are the statuses of a check with the same tags, the oldest first.
+type series struct {
+	key      string
+	statuses []*influxdb.CheckStatus
+}
+
+func (s series) latest() *influxdb.CheckStatus {
+	return s.statuses[len(s.statuses)-1]
+}
+
+// persistedSince returns the time of the first of the latest statuses at a
+// level ranking rank or more. It is bounded when an earlier status ranks
+// lower, rather than when the statuses that were read start there.
+func (s series) persistedSince(rank int) (time.Time, bool) {
+	since := s.latest().Time
+	for i := len(s.statuses) - 1; i >= 0; i-- {
+		if influxdb.StatusLevelRank(s.statuses[i].Level) < rank {
+			return since, true
+		}
+		since = s.statuses[i].Time
+	}
+	return since, false
+}
+
+// groupSeries groups the statuses matched by the tag rules of the rule by
+// their escalation key.
+func groupSeries(nr influxdb.NotificationRule, statuses []*influxdb.CheckStatus) map[string]series {
+	ss := make(map[string]series)
+	for _, st := range statuses {
+		tags := make([]influxdb.Tag, 0, len(st.Tags))
+		for k, v := range st.Tags {
+			tags = append(tags, influxdb.Tag{Key: k, Value: v})
+		}
+		if !nr.MatchesTags(tags) {
+			continue
+		}
+		key := influxdb.StatusKey(st.CheckID, st.Tags)
+		s := ss[key]
+		s.key = key
+		s.statuses = append(s.statuses, st)
+		ss[key] = s
+	}
+	for _, s := range ss {
+		sort.SliceStable(s.statuses, func(i, j int) bool {
+			return s.statuses[i].Time.Before(s.statuses[j].Time)
+		})
+	}
+	return ss
+}
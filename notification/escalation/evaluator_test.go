@@ -0,0 +1,217 @@
+package escalation
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/task/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type notified struct {
+	endpoint string
+	level    string
+	step     string
+}
+
+type recordingNotifier struct {
+	sent []notified
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, edp influxdb.NotificationEndpoint, r map[string]string) error {
+	n.sent = append(n.sent, notified{endpoint: edp.GetName(), level: r["_level"], step: r["_escalation_step"]})
+	return nil
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	require.NoError(t, svc.Initialize(ctx))
+
+	user := &influxdb.User{Name: "user"}
+	require.NoError(t, svc.CreateUser(ctx, user))
+	org := &influxdb.Organization{Name: "org"}
+	require.NoError(t, svc.CreateOrganization(ctx, org))
+
+	newEndpoint := func(name string) influxdb.ID {
+		edp := &endpoint.Slack{
+			Base: endpoint.Base{OrgID: &org.ID, Name: name, Status: influxdb.Active},
+			URL:  "http://localhost:8080",
+		}
+		require.NoError(t, svc.CreateNotificationEndpoint(ctx, edp, user.ID))
+		return edp.GetID()
+	}
+	slack, pager, manager := newEndpoint("slack"), newEndpoint("pager"), newEndpoint("manager")
+
+	every, err := notification.FromTimeDuration(time.Minute)
+	require.NoError(t, err)
+	nr := &rule.Slack{
+		Base: rule.Base{
+			OrgID:       org.ID,
+			Name:        "cpu",
+			EndpointID:  slack,
+			Every:       &every,
+			StatusRules: []notification.StatusRule{{CurrentLevel: notification.Warn}},
+			TagRules:    []notification.TagRule{{Tag: influxdb.Tag{Key: "env", Value: "prod"}, Operator: influxdb.Equal}},
+		},
+		MessageTemplate: "cpu is ${r._level}",
+	}
+	require.NoError(t, svc.CreateNotificationRule(ctx, influxdb.NotificationRuleCreate{NotificationRule: nr, Status: influxdb.Active}, user.ID))
+
+	p := &influxdb.EscalationPolicy{
+		OrgID:  org.ID,
+		RuleID: nr.ID,
+		Name:   "cpu escalation",
+		Steps: []influxdb.EscalationStep{
+			{Delay: *options.MustParseDuration("0s"), Level: "warn", EndpointID: slack},
+			{Delay: *options.MustParseDuration("15m"), Level: "crit", EndpointID: pager},
+			{Delay: *options.MustParseDuration("45m"), Level: "crit", EndpointID: manager},
+		},
+	}
+	require.NoError(t, svc.CreateEscalationPolicy(ctx, p, user.ID))
+
+	now := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	tags := map[string]string{"env": "prod", "host": "web-1"}
+	var statuses []*influxdb.CheckStatus
+	addStatus := func(ago time.Duration, level string) {
+		statuses = append(statuses, &influxdb.CheckStatus{Time: now.Add(-ago), CheckID: 1, CheckName: "cpu", Level: level, Tags: tags})
+	}
+	addStatus(30*time.Minute, "ok")
+	addStatus(20*time.Minute, "warn")
+	addStatus(16*time.Minute, "crit")
+	addStatus(time.Minute, "crit")
+	// statuses that the tag rules of the rule do not match are not escalated.
+	statuses = append(statuses, &influxdb.CheckStatus{Time: now.Add(-time.Hour), CheckID: 1, Level: "crit", Tags: map[string]string{"env": "dev"}})
+
+	history := mock.NewAlertHistoryService()
+	history.FindCheckStatusesFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opts ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+		// the equal tag rules of the rule are read with the statuses.
+		assert.Equal(t, []influxdb.Tag{{Key: "env", Value: "prod"}}, f.Tags)
+		require.Len(t, opts, 1)
+		assert.Equal(t, maxStatuses, opts[0].Limit)
+		var ss []*influxdb.CheckStatus
+		for _, s := range statuses {
+			if !s.Time.Before(f.Start) && !s.Time.After(f.Stop) {
+				ss = append(ss, s)
+			}
+		}
+		return ss, len(ss), nil
+	}
+	notifier := &recordingNotifier{}
+	pw := &mock.PointsWriter{}
+
+	evaluate := func(at time.Time) []notified {
+		t.Helper()
+		notifier.sent = nil
+		e := NewEvaluator(zaptest.NewLogger(t), svc, svc, svc, svc, svc, history, svc, pw, notifier, time.Minute)
+		e.now = func() time.Time { return at }
+		require.NoError(t, e.Evaluate(ctx))
+		return notifier.sent
+	}
+	key := influxdb.StatusKey(1, tags)
+
+	// warn is notified at once, crit after 15 minutes.
+	assert.Equal(t, []notified{{"slack", "crit", "1"}, {"pager", "crit", "2"}}, evaluate(now))
+	assert.Empty(t, evaluate(now))
+
+	// the notifications are logged to the monitoring bucket, with a point by
+	// field.
+	logged := func(field string) []models.Point {
+		var pts []models.Point
+		for _, pt := range pw.Points {
+			fields, err := pt.Fields()
+			require.NoError(t, err)
+			if _, ok := fields[field]; ok {
+				pts = append(pts, pt)
+			}
+		}
+		return pts
+	}
+	require.Len(t, pw.Points, 4)
+	for i, pt := range logged("_status_timestamp") {
+		assert.Equal(t, "notifications", string(pt.Tags().Get(models.MeasurementTagKeyBytes)))
+		assert.Equal(t, "true", string(pt.Tags().Get([]byte("_sent"))))
+		assert.Equal(t, strconv.Itoa(i+1), string(pt.Tags().Get([]byte("_escalation_step"))))
+		assert.Equal(t, nr.ID.String(), string(pt.Tags().Get([]byte("_notification_rule_id"))))
+		assert.Equal(t, "web-1", string(pt.Tags().Get([]byte("host"))))
+	}
+
+	es, err := svc.FindEscalations(ctx, p.ID)
+	require.NoError(t, err)
+	require.Len(t, es, 1)
+	assert.Equal(t, key, es[0].Key)
+	assert.Equal(t, now.Add(-20*time.Minute), es[0].Since)
+	assert.Equal(t, []int{0, 1}, es[0].NotifiedSteps)
+
+	// an acknowledged escalation is not escalated further.
+	_, err = svc.AcknowledgeEscalation(ctx, p.ID, key, user.ID)
+	require.NoError(t, err)
+	addStatus(-29*time.Minute, "crit")
+	assert.Empty(t, evaluate(now.Add(30*time.Minute)))
+
+	// the escalation ends when the check returns to ok.
+	addStatus(-31*time.Minute, "ok")
+	assert.Empty(t, evaluate(now.Add(31*time.Minute)))
+	es, err = svc.FindEscalations(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Empty(t, es)
+
+	// a new escalation starts over.
+	addStatus(-32*time.Minute, "crit")
+	assert.Equal(t, []notified{{"slack", "crit", "1"}}, evaluate(now.Add(32*time.Minute)))
+
+	// the steps due while a silence matches the statuses are logged as not
+	// sent, and notified once it ends.
+	silence := &influxdb.Silence{
+		OrgID:     org.ID,
+		Name:      "maintenance",
+		StartTime: now.Add(70 * time.Minute),
+		EndTime:   now.Add(80 * time.Minute),
+		TagRules:  []influxdb.TagRule{{Tag: influxdb.Tag{Key: "host", Value: "web-.*"}, Operator: influxdb.RegexEqual}},
+	}
+	require.NoError(t, svc.CreateSilence(ctx, silence, user.ID))
+	addStatus(-77*time.Minute, "crit")
+	pw.Points = nil
+	assert.Empty(t, evaluate(now.Add(77*time.Minute)))
+	require.Len(t, pw.Points, 6)
+	require.Len(t, logged("_silence_id"), 2)
+	for _, pt := range logged("_silence_id") {
+		assert.Equal(t, "false", string(pt.Tags().Get([]byte("_sent"))))
+		fields, err := pt.Fields()
+		require.NoError(t, err)
+		assert.Equal(t, silence.ID.String(), fields["_silence_id"])
+	}
+
+	// an acknowledged incident of the statuses stops the escalation.
+	inc := &influxdb.Incident{
+		OrgID:    org.ID,
+		CheckID:  1,
+		Key:      key,
+		Status:   influxdb.IncidentOpen,
+		OpenedAt: now.Add(32 * time.Minute),
+	}
+	require.NoError(t, svc.PutIncident(ctx, inc))
+	_, err = svc.AcknowledgeIncident(ctx, inc.ID, user.ID)
+	require.NoError(t, err)
+	addStatus(-81*time.Minute, "crit")
+	assert.Empty(t, evaluate(now.Add(81*time.Minute)))
+
+	// the manager is paged when crit persisted for 45 minutes.
+	_, err = svc.UnacknowledgeIncident(ctx, inc.ID)
+	require.NoError(t, err)
+	addStatus(-82*time.Minute, "crit")
+	assert.Equal(t, []notified{{"pager", "crit", "2"}, {"manager", "crit", "3"}}, evaluate(now.Add(82*time.Minute)))
+}
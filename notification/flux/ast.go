@@ -1,6 +1,10 @@
 package flux
 
-import "github.com/influxdata/flux/ast"
+import (
+	"strings"
+
+	"github.com/influxdata/flux/ast"
+)
 
 // File creates a new *ast.File.
 func File(name string, imports []*ast.ImportDeclaration, body []ast.Statement) *ast.File {
@@ -56,6 +60,24 @@ func Add(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	}
 }
 
+// Multiply returns a multiplication *ast.BinaryExpression.
+func Multiply(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.MultiplicationOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
+// Divide returns a division *ast.BinaryExpression.
+func Divide(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.DivisionOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
 // Member returns an *ast.MemberExpression where the key is p and the values is c.
 func Member(p, c string) *ast.MemberExpression {
 	return &ast.MemberExpression{
@@ -153,6 +175,18 @@ func String(s string) *ast.StringLiteral {
 	}
 }
 
+// LiteralString returns an *ast.StringLiteral of s that is not interpolated.
+// Unlike the flux formatter, it escapes the dollar signs that would otherwise
+// start an interpolation.
+func LiteralString(s string) *ast.StringLiteral {
+	lit := String(s)
+	if strings.Contains(s, "${") {
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`)
+		lit.Loc = &ast.SourceLocation{Source: `"` + r.Replace(s) + `"`}
+	}
+	return lit
+}
+
 // Bool returns an *ast.BooleanLiteral of b.
 func Bool(b bool) *ast.BooleanLiteral {
 	return &ast.BooleanLiteral{
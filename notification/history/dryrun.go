@@ -0,0 +1,214 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/jsonweb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+var _ influxdb.CheckDryRunService = (*Service)(nil)
+
+// dryRunWrite replaces the write of the statuses to the monitoring bucket, so
+// that the statuses of a dry run are returned instead.
+const dryRunWrite = `option monitor.write = (tables=<-) => tables`
+
+// dryRunID stands for the id and the owner of a check definition that is not
+// created yet.
+const dryRunID = influxdb.ID(1)
+
+// DryRunCheck replays the runs of chk between start and stop, the earliest
+// statuses first. A check definition that is not created yet is given a
+// placeholder id and owner.
+func (s *Service) DryRunCheck(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	if !chk.GetID().Valid() {
+		chk.SetID(dryRunID)
+	}
+	if !chk.GetOwnerID().Valid() {
+		chk.SetOwnerID(dryRunID)
+	}
+	statuses, err := s.dryRun(ctx, chk, start, stop)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpDryRunCheck,
+			Err: err,
+		}
+	}
+	return statuses, nil
+}
+
+// DryRunCheckByID replays the runs of the check with id between start and
+// stop, the earliest statuses first.
+func (s *Service) DryRunCheckByID(ctx context.Context, id influxdb.ID, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	chk, err := s.cs.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpDryRunCheckByID,
+			Err: err,
+		}
+	}
+	statuses, err := s.dryRun(ctx, chk, start, stop)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpDryRunCheckByID,
+			Err: err,
+		}
+	}
+	return statuses, nil
+}
+
+func (s *Service) dryRun(ctx context.Context, chk influxdb.Check, start, stop time.Time) ([]*influxdb.CheckStatus, error) {
+	if err := chk.Valid(); err != nil {
+		return nil, err
+	}
+	start, stop = influxdb.AlertHistoryFilter{Start: start, Stop: stop}.TimeRange(s.now())
+	if !start.Before(stop) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "start must be before stop",
+		}
+	}
+
+	auth, err := readOnlyAuthorization(ctx, chk.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := chk.GenerateFlux()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+	runs, err := scheduledRuns(script, start, stop)
+	if err != nil {
+		return nil, err
+	}
+	if script, err = withoutWrite(script); err != nil {
+		return nil, err
+	}
+
+	var statuses []*influxdb.CheckStatus
+	for _, now := range runs {
+		req := &query.Request{
+			Authorization:  auth,
+			OrganizationID: chk.GetOrgID(),
+			Compiler:       lang.FluxCompiler{Query: script, Now: now},
+		}
+		if err := s.read(ctx, req, func(_ string, r record) {
+			if r.str("_measurement") != "statuses" {
+				return
+			}
+			// monitor.check times the statuses with the wall clock rather
+			// than with the now of the query.
+			st := newCheckStatus(r)
+			st.Time = now
+			statuses = append(statuses, st)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to run check at %s: %v", now.Format(time.RFC3339), err)
+		}
+	}
+	return statuses, nil
+}
+
+// scheduledRuns returns the times the task of the check script would have run
+// between start and stop.
+func scheduledRuns(script string, start, stop time.Time) ([]time.Time, error) {
+	opts, err := options.FromScript(script)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task options of the check",
+			Err:  err,
+		}
+	}
+	sched, t, err := scheduler.NewSchedule(opts.EffectiveCronString(), start)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid schedule of the check",
+			Err:  err,
+		}
+	}
+
+	var runs []time.Time
+	for {
+		if t, err = sched.Next(t); err != nil {
+			return nil, err
+		}
+		if t.After(stop) {
+			return runs, nil
+		}
+		if len(runs) == influxdb.CheckDryRunMaxRuns {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("the check would run more than %d times between start and stop, use a shorter time range", influxdb.CheckDryRunMaxRuns),
+			}
+		}
+		runs = append(runs, t)
+	}
+}
+
+// withoutWrite returns the check script with its statuses returned instead of
+// written to the monitoring bucket.
+func withoutWrite(script string) (string, error) {
+	p := parser.ParseSource(script)
+	if errs := ast.GetErrors(p); len(errs) != 0 {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid check query",
+			Err:  errs[0],
+		}
+	}
+	if len(p.Files) != 1 {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("expected a single file in the check query, got %d", len(p.Files)),
+		}
+	}
+
+	f := p.Files[0]
+	write := parser.ParseSource(dryRunWrite).Files[0].Body
+	f.Body = append(write, f.Body...)
+	return ast.Format(p), nil
+}
+
+// readOnlyAuthorization returns the read permissions of the authorizer of ctx,
+// so that a dry run cannot write anything.
+func readOnlyAuthorization(ctx context.Context, orgID influxdb.ID) (*influxdb.Authorization, error) {
+	a, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth *influxdb.Authorization
+	switch a := a.(type) {
+	case *influxdb.Authorization:
+		auth = a
+	case *influxdb.Session:
+		auth = a.EphemeralAuth(orgID)
+	case *jsonweb.Token:
+		auth = a.EphemeralAuth(orgID)
+	default:
+		return nil, influxdb.ErrAuthorizerNotSupported
+	}
+
+	ro := *auth
+	ro.Permissions = nil
+	for _, p := range auth.Permissions {
+		if p.Action == influxdb.ReadAction {
+			ro.Permissions = append(ro.Permissions, p)
+		}
+	}
+	return &ro, nil
+}
@@ -0,0 +1,149 @@
+package history_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/check"
+	"github.com/influxdata/influxdb/notification/history"
+	"github.com/influxdata/influxdb/query"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	qmock "github.com/influxdata/influxdb/query/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+const cpu = `
+#datatype,string,long,dateTime:RFC3339,string,string,string,double
+#group,false,false,false,true,true,true,false
+#default,_result,,,,,,
+,result,table,_time,_measurement,_field,host,_value
+,,0,2020-03-02T10:00:30Z,cpu,usage,web-1,10
+,,0,2020-03-02T10:01:30Z,cpu,usage,web-1,90
+,,0,2020-03-02T10:02:30Z,cpu,usage,web-1,50
+`
+
+func mustDuration(t *testing.T, d string) *notification.Duration {
+	t.Helper()
+	dur, err := parser.ParseDuration(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return (*notification.Duration)(dur)
+}
+
+func TestService_DryRunCheck(t *testing.T) {
+	var auths []*influxdb.Authorization
+	qs := &qmock.QueryService{
+		// runs the check over the cpu data instead of the storage.
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			auths = append(auths, req.Authorization)
+			ctx = executetest.NewTestExecuteDependencies().Inject(ctx)
+			p, err := req.Compiler.Compile(ctx)
+			if err != nil {
+				return nil, err
+			}
+			q, err := p.Start(ctx, &memory.Allocator{})
+			if err != nil {
+				return nil, err
+			}
+			return flux.NewResultIteratorFromQuery(q), nil
+		},
+	}
+	svc := history.NewService(zaptest.NewLogger(t), qs, mock.NewBucketService(), mock.NewCheckService())
+
+	chk := &check.Threshold{
+		Base: check.Base{
+			Name:  "cpu",
+			OrgID: orgID,
+			Every: mustDuration(t, "1m"),
+			Query: influxdb.DashboardQuery{
+				Text: "import \"csv\"\n" +
+					"csv.from(csv: \"" + cpu + "\")\n" +
+					"  |> range(start: -1m)\n" +
+					"  |> filter(fn: (r) => r._field == \"usage\")\n" +
+					"  |> aggregateWindow(every: 1m, fn: mean)\n",
+			},
+			StatusMessageTemplate: "cpu is ${r._level}",
+		},
+		Thresholds: []check.ThresholdConfig{
+			check.Greater{
+				ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Critical},
+				Value:               80,
+			},
+			check.Greater{
+				ThresholdConfigBase: check.ThresholdConfigBase{Level: notification.Warn},
+				Value:               40,
+			},
+		},
+	}
+
+	ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+		Status: influxdb.Active,
+		OrgID:  orgID,
+		Permissions: []influxdb.Permission{
+			{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType}},
+			{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType}},
+		},
+	})
+	start := time.Date(2020, time.March, 2, 10, 0, 0, 0, time.UTC)
+	statuses, err := svc.DryRunCheck(ctx, chk, start, start.Add(3*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.Time.Format("15:04")+" "+s.Level+" "+s.Message+" "+s.Tags["host"])
+	}
+	want := []string{
+		"10:01 ok cpu is ok web-1",
+		"10:02 crit cpu is crit web-1",
+		"10:03 warn cpu is warn web-1",
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected statuses -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	if len(auths) != 3 {
+		t.Fatalf("expected a query for each of the 3 runs, got %d", len(auths))
+	}
+	for _, p := range auths[0].Permissions {
+		if p.Action != influxdb.ReadAction {
+			t.Errorf("expected a read only authorization, got permission %s", p)
+		}
+	}
+}
+
+func TestService_DryRunCheck_TooManyRuns(t *testing.T) {
+	svc := history.NewService(zaptest.NewLogger(t), &qmock.QueryService{}, mock.NewBucketService(), mock.NewCheckService())
+	chk := &check.Deadman{
+		Base: check.Base{
+			Name:  "cpu",
+			OrgID: orgID,
+			Every: mustDuration(t, "1s"),
+			Query: influxdb.DashboardQuery{
+				Text: `from(bucket: "telegraf") |> range(start: -1m) |> filter(fn: (r) => r._field == "usage")`,
+			},
+		},
+		TimeSince: mustDuration(t, "1m"),
+		StaleTime: mustDuration(t, "2m"),
+		Level:     notification.Critical,
+	}
+
+	ctx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{Status: influxdb.Active, OrgID: orgID})
+	_, err := svc.DryRunCheck(ctx, chk, start, stop)
+	if code := influxdb.ErrorCode(err); code != influxdb.EInvalid || !strings.Contains(err.Error(), "more than 1440 times") {
+		t.Errorf("expected too many runs error, got %v", err)
+	}
+}
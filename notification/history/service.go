@@ -0,0 +1,362 @@
+// Package history reads the alert history that checks and notification rules
+// write to the monitoring system bucket: the statuses of the checks and the
+// notifications of the rules. It also replays checks over past data, to
+// backtest them without writing their statuses.
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	notificationflux "github.com/influxdata/influxdb/notification/flux"
+	"github.com/influxdata/influxdb/query"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.AlertHistoryService = (*Service)(nil)
+
+// The columns of the statuses and notifications in the monitoring bucket.
+const (
+	checkIDColumn           = "_check_id"
+	checkNameColumn         = "_check_name"
+	checkTypeColumn         = "_type"
+	levelColumn             = "_level"
+	messageColumn           = "_message"
+	sourceMeasurementColumn = "_source_measurement"
+	sourceTimestampColumn   = "_source_timestamp"
+	statusTimestampColumn   = "_status_timestamp"
+	ruleIDColumn            = "_notification_rule_id"
+	ruleNameColumn          = "_notification_rule_name"
+	endpointIDColumn        = "_notification_endpoint_id"
+	endpointNameColumn      = "_notification_endpoint_name"
+	sentColumn              = "_sent"
+	silenceIDColumn         = "_silence_id"
+)
+
+var levels = map[string]bool{
+	"crit":    true,
+	"warn":    true,
+	"info":    true,
+	"ok":      true,
+	"unknown": true,
+}
+
+// Service reads the alert history with Flux queries of the monitoring bucket
+// of the organization. It does not authorize the reads: callers are expected
+// to have found the check or rule of the filter with their own authorization.
+type Service struct {
+	log *zap.Logger
+	qs  query.QueryService
+	bs  influxdb.BucketService
+	cs  influxdb.CheckService
+	now func() time.Time
+}
+
+// NewService returns a new alert history service.
+func NewService(log *zap.Logger, qs query.QueryService, bs influxdb.BucketService, cs influxdb.CheckService) *Service {
+	return &Service{
+		log: log,
+		qs:  qs,
+		bs:  bs,
+		cs:  cs,
+		now: time.Now,
+	}
+}
+
+// FindCheckStatuses returns the statuses that match filter, the most recent
+// first, and the total count of matching statuses.
+func (s *Service) FindCheckStatuses(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+	var statuses []*influxdb.CheckStatus
+	n, err := s.query(ctx, "statuses", filter, func(r record) {
+		statuses = append(statuses, newCheckStatus(r))
+	}, opt...)
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindCheckStatuses,
+			Err: err,
+		}
+	}
+	return statuses, n, nil
+}
+
+// FindNotificationEvents returns the notifications that match filter, the
+// most recent first, and the total count of matching notifications.
+func (s *Service) FindNotificationEvents(ctx context.Context, filter influxdb.AlertHistoryFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEvent, int, error) {
+	var events []*influxdb.NotificationEvent
+	n, err := s.query(ctx, "notifications", filter, func(r record) {
+		events = append(events, &influxdb.NotificationEvent{
+			Time:         r.time("_time"),
+			StatusTime:   r.time(statusTimestampColumn),
+			RuleID:       r.id(ruleIDColumn),
+			RuleName:     r.str(ruleNameColumn),
+			EndpointID:   r.id(endpointIDColumn),
+			EndpointName: r.str(endpointNameColumn),
+			CheckID:      r.id(checkIDColumn),
+			CheckName:    r.str(checkNameColumn),
+			Level:        r.str(levelColumn),
+			Message:      r.str(messageColumn),
+			Sent:         r.str(sentColumn) == "true",
+			SilenceID:    r.id(silenceIDColumn),
+			Tags:         r.tags,
+		})
+	}, opt...)
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindNotificationEvents,
+			Err: err,
+		}
+	}
+	return events, n, nil
+}
+
+// The names of the results of the history queries.
+const (
+	countResult = "count"
+	pageResult  = "page"
+)
+
+// query reads the page of opt of the records of the measurement that match
+// filter, the most recent first, with fn. It returns the total count of
+// matching records. The records are counted, sorted and paged by the query.
+func (s *Service) query(ctx context.Context, measurement string, filter influxdb.AlertHistoryFilter, fn func(record), opt ...influxdb.FindOptions) (int, error) {
+	if !filter.OrgID.Valid() {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "organization id is required",
+		}
+	}
+	for _, l := range filter.Levels {
+		if !levels[l] {
+			return 0, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid level %q, expected one of crit, warn, info, ok or unknown", l),
+			}
+		}
+	}
+	start, stop := filter.TimeRange(s.now())
+	if !start.Before(stop) {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "start must be before stop",
+		}
+	}
+
+	limit, offset := influxdb.DefaultPageSize, 0
+	if len(opt) > 0 {
+		if opt[0].Limit > 0 {
+			limit = opt[0].Limit
+		}
+		if opt[0].Offset > 0 {
+			offset = opt[0].Offset
+		}
+	}
+
+	sb, err := s.bs.FindBucketByName(ctx, filter.OrgID, influxdb.MonitoringSystemBucketName)
+	if err != nil {
+		return 0, err
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "data = from(bucketID: %s)\n", fluxString(sb.ID.String()))
+	fmt.Fprintf(&script, "  |> range(start: %s, stop: %s)\n", start.UTC().Format(time.RFC3339Nano), stop.UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(&script, "  |> filter(fn: (r) => r._measurement == %s)\n", fluxString(measurement))
+	if filter.CheckID != nil {
+		fmt.Fprintf(&script, "  |> filter(fn: (r) => r.%s == %s)\n", checkIDColumn, fluxString(filter.CheckID.String()))
+	}
+	if filter.RuleID != nil {
+		fmt.Fprintf(&script, "  |> filter(fn: (r) => r.%s == %s)\n", ruleIDColumn, fluxString(filter.RuleID.String()))
+	}
+	if len(filter.Levels) > 0 {
+		exprs := make([]string, 0, len(filter.Levels))
+		for _, l := range filter.Levels {
+			exprs = append(exprs, fmt.Sprintf("r.%s == %s", levelColumn, fluxString(l)))
+		}
+		fmt.Fprintf(&script, "  |> filter(fn: (r) => %s)\n", strings.Join(exprs, " or "))
+	}
+	for _, t := range filter.Tags {
+		fmt.Fprintf(&script, "  |> filter(fn: (r) => r[%s] == %s)\n", fluxString(t.Key), fluxString(t.Value))
+	}
+	script.WriteString("  |> pivot(rowKey: [\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n")
+	script.WriteString("  |> group()\n")
+	fmt.Fprintf(&script, "data\n  |> count(column: \"_measurement\")\n  |> yield(name: %s)\n", fluxString(countResult))
+	fmt.Fprintf(&script, "data\n  |> sort(columns: [\"_time\"], desc: true)\n  |> limit(n: %d, offset: %d)\n  |> yield(name: %s)", limit, offset, fluxString(pageResult))
+
+	// At this point the check or rule of the filter was found by the caller,
+	// so we are faking a read only permission to the org's monitoring bucket.
+	bucketID := sb.ID
+	auth := &influxdb.Authorization{
+		Status: influxdb.Active,
+		ID:     sb.ID,
+		OrgID:  filter.OrgID,
+		Permissions: []influxdb.Permission{
+			{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: &filter.OrgID,
+					ID:    &bucketID,
+				},
+			},
+		},
+	}
+	req := &query.Request{
+		Authorization:  auth,
+		OrganizationID: filter.OrgID,
+		Compiler:       lang.FluxCompiler{Query: script.String()},
+	}
+
+	var n int
+	err = s.read(ctx, req, func(result string, r record) {
+		switch result {
+		case countResult:
+			n += int(r.int("_measurement"))
+		case pageResult:
+			fn(r)
+		}
+	})
+	return n, err
+}
+
+// fluxString returns the Flux string literal of v.
+func fluxString(v string) string {
+	return ast.Format(notificationflux.LiteralString(v))
+}
+
+// read runs the query of req and calls fn with each of its records and the
+// name of their result.
+func (s *Service) read(ctx context.Context, req *query.Request, fn func(result string, r record)) error {
+	itr, err := s.qs.Query(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	for itr.More() {
+		res := itr.Next()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			return readTable(tbl, func(r record) {
+				fn(res.Name(), r)
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return itr.Err()
+}
+
+// record is a row of a status or notification table.
+type record struct {
+	cr   flux.ColReader
+	i    int
+	tags map[string]string
+}
+
+func newCheckStatus(r record) *influxdb.CheckStatus {
+	return &influxdb.CheckStatus{
+		Time:              r.time("_time"),
+		CheckID:           r.id(checkIDColumn),
+		CheckName:         r.str(checkNameColumn),
+		CheckType:         r.str(checkTypeColumn),
+		Level:             r.str(levelColumn),
+		Message:           r.str(messageColumn),
+		SourceMeasurement: r.str(sourceMeasurementColumn),
+		SourceTime:        r.time(sourceTimestampColumn),
+		Tags:              r.tags,
+	}
+}
+
+func readTable(tbl flux.Table, fn func(record)) error {
+	// The group key of the statuses and notifications is their tags. The
+	// tags of the checks are the ones that are not prefixed by an underscore.
+	var tags map[string]string
+	for j, c := range tbl.Key().Cols() {
+		if strings.HasPrefix(c.Label, "_") || c.Type != flux.TString {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[c.Label] = tbl.Key().ValueString(j)
+	}
+
+	// The tags of the records of a table that is not grouped, such as a page
+	// of the history, are their own columns.
+	ungrouped := len(tbl.Key().Cols()) == 0
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			r := record{cr: cr, i: i, tags: tags}
+			if ungrouped {
+				r.tags = r.columnTags()
+			}
+			fn(r)
+		}
+		return nil
+	})
+}
+
+// columnTags returns the tags of the record read from its columns.
+func (r record) columnTags() map[string]string {
+	var tags map[string]string
+	for j, c := range r.cr.Cols() {
+		if strings.HasPrefix(c.Label, "_") || c.Type != flux.TString || !r.cr.Strings(j).IsValid(r.i) {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[c.Label] = r.cr.Strings(j).ValueString(r.i)
+	}
+	return tags
+}
+
+func (r record) col(label string, typ flux.ColType) int {
+	for j, c := range r.cr.Cols() {
+		if c.Label == label && c.Type == typ {
+			return j
+		}
+	}
+	return -1
+}
+
+func (r record) str(label string) string {
+	j := r.col(label, flux.TString)
+	if j < 0 || !r.cr.Strings(j).IsValid(r.i) {
+		return ""
+	}
+	return r.cr.Strings(j).ValueString(r.i)
+}
+
+func (r record) int(label string) int64 {
+	j := r.col(label, flux.TInt)
+	if j < 0 || !r.cr.Ints(j).IsValid(r.i) {
+		return 0
+	}
+	return r.cr.Ints(j).Value(r.i)
+}
+
+func (r record) id(label string) influxdb.ID {
+	var id influxdb.ID
+	if s := r.str(label); s != "" {
+		// a malformed id is left unset.
+		_ = id.DecodeFromString(s)
+	}
+	return id
+}
+
+// time returns a time column, or a time stored as nanoseconds since the epoch.
+func (r record) time(label string) time.Time {
+	if j := r.col(label, flux.TTime); j >= 0 && r.cr.Times(j).IsValid(r.i) {
+		return time.Unix(0, r.cr.Times(j).Value(r.i)).UTC()
+	}
+	if j := r.col(label, flux.TInt); j >= 0 && r.cr.Ints(j).IsValid(r.i) {
+		return time.Unix(0, r.cr.Ints(j).Value(r.i)).UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,263 @@
+package history_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/notification/history"
+	"github.com/influxdata/influxdb/query"
+	qmock "github.com/influxdata/influxdb/query/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	orgID    = influxdb.ID(1)
+	bucketID = influxdb.ID(2)
+	checkID  = influxdb.ID(3)
+	ruleID   = influxdb.ID(4)
+)
+
+var (
+	start = time.Date(2020, time.March, 2, 0, 0, 0, 0, time.UTC)
+	stop  = time.Date(2020, time.March, 3, 0, 0, 0, 0, time.UTC)
+)
+
+// newService returns a service whose queries return the count of the history
+// and the tables of its page.
+func newService(t *testing.T, count int64, tables ...*executetest.Table) (*history.Service, *string) {
+	t.Helper()
+	var script string
+	bs := mock.NewBucketService()
+	bs.FindBucketByNameFn = func(ctx context.Context, id influxdb.ID, name string) (*influxdb.Bucket, error) {
+		if id != orgID || name != influxdb.MonitoringSystemBucketName {
+			t.Errorf("unexpected bucket %s in org %s", name, id)
+		}
+		return &influxdb.Bucket{ID: bucketID, OrgID: orgID, Name: name}, nil
+	}
+	qs := &qmock.QueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.ResultIterator, error) {
+			if req.OrganizationID != orgID {
+				t.Errorf("unexpected org %s", req.OrganizationID)
+			}
+			script = req.Compiler.(lang.FluxCompiler).Query
+			countResult := executetest.NewResult([]*executetest.Table{{
+				ColMeta: []flux.ColMeta{{Label: "_measurement", Type: flux.TInt}},
+				Data:    [][]interface{}{{count}},
+			}})
+			countResult.Nm = "count"
+			pageResult := executetest.NewResult(tables)
+			pageResult.Nm = "page"
+			return flux.NewSliceResultIterator([]flux.Result{countResult, pageResult}), nil
+		},
+	}
+	return history.NewService(zaptest.NewLogger(t), qs, bs, mock.NewCheckService()), &script
+}
+
+func statusTable(rows ...[]interface{}) *executetest.Table {
+	return &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_check_id", Type: flux.TString},
+			{Label: "_check_name", Type: flux.TString},
+			{Label: "_level", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "_source_measurement", Type: flux.TString},
+			{Label: "_type", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_message", Type: flux.TString},
+			{Label: "_source_timestamp", Type: flux.TInt},
+		},
+		Data: rows,
+	}
+}
+
+func statusRow(t time.Time, level, host string) []interface{} {
+	return []interface{}{
+		values.ConvertTime(t), checkID.String(), "cpu", level, "statuses", "cpu", "threshold", host,
+		host + " is " + level, t.Add(-time.Minute).UnixNano(),
+	}
+}
+
+func TestService_FindCheckStatuses(t *testing.T) {
+	t2 := start.Add(2 * time.Hour)
+	t3 := start.Add(3 * time.Hour)
+	svc, script := newService(t, 3,
+		statusTable(statusRow(t3, "crit", "web-1"), statusRow(t2, "crit", "web-2")),
+	)
+
+	id := checkID
+	statuses, n, err := svc.FindCheckStatuses(context.Background(), influxdb.AlertHistoryFilter{
+		OrgID:   orgID,
+		CheckID: &id,
+		Start:   start,
+		Stop:    stop,
+		Levels:  []string{"crit", "warn"},
+		Tags:    []influxdb.Tag{{Key: "host", Value: "web"}},
+	}, influxdb.FindOptions{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantScript := `data = from(bucketID: "0000000000000002")
+  |> range(start: 2020-03-02T00:00:00Z, stop: 2020-03-03T00:00:00Z)
+  |> filter(fn: (r) => r._measurement == "statuses")
+  |> filter(fn: (r) => r._check_id == "0000000000000003")
+  |> filter(fn: (r) => r._level == "crit" or r._level == "warn")
+  |> filter(fn: (r) => r["host"] == "web")
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> group()
+data
+  |> count(column: "_measurement")
+  |> yield(name: "count")
+data
+  |> sort(columns: ["_time"], desc: true)
+  |> limit(n: 2, offset: 1)
+  |> yield(name: "page")`
+	if *script != wantScript {
+		t.Errorf("unexpected script -want/+got:\n%s", cmp.Diff(wantScript, *script))
+	}
+
+	if n != 3 {
+		t.Errorf("expected 3 statuses in total, got %d", n)
+	}
+	want := []*influxdb.CheckStatus{
+		{
+			Time:              t3,
+			CheckID:           checkID,
+			CheckName:         "cpu",
+			CheckType:         "threshold",
+			Level:             "crit",
+			Message:           "web-1 is crit",
+			SourceMeasurement: "cpu",
+			SourceTime:        t3.Add(-time.Minute),
+			Tags:              map[string]string{"host": "web-1"},
+		},
+		{
+			Time:              t2,
+			CheckID:           checkID,
+			CheckName:         "cpu",
+			CheckType:         "threshold",
+			Level:             "crit",
+			Message:           "web-2 is crit",
+			SourceMeasurement: "cpu",
+			SourceTime:        t2.Add(-time.Minute),
+			Tags:              map[string]string{"host": "web-2"},
+		},
+	}
+	if !cmp.Equal(want, statuses) {
+		t.Errorf("unexpected statuses -want/+got:\n%s", cmp.Diff(want, statuses))
+	}
+}
+
+func TestService_FindCheckStatuses_EscapesTags(t *testing.T) {
+	svc, script := newService(t, 0)
+
+	_, _, err := svc.FindCheckStatuses(context.Background(), influxdb.AlertHistoryFilter{
+		OrgID: orgID,
+		Start: start,
+		Stop:  stop,
+		Tags:  []influxdb.Tag{{Key: `ho"st`, Value: `") or true or ("${r.host}\`}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `  |> filter(fn: (r) => r["ho\"st"] == "\") or true or (\"\${r.host}\\")` + "\n"
+	if !strings.Contains(*script, want) {
+		t.Errorf("expected the tag filter to be escaped as:\n%s\ngot script:\n%s", want, *script)
+	}
+}
+
+func TestService_FindNotificationEvents(t *testing.T) {
+	t1 := start.Add(time.Hour)
+	silenceID := influxdb.ID(5)
+	tbl := &executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_check_id", Type: flux.TString},
+			{Label: "_level", Type: flux.TString},
+			{Label: "_notification_rule_id", Type: flux.TString},
+			{Label: "_notification_endpoint_id", Type: flux.TString},
+			{Label: "_sent", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_message", Type: flux.TString},
+			{Label: "_status_timestamp", Type: flux.TInt},
+			{Label: "_silence_id", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{values.ConvertTime(t1), checkID.String(), "crit", ruleID.String(), influxdb.ID(6).String(), "false", "web-1", "web-1 is crit", t1.UnixNano(), silenceID.String()},
+		},
+	}
+	svc, script := newService(t, 1, tbl)
+
+	id := ruleID
+	events, n, err := svc.FindNotificationEvents(context.Background(), influxdb.AlertHistoryFilter{
+		OrgID:  orgID,
+		RuleID: &id,
+		Start:  start,
+		Stop:   stop,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(*script, `r._measurement == "notifications"`) ||
+		!strings.Contains(*script, `r._notification_rule_id == "0000000000000004"`) ||
+		!strings.Contains(*script, `limit(n: 20, offset: 0)`) {
+		t.Errorf("unexpected script:\n%s", *script)
+	}
+
+	want := []*influxdb.NotificationEvent{
+		{
+			Time:       t1,
+			StatusTime: t1,
+			RuleID:     ruleID,
+			EndpointID: influxdb.ID(6),
+			CheckID:    checkID,
+			Level:      "crit",
+			Message:    "web-1 is crit",
+			SilenceID:  silenceID,
+			Tags:       map[string]string{"host": "web-1"},
+		},
+	}
+	if n != 1 || !cmp.Equal(want, events) {
+		t.Errorf("unexpected notifications -want/+got:\n%s", cmp.Diff(want, events))
+	}
+}
+
+func TestService_InvalidFilter(t *testing.T) {
+	svc, _ := newService(t, 0)
+	tests := []struct {
+		name   string
+		filter influxdb.AlertHistoryFilter
+	}{
+		{
+			name:   "missing org",
+			filter: influxdb.AlertHistoryFilter{},
+		},
+		{
+			name:   "invalid level",
+			filter: influxdb.AlertHistoryFilter{OrgID: orgID, Levels: []string{"critical"}},
+		},
+		{
+			name:   "start after stop",
+			filter: influxdb.AlertHistoryFilter{OrgID: orgID, Start: stop, Stop: start},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.FindCheckStatuses(context.Background(), tt.filter)
+			if code := influxdb.ErrorCode(err); code != influxdb.EInvalid {
+				t.Errorf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
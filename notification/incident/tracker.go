@@ -0,0 +1,205 @@
+// Package incident tracks the incidents of the checks. The statuses of the
+// checks are read back from the monitoring bucket: an incident is opened when
+// the statuses of a check with the same tags transition into a level other
+// than ok, and closed when they return to ok.
+package incident
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultInterval is how often the statuses of the checks are tracked.
+	DefaultInterval = time.Minute
+
+	// lookback is how far back the statuses are read on every pass, which
+	// bounds how long the tracker can be down without missing transitions.
+	lookback = time.Hour
+)
+
+// Tracker opens and closes the incidents of the checks of every organization
+// from their statuses.
+type Tracker struct {
+	log       *zap.Logger
+	orgs      influxdb.OrganizationService
+	incidents influxdb.IncidentStore
+	history   influxdb.AlertHistoryService
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewTracker returns a new tracker of the incidents, tracking the statuses
+// every interval.
+func NewTracker(
+	log *zap.Logger,
+	orgs influxdb.OrganizationService,
+	incidents influxdb.IncidentStore,
+	history influxdb.AlertHistoryService,
+	interval time.Duration,
+) *Tracker {
+	return &Tracker{
+		log:       log,
+		orgs:      orgs,
+		incidents: incidents,
+		history:   history,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run tracks the statuses every interval until ctx is done.
+func (t *Tracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := t.Track(ctx); err != nil {
+				t.log.Error("Failed to track incidents", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Track tracks the recent statuses of every organization once. An
+// organization that fails to be tracked does not prevent the tracking of the
+// others.
+func (t *Tracker) Track(ctx context.Context) error {
+	orgs, _, err := t.orgs.FindOrganizations(ctx, influxdb.OrganizationFilter{})
+	if err != nil {
+		return err
+	}
+	now := t.now()
+	for _, o := range orgs {
+		if err := t.trackOrg(ctx, o.ID, now); err != nil {
+			t.log.Error("Failed to track incidents of organization", zap.Stringer("org_id", o.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) trackOrg(ctx context.Context, orgID influxdb.ID, now time.Time) error {
+	statuses, _, err := t.history.FindCheckStatuses(ctx, influxdb.AlertHistoryFilter{
+		OrgID: orgID,
+		Start: now.Add(-lookback),
+		Stop:  now,
+	}, influxdb.FindOptions{Limit: math.MaxInt32})
+	if err != nil {
+		return err
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	status := influxdb.IncidentOpen
+	is, _, err := t.incidents.FindIncidents(ctx, influxdb.IncidentFilter{OrgID: &orgID, Status: &status})
+	if err != nil {
+		return err
+	}
+	open := make(map[string]*influxdb.Incident, len(is))
+	for _, i := range is {
+		open[i.Key] = i
+	}
+
+	for key, ss := range groupStatuses(statuses) {
+		if err := t.track(ctx, orgID, ss, open[key], now); err != nil {
+			t.log.Error("Failed to track incident", zap.Stringer("org_id", orgID), zap.String("key", key), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// track replays the statuses ss of a check with the same tags, the oldest
+// first, on its open incident inc, which is nil when there is none.
+func (t *Tracker) track(ctx context.Context, orgID influxdb.ID, ss []*influxdb.CheckStatus, inc *influxdb.Incident, now time.Time) error {
+	var start int
+	if inc != nil {
+		// the statuses up to the latest one of the incident were tracked.
+		for start < len(ss) && !ss[start].Time.After(inc.LastStatusAt) {
+			start++
+		}
+	} else {
+		// without an open incident, only the latest statuses other than ok
+		// can open one: the earlier ones were closed or are over.
+		start = len(ss)
+		for start > 0 && influxdb.StatusLevelRank(ss[start-1].Level) != 0 {
+			start--
+		}
+	}
+
+	var changed bool
+	for _, st := range ss[start:] {
+		rank := influxdb.StatusLevelRank(st.Level)
+		if rank < 0 || (rank == 0 && inc == nil) {
+			// unknown levels neither open nor close an incident, and ok
+			// only closes an open one.
+			continue
+		}
+		if inc == nil {
+			inc = &influxdb.Incident{
+				OrgID:    orgID,
+				CheckID:  st.CheckID,
+				Key:      influxdb.StatusKey(st.CheckID, st.Tags),
+				Tags:     st.Tags,
+				Status:   influxdb.IncidentOpen,
+				OpenedAt: st.Time,
+			}
+		}
+		inc.CheckName = st.CheckName
+		inc.Level = st.Level
+		inc.LastStatusAt = st.Time
+		inc.UpdatedAt = now
+		changed = true
+
+		if rank == 0 {
+			closed := st.Time
+			inc.Status = influxdb.IncidentClosed
+			inc.ClosedAt = &closed
+			if err := t.put(ctx, inc); err != nil {
+				return err
+			}
+			inc, changed = nil, false
+		}
+	}
+	if changed {
+		return t.put(ctx, inc)
+	}
+	return nil
+}
+
+func (t *Tracker) put(ctx context.Context, inc *influxdb.Incident) error {
+	opened := !inc.ID.Valid()
+	if err := t.incidents.PutIncident(ctx, inc); err != nil {
+		return err
+	}
+	switch {
+	case !inc.Open():
+		t.log.Info("Closed incident", zap.Stringer("incident_id", inc.ID), zap.Stringer("check_id", inc.CheckID))
+	case opened:
+		t.log.Info("Opened incident", zap.Stringer("incident_id", inc.ID), zap.Stringer("check_id", inc.CheckID), zap.String("level", inc.Level))
+	}
+	return nil
+}
+
+// groupStatuses groups the statuses by their status key, the oldest first.
+func groupStatuses(statuses []*influxdb.CheckStatus) map[string][]*influxdb.CheckStatus {
+	ss := make(map[string][]*influxdb.CheckStatus)
+	for _, st := range statuses {
+		key := influxdb.StatusKey(st.CheckID, st.Tags)
+		ss[key] = append(ss[key], st)
+	}
+	for _, s := range ss {
+		sort.SliceStable(s, func(i, j int) bool {
+			return s[i].Time.Before(s[j].Time)
+		})
+	}
+	return ss
+}
@@ -0,0 +1,90 @@
+package incident
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTracker_Track(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	require.NoError(t, svc.Initialize(ctx))
+
+	orgID := influxdb.ID(10)
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationsF = func(context.Context, influxdb.OrganizationFilter, ...influxdb.FindOptions) ([]*influxdb.Organization, int, error) {
+		return []*influxdb.Organization{{ID: orgID, Name: "org"}}, 1, nil
+	}
+
+	now := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	web1 := map[string]string{"host": "web-1"}
+	web2 := map[string]string{"host": "web-2"}
+	var statuses []*influxdb.CheckStatus
+	addStatus := func(ago time.Duration, level string, tags map[string]string) {
+		statuses = append(statuses, &influxdb.CheckStatus{Time: now.Add(-ago), CheckID: 1, CheckName: "cpu", Level: level, Tags: tags})
+	}
+	addStatus(20*time.Minute, "crit", web1)
+	addStatus(15*time.Minute, "ok", web1)
+	addStatus(10*time.Minute, "crit", web1)
+	addStatus(5*time.Minute, "warn", web1)
+	addStatus(5*time.Minute, "ok", web2)
+
+	history := mock.NewAlertHistoryService()
+	history.FindCheckStatusesFn = func(ctx context.Context, f influxdb.AlertHistoryFilter, opts ...influxdb.FindOptions) ([]*influxdb.CheckStatus, int, error) {
+		var ss []*influxdb.CheckStatus
+		for _, s := range statuses {
+			if f.OrgID == orgID && !s.Time.Before(f.Start) && !s.Time.After(f.Stop) {
+				ss = append(ss, s)
+			}
+		}
+		return ss, len(ss), nil
+	}
+
+	track := func(at time.Time) []*influxdb.Incident {
+		t.Helper()
+		tr := NewTracker(zaptest.NewLogger(t), orgs, svc, history, time.Minute)
+		tr.now = func() time.Time { return at }
+		require.NoError(t, tr.Track(ctx))
+		is, _, err := svc.FindIncidents(ctx, influxdb.IncidentFilter{OrgID: &orgID})
+		require.NoError(t, err)
+		return is
+	}
+
+	// the statuses that returned to ok before the first pass are over.
+	is := track(now)
+	require.Len(t, is, 1)
+	first := is[0]
+	assert.Equal(t, influxdb.IncidentOpen, first.Status)
+	assert.Equal(t, influxdb.StatusKey(1, web1), first.Key)
+	assert.Equal(t, now.Add(-10*time.Minute), first.OpenedAt)
+	assert.Equal(t, "warn", first.Level)
+
+	// tracking the same statuses again changes nothing.
+	assert.Equal(t, is, track(now))
+
+	// an acknowledged incident is closed when the check returns to ok, and
+	// the next transition out of ok opens a new incident.
+	_, err := svc.AcknowledgeIncident(ctx, first.ID, 6)
+	require.NoError(t, err)
+	addStatus(-time.Minute, "ok", web1)
+	addStatus(-2*time.Minute, "crit", web1)
+	is = track(now.Add(3 * time.Minute))
+	require.Len(t, is, 2)
+	assert.Equal(t, influxdb.IncidentOpen, is[0].Status)
+	assert.Equal(t, now.Add(2*time.Minute), is[0].OpenedAt)
+	assert.False(t, is[0].Acknowledged())
+	assert.Equal(t, first.ID, is[1].ID)
+	assert.Equal(t, influxdb.IncidentClosed, is[1].Status)
+	require.NotNil(t, is[1].ClosedAt)
+	assert.Equal(t, now.Add(time.Minute), *is[1].ClosedAt)
+	assert.True(t, is[1].Acknowledged())
+}
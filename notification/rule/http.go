@@ -3,6 +3,8 @@ package rule
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/influxdb"
@@ -46,11 +48,11 @@ func (s *HTTP) imports(e *endpoint.HTTP) []*ast.ImportDeclaration {
 		"experimental",
 	}
 
-	if e.AuthMethod == "bearer" || e.AuthMethod == "basic" {
+	if e.AuthMethod == "bearer" || e.AuthMethod == "basic" || len(e.SecretHeaders) > 0 {
 		packages = append(packages, "influxdata/influxdb/secrets")
 	}
 
-	return flux.Imports(packages...)
+	return s.generateImports(packages...)
 }
 
 func (s *HTTP) generateFluxASTBody(e *endpoint.HTTP) []ast.Statement {
@@ -61,16 +63,37 @@ func (s *HTTP) generateFluxASTBody(e *endpoint.HTTP) []ast.Statement {
 	statements = append(statements, s.generateFluxASTNotificationDefinition(e))
 	statements = append(statements, s.generateFluxASTStatuses())
 	statements = append(statements, s.generateLevelChecks()...)
-	statements = append(statements, s.generateFluxASTNotifyPipe())
+	statements = append(statements, s.generateFluxASTNotifyPipe(e))
 
 	return statements
 }
 
+// generateHeaders defines the headers of the requests: the default content
+// type, the headers of the endpoint, in order, and then the authorization.
 func (s *HTTP) generateHeaders(e *endpoint.HTTP) ast.Statement {
-	props := []*ast.Property{
-		flux.Dictionary(
+	var props []*ast.Property
+	if !hasHeader(e, "Content-Type") {
+		props = append(props, flux.Dictionary(
 			"Content-Type", flux.String("application/json"),
-		),
+		))
+	}
+
+	names := make([]string, 0, len(e.Headers))
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		props = append(props, flux.Dictionary(name, flux.String(e.Headers[name])))
+	}
+	for _, name := range e.SecretHeaderNames() {
+		value := flux.Call(
+			flux.Member("secrets", "get"),
+			flux.Object(
+				flux.Property("key", flux.String(e.SecretHeaders[name].Key)),
+			),
+		)
+		props = append(props, flux.Dictionary(name, value))
 	}
 
 	switch e.AuthMethod {
@@ -121,11 +144,36 @@ func (s *HTTP) generateFluxASTEndpoint(e *endpoint.HTTP) ast.Statement {
 	return flux.DefineVariable("endpoint", call)
 }
 
-func (s *HTTP) generateFluxASTNotifyPipe() ast.Statement {
+// hasHeader reports whether the endpoint sets a header, whatever its case.
+func hasHeader(e *endpoint.HTTP, name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	for n := range e.Headers {
+		if http.CanonicalHeaderKey(n) == name {
+			return true
+		}
+	}
+	for n := range e.SecretHeaders {
+		if http.CanonicalHeaderKey(n) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *HTTP) generateFluxASTNotifyPipe(e *endpoint.HTTP) ast.Statement {
 	endpointBody := flux.Call(
 		flux.Member("json", "encode"),
 		flux.Object(flux.Property("v", flux.Identifier("body"))),
 	)
+	body := s.generateBody()
+	// The template is a flux string interpolating the fields of the status.
+	if e.ContentTemplate != "" {
+		endpointBody = flux.Call(
+			flux.Identifier("bytes"),
+			flux.Object(flux.Property("v", flux.Identifier("body"))),
+		)
+		body = flux.DefineVariable("body", flux.String(e.ContentTemplate))
+	}
 	headers := flux.Property("headers", flux.Identifier("headers"))
 
 	endpointProps := []*ast.Property{
@@ -133,7 +181,7 @@ func (s *HTTP) generateFluxASTNotifyPipe() ast.Statement {
 		flux.Property("data", endpointBody),
 	}
 	endpointFn := flux.FuncBlock(flux.FunctionParams("r"),
-		s.generateBody(),
+		body,
 		&ast.ReturnStatement{
 			Argument: flux.Object(endpointProps...),
 		},
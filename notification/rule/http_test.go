@@ -16,6 +16,7 @@ import "influxdata/influxdb/monitor"
 import "http"
 import "json"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h, offset: 1s}
 
@@ -31,9 +32,16 @@ statuses = monitor.from(start: -2h)
 crit = statuses
 	|> filter(fn: (r) =>
 		(r._level == "crit"))
-all_statuses = crit
+matched_statuses = crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: endpoint(mapFn: (r) => {
@@ -45,6 +53,7 @@ all_statuses
 	s := &rule.HTTP{
 		Base: rule.Base{
 			ID:         1,
+			OrgID:      3,
 			Name:       "foo",
 			Every:      mustDuration("1h"),
 			Offset:     mustDuration("1s"),
@@ -85,6 +94,7 @@ import "http"
 import "json"
 import "experimental"
 import "influxdata/influxdb/secrets"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h, offset: 1s}
 
@@ -100,9 +110,16 @@ statuses = monitor.from(start: -2h)
 crit = statuses
 	|> filter(fn: (r) =>
 		(r._level == "crit"))
-all_statuses = crit
+matched_statuses = crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: endpoint(mapFn: (r) => {
@@ -113,6 +130,7 @@ all_statuses
 	s := &rule.HTTP{
 		Base: rule.Base{
 			ID:         1,
+			OrgID:      3,
 			Name:       "foo",
 			Every:      mustDuration("1h"),
 			Offset:     mustDuration("1s"),
@@ -160,6 +178,7 @@ import "http"
 import "json"
 import "experimental"
 import "influxdata/influxdb/secrets"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h, offset: 1s}
 
@@ -175,9 +194,16 @@ statuses = monitor.from(start: -2h)
 crit = statuses
 	|> filter(fn: (r) =>
 		(r._level == "crit"))
-all_statuses = crit
+matched_statuses = crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: endpoint(mapFn: (r) => {
@@ -189,6 +215,7 @@ all_statuses
 	s := &rule.HTTP{
 		Base: rule.Base{
 			ID:         1,
+			OrgID:      3,
 			Name:       "foo",
 			Every:      mustDuration("1h"),
 			Offset:     mustDuration("1s"),
@@ -233,6 +260,7 @@ import "http"
 import "json"
 import "experimental"
 import "influxdata/influxdb/secrets"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 5s, offset: 1s}
 
@@ -248,9 +276,16 @@ statuses = monitor.from(start: -10s)
 crit = statuses
 	|> filter(fn: (r) =>
 		(r._level == "crit"))
-all_statuses = crit
+matched_statuses = crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 5s)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: endpoint(mapFn: (r) => {
@@ -262,6 +297,7 @@ all_statuses
 	s := &rule.HTTP{
 		Base: rule.Base{
 			ID:         1,
+			OrgID:      3,
 			Name:       "foo",
 			Every:      mustDuration("5s"),
 			Offset:     mustDuration("1s"),
@@ -297,3 +333,89 @@ all_statuses
 		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
 	}
 }
+
+func TestHTTP_GenerateFlux_template(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "experimental"
+import "influxdata/influxdb/secrets"
+import "influxdata/influxdb/silences"
+
+option task = {name: "foo", every: 1h}
+
+headers = {"X-Source": "influxdb", "content-type": "text/plain", "X-Api-Key": secrets.get(key: "000000000000000e-header-x-api-key")}
+endpoint = http.endpoint(url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor.from(start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r._level == "crit"))
+matched_statuses = crit
+	|> filter(fn: (r) =>
+		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
+
+all_statuses
+	|> monitor.notify(data: notification, endpoint: endpoint(mapFn: (r) => {
+		body = "{\"alias\": \"${r._check_name}\", \"priority\": \"${r._level}\", \"message\": \"${r._message} on ${r.host} at ${string(v: r._time)}\"}"
+
+		return {headers: headers, data: bytes(v: body)}
+	}))`
+
+	s := &rule.HTTP{
+		Base: rule.Base{
+			ID:         1,
+			OrgID:      3,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			TagRules:   []notification.TagRule{},
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+
+	id := influxdb.ID(2)
+	e := &endpoint.HTTP{
+		Base: endpoint.Base{
+			ID:   &id,
+			Name: "foo",
+		},
+		URL:        "http://localhost:7777",
+		AuthMethod: "none",
+		Headers: map[string]string{
+			"X-Source":     "influxdb",
+			"content-type": "text/plain",
+		},
+		SecretHeaders: map[string]influxdb.SecretField{
+			"X-Api-Key": {Key: "000000000000000e-header-x-api-key"},
+		},
+		ContentTemplate: `{"alias": "${r._check_name}", "priority": "${r._level}", "message": "${r._message} on ${r.host} at ${string(v: r._time)}"}`,
+	}
+
+	f, err := s.GenerateFlux(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
@@ -33,6 +33,7 @@ func TestPagerDuty_GenerateFlux(t *testing.T) {
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -65,6 +66,7 @@ import "influxdata/influxdb/monitor"
 import "pagerduty"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -81,9 +83,16 @@ statuses = monitor.from(start: -2h, fn: (r) =>
 crit = statuses
 	|> filter(fn: (r) =>
 		(r._level == "crit"))
-all_statuses = crit
+matched_statuses = crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: pagerduty_endpoint(mapFn: (r) =>
@@ -116,6 +125,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -149,6 +159,7 @@ import "influxdata/influxdb/monitor"
 import "pagerduty"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -164,9 +175,16 @@ statuses = monitor.from(start: -2h, fn: (r) =>
 	(r.foo == "bar" and r.baz == "bang"))
 info_to_crit = statuses
 	|> monitor.stateChanges(fromLevel: "info", toLevel: "crit")
-all_statuses = info_to_crit
+matched_statuses = info_to_crit
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: pagerduty_endpoint(mapFn: (r) =>
@@ -199,6 +217,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -235,6 +254,7 @@ import "influxdata/influxdb/monitor"
 import "pagerduty"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -253,10 +273,17 @@ crit = statuses
 		(r._level == "crit"))
 ok_to_warn = statuses
 	|> monitor.stateChanges(fromLevel: "ok", toLevel: "warn")
-all_statuses = union(tables: [crit, ok_to_warn])
+matched_statuses = union(tables: [crit, ok_to_warn])
 	|> sort(columns: ["_time"])
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: pagerduty_endpoint(mapFn: (r) =>
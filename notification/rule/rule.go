@@ -16,6 +16,7 @@ var typeToRule = map[string](func() influxdb.NotificationRule){
 	"slack":     func() influxdb.NotificationRule { return &Slack{} },
 	"pagerduty": func() influxdb.NotificationRule { return &PagerDuty{} },
 	"http":      func() influxdb.NotificationRule { return &HTTP{} },
+	"smtp":      func() influxdb.NotificationRule { return &SMTP{} },
 }
 
 // UnmarshalJSON will convert
@@ -57,6 +58,15 @@ type Base struct {
 	RunbookLink string                    `json:"runbookLink"`
 	TagRules    []notification.TagRule    `json:"tagRules,omitempty"`
 	StatusRules []notification.StatusRule `json:"statusRules,omitempty"`
+	// RepeatEvery is an optional interval during which a status of the same
+	// check, level and tags is not notified again.
+	RepeatEvery *notification.Duration `json:"repeatEvery,omitempty"`
+	// Digest batches all the statuses matched by a run of the rule into a
+	// single notification.
+	Digest bool `json:"digest,omitempty"`
+	// SkipAcknowledged skips notifying the statuses of the incidents that
+	// someone acknowledged.
+	SkipAcknowledged bool `json:"skipAcknowledged,omitempty"`
 	*influxdb.Limit
 	influxdb.CRUDLog
 }
@@ -98,6 +108,12 @@ func (b Base) valid() error {
 			Msg:  "Offset should not be equal or greater than the interval",
 		}
 	}
+	if b.RepeatEvery != nil && b.RepeatEvery.TimeDuration() <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "Repeat interval should be positive",
+		}
+	}
 	for _, tagRule := range b.TagRules {
 		if err := tagRule.Valid(); err != nil {
 			return err
@@ -181,9 +197,92 @@ func (b *Base) generateLevelChecks() []ast.Statement {
 		)
 	}
 
-	stmts = append(stmts, flux.DefineVariable("all_statuses", pipe))
+	return append(stmts, b.generateSilenceChecks(pipe)...)
+}
 
-	return stmts
+// generateSilenceChecks defines all_statuses as the statuses of pipe that no
+// active silence matches. The statuses matched by a silence are logged as
+// notifications that were not sent. The statuses of acknowledged incidents are
+// dropped when the rule skips them.
+func (b *Base) generateSilenceChecks(pipe *ast.PipeExpression) []ast.Statement {
+	matched := flux.Pipe(
+		pipe,
+		flux.Call(
+			flux.Member("silences", "match"),
+			flux.Object(
+				flux.Property("orgID", flux.String(b.OrgID.String())),
+				flux.Property("ruleID", flux.String(b.ID.String())),
+			),
+		),
+	)
+	suppressed := flux.Pipe(
+		flux.Identifier("matched_statuses"),
+		flux.Call(
+			flux.Member("silences", "suppress"),
+			flux.Object(
+				flux.Property("data", flux.Identifier("notification")),
+			),
+		),
+	)
+	calls := []*ast.CallExpression{
+		flux.Call(
+			flux.Member("silences", "unsilenced"),
+			flux.Object(),
+		),
+	}
+	if b.SkipAcknowledged {
+		calls = append(calls, flux.Call(
+			flux.Member("incidents", "unacknowledged"),
+			flux.Object(
+				flux.Property("orgID", flux.String(b.OrgID.String())),
+			),
+		))
+	}
+	unsilenced := flux.Pipe(
+		flux.Identifier("matched_statuses"),
+		append(calls, b.generateThrottleCalls()...)...,
+	)
+
+	return []ast.Statement{
+		flux.DefineVariable("matched_statuses", matched),
+		flux.ExpressionStatement(suppressed),
+		flux.DefineVariable("all_statuses", unsilenced),
+	}
+}
+
+// generateThrottleCalls returns the calls limiting the statuses notified by
+// the repeat interval and the digest of the rule.
+func (b *Base) generateThrottleCalls() []*ast.CallExpression {
+	var calls []*ast.CallExpression
+	if b.RepeatEvery != nil {
+		calls = append(calls, flux.Call(
+			flux.Member("throttle", "repeat"),
+			flux.Object(
+				flux.Property("ruleID", flux.String(b.ID.String())),
+				flux.Property("every", (*ast.DurationLiteral)(b.RepeatEvery)),
+			),
+		))
+	}
+	if b.Digest {
+		calls = append(calls, flux.Call(
+			flux.Member("throttle", "digest"),
+			flux.Object(),
+		))
+	}
+	return calls
+}
+
+// generateImports returns the imports of the packages, with the packages
+// used by every rule.
+func (b *Base) generateImports(pkgs ...string) []*ast.ImportDeclaration {
+	pkgs = append(pkgs, "influxdata/influxdb/silences")
+	if b.SkipAcknowledged {
+		pkgs = append(pkgs, "influxdata/influxdb/incidents")
+	}
+	if b.RepeatEvery != nil || b.Digest {
+		pkgs = append(pkgs, "influxdata/influxdb/throttle")
+	}
+	return flux.Imports(pkgs...)
 }
 
 func (b *Base) generateLevelCheck(r notification.StatusRule) (ast.Statement, *ast.Identifier) {
@@ -335,6 +434,11 @@ func (b *Base) ClearPrivateData() {
 	b.TaskID = 0
 }
 
+// GetTagRules returns the tag rules of the statuses the rule notifies.
+func (b *Base) GetTagRules() []notification.TagRule {
+	return b.TagRules
+}
+
 // MatchesTags returns true if the Rule matches all of the tags
 func (b *Base) MatchesTags(tags []influxdb.Tag) bool {
 	if len(tags) == 0 {
@@ -121,6 +121,24 @@ func TestValidRule(t *testing.T) {
 				Msg:  "Offset should not be equal or greater than the interval",
 			},
 		},
+		{
+			name: "zero repeat interval",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:          influxTesting.MustIDBase16(id1),
+					Name:        "name1",
+					OwnerID:     influxTesting.MustIDBase16(id2),
+					OrgID:       influxTesting.MustIDBase16(id3),
+					EndpointID:  1,
+					Every:       mustDuration("1m"),
+					RepeatEvery: mustDuration("0s"),
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "Repeat interval should be positive",
+			},
+		},
 		{
 			name: "empty slack message",
 			src: &rule.Slack{
@@ -132,6 +150,19 @@ func TestValidRule(t *testing.T) {
 				Msg:  "slack msg template is empty",
 			},
 		},
+		{
+			name: "invalid smtp recipient",
+			src: &rule.SMTP{
+				Base:            goodBase,
+				To:              "oncall",
+				SubjectTemplate: "subject",
+				BodyTemplate:    "body",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `smtp to addresses are invalid: mail: missing '@' or angle-addr`,
+			},
+		},
 		{
 			name: "empty pagerDuty message",
 			src: &rule.PagerDuty{
@@ -286,6 +317,26 @@ func TestJSON(t *testing.T) {
 				MessageTemplate: "msg1",
 			},
 		},
+		{
+			name: "smtp with recipients",
+			src: &rule.SMTP{
+				Base: rule.Base{
+					ID:          influxTesting.MustIDBase16(id1),
+					Name:        "name1",
+					OwnerID:     influxTesting.MustIDBase16(id2),
+					OrgID:       influxTesting.MustIDBase16(id3),
+					RunbookLink: "runbooklink1",
+					Every:       mustDuration("1h"),
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				To:              "oncall@example.com, ops@example.com",
+				SubjectTemplate: "subject1",
+				BodyTemplate:    "body1",
+			},
+		},
 		{
 			name: "simple pagerDuty",
 			src: &rule.PagerDuty{
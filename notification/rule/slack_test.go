@@ -35,6 +35,136 @@ func TestSlack_GenerateFlux(t *testing.T) {
 		rule     *rule.Slack
 		endpoint *endpoint.Slack
 	}{
+		{
+			name: "with repeat interval and digest",
+			want: `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+import "influxdata/influxdb/silences"
+import "influxdata/influxdb/throttle"
+
+option task = {name: "foo", every: 1h}
+
+slack_endpoint = slack.endpoint(url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor.from(start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r._level == "crit"))
+matched_statuses = crit
+	|> filter(fn: (r) =>
+		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
+	|> throttle.repeat(ruleID: "0000000000000001", every: 4h)
+	|> throttle.digest()
+
+all_statuses
+	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r._level == "crit" then "danger" else if r._level == "warn" then "warning" else "good"})))`,
+			rule: &rule.Slack{
+				Channel:         "bar",
+				MessageTemplate: "blah",
+				Base: rule.Base{
+					ID:          1,
+					OrgID:       3,
+					EndpointID:  2,
+					Name:        "foo",
+					Every:       mustDuration("1h"),
+					RepeatEvery: mustDuration("4h"),
+					Digest:      true,
+					StatusRules: []notification.StatusRule{
+						{
+							CurrentLevel: notification.Critical,
+						},
+					},
+				},
+			},
+			endpoint: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:   idPtr(2),
+					Name: "foo",
+				},
+				URL: "http://localhost:7777",
+			},
+		},
+		{
+			name: "skipping acknowledged incidents",
+			want: `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+import "influxdata/influxdb/silences"
+import "influxdata/influxdb/incidents"
+
+option task = {name: "foo", every: 1h}
+
+slack_endpoint = slack.endpoint(url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor.from(start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r._level == "crit"))
+matched_statuses = crit
+	|> filter(fn: (r) =>
+		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
+	|> incidents.unacknowledged(orgID: "0000000000000003")
+
+all_statuses
+	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r._level == "crit" then "danger" else if r._level == "warn" then "warning" else "good"})))`,
+			rule: &rule.Slack{
+				Channel:         "bar",
+				MessageTemplate: "blah",
+				Base: rule.Base{
+					ID:               1,
+					OrgID:            3,
+					EndpointID:       2,
+					Name:             "foo",
+					Every:            mustDuration("1h"),
+					SkipAcknowledged: true,
+					StatusRules: []notification.StatusRule{
+						{
+							CurrentLevel: notification.Critical,
+						},
+					},
+				},
+			},
+			endpoint: &endpoint.Slack{
+				Base: endpoint.Base{
+					ID:   idPtr(2),
+					Name: "foo",
+				},
+				URL: "http://localhost:7777",
+			},
+		},
 		{
 			name: "with any status",
 			want: `package main
@@ -43,6 +173,7 @@ import "influxdata/influxdb/monitor"
 import "slack"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -58,9 +189,16 @@ statuses = monitor.from(start: -2h, fn: (r) =>
 any = statuses
 	|> filter(fn: (r) =>
 		(true))
-all_statuses = any
+matched_statuses = any
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
@@ -70,6 +208,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -112,6 +251,7 @@ import "influxdata/influxdb/monitor"
 import "slack"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -129,10 +269,17 @@ crit = statuses
 		(r._level == "crit"))
 info_to_warn = statuses
 	|> monitor.stateChanges(fromLevel: "info", toLevel: "warn")
-all_statuses = union(tables: [crit, info_to_warn])
+matched_statuses = union(tables: [crit, info_to_warn])
 	|> sort(columns: ["_time"])
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
@@ -142,6 +289,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -188,6 +336,7 @@ import "influxdata/influxdb/monitor"
 import "slack"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -206,10 +355,17 @@ crit = statuses
 		(r._level == "crit"))
 info_to_warn = statuses
 	|> monitor.stateChanges(fromLevel: "info", toLevel: "warn")
-all_statuses = union(tables: [crit, info_to_warn])
+matched_statuses = union(tables: [crit, info_to_warn])
 	|> sort(columns: ["_time"])
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
@@ -219,6 +375,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
@@ -267,6 +424,7 @@ import "influxdata/influxdb/monitor"
 import "slack"
 import "influxdata/influxdb/secrets"
 import "experimental"
+import "influxdata/influxdb/silences"
 
 option task = {name: "foo", every: 1h}
 
@@ -285,10 +443,17 @@ crit = statuses
 		(r._level == "crit"))
 info_to_warn = statuses
 	|> monitor.stateChanges(fromLevel: "info", toLevel: "warn")
-all_statuses = union(tables: [crit, info_to_warn])
+matched_statuses = union(tables: [crit, info_to_warn])
 	|> sort(columns: ["_time"])
 	|> filter(fn: (r) =>
 		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
 
 all_statuses
 	|> monitor.notify(data: notification, endpoint: slack_endpoint(mapFn: (r) =>
@@ -298,6 +463,7 @@ all_statuses
 				MessageTemplate: "blah",
 				Base: rule.Base{
 					ID:         1,
+					OrgID:      3,
 					EndpointID: 2,
 					Name:       "foo",
 					Every:      mustDuration("1h"),
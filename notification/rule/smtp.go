@@ -0,0 +1,145 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/flux"
+)
+
+// SMTP is the notification rule config of smtp, which sends an email for
+// every status.
+type SMTP struct {
+	Base
+	// To is the comma separated list of addresses the emails are sent to.
+	To              string `json:"to"`
+	SubjectTemplate string `json:"subjectTemplate"`
+	BodyTemplate    string `json:"bodyTemplate"`
+}
+
+// GenerateFlux generates a flux script for the smtp notification rule.
+func (s *SMTP) GenerateFlux(e influxdb.NotificationEndpoint) (string, error) {
+	smtpEndpoint, ok := e.(*endpoint.SMTP)
+	if !ok {
+		return "", fmt.Errorf("endpoint provided is a %s, not an SMTP endpoint", e.Type())
+	}
+	p, err := s.GenerateFluxAST(smtpEndpoint)
+	if err != nil {
+		return "", err
+	}
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST generates a flux AST for the smtp notification rule.
+func (s *SMTP) GenerateFluxAST(e *endpoint.SMTP) (*ast.Package, error) {
+	f := flux.File(
+		s.Name,
+		s.generateImports("influxdata/influxdb/monitor", "influxdata/influxdb/smtp", "influxdata/influxdb/secrets", "experimental"),
+		s.generateFluxASTBody(e),
+	)
+	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
+}
+
+func (s *SMTP) generateFluxASTBody(e *endpoint.SMTP) []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, s.generateTaskOption())
+	if e.Password.Key != "" {
+		statements = append(statements, s.generateFluxASTSecrets(e))
+	}
+	statements = append(statements, s.generateFluxASTEndpoint(e))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e))
+	statements = append(statements, s.generateFluxASTStatuses())
+	statements = append(statements, s.generateLevelChecks()...)
+	statements = append(statements, s.generateFluxASTNotifyPipe())
+
+	return statements
+}
+
+func (s *SMTP) generateFluxASTSecrets(e *endpoint.SMTP) ast.Statement {
+	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.Password.Key))))
+
+	return flux.DefineVariable("smtp_secret", call)
+}
+
+func (s *SMTP) generateFluxASTEndpoint(e *endpoint.SMTP) ast.Statement {
+	props := []*ast.Property{}
+	props = append(props, flux.Property("host", flux.String(e.Host)))
+	props = append(props, flux.Property("port", flux.Integer(int64(e.Port))))
+	props = append(props, flux.Property("tls", flux.String(e.TLSMode)))
+	if e.Username != "" {
+		props = append(props, flux.Property("username", flux.String(e.Username)))
+	}
+	if e.Password.Key != "" {
+		props = append(props, flux.Property("password", flux.Identifier("smtp_secret")))
+	}
+	props = append(props, flux.Property("from", flux.String(e.From)))
+	call := flux.Call(flux.Member("smtp", "endpoint"), flux.Object(props...))
+
+	return flux.DefineVariable("smtp_endpoint", call)
+}
+
+func (s *SMTP) generateFluxASTNotifyPipe() ast.Statement {
+	endpointProps := []*ast.Property{}
+	endpointProps = append(endpointProps, flux.Property("to", flux.String(s.To)))
+	endpointProps = append(endpointProps, flux.Property("subject", flux.String(s.SubjectTemplate)))
+	endpointProps = append(endpointProps, flux.Property("body", flux.String(s.BodyTemplate)))
+	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
+
+	props := []*ast.Property{}
+	props = append(props, flux.Property("data", flux.Identifier("notification")))
+	props = append(props, flux.Property("endpoint",
+		flux.Call(flux.Identifier("smtp_endpoint"), flux.Object(flux.Property("mapFn", endpointFn)))))
+
+	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
+
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"), call))
+}
+
+type smtpAlias SMTP
+
+// MarshalJSON implement json.Marshaler interface.
+func (s SMTP) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			smtpAlias
+			Type string `json:"type"`
+		}{
+			smtpAlias: smtpAlias(s),
+			Type:      s.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (s SMTP) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.To == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp to addresses are empty",
+		}
+	}
+	if _, err := mail.ParseAddressList(s.To); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp to addresses are invalid: %s", err.Error()),
+		}
+	}
+	if s.SubjectTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp subject template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (s SMTP) Type() string {
+	return "smtp"
+}
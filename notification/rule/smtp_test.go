@@ -0,0 +1,98 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification"
+	"github.com/influxdata/influxdb/notification/endpoint"
+	"github.com/influxdata/influxdb/notification/rule"
+)
+
+func TestSMTP_GenerateFlux(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "influxdata/influxdb/smtp"
+import "influxdata/influxdb/secrets"
+import "experimental"
+import "influxdata/influxdb/silences"
+
+option task = {name: "foo", every: 1h}
+
+smtp_secret = secrets.get(key: "0000000000000002-password")
+smtp_endpoint = smtp.endpoint(
+	host: "smtp.example.com",
+	port: 587,
+	tls: "starttls",
+	username: "alerts",
+	password: smtp_secret,
+	from: "alerts@example.com",
+)
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor.from(start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r._level == "crit"))
+matched_statuses = crit
+	|> filter(fn: (r) =>
+		(r._time > experimental.subDuration(from: now(), d: 1h)))
+	|> silences.match(orgID: "0000000000000003", ruleID: "0000000000000001")
+
+matched_statuses
+	|> silences.suppress(data: notification)
+
+all_statuses = matched_statuses
+	|> silences.unsilenced()
+
+all_statuses
+	|> monitor.notify(data: notification, endpoint: smtp_endpoint(mapFn: (r) =>
+		({to: "oncall@example.com, ops@example.com", subject: "${r._check_name} is ${r._level}", body: "${r._message}"})))`
+
+	s := &rule.SMTP{
+		Base: rule.Base{
+			ID:         1,
+			OrgID:      3,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			TagRules:   []notification.TagRule{},
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+		To:              "oncall@example.com, ops@example.com",
+		SubjectTemplate: "${r._check_name} is ${r._level}",
+		BodyTemplate:    "${r._message}",
+	}
+
+	id := influxdb.ID(2)
+	e := &endpoint.SMTP{
+		Base: endpoint.Base{
+			ID:   &id,
+			Name: "foo",
+		},
+		Host:     "smtp.example.com",
+		Port:     587,
+		TLSMode:  "starttls",
+		Username: "alerts",
+		Password: influxdb.SecretField{Key: "0000000000000002-password"},
+		From:     "alerts@example.com",
+	}
+
+	f, err := s.GenerateFlux(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
@@ -45,6 +45,7 @@ var (
 	OpCreateNotificationEndpoint   = "CreateNotificationEndpoint"
 	OpUpdateNotificationEndpoint   = "UpdateNotificationEndpoint"
 	OpDeleteNotificationEndpoint   = "DeleteNotificationEndpoint"
+	OpTestNotificationEndpoint     = "TestNotificationEndpoint"
 )
 
 // NotificationEndpointFilter represents a set of filter that restrict the returned notification endpoints.
@@ -131,3 +132,23 @@ type NotificationEndpointService interface {
 	// DeleteNotificationEndpoint removes a notification endpoint by ID, returns secret fields, orgID for further deletion.
 	DeleteNotificationEndpoint(ctx context.Context, id ID) (flds []SecretField, orgID ID, err error)
 }
+
+// NotificationEndpointTestResult is the outcome of a test notification sent
+// to a notification endpoint.
+type NotificationEndpointTestResult struct {
+	// Sent is true when the endpoint accepted the notification.
+	Sent bool `json:"sent"`
+	// StatusCode is the status code the endpoint responded with, zero when it
+	// could not be reached.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Error is the body the endpoint responded with when it did not accept
+	// the notification, or the reason it could not be reached.
+	Error string `json:"error,omitempty"`
+}
+
+// NotificationEndpointTestService sends test notifications to notification endpoints.
+type NotificationEndpointTestService interface {
+	// TestNotificationEndpoint delivers a synthetic notification to the notification
+	// endpoint with id, with its secrets resolved, and returns how the endpoint responded.
+	TestNotificationEndpoint(ctx context.Context, id ID) (*NotificationEndpointTestResult, error)
+}
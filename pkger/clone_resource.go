@@ -120,6 +120,19 @@ func checkToObject(ch influxdb.Check, name string) Object {
 		})
 		k.Spec[fieldLevel] = cT.Level.String()
 		assignNonZeroBools(k.Spec, map[string]bool{fieldCheckReportZero: cT.ReportZero})
+	case *icheck.Change:
+		k.Type = KindCheckChange
+		assignBase(cT.Base)
+		assignNonZeroFluxDurs(k.Spec, map[string]*notification.Duration{
+			fieldCheckWindow: cT.Window,
+			fieldCheckUnit:   cT.Unit,
+		})
+		k.Spec[fieldCheckChangeType] = cT.ChangeType
+		var thresholds []Resource
+		for _, th := range cT.Thresholds {
+			thresholds = append(thresholds, convertThreshold(th))
+		}
+		k.Spec[fieldCheckThresholds] = thresholds
 	case *icheck.Threshold:
 		k.Type = KindCheckThreshold
 		assignBase(cT.Base)
@@ -501,6 +514,18 @@ func endpointKind(e influxdb.NotificationEndpoint, name string) Object {
 		assignNonZeroSecrets(k.Spec, map[string]influxdb.SecretField{
 			fieldNotificationEndpointToken: actual.Token,
 		})
+	case *endpoint.SMTP:
+		k.Type = KindNotificationEndpointSMTP
+		k.Spec[fieldNotificationEndpointHost] = actual.Host
+		k.Spec[fieldNotificationEndpointPort] = actual.Port
+		k.Spec[fieldNotificationEndpointTLSMode] = actual.TLSMode
+		k.Spec[fieldNotificationEndpointFrom] = actual.From
+		assignNonZeroStrings(k.Spec, map[string]string{
+			fieldNotificationEndpointUsername: actual.Username,
+		})
+		assignNonZeroSecrets(k.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointPassword: actual.Password,
+		})
 	}
 
 	return k
@@ -565,6 +590,11 @@ func ruleToObject(iRule influxdb.NotificationRule, endpointName, name string) Ob
 		assignBase(t.Base)
 		k.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
 		assignNonZeroStrings(k.Spec, map[string]string{fieldNotificationRuleChannel: t.Channel})
+	case *rule.SMTP:
+		assignBase(t.Base)
+		k.Spec[fieldNotificationRuleTo] = t.To
+		k.Spec[fieldNotificationRuleSubjectTemplate] = t.SubjectTemplate
+		k.Spec[fieldNotificationRuleBodyTemplate] = t.BodyTemplate
 	}
 
 	return k
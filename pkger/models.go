@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -24,6 +25,7 @@ const (
 	KindUnknown                       Kind = ""
 	KindBucket                        Kind = "Bucket"
 	KindCheck                         Kind = "Check"
+	KindCheckChange                   Kind = "CheckChange"
 	KindCheckDeadman                  Kind = "CheckDeadman"
 	KindCheckThreshold                Kind = "CheckThreshold"
 	KindDashboard                     Kind = "Dashboard"
@@ -32,6 +34,7 @@ const (
 	KindNotificationEndpointHTTP      Kind = "NotificationEndpointHTTP"
 	KindNotificationEndpointPagerDuty Kind = "NotificationEndpointPagerDuty"
 	KindNotificationEndpointSlack     Kind = "NotificationEndpointSlack"
+	KindNotificationEndpointSMTP      Kind = "NotificationEndpointSMTP"
 	KindNotificationRule              Kind = "NotificationRule"
 	KindPackage                       Kind = "Package"
 	KindTask                          Kind = "Task"
@@ -42,6 +45,7 @@ const (
 var kinds = map[Kind]bool{
 	KindBucket:                        true,
 	KindCheck:                         true,
+	KindCheckChange:                   true,
 	KindCheckDeadman:                  true,
 	KindCheckThreshold:                true,
 	KindDashboard:                     true,
@@ -50,6 +54,7 @@ var kinds = map[Kind]bool{
 	KindNotificationEndpointHTTP:      true,
 	KindNotificationEndpointPagerDuty: true,
 	KindNotificationEndpointSlack:     true,
+	KindNotificationEndpointSMTP:      true,
 	KindNotificationRule:              true,
 	KindTask:                          true,
 	KindTelegraf:                      true,
@@ -59,6 +64,7 @@ var kinds = map[Kind]bool{
 var kindsUniqByName = map[Kind]bool{
 	KindBucket:                        true,
 	KindCheck:                         true,
+	KindCheckChange:                   true,
 	KindCheckDeadman:                  true,
 	KindCheckThreshold:                true,
 	KindLabel:                         true,
@@ -66,6 +72,7 @@ var kindsUniqByName = map[Kind]bool{
 	KindNotificationEndpointHTTP:      true,
 	KindNotificationEndpointPagerDuty: true,
 	KindNotificationEndpointSlack:     true,
+	KindNotificationEndpointSMTP:      true,
 	KindVariable:                      true,
 }
 
@@ -99,7 +106,7 @@ func (k Kind) ResourceType() influxdb.ResourceType {
 	switch k {
 	case KindBucket:
 		return influxdb.BucketsResourceType
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckChange, KindCheckDeadman, KindCheckThreshold:
 		return influxdb.ChecksResourceType
 	case KindDashboard:
 		return influxdb.DashboardsResourceType
@@ -108,7 +115,8 @@ func (k Kind) ResourceType() influxdb.ResourceType {
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP:
 		return influxdb.NotificationEndpointResourceType
 	case KindNotificationRule:
 		return influxdb.NotificationRuleResourceType
@@ -407,6 +415,9 @@ type DiffNotificationRule struct {
 	Every           string              `json:"every"`
 	Offset          string              `json:"offset"`
 	MessageTemplate string              `json:"messageTemplate"`
+	To              string              `json:"to,omitempty"`
+	SubjectTemplate string              `json:"subjectTemplate,omitempty"`
+	BodyTemplate    string              `json:"bodyTemplate,omitempty"`
 	Status          influxdb.Status     `json:"status"`
 	StatusRules     []SummaryStatusRule `json:"statusRules"`
 	TagRules        []SummaryTagRule    `json:"tagRules"`
@@ -420,6 +431,9 @@ func newDiffNotificationRule(r *notificationRule, iEndpoint influxdb.Notificatio
 		Every:           r.every.String(),
 		Offset:          r.offset.String(),
 		MessageTemplate: r.msgTemplate,
+		To:              r.to,
+		SubjectTemplate: r.subjectTemplate,
+		BodyTemplate:    r.bodyTemplate,
 		Status:          r.Status(),
 		StatusRules:     toSummaryStatusRules(r.statusRules),
 		TagRules:        toSummaryTagRules(r.tagRules),
@@ -694,6 +708,9 @@ type (
 		LabelAssociations []SummaryLabel      `json:"labelAssociations"`
 		Offset            string              `json:"offset"`
 		MessageTemplate   string              `json:"messageTemplate"`
+		To                string              `json:"to,omitempty"`
+		SubjectTemplate   string              `json:"subjectTemplate,omitempty"`
+		BodyTemplate      string              `json:"bodyTemplate,omitempty"`
 		Status            influxdb.Status     `json:"status"`
 		StatusRules       []SummaryStatusRule `json:"statusRules"`
 		TagRules          []SummaryTagRule    `json:"tagRules"`
@@ -951,16 +968,20 @@ type checkKind int
 const (
 	checkKindDeadman checkKind = iota + 1
 	checkKindThreshold
+	checkKindChange
 )
 
 const (
 	fieldCheckAllValues             = "allValues"
+	fieldCheckChangeType            = "changeType"
 	fieldCheckReportZero            = "reportZero"
 	fieldCheckStaleTime             = "staleTime"
 	fieldCheckStatusMessageTemplate = "statusMessageTemplate"
 	fieldCheckTags                  = "tags"
 	fieldCheckThresholds            = "thresholds"
 	fieldCheckTimeSince             = "timeSince"
+	fieldCheckUnit                  = "unit"
+	fieldCheckWindow                = "window"
 )
 
 type check struct {
@@ -968,6 +989,7 @@ type check struct {
 	orgID         influxdb.ID
 	kind          checkKind
 	name          *references
+	changeType    string
 	description   string
 	every         time.Duration
 	level         string
@@ -980,6 +1002,8 @@ type check struct {
 	tags          []struct{ k, v string }
 	timeSince     time.Duration
 	thresholds    []threshold
+	unit          time.Duration
+	window        time.Duration
 
 	labels sortedLabels
 
@@ -1042,6 +1066,14 @@ func (c *check) summarize() SummaryCheck {
 			Base:       base,
 			Thresholds: toInfluxThresholds(c.thresholds...),
 		}
+	case checkKindChange:
+		sum.Check = &icheck.Change{
+			Base:       base,
+			Window:     toNotificationDuration(c.window),
+			ChangeType: c.changeType,
+			Unit:       toNotificationDuration(c.unit),
+			Thresholds: toInfluxThresholds(c.thresholds...),
+		}
 	case checkKindDeadman:
 		sum.Check = &icheck.Deadman{
 			Base:       base,
@@ -1082,6 +1114,29 @@ func (c *check) valid() []validationErr {
 	}
 
 	switch c.kind {
+	case checkKindChange:
+		if c.window == 0 {
+			vErrs = append(vErrs, validationErr{
+				Field: fieldCheckWindow,
+				Msg:   "duration value must be provided",
+			})
+		}
+		switch c.changeType {
+		case icheck.ChangeAbsolute, icheck.ChangePercent:
+		case icheck.ChangeDerivative:
+			if c.unit == 0 {
+				vErrs = append(vErrs, validationErr{
+					Field: fieldCheckUnit,
+					Msg:   "duration value must be provided for a derivative change",
+				})
+			}
+		default:
+			vErrs = append(vErrs, validationErr{
+				Field: fieldCheckChangeType,
+				Msg:   fmt.Sprintf("must be 1 in [absolute, percent, derivative]; got=%q", c.changeType),
+			})
+		}
+		fallthrough
 	case checkKindThreshold:
 		if len(c.thresholds) == 0 {
 			vErrs = append(vErrs, validationErr{
@@ -1379,6 +1434,7 @@ const (
 	notificationKindHTTP notificationKind = iota + 1
 	notificationKindPagerDuty
 	notificationKindSlack
+	notificationKindSMTP
 )
 
 const (
@@ -1388,9 +1444,13 @@ const (
 )
 
 const (
+	fieldNotificationEndpointFrom       = "from"
+	fieldNotificationEndpointHost       = "host"
 	fieldNotificationEndpointHTTPMethod = "method"
 	fieldNotificationEndpointPassword   = "password"
+	fieldNotificationEndpointPort       = "port"
 	fieldNotificationEndpointRoutingKey = "routingKey"
+	fieldNotificationEndpointTLSMode    = "tlsMode"
 	fieldNotificationEndpointToken      = "token"
 	fieldNotificationEndpointURL        = "url"
 	fieldNotificationEndpointUsername   = "username"
@@ -1402,10 +1462,14 @@ type notificationEndpoint struct {
 	OrgID       influxdb.ID
 	name        *references
 	description string
+	from        string
+	host        string
 	method      string
 	password    *references
+	port        int
 	routingKey  *references
 	status      string
+	tlsMode     string
 	token       *references
 	httpType    string
 	url         string
@@ -1494,6 +1558,16 @@ func (n *notificationEndpoint) summarize() SummaryNotificationEndpoint {
 			URL:   n.url,
 			Token: n.token.SecretField(),
 		}
+	case notificationKindSMTP:
+		sum.NotificationEndpoint = &endpoint.SMTP{
+			Base:     base,
+			Host:     n.host,
+			Port:     n.port,
+			TLSMode:  n.tlsMode,
+			Username: n.username.String(),
+			Password: n.password.SecretField(),
+			From:     n.from,
+		}
 	}
 	return sum
 }
@@ -1508,13 +1582,21 @@ var validEndpointHTTPMethods = map[string]bool{
 	"PUT":     true,
 }
 
+var validEndpointSMTPTLSModes = map[string]bool{
+	endpoint.SMTPTLSNone:     true,
+	endpoint.SMTPTLSStartTLS: true,
+	endpoint.SMTPTLS:         true,
+}
+
 func (n *notificationEndpoint) valid() []validationErr {
 	var failures []validationErr
-	if _, err := url.Parse(n.url); err != nil || n.url == "" {
-		failures = append(failures, validationErr{
-			Field: fieldNotificationEndpointURL,
-			Msg:   "must be valid url",
-		})
+	if n.kind != notificationKindSMTP {
+		if _, err := url.Parse(n.url); err != nil || n.url == "" {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointURL,
+				Msg:   "must be valid url",
+			})
+		}
 	}
 
 	status := influxdb.Status(n.status)
@@ -1575,6 +1657,43 @@ func (n *notificationEndpoint) valid() []validationErr {
 				),
 			})
 		}
+	case notificationKindSMTP:
+		if n.host == "" {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointHost,
+				Msg:   "must provide non empty string",
+			})
+		}
+		if n.port <= 0 || n.port > 65535 {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointPort,
+				Msg:   "must be a valid port between 1 and 65535",
+			})
+		}
+		if !validEndpointSMTPTLSModes[n.tlsMode] {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointTLSMode,
+				Msg: fmt.Sprintf(
+					"invalid tls mode provided %q; valid tls mode is 1 in [%s, %s, %s]",
+					n.tlsMode,
+					endpoint.SMTPTLSNone,
+					endpoint.SMTPTLSStartTLS,
+					endpoint.SMTPTLS,
+				),
+			})
+		}
+		if n.username.hasValue() && !n.password.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointPassword,
+				Msg:   "must provide non empty string when a username is provided",
+			})
+		}
+		if _, err := mail.ParseAddress(n.from); err != nil {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointFrom,
+				Msg:   "must be a valid email address",
+			})
+		}
 	}
 	return failures
 }
@@ -1590,13 +1709,16 @@ func (n mapperNotificationEndpoints) Len() int {
 }
 
 const (
+	fieldNotificationRuleBodyTemplate    = "bodyTemplate"
 	fieldNotificationRuleChannel         = "channel"
 	fieldNotificationRuleCurrentLevel    = "currentLevel"
 	fieldNotificationRuleEndpointName    = "endpointName"
 	fieldNotificationRuleMessageTemplate = "messageTemplate"
 	fieldNotificationRulePreviousLevel   = "previousLevel"
 	fieldNotificationRuleStatusRules     = "statusRules"
+	fieldNotificationRuleSubjectTemplate = "subjectTemplate"
 	fieldNotificationRuleTagRules        = "tagRules"
+	fieldNotificationRuleTo              = "to"
 )
 
 type notificationRule struct {
@@ -1613,6 +1735,11 @@ type notificationRule struct {
 	statusRules []struct{ curLvl, prevLvl string }
 	tagRules    []struct{ k, v, op string }
 
+	// to, subjectTemplate and bodyTemplate are the mails sent by smtp rules.
+	to              string
+	subjectTemplate string
+	bodyTemplate    string
+
 	endpointID   influxdb.ID
 	endpointName *references
 	endpointType string
@@ -1659,6 +1786,9 @@ func (r *notificationRule) summarize() SummaryNotificationRule {
 		LabelAssociations: toSummaryLabels(r.labels...),
 		Offset:            r.offset.String(),
 		MessageTemplate:   r.msgTemplate,
+		To:                r.to,
+		SubjectTemplate:   r.subjectTemplate,
+		BodyTemplate:      r.bodyTemplate,
 		Status:            r.Status(),
 		StatusRules:       toSummaryStatusRules(r.statusRules),
 		TagRules:          toSummaryTagRules(r.tagRules),
@@ -1710,6 +1840,13 @@ func (r *notificationRule) toInfluxRule() influxdb.NotificationRule {
 			Channel:         r.channel,
 			MessageTemplate: r.msgTemplate,
 		}
+	case "smtp":
+		return &rule.SMTP{
+			Base:            base,
+			To:              r.to,
+			SubjectTemplate: r.subjectTemplate,
+			BodyTemplate:    r.bodyTemplate,
+		}
 	}
 	return nil
 }
@@ -2598,8 +2735,8 @@ type color struct {
 }
 
 // TODO:
-//  - verify templates are desired
-//  - template colors so references can be shared
+//   - verify templates are desired
+//   - template colors so references can be shared
 type colors []*color
 
 func (c colors) influxViewColors() []influxdb.ViewColor {
@@ -2633,8 +2770,9 @@ func (c colors) strings() []string {
 }
 
 // TODO: looks like much of these are actually getting defaults in
-//  the UI. looking at sytem charts, seeign lots of failures for missing
-//  color types or no colors at all.
+//
+//	the UI. looking at sytem charts, seeign lots of failures for missing
+//	color types or no colors at all.
 func (c colors) hasTypes(types ...string) []validationErr {
 	tMap := make(map[string]bool)
 	for _, cc := range c {
@@ -746,6 +746,7 @@ func (p *Pkg) graphChecks() *parseErr {
 	}{
 		{kind: KindCheckThreshold, checkKind: checkKindThreshold},
 		{kind: KindCheckDeadman, checkKind: checkKindDeadman},
+		{kind: KindCheckChange, checkKind: checkKindChange},
 	}
 	var pErr parseErr
 	for _, checkKind := range checkKinds {
@@ -761,6 +762,7 @@ func (p *Pkg) graphChecks() *parseErr {
 			ch := &check{
 				kind:          checkKind.checkKind,
 				name:          nameRef,
+				changeType:    normStr(o.Spec.stringShort(fieldCheckChangeType)),
 				description:   o.Spec.stringShort(fieldDescription),
 				every:         o.Spec.durationShort(fieldEvery),
 				level:         o.Spec.stringShort(fieldLevel),
@@ -771,6 +773,8 @@ func (p *Pkg) graphChecks() *parseErr {
 				status:        normStr(o.Spec.stringShort(fieldStatus)),
 				statusMessage: o.Spec.stringShort(fieldCheckStatusMessageTemplate),
 				timeSince:     o.Spec.durationShort(fieldCheckTimeSince),
+				unit:          o.Spec.durationShort(fieldCheckUnit),
+				window:        o.Spec.durationShort(fieldCheckWindow),
 			}
 			for _, tagRes := range o.Spec.slcResource(fieldCheckTags) {
 				ch.tags = append(ch.tags, struct{ k, v string }{
@@ -865,6 +869,10 @@ func (p *Pkg) graphNotificationEndpoints() *parseErr {
 			kind:             KindNotificationEndpointSlack,
 			notificationKind: notificationKindSlack,
 		},
+		{
+			kind:             KindNotificationEndpointSMTP,
+			notificationKind: notificationKindSMTP,
+		},
 	}
 
 	var pErr parseErr
@@ -882,11 +890,15 @@ func (p *Pkg) graphNotificationEndpoints() *parseErr {
 				kind:        nk.notificationKind,
 				name:        nameRef,
 				description: o.Spec.stringShort(fieldDescription),
+				from:        strings.TrimSpace(o.Spec.stringShort(fieldNotificationEndpointFrom)),
+				host:        strings.TrimSpace(o.Spec.stringShort(fieldNotificationEndpointHost)),
 				method:      strings.TrimSpace(strings.ToUpper(o.Spec.stringShort(fieldNotificationEndpointHTTPMethod))),
 				httpType:    normStr(o.Spec.stringShort(fieldType)),
 				password:    o.Spec.references(fieldNotificationEndpointPassword),
+				port:        o.Spec.intShort(fieldNotificationEndpointPort),
 				routingKey:  o.Spec.references(fieldNotificationEndpointRoutingKey),
 				status:      normStr(o.Spec.stringShort(fieldStatus)),
+				tlsMode:     normStr(o.Spec.stringShort(fieldNotificationEndpointTLSMode)),
 				token:       o.Spec.references(fieldNotificationEndpointToken),
 				url:         o.Spec.stringShort(fieldNotificationEndpointURL),
 				username:    o.Spec.references(fieldNotificationEndpointUsername),
@@ -925,6 +937,10 @@ func (p *Pkg) graphNotificationRules() *parseErr {
 			msgTemplate:  o.Spec.stringShort(fieldNotificationRuleMessageTemplate),
 			offset:       o.Spec.durationShort(fieldOffset),
 			status:       normStr(o.Spec.stringShort(fieldStatus)),
+
+			to:              o.Spec.stringShort(fieldNotificationRuleTo),
+			subjectTemplate: o.Spec.stringShort(fieldNotificationRuleSubjectTemplate),
+			bodyTemplate:    o.Spec.stringShort(fieldNotificationRuleBodyTemplate),
 		}
 
 		for _, sRule := range o.Spec.slcResource(fieldNotificationRuleStatusRules) {
@@ -838,6 +838,120 @@ spec:
 				testPkgErrors(t, tt.kind, tt.resErr)
 			}
 		})
+
+		t.Run("change check", func(t *testing.T) {
+			testfileRunner(t, "testdata/check_change", func(t *testing.T, pkg *Pkg) {
+				sum := pkg.Summary()
+				require.Len(t, sum.Checks, 1)
+
+				changeCheck, ok := sum.Checks[0].Check.(*icheck.Change)
+				require.Truef(t, ok, "got: %#v", sum.Checks[0])
+
+				assert.Equal(t, "disk_growth", changeCheck.Name)
+				assert.Equal(t, "disk usage grows too fast", changeCheck.Description)
+				assert.Equal(t, mustDuration(t, 5*time.Minute), changeCheck.Every)
+				assert.Equal(t, mustDuration(t, time.Hour), changeCheck.Window)
+				assert.Equal(t, icheck.ChangePercent, changeCheck.ChangeType)
+				expectedThresholds := []icheck.ThresholdConfig{
+					icheck.Greater{
+						ThresholdConfigBase: icheck.ThresholdConfigBase{Level: notification.Critical},
+						Value:               10.0,
+					},
+					icheck.Greater{
+						ThresholdConfigBase: icheck.ThresholdConfigBase{Level: notification.Warn},
+						Value:               5.0,
+					},
+				}
+				assert.Equal(t, expectedThresholds, changeCheck.Thresholds)
+				assert.Equal(t, influxdb.Active, sum.Checks[0].Status)
+			})
+
+			tests := []testPkgResourceError{
+				{
+					name:           "missing window",
+					validationErrs: 1,
+					valFields:      []string{fieldCheckWindow},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: CheckChange
+metadata:
+  name: check_0
+spec:
+  every: 5m
+  changeType: absolute
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  thresholds:
+    - type: greater
+      level: crit
+      value: 10.0
+`,
+				},
+				{
+					name:           "invalid change type",
+					validationErrs: 1,
+					valFields:      []string{fieldCheckChangeType},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: CheckChange
+metadata:
+  name: check_0
+spec:
+  every: 5m
+  window: 1h
+  changeType: ratio
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  thresholds:
+    - type: greater
+      level: crit
+      value: 10.0
+`,
+				},
+				{
+					name:           "derivative without unit",
+					validationErrs: 1,
+					valFields:      []string{fieldCheckUnit},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: CheckChange
+metadata:
+  name: check_0
+spec:
+  every: 5m
+  window: 1h
+  changeType: derivative
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  thresholds:
+    - type: greater
+      level: crit
+      value: 10.0
+`,
+				},
+				{
+					name:           "missing thresholds",
+					validationErrs: 1,
+					valFields:      []string{fieldCheckThresholds},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: CheckChange
+metadata:
+  name: check_0
+spec:
+  every: 5m
+  window: 1h
+  changeType: absolute
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+`,
+				},
+			}
+
+			for _, tt := range tests {
+				testPkgErrors(t, KindCheckChange, tt)
+			}
+		})
 	})
 
 	t.Run("pkg with single dashboard and single chart", func(t *testing.T) {
@@ -2740,6 +2854,43 @@ kind: NotificationEndpointPagerDuty
 metadata:
   name: pager_duty_notification_endpoint
 spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointSMTP,
+					resErr: testPkgResourceError{
+						name:           "invalid smtp tls mode",
+						validationErrs: 1,
+						valFields:      []string{fieldNotificationEndpointTLSMode},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointSMTP
+metadata:
+  name: smtp_notification_endpoint
+spec:
+  host: smtp.example.com
+  port: 465
+  tlsMode: ssl
+  from: alerts@example.com
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointSMTP,
+					resErr: testPkgResourceError{
+						name:           "missing smtp password",
+						validationErrs: 1,
+						valFields:      []string{fieldNotificationEndpointPassword},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointSMTP
+metadata:
+  name: smtp_notification_endpoint
+spec:
+  host: smtp.example.com
+  port: 587
+  tlsMode: starttls
+  username: alerts
+  from: alerts@example.com
 `,
 					},
 				},
@@ -2947,6 +3098,36 @@ spec:
 		})
 	})
 
+	t.Run("pkg with smtp notification endpoint and rule", func(t *testing.T) {
+		testfileRunner(t, "testdata/notification_endpoint_smtp.yml", func(t *testing.T, pkg *Pkg) {
+			sum := pkg.Summary()
+
+			require.Len(t, sum.NotificationEndpoints, 1)
+			expectedEndpoint := &endpoint.SMTP{
+				Base: endpoint.Base{
+					Name:        "smtp_notification_endpoint",
+					Description: "smtp desc",
+					Status:      influxdb.TaskStatusActive,
+				},
+				Host:     "smtp.example.com",
+				Port:     587,
+				TLSMode:  "starttls",
+				Username: "alerts",
+				Password: influxdb.SecretField{Value: strPtr("secret password")},
+				From:     "InfluxDB <alerts@example.com>",
+			}
+			assert.Equal(t, expectedEndpoint, sum.NotificationEndpoints[0].NotificationEndpoint)
+
+			require.Len(t, sum.NotificationRules, 1)
+			actual := sum.NotificationRules[0]
+			assert.Equal(t, "smtp_rule", actual.Name)
+			assert.Equal(t, "smtp_notification_endpoint", actual.EndpointName)
+			assert.Equal(t, "oncall@example.com, ops@example.com", actual.To)
+			assert.Equal(t, "${ r._check_name } is ${ r._level }", actual.SubjectTemplate)
+			assert.Equal(t, "Notification Rule: ${ r._notification_rule_name } triggered by check: ${ r._check_name }: ${ r._message }", actual.BodyTemplate)
+		})
+	})
+
 	t.Run("pkg with notification rules", func(t *testing.T) {
 		t.Run("happy path", func(t *testing.T) {
 			testfileRunner(t, "testdata/notification_rule", func(t *testing.T, pkg *Pkg) {
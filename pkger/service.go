@@ -274,13 +274,15 @@ func (s *Service) CreatePkg(ctx context.Context, setters ...CreatePkgSetFn) (*Pk
 		KindBucket:                        2,
 		KindCheckDeadman:                  3,
 		KindCheckThreshold:                4,
-		KindNotificationEndpointHTTP:      5,
-		KindNotificationEndpointPagerDuty: 6,
-		KindNotificationEndpointSlack:     7,
-		KindNotificationRule:              8,
-		KindVariable:                      9,
-		KindTelegraf:                      10,
-		KindDashboard:                     11,
+		KindCheckChange:                   5,
+		KindNotificationEndpointHTTP:      6,
+		KindNotificationEndpointPagerDuty: 7,
+		KindNotificationEndpointSlack:     8,
+		KindNotificationEndpointSMTP:      9,
+		KindNotificationRule:              10,
+		KindVariable:                      11,
+		KindTelegraf:                      12,
+		KindDashboard:                     13,
 	}
 
 	sort.Slice(pkg.Objects, func(i, j int) bool {
@@ -523,6 +525,7 @@ func (s *Service) resourceCloneToKind(ctx context.Context, r ResourceToClone, cF
 		}
 		newKind = bucketToObject(*bkt, r.Name)
 	case r.Kind.is(KindCheck),
+		r.Kind.is(KindCheckChange),
 		r.Kind.is(KindCheckDeadman),
 		r.Kind.is(KindCheckThreshold):
 		ch, err := s.checkSVC.FindCheckByID(ctx, r.ID)
@@ -545,7 +548,8 @@ func (s *Service) resourceCloneToKind(ctx context.Context, r ResourceToClone, cF
 	case r.Kind.is(KindNotificationEndpoint),
 		r.Kind.is(KindNotificationEndpointHTTP),
 		r.Kind.is(KindNotificationEndpointPagerDuty),
-		r.Kind.is(KindNotificationEndpointSlack):
+		r.Kind.is(KindNotificationEndpointSlack),
+		r.Kind.is(KindNotificationEndpointSMTP):
 		e, err := s.endpointSVC.FindNotificationEndpointByID(ctx, r.ID)
 		if err != nil {
 			return nil, err
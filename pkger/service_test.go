@@ -1574,6 +1574,21 @@ func TestService(t *testing.T) {
 							Level:      notification.Critical,
 						},
 					},
+					{
+						name: "change",
+						expected: &icheck.Change{
+							Base:       newThresholdBase(2),
+							Window:     mustDuration(t, time.Hour),
+							ChangeType: icheck.ChangeDerivative,
+							Unit:       mustDuration(t, time.Minute),
+							Thresholds: []icheck.ThresholdConfig{
+								icheck.Greater{
+									ThresholdConfigBase: icheck.ThresholdConfigBase{Level: notification.Critical},
+									Value:               2,
+								},
+							},
+						},
+					},
 				}
 
 				for _, tt := range tests {
@@ -1610,6 +1625,14 @@ func TestService(t *testing.T) {
 							expectedName = tt.newName
 						}
 						assert.Equal(t, expectedName, actual.GetName())
+						if expected, ok := tt.expected.(*icheck.Change); ok {
+							actual, ok := actual.(*icheck.Change)
+							require.True(t, ok)
+							assert.Equal(t, expected.Window, actual.Window)
+							assert.Equal(t, expected.ChangeType, actual.ChangeType)
+							assert.Equal(t, expected.Unit, actual.Unit)
+							assert.Equal(t, expected.Thresholds, actual.Thresholds)
+						}
 					}
 					t.Run(tt.name, fn)
 				}
@@ -2113,6 +2136,22 @@ func TestService(t *testing.T) {
 							URL:        "http://example.com",
 						},
 					},
+					{
+						name: "smtp",
+						expected: &endpoint.SMTP{
+							Base: endpoint.Base{
+								Name:        "smtp-endpoint",
+								Description: "desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							Host:     "smtp.example.com",
+							Port:     587,
+							TLSMode:  "starttls",
+							Username: "alerts",
+							Password: influxdb.SecretField{Key: "password"},
+							From:     "alerts@example.com",
+						},
+					},
 				}
 
 				for _, tt := range tests {
@@ -2152,6 +2191,15 @@ func TestService(t *testing.T) {
 						assert.Equal(t, tt.expected.GetDescription(), actual.GetDescription())
 						assert.Equal(t, tt.expected.GetStatus(), actual.GetStatus())
 						assert.Equal(t, tt.expected.SecretFields(), actual.SecretFields())
+
+						if expected, ok := tt.expected.(*endpoint.SMTP); ok {
+							actual := actual.(*endpoint.SMTP)
+							assert.Equal(t, expected.Host, actual.Host)
+							assert.Equal(t, expected.Port, actual.Port)
+							assert.Equal(t, expected.TLSMode, actual.TLSMode)
+							assert.Equal(t, expected.Username, actual.Username)
+							assert.Equal(t, expected.From, actual.From)
+						}
 					}
 					t.Run(tt.name, fn)
 				}
@@ -2235,6 +2283,27 @@ func TestService(t *testing.T) {
 							Base: newRuleBase(13),
 						},
 					},
+					{
+						name: "smtp",
+						endpoint: &endpoint.SMTP{
+							Base: endpoint.Base{
+								ID:          newTestIDPtr(13),
+								Name:        "endpoint_0",
+								Description: "desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							Host:    "smtp.example.com",
+							Port:    25,
+							TLSMode: "none",
+							From:    "alerts@example.com",
+						},
+						rule: &rule.SMTP{
+							Base:            newRuleBase(13),
+							To:              "oncall@example.com, ops@example.com",
+							SubjectTemplate: "${r._check_name} is ${r._level}",
+							BodyTemplate:    "${r._message}",
+						},
+					},
 				}
 
 				for _, tt := range tests {
@@ -2312,6 +2381,11 @@ func TestService(t *testing.T) {
 						case *rule.Slack:
 							baseEqual(t, p.Base)
 							assert.Equal(t, p.MessageTemplate, actualRule.MessageTemplate)
+						case *rule.SMTP:
+							baseEqual(t, p.Base)
+							assert.Equal(t, p.To, actualRule.To)
+							assert.Equal(t, p.SubjectTemplate, actualRule.SubjectTemplate)
+							assert.Equal(t, p.BodyTemplate, actualRule.BodyTemplate)
 						}
 
 						require.Len(t, pkg.Summary().NotificationEndpoints, 1)
@@ -0,0 +1,144 @@
+package influxql
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+)
+
+// CSVMultiResultEncoder encodes results in the CSV format of the influxdb 1.X http api.
+// Every series is written with its name and tags as the first two columns. A new
+// header is written whenever the columns differ from the previous series.
+type CSVMultiResultEncoder struct {
+	// TimeFormat is the format used to encode values of time columns. Times
+	// formatted as RFC3339Nano are written as nanosecond epochs, as influxdb 1.X does.
+	TimeFormat TimeFormat
+}
+
+func NewCSVMultiResultEncoder() *CSVMultiResultEncoder {
+	return new(CSVMultiResultEncoder)
+}
+
+// Encode writes a collection of results as CSV to w.
+func (e *CSVMultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	cw := csv.NewWriter(wc)
+
+	tf := e.TimeFormat
+	if tf == RFC3339Nano {
+		tf = Nanosecond
+	}
+
+	var columns []string
+	err := walkResults(results, tf, func(id int, row *Row) error {
+		if !equalColumns(columns, row.Columns) {
+			if columns != nil {
+				// separate the series of different shapes by an empty line.
+				cw.Flush()
+				if _, err := io.WriteString(wc, "\n"); err != nil {
+					return err
+				}
+			}
+			columns = row.Columns
+			if err := cw.Write(append([]string{"name", "tags"}, columns...)); err != nil {
+				return err
+			}
+		}
+
+		tags := formatTags(row.Tags)
+		record := make([]string, len(columns)+2)
+		for _, values := range row.Values {
+			record[0], record[1] = row.Name, tags
+			for i, v := range values {
+				record[i+2] = formatCSVValue(v)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func(id int) error {
+		return nil
+	})
+	if err != nil {
+		if columns != nil {
+			cw.Flush()
+			if _, err := io.WriteString(wc, "\n"); err != nil {
+				return wc.Count(), err
+			}
+		}
+		if err := cw.Write([]string{"error"}); err != nil {
+			return wc.Count(), err
+		}
+		if err := cw.Write([]string{err.Error()}); err != nil {
+			return wc.Count(), err
+		}
+	}
+
+	cw.Flush()
+	return wc.Count(), cw.Error()
+}
+
+func equalColumns(a, b []string) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatTags formats tags as comma separated key=value pairs sorted by key.
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+	return b.String()
+}
+
+func formatCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	case time.Time:
+		return strconv.FormatInt(v.UnixNano(), 10)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
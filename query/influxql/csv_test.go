@@ -0,0 +1,85 @@
+package influxql_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query/influxql"
+)
+
+func TestCSVMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   flux.ResultIterator
+		out  string
+	}{
+		{
+			name: "Default",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "host", "region"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "region", Type: flux.TString},
+								{Label: "value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m0", "server01", "east", float64(2)},
+								{ts("2018-05-24T09:00:10Z"), "m0", "server01", "east", float64(2.5)},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "count", Type: flux.TInt},
+							},
+							Data: [][]interface{}{
+								{ts("2018-05-24T09:00:00Z"), "m1", int64(7)},
+							},
+						},
+					},
+				}},
+			),
+			out: `name,tags,time,value
+m0,"host=server01,region=east",1527152400000000000,2
+m0,"host=server01,region=east",1527152410000000000,2.5
+
+name,tags,time,count
+m1,,1527152400000000000,7
+`,
+		},
+		{
+			name: "Error",
+			in:   &resultErrorIterator{Error: "expected"},
+			out: `error
+expected
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := influxql.NewCSVMultiResultEncoder()
+			n, err := enc.Encode(&buf, tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count: want %d got %d", w, g)
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func init() {
 		OrganizationID:  platformtesting.MustIDBase16("cadecadecadecade"),
 		BucketID:        platformtesting.MustIDBase16("da7aba5e5eedca5e"),
 	}
-	dbrpMappingSvcE2E.FindByFn = func(ctx context.Context, cluster string, db string, rp string) (*platform.DBRPMapping, error) {
+	dbrpMappingSvcE2E.FindByFn = func(ctx context.Context, orgID platform.ID, cluster string, db string, rp string) (*platform.DBRPMapping, error) {
 		return &mapping, nil
 	}
 	dbrpMappingSvcE2E.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
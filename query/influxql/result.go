@@ -13,7 +13,15 @@ import (
 )
 
 // MultiResultEncoder encodes results as InfluxQL JSON format.
-type MultiResultEncoder struct{}
+type MultiResultEncoder struct {
+	// TimeFormat is the format used to encode values of time columns.
+	TimeFormat TimeFormat
+	// ChunkSize enables chunked encoding when greater than zero. Each series is then
+	// written as its own newline delimited response holding at most ChunkSize values.
+	ChunkSize int
+	// Pretty indents the encoded JSON.
+	Pretty bool
+}
 
 // Encode writes a collection of results to the influxdb 1.X http response format.
 // Expectations/Assumptions:
@@ -28,163 +36,302 @@ type MultiResultEncoder struct{}
 //      TODO(jsternberg): This function currently requires the first column to be a time field, but this isn't
 //      a strict requirement and will be lifted when we begin to work on transpiling meta queries.
 func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
-	resp := Response{}
 	wc := &iocounter.Writer{Writer: w}
+	enc := json.NewEncoder(wc)
+	if e.Pretty {
+		enc.SetIndent("", "    ")
+	}
+
+	if e.ChunkSize > 0 {
+		return e.encodeChunked(wc, enc, results)
+	}
+
+	resp := Response{}
+	var result *Result
+	err := walkResults(results, e.TimeFormat, func(id int, row *Row) error {
+		if result == nil {
+			result = &Result{StatementID: id}
+		}
+		result.Series = append(result.Series, row)
+		return nil
+	}, func(id int) error {
+		if result == nil {
+			result = &Result{StatementID: id}
+		}
+		resp.Results = append(resp.Results, *result)
+		result = nil
+		return nil
+	})
+	if err != nil {
+		resp.error(err)
+	}
+
+	err = enc.Encode(resp)
+	return wc.Count(), err
+}
+
+// encodeChunked streams every series as soon as it has been read. The last chunk
+// of a statement is the only one that is not marked as partial, which matches
+// the chunked responses of influxdb 1.X.
+func (e *MultiResultEncoder) encodeChunked(wc *iocounter.Writer, enc *json.Encoder, results flux.ResultIterator) (int64, error) {
+	var (
+		writeErr error
+		pending  *Result
+	)
+	write := func(resp Response) error {
+		if err := enc.Encode(resp); err != nil {
+			writeErr = err
+			return err
+		}
+		return nil
+	}
+	flush := func(partial bool) error {
+		if pending == nil {
+			return nil
+		}
+		result := *pending
+		result.Partial = partial
+		pending = nil
+		return write(Response{Results: []Result{result}})
+	}
 
+	err := walkResults(results, e.TimeFormat, func(id int, row *Row) error {
+		for _, chunk := range chunkRow(row, e.ChunkSize) {
+			if err := flush(true); err != nil {
+				return err
+			}
+			pending = &Result{StatementID: id, Series: []*Row{chunk}}
+		}
+		return nil
+	}, func(id int) error {
+		if pending == nil {
+			pending = &Result{StatementID: id}
+		}
+		return flush(false)
+	})
+	if writeErr != nil {
+		return wc.Count(), writeErr
+	}
+	if err != nil {
+		if err := flush(false); err != nil {
+			return wc.Count(), err
+		}
+		resp := Response{}
+		resp.error(err)
+		if err := write(resp); err != nil {
+			return wc.Count(), err
+		}
+	}
+	return wc.Count(), nil
+}
+
+// chunkRow splits a row into rows of at most size values. All but the last
+// of the returned rows are marked as partial.
+func chunkRow(row *Row, size int) []*Row {
+	if len(row.Values) <= size {
+		return []*Row{row}
+	}
+
+	var rows []*Row
+	for values := row.Values; len(values) > 0; {
+		n := size
+		if n > len(values) {
+			n = len(values)
+		}
+		rows = append(rows, &Row{
+			Name:    row.Name,
+			Tags:    row.Tags,
+			Columns: row.Columns,
+			Values:  values[:n],
+			Partial: n < len(values),
+		})
+		values = values[n:]
+	}
+	return rows
+}
+
+func NewMultiResultEncoder() *MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+// walkResults converts every table of every result into a row. The row function
+// is called for each converted table and the done function once all tables of a
+// statement have been read. The results are released when an error occurs.
+func walkResults(results flux.ResultIterator, tf TimeFormat, row func(id int, row *Row) error, done func(id int) error) error {
 	for results.More() {
 		res := results.Next()
 		name := res.Name()
 		id, err := strconv.Atoi(name)
 		if err != nil {
-			resp.error(fmt.Errorf("unable to parse statement id from result name: %s", err))
 			results.Release()
-			break
+			return fmt.Errorf("unable to parse statement id from result name: %s", err)
 		}
 
-		tables := res.Tables()
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			r, err := tableToRow(tbl, tf)
+			if err != nil {
+				return err
+			}
+			return row(id, r)
+		}); err != nil {
+			results.Release()
+			return err
+		}
 
-		result := Result{StatementID: id}
-		if err := tables.Do(func(tbl flux.Table) error {
-			var row Row
+		if err := done(id); err != nil {
+			results.Release()
+			return err
+		}
+	}
+	return results.Err()
+}
 
-			for j, c := range tbl.Key().Cols() {
-				if c.Type != flux.TString {
-					// Skip any columns that aren't strings. They are extra ones that
-					// flux includes by default like the start and end times that we do not
-					// care about.
-					continue
-				}
-				v := tbl.Key().Value(j).Str()
-				if c.Label == "_measurement" {
-					row.Name = v
-				} else if c.Label == "_field" {
-					// If the field key was not removed by a previous operation, we explicitly
-					// ignore it here when encoding the result back.
-				} else {
-					if row.Tags == nil {
-						row.Tags = make(map[string]string)
-					}
-					row.Tags[c.Label] = v
-				}
-			}
+// tableToRow converts a flux table into a single InfluxQL series.
+func tableToRow(tbl flux.Table, tf TimeFormat) (*Row, error) {
+	var row Row
 
-			// TODO: resultColMap should be constructed from query metadata once it is provided.
-			// for now we know that an influxql query ALWAYS has time first, so we put this placeholder
-			// here to catch this most obvious requirement.  Column orderings should be explicitly determined
-			// from the ordering given in the original flux.
-			resultColMap := map[string]int{}
-			j := 1
-			for _, c := range tbl.Cols() {
-				if c.Label == execute.DefaultTimeColLabel {
-					resultColMap[c.Label] = 0
-				} else if !tbl.Key().HasCol(c.Label) {
-					resultColMap[c.Label] = j
-					j++
-				}
+	for j, c := range tbl.Key().Cols() {
+		if c.Type != flux.TString {
+			// Skip any columns that aren't strings. They are extra ones that
+			// flux includes by default like the start and end times that we do not
+			// care about.
+			continue
+		}
+		v := tbl.Key().Value(j).Str()
+		if c.Label == "_measurement" {
+			row.Name = v
+		} else if c.Label == "_field" {
+			// If the field key was not removed by a previous operation, we explicitly
+			// ignore it here when encoding the result back.
+		} else {
+			if row.Tags == nil {
+				row.Tags = make(map[string]string)
 			}
+			row.Tags[c.Label] = v
+		}
+	}
 
-			if _, ok := resultColMap[execute.DefaultTimeColLabel]; !ok {
-				for k, v := range resultColMap {
-					resultColMap[k] = v - 1
-				}
-			}
+	// TODO: resultColMap should be constructed from query metadata once it is provided.
+	// for now we know that an influxql query ALWAYS has time first, so we put this placeholder
+	// here to catch this most obvious requirement.  Column orderings should be explicitly determined
+	// from the ordering given in the original flux.
+	resultColMap := map[string]int{}
+	j := 1
+	for _, c := range tbl.Cols() {
+		if c.Label == execute.DefaultTimeColLabel {
+			resultColMap[c.Label] = 0
+		} else if !tbl.Key().HasCol(c.Label) {
+			resultColMap[c.Label] = j
+			j++
+		}
+	}
 
-			row.Columns = make([]string, len(resultColMap))
-			for k, v := range resultColMap {
-				if k == execute.DefaultTimeColLabel {
-					k = "time"
-				}
-				row.Columns[v] = k
+	if _, ok := resultColMap[execute.DefaultTimeColLabel]; !ok {
+		for k, v := range resultColMap {
+			resultColMap[k] = v - 1
+		}
+	}
+
+	row.Columns = make([]string, len(resultColMap))
+	for k, v := range resultColMap {
+		if k == execute.DefaultTimeColLabel {
+			k = "time"
+		}
+		row.Columns[v] = k
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		// Preallocate the number of rows for the response to make this section
+		// of code easier to read. Find a time column which should exist
+		// in the output.
+		values := make([][]interface{}, cr.Len())
+		for j := range values {
+			values[j] = make([]interface{}, len(row.Columns))
+		}
+
+		j := 0
+		for idx, c := range tbl.Cols() {
+			if cr.Key().HasCol(c.Label) {
+				continue
 			}
 
-			if err := tbl.Do(func(cr flux.ColReader) error {
-				// Preallocate the number of rows for the response to make this section
-				// of code easier to read. Find a time column which should exist
-				// in the output.
-				values := make([][]interface{}, cr.Len())
-				for j := range values {
-					values[j] = make([]interface{}, len(row.Columns))
+			j = resultColMap[c.Label]
+			// Fill in the values for each column.
+			switch c.Type {
+			case flux.TFloat:
+				vs := cr.Floats(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = vs.Value(i)
+					}
 				}
-
-				j := 0
-				for idx, c := range tbl.Cols() {
-					if cr.Key().HasCol(c.Label) {
-						continue
+			case flux.TInt:
+				vs := cr.Ints(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = vs.Value(i)
 					}
-
-					j = resultColMap[c.Label]
-					// Fill in the values for each column.
-					switch c.Type {
-					case flux.TFloat:
-						vs := cr.Floats(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = vs.Value(i)
-							}
-						}
-					case flux.TInt:
-						vs := cr.Ints(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = vs.Value(i)
-							}
-						}
-					case flux.TString:
-						vs := cr.Strings(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = vs.ValueString(i)
-							}
-						}
-					case flux.TUInt:
-						vs := cr.UInts(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = vs.Value(i)
-							}
-						}
-					case flux.TBool:
-						vs := cr.Bools(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = vs.Value(i)
-							}
-						}
-					case flux.TTime:
-						vs := cr.Times(idx)
-						for i := 0; i < vs.Len(); i++ {
-							if vs.IsValid(i) {
-								values[i][j] = execute.Time(vs.Value(i)).Time().Format(time.RFC3339Nano)
-							}
-						}
-					default:
-						return fmt.Errorf("unsupported column type: %s", c.Type)
+				}
+			case flux.TString:
+				vs := cr.Strings(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = vs.ValueString(i)
 					}
-
 				}
-				row.Values = append(row.Values, values...)
-				return nil
-			}); err != nil {
-				return err
+			case flux.TUInt:
+				vs := cr.UInts(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = vs.Value(i)
+					}
+				}
+			case flux.TBool:
+				vs := cr.Bools(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = vs.Value(i)
+					}
+				}
+			case flux.TTime:
+				vs := cr.Times(idx)
+				for i := 0; i < vs.Len(); i++ {
+					if vs.IsValid(i) {
+						values[i][j] = formatTime(execute.Time(vs.Value(i)).Time(), tf)
+					}
+				}
+			default:
+				return fmt.Errorf("unsupported column type: %s", c.Type)
 			}
 
-			result.Series = append(result.Series, &row)
-			return nil
-		}); err != nil {
-			resp.error(err)
-			results.Release()
-			break
 		}
-		resp.Results = append(resp.Results, result)
+		row.Values = append(row.Values, values...)
+		return nil
+	}); err != nil {
+		return nil, err
 	}
+	return &row, nil
+}
 
-	if err := results.Err(); err != nil && resp.Err == "" {
-		resp.error(err)
+// formatTime encodes t as an RFC3339 string or as an integer epoch in the
+// precision requested by tf.
+func formatTime(t time.Time, tf TimeFormat) interface{} {
+	var unit time.Duration
+	switch tf {
+	case Hour:
+		unit = time.Hour
+	case Minute:
+		unit = time.Minute
+	case Second:
+		unit = time.Second
+	case Millisecond:
+		unit = time.Millisecond
+	case Microsecond:
+		unit = time.Microsecond
+	case Nanosecond:
+		unit = time.Nanosecond
+	default:
+		return t.Format(time.RFC3339Nano)
 	}
-
-	err := json.NewEncoder(wc).Encode(resp)
-	return wc.Count(), err
-}
-func NewMultiResultEncoder() *MultiResultEncoder {
-	return new(MultiResultEncoder)
+	return t.UnixNano() / int64(unit)
 }
@@ -106,6 +106,72 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 	}
 }
 
+func TestMultiResultEncoder_EncodeOptions(t *testing.T) {
+	newResults := func() flux.ResultIterator {
+		return flux.NewSliceResultIterator(
+			[]flux.Result{&executetest.Result{
+				Nm: "0",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+						{ts("2018-05-24T09:00:10Z"), "m0", "server01", float64(3)},
+						{ts("2018-05-24T09:00:20Z"), "m0", "server01", float64(4)},
+					},
+				}},
+			}},
+		)
+	}
+
+	for _, tt := range []struct {
+		name string
+		enc  *influxql.MultiResultEncoder
+		out  string
+	}{
+		{
+			name: "Epoch",
+			enc:  &influxql.MultiResultEncoder{TimeFormat: influxql.Second},
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[[1527152400,2],[1527152410,3],[1527152420,4]]}]}]}
+`,
+		},
+		{
+			name: "Chunked",
+			enc:  &influxql.MultiResultEncoder{TimeFormat: influxql.Millisecond, ChunkSize: 2},
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[[1527152400000,2],[1527152410000,3]],"partial":true}],"partial":true}]}
+{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[[1527152420000,4]]}]}]}
+`,
+		},
+		{
+			name: "Chunked Without Splitting",
+			enc:  &influxql.MultiResultEncoder{ChunkSize: 10},
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[["2018-05-24T09:00:00Z",2],["2018-05-24T09:00:10Z",3],["2018-05-24T09:00:20Z",4]]}]}]}
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.enc.Encode(&buf, newResults())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+			}
+		})
+	}
+}
+
 type resultErrorIterator struct {
 	Error string
 }
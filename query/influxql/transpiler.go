@@ -696,15 +696,19 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 		}
 
 		var filter influxdb.DBRPMappingFilter
-		filter.Cluster = &t.config.Cluster
+		if t.config.Cluster != "" {
+			filter.Cluster = &t.config.Cluster
+		}
 		if db != "" {
 			filter.Database = &db
 		}
 		if rp != "" {
 			filter.RetentionPolicy = &rp
+		} else {
+			// without an explicit retention policy the default one of the database is used.
+			defaultRP := true
+			filter.Default = &defaultRP
 		}
-		defaultRP := rp == ""
-		filter.Default = &defaultRP
 		mapping, err := t.dbrpMappingSvc.Find(context.TODO(), filter)
 		if err != nil {
 			if !t.config.FallbackToDBRP {
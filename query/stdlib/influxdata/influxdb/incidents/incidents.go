@@ -0,0 +1,131 @@
+// Package incidents registers the Flux influxdata/influxdb/incidents package,
+// which consults the incidents of the checks of an organization. The Flux
+// generated by notification rules that skip acknowledged incidents uses it to
+// drop the statuses of the incidents someone is handling:
+//
+//	import "influxdata/influxdb/incidents"
+//
+//	all_statuses = statuses
+//	    |> incidents.unacknowledged(orgID: "0000000000000001")
+package incidents
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/internal/statusdep"
+)
+
+const pkgpath = "influxdata/influxdb/incidents"
+
+const source = `
+package incidents
+
+// acknowledged reports whether the open incident of the check and the tags
+// of the status r is acknowledged.
+builtin acknowledged
+
+// unacknowledged keeps the statuses whose open incident is not acknowledged.
+unacknowledged = (tables=<-, orgID) => tables
+    |> filter(fn: (r) => not acknowledged(orgID: orgID, r: r))
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = pkgpath
+	flux.RegisterPackage(pkg)
+	flux.RegisterPackageValue(pkgpath, "acknowledged", values.NewFunction(
+		"acknowledged",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"orgID": semantic.String,
+				"r":     semantic.Tvar(1),
+			},
+			Required: semantic.LabelSet{"orgID", "r"},
+			Return:   semantic.Bool,
+		}),
+		acknowledged,
+		false,
+	))
+}
+
+type key int
+
+const dependencyKey key = iota
+
+// Dependency provides the incident service to the incidents package.
+type Dependency struct {
+	IncidentService influxdb.IncidentService
+}
+
+// Inject implements flux.Dependency. The acknowledged incidents of an
+// organization are loaded once per query, which is injected its own
+// dependency.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dependencyKey, &dependency{
+		Dependency:   d,
+		acknowledged: statusdep.NewOrgCache(d.load),
+	})
+}
+
+// GetDependency returns the incidents dependency of the context, if any.
+func GetDependency(ctx context.Context) (Dependency, bool) {
+	d, ok := ctx.Value(dependencyKey).(*dependency)
+	if !ok {
+		return Dependency{}, false
+	}
+	return d.Dependency, d.IncidentService != nil
+}
+
+// load returns the status keys of the acknowledged open incidents of the
+// organization.
+func (d Dependency) load(ctx context.Context, orgID influxdb.ID) (interface{}, error) {
+	open, acknowledged := influxdb.IncidentOpen, true
+	is, _, err := d.IncidentService.FindIncidents(ctx, influxdb.IncidentFilter{
+		OrgID:        &orgID,
+		Status:       &open,
+		Acknowledged: &acknowledged,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(is))
+	for _, i := range is {
+		keys[i.Key] = true
+	}
+	return keys, nil
+}
+
+// dependency is the incidents dependency of a query.
+type dependency struct {
+	Dependency
+	acknowledged *statusdep.OrgCache
+}
+
+func acknowledged(ctx context.Context, args values.Object) (values.Value, error) {
+	orgID, err := statusdep.IDArg(args, "orgID")
+	if err != nil {
+		return nil, err
+	}
+	st, err := statusdep.StatusArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an incident service nothing is ever acknowledged.
+	d, ok := ctx.Value(dependencyKey).(*dependency)
+	if !ok || d.IncidentService == nil || !st.CheckID.Valid() {
+		return values.NewBool(false), nil
+	}
+
+	keys, err := d.acknowledged.Get(ctx, orgID)
+	if err != nil {
+		return nil, &flux.Error{Code: codes.Internal, Msg: "failed to find incidents", Err: err}
+	}
+	return values.NewBool(keys.(map[string]bool)[influxdb.StatusKey(st.CheckID, st.Tags)]), nil
+}
@@ -0,0 +1,90 @@
+package incidents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/incidents"
+)
+
+func eval(t *testing.T, ctx context.Context, expr string) bool {
+	t.Helper()
+	ctx = dependenciestest.Default().Inject(ctx)
+	_, scope, err := flux.Eval(ctx, "import \"influxdata/influxdb/incidents\"\nx = "+expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok := scope.Lookup("x")
+	if !ok {
+		t.Fatal("x is not defined")
+	}
+	return v.Bool()
+}
+
+func TestAcknowledged(t *testing.T) {
+	acked := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	svc := mock.NewIncidentService()
+	var calls int
+	svc.FindIncidentsFn = func(_ context.Context, filter influxdb.IncidentFilter) ([]*influxdb.Incident, int, error) {
+		calls++
+		if filter.OrgID == nil || *filter.OrgID != 1 {
+			t.Errorf("unexpected org filter %v", filter.OrgID)
+		}
+		if filter.Status == nil || *filter.Status != influxdb.IncidentOpen {
+			t.Errorf("unexpected status filter %v", filter.Status)
+		}
+		var is []*influxdb.Incident
+		for _, i := range []*influxdb.Incident{
+			{ID: 5, OrgID: 1, CheckID: 3, Status: influxdb.IncidentOpen, Key: influxdb.StatusKey(3, map[string]string{"host": "web-1"}), AcknowledgedAt: &acked},
+			{ID: 6, OrgID: 1, CheckID: 3, Status: influxdb.IncidentOpen, Key: influxdb.StatusKey(3, map[string]string{"host": "web-2"})},
+		} {
+			if filter.Matches(i) {
+				is = append(is, i)
+			}
+		}
+		return is, len(is), nil
+	}
+	ctx := incidents.Dependency{IncidentService: svc}.Inject(context.Background())
+
+	for _, tt := range []struct {
+		name string
+		r    string
+		want bool
+	}{
+		{
+			name: "acknowledged incident",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000003", _level: "crit", host: "web-1"}`,
+			want: true,
+		},
+		{
+			name: "unacknowledged incident",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000003", _level: "crit", host: "web-2"}`,
+		},
+		{
+			name: "other check",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000004", _level: "crit", host: "web-1"}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eval(t, ctx, `incidents.acknowledged(orgID: "0000000000000001", r: `+tt.r+`)`)
+			if got != tt.want {
+				t.Errorf("expected acknowledged %t, got %t", tt.want, got)
+			}
+		})
+	}
+	if calls != 1 {
+		t.Errorf("expected the incidents to be found once, found %d times", calls)
+	}
+}
+
+func TestAcknowledged_NoDependency(t *testing.T) {
+	if eval(t, context.Background(), `incidents.acknowledged(orgID: "0000000000000001", r: {_check_id: "0000000000000003", host: "web-1"})`) {
+		t.Error("expected no acknowledged incident without an incident service")
+	}
+}
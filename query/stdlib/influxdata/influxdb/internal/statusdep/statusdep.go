@@ -0,0 +1,104 @@
+// Package statusdep provides what the Flux packages consulting the state of
+// the notifications of an organization, such as its silences and incidents,
+// share: reading the status record and the ID arguments of their builtins, and
+// loading the state of an organization once per query.
+package statusdep
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+)
+
+// LoadFunc loads the state of the organization.
+type LoadFunc func(ctx context.Context, orgID influxdb.ID) (interface{}, error)
+
+// OrgCache loads the state of an organization on its first use. A query is
+// injected its own cache, so that the state is loaded once per query.
+type OrgCache struct {
+	load LoadFunc
+
+	mu    sync.Mutex
+	byOrg map[influxdb.ID]interface{}
+}
+
+// NewOrgCache returns a cache loading the state of the organizations with load.
+func NewOrgCache(load LoadFunc) *OrgCache {
+	return &OrgCache{
+		load:  load,
+		byOrg: make(map[influxdb.ID]interface{}),
+	}
+}
+
+// Get returns the state of the organization, loaded on the first call.
+func (c *OrgCache) Get(ctx context.Context, orgID influxdb.ID) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.byOrg[orgID]; ok {
+		return v, nil
+	}
+	v, err := c.load(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	c.byOrg[orgID] = v
+	return v, nil
+}
+
+// IDArg returns the ID argument name.
+func IDArg(args values.Object, name string) (influxdb.ID, error) {
+	v, ok := args.Get(name)
+	if !ok || v.IsNull() {
+		return 0, &flux.Error{Code: codes.Invalid, Msg: "missing argument " + name}
+	}
+	id, err := influxdb.IDFromString(v.Str())
+	if err != nil {
+		return 0, &flux.Error{Code: codes.Invalid, Msg: "invalid argument " + name, Err: err}
+	}
+	return *id, nil
+}
+
+// Status is the status record argument r.
+type Status struct {
+	// CheckID is the _check_id column, not valid when it is missing.
+	CheckID influxdb.ID
+	// Time is the _time column, zero when it is missing.
+	Time values.Time
+	// Tags are the string columns not starting with an underscore.
+	Tags map[string]string
+}
+
+// StatusArg returns the status record argument r.
+func StatusArg(args values.Object) (*Status, error) {
+	r, ok := args.Get("r")
+	if !ok {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "missing argument r"}
+	}
+	if r.Type().Nature() != semantic.Object {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "argument r is not a record"}
+	}
+
+	st := &Status{Tags: make(map[string]string)}
+	r.Object().Range(func(name string, v values.Value) {
+		if v.IsNull() {
+			return
+		}
+		switch {
+		case name == "_check_id" && v.Type().Nature() == semantic.String:
+			if id, err := influxdb.IDFromString(v.Str()); err == nil {
+				st.CheckID = *id
+			}
+		case name == "_time" && v.Type().Nature() == semantic.Time:
+			st.Time = v.Time()
+		case len(name) > 0 && name[0] != '_' && v.Type().Nature() == semantic.String:
+			st.Tags[name] = v.Str()
+		}
+	})
+	return st, nil
+}
@@ -17,6 +17,8 @@ const (
 	ReadGroupPhysKind     = "ReadGroupPhysKind"
 	ReadTagKeysPhysKind   = "ReadTagKeysPhysKind"
 	ReadTagValuesPhysKind = "ReadTagValuesPhysKind"
+
+	ReadWindowAggregatePhysKind = "ReadWindowAggregatePhysKind"
 )
 
 type ReadGroupPhysSpec struct {
@@ -44,6 +46,37 @@ func (s *ReadGroupPhysSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// ReadWindowAggregatePhysSpec reads a range of points and aggregates
+// them per window in storage.
+type ReadWindowAggregatePhysSpec struct {
+	plan.DefaultCost
+	ReadRangePhysSpec
+
+	// WindowEvery is the duration of the windows in nanoseconds.
+	// math.MaxInt64 selects a single window spanning the range.
+	WindowEvery int64
+	Aggregates  []plan.ProcedureKind
+
+	// CreateEmpty produces a table for the windows of a series that
+	// contain no points.
+	CreateEmpty bool
+}
+
+func (s *ReadWindowAggregatePhysSpec) Kind() plan.ProcedureKind {
+	return ReadWindowAggregatePhysKind
+}
+
+func (s *ReadWindowAggregatePhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadWindowAggregatePhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+
+	ns.WindowEvery = s.WindowEvery
+	ns.Aggregates = make([]plan.ProcedureKind, len(s.Aggregates))
+	copy(ns.Aggregates, s.Aggregates)
+	ns.CreateEmpty = s.CreateEmpty
+	return ns
+}
+
 type ReadRangePhysSpec struct {
 	plan.DefaultCost
 
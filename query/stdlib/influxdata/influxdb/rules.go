@@ -1,6 +1,8 @@
 package influxdb
 
 import (
+	"math"
+
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
@@ -16,6 +18,13 @@ func init() {
 		PushDownGroupRule{},
 		PushDownReadTagKeysRule{},
 		PushDownReadTagValuesRule{},
+		PushDownWindowAggregateRule{AggregateKind: universe.CountKind},
+		PushDownWindowAggregateRule{AggregateKind: universe.SumKind},
+		PushDownWindowAggregateRule{AggregateKind: universe.MinKind},
+		PushDownWindowAggregateRule{AggregateKind: universe.MaxKind},
+		PushDownWindowAggregateRule{AggregateKind: universe.MeanKind},
+		PushDownBareAggregateRule{AggregateKind: universe.FirstKind},
+		PushDownBareAggregateRule{AggregateKind: universe.LastKind},
 		SortedPivotRule{},
 	)
 }
@@ -299,6 +308,120 @@ func (rule PushDownReadTagValuesRule) Rewrite(pn plan.Node) (plan.Node, bool, er
 	}), true, nil
 }
 
+// PushDownWindowAggregateRule matches 'ReadRange |> window() |> <aggregate>()'
+// and rewrites it to a ReadWindowAggregate, which aggregates the points
+// of every window in storage. The 'from()' must have already been merged
+// with 'range' and, optionally, may have been merged with 'filter'.
+// There is a rule for each of the aggregates storage can compute.
+type PushDownWindowAggregateRule struct {
+	AggregateKind plan.ProcedureKind
+}
+
+func (rule PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule/" + string(rule.AggregateKind)
+}
+
+func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.AggregateKind,
+		plan.Pat(universe.WindowKind,
+			plan.Pat(ReadRangePhysKind)))
+}
+
+func (rule PushDownWindowAggregateRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	windowNode := pn.Predecessors()[0]
+	windowSpec := windowNode.ProcedureSpec().(*universe.WindowProcedureSpec)
+	fromNode := windowNode.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// Storage aggregates the _value column of each series.
+	if !isPushableWindowAggregate(pn.ProcedureSpec()) {
+		return pn, false, nil
+	}
+
+	// Storage windows are aligned to the unix epoch and do not overlap,
+	// they can only be computed for fixed durations.
+	window := windowSpec.Window
+	if window.Every.Months() != 0 || !window.Every.IsPositive() ||
+		!window.Period.Equal(window.Every) || !window.Offset.IsZero() {
+		return pn, false, nil
+	}
+
+	// The window must use the default columns.
+	if windowSpec.TimeColumn != execute.DefaultTimeColLabel ||
+		windowSpec.StartColumn != execute.DefaultStartColLabel ||
+		windowSpec.StopColumn != execute.DefaultStopColLabel {
+		return pn, false, nil
+	}
+
+	return plan.CreatePhysicalNode("ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		WindowEvery:       window.Every.Nanoseconds(),
+		Aggregates:        []plan.ProcedureKind{rule.AggregateKind},
+		CreateEmpty:       windowSpec.CreateEmpty,
+	}), true, nil
+}
+
+// isPushableWindowAggregate returns true if spec is an aggregate of only the
+// _value column.
+func isPushableWindowAggregate(spec plan.ProcedureSpec) bool {
+	var columns []string
+	switch spec := spec.(type) {
+	case *universe.CountProcedureSpec:
+		columns = spec.Columns
+	case *universe.SumProcedureSpec:
+		columns = spec.Columns
+	case *universe.MeanProcedureSpec:
+		columns = spec.Columns
+	case *universe.MinProcedureSpec:
+		columns = []string{spec.Column}
+	case *universe.MaxProcedureSpec:
+		columns = []string{spec.Column}
+	default:
+		return false
+	}
+	return len(columns) == 1 && columns[0] == execute.DefaultValueColLabel
+}
+
+// PushDownBareAggregateRule matches 'ReadRange |> <selector>()' where the
+// selector is first or last and rewrites it to a ReadWindowAggregate with a
+// single window spanning the range. Storage answers these by reading only
+// the oldest or newest point of each series.
+type PushDownBareAggregateRule struct {
+	AggregateKind plan.ProcedureKind
+}
+
+func (rule PushDownBareAggregateRule) Name() string {
+	return "PushDownBareAggregateRule/" + string(rule.AggregateKind)
+}
+
+func (rule PushDownBareAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.AggregateKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownBareAggregateRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	var column string
+	switch spec := pn.ProcedureSpec().(type) {
+	case *universe.FirstProcedureSpec:
+		column = spec.Column
+	case *universe.LastProcedureSpec:
+		column = spec.Column
+	default:
+		return pn, false, nil
+	}
+	if column != execute.DefaultValueColLabel {
+		return pn, false, nil
+	}
+
+	return plan.CreatePhysicalNode("ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		WindowEvery:       math.MaxInt64,
+		Aggregates:        []plan.ProcedureKind{rule.AggregateKind},
+	}), true, nil
+}
+
 var invalidTagKeysForTagValues = []string{
 	execute.DefaultTimeColLabel,
 	execute.DefaultValueColLabel,
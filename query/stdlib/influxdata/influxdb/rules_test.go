@@ -1,6 +1,7 @@
 package influxdb_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -1284,3 +1285,252 @@ func TestReadTagValuesRule(t *testing.T) {
 		})
 	}
 }
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	readRange := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+
+	window := func(every, period, offset time.Duration) *universe.WindowProcedureSpec {
+		return &universe.WindowProcedureSpec{
+			Window: plan.WindowSpec{
+				Every:  flux.ConvertDuration(every),
+				Period: flux.ConvertDuration(period),
+				Offset: flux.ConvertDuration(offset),
+			},
+			TimeColumn:  execute.DefaultTimeColLabel,
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+		}
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownWindowAggregateRule{AggregateKind: universe.CountKind},
+		influxdb.PushDownWindowAggregateRule{AggregateKind: universe.SumKind},
+		influxdb.PushDownWindowAggregateRule{AggregateKind: universe.MinKind},
+		influxdb.PushDownWindowAggregateRule{AggregateKind: universe.MaxKind},
+		influxdb.PushDownWindowAggregateRule{AggregateKind: universe.MeanKind},
+	}
+
+	// ReadRange -> window -> <aggregate>
+	before := func(windowSpec *universe.WindowProcedureSpec, aggName plan.NodeID, aggSpec plan.PhysicalProcedureSpec) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadRange", &readRange),
+				plan.CreatePhysicalNode("window", windowSpec),
+				plan.CreatePhysicalNode(aggName, aggSpec),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+		}
+	}
+
+	after := func(aggKind plan.ProcedureKind) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+					ReadRangePhysSpec: readRange,
+					WindowEvery:       int64(time.Minute),
+					Aggregates:        []plan.ProcedureKind{aggKind},
+				}),
+			},
+		}
+	}
+
+	minute := window(time.Minute, time.Minute, 0)
+	aggregate := execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}}
+	selector := execute.SelectorConfig{Column: execute.DefaultValueColLabel}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "count",
+			Rules:  rules,
+			Before: before(minute, "count", &universe.CountProcedureSpec{AggregateConfig: aggregate}),
+			After:  after(universe.CountKind),
+		},
+		{
+			Name:   "sum",
+			Rules:  rules,
+			Before: before(minute, "sum", &universe.SumProcedureSpec{AggregateConfig: aggregate}),
+			After:  after(universe.SumKind),
+		},
+		{
+			Name:   "min",
+			Rules:  rules,
+			Before: before(minute, "min", &universe.MinProcedureSpec{SelectorConfig: selector}),
+			After:  after(universe.MinKind),
+		},
+		{
+			Name:   "max",
+			Rules:  rules,
+			Before: before(minute, "max", &universe.MaxProcedureSpec{SelectorConfig: selector}),
+			After:  after(universe.MaxKind),
+		},
+		{
+			Name:   "mean",
+			Rules:  rules,
+			Before: before(minute, "mean", &universe.MeanProcedureSpec{AggregateConfig: aggregate}),
+			After:  after(universe.MeanKind),
+		},
+	}
+
+	// ReadRange -> window -> mean -> duplicate -> window, the expansion of
+	// aggregateWindow(every: 1m, fn: mean), which creates empty windows.
+	createEmpty := window(time.Minute, time.Minute, 0)
+	createEmpty.CreateEmpty = true
+	duplicate := &universe.SchemaMutationProcedureSpec{
+		Mutations: []universe.SchemaMutation{
+			&universe.DuplicateOpSpec{
+				Column: execute.DefaultStopColLabel,
+				As:     execute.DefaultTimeColLabel,
+			},
+		},
+	}
+	infinite := window(math.MaxInt64, math.MaxInt64, 0)
+	tests = append(tests, plantest.RuleTestCase{
+		Name:  "aggregate window",
+		Rules: rules,
+		Before: &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadRange", &readRange),
+				plan.CreatePhysicalNode("window", createEmpty),
+				plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{AggregateConfig: aggregate}),
+				plan.CreatePhysicalNode("duplicate", duplicate),
+				plan.CreatePhysicalNode("window1", infinite),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+				{2, 3},
+				{3, 4},
+			},
+		},
+		After: &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+					ReadRangePhysSpec: readRange,
+					WindowEvery:       int64(time.Minute),
+					Aggregates:        []plan.ProcedureKind{universe.MeanKind},
+					CreateEmpty:       true,
+				}),
+				plan.CreatePhysicalNode("duplicate", duplicate),
+				plan.CreatePhysicalNode("window1", infinite),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+		},
+	})
+
+	// The window procedure spec does not copy its column settings, so the
+	// unchanged plans are built explicitly rather than with NoChange.
+	unchanged := func(name string, windowSpec *universe.WindowProcedureSpec, aggName plan.NodeID, aggSpec plan.PhysicalProcedureSpec) plantest.RuleTestCase {
+		return plantest.RuleTestCase{
+			Name:   name,
+			Rules:  rules,
+			Before: before(windowSpec, aggName, aggSpec),
+			After:  before(windowSpec, aggName, aggSpec),
+		}
+	}
+
+	timeColumn := window(time.Minute, time.Minute, 0)
+	timeColumn.TimeColumn = execute.DefaultStopColLabel
+
+	tests = append(tests,
+		unchanged("other column", minute, "count", &universe.CountProcedureSpec{
+			AggregateConfig: execute.AggregateConfig{Columns: []string{"host"}},
+		}),
+		unchanged("period", window(time.Minute, 2*time.Minute, 0), "count", &universe.CountProcedureSpec{AggregateConfig: aggregate}),
+		unchanged("offset", window(time.Minute, time.Minute, time.Second), "count", &universe.CountProcedureSpec{AggregateConfig: aggregate}),
+		unchanged("time column", timeColumn, "count", &universe.CountProcedureSpec{AggregateConfig: aggregate}),
+		unchanged("unsupported aggregate", minute, "last", &universe.LastProcedureSpec{SelectorConfig: selector}),
+	)
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownBareAggregateRule(t *testing.T) {
+	readRange := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownBareAggregateRule{AggregateKind: universe.FirstKind},
+		influxdb.PushDownBareAggregateRule{AggregateKind: universe.LastKind},
+	}
+
+	// ReadRange -> <selector>
+	before := func(name plan.NodeID, spec plan.PhysicalProcedureSpec) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadRange", &readRange),
+				plan.CreatePhysicalNode(name, spec),
+			},
+			Edges: [][2]int{
+				{0, 1},
+			},
+		}
+	}
+
+	after := func(kind plan.ProcedureKind) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+					ReadRangePhysSpec: readRange,
+					WindowEvery:       math.MaxInt64,
+					Aggregates:        []plan.ProcedureKind{kind},
+				}),
+			},
+		}
+	}
+
+	selector := execute.SelectorConfig{Column: execute.DefaultValueColLabel}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "first",
+			Rules:  rules,
+			Before: before("first", &universe.FirstProcedureSpec{SelectorConfig: selector}),
+			After:  after(universe.FirstKind),
+		},
+		{
+			Name:   "last",
+			Rules:  rules,
+			Before: before("last", &universe.LastProcedureSpec{SelectorConfig: selector}),
+			After:  after(universe.LastKind),
+		},
+		{
+			Name:  "other column",
+			Rules: rules,
+			Before: before("last", &universe.LastProcedureSpec{
+				SelectorConfig: execute.SelectorConfig{Column: "host"},
+			}),
+			NoChange: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Package silences registers the Flux influxdata/influxdb/silences package,
+// which consults the notification silences of an organization. The Flux
+// generated by notification rules uses it to record the statuses matched by an
+// active silence as suppressed instead of notifying them:
+//
+//	import "influxdata/influxdb/silences"
+//
+//	matched_statuses = statuses
+//	    |> silences.match(orgID: "0000000000000001", ruleID: "0000000000000002")
+//	matched_statuses
+//	    |> silences.suppress(data: notification)
+//	all_statuses = matched_statuses
+//	    |> silences.unsilenced()
+package silences
+
+import (
+	"context"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/internal/statusdep"
+)
+
+const pkgpath = "influxdata/influxdb/silences"
+
+const source = `
+package silences
+
+import "experimental"
+import "influxdata/influxdb/monitor"
+
+// find returns the ID of an active silence of the organization matching the
+// status r sent by the rule, or an empty string.
+builtin find
+
+// match annotates the statuses with the _silence_id of the silence they match.
+match = (tables=<-, orgID, ruleID) => tables
+    |> map(fn: (r) => ({r with _silence_id: find(orgID: orgID, ruleID: ruleID, r: r)}))
+
+// suppress records the silenced statuses as notifications that were not sent.
+suppress = (tables=<-, data) => tables
+    |> filter(fn: (r) => r._silence_id != "")
+    |> experimental.set(o: data)
+    |> experimental.group(mode: "extend", columns: experimental.objectKeys(o: data))
+    |> map(fn: (r) => ({r with
+        _measurement: "notifications",
+        _status_timestamp: int(v: r._time),
+        _time: now(),
+        _sent: "false",
+    }))
+    |> experimental.group(mode: "extend", columns: ["_sent"])
+    |> monitor.log()
+
+// unsilenced keeps the statuses that no silence matches.
+unsilenced = (tables=<-) => tables
+    |> filter(fn: (r) => r._silence_id == "")
+    |> drop(columns: ["_silence_id"])
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = pkgpath
+	flux.RegisterPackage(pkg)
+	flux.RegisterPackageValue(pkgpath, "find", values.NewFunction(
+		"find",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"orgID":  semantic.String,
+				"ruleID": semantic.String,
+				"r":      semantic.Tvar(1),
+			},
+			Required: semantic.LabelSet{"orgID", "ruleID", "r"},
+			Return:   semantic.String,
+		}),
+		find,
+		false,
+	))
+}
+
+type key int
+
+const dependencyKey key = iota
+
+// Dependency provides the silence service to the silences package.
+type Dependency struct {
+	SilenceService influxdb.SilenceService
+}
+
+// Inject implements flux.Dependency. The silences of an organization are
+// loaded once per query, which is injected its own dependency.
+func (d Dependency) Inject(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dependencyKey, &dependency{
+		Dependency: d,
+		silences:   statusdep.NewOrgCache(d.load),
+	})
+}
+
+// GetDependency returns the silences dependency of the context, if any.
+func GetDependency(ctx context.Context) (Dependency, bool) {
+	d, ok := ctx.Value(dependencyKey).(*dependency)
+	if !ok {
+		return Dependency{}, false
+	}
+	return d.Dependency, d.SilenceService != nil
+}
+
+// load returns the matchers of the silences of the organization.
+func (d Dependency) load(ctx context.Context, orgID influxdb.ID) (interface{}, error) {
+	ss, _, err := d.SilenceService.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	ms := make([]*influxdb.SilenceMatcher, 0, len(ss))
+	for _, s := range ss {
+		m, err := influxdb.NewSilenceMatcher(s)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
+
+// dependency is the silences dependency of a query.
+type dependency struct {
+	Dependency
+	silences *statusdep.OrgCache
+}
+
+func find(ctx context.Context, args values.Object) (values.Value, error) {
+	orgID, err := statusdep.IDArg(args, "orgID")
+	if err != nil {
+		return nil, err
+	}
+	ruleID, err := statusdep.IDArg(args, "ruleID")
+	if err != nil {
+		return nil, err
+	}
+	st, err := statusdep.StatusArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a silence service nothing is ever silenced.
+	d, ok := ctx.Value(dependencyKey).(*dependency)
+	if !ok || d.SilenceService == nil {
+		return values.NewString(""), nil
+	}
+	if st.Time == 0 {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "status record has no _time"}
+	}
+
+	ms, err := d.silences.Get(ctx, orgID)
+	if err != nil {
+		return nil, &flux.Error{Code: codes.Internal, Msg: "failed to find silences", Err: err}
+	}
+	t := st.Time.Time()
+	for _, m := range ms.([]*influxdb.SilenceMatcher) {
+		if m.Silence.ActiveAt(t) && m.Matches(ruleID, st.CheckID, st.Tags) {
+			return values.NewString(m.Silence.ID.String()), nil
+		}
+	}
+	return values.NewString(""), nil
+}
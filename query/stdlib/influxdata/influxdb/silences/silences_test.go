@@ -0,0 +1,98 @@
+package silences_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/silences"
+)
+
+func eval(t *testing.T, ctx context.Context, expr string) string {
+	t.Helper()
+	ctx = dependenciestest.Default().Inject(ctx)
+	_, scope, err := flux.Eval(ctx, "import \"influxdata/influxdb/silences\"\nx = "+expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ok := scope.Lookup("x")
+	if !ok {
+		t.Fatal("x is not defined")
+	}
+	return v.Str()
+}
+
+func TestFind(t *testing.T) {
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	svc := mock.NewSilenceService()
+	var calls int
+	svc.FindSilencesFn = func(_ context.Context, filter influxdb.SilenceFilter) ([]*influxdb.Silence, int, error) {
+		calls++
+		if filter.OrgID == nil || *filter.OrgID != 1 {
+			t.Errorf("unexpected org filter %v", filter.OrgID)
+		}
+		return []*influxdb.Silence{
+			{
+				ID:        5,
+				OrgID:     1,
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				TagRules: []influxdb.TagRule{
+					{Tag: influxdb.Tag{Key: "host", Value: "web-1"}, Operator: influxdb.Equal},
+				},
+				CheckIDs: []influxdb.ID{3},
+			},
+		}, 1, nil
+	}
+	ctx := silences.Dependency{SilenceService: svc}.Inject(context.Background())
+
+	for _, tt := range []struct {
+		name string
+		r    string
+		want string
+	}{
+		{
+			name: "matching status",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000003", host: "web-1"}`,
+			want: "0000000000000005",
+		},
+		{
+			name: "other check",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000004", host: "web-1"}`,
+			want: "",
+		},
+		{
+			name: "other tag",
+			r:    `{_time: 2020-03-02T22:00:00Z, _check_id: "0000000000000003", host: "web-2"}`,
+			want: "",
+		},
+		{
+			name: "after the window",
+			r:    `{_time: 2020-03-02T23:00:00Z, _check_id: "0000000000000003", host: "web-1"}`,
+			want: "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eval(t, ctx, `silences.find(orgID: "0000000000000001", ruleID: "0000000000000002", r: `+tt.r+`)`)
+			if got != tt.want {
+				t.Errorf("expected silence %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the silences to be found once, found %d times", calls)
+	}
+}
+
+func TestFind_NoDependency(t *testing.T) {
+	got := eval(t, context.Background(), `silences.find(orgID: "0000000000000001", ruleID: "0000000000000002", r: {_time: 2020-03-02T22:00:00Z, host: "web-1"})`)
+	if got != "" {
+		t.Errorf("expected no silence without a silence service, got %q", got)
+	}
+}
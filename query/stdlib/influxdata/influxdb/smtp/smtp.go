@@ -0,0 +1,279 @@
+// Package smtp registers the Flux smtp package, which sends email through an
+// SMTP server. It provides the endpoint used by the notification rules of SMTP
+// notification endpoints:
+//
+//	import "influxdata/influxdb/smtp"
+//
+//	e = smtp.endpoint(host: "smtp.example.com", port: 587, from: "alerts@example.com")
+//	statuses
+//	    |> monitor.notify(data: notification, endpoint: e(mapFn: (r) => ({
+//	        to: "oncall@example.com",
+//	        subject: "${r._check_name} is ${r._level}",
+//	        body: r._message,
+//	    })))
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "influxdata/influxdb/smtp"
+
+// The TLS modes of the connection to the SMTP server.
+const (
+	// TLSNone sends the mail over an unencrypted connection.
+	TLSNone = "none"
+	// TLSStartTLS upgrades the connection with the STARTTLS command.
+	TLSStartTLS = "starttls"
+	// TLSImplicit opens a TLS connection, usually on port 465.
+	TLSImplicit = "tls"
+)
+
+// TLSModes are the valid TLS modes.
+var TLSModes = map[string]bool{
+	TLSNone:     true,
+	TLSStartTLS: true,
+	TLSImplicit: true,
+}
+
+// dialTimeout bounds the time taken to send a mail when the context has no deadline.
+const dialTimeout = 30 * time.Second
+
+const source = `package smtp
+
+builtin sendMail
+
+// endpoint creates the endpoint for an SMTP server.
+// The returned factory function accepts a mapFn parameter, which must return
+// an object with the to, subject and body fields of the mail sent for a row;
+// to is a comma separated list of addresses.
+endpoint = (host, port=25, tls="starttls", username="", password="", from) =>
+    (mapFn) =>
+        (tables=<-) => tables
+            |> map(fn: (r) => {
+                obj = mapFn(r: r)
+                return {r with _sent: string(v: sendMail(
+                    host: host,
+                    port: port,
+                    tls: tls,
+                    username: username,
+                    password: password,
+                    from: from,
+                    to: obj.to,
+                    subject: obj.subject,
+                    body: obj.body,
+                ))}
+            })
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = pkgpath
+	flux.RegisterPackage(pkg)
+	flux.RegisterPackageValue(pkgpath, "sendMail", values.NewFunction(
+		"sendMail",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"host":     semantic.String,
+				"port":     semantic.Int,
+				"tls":      semantic.String,
+				"username": semantic.String,
+				"password": semantic.String,
+				"from":     semantic.String,
+				"to":       semantic.String,
+				"subject":  semantic.String,
+				"body":     semantic.String,
+			},
+			Required: semantic.LabelSet{"host", "from", "to", "subject", "body"},
+			Return:   semantic.Bool,
+		}),
+		sendMail,
+		true, // sendMail has side-effects
+	))
+}
+
+// mailArgs are the arguments of sendMail.
+type mailArgs struct {
+	host, tls          string
+	port               int64
+	username, password string
+	from               *mail.Address
+	to                 []*mail.Address
+	subject, body      string
+}
+
+func readArgs(args values.Object) (*mailArgs, error) {
+	str := func(name, def string) string {
+		if v, ok := args.Get(name); ok && !v.IsNull() {
+			return v.Str()
+		}
+		return def
+	}
+
+	a := &mailArgs{
+		host:     str("host", ""),
+		port:     25,
+		tls:      str("tls", TLSStartTLS),
+		username: str("username", ""),
+		password: str("password", ""),
+		subject:  str("subject", ""),
+		body:     str("body", ""),
+	}
+	if v, ok := args.Get("port"); ok && !v.IsNull() {
+		a.port = v.Int()
+	}
+
+	if a.host == "" {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "missing argument host"}
+	}
+	if a.port <= 0 || a.port > 65535 {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: fmt.Sprintf("invalid port %d", a.port)}
+	}
+	if !TLSModes[a.tls] {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: fmt.Sprintf("invalid tls mode %q, expected none, starttls or tls", a.tls)}
+	}
+
+	var err error
+	if a.from, err = mail.ParseAddress(str("from", "")); err != nil {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "invalid from address", Err: err}
+	}
+	if a.to, err = mail.ParseAddressList(str("to", "")); err != nil {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "invalid to addresses", Err: err}
+	}
+	return a, nil
+}
+
+// message returns the mail with its headers. Line breaks are removed from the
+// subject so that it cannot add headers; those of the body are converted to
+// CRLF when the message is written to the server.
+func (a *mailArgs) message(now time.Time) []byte {
+	to := make([]string, len(a.to))
+	for i, addr := range a.to {
+		to[i] = addr.String()
+	}
+	subject := strings.Join(strings.Fields(a.subject), " ")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", a.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(a.body)
+	return []byte(b.String())
+}
+
+// sendMail sends a mail through an SMTP server and returns true once the server
+// accepted it. The address of the server is checked with the URL validator of
+// the dependencies, as the URLs of http.post are.
+func sendMail(ctx context.Context, args values.Object) (values.Value, error) {
+	a, err := readArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := net.JoinHostPort(a.host, strconv.FormatInt(a.port, 10))
+	validator, err := flux.GetDependencies(ctx).URLValidator()
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(&url.URL{Scheme: "smtp", Host: addr}); err != nil {
+		return nil, err
+	}
+
+	if err := send(ctx, addr, a); err != nil {
+		return nil, &flux.Error{
+			Code: codes.Unavailable,
+			Msg:  fmt.Sprintf("failed to send mail through %s", addr),
+			Err:  err,
+		}
+	}
+	return values.NewBool(true), nil
+}
+
+func send(ctx context.Context, addr string, a *mailArgs) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(dialTimeout)
+	}
+
+	d := net.Dialer{Deadline: deadline}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{ServerName: a.host}
+	if a.tls == TLSImplicit {
+		tconn := tls.Client(conn, tlsConfig)
+		if err := tconn.Handshake(); err != nil {
+			return err
+		}
+		conn = tconn
+	}
+
+	c, err := smtp.NewClient(conn, a.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if a.tls == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server does not support STARTTLS")
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	// PLAIN authentication is refused over an unencrypted connection, unless
+	// the server is local.
+	if a.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", a.username, a.password, a.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(a.from.Address); err != nil {
+		return err
+	}
+	for _, addr := range a.to {
+		if err := c.Rcpt(addr.Address); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(a.message(time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
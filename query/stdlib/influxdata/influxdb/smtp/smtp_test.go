@@ -0,0 +1,220 @@
+package smtp_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	"github.com/influxdata/flux/values"
+	_ "github.com/influxdata/influxdb/query/builtin"
+)
+
+// fakeServer is a minimal SMTP server recording the mails it receives.
+type fakeServer struct {
+	ln net.Listener
+
+	mu    sync.Mutex
+	auth  string
+	from  string
+	to    []string
+	data  string
+	wg    sync.WaitGroup
+	close func()
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{ln: ln}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.serve(conn)
+		}
+	}()
+	s.close = func() {
+		ln.Close()
+		s.wg.Wait()
+	}
+	return s
+}
+
+func (s *fakeServer) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	reply := func(format string, args ...interface{}) { _ = tp.PrintfLine(format, args...) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		s.mu.Lock()
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			s.auth = string(creds)
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = line
+			reply("250 OK")
+		case "RCPT":
+			s.to = append(s.to, line)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				s.mu.Unlock()
+				return
+			}
+			s.data = string(data)
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func eval(t *testing.T, script string) (values.Value, error) {
+	t.Helper()
+	ctx := dependenciestest.Default().Inject(context.Background())
+	_, scope, err := flux.Eval(ctx, "import \"influxdata/influxdb/smtp\"\n"+script)
+	if err != nil {
+		return nil, err
+	}
+	x, ok := scope.Lookup("x")
+	if !ok {
+		t.Fatal("missing x")
+	}
+	return x, nil
+}
+
+func TestSendMail(t *testing.T) {
+	s := newFakeServer(t)
+	defer s.close()
+
+	x, err := eval(t, fmt.Sprintf(`x = smtp.sendMail(
+		host: "127.0.0.1",
+		port: %d,
+		tls: "none",
+		username: "user",
+		password: "secret",
+		from: "InfluxDB <alerts@example.com>",
+		to: "oncall@example.com, ops@example.com",
+		subject: "cpu is crit
+Bcc: eve@example.com",
+		body: "usage is 99%%
+on host a",
+	)`, s.port()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !x.Bool() {
+		t.Fatal("expected the mail to be sent")
+	}
+
+	s.close()
+	if exp := "\x00user\x00secret"; s.auth != exp {
+		t.Errorf("got credentials %q, expected %q", s.auth, exp)
+	}
+	if exp := "MAIL FROM:<alerts@example.com>"; !strings.HasPrefix(s.from, exp) {
+		t.Errorf("got %q, expected %q", s.from, exp)
+	}
+	if got, exp := strings.Join(s.to, "|"), "RCPT TO:<oncall@example.com>|RCPT TO:<ops@example.com>"; got != exp {
+		t.Errorf("got recipients %q, expected %q", got, exp)
+	}
+
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(s.data)))
+	header, err := r.ReadMIMEHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := header.Get("Subject"), "cpu is crit Bcc: eve@example.com"; got != exp {
+		t.Errorf("got subject %q, expected %q", got, exp)
+	}
+	if header.Get("Bcc") != "" {
+		t.Error("the subject added a header")
+	}
+	if got, exp := header.Get("To"), "<oncall@example.com>, <ops@example.com>"; got != exp {
+		t.Errorf("got to %q, expected %q", got, exp)
+	}
+	body, err := ioutil.ReadAll(r.R)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := string(body), "usage is 99%\non host a\n"; got != exp {
+		t.Errorf("got body %q, expected %q", got, exp)
+	}
+}
+
+func TestSendMail_Errors(t *testing.T) {
+	s := newFakeServer(t)
+	defer s.close()
+
+	tests := []struct {
+		name   string
+		script string
+		err    string
+	}{
+		{
+			name:   "invalid tls mode",
+			script: `x = smtp.sendMail(host: "127.0.0.1", tls: "ssl", from: "a@example.com", to: "b@example.com", subject: "s", body: "b")`,
+			err:    `invalid tls mode "ssl"`,
+		},
+		{
+			name:   "invalid recipient",
+			script: `x = smtp.sendMail(host: "127.0.0.1", from: "a@example.com", to: "b", subject: "s", body: "b")`,
+			err:    "invalid to addresses",
+		},
+		{
+			name:   "no starttls",
+			script: fmt.Sprintf(`x = smtp.sendMail(host: "127.0.0.1", port: %d, from: "a@example.com", to: "b@example.com", subject: "s", body: "b")`, s.port()),
+			err:    "server does not support STARTTLS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := eval(t, tt.script)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("got error %v, expected %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	if _, err := eval(t, `x = smtp.endpoint(host: "smtp.example.com", port: 587, from: "alerts@example.com")(
+		mapFn: (r) => ({to: "oncall@example.com", subject: r._check_name, body: r._message}),
+	)`); err != nil {
+		t.Fatal(err)
+	}
+}
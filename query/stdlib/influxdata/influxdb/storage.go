@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/prom"
@@ -128,9 +129,23 @@ type ReadTagValuesSpec struct {
 	TagKey string
 }
 
+type ReadWindowAggregateSpec struct {
+	ReadFilterSpec
+
+	// WindowEvery is the duration of the windows in nanoseconds.
+	// math.MaxInt64 selects a single window spanning the range.
+	WindowEvery int64
+	Aggregates  []plan.ProcedureKind
+
+	// CreateEmpty produces a table for the windows of a series that
+	// contain no points.
+	CreateEmpty bool
+}
+
 type Reader interface {
 	ReadFilter(ctx context.Context, spec ReadFilterSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadGroup(ctx context.Context, spec ReadGroupSpec, alloc *memory.Allocator) (TableIterator, error)
+	ReadWindowAggregate(ctx context.Context, spec ReadWindowAggregateSpec, alloc *memory.Allocator) (TableIterator, error)
 
 	ReadTagKeys(ctx context.Context, spec ReadTagKeysSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadTagValues(ctx context.Context, spec ReadTagValuesSpec, alloc *memory.Allocator) (TableIterator, error)
@@ -0,0 +1,237 @@
+// Package throttle registers the Flux influxdata/influxdb/throttle package,
+// which limits the notifications sent by notification rules. It keeps no state
+// of its own: the notifications already sent are read back from the
+// notifications that rules log in the _monitoring bucket.
+//
+//	import "influxdata/influxdb/throttle"
+//
+//	all_statuses = statuses
+//	    |> throttle.repeat(ruleID: "0000000000000001", every: 1h)
+//	    |> throttle.digest()
+package throttle
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb"
+)
+
+const pkgpath = "influxdata/influxdb/throttle"
+
+// DigestKeysColumn is the column of a digest notification that holds the keys
+// of the statuses it notified, one per line.
+const DigestKeysColumn = "_digest_keys"
+
+const source = `
+package throttle
+
+import "influxdata/influxdb/monitor"
+
+// key returns the identity of a status: its check, level and tags.
+builtin key
+
+// sent returns the keys of the statuses notified by the notifications.
+builtin sent
+
+// repeat drops the statuses that the rule notified at the same level within every.
+repeat = (tables=<-, ruleID, every) => {
+    notified = monitor.logs(start: -every, fn: (r) => r._notification_rule_id == ruleID and r._sent == "true")
+        |> sent()
+
+    return tables
+        |> filter(fn: (r) => not contains(value: key(r: r), set: notified))
+}
+
+// levelRank returns the severity of a status level, as ranked by the
+// escalations of notification rules.
+builtin levelRank
+
+// digest batches the statuses into a single status of the most severe level,
+// with the messages of all the statuses.
+digest = (tables=<-) => tables
+    |> group()
+    |> sort(columns: ["_time"])
+    |> reduce(
+        identity: {
+            _check_id: "",
+            _check_name: "",
+            _level: "",
+            _source_measurement: "",
+            _type: "",
+            _time: 1970-01-01T00:00:00Z,
+            _message: "",
+            _digest_keys: "",
+            _digest_count: 0,
+        },
+        fn: (r, accumulator) => ({
+            _check_id: r._check_id,
+            _check_name: r._check_name,
+            _level: if levelRank(level: r._level) > levelRank(level: accumulator._level) then r._level else accumulator._level,
+            _source_measurement: r._source_measurement,
+            _type: r._type,
+            _time: r._time,
+            _message: if accumulator._digest_count == 0 then r._message else accumulator._message + "\n" + r._message,
+            _digest_keys: if accumulator._digest_count == 0 then key(r: r) else accumulator._digest_keys + "\n" + key(r: r),
+            _digest_count: accumulator._digest_count + 1,
+        }),
+    )
+    |> filter(fn: (r) => r._digest_count > 0)
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = pkgpath
+	flux.RegisterPackage(pkg)
+	flux.RegisterPackageValue(pkgpath, "key", values.NewFunction(
+		"key",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"r": semantic.Tvar(1),
+			},
+			Required: semantic.LabelSet{"r"},
+			Return:   semantic.String,
+		}),
+		key,
+		false,
+	))
+	flux.RegisterPackageValue(pkgpath, "sent", values.NewFunction(
+		"sent",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"tables": flux.TableObjectType,
+			},
+			Required:     semantic.LabelSet{"tables"},
+			PipeArgument: "tables",
+			Return:       semantic.NewArrayPolyType(semantic.String),
+		}),
+		sent,
+		false,
+	))
+	flux.RegisterPackageValue(pkgpath, "levelRank", values.NewFunction(
+		"levelRank",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"level": semantic.String,
+			},
+			Required: semantic.LabelSet{"level"},
+			Return:   semantic.Int,
+		}),
+		levelRank,
+		false,
+	))
+}
+
+func key(ctx context.Context, args values.Object) (values.Value, error) {
+	r, ok := args.Get("r")
+	if !ok {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "missing argument r"}
+	}
+	if r.Type().Nature() != semantic.Object {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "argument r is not a record"}
+	}
+	return values.NewString(StatusKey(r.Object().Range)), nil
+}
+
+// StatusKey returns the identity of a status, given a function ranging over
+// its columns. Two statuses have the same key when they come from the same
+// check, at the same level, with the same tags.
+func StatusKey(rangeFn func(func(name string, v values.Value))) string {
+	var pairs []string
+	rangeFn(func(name string, v values.Value) {
+		if v == nil || v.IsNull() || v.Type().Nature() != semantic.String {
+			return
+		}
+		if name != "_check_id" && name != "_level" && strings.HasPrefix(name, "_") {
+			return
+		}
+		pairs = append(pairs, keyEscaper.Replace(name)+"="+keyEscaper.Replace(v.Str()))
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+var keyEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`, "\n", `\n`)
+
+func levelRank(ctx context.Context, args values.Object) (values.Value, error) {
+	level, ok := args.Get("level")
+	if !ok {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "missing argument level"}
+	}
+	if level.IsNull() {
+		return values.NewInt(int64(influxdb.StatusLevelRank(""))), nil
+	}
+	if level.Type().Nature() != semantic.String {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "argument level is not a string"}
+	}
+	return values.NewInt(int64(influxdb.StatusLevelRank(level.Str()))), nil
+}
+
+func sent(ctx context.Context, args values.Object) (values.Value, error) {
+	arguments := interpreter.NewArguments(args)
+	v, err := arguments.GetRequired("tables")
+	if err != nil {
+		return nil, err
+	}
+	to, ok := v.(*flux.TableObject)
+	if !ok {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "argument tables is not a table stream"}
+	}
+
+	c := lang.TableObjectCompiler{
+		Tables: to,
+		Now:    time.Now(),
+	}
+	p, err := c.Compile(ctx)
+	if err != nil {
+		return nil, &flux.Error{Code: codes.Inherit, Msg: "error in table object compilation", Err: err}
+	}
+	if !lang.HaveExecutionDependencies(ctx) {
+		return nil, &flux.Error{Code: codes.Invalid, Msg: "do not have an execution context for sent"}
+	}
+	deps := lang.GetExecutionDependencies(ctx)
+	q, err := p.Start(ctx, deps.Allocator)
+	if err != nil {
+		return nil, &flux.Error{Code: codes.Inherit, Msg: "error in table object start", Err: err}
+	}
+	defer q.Done()
+
+	var keys []values.Value
+	for res := range q.Results() {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(cr flux.ColReader) error {
+				digestIdx := execute.ColIdx(DigestKeysColumn, cr.Cols())
+				for i := 0; i < cr.Len(); i++ {
+					if digestIdx >= 0 && cr.Cols()[digestIdx].Type == flux.TString && cr.Strings(digestIdx).IsValid(i) {
+						for _, k := range strings.Split(cr.Strings(digestIdx).ValueString(i), "\n") {
+							keys = append(keys, values.NewString(k))
+						}
+						continue
+					}
+					keys = append(keys, values.NewString(StatusKey(func(fn func(string, values.Value)) {
+						for j, c := range cr.Cols() {
+							fn(c.Label, execute.ValueForRow(cr, i, j))
+						}
+					})))
+				}
+				return nil
+			})
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := q.Err(); err != nil {
+		return nil, err
+	}
+	return values.NewArrayWithBacking(semantic.String, keys), nil
+}
@@ -0,0 +1,133 @@
+package throttle_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/influxdb"
+	_ "github.com/influxdata/influxdb/query/builtin"
+)
+
+const statuses = `
+#datatype,string,long,dateTime:RFC3339,string,string,string,string,string,string,string
+#group,false,false,false,true,true,true,true,true,true,false
+#default,_result,,,,,,,,,
+,result,table,_time,_check_id,_check_name,_level,_source_measurement,_type,host,_message
+,,0,2020-03-02T22:00:00Z,0000000000000003,cpu,warn,cpu,threshold,web-1,web-1 is warn
+,,1,2020-03-02T22:00:01Z,0000000000000003,cpu,crit,cpu,threshold,web-2,web-2 is crit
+,,2,2020-03-02T22:00:02Z,0000000000000003,cpu,ok,cpu,threshold,web-3,web-3 is ok
+`
+
+// column runs the query and returns the values of the string column of its result.
+func column(t *testing.T, query, col string) []string {
+	t.Helper()
+	ctx := executetest.NewTestExecuteDependencies().Inject(context.Background())
+	src := "import \"csv\"\nimport \"influxdata/influxdb/throttle\"\n" +
+		"statuses = csv.from(csv: \"" + statuses + "\")\n" + query
+	p, err := lang.FluxCompiler{Query: src, Now: time.Now()}.Compile(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := p.Start(ctx, &memory.Allocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	var vs []string
+	for res := range q.Results() {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			return tbl.Do(func(cr flux.ColReader) error {
+				for j, c := range cr.Cols() {
+					if c.Label != col {
+						continue
+					}
+					for i := 0; i < cr.Len(); i++ {
+						vs = append(vs, cr.Strings(j).ValueString(i))
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func TestKey(t *testing.T) {
+	got := column(t, `statuses |> map(fn: (r) => ({r with key: throttle.key(r: r)}))`, "key")
+	sort.Strings(got)
+	want := []string{
+		"_check_id=0000000000000003,_level=crit,host=web-2",
+		"_check_id=0000000000000003,_level=ok,host=web-3",
+		"_check_id=0000000000000003,_level=warn,host=web-1",
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected keys -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestSent(t *testing.T) {
+	// web-1 was notified on its own and web-2 in a digest.
+	query := `
+notified = union(tables: [
+    statuses |> filter(fn: (r) => r.host == "web-1"),
+    statuses |> filter(fn: (r) => r.host == "web-2") |> throttle.digest(),
+]) |> throttle.sent()
+
+statuses
+    |> filter(fn: (r) => not contains(value: throttle.key(r: r), set: notified))
+`
+	got := column(t, query, "host")
+	if want := []string{"web-3"}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected statuses -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestLevelRank(t *testing.T) {
+	got := column(t, `statuses |> map(fn: (r) => ({r with rank: r._level + "=" + string(v: throttle.levelRank(level: r._level))}))`, "rank")
+	sort.Strings(got)
+	var want []string
+	for _, level := range []string{"crit", "ok", "warn"} {
+		want = append(want, level+"="+strconv.Itoa(influxdb.StatusLevelRank(level)))
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected ranks -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestDigest(t *testing.T) {
+	got := column(t, `statuses |> throttle.digest()`, "_message")
+	if want := []string{"web-1 is warn\nweb-2 is crit\nweb-3 is ok"}; !cmp.Equal(want, got) {
+		t.Errorf("unexpected message -want/+got:\n%s", cmp.Diff(want, got))
+	}
+
+	got = column(t, `statuses |> throttle.digest()`, "_level")
+	if want := []string{"crit"}; !cmp.Equal(want, got) {
+		t.Errorf("expected the most severe level, got %v", got)
+	}
+
+	// An unknown level ranks below ok.
+	got = column(t, `statuses |> map(fn: (r) => ({r with _level: if r.host == "web-2" then "unknown" else r._level})) |> throttle.digest()`, "_level")
+	if want := []string{"warn"}; !cmp.Equal(want, got) {
+		t.Errorf("expected the most severe level, got %v", got)
+	}
+
+	got = column(t, `statuses |> throttle.digest()`, "_digest_keys")
+	if len(got) != 1 || len(strings.Split(got[0], "\n")) != 3 {
+		t.Errorf("expected the keys of the 3 statuses, got %q", got)
+	}
+}
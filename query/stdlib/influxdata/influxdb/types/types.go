@@ -0,0 +1,95 @@
+// Package types registers the Flux types package, which provides functions
+// to inspect the type of a value.
+//
+// Flux tables have a single type per column, so isType can be used to route
+// the tables of a stream by the type of their _value column:
+//
+//	import "influxdata/influxdb/types"
+//
+//	from(bucket: "telegraf")
+//	    |> range(start: -1h)
+//	    |> filter(fn: (r) => types.isType(v: r._value, type: "float"))
+//	    |> aggregateWindow(every: 1m, fn: mean)
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const pkgpath = "influxdata/influxdb/types"
+
+const source = `package types
+
+builtin isType
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = pkgpath
+	flux.RegisterPackage(pkg)
+	flux.RegisterPackageValue(pkgpath, "isType", values.NewFunction(
+		"isType",
+		semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+			Parameters: map[string]semantic.PolyType{
+				"v":    semantic.Tvar(1),
+				"type": semantic.String,
+			},
+			Required: semantic.LabelSet{"v", "type"},
+			Return:   semantic.Bool,
+		}),
+		isType,
+		false,
+	))
+}
+
+// typeNames maps the type names accepted by isType to their nature.
+var typeNames = map[string]semantic.Nature{
+	"string":   semantic.String,
+	"bytes":    semantic.Bytes,
+	"int":      semantic.Int,
+	"uint":     semantic.UInt,
+	"float":    semantic.Float,
+	"bool":     semantic.Bool,
+	"time":     semantic.Time,
+	"duration": semantic.Duration,
+	"regexp":   semantic.Regexp,
+}
+
+// isType reports whether the value v is of the named type.
+// A null value is not of any type.
+func isType(ctx context.Context, args values.Object) (values.Value, error) {
+	v, ok := args.Get("v")
+	if !ok {
+		return nil, &flux.Error{
+			Code: codes.Invalid,
+			Msg:  "missing argument v",
+		}
+	}
+	typ, ok := args.Get("type")
+	if !ok {
+		return nil, &flux.Error{
+			Code: codes.Invalid,
+			Msg:  "missing argument type",
+		}
+	}
+
+	nature, ok := typeNames[typ.Str()]
+	if !ok {
+		return nil, &flux.Error{
+			Code: codes.Invalid,
+			Msg:  fmt.Sprintf("unknown type %q", typ.Str()),
+		}
+	}
+
+	if v.IsNull() {
+		return values.NewBool(false), nil
+	}
+	return values.NewBool(v.Type().Nature() == nature), nil
+}
@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/dependencies/dependenciestest"
+	_ "github.com/influxdata/influxdb/query/builtin"
+)
+
+func TestIsType(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "float", expr: `types.isType(v: 1.0, type: "float")`, want: true},
+		{name: "int", expr: `types.isType(v: 1, type: "int")`, want: true},
+		{name: "int is not float", expr: `types.isType(v: 1, type: "float")`, want: false},
+		{name: "uint", expr: `types.isType(v: uint(v: 1), type: "uint")`, want: true},
+		{name: "string", expr: `types.isType(v: "a", type: "string")`, want: true},
+		{name: "string is not bool", expr: `types.isType(v: "true", type: "bool")`, want: false},
+		{name: "bool", expr: `types.isType(v: true, type: "bool")`, want: true},
+		{name: "time", expr: `types.isType(v: 2019-01-01T00:00:00Z, type: "time")`, want: true},
+		{name: "duration", expr: `types.isType(v: 1h, type: "duration")`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := dependenciestest.Default().Inject(context.Background())
+			_, scope, err := flux.Eval(ctx, "import \"influxdata/influxdb/types\"\nx = "+tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			x, ok := scope.Lookup("x")
+			if !ok {
+				t.Fatal("missing x")
+			}
+			if got := x.Bool(); got != tt.want {
+				t.Errorf("unexpected result -want/+got\n\t- %v\n\t+ %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsType_UnknownType(t *testing.T) {
+	ctx := dependenciestest.Default().Inject(context.Background())
+	if _, _, err := flux.Eval(ctx, `import "influxdata/influxdb/types"
+x = types.isType(v: 1, type: "number")`); err == nil {
+		t.Fatal("expected error for unknown type")
+	}
+}
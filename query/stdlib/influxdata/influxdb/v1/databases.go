@@ -78,7 +78,7 @@ func (bd *DatabasesDecoder) Connect(ctx context.Context) error {
 }
 
 func (bd *DatabasesDecoder) Fetch(ctx context.Context) (bool, error) {
-	b, _, err := bd.deps.DBRP.FindMany(ctx, platform.DBRPMappingFilter{})
+	b, _, err := bd.deps.DBRP.FindMany(ctx, platform.DBRPMappingFilter{OrganizationID: &bd.orgID})
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,324 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// ops for silence errors.
+var (
+	OpFindSilenceByID = "FindSilenceByID"
+	OpFindSilences    = "FindSilences"
+	OpCreateSilence   = "CreateSilence"
+	OpUpdateSilence   = "UpdateSilence"
+	OpDeleteSilence   = "DeleteSilence"
+)
+
+// Silence mutes the notifications of the statuses it matches during a time
+// window, such as a planned deploy. The statuses are still recorded by the
+// notification rules, as suppressed notifications.
+type Silence struct {
+	ID          ID     `json:"id,omitempty"`
+	OrgID       ID     `json:"orgID"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// StartTime and EndTime bound the first window of the silence.
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Every repeats the window from its start when set, such as 1w for a
+	// weekly maintenance.
+	Every *options.Duration `json:"every,omitempty"`
+	// Until ends the repetition of the window when set.
+	Until *time.Time `json:"until,omitempty"`
+	// TagRules must all match the tags of a status.
+	TagRules []TagRule `json:"tagRules,omitempty"`
+	// CheckIDs restrict the silence to the statuses of the checks when set.
+	CheckIDs []ID `json:"checkIDs,omitempty"`
+	// RuleIDs restrict the silence to the notification rules when set.
+	RuleIDs []ID `json:"ruleIDs,omitempty"`
+	OwnerID ID   `json:"ownerID,omitempty"`
+	CRUDLog
+}
+
+// Valid returns an error if the silence is invalid.
+func (s *Silence) Valid() error {
+	if s.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence name is empty",
+		}
+	}
+	if s.StartTime.IsZero() || s.EndTime.IsZero() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence requires a start time and an end time",
+		}
+	}
+	if !s.EndTime.After(s.StartTime) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence end time must be after its start time",
+		}
+	}
+	if s.Every != nil {
+		if !validEvery(s.Every) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "silence repetition must be positive",
+			}
+		}
+		if s.windowStart(1).Before(s.EndTime) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "silence repetition must be longer than its window",
+			}
+		}
+	}
+	if s.Until != nil && s.Every == nil {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence end of repetition requires a repetition",
+		}
+	}
+	for _, tr := range s.TagRules {
+		if err := tr.Valid(); err != nil {
+			return err
+		}
+	}
+	if _, err := NewSilenceMatcher(s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ActiveAt reports whether t falls in a window of the silence. The windows
+// repeat on the calendar, so that the windows of a silence repeated every 1mo
+// start on the same day of each month, normalized as by time.Time.AddDate.
+func (s *Silence) ActiveAt(t time.Time) bool {
+	if t.Before(s.StartTime) {
+		return false
+	}
+	if t.Before(s.EndTime) {
+		return true
+	}
+	if s.Every == nil || (s.Until != nil && !t.Before(*s.Until)) {
+		return false
+	}
+	if !validEvery(s.Every) {
+		return false
+	}
+	approx, err := s.Every.DurationFrom(s.StartTime)
+	if err != nil || approx <= 0 {
+		return false
+	}
+
+	// Guess the window from the approximate length of the period, then step
+	// to the last window starting at or before t.
+	n := int(t.Sub(s.StartTime) / approx)
+	for n > 0 && s.windowStart(n).After(t) {
+		n--
+	}
+	for !s.windowStart(n + 1).After(t) {
+		n++
+	}
+	return t.Before(s.windowStart(n).Add(s.EndTime.Sub(s.StartTime)))
+}
+
+// windowStart returns the start of the nth window of the silence, the first
+// being the 0th. The months and years of the repetition are added to the
+// calendar date, and its other units as a fixed duration.
+func (s *Silence) windowStart(n int) time.Time {
+	var (
+		months int
+		fixed  time.Duration
+	)
+	for _, v := range s.Every.Node.Values {
+		switch v.Unit {
+		case ast.MonthUnit:
+			months += int(v.Magnitude) * n
+		case ast.YearUnit:
+			months += 12 * int(v.Magnitude) * n
+		default:
+			fixed += time.Duration(v.Magnitude) * time.Duration(n) * fixedUnits[v.Unit]
+		}
+	}
+	return s.StartTime.AddDate(0, months, 0).Add(fixed)
+}
+
+// fixedUnits are the lengths of the flux duration units that are not calendar units.
+var fixedUnits = map[string]time.Duration{
+	ast.NanosecondUnit:  time.Nanosecond,
+	ast.MicrosecondUnit: time.Microsecond,
+	ast.MillisecondUnit: time.Millisecond,
+	ast.SecondUnit:      time.Second,
+	ast.MinuteUnit:      time.Minute,
+	ast.HourUnit:        time.Hour,
+	ast.DayUnit:         24 * time.Hour,
+	ast.WeekUnit:        7 * 24 * time.Hour,
+}
+
+// validEvery reports whether every repeats windows forward in time: it has
+// a value, all of its magnitudes are positive and its units are known.
+func validEvery(every *options.Duration) bool {
+	for _, v := range every.Node.Values {
+		if v.Magnitude <= 0 {
+			return false
+		}
+		if _, ok := fixedUnits[v.Unit]; !ok && v.Unit != ast.MonthUnit && v.Unit != ast.YearUnit {
+			return false
+		}
+	}
+	return len(every.Node.Values) > 0
+}
+
+// SilenceMatcher matches statuses against a silence, with the regexes of its
+// tag rules compiled once.
+type SilenceMatcher struct {
+	Silence *Silence
+	regexps []*regexp.Regexp // by tag rule, nil for the rules without a regex
+}
+
+// NewSilenceMatcher compiles the tag rules of the silence.
+func NewSilenceMatcher(s *Silence) (*SilenceMatcher, error) {
+	m := &SilenceMatcher{
+		Silence: s,
+		regexps: make([]*regexp.Regexp, len(s.TagRules)),
+	}
+	for i, tr := range s.TagRules {
+		if tr.Operator != RegexEqual && tr.Operator != NotRegexEqual {
+			continue
+		}
+		re, err := regexp.Compile(tr.Value)
+		if err != nil {
+			return nil, &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("silence tag rule %q has an invalid regex", tr.Key),
+				Err:  err,
+			}
+		}
+		m.regexps[i] = re
+	}
+	return m, nil
+}
+
+// Matches reports whether the silence applies to the status of the check
+// with the tags, sent by the notification rule.
+func (m *SilenceMatcher) Matches(ruleID, checkID ID, tags map[string]string) bool {
+	s := m.Silence
+	if len(s.RuleIDs) > 0 && !containsID(s.RuleIDs, ruleID) {
+		return false
+	}
+	if len(s.CheckIDs) > 0 && !containsID(s.CheckIDs, checkID) {
+		return false
+	}
+	for i, tr := range s.TagRules {
+		if !matchesTagRule(tr, m.regexps[i], tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTagRule reports whether the tags satisfy the rule, whose regex is re;
+// a missing tag only satisfies the negative operators.
+func matchesTagRule(tr TagRule, re *regexp.Regexp, tags map[string]string) bool {
+	v, ok := tags[tr.Key]
+	switch tr.Operator {
+	case Equal:
+		return ok && v == tr.Value
+	case NotEqual:
+		return !ok || v != tr.Value
+	case RegexEqual, NotRegexEqual:
+		return (ok && re.MatchString(v)) == (tr.Operator == RegexEqual)
+	}
+	return false
+}
+
+func containsID(ids []ID, id ID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SilenceService represents a service for managing the silences of the
+// notifications.
+type SilenceService interface {
+	// FindSilenceByID returns a single silence by ID.
+	FindSilenceByID(ctx context.Context, id ID) (*Silence, error)
+	// FindSilences returns a list of silences that match filter and the total
+	// count of matching silences.
+	FindSilences(ctx context.Context, filter SilenceFilter) ([]*Silence, int, error)
+	// CreateSilence creates a new silence and sets s.ID with the new identifier.
+	CreateSilence(ctx context.Context, s *Silence, userID ID) error
+	// UpdateSilence updates a single silence with changeset.
+	// Returns the new silence state after update.
+	UpdateSilence(ctx context.Context, id ID, upd SilenceUpdate) (*Silence, error)
+	// DeleteSilence removes a silence by ID.
+	DeleteSilence(ctx context.Context, id ID) error
+}
+
+// SilenceFilter represents a set of filters that restrict the returned
+// silences.
+type SilenceFilter struct {
+	OrgID *ID
+	// ActiveAt matches the silences with a window containing the time.
+	ActiveAt *time.Time
+}
+
+// SilenceUpdate represents updates to a silence.
+// Only fields which are set are updated.
+type SilenceUpdate struct {
+	Name        *string    `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	StartTime   *time.Time `json:"startTime,omitempty"`
+	EndTime     *time.Time `json:"endTime,omitempty"`
+	// Every removes the repetition of the window when it is zero.
+	Every    *options.Duration `json:"every,omitempty"`
+	Until    *time.Time        `json:"until,omitempty"`
+	TagRules *[]TagRule        `json:"tagRules,omitempty"`
+	CheckIDs *[]ID             `json:"checkIDs,omitempty"`
+	RuleIDs  *[]ID             `json:"ruleIDs,omitempty"`
+}
+
+// Apply applies the update to the silence.
+func (u SilenceUpdate) Apply(s *Silence) {
+	if u.Name != nil {
+		s.Name = *u.Name
+	}
+	if u.Description != nil {
+		s.Description = *u.Description
+	}
+	if u.StartTime != nil {
+		s.StartTime = *u.StartTime
+	}
+	if u.EndTime != nil {
+		s.EndTime = *u.EndTime
+	}
+	if u.Every != nil {
+		s.Every = u.Every
+		if u.Every.IsZero() {
+			s.Every = nil
+			s.Until = nil
+		}
+	}
+	if u.Until != nil {
+		s.Until = u.Until
+	}
+	if u.TagRules != nil {
+		s.TagRules = *u.TagRules
+	}
+	if u.CheckIDs != nil {
+		s.CheckIDs = *u.CheckIDs
+	}
+	if u.RuleIDs != nil {
+		s.RuleIDs = *u.RuleIDs
+	}
+}
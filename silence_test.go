@@ -0,0 +1,212 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestSilence_ActiveAt(t *testing.T) {
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	until := start.Add(14 * 24 * time.Hour)
+	s := &influxdb.Silence{
+		Name:      "nightly deploy",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Every:     options.MustParseDuration("1d"),
+		Until:     &until,
+	}
+
+	tests := []struct {
+		t      time.Time
+		active bool
+	}{
+		{t: start.Add(-time.Minute)},
+		{t: start, active: true},
+		{t: start.Add(59 * time.Minute), active: true},
+		{t: start.Add(time.Hour)},
+		{t: start.Add(24*time.Hour + 30*time.Minute), active: true},
+		{t: start.Add(13*24*time.Hour + 30*time.Minute), active: true},
+		{t: start.Add(14*24*time.Hour + 30*time.Minute)},
+	}
+	for _, tt := range tests {
+		if got := s.ActiveAt(tt.t); got != tt.active {
+			t.Errorf("silence active at %s is %t, expected %t", tt.t, got, tt.active)
+		}
+	}
+
+	s.Every, s.Until = nil, nil
+	if s.ActiveAt(start.Add(24 * time.Hour)) {
+		t.Error("a silence without repetition is active after its window")
+	}
+}
+
+func TestSilence_ActiveAt_Calendar(t *testing.T) {
+	start := time.Date(2020, time.January, 15, 22, 0, 0, 0, time.UTC)
+	s := &influxdb.Silence{
+		Name:      "monthly maintenance",
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		Every:     options.MustParseDuration("1mo"),
+	}
+
+	tests := []struct {
+		t      time.Time
+		active bool
+	}{
+		{t: time.Date(2020, time.February, 15, 22, 30, 0, 0, time.UTC), active: true},
+		{t: time.Date(2020, time.March, 15, 23, 59, 0, 0, time.UTC), active: true},
+		{t: time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{t: time.Date(2021, time.December, 15, 22, 0, 0, 0, time.UTC), active: true},
+		// A month of 30 days would have moved the window by then.
+		{t: time.Date(2021, time.December, 7, 22, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := s.ActiveAt(tt.t); got != tt.active {
+			t.Errorf("silence active at %s is %t, expected %t", tt.t, got, tt.active)
+		}
+	}
+}
+
+func TestSilence_Matches(t *testing.T) {
+	s := &influxdb.Silence{
+		TagRules: []influxdb.TagRule{
+			{Tag: influxdb.Tag{Key: "host", Value: "^web-"}, Operator: influxdb.RegexEqual},
+			{Tag: influxdb.Tag{Key: "env", Value: "dev"}, Operator: influxdb.NotEqual},
+		},
+		CheckIDs: []influxdb.ID{2},
+	}
+	m, err := influxdb.NewSilenceMatcher(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ruleID  influxdb.ID
+		checkID influxdb.ID
+		tags    map[string]string
+		matches bool
+	}{
+		{
+			name:    "matching tags",
+			ruleID:  1,
+			checkID: 2,
+			tags:    map[string]string{"host": "web-1", "env": "prod"},
+			matches: true,
+		},
+		{
+			name:    "missing negated tag",
+			ruleID:  1,
+			checkID: 2,
+			tags:    map[string]string{"host": "web-1"},
+			matches: true,
+		},
+		{
+			name:    "other check",
+			ruleID:  1,
+			checkID: 3,
+			tags:    map[string]string{"host": "web-1"},
+		},
+		{
+			name:    "regex mismatch",
+			ruleID:  1,
+			checkID: 2,
+			tags:    map[string]string{"host": "db-1"},
+		},
+		{
+			name:    "negated tag",
+			ruleID:  1,
+			checkID: 2,
+			tags:    map[string]string{"host": "web-1", "env": "dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.ruleID, tt.checkID, tt.tags); got != tt.matches {
+				t.Fatalf("silence matches is %t, expected %t", got, tt.matches)
+			}
+		})
+	}
+
+	s.RuleIDs = []influxdb.ID{4}
+	if m.Matches(1, 2, map[string]string{"host": "web-1"}) {
+		t.Fatal("silence matches a status of another rule")
+	}
+}
+
+func TestSilence_Valid(t *testing.T) {
+	start := time.Date(2020, time.March, 2, 22, 0, 0, 0, time.UTC)
+	valid := func() *influxdb.Silence {
+		return &influxdb.Silence{
+			Name:      "deploy",
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		update  func(s *influxdb.Silence)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(s *influxdb.Silence) {},
+		},
+		{
+			name:    "missing name",
+			update:  func(s *influxdb.Silence) { s.Name = "" },
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			update:  func(s *influxdb.Silence) { s.EndTime = start.Add(-time.Hour) },
+			wantErr: true,
+		},
+		{
+			name:    "repetition shorter than the window",
+			update:  func(s *influxdb.Silence) { s.Every = options.MustParseDuration("30m") },
+			wantErr: true,
+		},
+		{
+			name:    "repetition not positive",
+			update:  func(s *influxdb.Silence) { s.Every = options.MustParseDuration("-1d") },
+			wantErr: true,
+		},
+		{
+			name:   "calendar repetition",
+			update: func(s *influxdb.Silence) { s.Every = options.MustParseDuration("1mo") },
+		},
+		{
+			name: "end of repetition without repetition",
+			update: func(s *influxdb.Silence) {
+				until := start.Add(24 * time.Hour)
+				s.Until = &until
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid regex",
+			update: func(s *influxdb.Silence) {
+				s.TagRules = []influxdb.TagRule{{Tag: influxdb.Tag{Key: "host", Value: "("}, Operator: influxdb.RegexEqual}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.update(s)
+			err := s.Valid()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil && influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Fatalf("expected invalid error, got %v", err)
+			}
+		})
+	}
+}
@@ -24,6 +24,15 @@ type Config struct {
 	// Frequency of retention in seconds.
 	RetentionInterval toml.Duration `toml:"retention-interval"`
 
+	// Duration of the shard groups, the time partitions of the data, of all buckets.
+	// When zero, it is derived from the retention period of each bucket.
+	ShardGroupDuration toml.Duration `toml:"shard-group-duration"`
+
+	// Maximum number of series of each bucket and of each organization. Points
+	// creating new series beyond these limits are dropped. Zero means no limit.
+	MaxSeriesPerBucket int `toml:"max-series-per-bucket"`
+	MaxSeriesPerOrg    int `toml:"max-series-per-org"`
+
 	// Series file config.
 	SeriesFilePath string `toml:"series-file-path"` // Overrides the default path.
 
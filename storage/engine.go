@@ -5,10 +5,11 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"math"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -62,6 +63,10 @@ type Engine struct {
 	retentionEnforcer        runner
 	retentionEnforcerLimiter runnable
 
+	shardGroupDurations *shardGroupDurations
+	bucketSchemas       *bucketSchemas // nil if schemas are not enforced
+	seriesLimiter       *seriesLimiter // nil if the number of series is not limited
+
 	defaultMetricLabels prometheus.Labels
 
 	// Tracks all goroutines started by the Engine.
@@ -112,6 +117,15 @@ func WithNodeID(id int) Option {
 func WithRetentionEnforcer(finder BucketFinder) Option {
 	return func(e *Engine) {
 		e.retentionEnforcer = newRetentionEnforcer(e, e.engine, finder)
+		e.shardGroupDurations.finder = finder
+	}
+}
+
+// WithSchemaEnforcement makes the engine drop the points written to buckets
+// with a schema that do not match it. The schemas are looked up with finder.
+func WithSchemaEnforcement(finder BucketFinder) Option {
+	return func(e *Engine) {
+		e.bucketSchemas = newBucketSchemas(finder)
 	}
 }
 
@@ -179,8 +193,15 @@ func NewEngine(path string, c Config, options ...Option) *Engine {
 	e.wal.WithFsyncDelay(time.Duration(c.WAL.FsyncDelay))
 	e.wal.SetEnabled(c.WAL.Enabled)
 
-	// Initialise Engine
-	e.engine = tsm1.NewEngine(c.GetEnginePath(path), e.index, c.Engine, tsm1.WithSnapshotter(e))
+	// Initialise Engine, partitioning its data in shard groups.
+	e.shardGroupDurations = newShardGroupDurations(time.Duration(c.ShardGroupDuration))
+	e.engine = tsm1.NewEngine(c.GetEnginePath(path), e.index, c.Engine,
+		tsm1.WithSnapshotter(e),
+		tsm1.WithPartitionDurationFunc(e.shardGroupDurations.Duration))
+
+	if c.MaxSeriesPerBucket > 0 || c.MaxSeriesPerOrg > 0 {
+		e.seriesLimiter = newSeriesLimiter(int64(c.MaxSeriesPerBucket), int64(c.MaxSeriesPerOrg), e.index, e.sfile)
+	}
 
 	// Apply options.
 	for _, option := range options {
@@ -216,6 +237,10 @@ func (e *Engine) WithLogger(log *zap.Logger) {
 	e.index.WithLogger(e.logger)
 	e.engine.WithLogger(e.logger)
 	e.wal.WithLogger(e.logger)
+	e.shardGroupDurations.logger = e.logger
+	if e.bucketSchemas != nil {
+		e.bucketSchemas.logger = e.logger
+	}
 	if r, ok := e.retentionEnforcer.(*retentionEnforcer); ok {
 		r.WithLogger(e.logger)
 	}
@@ -447,8 +472,9 @@ func (e *Engine) CreateCursorIterator(ctx context.Context) (cursors.CursorIterat
 // WritePoints writes the provided points to the engine.
 //
 // The Engine expects all points to have been correctly validated by the caller.
-// However, WritePoints will determine if any tag key-pairs are missing, or if
-// there are any field type conflicts.
+// However, WritePoints will determine if any tag key-pairs are missing, if
+// there are any field type conflicts, or if points do not match the schema of
+// their bucket when schemas are enforced.
 //
 // Appropriate errors are returned in those cases.
 func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
@@ -457,28 +483,25 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 
 	collection, j := tsdb.NewSeriesCollection(points), 0
 
-	// dropPoint should be called whenever there is reason to drop a point from
-	// the batch.
-	dropPoint := func(key []byte, reason string) {
-		if collection.Reason == "" {
-			collection.Reason = reason
-		}
-		collection.Dropped++
-		collection.DroppedKeys = append(collection.DroppedKeys, key)
-	}
+	// The schema of the bucket of the previous point, as a batch usually holds
+	// the points of a single bucket.
+	var (
+		schemaName   []byte
+		bucketSchema *schema
+	)
 
 	for iter := collection.Iterator(); iter.Next(); {
 		tags := iter.Tags()
 
 		// Not enough tags present.
 		if tags.Len() < 2 {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required tags: parsed tags: %q", tags))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required tags: parsed tags: %q", tags))
 			continue
 		}
 
 		// First tag key is not measurement tag.
 		if !bytes.Equal(tags[0].Key, models.MeasurementTagKeyBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required measurement tag as first tag, got: %q", tags[0].Key))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required measurement tag as first tag, got: %q", tags[0].Key))
 			continue
 		}
 
@@ -486,29 +509,42 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 
 		// Last tag key is not field tag.
 		if !bytes.Equal(fkey, models.FieldKeyTagKeyBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required field key tag as last tag, got: %q", tags[0].Key))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required field key tag as last tag, got: %q", tags[0].Key))
 			continue
 		}
 
 		// The value representing the underlying field key is invalid if it's "time".
 		if bytes.Equal(fval, timeBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes))
+			collection.Drop(iter.Index(), fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes))
 			continue
 		}
 
 		// Filter out any tags with key equal to "time": they are invalid.
 		if tags.Get(timeBytes) != nil {
-			dropPoint(iter.Key(), fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name()))
+			collection.Drop(iter.Index(), fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name()))
 			continue
 		}
 
 		// Drop any point with invalid unicode characters in any of the tag keys or values.
 		// This will also cover validating the value used to represent the field key.
 		if !models.ValidTagTokens(tags) {
-			dropPoint(iter.Key(), fmt.Sprintf("key contains invalid unicode: %q", iter.Key()))
+			collection.Drop(iter.Index(), fmt.Sprintf("key contains invalid unicode: %q", iter.Key()))
 			continue
 		}
 
+		// Drop any point that does not match the schema of its bucket.
+		if e.bucketSchemas != nil {
+			if name := iter.Name(); !bytes.Equal(name, schemaName) {
+				schemaName, bucketSchema = name, e.bucketSchemas.Schema(ctx, name)
+			}
+			if bucketSchema != nil {
+				if reason := bucketSchema.validate(tags, iter.Type()); reason != "" {
+					collection.Drop(iter.Index(), reason)
+					continue
+				}
+			}
+		}
+
 		collection.Copy(j, iter.Index())
 		j++
 	}
@@ -521,6 +557,28 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 		return ErrEngineClosed
 	}
 
+	// Drop the points that would create series beyond the limits of their bucket
+	// or org. This is done last so that dropped points are not counted.
+	var seriesLimitExceeded bool
+	if e.seriesLimiter != nil {
+		reasons, err := e.seriesLimiter.Check(collection)
+		if err != nil {
+			return err
+		}
+
+		j = 0
+		for iter := collection.Iterator(); iter.Next(); {
+			if reason := reasons[iter.Index()]; reason != "" {
+				collection.Drop(iter.Index(), reason)
+				seriesLimitExceeded = true
+				continue
+			}
+			collection.Copy(j, iter.Index())
+			j++
+		}
+		collection.Truncate(j)
+	}
+
 	// Convert the collection to values for adding to the WAL/Cache.
 	values, err := tsm1.CollectionToValues(collection)
 	if err != nil {
@@ -532,7 +590,15 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 		return err
 	}
 
-	return e.writePointsLocked(ctx, collection, values)
+	err = e.writePointsLocked(ctx, collection, values)
+	if err != nil && seriesLimitExceeded {
+		return &influxdb.Error{
+			Code: influxdb.ESeriesLimitExceeded,
+			Msg:  "points creating new series beyond the series limits were dropped",
+			Err:  err,
+		}
+	}
+	return err
 }
 
 // writePointsLocked does the work of writing points and must be called under some sort of lock.
@@ -605,7 +671,47 @@ func (e *Engine) CommitSegments(ctx context.Context, segs []string, fn func() er
 func (e *Engine) DeleteBucket(ctx context.Context, orgID, bucketID influxdb.ID) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
-	return e.DeleteBucketRange(ctx, orgID, bucketID, math.MinInt64, math.MaxInt64)
+
+	// Drop the shard groups of the bucket as a whole before deleting what remains.
+	if _, err := e.DropBucketPartitions(ctx, orgID, bucketID, math.MaxInt64); err != nil {
+		return err
+	}
+	if err := e.DeleteBucketRange(ctx, orgID, bucketID, math.MinInt64, math.MaxInt64); err != nil {
+		return err
+	}
+
+	// The series of the bucket no longer count against the limit of its org.
+	if e.seriesLimiter != nil {
+		e.seriesLimiter.Invalidate()
+	}
+	return nil
+}
+
+// DropBucketPartitions drops the shard groups of a bucket that end at or before max,
+// removing their TSM files from disk. It returns the number of bytes reclaimed.
+func (e *Engine) DropBucketPartitions(ctx context.Context, orgID, bucketID influxdb.ID, max int64) (int64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0, ErrEngineClosed
+	}
+
+	return e.engine.DropPartitions(ctx, orgID, bucketID, max)
+}
+
+// HasUnpartitionedData returns true if TSM files written before the data of the
+// engine was partitioned in shard groups may hold data of the bucket at or before max.
+func (e *Engine) HasUnpartitionedData(orgID, bucketID influxdb.ID, max int64) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return false
+	}
+
+	return e.engine.HasUnpartitionedData(orgID, bucketID, max)
 }
 
 // DeleteBucketRange deletes an entire bucket from the storage engine.
@@ -684,21 +790,101 @@ func (e *Engine) CreateBackup(ctx context.Context) (int, []string, error) {
 		return 0, nil, err
 	}
 
-	id, snapshotPath, err := e.engine.FileStore.CreateSnapshot(ctx)
+	id, _, snapshotFiles, err := e.engine.FileStore.CreateIncrementalSnapshot(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	filenames := make([]string, len(snapshotFiles))
+	for i, f := range snapshotFiles {
+		filenames[i] = f.Name
+	}
+
+	return id, filenames, nil
+}
+
+// CreateIncrementalBackup creates a "snapshot" of the TSM data and WAL segments
+// that are not listed in exclude.
+//   1) Close the current WAL segment so that every write before now is in a
+//      closed segment. If the WAL is disabled, snapshot the cache instead.
+//   2) Create hard links to the TSM files, tombstones and closed WAL segments
+//      that are not excluded, in a new directory within the engine root directory.
+//   3) Return a unique backup ID (invalid after the process terminates) and
+//      the list of all files, linked or not.
+func (e *Engine) CreateIncrementalBackup(ctx context.Context, exclude []influxdb.BackupFile) (int, []influxdb.BackupFile, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if e.closing == nil {
+		return 0, nil, ErrEngineClosed
+	}
+
+	excluded := make(map[influxdb.BackupFile]bool, len(exclude))
+	for _, f := range exclude {
+		excluded[f] = true
+	}
+
+	include := func(f tsm1.SnapshotFile) bool {
+		return !excluded[influxdb.BackupFile{Name: f.Name, Size: f.Size}]
+	}
+
+	if !e.config.WAL.Enabled {
+		if err := e.engine.WriteSnapshot(ctx, tsm1.CacheStatusBackup); err != nil {
+			return 0, nil, err
+		}
+
+		id, _, snapshotFiles, err := e.engine.FileStore.CreateIncrementalSnapshot(ctx, include)
+		if err != nil {
+			return 0, nil, err
+		}
+		return id, backupFiles(snapshotFiles), nil
+	}
+
+	// Segments are only removed under the engine lock once their data has
+	// been written to TSM files, so holding it ensures every write is either
+	// in a linked segment or in a linked TSM file.
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.wal.CloseSegment(); err != nil {
+		return 0, nil, err
+	}
+
+	segments, err := e.wal.ClosedSegments()
 	if err != nil {
 		return 0, nil, err
 	}
 
-	fileInfos, err := ioutil.ReadDir(snapshotPath)
+	id, snapshotPath, snapshotFiles, err := e.engine.FileStore.CreateIncrementalSnapshot(ctx, include)
 	if err != nil {
 		return 0, nil, err
 	}
-	filenames := make([]string, len(fileInfos))
-	for i, fi := range fileInfos {
-		filenames[i] = fi.Name()
+
+	files := backupFiles(snapshotFiles)
+	for _, seg := range segments {
+		fi, err := os.Stat(seg)
+		if err != nil {
+			return 0, nil, multierr.Append(err, os.RemoveAll(snapshotPath))
+		}
+
+		f := influxdb.BackupFile{Name: filepath.Base(seg), Size: fi.Size()}
+		if !excluded[f] {
+			if err := os.Link(seg, filepath.Join(snapshotPath, f.Name)); err != nil {
+				return 0, nil, multierr.Append(err, os.RemoveAll(snapshotPath))
+			}
+		}
+		files = append(files, f)
 	}
 
-	return id, filenames, nil
+	return id, files, nil
+}
+
+func backupFiles(snapshotFiles []tsm1.SnapshotFile) []influxdb.BackupFile {
+	files := make([]influxdb.BackupFile, 0, len(snapshotFiles))
+	for _, f := range snapshotFiles {
+		files = append(files, influxdb.BackupFile{Name: f.Name, Size: f.Size})
+	}
+	return files
 }
 
 // FetchBackupFile writes a given backup file to the provided writer.
@@ -719,7 +905,7 @@ func (e *Engine) FetchBackupFile(ctx context.Context, backupID int, backupFile s
 	}
 
 	backupPath := e.engine.FileStore.InternalBackupPath(backupID)
-	backupFileFullPath := filepath.Join(backupPath, backupFile)
+	backupFileFullPath := filepath.Join(backupPath, filepath.FromSlash(backupFile))
 	if err := os.Remove(backupFileFullPath); err != nil {
 		e.logger.Info("Failed to remove backup file after fetch", zap.Error(err), zap.Int("backup_id", backupID), zap.String("backup_file", backupFile))
 	}
@@ -738,7 +924,13 @@ func (e *Engine) fetchBackup(ctx context.Context, backupID int, backupFile strin
 		return errors.Errorf("error in filesystem path of backup %d", backupID)
 	}
 
-	backupFileFullPath := filepath.Join(backupPath, backupFile)
+	// The files of time partitions are in directories of their own, but no
+	// file is outside of the backup.
+	if name := path.Clean(backupFile); name != backupFile || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return errors.Errorf("backup file %d/%s not found", backupID, backupFile)
+	}
+
+	backupFileFullPath := filepath.Join(backupPath, filepath.FromSlash(backupFile))
 	file, err := os.Open(backupFileFullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -796,6 +988,21 @@ func (e *Engine) MeasurementCardinalityStats() (tsi1.MeasurementCardinalityStats
 	return e.index.MeasurementCardinalityStats()
 }
 
+// BucketCardinality returns the series cardinality of a bucket broken down by
+// measurement and tag key, with the topN values having the most series for each
+// tag key.
+func (e *Engine) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+	return e.index.BucketCardinality(ctx, orgID, bucketID, topN)
+}
+
 // MeasurementStats returns the current measurement stats for the engine.
 func (e *Engine) MeasurementStats() (tsm1.MeasurementStats, error) {
 	e.mu.RLock()
@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -464,6 +465,64 @@ func TestEngine_WALDisabled(t *testing.T) {
 	}
 }
 
+func TestEngine_CreateIncrementalBackup(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pt := models.MustNewPoint(
+		"cpu",
+		models.Tags{
+			{Key: models.MeasurementTagKeyBytes, Value: []byte("cpu")},
+			{Key: []byte("host"), Value: []byte("server")},
+			{Key: models.FieldKeyTagKeyBytes, Value: []byte("value")},
+		},
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)
+
+	if err := engine.Engine.WritePoints(context.TODO(), []models.Point{pt}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, files, err := engine.CreateIncrementalBackup(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The write is only in the WAL, so the backup must hold a closed segment.
+	var segments int
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(engine.InternalBackupPath(id), f.Name)); err != nil {
+			t.Fatalf("unable to find file %q: %v", f.Name, err)
+		}
+		if filepath.Ext(f.Name) == ".wal" && f.Size > 0 {
+			segments++
+		}
+	}
+	if segments == 0 {
+		t.Fatalf("expected a WAL segment in backup files %v", files)
+	}
+
+	id, next, err := engine.CreateIncrementalBackup(context.Background(), files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	excluded := make(map[influxdb.BackupFile]bool)
+	for _, f := range files {
+		excluded[f] = true
+	}
+	for _, f := range next {
+		_, err := os.Stat(filepath.Join(engine.InternalBackupPath(id), f.Name))
+		if excluded[f] && !os.IsNotExist(err) {
+			t.Fatalf("expected excluded file %q not to be in backup: %v", f.Name, err)
+		} else if !excluded[f] && err != nil {
+			t.Fatalf("unable to find file %q: %v", f.Name, err)
+		}
+	}
+}
+
 func TestEngine_WriteConflictingBatch(t *testing.T) {
 	engine := NewDefaultEngine()
 	defer engine.Close()
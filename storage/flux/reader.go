@@ -3,6 +3,7 @@ package storageflux
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/gogo/protobuf/types"
@@ -53,6 +54,15 @@ func (r *storeReader) ReadGroup(ctx context.Context, spec influxdb.ReadGroupSpec
 	}, nil
 }
 
+func (r *storeReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (influxdb.TableIterator, error) {
+	return &windowAggregateIterator{
+		ctx:   ctx,
+		s:     r.s,
+		spec:  spec,
+		alloc: alloc,
+	}, nil
+}
+
 func (r *storeReader) ReadTagKeys(ctx context.Context, spec influxdb.ReadTagKeysSpec, alloc *memory.Allocator) (influxdb.TableIterator, error) {
 	var predicate *datatypes.Predicate
 	if spec.Predicate != nil {
@@ -310,6 +320,9 @@ READ:
 		}
 
 		if cur == nil {
+			if err := gc.Err(); err != nil {
+				return err
+			}
 			gc.Close()
 			gc = rs.Next()
 			continue
@@ -365,6 +378,286 @@ READ:
 	return rs.Err()
 }
 
+type windowAggregateIterator struct {
+	ctx   context.Context
+	s     storage.Store
+	spec  influxdb.ReadWindowAggregateSpec
+	stats cursors.CursorStats
+	alloc *memory.Allocator
+}
+
+func (wai *windowAggregateIterator) Statistics() cursors.CursorStats { return wai.stats }
+
+func (wai *windowAggregateIterator) Do(f func(flux.Table) error) error {
+	src := wai.s.GetSource(
+		uint64(wai.spec.OrganizationID),
+		uint64(wai.spec.BucketID),
+	)
+
+	// Setup read request
+	any, err := types.MarshalAny(src)
+	if err != nil {
+		return err
+	}
+
+	var predicate *datatypes.Predicate
+	if wai.spec.Predicate != nil {
+		p, err := toStoragePredicate(wai.spec.Predicate)
+		if err != nil {
+			return err
+		}
+		predicate = p
+	}
+
+	var req datatypes.ReadWindowAggregateRequest
+	req.ReadSource = any
+	req.Predicate = predicate
+	req.Range.Start = int64(wai.spec.Bounds.Start)
+	req.Range.End = int64(wai.spec.Bounds.Stop)
+	req.WindowEvery = wai.spec.WindowEvery
+	req.Aggregate = make([]*datatypes.Aggregate, len(wai.spec.Aggregates))
+	for i, aggKind := range wai.spec.Aggregates {
+		agg, err := determineAggregateMethod(string(aggKind))
+		if err != nil {
+			return err
+		} else if agg == datatypes.AggregateTypeNone {
+			return fmt.Errorf("window aggregate requires an aggregate")
+		}
+		req.Aggregate[i] = &datatypes.Aggregate{Type: agg}
+	}
+
+	rs, err := wai.s.ReadWindowAggregate(wai.ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	if rs == nil {
+		return nil
+	}
+
+	agg := datatypes.AggregateTypeNone
+	if len(req.Aggregate) > 0 {
+		agg = req.Aggregate[0].Type
+	}
+	return wai.handleRead(f, rs, agg)
+}
+
+func (wai *windowAggregateIterator) handleRead(f func(flux.Table) error, rs storage.ResultSet, agg datatypes.Aggregate_AggregateType) error {
+	defer rs.Close()
+
+	for rs.Next() {
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		err := wai.handleSeries(f, rs.Tags(), cur, agg)
+		stats := cur.Stats()
+		wai.stats.ScannedValues += stats.ScannedValues
+		wai.stats.ScannedBytes += stats.ScannedBytes
+		cur.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := wai.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return rs.Err()
+}
+
+// handleSeries produces one table for every window of the series. Each
+// point read from the cursor is the aggregate of a single window.
+func (wai *windowAggregateIterator) handleSeries(f func(flux.Table) error, tags models.Tags, cur cursors.Cursor, agg datatypes.Aggregate_AggregateType) error {
+	var (
+		typ  flux.ColType
+		next func() ([]int64, func(b execute.TableBuilder, j, i int) error)
+	)
+	switch typedCur := cur.(type) {
+	case cursors.IntegerArrayCursor:
+		typ = flux.TInt
+		next = func() ([]int64, func(b execute.TableBuilder, j, i int) error) {
+			a := typedCur.Next()
+			return a.Timestamps, func(b execute.TableBuilder, j, i int) error { return b.AppendInt(j, a.Values[i]) }
+		}
+	case cursors.FloatArrayCursor:
+		typ = flux.TFloat
+		next = func() ([]int64, func(b execute.TableBuilder, j, i int) error) {
+			a := typedCur.Next()
+			return a.Timestamps, func(b execute.TableBuilder, j, i int) error { return b.AppendFloat(j, a.Values[i]) }
+		}
+	case cursors.UnsignedArrayCursor:
+		typ = flux.TUInt
+		next = func() ([]int64, func(b execute.TableBuilder, j, i int) error) {
+			a := typedCur.Next()
+			return a.Timestamps, func(b execute.TableBuilder, j, i int) error { return b.AppendUInt(j, a.Values[i]) }
+		}
+	case cursors.BooleanArrayCursor:
+		typ = flux.TBool
+		next = func() ([]int64, func(b execute.TableBuilder, j, i int) error) {
+			a := typedCur.Next()
+			return a.Timestamps, func(b execute.TableBuilder, j, i int) error { return b.AppendBool(j, a.Values[i]) }
+		}
+	case cursors.StringArrayCursor:
+		typ = flux.TString
+		next = func() ([]int64, func(b execute.TableBuilder, j, i int) error) {
+			a := typedCur.Next()
+			return a.Timestamps, func(b execute.TableBuilder, j, i int) error { return b.AppendString(j, a.Values[i]) }
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", typedCur))
+	}
+
+	selector := isSelectorAggregate(agg)
+	cols := determineTableColsForWindowAggregate(tags, typ, selector)
+
+	// emptyStart is the start of the first window that has not been
+	// produced yet, it only matters when empty windows are created.
+	emptyStart, seen := wai.spec.Bounds.Start, false
+	for {
+		ts, appendValue := next()
+		if len(ts) == 0 {
+			if !wai.spec.CreateEmpty || !seen {
+				return nil
+			}
+			return wai.handleEmptyWindows(f, tags, cols, agg, emptyStart, wai.spec.Bounds.Stop)
+		}
+
+		for i, t := range ts {
+			bnds := wai.windowBounds(t)
+			if wai.spec.CreateEmpty {
+				if err := wai.handleEmptyWindows(f, tags, cols, agg, emptyStart, bnds.Start); err != nil {
+					return err
+				}
+				emptyStart, seen = bnds.Stop, true
+			}
+
+			key := defaultGroupKeyForSeries(tags, bnds)
+			b := execute.NewColListTableBuilder(key, wai.alloc)
+			for _, c := range cols {
+				if _, err := b.AddCol(c); err != nil {
+					return err
+				}
+			}
+			for j, c := range cols {
+				var err error
+				switch c.Label {
+				case execute.DefaultStartColLabel:
+					err = b.AppendTime(j, bnds.Start)
+				case execute.DefaultStopColLabel:
+					err = b.AppendTime(j, bnds.Stop)
+				case execute.DefaultTimeColLabel:
+					err = b.AppendTime(j, values.Time(t))
+				case execute.DefaultValueColLabel:
+					err = appendValue(b, j, i)
+				default:
+					err = b.AppendString(j, string(tags.Get([]byte(c.Label))))
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			table, err := b.Table()
+			if err != nil {
+				return err
+			}
+			if err := f(table); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleEmptyWindows produces one table for every window of the series
+// between start and stop, which contain no points. As with the aggregates
+// of flux, count is zero, the other aggregates are null and selectors
+// produce no rows.
+func (wai *windowAggregateIterator) handleEmptyWindows(f func(flux.Table) error, tags models.Tags, cols []flux.ColMeta, agg datatypes.Aggregate_AggregateType, start, stop values.Time) error {
+	for start < stop {
+		bnds := wai.windowBounds(int64(start))
+		start = bnds.Stop
+
+		key := defaultGroupKeyForSeries(tags, bnds)
+		b := execute.NewColListTableBuilder(key, wai.alloc)
+		for _, c := range cols {
+			if _, err := b.AddCol(c); err != nil {
+				return err
+			}
+		}
+		if !isSelectorAggregate(agg) {
+			for j, c := range cols {
+				var err error
+				switch c.Label {
+				case execute.DefaultStartColLabel:
+					err = b.AppendTime(j, bnds.Start)
+				case execute.DefaultStopColLabel:
+					err = b.AppendTime(j, bnds.Stop)
+				case execute.DefaultValueColLabel:
+					if agg == datatypes.AggregateTypeCount {
+						err = b.AppendInt(j, 0)
+					} else {
+						err = b.AppendNil(j)
+					}
+				default:
+					err = b.AppendString(j, string(tags.Get([]byte(c.Label))))
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		table, err := b.Table()
+		if err != nil {
+			return err
+		}
+		if err := f(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSelectorAggregate returns true if the aggregate selects one of the
+// points of the window rather than computing a new value.
+func isSelectorAggregate(agg datatypes.Aggregate_AggregateType) bool {
+	switch agg {
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return true
+	}
+	return false
+}
+
+// windowBounds returns the bounds of the window containing t, limited
+// to the bounds of the read.
+func (wai *windowAggregateIterator) windowBounds(t int64) execute.Bounds {
+	every := wai.spec.WindowEvery
+	if every == math.MaxInt64 {
+		// A single window spans the range.
+		return wai.spec.Bounds
+	}
+
+	r := t % every
+	if r < 0 {
+		r += every
+	}
+	bnds := execute.Bounds{
+		Start: values.Time(t - r),
+		Stop:  values.Time(t - r + every),
+	}
+	if bnds.Start < wai.spec.Bounds.Start {
+		bnds.Start = wai.spec.Bounds.Start
+	}
+	if bnds.Stop > wai.spec.Bounds.Stop {
+		bnds.Stop = wai.spec.Bounds.Stop
+	}
+	return bnds
+}
+
 func determineAggregateMethod(agg string) (datatypes.Aggregate_AggregateType, error) {
 	if agg == "" {
 		return datatypes.AggregateTypeNone, nil
@@ -422,6 +715,26 @@ func determineTableColsForSeries(tags models.Tags, typ flux.ColType) ([]flux.Col
 	return cols, defs
 }
 
+// determineTableColsForWindowAggregate returns the columns of a window
+// aggregate table. Selectors keep the columns of the series while
+// aggregates retain only the group key and the aggregated value.
+func determineTableColsForWindowAggregate(tags models.Tags, typ flux.ColType, selector bool) []flux.ColMeta {
+	if selector {
+		cols, _ := determineTableColsForSeries(tags, typ)
+		return cols
+	}
+
+	cols := make([]flux.ColMeta, 0, 3+len(tags))
+	cols = append(cols,
+		flux.ColMeta{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+		flux.ColMeta{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+	)
+	for _, tag := range tags {
+		cols = append(cols, flux.ColMeta{Label: string(tag.Key), Type: flux.TString})
+	}
+	return append(cols, flux.ColMeta{Label: execute.DefaultValueColLabel, Type: typ})
+}
+
 func defaultGroupKeyForSeries(tags models.Tags, bnds execute.Bounds) flux.GroupKey {
 	cols := make([]flux.ColMeta, 2, len(tags)+2)
 	vs := make([]values.Value, 2, len(tags)+2)
@@ -34,9 +34,10 @@ const retentionSubsystem = "retention" // sub-system associated with metrics for
 
 // retentionMetrics is a set of metrics concerned with tracking data about retention policies.
 type retentionMetrics struct {
-	labels        prometheus.Labels
-	Checks        *prometheus.CounterVec
-	CheckDuration *prometheus.HistogramVec
+	labels         prometheus.Labels
+	Checks         *prometheus.CounterVec
+	CheckDuration  *prometheus.HistogramVec
+	ReclaimedBytes *prometheus.CounterVec
 }
 
 func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
@@ -52,6 +53,8 @@ func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
 	checkDurationNames := append(append([]string(nil), names...), "status")
 	sort.Strings(checkDurationNames)
 
+	reclaimedBytesNames := append([]string(nil), names...)
+
 	return &retentionMetrics{
 		labels: labels,
 		Checks: prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -69,6 +72,13 @@ func newRetentionMetrics(labels prometheus.Labels) *retentionMetrics {
 			// 25 buckets spaced exponentially between 10s and ~2h
 			Buckets: prometheus.ExponentialBuckets(10, 1.32, 25),
 		}, checkDurationNames),
+
+		ReclaimedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: retentionSubsystem,
+			Name:      "reclaimed_bytes_total",
+			Help:      "Number of bytes reclaimed by dropping expired shard groups.",
+		}, reclaimedBytesNames),
 	}
 }
 
@@ -86,5 +96,6 @@ func (rm *retentionMetrics) PrometheusCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		rm.Checks,
 		rm.CheckDuration,
+		rm.ReclaimedBytes,
 	}
 }
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultShardGroupDuration is the duration of the shard groups of buckets with
+	// an infinite or long retention period, and of buckets that are not known yet.
+	DefaultShardGroupDuration = 7 * 24 * time.Hour
+
+	// shardGroupDurationsTTL is how long the shard group durations of the buckets
+	// are used before being refreshed from the bucket service.
+	shardGroupDurationsTTL = time.Minute
+)
+
+// ShardGroupDuration returns the duration of the shard groups, the time partitions
+// of the TSM data, of a bucket with the given retention period. It follows the
+// rules of InfluxDB 1.x so that expired data is dropped shortly after it expires
+// without creating too many partitions.
+func ShardGroupDuration(retentionPeriod time.Duration) time.Duration {
+	switch {
+	case retentionPeriod == 0 || retentionPeriod >= 180*24*time.Hour:
+		return DefaultShardGroupDuration
+	case retentionPeriod >= 2*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// shardGroupDurations provides the shard group duration of every bucket to the
+// TSM engine, caching the retention periods of the buckets.
+type shardGroupDurations struct {
+	// fixed, if set, is the shard group duration of all buckets.
+	fixed time.Duration
+
+	mu        sync.RWMutex
+	finder    BucketFinder
+	durations map[[16]byte]time.Duration
+	refreshed time.Time
+
+	logger *zap.Logger
+}
+
+func newShardGroupDurations(fixed time.Duration) *shardGroupDurations {
+	return &shardGroupDurations{
+		fixed:     fixed,
+		durations: make(map[[16]byte]time.Duration),
+		logger:    zap.NewNop(),
+	}
+}
+
+// Duration returns the shard group duration of the bucket encoded in name. It
+// satisfies tsm1.PartitionDurationFunc.
+func (s *shardGroupDurations) Duration(name []byte) time.Duration {
+	if s.fixed > 0 {
+		return s.fixed
+	}
+
+	var key [16]byte
+	copy(key[:], name)
+
+	s.mu.RLock()
+	stale := s.finder != nil && time.Since(s.refreshed) > shardGroupDurationsTTL
+	d, ok := s.durations[key]
+	s.mu.RUnlock()
+
+	if stale {
+		s.refresh()
+		s.mu.RLock()
+		d, ok = s.durations[key]
+		s.mu.RUnlock()
+	}
+
+	if !ok {
+		return DefaultShardGroupDuration
+	}
+	return d
+}
+
+// refresh reloads the retention periods of all buckets.
+func (s *shardGroupDurations) refresh() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.refreshed) <= shardGroupDurationsTTL {
+		return // Refreshed concurrently.
+	}
+	// Don't retry before the TTL expires, whatever the outcome.
+	s.refreshed = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
+	defer cancel()
+
+	buckets, _, err := s.finder.FindBuckets(ctx, influxdb.BucketFilter{})
+	if err != nil {
+		s.logger.Info("Unable to refresh bucket shard group durations", zap.Error(err))
+		return
+	}
+	s.durations = make(map[[16]byte]time.Duration, len(buckets))
+	for _, b := range buckets {
+		s.durations[tsdb.EncodeName(b.OrgID, b.ID)] = ShardGroupDuration(b.RetentionPeriod)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestShardGroupDuration(t *testing.T) {
+	const day = 24 * time.Hour
+	for _, tt := range []struct {
+		retention time.Duration
+		exp       time.Duration
+	}{
+		{retention: 0, exp: 7 * day},
+		{retention: time.Hour, exp: time.Hour},
+		{retention: 2*day - time.Nanosecond, exp: time.Hour},
+		{retention: 2 * day, exp: day},
+		{retention: 180*day - time.Nanosecond, exp: day},
+		{retention: 180 * day, exp: 7 * day},
+		{retention: 365 * day, exp: 7 * day},
+	} {
+		if got := ShardGroupDuration(tt.retention); got != tt.exp {
+			t.Errorf("retention %v: got %v, expected %v", tt.retention, got, tt.exp)
+		}
+	}
+}
+
+func TestShardGroupDurations_Duration(t *testing.T) {
+	var calls int
+	finder := NewTestBucketFinder()
+	finder.FindBucketsFn = func(context.Context, influxdb.BucketFilter, ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		calls++
+		return []*influxdb.Bucket{
+			{OrgID: 1, ID: 2, RetentionPeriod: time.Hour},
+			{OrgID: 1, ID: 3, RetentionPeriod: 30 * 24 * time.Hour},
+		}, 2, nil
+	}
+
+	durations := newShardGroupDurations(0)
+	durations.finder = finder
+
+	for _, tt := range []struct {
+		bucket influxdb.ID
+		exp    time.Duration
+	}{
+		{bucket: 2, exp: time.Hour},
+		{bucket: 3, exp: 24 * time.Hour},
+		{bucket: 4, exp: DefaultShardGroupDuration}, // unknown bucket
+	} {
+		name := tsdb.EncodeName(1, tt.bucket)
+		if got := durations.Duration(name[:]); got != tt.exp {
+			t.Errorf("bucket %v: got %v, expected %v", tt.bucket, got, tt.exp)
+		}
+	}
+
+	// The buckets are only looked up once per TTL.
+	if calls != 1 {
+		t.Fatalf("got %d bucket lookups, expected 1", calls)
+	}
+
+	// A fixed duration applies to all buckets.
+	durations = newShardGroupDurations(time.Minute)
+	durations.finder = finder
+	name := tsdb.EncodeName(1, 2)
+	if got, exp := durations.Duration(name[:]), time.Minute; got != exp {
+		t.Fatalf("got %v, expected %v", got, exp)
+	}
+}
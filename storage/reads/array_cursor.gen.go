@@ -224,6 +224,343 @@ func (c *integerFloatCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// floatLimitArrayCursor returns only the first point of a FloatArrayCursor. The
+// underlying cursor is not advanced past its first block, so only the
+// blocks containing that point are read.
+type floatLimitArrayCursor struct {
+	cursors.FloatArrayCursor
+	res  *cursors.FloatArray
+	done bool
+}
+
+func newFloatLimitArrayCursor(cur cursors.FloatArrayCursor) *floatLimitArrayCursor {
+	return &floatLimitArrayCursor{
+		FloatArrayCursor: cur,
+		res:              cursors.NewFloatArrayLen(1),
+	}
+}
+
+func (c *floatLimitArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatLimitArrayCursor) Next() *cursors.FloatArray {
+	if c.done {
+		return &cursors.FloatArray{}
+	}
+	a := c.FloatArrayCursor.Next()
+	if a.Len() == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+// floatWindowCountArrayCursor counts the points of each window of a FloatArrayCursor.
+type floatWindowCountArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.FloatArray
+}
+
+func newFloatWindowCountArrayCursor(every int64, cur cursors.FloatArrayCursor) *floatWindowCountArrayCursor {
+	return &floatWindowCountArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowCountArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc++
+		}
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// next returns the points left over by the previous call to Next, or the
+// next array of the underlying cursor.
+func (c *floatWindowCountArrayCursor) next() *cursors.FloatArray {
+	if c.tmp.Len() == 0 {
+		return c.FloatArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.FloatArray{}
+	return &a
+}
+
+// floatWindowSumArrayCursor sums the values of each window of a FloatArrayCursor.
+type floatWindowSumArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	tmp   cursors.FloatArray
+}
+
+func newFloatWindowSumArrayCursor(every int64, cur cursors.FloatArrayCursor) *floatWindowSumArrayCursor {
+	return &floatWindowSumArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowSumArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowSumArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   float64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc += a.Values[i]
+		}
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *floatWindowSumArrayCursor) next() *cursors.FloatArray {
+	if c.tmp.Len() == 0 {
+		return c.FloatArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.FloatArray{}
+	return &a
+}
+
+// floatWindowSelectorArrayCursor selects the minimum or maximum point of each window of a
+// FloatArrayCursor. The selected points keep their timestamp; when
+// several points share the selected value, the first one is selected.
+type floatWindowSelectorArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	max   bool
+	res   *cursors.FloatArray
+	tmp   cursors.FloatArray
+}
+
+func newFloatWindowSelectorArrayCursor(every int64, max bool, cur cursors.FloatArrayCursor) *floatWindowSelectorArrayCursor {
+	return &floatWindowSelectorArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		max:              max,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatWindowSelectorArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		selT  int64
+		selV  float64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = selT
+				c.res.Values[pos] = selV
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			v := a.Values[i]
+			if !ok {
+				start, selT, selV, ok = windowStart(t, c.every), t, v, true
+			} else if (c.max && v > selV) || (!c.max && v < selV) {
+				selT, selV = t, v
+			}
+		}
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = selT
+		c.res.Values[pos] = selV
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *floatWindowSelectorArrayCursor) next() *cursors.FloatArray {
+	if c.tmp.Len() == 0 {
+		return c.FloatArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.FloatArray{}
+	return &a
+}
+
+// floatWindowMeanArrayCursor averages the values of each window of a FloatArrayCursor.
+type floatWindowMeanArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	tmp   cursors.FloatArray
+}
+
+func newFloatWindowMeanArrayCursor(every int64, cur cursors.FloatArrayCursor) *floatWindowMeanArrayCursor {
+	return &floatWindowMeanArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowMeanArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		sum   float64
+		count int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(count)
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, sum, count, ok = windowStart(t, c.every), 0, 0, true
+			}
+			sum += float64(a.Values[i])
+			count++
+		}
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(count)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *floatWindowMeanArrayCursor) next() *cursors.FloatArray {
+	if c.tmp.Len() == 0 {
+		return c.FloatArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.FloatArray{}
+	return &a
+}
+
 type floatEmptyArrayCursor struct {
 	res cursors.FloatArray
 }
@@ -440,6 +777,349 @@ func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// integerLimitArrayCursor returns only the first point of a IntegerArrayCursor. The
+// underlying cursor is not advanced past its first block, so only the
+// blocks containing that point are read.
+type integerLimitArrayCursor struct {
+	cursors.IntegerArrayCursor
+	res  *cursors.IntegerArray
+	done bool
+}
+
+func newIntegerLimitArrayCursor(cur cursors.IntegerArrayCursor) *integerLimitArrayCursor {
+	return &integerLimitArrayCursor{
+		IntegerArrayCursor: cur,
+		res:                cursors.NewIntegerArrayLen(1),
+	}
+}
+
+func (c *integerLimitArrayCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerLimitArrayCursor) Next() *cursors.IntegerArray {
+	if c.done {
+		return &cursors.IntegerArray{}
+	}
+	a := c.IntegerArrayCursor.Next()
+	if a.Len() == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+// integerWindowCountArrayCursor counts the points of each window of a IntegerArrayCursor.
+type integerWindowCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.IntegerArray
+}
+
+func newIntegerWindowCountArrayCursor(every int64, cur cursors.IntegerArrayCursor) *integerWindowCountArrayCursor {
+	return &integerWindowCountArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc++
+		}
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// next returns the points left over by the previous call to Next, or the
+// next array of the underlying cursor.
+func (c *integerWindowCountArrayCursor) next() *cursors.IntegerArray {
+	if c.tmp.Len() == 0 {
+		return c.IntegerArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.IntegerArray{}
+	return &a
+}
+
+// integerWindowSumArrayCursor sums the values of each window of a IntegerArrayCursor.
+type integerWindowSumArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.IntegerArray
+}
+
+func newIntegerWindowSumArrayCursor(every int64, cur cursors.IntegerArrayCursor) *integerWindowSumArrayCursor {
+	return &integerWindowSumArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSumArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc += a.Values[i]
+		}
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *integerWindowSumArrayCursor) next() *cursors.IntegerArray {
+	if c.tmp.Len() == 0 {
+		return c.IntegerArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.IntegerArray{}
+	return &a
+}
+
+// integerWindowSelectorArrayCursor selects the minimum or maximum point of each window of a
+// IntegerArrayCursor. The selected points keep their timestamp; when
+// several points share the selected value, the first one is selected.
+type integerWindowSelectorArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	max   bool
+	res   *cursors.IntegerArray
+	tmp   cursors.IntegerArray
+}
+
+func newIntegerWindowSelectorArrayCursor(every int64, max bool, cur cursors.IntegerArrayCursor) *integerWindowSelectorArrayCursor {
+	return &integerWindowSelectorArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		max:                max,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSelectorArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		selT  int64
+		selV  int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = selT
+				c.res.Values[pos] = selV
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			v := a.Values[i]
+			if !ok {
+				start, selT, selV, ok = windowStart(t, c.every), t, v, true
+			} else if (c.max && v > selV) || (!c.max && v < selV) {
+				selT, selV = t, v
+			}
+		}
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = selT
+		c.res.Values[pos] = selV
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *integerWindowSelectorArrayCursor) next() *cursors.IntegerArray {
+	if c.tmp.Len() == 0 {
+		return c.IntegerArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.IntegerArray{}
+	return &a
+}
+
+// integerWindowMeanArrayCursor averages the values of each window of a IntegerArrayCursor.
+type integerWindowMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	tmp   cursors.IntegerArray
+}
+
+func newIntegerWindowMeanArrayCursor(every int64, cur cursors.IntegerArrayCursor) *integerWindowMeanArrayCursor {
+	return &integerWindowMeanArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		sum   float64
+		count int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(count)
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, sum, count, ok = windowStart(t, c.every), 0, 0, true
+			}
+			sum += float64(a.Values[i])
+			count++
+		}
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(count)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *integerWindowMeanArrayCursor) next() *cursors.IntegerArray {
+	if c.tmp.Len() == 0 {
+		return c.IntegerArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.IntegerArray{}
+	return &a
+}
+
 type integerEmptyArrayCursor struct {
 	res cursors.IntegerArray
 }
@@ -656,6 +1336,349 @@ func (c *integerUnsignedCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// unsignedLimitArrayCursor returns only the first point of a UnsignedArrayCursor. The
+// underlying cursor is not advanced past its first block, so only the
+// blocks containing that point are read.
+type unsignedLimitArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	res  *cursors.UnsignedArray
+	done bool
+}
+
+func newUnsignedLimitArrayCursor(cur cursors.UnsignedArrayCursor) *unsignedLimitArrayCursor {
+	return &unsignedLimitArrayCursor{
+		UnsignedArrayCursor: cur,
+		res:                 cursors.NewUnsignedArrayLen(1),
+	}
+}
+
+func (c *unsignedLimitArrayCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedLimitArrayCursor) Next() *cursors.UnsignedArray {
+	if c.done {
+		return &cursors.UnsignedArray{}
+	}
+	a := c.UnsignedArrayCursor.Next()
+	if a.Len() == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+// unsignedWindowCountArrayCursor counts the points of each window of a UnsignedArrayCursor.
+type unsignedWindowCountArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.UnsignedArray
+}
+
+func newUnsignedWindowCountArrayCursor(every int64, cur cursors.UnsignedArrayCursor) *unsignedWindowCountArrayCursor {
+	return &unsignedWindowCountArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc++
+		}
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// next returns the points left over by the previous call to Next, or the
+// next array of the underlying cursor.
+func (c *unsignedWindowCountArrayCursor) next() *cursors.UnsignedArray {
+	if c.tmp.Len() == 0 {
+		return c.UnsignedArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.UnsignedArray{}
+	return &a
+}
+
+// unsignedWindowSumArrayCursor sums the values of each window of a UnsignedArrayCursor.
+type unsignedWindowSumArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	tmp   cursors.UnsignedArray
+}
+
+func newUnsignedWindowSumArrayCursor(every int64, cur cursors.UnsignedArrayCursor) *unsignedWindowSumArrayCursor {
+	return &unsignedWindowSumArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   uint64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc += a.Values[i]
+		}
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *unsignedWindowSumArrayCursor) next() *cursors.UnsignedArray {
+	if c.tmp.Len() == 0 {
+		return c.UnsignedArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.UnsignedArray{}
+	return &a
+}
+
+// unsignedWindowSelectorArrayCursor selects the minimum or maximum point of each window of a
+// UnsignedArrayCursor. The selected points keep their timestamp; when
+// several points share the selected value, the first one is selected.
+type unsignedWindowSelectorArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	max   bool
+	res   *cursors.UnsignedArray
+	tmp   cursors.UnsignedArray
+}
+
+func newUnsignedWindowSelectorArrayCursor(every int64, max bool, cur cursors.UnsignedArrayCursor) *unsignedWindowSelectorArrayCursor {
+	return &unsignedWindowSelectorArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		max:                 max,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowSelectorArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		selT  int64
+		selV  uint64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = selT
+				c.res.Values[pos] = selV
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			v := a.Values[i]
+			if !ok {
+				start, selT, selV, ok = windowStart(t, c.every), t, v, true
+			} else if (c.max && v > selV) || (!c.max && v < selV) {
+				selT, selV = t, v
+			}
+		}
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = selT
+		c.res.Values[pos] = selV
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *unsignedWindowSelectorArrayCursor) next() *cursors.UnsignedArray {
+	if c.tmp.Len() == 0 {
+		return c.UnsignedArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.UnsignedArray{}
+	return &a
+}
+
+// unsignedWindowMeanArrayCursor averages the values of each window of a UnsignedArrayCursor.
+type unsignedWindowMeanArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	tmp   cursors.UnsignedArray
+}
+
+func newUnsignedWindowMeanArrayCursor(every int64, cur cursors.UnsignedArrayCursor) *unsignedWindowMeanArrayCursor {
+	return &unsignedWindowMeanArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		sum   float64
+		count int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(count)
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, sum, count, ok = windowStart(t, c.every), 0, 0, true
+			}
+			sum += float64(a.Values[i])
+			count++
+		}
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(count)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+func (c *unsignedWindowMeanArrayCursor) next() *cursors.UnsignedArray {
+	if c.tmp.Len() == 0 {
+		return c.UnsignedArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.UnsignedArray{}
+	return &a
+}
+
 type unsignedEmptyArrayCursor struct {
 	res cursors.UnsignedArray
 }
@@ -832,6 +1855,115 @@ func (c *integerStringCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// stringLimitArrayCursor returns only the first point of a StringArrayCursor. The
+// underlying cursor is not advanced past its first block, so only the
+// blocks containing that point are read.
+type stringLimitArrayCursor struct {
+	cursors.StringArrayCursor
+	res  *cursors.StringArray
+	done bool
+}
+
+func newStringLimitArrayCursor(cur cursors.StringArrayCursor) *stringLimitArrayCursor {
+	return &stringLimitArrayCursor{
+		StringArrayCursor: cur,
+		res:               cursors.NewStringArrayLen(1),
+	}
+}
+
+func (c *stringLimitArrayCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringLimitArrayCursor) Next() *cursors.StringArray {
+	if c.done {
+		return &cursors.StringArray{}
+	}
+	a := c.StringArrayCursor.Next()
+	if a.Len() == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+// stringWindowCountArrayCursor counts the points of each window of a StringArrayCursor.
+type stringWindowCountArrayCursor struct {
+	cursors.StringArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.StringArray
+}
+
+func newStringWindowCountArrayCursor(every int64, cur cursors.StringArrayCursor) *stringWindowCountArrayCursor {
+	return &stringWindowCountArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		res:               cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc++
+		}
+		a = c.StringArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// next returns the points left over by the previous call to Next, or the
+// next array of the underlying cursor.
+func (c *stringWindowCountArrayCursor) next() *cursors.StringArray {
+	if c.tmp.Len() == 0 {
+		return c.StringArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.StringArray{}
+	return &a
+}
+
 type stringEmptyArrayCursor struct {
 	res cursors.StringArray
 }
@@ -1008,6 +2140,115 @@ func (c *integerBooleanCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// booleanLimitArrayCursor returns only the first point of a BooleanArrayCursor. The
+// underlying cursor is not advanced past its first block, so only the
+// blocks containing that point are read.
+type booleanLimitArrayCursor struct {
+	cursors.BooleanArrayCursor
+	res  *cursors.BooleanArray
+	done bool
+}
+
+func newBooleanLimitArrayCursor(cur cursors.BooleanArrayCursor) *booleanLimitArrayCursor {
+	return &booleanLimitArrayCursor{
+		BooleanArrayCursor: cur,
+		res:                cursors.NewBooleanArrayLen(1),
+	}
+}
+
+func (c *booleanLimitArrayCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+func (c *booleanLimitArrayCursor) Next() *cursors.BooleanArray {
+	if c.done {
+		return &cursors.BooleanArray{}
+	}
+	a := c.BooleanArrayCursor.Next()
+	if a.Len() == 0 {
+		return a
+	}
+	c.done = true
+	c.res.Timestamps[0] = a.Timestamps[0]
+	c.res.Values[0] = a.Values[0]
+	return c.res
+}
+
+// booleanWindowCountArrayCursor counts the points of each window of a BooleanArrayCursor.
+type booleanWindowCountArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	tmp   cursors.BooleanArray
+}
+
+func newBooleanWindowCountArrayCursor(every int64, cur cursors.BooleanArrayCursor) *booleanWindowCountArrayCursor {
+	return &booleanWindowCountArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	a := c.next()
+
+	var (
+		start int64
+		acc   int64
+		ok    bool
+	)
+
+WINDOWS:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if ok && t-start >= c.every {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				ok = false
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break WINDOWS
+				}
+			}
+			if !ok {
+				start, acc, ok = windowStart(t, c.every), 0, true
+			}
+			acc++
+		}
+		a = c.BooleanArrayCursor.Next()
+	}
+
+	if ok {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// next returns the points left over by the previous call to Next, or the
+// next array of the underlying cursor.
+func (c *booleanWindowCountArrayCursor) next() *cursors.BooleanArray {
+	if c.tmp.Len() == 0 {
+		return c.BooleanArrayCursor.Next()
+	}
+	a := c.tmp
+	c.tmp = cursors.BooleanArray{}
+	return &a
+}
+
 type booleanEmptyArrayCursor struct {
 	res cursors.BooleanArray
 }
@@ -16,19 +16,18 @@ func (v *singleValue) Value(key string) (interface{}, bool) {
 	return v.v, true
 }
 
-func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
+func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) (cursors.Cursor, error) {
 	if cursor == nil {
-		return nil
+		return nil, nil
 	}
 
 	switch agg.Type {
 	case datatypes.AggregateTypeSum:
-		return newSumArrayCursor(cursor)
+		return newSumArrayCursor(cursor), nil
 	case datatypes.AggregateTypeCount:
-		return newCountArrayCursor(cursor)
+		return newCountArrayCursor(cursor), nil
 	default:
-		// TODO(sgc): should be validated higher up
-		panic("invalid aggregate")
+		return nil, fmt.Errorf("unsupported aggregate: %s", agg.Type)
 	}
 }
 
@@ -63,6 +62,135 @@ func newCountArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	}
 }
 
+func newWindowAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, every int64, cursor cursors.Cursor) (cursors.Cursor, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	switch agg.Type {
+	case datatypes.AggregateTypeCount:
+		return newWindowCountArrayCursor(every, cursor), nil
+	case datatypes.AggregateTypeSum:
+		return newWindowSumArrayCursor(every, cursor)
+	case datatypes.AggregateTypeMin:
+		return newWindowSelectorArrayCursor(every, false, cursor)
+	case datatypes.AggregateTypeMax:
+		return newWindowSelectorArrayCursor(every, true, cursor)
+	case datatypes.AggregateTypeMean:
+		return newWindowMeanArrayCursor(every, cursor)
+	case datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		// The cursor is ordered such that the selected point is first.
+		return newLimitArrayCursor(cursor), nil
+	default:
+		return nil, fmt.Errorf("unsupported window aggregate: %s", agg.Type)
+	}
+}
+
+func newLimitArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatLimitArrayCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerLimitArrayCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedLimitArrayCursor(cur)
+	case cursors.StringArrayCursor:
+		return newStringLimitArrayCursor(cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanLimitArrayCursor(cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newWindowCountArrayCursor(every int64, cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowCountArrayCursor(every, cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowCountArrayCursor(every, cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowCountArrayCursor(every, cur)
+	case cursors.StringArrayCursor:
+		return newStringWindowCountArrayCursor(every, cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowCountArrayCursor(every, cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newWindowSumArrayCursor(every int64, cur cursors.Cursor) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSumArrayCursor(every, cur), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSumArrayCursor(every, cur), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSumArrayCursor(every, cur), nil
+	default:
+		return nil, unsupportedWindowAggregateType("sum", cur)
+	}
+}
+
+func newWindowSelectorArrayCursor(every int64, max bool, cur cursors.Cursor) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSelectorArrayCursor(every, max, cur), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSelectorArrayCursor(every, max, cur), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSelectorArrayCursor(every, max, cur), nil
+	default:
+		return nil, unsupportedWindowAggregateType(selectorName(max), cur)
+	}
+}
+
+func newWindowMeanArrayCursor(every int64, cur cursors.Cursor) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowMeanArrayCursor(every, cur), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowMeanArrayCursor(every, cur), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowMeanArrayCursor(every, cur), nil
+	default:
+		return nil, unsupportedWindowAggregateType("mean", cur)
+	}
+}
+
+func selectorName(max bool) string {
+	if max {
+		return "max"
+	}
+	return "min"
+}
+
+// unsupportedWindowAggregateType reports that the values of cur cannot be
+// aggregated, as flux would if the aggregate had not been pushed down.
+func unsupportedWindowAggregateType(agg string, cur cursors.Cursor) error {
+	var typ string
+	switch cur.(type) {
+	case cursors.StringArrayCursor:
+		typ = "string"
+	case cursors.BooleanArrayCursor:
+		typ = "bool"
+	default:
+		typ = fmt.Sprintf("%T", cur)
+	}
+	return fmt.Errorf("unsupported input type for %s aggregate: %s", agg, typ)
+}
+
+// windowStart returns the start of the window of length every that contains
+// t. Windows are aligned to the unix epoch.
+func windowStart(t, every int64) int64 {
+	r := t % every
+	if r < 0 {
+		r += every
+	}
+	return t - r
+}
+
 type cursorContext struct {
 	ctx            context.Context
 	req            *cursors.CursorRequest
@@ -74,6 +202,11 @@ type arrayCursors struct {
 	ctx context.Context
 	req cursors.CursorRequest
 
+	// limit is the number of points read from each cursor or zero to read
+	// all points. It is passed to the engine when no value condition
+	// filters the points of the series.
+	limit int
+
 	cursors struct {
 		i integerArrayCursor
 		f floatArrayCursor
@@ -112,9 +245,12 @@ func (m *arrayCursors) createCursor(seriesRow SeriesRow) cursors.Cursor {
 	m.req.Tags = seriesRow.SeriesTags
 	m.req.Field = seriesRow.Field
 
+	m.req.Limit = m.limit
+
 	var cond expression
 	if seriesRow.ValueCond != nil {
 		cond = &astExpr{seriesRow.ValueCond}
+		m.req.Limit = 0
 	}
 
 	if seriesRow.Query == nil {
@@ -0,0 +1,268 @@
+package reads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+type mockFloatArrayCursor struct {
+	arrays []*cursors.FloatArray
+}
+
+func (c *mockFloatArrayCursor) Close()                     {}
+func (c *mockFloatArrayCursor) Err() error                 { return nil }
+func (c *mockFloatArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *mockFloatArrayCursor) Next() *cursors.FloatArray {
+	if len(c.arrays) == 0 {
+		return cursors.NewFloatArrayLen(0)
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+func newMockFloatArrayCursor() *mockFloatArrayCursor {
+	// Points are split across two blocks so windows span block boundaries.
+	return &mockFloatArrayCursor{
+		arrays: []*cursors.FloatArray{
+			{
+				Timestamps: []int64{-5, 0, 3, 9},
+				Values:     []float64{4, 1, 5, 2},
+			},
+			{
+				Timestamps: []int64{10, 11, 25},
+				Values:     []float64{7, 3, 6},
+			},
+		},
+	}
+}
+
+func TestWindowAggregateArrayCursor(t *testing.T) {
+	type result struct {
+		Timestamps []int64
+		Values     interface{}
+	}
+
+	tests := []struct {
+		name string
+		agg  datatypes.Aggregate_AggregateType
+		exp  result
+	}{
+		{
+			name: "count",
+			agg:  datatypes.AggregateTypeCount,
+			exp: result{
+				Timestamps: []int64{-10, 0, 10, 20},
+				Values:     []int64{1, 3, 2, 1},
+			},
+		},
+		{
+			name: "sum",
+			agg:  datatypes.AggregateTypeSum,
+			exp: result{
+				Timestamps: []int64{-10, 0, 10, 20},
+				Values:     []float64{4, 8, 10, 6},
+			},
+		},
+		{
+			name: "min",
+			agg:  datatypes.AggregateTypeMin,
+			exp: result{
+				Timestamps: []int64{-5, 0, 11, 25},
+				Values:     []float64{4, 1, 3, 6},
+			},
+		},
+		{
+			name: "max",
+			agg:  datatypes.AggregateTypeMax,
+			exp: result{
+				Timestamps: []int64{-5, 3, 10, 25},
+				Values:     []float64{4, 5, 7, 6},
+			},
+		},
+		{
+			name: "mean",
+			agg:  datatypes.AggregateTypeMean,
+			exp: result{
+				Timestamps: []int64{-10, 0, 10, 20},
+				Values:     []float64{4, 8.0 / 3, 5, 6},
+			},
+		},
+		{
+			name: "first",
+			agg:  datatypes.AggregateTypeFirst,
+			exp: result{
+				Timestamps: []int64{-5},
+				Values:     []float64{4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := &datatypes.Aggregate{Type: tt.agg}
+			cur, err := newWindowAggregateArrayCursor(context.Background(), agg, 10, newMockFloatArrayCursor())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got result
+			switch cur := cur.(type) {
+			case cursors.IntegerArrayCursor:
+				a := cur.Next()
+				got = result{Timestamps: a.Timestamps, Values: a.Values}
+				if a := cur.Next(); a.Len() != 0 {
+					t.Fatalf("unexpected points after first block: %d", a.Len())
+				}
+			case cursors.FloatArrayCursor:
+				a := cur.Next()
+				got = result{Timestamps: a.Timestamps, Values: a.Values}
+				if a := cur.Next(); a.Len() != 0 {
+					t.Fatalf("unexpected points after first block: %d", a.Len())
+				}
+			default:
+				t.Fatalf("unexpected cursor type: %T", cur)
+			}
+
+			if !cmp.Equal(tt.exp, got) {
+				t.Errorf("unexpected result -want/+got:\n%s", cmp.Diff(tt.exp, got))
+			}
+		})
+	}
+}
+
+// Ensure aggregates the cursors do not support, which come from the
+// request, are reported as errors.
+func TestAggregateArrayCursor_Unsupported(t *testing.T) {
+	agg := &datatypes.Aggregate{Type: datatypes.AggregateTypeMean}
+	if _, err := newAggregateArrayCursor(context.Background(), agg, newMockFloatArrayCursor()); err == nil || err.Error() != "unsupported aggregate: MEAN" {
+		t.Errorf("unexpected error: got %v, want unsupported aggregate: MEAN", err)
+	}
+
+	agg = &datatypes.Aggregate{Type: datatypes.AggregateTypeNone}
+	if _, err := newWindowAggregateArrayCursor(context.Background(), agg, 10, newMockFloatArrayCursor()); err == nil || err.Error() != "unsupported window aggregate: NONE" {
+		t.Errorf("unexpected error: got %v, want unsupported window aggregate: NONE", err)
+	}
+}
+
+type mockStringArrayCursor struct {
+	arrays []*cursors.StringArray
+}
+
+func (c *mockStringArrayCursor) Close()                     {}
+func (c *mockStringArrayCursor) Err() error                 { return nil }
+func (c *mockStringArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *mockStringArrayCursor) Next() *cursors.StringArray {
+	if len(c.arrays) == 0 {
+		return cursors.NewStringArrayLen(0)
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+type mockCursorIterator struct {
+	cur cursors.Cursor
+}
+
+func (i *mockCursorIterator) Next(ctx context.Context, r *cursors.CursorRequest) (cursors.Cursor, error) {
+	return i.cur, nil
+}
+
+func (i *mockCursorIterator) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type mockSeriesCursor struct {
+	rows []SeriesRow
+}
+
+func (c *mockSeriesCursor) Close()     {}
+func (c *mockSeriesCursor) Err() error { return nil }
+
+func (c *mockSeriesCursor) Next() *SeriesRow {
+	if len(c.rows) == 0 {
+		return nil
+	}
+	row := &c.rows[0]
+	c.rows = c.rows[1:]
+	return row
+}
+
+// Ensure string fields are counted, and fail the aggregates flux does not
+// support for strings instead of disappearing from the results.
+func TestWindowAggregateResultSet_String(t *testing.T) {
+	newResultSet := func(t *testing.T, agg datatypes.Aggregate_AggregateType) ResultSet {
+		cur := &mockStringArrayCursor{
+			arrays: []*cursors.StringArray{
+				{
+					Timestamps: []int64{0, 3, 11},
+					Values:     []string{"a", "b", "c"},
+				},
+			},
+		}
+		seriesCursor := &mockSeriesCursor{
+			rows: []SeriesRow{
+				{Name: []byte("m0"), Field: "f0", Query: &mockCursorIterator{cur: cur}},
+			},
+		}
+		rs, err := NewWindowAggregateResultSet(context.Background(), &datatypes.ReadWindowAggregateRequest{
+			Range:       datatypes.TimestampRange{Start: 0, End: 20},
+			WindowEvery: 10,
+			Aggregate:   []*datatypes.Aggregate{{Type: agg}},
+		}, seriesCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	}
+
+	t.Run("count", func(t *testing.T) {
+		rs := newResultSet(t, datatypes.AggregateTypeCount)
+		if !rs.Next() {
+			t.Fatal("expected a series")
+		}
+		cur, ok := rs.Cursor().(cursors.IntegerArrayCursor)
+		if !ok {
+			t.Fatalf("unexpected cursor type: %T", cur)
+		}
+		a := cur.Next()
+		exp := []int64{2, 1}
+		if !cmp.Equal(exp, a.Values) {
+			t.Errorf("unexpected counts -want/+got:\n%s", cmp.Diff(exp, a.Values))
+		}
+		if err := rs.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	for _, tt := range []struct {
+		agg datatypes.Aggregate_AggregateType
+		exp string
+	}{
+		{agg: datatypes.AggregateTypeSum, exp: "unsupported input type for sum aggregate: string"},
+		{agg: datatypes.AggregateTypeMin, exp: "unsupported input type for min aggregate: string"},
+		{agg: datatypes.AggregateTypeMax, exp: "unsupported input type for max aggregate: string"},
+		{agg: datatypes.AggregateTypeMean, exp: "unsupported input type for mean aggregate: string"},
+	} {
+		t.Run(tt.exp, func(t *testing.T) {
+			rs := newResultSet(t, tt.agg)
+			if !rs.Next() {
+				t.Fatal("expected a series")
+			}
+			if cur := rs.Cursor(); cur != nil {
+				t.Fatalf("unexpected cursor: %T", cur)
+			}
+			if rs.Next() {
+				t.Fatal("expected the result set to end")
+			}
+			if err := rs.Err(); err == nil || err.Error() != tt.exp {
+				t.Fatalf("unexpected error: got %v, want %s", err, tt.exp)
+			}
+		})
+	}
+}
@@ -272,9 +272,10 @@ type groupNoneCursor struct {
 	cur          SeriesCursor
 	row          SeriesRow
 	keys         [][]byte
+	err          error
 }
 
-func (c *groupNoneCursor) Err() error                 { return nil }
+func (c *groupNoneCursor) Err() error                 { return c.err }
 func (c *groupNoneCursor) Tags() models.Tags          { return c.row.Tags }
 func (c *groupNoneCursor) Keys() [][]byte             { return c.keys }
 func (c *groupNoneCursor) PartitionKeyVals() [][]byte { return nil }
@@ -295,7 +296,14 @@ func (c *groupNoneCursor) Next() bool {
 func (c *groupNoneCursor) Cursor() cursors.Cursor {
 	cur := c.arrayCursors.createCursor(c.row)
 	if c.agg != nil {
-		cur = newAggregateArrayCursor(c.ctx, c.agg, cur)
+		acur, err := newAggregateArrayCursor(c.ctx, c.agg, cur)
+		if err != nil {
+			// the error is reported by Err.
+			cur.Close()
+			c.err = err
+			return nil
+		}
+		cur = acur
 	}
 	return cur
 }
@@ -308,6 +316,7 @@ type groupByCursor struct {
 	seriesRows   []*SeriesRow
 	keys         [][]byte
 	vals         [][]byte
+	err          error
 }
 
 func (c *groupByCursor) reset(seriesRows []*SeriesRow) {
@@ -315,7 +324,7 @@ func (c *groupByCursor) reset(seriesRows []*SeriesRow) {
 	c.seriesRows = seriesRows
 }
 
-func (c *groupByCursor) Err() error                 { return nil }
+func (c *groupByCursor) Err() error                 { return c.err }
 func (c *groupByCursor) Keys() [][]byte             { return c.keys }
 func (c *groupByCursor) PartitionKeyVals() [][]byte { return c.vals }
 func (c *groupByCursor) Tags() models.Tags          { return c.seriesRows[c.i-1].Tags }
@@ -332,7 +341,14 @@ func (c *groupByCursor) Next() bool {
 func (c *groupByCursor) Cursor() cursors.Cursor {
 	cur := c.arrayCursors.createCursor(*c.seriesRows[c.i-1])
 	if c.agg != nil {
-		cur = newAggregateArrayCursor(c.ctx, c.agg, cur)
+		acur, err := newAggregateArrayCursor(c.ctx, c.agg, cur)
+		if err != nil {
+			// the error is reported by Err.
+			cur.Close()
+			c.err = err
+			return nil
+		}
+		cur = acur
 	}
 	return cur
 }
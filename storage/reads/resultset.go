@@ -2,6 +2,9 @@ package reads
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/storage/reads/datatypes"
@@ -11,9 +14,11 @@ import (
 type resultSet struct {
 	ctx          context.Context
 	agg          *datatypes.Aggregate
+	every        int64
 	seriesCursor SeriesCursor
 	seriesRow    SeriesRow
 	arrayCursors *arrayCursors
+	err          error
 }
 
 func NewFilteredResultSet(ctx context.Context, req *datatypes.ReadFilterRequest, seriesCursor SeriesCursor) ResultSet {
@@ -24,7 +29,54 @@ func NewFilteredResultSet(ctx context.Context, req *datatypes.ReadFilterRequest,
 	}
 }
 
-func (r *resultSet) Err() error { return nil }
+// NewWindowAggregateResultSet returns a ResultSet whose cursors produce the
+// aggregate of every window of req.WindowEvery nanoseconds of each series.
+func NewWindowAggregateResultSet(ctx context.Context, req *datatypes.ReadWindowAggregateRequest, seriesCursor SeriesCursor) (ResultSet, error) {
+	if req.WindowEvery <= 0 {
+		return nil, errors.New("window every must be greater than zero")
+	}
+	if len(req.Aggregate) != 1 {
+		return nil, errors.New("window aggregate requires exactly one aggregate")
+	}
+
+	start, end := req.Range.Start, req.Range.End
+	ascending, limit := true, 0
+	switch req.Aggregate[0].Type {
+	case datatypes.AggregateTypeCount,
+		datatypes.AggregateTypeSum,
+		datatypes.AggregateTypeMin,
+		datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeMean:
+	case datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		// first and last are answered by reading a single point from each
+		// series, which requires the window to span the entire range.
+		if req.WindowEvery != math.MaxInt64 {
+			return nil, fmt.Errorf("window aggregate %s requires a single window", req.Aggregate[0].Type)
+		}
+		limit = 1
+		if req.Aggregate[0].Type == datatypes.AggregateTypeLast {
+			// Descending cursors include the end of the range and exclude
+			// the start, so the range is shifted to keep it [start, end).
+			ascending = false
+			start, end = start-1, end-1
+		}
+	default:
+		return nil, fmt.Errorf("unsupported window aggregate: %s", req.Aggregate[0].Type)
+	}
+
+	arrayCursors := newArrayCursors(ctx, start, end, ascending)
+	arrayCursors.limit = limit
+
+	return &resultSet{
+		ctx:          ctx,
+		agg:          req.Aggregate[0],
+		every:        req.WindowEvery,
+		seriesCursor: seriesCursor,
+		arrayCursors: arrayCursors,
+	}, nil
+}
+
+func (r *resultSet) Err() error { return r.err }
 
 // Close closes the result set. Close is idempotent.
 func (r *resultSet) Close() {
@@ -37,7 +89,7 @@ func (r *resultSet) Close() {
 
 // Next returns true if there are more results available.
 func (r *resultSet) Next() bool {
-	if r == nil {
+	if r == nil || r.err != nil {
 		return false
 	}
 
@@ -54,7 +106,22 @@ func (r *resultSet) Next() bool {
 func (r *resultSet) Cursor() cursors.Cursor {
 	cur := r.arrayCursors.createCursor(r.seriesRow)
 	if r.agg != nil {
-		cur = newAggregateArrayCursor(r.ctx, r.agg, cur)
+		var (
+			acur cursors.Cursor
+			err  error
+		)
+		if r.every > 0 {
+			acur, err = newWindowAggregateArrayCursor(r.ctx, r.agg, r.every, cur)
+		} else {
+			acur, err = newAggregateArrayCursor(r.ctx, r.agg, cur)
+		}
+		if err != nil {
+			// the error ends the result set, it is reported by Err.
+			cur.Close()
+			r.err = err
+			return nil
+		}
+		cur = acur
 	}
 	return cur
 }
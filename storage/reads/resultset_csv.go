@@ -0,0 +1,162 @@
+package reads
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// ResultSetToCSV transforms rs to annotated CSV, with one table per series,
+// and writes the output to wr. The output can be decoded as the result of
+// a flux query.
+func ResultSetToCSV(wr io.Writer, rs ResultSet) (err error) {
+	defer rs.Close()
+
+	w := csv.NewWriter(wr)
+	var (
+		table  int
+		header []string
+		row    []string
+	)
+	for rs.Next() {
+		name, field, tags := splitSeriesTags(rs.Tags())
+		if len(name) == 0 || len(field) == 0 {
+			return errors.New("missing measurement / field")
+		}
+
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		var datatype string
+		switch cur.(type) {
+		case cursors.IntegerArrayCursor:
+			datatype = "long"
+		case cursors.FloatArrayCursor:
+			datatype = "double"
+		case cursors.UnsignedArrayCursor:
+			datatype = "unsignedLong"
+		case cursors.BooleanArrayCursor:
+			datatype = "boolean"
+		case cursors.StringArrayCursor:
+			datatype = "string"
+		default:
+			panic("unreachable")
+		}
+
+		if table > 0 {
+			// tables are separated by an empty line
+			if err := w.Write(nil); err != nil {
+				return err
+			}
+		}
+
+		header = append(header[:0], "", "result", "table", "_time", "_value", "_field", "_measurement")
+		for _, tag := range tags {
+			header = append(header, string(tag.Key))
+		}
+
+		annotations := [][]string{
+			make([]string, 0, len(header)),
+			make([]string, 0, len(header)),
+			make([]string, 0, len(header)),
+		}
+		annotations[0] = append(annotations[0], "#datatype", "string", "long", "dateTime:RFC3339Nano", datatype, "string", "string")
+		annotations[1] = append(annotations[1], "#group", "false", "false", "false", "false", "true", "true")
+		annotations[2] = append(annotations[2], "#default", "_result", "", "", "", "", "")
+		for range tags {
+			annotations[0] = append(annotations[0], "string")
+			annotations[1] = append(annotations[1], "true")
+			annotations[2] = append(annotations[2], "")
+		}
+		annotations = append(annotations, header)
+		if err := w.WriteAll(annotations); err != nil {
+			return err
+		}
+
+		row = append(row[:0], "", "", strconv.Itoa(table), "", "", string(field), string(name))
+		for _, tag := range tags {
+			row = append(row, string(tag.Value))
+		}
+
+		if err := cursorToCSV(w, row, cur); err != nil {
+			return err
+		}
+		table++
+	}
+
+	if err := rs.Err(); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// cursorToCSV writes a row for every value of cur, setting the _time and
+// _value columns of row.
+func cursorToCSV(w *csv.Writer, row []string, cur cursors.Cursor) error {
+	defer cur.Close()
+
+	const (
+		timeCol  = 3
+		valueCol = 4
+	)
+	write := func(ts int64, v string) error {
+		row[timeCol] = time.Unix(0, ts).UTC().Format(time.RFC3339Nano)
+		row[valueCol] = v
+		return w.Write(row)
+	}
+
+	switch ccur := cur.(type) {
+	case cursors.IntegerArrayCursor:
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				if err := write(a.Timestamps[i], strconv.FormatInt(a.Values[i], 10)); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.FloatArrayCursor:
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				if err := write(a.Timestamps[i], strconv.FormatFloat(a.Values[i], 'f', -1, 64)); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				if err := write(a.Timestamps[i], strconv.FormatUint(a.Values[i], 10)); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.BooleanArrayCursor:
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				if err := write(a.Timestamps[i], strconv.FormatBool(a.Values[i])); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.StringArrayCursor:
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				if err := write(a.Timestamps[i], a.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		panic("unreachable")
+	}
+
+	return cur.Err()
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/tsdb/cursors"
 )
 
@@ -16,23 +18,24 @@ func ResultSetToLineProtocol(wr io.Writer, rs ResultSet) (err error) {
 
 	line := make([]byte, 0, 4096)
 	for rs.Next() {
-		tags := rs.Tags()
-		name := tags.Get(models.MeasurementTagKeyBytes)
-		field := tags.Get(models.FieldKeyTagKeyBytes)
+		name, field, tags := splitSeriesTags(rs.Tags())
 		if len(name) == 0 || len(field) == 0 {
 			return errors.New("missing measurement / field")
 		}
 
-		line = append(line[:0], name...)
-		if tags.Len() > 2 {
-			tags = tags[1 : len(tags)-1] // take first and last elements which are measurement and field keys
-			line = tags.AppendHashKey(line)
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
 		}
 
+		line = append(line[:0], models.EscapeMeasurement(name)...)
+		line = tags.AppendHashKey(line)
+
 		line = append(line, ' ')
-		line = append(line, field...)
+		line = append(line, escape.Bytes(field)...)
 		line = append(line, '=')
-		err = cursorToLineProtocol(wr, line, rs.Cursor())
+		err = cursorToLineProtocol(wr, line, cur)
 		if err != nil {
 			return err
 		}
@@ -41,89 +44,89 @@ func ResultSetToLineProtocol(wr io.Writer, rs ResultSet) (err error) {
 	return rs.Err()
 }
 
+// splitSeriesTags returns the measurement and field of the series tags and
+// the remaining tags. The measurement and field keys are either the \x00 and
+// \xff keys of the series key or their _measurement and _field replacements.
+func splitSeriesTags(tags models.Tags) (name, field []byte, rest models.Tags) {
+	rest = make(models.Tags, 0, len(tags))
+	for _, tag := range tags {
+		switch string(tag.Key) {
+		case models.MeasurementTagKey, datatypes.MeasurementKey:
+			name = tag.Value
+		case models.FieldKeyTagKey, datatypes.FieldKey:
+			field = tag.Value
+		default:
+			rest = append(rest, tag)
+		}
+	}
+	return name, field, rest
+}
+
 func cursorToLineProtocol(wr io.Writer, line []byte, cur cursors.Cursor) error {
-	var newLine = []byte{'\n'}
+	defer cur.Close()
+
+	var buf []byte
+	write := func(ts int64) error {
+		buf = append(buf, ' ')
+		buf = strconv.AppendInt(buf, ts, 10)
+		buf = append(buf, '\n')
+		_, err := wr.Write(buf)
+		return err
+	}
 
 	switch ccur := cur.(type) {
 	case cursors.IntegerArrayCursor:
-		for {
-			a := ccur.Next()
-			if a.Len() > 0 {
-				for i := range a.Timestamps {
-					buf := strconv.AppendInt(line, a.Values[i], 10)
-					buf = append(buf, 'i', ' ')
-					buf = strconv.AppendInt(buf, a.Timestamps[i], 10)
-					wr.Write(buf)
-					wr.Write(newLine)
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				buf = strconv.AppendInt(append(buf[:0], line...), a.Values[i], 10)
+				buf = append(buf, 'i')
+				if err := write(a.Timestamps[i]); err != nil {
+					return err
 				}
-			} else {
-				break
 			}
 		}
 	case cursors.FloatArrayCursor:
-		for {
-			a := ccur.Next()
-			if a.Len() > 0 {
-				for i := range a.Timestamps {
-					buf := strconv.AppendFloat(line, a.Values[i], 'f', -1, 64)
-					buf = append(buf, ' ')
-					buf = strconv.AppendInt(buf, a.Timestamps[i], 10)
-					wr.Write(buf)
-					wr.Write(newLine)
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				buf = strconv.AppendFloat(append(buf[:0], line...), a.Values[i], 'f', -1, 64)
+				if err := write(a.Timestamps[i]); err != nil {
+					return err
 				}
-			} else {
-				break
 			}
 		}
 	case cursors.UnsignedArrayCursor:
-		for {
-			a := ccur.Next()
-			if a.Len() > 0 {
-				for i := range a.Timestamps {
-					buf := strconv.AppendUint(line, a.Values[i], 10)
-					buf = append(buf, 'u', ' ')
-					buf = strconv.AppendInt(buf, a.Timestamps[i], 10)
-					wr.Write(buf)
-					wr.Write(newLine)
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				buf = strconv.AppendUint(append(buf[:0], line...), a.Values[i], 10)
+				buf = append(buf, 'u')
+				if err := write(a.Timestamps[i]); err != nil {
+					return err
 				}
-			} else {
-				break
 			}
 		}
 	case cursors.BooleanArrayCursor:
-		for {
-			a := ccur.Next()
-			if a.Len() > 0 {
-				for i := range a.Timestamps {
-					buf := strconv.AppendBool(line, a.Values[i])
-					buf = append(buf, ' ')
-					buf = strconv.AppendInt(buf, a.Timestamps[i], 10)
-					wr.Write(buf)
-					wr.Write(newLine)
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				buf = strconv.AppendBool(append(buf[:0], line...), a.Values[i])
+				if err := write(a.Timestamps[i]); err != nil {
+					return err
 				}
-			} else {
-				break
 			}
 		}
 	case cursors.StringArrayCursor:
-		for {
-			a := ccur.Next()
-			if a.Len() > 0 {
-				for i := range a.Timestamps {
-					buf := strconv.AppendQuote(line, a.Values[i])
-					buf = append(buf, 'i', ' ')
-					buf = strconv.AppendInt(buf, a.Timestamps[i], 10)
-					wr.Write(buf)
-					wr.Write(newLine)
+		for a := ccur.Next(); a.Len() > 0; a = ccur.Next() {
+			for i := range a.Timestamps {
+				buf = append(append(buf[:0], line...), '"')
+				buf = append(buf, models.EscapeStringField(a.Values[i])...)
+				buf = append(buf, '"')
+				if err := write(a.Timestamps[i]); err != nil {
+					return err
 				}
-			} else {
-				break
 			}
 		}
 	default:
 		panic("unreachable")
 	}
 
-	cur.Close()
 	return cur.Err()
 }
@@ -0,0 +1,82 @@
+package readservice
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/predicate"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+)
+
+var _ influxdb.ExportService = (*ExportService)(nil)
+
+// ExportService exports the data of a bucket by reading all of its series
+// from a store.
+type ExportService struct {
+	store reads.Store
+}
+
+// NewExportService returns an export service reading from store.
+func NewExportService(store reads.Store) *ExportService {
+	return &ExportService{store: store}
+}
+
+// ExportBucket streams the data of a bucket matching the filter to w in the given format.
+func (s *ExportService) ExportBucket(ctx context.Context, filter influxdb.ExportFilter, format influxdb.ExportFormat, w io.Writer) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := format.Valid(); err != nil {
+		return err
+	}
+
+	var req datatypes.ReadFilterRequest
+	any, err := types.MarshalAny(s.store.GetSource(uint64(filter.OrgID), uint64(filter.BucketID)))
+	if err != nil {
+		return err
+	}
+	req.ReadSource = any
+	req.Range.Start = filter.Start
+	req.Range.End = filter.Stop
+
+	if filter.Predicate != "" {
+		node, err := predicate.Parse(filter.Predicate)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid export predicate",
+				Err:  err,
+			}
+		}
+		root, err := node.ToDataType()
+		if err != nil {
+			return err
+		}
+		req.Predicate = &datatypes.Predicate{Root: root}
+	}
+
+	rs, err := s.store.ReadFilter(ctx, &req)
+	if err != nil {
+		return tracing.LogError(span, err)
+	}
+	if rs == nil {
+		return nil
+	}
+
+	bw := bufio.NewWriter(w)
+	switch format {
+	case influxdb.ExportFormatCSV:
+		err = reads.ResultSetToCSV(bw, rs)
+	default:
+		err = reads.ResultSetToLineProtocol(bw, rs)
+	}
+	if err != nil {
+		return tracing.LogError(span, err)
+	}
+	return bw.Flush()
+}
@@ -65,6 +65,34 @@ func (s *store) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest)
 	return reads.NewGroupResultSet(ctx, req, newCursor), nil
 }
 
+func (s *store) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if req.ReadSource == nil {
+		return nil, tracing.LogError(span, errors.New("missing read source"))
+	}
+
+	source, err := getReadSource(*req.ReadSource)
+	if err != nil {
+		return nil, tracing.LogError(span, err)
+	}
+
+	var cur reads.SeriesCursor
+	if cur, err = reads.NewIndexSeriesCursor(ctx, source.GetOrgID(), source.GetBucketID(), req.Predicate, s.viewer); err != nil {
+		return nil, tracing.LogError(span, err)
+	} else if cur == nil {
+		return nil, nil
+	}
+
+	rs, err := reads.NewWindowAggregateResultSet(ctx, req, cur)
+	if err != nil {
+		cur.Close()
+		return nil, tracing.LogError(span, err)
+	}
+	return rs, nil
+}
+
 func (s *store) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
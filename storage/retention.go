@@ -22,6 +22,14 @@ const (
 // A Deleter implementation is capable of deleting data from a storage engine.
 type Deleter interface {
 	DeleteBucketRange(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64) error
+
+	// DropBucketPartitions drops the shard groups of a bucket that end at or before
+	// max, returning the number of bytes reclaimed.
+	DropBucketPartitions(ctx context.Context, orgID, bucketID influxdb.ID, max int64) (int64, error)
+
+	// HasUnpartitionedData returns true if data of the bucket at or before max may be
+	// stored outside of any shard group, in which case it can only be deleted.
+	HasUnpartitionedData(orgID, bucketID influxdb.ID, max int64) bool
 }
 
 // A Snapshotter implementation can take snapshots of the entire engine.
@@ -38,7 +46,9 @@ type BucketFinder interface {
 var ErrServiceClosed = errors.New("service is currently closed")
 
 // The retentionEnforcer periodically removes data that is outside of the retention
-// period of the bucket associated with the data.
+// period of the bucket associated with the data. Data is removed by dropping the
+// shard groups of the bucket that have entirely expired, so a shard group is kept
+// until its most recent data expires.
 type retentionEnforcer struct {
 	// Engine provides access to data stored on the engine
 	Engine Deleter
@@ -113,10 +123,11 @@ func (s *retentionEnforcer) run() {
 	s.tracker.CheckDuration(time.Since(now), err == nil)
 }
 
-// expireData runs a delete operation on the storage engine.
+// expireData drops the expired shard groups of the buckets from the storage engine.
 //
-// Any series data that (1) belongs to a bucket in the provided list and
-// (2) falls outside the bucket's indicated retention period will be deleted.
+// Any shard group that (1) belongs to a bucket in the provided list and (2) falls
+// entirely outside the bucket's indicated retention period will be dropped. Expired
+// data that is not part of any shard group is deleted.
 func (s *retentionEnforcer) expireData(ctx context.Context, buckets []*influxdb.Bucket, now time.Time) {
 	logger, logEnd := logger.NewOperation(ctx, s.logger, "Data deletion", "data_deletion",
 		zap.Int("buckets", len(buckets)))
@@ -160,12 +171,21 @@ func (s *retentionEnforcer) expireData(ctx context.Context, buckets []*influxdb.
 			"to", time.Unix(0, max).UTC(),
 		)
 
-		err := s.Engine.DeleteBucketRange(ctx, b.OrgID, b.ID, min, max)
+		reclaimed, err := s.Engine.DropBucketPartitions(ctx, b.OrgID, b.ID, max)
 		if err != nil {
-			logger.Info("Unable to delete bucket range",
-				append(bucketFields, zap.Time("min", time.Unix(0, min)), zap.Time("max", time.Unix(0, max)), zap.Error(err))...)
+			logger.Info("Unable to drop bucket shard groups",
+				append(bucketFields, zap.Time("max", time.Unix(0, max)), zap.Error(err))...)
 			tracing.LogError(span, err)
+		} else if s.Engine.HasUnpartitionedData(b.OrgID, b.ID, max) {
+			err = s.Engine.DeleteBucketRange(ctx, b.OrgID, b.ID, min, max)
+			if err != nil {
+				logger.Info("Unable to delete bucket range",
+					append(bucketFields, zap.Time("min", time.Unix(0, min)), zap.Time("max", time.Unix(0, max)), zap.Error(err))...)
+				tracing.LogError(span, err)
+			}
 		}
+		span.LogKV("reclaimed_bytes", reclaimed)
+		s.tracker.AddReclaimedBytes(reclaimed)
 		s.tracker.IncChecks(err == nil)
 		span.Finish()
 	}
@@ -219,6 +239,11 @@ func (t *retentionTracker) IncChecks(success bool) {
 	t.metrics.Checks.With(labels).Inc()
 }
 
+// AddReclaimedBytes records the number of bytes reclaimed by dropping shard groups.
+func (t *retentionTracker) AddReclaimedBytes(n int64) {
+	t.metrics.ReclaimedBytes.With(t.Labels()).Add(float64(n))
+}
+
 // CheckDuration records the overall duration of a full retention check.
 func (t *retentionTracker) CheckDuration(dur time.Duration, success bool) {
 	labels := t.Labels()
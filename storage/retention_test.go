@@ -215,6 +215,21 @@ func TestRetentionService(t *testing.T) {
 	}
 
 	gotMatched := map[string]struct{}{}
+	engine.DropBucketPartitionsFn = func(ctx context.Context, orgID, bucketID influxdb.ID, to int64) (int64, error) {
+		wantTo := now.Add(-3 * time.Hour).UnixNano()
+		if to != wantTo {
+			t.Fatalf("got to %d, expected %d", to, wantTo)
+		}
+
+		name := tsdb.EncodeName(orgID, bucketID)
+		if _, ok := expRejected[string(name[:])]; ok {
+			t.Fatalf("got a drop for %x", name)
+		}
+		gotMatched[string(name[:])] = struct{}{}
+		return 0, nil
+	}
+
+	gotDeleted := map[string]struct{}{}
 	engine.DeleteBucketRangeFn = func(ctx context.Context, orgID, bucketID influxdb.ID, from, to int64) error {
 		if from != math.MinInt64 {
 			t.Fatalf("got from %d, expected %d", from, int64(math.MinInt64))
@@ -225,10 +240,7 @@ func TestRetentionService(t *testing.T) {
 		}
 
 		name := tsdb.EncodeName(orgID, bucketID)
-		if _, ok := expRejected[string(name[:])]; ok {
-			t.Fatalf("got a delete for %x", name)
-		}
-		gotMatched[string(name[:])] = struct{}{}
+		gotDeleted[string(name[:])] = struct{}{}
 		return nil
 	}
 
@@ -237,6 +249,19 @@ func TestRetentionService(t *testing.T) {
 		if !reflect.DeepEqual(gotMatched, expMatched) {
 			t.Fatalf("got\n%#v\nexpected\n%#v", gotMatched, expMatched)
 		}
+		if len(gotDeleted) != 0 {
+			t.Fatalf("got deletes without unpartitioned data: %#v", gotDeleted)
+		}
+	})
+
+	t.Run("unpartitioned data", func(t *testing.T) {
+		engine.HasUnpartitionedDataFn = func(orgID, bucketID influxdb.ID, max int64) bool { return true }
+		defer func() { engine.HasUnpartitionedDataFn = nil }()
+
+		service.expireData(context.Background(), buckets, now)
+		if !reflect.DeepEqual(gotDeleted, expMatched) {
+			t.Fatalf("got\n%#v\nexpected\n%#v", gotDeleted, expMatched)
+		}
 	})
 }
 
@@ -259,6 +284,7 @@ func TestMetrics_Retention(t *testing.T) {
 		tracker.IncChecks(false)
 		tracker.CheckDuration(time.Second, true)
 		tracker.CheckDuration(time.Second, false)
+		tracker.AddReclaimedBytes(100)
 	}
 
 	// Test that all the correct metrics are present.
@@ -274,6 +300,12 @@ func TestMetrics_Retention(t *testing.T) {
 	}
 
 	for i, labels := range labelVariants {
+		name := base + "reclaimed_bytes_total"
+		metric := promtest.MustFindMetric(t, mfs, name, labels)
+		if got, exp := metric.GetCounter().GetValue(), float64(100); got != exp {
+			t.Errorf("[%s %d %v] got %v, expected %v", name, i, labels, got, exp)
+		}
+
 		for _, status := range []string{"ok", "error"} {
 			labels["status"] = status
 
@@ -314,12 +346,15 @@ func (r *MockRunner) run() {
 }
 
 type TestEngine struct {
-	DeleteBucketRangeFn func(context.Context, influxdb.ID, influxdb.ID, int64, int64) error
+	DeleteBucketRangeFn    func(context.Context, influxdb.ID, influxdb.ID, int64, int64) error
+	DropBucketPartitionsFn func(context.Context, influxdb.ID, influxdb.ID, int64) (int64, error)
+	HasUnpartitionedDataFn func(influxdb.ID, influxdb.ID, int64) bool
 }
 
 func NewTestEngine() *TestEngine {
 	return &TestEngine{
-		DeleteBucketRangeFn: func(context.Context, influxdb.ID, influxdb.ID, int64, int64) error { return nil },
+		DeleteBucketRangeFn:    func(context.Context, influxdb.ID, influxdb.ID, int64, int64) error { return nil },
+		DropBucketPartitionsFn: func(context.Context, influxdb.ID, influxdb.ID, int64) (int64, error) { return 0, nil },
 	}
 }
 
@@ -327,6 +362,17 @@ func (e *TestEngine) DeleteBucketRange(ctx context.Context, orgID, bucketID infl
 	return e.DeleteBucketRangeFn(ctx, orgID, bucketID, min, max)
 }
 
+func (e *TestEngine) DropBucketPartitions(ctx context.Context, orgID, bucketID influxdb.ID, max int64) (int64, error) {
+	return e.DropBucketPartitionsFn(ctx, orgID, bucketID, max)
+}
+
+func (e *TestEngine) HasUnpartitionedData(orgID, bucketID influxdb.ID, max int64) bool {
+	if e.HasUnpartitionedDataFn == nil {
+		return false
+	}
+	return e.HasUnpartitionedDataFn(orgID, bucketID, max)
+}
+
 type TestSnapshotter struct{}
 
 func (s *TestSnapshotter) WriteSnapshot(ctx context.Context, status tsm1.CacheStatus) error {
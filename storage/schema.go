@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// bucketSchemasTTL is how long the schema of a bucket is enforced before being
+// looked up again, which bounds how long an updated schema takes to apply.
+const bucketSchemasTTL = 10 * time.Second
+
+// schemaFieldTypes maps the field types of bucket schemas to the types of points.
+var schemaFieldTypes = map[influxdb.SchemaFieldType]models.FieldType{
+	influxdb.SchemaFieldTypeFloat:    models.Float,
+	influxdb.SchemaFieldTypeInteger:  models.Integer,
+	influxdb.SchemaFieldTypeUnsigned: models.Unsigned,
+	influxdb.SchemaFieldTypeString:   models.String,
+	influxdb.SchemaFieldTypeBoolean:  models.Boolean,
+}
+
+// schema is a bucket schema compiled to validate points.
+type schema struct {
+	measurements map[string]*measurementSchema
+}
+
+type measurementSchema struct {
+	tags   map[string]struct{}
+	fields map[string]influxdb.SchemaFieldType
+}
+
+func newSchema(bs *influxdb.BucketSchema) *schema {
+	s := &schema{measurements: make(map[string]*measurementSchema, len(bs.Measurements))}
+	for _, m := range bs.Measurements {
+		ms := &measurementSchema{
+			tags:   make(map[string]struct{}, len(m.Tags)),
+			fields: make(map[string]influxdb.SchemaFieldType, len(m.Fields)),
+		}
+		for _, k := range m.Tags {
+			ms.tags[k] = struct{}{}
+		}
+		for _, f := range m.Fields {
+			ms.fields[f.Name] = f.Type
+		}
+		s.measurements[m.Name] = ms
+	}
+	return s
+}
+
+// validate returns the reason why a point does not match the schema, or an empty
+// string if it does. The tags are those of a point written to the engine, with
+// the measurement as the first tag and the field key as the last one.
+func (s *schema) validate(tags models.Tags, typ models.FieldType) string {
+	measurement := tags[0].Value
+	ms, ok := s.measurements[string(measurement)]
+	if !ok {
+		return fmt.Sprintf("schema violation: measurement %q is not in the bucket schema", measurement)
+	}
+
+	for _, t := range tags[1 : len(tags)-1] {
+		if _, ok := ms.tags[string(t.Key)]; !ok {
+			return fmt.Sprintf("schema violation: tag key %q is not in the schema of measurement %q", t.Key, measurement)
+		}
+	}
+
+	field := tags[len(tags)-1].Value
+	ft, ok := ms.fields[string(field)]
+	if !ok {
+		return fmt.Sprintf("schema violation: field %q is not in the schema of measurement %q", field, measurement)
+	}
+	if schemaFieldTypes[ft] != typ {
+		return fmt.Sprintf("schema violation: field %q of measurement %q is %s, the bucket schema requires %s", field, measurement, typeName(typ), ft)
+	}
+	return ""
+}
+
+// typeName returns the name of a point field type in the terms of bucket schemas.
+func typeName(typ models.FieldType) string {
+	for ft, t := range schemaFieldTypes {
+		if t == typ {
+			return string(ft)
+		}
+	}
+	return typ.String()
+}
+
+// bucketSchemas caches the schemas of the buckets written to.
+type bucketSchemas struct {
+	finder BucketFinder
+
+	mu      sync.RWMutex
+	schemas map[[16]byte]cachedSchema
+
+	logger *zap.Logger
+}
+
+type cachedSchema struct {
+	schema  *schema // nil if the bucket has no schema
+	fetched time.Time
+}
+
+func newBucketSchemas(finder BucketFinder) *bucketSchemas {
+	return &bucketSchemas{
+		finder:  finder,
+		schemas: make(map[[16]byte]cachedSchema),
+		logger:  zap.NewNop(),
+	}
+}
+
+// Schema returns the schema of the bucket encoded in name, or nil if the bucket
+// has none. The schema is looked up when it is not cached or has expired. If
+// the lookup fails, the schema cached last, if any, is returned.
+func (s *bucketSchemas) Schema(ctx context.Context, name []byte) *schema {
+	var key [16]byte
+	copy(key[:], name)
+
+	s.mu.RLock()
+	cached, ok := s.schemas[key]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.fetched) <= bucketSchemasTTL {
+		return cached.schema
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bucketAPITimeout)
+	defer cancel()
+
+	orgID, bucketID := tsdb.DecodeName(key)
+	buckets, _, err := s.finder.FindBuckets(ctx, influxdb.BucketFilter{
+		ID:             &bucketID,
+		OrganizationID: &orgID,
+	})
+	if err != nil && influxdb.ErrorCode(err) == influxdb.ENotFound {
+		cached.schema = nil
+	} else if err != nil {
+		s.logger.Info("Unable to look up bucket schema", zap.Stringer("org_id", orgID), zap.Stringer("bucket_id", bucketID), zap.Error(err))
+	} else {
+		cached.schema = nil
+		if len(buckets) > 0 && buckets[0].Schema != nil {
+			cached.schema = newSchema(buckets[0].Schema)
+		}
+	}
+
+	// Don't look the schema up again before the TTL expires, whatever the outcome.
+	cached.fetched = time.Now()
+	s.mu.Lock()
+	s.schemas[key] = cached
+	s.mu.Unlock()
+	return cached.schema
+}
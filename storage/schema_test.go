@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestEngine_WritePoints_Schema(t *testing.T) {
+	org, bucket, other := influxdb.ID(1), influxdb.ID(2), influxdb.ID(3)
+
+	finder := NewTestBucketFinder()
+	finder.FindBucketsFn = func(_ context.Context, filter influxdb.BucketFilter, _ ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		if *filter.ID != bucket {
+			return []*influxdb.Bucket{{ID: *filter.ID, OrgID: org}}, 1, nil
+		}
+		return []*influxdb.Bucket{{
+			ID:    bucket,
+			OrgID: org,
+			Schema: &influxdb.BucketSchema{
+				Measurements: []influxdb.MeasurementSchema{{
+					Name:   "cpu",
+					Tags:   []string{"host"},
+					Fields: []influxdb.FieldSchema{{Name: "usage", Type: influxdb.SchemaFieldTypeFloat}},
+				}},
+			},
+		}}, 1, nil
+	}
+
+	path := MustTempDir()
+	defer os.RemoveAll(path)
+
+	engine := NewEngine(path, NewConfig(), WithNodeID(102), WithEngineID(33), WithSchemaEnforcement(finder))
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	point := func(bucketID influxdb.ID, tags map[string]string, value interface{}) models.Point {
+		return models.MustNewPoint(
+			tsdb.EncodeNameString(org, bucketID),
+			models.NewTags(tags),
+			map[string]interface{}{tags[models.FieldKeyTagKey]: value},
+			time.Unix(1, 0),
+		)
+	}
+
+	err := engine.WritePoints(context.Background(), []models.Point{
+		point(bucket, map[string]string{models.MeasurementTagKey: "cpu", "host": "a", models.FieldKeyTagKey: "usage"}, 1.0),
+		point(bucket, map[string]string{models.MeasurementTagKey: "cpu", "host": "b", models.FieldKeyTagKey: "usage"}, int64(1)),
+		point(bucket, map[string]string{models.MeasurementTagKey: "cpu", "host": "c", models.FieldKeyTagKey: "idle"}, 1.0),
+		point(bucket, map[string]string{models.MeasurementTagKey: "cpu", "region": "west", models.FieldKeyTagKey: "usage"}, 1.0),
+		point(bucket, map[string]string{models.MeasurementTagKey: "mem", models.FieldKeyTagKey: "free"}, 1.0),
+		point(other, map[string]string{models.MeasurementTagKey: "mem", models.FieldKeyTagKey: "free"}, int64(1)),
+	})
+
+	var pwe tsdb.PartialWriteError
+	if !errors.As(err, &pwe) {
+		t.Fatalf("expected a partial write error, got %v", err)
+	}
+	if got, exp := pwe.Dropped, 4; got != exp {
+		t.Fatalf("got %d points dropped, expected %d", got, exp)
+	}
+	if exp := `schema violation: field "usage" of measurement "cpu" is integer, the bucket schema requires float`; pwe.Reason != exp {
+		t.Fatalf("got reason %q, expected %q", pwe.Reason, exp)
+	}
+
+	// Only the point matching the schema and the one of the bucket without
+	// schema are written.
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	s := newSchema(&influxdb.BucketSchema{
+		Measurements: []influxdb.MeasurementSchema{{
+			Name: "cpu",
+			Tags: []string{"host"},
+			Fields: []influxdb.FieldSchema{
+				{Name: "usage", Type: influxdb.SchemaFieldTypeFloat},
+				{Name: "up", Type: influxdb.SchemaFieldTypeBoolean},
+			},
+		}},
+	})
+
+	tags := func(kvs ...string) models.Tags {
+		var tags models.Tags
+		for i := 0; i < len(kvs); i += 2 {
+			tags = append(tags, models.NewTag([]byte(kvs[i]), []byte(kvs[i+1])))
+		}
+		return tags
+	}
+
+	tests := []struct {
+		name   string
+		tags   models.Tags
+		typ    models.FieldType
+		reason string
+	}{
+		{
+			name: "valid",
+			tags: tags("\x00", "cpu", "host", "a", "\xff", "usage"),
+			typ:  models.Float,
+		},
+		{
+			name: "valid without tags",
+			tags: tags("\x00", "cpu", "\xff", "up"),
+			typ:  models.Boolean,
+		},
+		{
+			name:   "unknown measurement",
+			tags:   tags("\x00", "disk", "\xff", "usage"),
+			typ:    models.Float,
+			reason: `measurement "disk" is not in the bucket schema`,
+		},
+		{
+			name:   "unknown tag key",
+			tags:   tags("\x00", "cpu", "hots", "a", "\xff", "usage"),
+			typ:    models.Float,
+			reason: `tag key "hots" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:   "unknown field",
+			tags:   tags("\x00", "cpu", "\xff", "usage_idle"),
+			typ:    models.Float,
+			reason: `field "usage_idle" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:   "wrong field type",
+			tags:   tags("\x00", "cpu", "\xff", "up"),
+			typ:    models.String,
+			reason: `field "up" of measurement "cpu" is string, the bucket schema requires boolean`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := s.validate(tt.tags, tt.typ)
+			if tt.reason == "" && reason != "" {
+				t.Fatalf("unexpected schema violation: %s", reason)
+			}
+			if !strings.HasSuffix(reason, tt.reason) {
+				t.Fatalf("got reason %q, expected %q", reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestBucketSchemas_Schema(t *testing.T) {
+	var calls int
+	finder := NewTestBucketFinder()
+	finder.FindBucketsFn = func(context.Context, influxdb.BucketFilter, ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		calls++
+		if calls > 1 {
+			return nil, 0, errors.New("bucket service unavailable")
+		}
+		return []*influxdb.Bucket{{
+			ID:    2,
+			OrgID: 1,
+			Schema: &influxdb.BucketSchema{
+				Measurements: []influxdb.MeasurementSchema{{
+					Name:   "cpu",
+					Fields: []influxdb.FieldSchema{{Name: "usage", Type: influxdb.SchemaFieldTypeFloat}},
+				}},
+			},
+		}}, 1, nil
+	}
+
+	schemas := newBucketSchemas(finder)
+	name := tsdb.EncodeName(1, 2)
+	if schemas.Schema(context.Background(), name[:]) == nil {
+		t.Fatal("expected a schema")
+	}
+
+	// The schema is cached until it expires.
+	if schemas.Schema(context.Background(), name[:]) == nil || calls != 1 {
+		t.Fatalf("expected a cached schema, got %d lookups", calls)
+	}
+
+	// The schema cached last is used when the lookup fails.
+	cached := schemas.schemas[name]
+	cached.fetched = time.Now().Add(-2 * bucketSchemasTTL)
+	schemas.schemas[name] = cached
+	if schemas.Schema(context.Background(), name[:]) == nil || calls != 2 {
+		t.Fatalf("expected the schema cached last, got %d lookups", calls)
+	}
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/pkg/hll"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/seriesfile"
+	"github.com/influxdata/influxdb/tsdb/tsi1"
+)
+
+// seriesLimitsRefreshInterval is how often the series cardinality of the buckets
+// is read again from the index, so that the series that were deleted since are
+// no longer counted against the limits.
+const seriesLimitsRefreshInterval = time.Minute
+
+// seriesLimiter enforces the maximum number of series of each bucket and of each
+// organization. The number of series of a bucket is estimated from the exact
+// cardinality of the index at the last refresh, plus a sketch of the series
+// created since, so that the series created by concurrent writes are counted
+// only once.
+type seriesLimiter struct {
+	maxPerBucket int64
+	maxPerOrg    int64
+
+	index *tsi1.Index
+	sfile *seriesfile.SeriesFile
+
+	mu        sync.Mutex
+	refreshed time.Time
+	base      map[string]int64     // series per bucket and per org at the last refresh
+	created   map[string]*hll.Plus // series created per bucket and per org since
+}
+
+func newSeriesLimiter(maxPerBucket, maxPerOrg int64, index *tsi1.Index, sfile *seriesfile.SeriesFile) *seriesLimiter {
+	return &seriesLimiter{
+		maxPerBucket: maxPerBucket,
+		maxPerOrg:    maxPerOrg,
+		index:        index,
+		sfile:        sfile,
+	}
+}
+
+// The counts of a bucket are keyed by its encoded org and bucket IDs, and those
+// of an org by its encoded ID alone.
+func orgCountKey(name []byte) string { return string(name[:influxdb.OrgIDLength]) }
+
+// refresh reads the series cardinality of all buckets from the index and resets
+// the sketches. It must be called with l.mu held.
+func (l *seriesLimiter) refresh() error {
+	stats, err := l.index.MeasurementCardinalityStats()
+	if err != nil {
+		return err
+	}
+
+	l.base = make(map[string]int64, len(stats))
+	for name, n := range stats {
+		if len(name) != influxdb.MeasurementLength {
+			continue
+		}
+		l.base[name] += int64(n)
+		l.base[orgCountKey([]byte(name))] += int64(n)
+	}
+	l.created = make(map[string]*hll.Plus)
+	l.refreshed = time.Now()
+	return nil
+}
+
+// Invalidate makes the next check read the series cardinality from the index,
+// for instance after series were deleted.
+func (l *seriesLimiter) Invalidate() {
+	l.mu.Lock()
+	l.refreshed = time.Time{}
+	l.mu.Unlock()
+}
+
+// count returns the estimated number of series of a bucket or org.
+func (l *seriesLimiter) count(key string) int64 {
+	n := l.base[key]
+	if sketch := l.created[key]; sketch != nil {
+		n += int64(sketch.Count())
+	}
+	return n
+}
+
+// add counts a series created in a bucket or org.
+func (l *seriesLimiter) add(key string, seriesKey []byte) {
+	sketch := l.created[key]
+	if sketch == nil {
+		sketch = hll.NewDefaultPlus()
+		l.created[key] = sketch
+	}
+	sketch.Add(seriesKey)
+}
+
+// Check returns, for every point of the collection, the reason why it must be
+// dropped, or an empty string if it can be written. Only the points that would
+// create a new series beyond the limit of their bucket or org are dropped; the
+// new series of the other points are counted as created.
+func (l *seriesLimiter) Check(collection *tsdb.SeriesCollection) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Since(l.refreshed) > seriesLimitsRefreshInterval {
+		if err := l.refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		reasons = make([]string, collection.Length())
+
+		// The number of series of the buckets and orgs written to, estimated
+		// once per batch as counting the series of a sketch is not cheap.
+		counts  = make(map[string]int64)
+		created = make(map[string]struct{})
+		buf     []byte
+	)
+	countOf := func(key string) int64 {
+		n, ok := counts[key]
+		if !ok {
+			n = l.count(key)
+			counts[key] = n
+		}
+		return n
+	}
+
+	for iter := collection.Iterator(); iter.Next(); {
+		buf = seriesfile.AppendSeriesKey(buf[:0], iter.Name(), iter.Tags())
+		if _, ok := created[string(buf)]; ok {
+			continue
+		}
+		if !l.sfile.SeriesIDTypedBySeriesKey(buf).IsZero() {
+			continue
+		}
+
+		bucketKey, orgKey := string(iter.Name()), orgCountKey(iter.Name())
+		orgID, bucketID := tsdb.DecodeNameSlice(iter.Name())
+		if n := countOf(bucketKey); l.maxPerBucket > 0 && n >= l.maxPerBucket {
+			reasons[iter.Index()] = fmt.Sprintf("max series per bucket exceeded: bucket %s has %d series, the limit is %d", bucketID, n, l.maxPerBucket)
+			continue
+		}
+		if n := countOf(orgKey); l.maxPerOrg > 0 && n >= l.maxPerOrg {
+			reasons[iter.Index()] = fmt.Sprintf("max series per org exceeded: org %s has %d series, the limit is %d", orgID, n, l.maxPerOrg)
+			continue
+		}
+
+		created[string(buf)] = struct{}{}
+		counts[bucketKey]++
+		counts[orgKey]++
+		l.add(bucketKey, buf)
+		l.add(orgKey, buf)
+	}
+	return reasons, nil
+}
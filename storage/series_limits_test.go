@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestEngine_WritePoints_SeriesLimits(t *testing.T) {
+	path := MustTempDir()
+	defer os.RemoveAll(path)
+
+	config := NewConfig()
+	config.MaxSeriesPerBucket = 3
+	config.MaxSeriesPerOrg = 5
+
+	engine := NewEngine(path, config, WithNodeID(103), WithEngineID(34))
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	points := func(org, bucket influxdb.ID, hosts ...int) []models.Point {
+		var points []models.Point
+		for _, host := range hosts {
+			points = append(points, models.MustNewPoint(
+				tsdb.EncodeNameString(org, bucket),
+				models.NewTags(map[string]string{
+					models.MeasurementTagKey: "cpu",
+					"host":                   fmt.Sprintf("host%d", host),
+					models.FieldKeyTagKey:    "usage",
+				}),
+				map[string]interface{}{"usage": 1.0},
+				time.Unix(1, 0),
+			))
+		}
+		return points
+	}
+
+	// partialWrite returns the points dropped by a write because of the limits.
+	partialWrite := func(err error) tsdb.PartialWriteError {
+		t.Helper()
+		if influxdb.ErrorCode(err) != influxdb.ESeriesLimitExceeded {
+			t.Fatalf("expected a series limit error, got %v", err)
+		}
+		pwe, ok := err.(*influxdb.Error).Err.(tsdb.PartialWriteError)
+		if !ok {
+			t.Fatalf("expected a partial write error, got %v", err)
+		}
+		return pwe
+	}
+
+	// A batch creating series beyond the limit of the bucket is partially written;
+	// the point of an existing series is always written.
+	if err := engine.WritePoints(context.Background(), points(1, 2, 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	pwe := partialWrite(engine.WritePoints(context.Background(), points(1, 2, 1, 3, 3, 4, 5)))
+	if got, exp := pwe.Dropped, 2; got != exp {
+		t.Fatalf("got %d points dropped, expected %d", got, exp)
+	}
+	if !strings.HasPrefix(pwe.Reason, "max series per bucket exceeded") {
+		t.Fatalf("unexpected reason %q", pwe.Reason)
+	}
+
+	// Points of existing series are still written to a full bucket.
+	if err := engine.WritePoints(context.Background(), points(1, 2, 1, 2, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another bucket of the org is limited by the series of the org.
+	pwe = partialWrite(engine.WritePoints(context.Background(), points(1, 3, 1, 2, 3)))
+	if pwe.Dropped != 1 || !strings.HasPrefix(pwe.Reason, "max series per org exceeded") {
+		t.Fatalf("expected the org limit to be exceeded, got %v", pwe)
+	}
+
+	// Other orgs are not limited.
+	if err := engine.WritePoints(context.Background(), points(4, 5, 1, 2, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(8); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+}
@@ -56,7 +56,9 @@ type Task struct {
 	Flux            string                 `json:"flux"`
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
+	Location        string                 `json:"location,omitempty"`
 	Offset          time.Duration          `json:"offset,omitempty"`
+	DependsOn       []ID                   `json:"dependsOn,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
@@ -81,6 +83,16 @@ func (t *Task) EffectiveCron() string {
 	return ""
 }
 
+// ScheduleLocation returns the location whose wall clock the cron of the
+// task triggers on, UTC when the task has no location option.
+func (t *Task) ScheduleLocation() (*time.Location, error) {
+	if t.Location == "Local" {
+		// the schedule must not depend on the time zone of the server.
+		return nil, errors.New("location Local is not supported, use an IANA time zone")
+	}
+	return time.LoadLocation(t.Location)
+}
+
 // Run is a record createId when a run of a task is scheduled.
 type Run struct {
 	ID           ID        `json:"id,omitempty"`
@@ -91,6 +103,8 @@ type Run struct {
 	StartedAt    time.Time `json:"startedAt,omitempty"`   // StartedAt is the time the executor begins running the task
 	FinishedAt   time.Time `json:"finishedAt,omitempty"`  // FinishedAt is the time the executor finishes running the task
 	RequestedAt  time.Time `json:"requestedAt,omitempty"` // RequestedAt is the time the coordinator told the scheduler to schedule the task
+	Attempt      int       `json:"attempt,omitempty"`     // Attempt is the number of the attempt at running the task for ScheduledFor, starting at 1
+	RetryOf      ID        `json:"retryOf,omitempty"`     // RetryOf is the run of the previous attempt, which this run retries
 	Log          []Log     `json:"log,omitempty"`
 }
 
@@ -142,6 +156,27 @@ type TaskService interface {
 	// ForceRun forces a run to occur with unix timestamp scheduledFor, to be executed as soon as possible.
 	// The value of scheduledFor may or may not align with the task's schedule.
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// BackfillTask creates a backfill running the task for every point of its schedule in the range.
+	BackfillTask(ctx context.Context, taskID ID, bc BackfillCreate) (*Backfill, error)
+
+	// FindBackfills returns the backfills of a task, the most recently created first, and their count.
+	FindBackfills(ctx context.Context, taskID ID) ([]*Backfill, int, error)
+
+	// FindBackfillByID returns a single backfill of a task.
+	FindBackfillByID(ctx context.Context, taskID, id ID) (*Backfill, error)
+
+	// CancelBackfill stops a running backfill from running any more runs and cancels its runs in progress.
+	CancelBackfill(ctx context.Context, taskID, id ID) (*Backfill, error)
+
+	// FindTaskRevisions returns the revisions of a task, the most recent first, and their count.
+	FindTaskRevisions(ctx context.Context, taskID ID) ([]*TaskRevision, int, error)
+
+	// FindTaskRevision returns a single revision of a task by its number.
+	FindTaskRevision(ctx context.Context, taskID ID, revision int) (*TaskRevision, error)
+
+	// RollbackTask restores the flux, description and upstream tasks of a revision of a task, and its status when restoreStatus is set, which records a new revision.
+	RollbackTask(ctx context.Context, taskID ID, revision int, restoreStatus bool) (*Task, error)
 }
 
 // TaskCreate is the set of values to create a task.
@@ -154,6 +189,10 @@ type TaskCreate struct {
 	Organization   string                 `json:"org,omitempty"`
 	OwnerID        ID                     `json:"-"`
 	Metadata       map[string]interface{} `json:"-"` // not to be set through a web request but rather used by a http service using tasks backend.
+	// DependsOn are the IDs of the upstream tasks of the task. A task with
+	// upstream tasks is run for a point of its schedule once the runs of all
+	// of its upstream tasks scheduled for the same time have succeeded.
+	DependsOn []ID `json:"dependsOn,omitempty"`
 }
 
 func (t TaskCreate) Validate() error {
@@ -173,6 +212,8 @@ type TaskUpdate struct {
 	Flux        *string `json:"flux,omitempty"`
 	Status      *string `json:"status,omitempty"`
 	Description *string `json:"description,omitempty"`
+	// DependsOn replaces the upstream tasks of the task when set.
+	DependsOn *[]ID `json:"dependsOn,omitempty"`
 
 	// LatestCompleted us to set latest completed on startup to skip task catchup
 	LatestCompleted *time.Time             `json:"-"`
@@ -192,6 +233,7 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 		Status      *string `json:"status,omitempty"`
 		Name        string  `json:"name,omitempty"`
 		Description *string `json:"description,omitempty"`
+		DependsOn   *[]ID   `json:"dependsOn,omitempty"`
 
 		// Cron is a cron style time schedule that can be used in place of Every.
 		Cron string `json:"cron,omitempty"`
@@ -207,6 +249,10 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 		Concurrency *int64 `json:"concurrency,omitempty"`
 
 		Retry *int64 `json:"retry,omitempty"`
+
+		// Location is the time zone the cron is evaluated in, an empty
+		// location removes it.
+		Location *string `json:"location,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(data, &jo); err != nil {
@@ -214,6 +260,7 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 	}
 	t.Options.Name = jo.Name
 	t.Description = jo.Description
+	t.DependsOn = jo.DependsOn
 	t.Options.Cron = jo.Cron
 	t.Options.Every = jo.Every
 	if jo.Offset != nil {
@@ -222,6 +269,7 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 	}
 	t.Options.Concurrency = jo.Concurrency
 	t.Options.Retry = jo.Retry
+	t.Options.Location = jo.Location
 	t.Flux = jo.Flux
 	t.Status = jo.Status
 	return nil
@@ -233,6 +281,7 @@ func (t *TaskUpdate) MarshalJSON() ([]byte, error) {
 		Status      *string `json:"status,omitempty"`
 		Name        string  `json:"name,omitempty"`
 		Description *string `json:"description,omitempty"`
+		DependsOn   *[]ID   `json:"dependsOn,omitempty"`
 
 		// Cron is a cron style time schedule that can be used in place of Every.
 		Cron string `json:"cron,omitempty"`
@@ -246,17 +295,23 @@ func (t *TaskUpdate) MarshalJSON() ([]byte, error) {
 		Concurrency *int64 `json:"concurrency,omitempty"`
 
 		Retry *int64 `json:"retry,omitempty"`
+
+		// Location is the time zone the cron is evaluated in, an empty
+		// location removes it.
+		Location *string `json:"location,omitempty"`
 	}{}
 	jo.Name = t.Options.Name
 	jo.Cron = t.Options.Cron
 	jo.Every = t.Options.Every
 	jo.Description = t.Description
+	jo.DependsOn = t.DependsOn
 	if t.Options.Offset != nil {
 		offset := *t.Options.Offset
 		jo.Offset = &offset
 	}
 	jo.Concurrency = t.Options.Concurrency
 	jo.Retry = t.Options.Retry
+	jo.Location = t.Options.Location
 	jo.Flux = t.Flux
 	jo.Status = t.Status
 	return json.Marshal(jo)
@@ -274,7 +329,7 @@ func (t *TaskUpdate) Validate() error {
 		if _, err := time.ParseDuration(t.Options.Offset.String()); err != nil {
 			return fmt.Errorf("offset: %s, %s is invalid, the largest unit supported is h", t.Options.Offset.String(), err)
 		}
-	case t.Flux == nil && t.Status == nil && t.Options.IsZero():
+	case t.Flux == nil && t.Status == nil && t.DependsOn == nil && t.Options.IsZero():
 		return errors.New("cannot update task without content")
 	case t.Status != nil && *t.Status != TaskStatusActive && *t.Status != TaskStatusInactive:
 		return fmt.Errorf("invalid task status: %q", *t.Status)
@@ -328,6 +383,16 @@ func (t *TaskUpdate) UpdateFlux(oldFlux string) (err error) {
 	if t.Options.Cron != "" {
 		op["cron"] = &ast.StringLiteral{Value: t.Options.Cron}
 	}
+	if t.Options.Location != nil {
+		if *t.Options.Location != "" {
+			op["location"] = &ast.StringLiteral{Value: *t.Options.Location}
+		} else {
+			toDelete["location"] = struct{}{}
+		}
+	} else if !t.Options.Every.IsZero() {
+		// the location only applies to cron.
+		toDelete["location"] = struct{}{}
+	}
 	if t.Options.Offset != nil {
 		if !t.Options.Offset.IsZero() {
 			op["offset"] = &t.Options.Offset.Node
@@ -345,18 +410,29 @@ func (t *TaskUpdate) UpdateFlux(oldFlux string) (err error) {
 			if !ok {
 				return nil, fmt.Errorf("value is is %s, not an object expression", a.Init.Type())
 			}
+			// remove the deleted keys
+			properties := obj.Properties[:0]
+			for _, p := range obj.Properties {
+				if _, ok := toDelete[p.Key.Key()]; !ok {
+					properties = append(properties, p)
+				}
+			}
+			obj.Properties = properties
+
 			// modify in the keys and values that already are in the ast
-			for i, p := range obj.Properties {
+			for _, p := range obj.Properties {
 				k := p.Key.Key()
-				if _, ok := toDelete[k]; ok {
-					obj.Properties = append(obj.Properties[:i], obj.Properties[i+1:]...)
-				}
 				switch k {
 				case "name":
 					if name, ok := op["name"]; ok && t.Options.Name != "" {
 						delete(op, "name")
 						p.Value = name
 					}
+				case "location":
+					if location, ok := op["location"]; ok {
+						delete(op, "location")
+						p.Value = location
+					}
 				case "offset":
 					if offset, ok := op["offset"]; ok && t.Options.Offset != nil {
 						delete(op, "offset")
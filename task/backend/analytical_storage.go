@@ -21,6 +21,8 @@ const (
 	startedAtField    = "startedAt"
 	finishedAtField   = "finishedAt"
 	requestedAtField  = "requestedAt"
+	attemptField      = "attempt"
+	retryOfField      = "retryOf"
 	logField          = "logs"
 
 	taskIDTag = "taskID"
@@ -399,6 +401,19 @@ func (re *runReader) readRuns(cr flux.ColReader) error {
 					continue
 				}
 				r.FinishedAt = finished.UTC()
+			case attemptField:
+				if cr.Ints(j).IsValid(i) {
+					r.Attempt = int(cr.Ints(j).Value(i))
+				}
+			case retryOfField:
+				if cr.Strings(j).ValueString(i) != "" {
+					id, err := influxdb.IDFromString(cr.Strings(j).ValueString(i))
+					if err != nil {
+						re.log.Info("Failed to parse retryOf", zap.Error(err))
+						continue
+					}
+					r.RetryOf = *id
+				}
 			case logField:
 				logBytes := bytes.TrimSpace(cr.Strings(j).Value(i))
 				if len(logBytes) != 0 {
@@ -66,9 +66,10 @@ func NotifyCoordinatorOfExisting(ctx context.Context, log *zap.Logger, ts TaskSe
 type TaskResumer func(ctx context.Context, id influxdb.ID, runID influxdb.ID) error
 
 // TaskNotifyCoordinatorOfExisting lists all tasks by the provided task service and for
-// each task it calls the provided coordinators task created method
+// each task it calls the provided coordinators task created method, and
+// resumes the retries of failed runs queued as manual runs with retry.
 // TODO(docmerlin): this is temporary untill the executor queue is persistent
-func TaskNotifyCoordinatorOfExisting(ctx context.Context, ts TaskService, tcs TaskControlService, coord Coordinator, exec TaskResumer, log *zap.Logger) error {
+func TaskNotifyCoordinatorOfExisting(ctx context.Context, ts TaskService, tcs TaskControlService, coord Coordinator, exec TaskResumer, retry TaskResumer, log *zap.Logger) error {
 	// If we missed a Create Action
 	tasks, _, err := ts.FindTasks(ctx, influxdb.TaskFilter{})
 	if err != nil {
@@ -101,6 +102,61 @@ func TaskNotifyCoordinatorOfExisting(ctx context.Context, ts TaskService, tcs Ta
 					return err
 				}
 			}
+
+			manualRuns, err := tcs.ManualRuns(ctx, task.ID)
+			if err != nil {
+				return err
+			}
+			for _, run := range manualRuns {
+				if !run.RetryOf.Valid() {
+					continue
+				}
+				if err := retry(ctx, task.ID, run.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		tasks, _, err = ts.FindTasks(ctx, influxdb.TaskFilter{
+			After: &tasks[len(tasks)-1].ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BackfillFinder finds the backfills of a task.
+type BackfillFinder interface {
+	FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error)
+}
+
+// FailInterruptedBackfills lists all tasks by the provided task service and
+// marks their backfills still running as failed, as the executor running them
+// stopped with the server.
+func FailInterruptedBackfills(ctx context.Context, ts TaskService, bf BackfillFinder, tcs TaskControlService, log *zap.Logger) error {
+	tasks, _, err := ts.FindTasks(ctx, influxdb.TaskFilter{})
+	if err != nil {
+		return err
+	}
+
+	failed := influxdb.BackfillFailed
+	for len(tasks) > 0 {
+		for _, task := range tasks {
+			backfills, _, err := bf.FindBackfills(ctx, task.ID)
+			if err != nil {
+				return err
+			}
+			for _, b := range backfills {
+				if !b.Running() {
+					continue
+				}
+				if _, err := tcs.UpdateBackfill(ctx, task.ID, b.ID, influxdb.BackfillUpdate{Status: &failed}); err != nil {
+					log.Error("Failed to fail interrupted backfill", zap.String("taskID", task.ID.String()), zap.String("backfillID", b.ID.String()), zap.Error(err))
+				}
+			}
 		}
 
 		tasks, _, err = ts.FindTasks(ctx, influxdb.TaskFilter{
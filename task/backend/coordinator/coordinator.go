@@ -3,6 +3,7 @@ package coordinator
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/influxdata/influxdb"
@@ -18,10 +19,22 @@ var _ Executor = (*executor.Executor)(nil)
 // DefaultLimit is the maximum number of tasks that a given taskd server can own
 const DefaultLimit = 1000
 
+// maxPendingDependencyRuns bounds the number of schedule points of a task
+// waiting for the runs of its upstream tasks to succeed.
+const maxPendingDependencyRuns = 100
+
 // Executor is an abstraction of the task executor with only the functions needed by the coordinator
 type Executor interface {
+	PromisedExecute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) (executor.Promise, error)
 	ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error)
 	Cancel(ctx context.Context, runID influxdb.ID) error
+	Backfill(ctx context.Context, task *influxdb.Task, b *influxdb.Backfill) error
+	CancelBackfill(ctx context.Context, id influxdb.ID) error
+}
+
+// RunFinder finds the runs of a task, the latest scheduled first.
+type RunFinder interface {
+	FindRuns(ctx context.Context, filter influxdb.RunFilter) ([]*influxdb.Run, int, error)
 }
 
 // Coordinator is the intermediary between the scheduling/executing system and the rest of the task system
@@ -31,6 +44,24 @@ type Coordinator struct {
 	ex  Executor
 
 	limit int
+	// runs finds the runs of the upstream tasks that succeeded before the
+	// coordinator recorded them, such as before a restart.
+	runs RunFinder
+
+	mu sync.Mutex
+	// dependencies are the active tasks with upstream tasks, by task ID. They
+	// are not scheduled but run when their upstream tasks succeed.
+	dependencies map[influxdb.ID]*dependency
+}
+
+// dependency is a task with upstream tasks and the upstream tasks whose runs
+// succeeded, by the scheduledFor of the runs, for the schedule points of the
+// task not run yet.
+type dependency struct {
+	task      *influxdb.Task
+	succeeded map[int64]map[influxdb.ID]bool
+	// lastRun is the latest schedule point run, as a unix timestamp.
+	lastRun int64
 }
 
 type CoordinatorOption func(*Coordinator)
@@ -67,6 +98,14 @@ func WithLimitOpt(i int) CoordinatorOption {
 	}
 }
 
+// WithRunFinderOpt looks up the runs of the upstream tasks of a task that
+// the coordinator did not see succeed in their history.
+func WithRunFinderOpt(runs RunFinder) CoordinatorOption {
+	return func(c *Coordinator) {
+		c.runs = runs
+	}
+}
+
 // NewSchedulableTask transforms an influxdb task to a schedulable task type
 func NewSchedulableTask(task *influxdb.Task) (SchedulableTask, error) {
 
@@ -81,9 +120,13 @@ func NewSchedulableTask(task *influxdb.Task) (SchedulableTask, error) {
 		ts = task.LatestScheduled
 	}
 
+	loc, err := task.ScheduleLocation()
+	if err != nil {
+		return SchedulableTask{}, err
+	}
+
 	var sch scheduler.Schedule
-	var err error
-	sch, ts, err = scheduler.NewSchedule(effCron, ts)
+	sch, ts, err = scheduler.NewScheduleIn(effCron, loc, ts)
 	if err != nil {
 		return SchedulableTask{}, err
 	}
@@ -96,6 +139,8 @@ func NewCoordinator(log *zap.Logger, scheduler scheduler.Scheduler, executor Exe
 		sch:   scheduler,
 		ex:    executor,
 		limit: DefaultLimit,
+
+		dependencies: make(map[influxdb.ID]*dependency),
 	}
 
 	for _, opt := range opts {
@@ -112,6 +157,11 @@ func (c *Coordinator) TaskCreated(ctx context.Context, task *influxdb.Task) erro
 	if err != nil {
 		return err
 	}
+	if len(task.DependsOn) > 0 {
+		c.setDependency(task)
+		return nil
+	}
+
 	// func new schedulable task
 	// catch errors from offset and last scheduled
 	if err = c.sch.Schedule(t); err != nil {
@@ -131,10 +181,20 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 
 	// if disabling the task, release it before schedule update
 	if to.Status != from.Status && to.Status == string(influxdb.TaskInactive) {
+		c.removeDependency(to.ID)
 		if err := c.sch.Release(sid); err != nil && err != influxdb.ErrTaskNotClaimed {
 			return err
 		}
+	} else if len(to.DependsOn) > 0 {
+		// the task is run by its upstream tasks from now on.
+		if len(from.DependsOn) == 0 {
+			if err := c.sch.Release(sid); err != nil && err != influxdb.ErrTaskNotClaimed {
+				return err
+			}
+		}
+		c.setDependency(to)
 	} else {
+		c.removeDependency(to.ID)
 		if err := c.sch.Schedule(t); err != nil {
 			return err
 		}
@@ -145,6 +205,7 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 
 //TaskDeleted asks the Scheduler to release the deleted task
 func (c *Coordinator) TaskDeleted(ctx context.Context, id influxdb.ID) error {
+	c.removeDependency(id)
 	tid := scheduler.ID(id)
 	if err := c.sch.Release(tid); err != nil && err != influxdb.ErrTaskNotClaimed {
 		return err
@@ -189,3 +250,195 @@ func (c *Coordinator) RunForced(ctx context.Context, task *influxdb.Task, run *i
 
 	return nil
 }
+
+// BackfillCreated speaks directly to the Executor to run the backfill of a task in the background
+func (c *Coordinator) BackfillCreated(ctx context.Context, task *influxdb.Task, b *influxdb.Backfill) error {
+	return c.ex.Backfill(ctx, task, b)
+}
+
+// BackfillCancelled speaks directly to the Executor to stop running a backfill
+func (c *Coordinator) BackfillCancelled(ctx context.Context, id influxdb.ID) error {
+	return c.ex.CancelBackfill(ctx, id)
+}
+
+// RunSucceeded runs the tasks that depend on the task of the run for the
+// scheduledFor of the run, once the runs of all of their upstream tasks
+// scheduled for the same time have succeeded. Only the schedule points of
+// the tasks that depend on the task are run.
+func (c *Coordinator) RunSucceeded(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error {
+	var rerr error
+	for _, t := range c.dependentsReady(ctx, task.ID, run.ScheduledFor) {
+		if _, err := c.ex.PromisedExecute(ctx, scheduler.ID(t.ID), run.ScheduledFor, time.Now().UTC()); err != nil {
+			c.log.Error("Failed to run task after its upstream tasks", zap.String("taskID", t.ID.String()), zap.Time("scheduledFor", run.ScheduledFor), zap.Error(err))
+			rerr = err
+		}
+	}
+	return rerr
+}
+
+// pendingDependency is a task waiting for the runs of the upstream tasks
+// that the coordinator did not see succeed for a schedule point.
+type pendingDependency struct {
+	task    *influxdb.Task
+	waiting []influxdb.ID
+}
+
+// dependentsReady records the success of the run of the upstream task for
+// scheduledFor and returns the tasks whose upstream tasks have all succeeded
+// for it. The upstream tasks not seen to succeed are looked up in the history
+// of their runs.
+func (c *Coordinator) dependentsReady(ctx context.Context, upstreamID influxdb.ID, scheduledFor time.Time) []*influxdb.Task {
+	var ready []*influxdb.Task
+	for _, p := range c.recordSuccess(upstreamID, scheduledFor) {
+		if !c.succeededFor(ctx, p.waiting, scheduledFor) {
+			continue
+		}
+		if c.markRun(p.task.ID, scheduledFor) {
+			ready = append(ready, p.task)
+		}
+	}
+	return ready
+}
+
+// recordSuccess records the success of the run of the upstream task for
+// scheduledFor and returns the tasks that depend on it with the upstream
+// tasks they are still waiting for.
+func (c *Coordinator) recordSuccess(upstreamID influxdb.ID, scheduledFor time.Time) []pendingDependency {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sf := scheduledFor.Unix()
+	var pending []pendingDependency
+	for _, d := range c.dependencies {
+		if !dependsOn(d.task, upstreamID) || !schedulePoint(d.task, scheduledFor) || sf <= d.lastRun {
+			continue
+		}
+
+		succeeded := d.succeeded[sf]
+		if succeeded == nil {
+			succeeded = make(map[influxdb.ID]bool)
+			d.succeeded[sf] = succeeded
+		}
+		succeeded[upstreamID] = true
+		d.prune(maxPendingDependencyRuns)
+
+		p := pendingDependency{task: d.task}
+		for _, id := range d.task.DependsOn {
+			if !succeeded[id] {
+				p.waiting = append(p.waiting, id)
+			}
+		}
+		pending = append(pending, p)
+	}
+	return pending
+}
+
+// succeededFor reports whether a run of each of the upstream tasks scheduled
+// for scheduledFor succeeded, according to the history of their runs.
+func (c *Coordinator) succeededFor(ctx context.Context, upstreamIDs []influxdb.ID, scheduledFor time.Time) bool {
+	if len(upstreamIDs) == 0 {
+		return true
+	}
+	if c.runs == nil {
+		return false
+	}
+
+	for _, id := range upstreamIDs {
+		runs, _, err := c.runs.FindRuns(ctx, influxdb.RunFilter{Task: id, Limit: influxdb.TaskMaxPageSize})
+		if err != nil {
+			c.log.Error("Failed to find runs of upstream task", zap.String("taskID", id.String()), zap.Error(err))
+			return false
+		}
+
+		succeeded := false
+		for _, r := range runs {
+			if r.ScheduledFor.Equal(scheduledFor) && r.Status == influxdb.RunSuccess.String() {
+				succeeded = true
+				break
+			}
+		}
+		if !succeeded {
+			return false
+		}
+	}
+	return true
+}
+
+// markRun records that the schedule point of the task is run and reports
+// whether it was not run yet.
+func (c *Coordinator) markRun(id influxdb.ID, scheduledFor time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	d, ok := c.dependencies[id]
+	sf := scheduledFor.Unix()
+	if !ok || sf <= d.lastRun {
+		return false
+	}
+	d.lastRun = sf
+
+	// the earlier schedule points won't be run anymore.
+	for k := range d.succeeded {
+		if k <= sf {
+			delete(d.succeeded, k)
+		}
+	}
+	return true
+}
+
+// prune drops the earliest schedule points of the dependency so that at most
+// max of them are waiting for upstream tasks.
+func (d *dependency) prune(max int) {
+	for len(d.succeeded) > max {
+		var earliest int64
+		first := true
+		for k := range d.succeeded {
+			if first || k < earliest {
+				earliest, first = k, false
+			}
+		}
+		delete(d.succeeded, earliest)
+	}
+}
+
+func (c *Coordinator) setDependency(task *influxdb.Task) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the upstream tasks may have changed, the schedule points waiting for
+	// them start over.
+	d := &dependency{
+		task:      task,
+		succeeded: make(map[int64]map[influxdb.ID]bool),
+	}
+	if old, ok := c.dependencies[task.ID]; ok {
+		d.lastRun = old.lastRun
+	}
+	c.dependencies[task.ID] = d
+}
+
+func (c *Coordinator) removeDependency(id influxdb.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.dependencies, id)
+}
+
+func dependsOn(task *influxdb.Task, id influxdb.ID) bool {
+	for _, upID := range task.DependsOn {
+		if upID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// schedulePoint reports whether the schedule of the task triggers on t.
+func schedulePoint(task *influxdb.Task, t time.Time) bool {
+	loc, err := task.ScheduleLocation()
+	if err != nil {
+		return false
+	}
+	points, err := scheduler.BetweenIn(task.EffectiveCron(), loc, t, t.Add(time.Second), 1)
+	return err == nil && len(points) == 1
+}
@@ -24,6 +24,11 @@ func Test_Coordinator_Executor_Methods(t *testing.T) {
 			ScheduledFor: time.Now(),
 		}
 
+		backfillOne = &influxdb.Backfill{
+			ID:     one,
+			TaskID: one,
+		}
+
 		allowUnexported = cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{})
 	)
 
@@ -74,6 +79,32 @@ func Test_Coordinator_Executor_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "BackfillCreated",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.BackfillCreated(context.Background(), taskOne, backfillOne); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			executor: &executorE{
+				calls: []interface{}{
+					backfillCall{taskOne.ID, backfillOne.ID},
+				},
+			},
+		},
+		{
+			name: "BackfillCancelled",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.BackfillCancelled(context.Background(), backfillOne.ID); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			executor: &executorE{
+				calls: []interface{}{
+					cancelBackfillCall{backfillOne.ID},
+				},
+			},
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			var (
@@ -124,6 +155,7 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 		one   = influxdb.ID(1)
 		two   = influxdb.ID(2)
 		three = influxdb.ID(3)
+		four  = influxdb.ID(4)
 		now   = time.Now().UTC()
 
 		taskOne           = &influxdb.Task{ID: one, CreatedAt: now, Cron: "* * * * *"}
@@ -143,6 +175,8 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			CreatedAt: now,
 			Cron:      "* * * * *",
 		}
+		taskFour          = &influxdb.Task{ID: four, Status: "active", CreatedAt: now, Cron: "* * * * *"}
+		taskFourDependent = &influxdb.Task{ID: four, Status: "active", CreatedAt: now, Cron: "* * * * *", DependsOn: []influxdb.ID{one}}
 	)
 
 	schedulableT, err := NewSchedulableTask(taskOne)
@@ -159,6 +193,11 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	schedulableTaskFour, err := NewSchedulableTask(taskFour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	runOne := &influxdb.Run{
 		ID:           one,
 		TaskID:       one,
@@ -225,6 +264,41 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TaskCreated - with upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskCreated(context.Background(), taskFourDependent); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{},
+		},
+		{
+			name: "TaskUpdated - add upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskUpdated(context.Background(), taskFour, taskFourDependent); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					releaseCallC{scheduler.ID(taskFour.ID)},
+				},
+			},
+		},
+		{
+			name: "TaskUpdated - remove upstream tasks",
+			call: func(t *testing.T, c *Coordinator) {
+				if err := c.TaskUpdated(context.Background(), taskFourDependent, taskFour); err != nil {
+					t.Errorf("expected nil error found %q", err)
+				}
+			},
+			scheduler: &schedulerC{
+				calls: []interface{}{
+					scheduleCall{schedulableTaskFour},
+				},
+			},
+		},
 		{
 			name: "TaskDeleted",
 			call: func(t *testing.T, c *Coordinator) {
@@ -251,7 +325,7 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			if diff := cmp.Diff(
 				test.scheduler.calls,
 				sch.calls,
-				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, *coord),
+				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, Coordinator{}),
 				cmpopts.IgnoreUnexported(scheduler.Schedule{}),
 			); diff != "" {
 				t.Errorf("unexpected scheduler contents %s", diff)
@@ -259,3 +333,108 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 		})
 	}
 }
+
+func Test_Coordinator_RunSucceeded(t *testing.T) {
+	var (
+		one   = influxdb.ID(1)
+		two   = influxdb.ID(2)
+		three = influxdb.ID(3)
+		hour  = time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		taskOne   = &influxdb.Task{ID: one, Status: "active", Every: "1m"}
+		taskTwo   = &influxdb.Task{ID: two, Status: "active", Every: "1m"}
+		taskThree = &influxdb.Task{ID: three, Status: "active", Cron: "0 * * * *", DependsOn: []influxdb.ID{one, two}}
+
+		executor = &executorE{}
+		coord    = NewCoordinator(zaptest.NewLogger(t), &schedulerC{}, executor)
+	)
+
+	if err := coord.TaskCreated(context.Background(), taskThree); err != nil {
+		t.Fatal(err)
+	}
+
+	succeed := func(task *influxdb.Task, scheduledFor time.Time) {
+		t.Helper()
+		run := &influxdb.Run{ID: influxdb.ID(100), TaskID: task.ID, ScheduledFor: scheduledFor}
+		if err := coord.RunSucceeded(context.Background(), task, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the task waits for all of its upstream tasks.
+	succeed(taskOne, hour)
+	if len(executor.calls) != 0 {
+		t.Fatalf("expected no run before all upstream tasks succeed, got %v", executor.calls)
+	}
+	succeed(taskTwo, hour)
+
+	// the points that are not in the schedule of the task are not run.
+	succeed(taskOne, hour.Add(time.Minute))
+	succeed(taskTwo, hour.Add(time.Minute))
+
+	// the point is only run once.
+	succeed(taskTwo, hour)
+
+	if diff := cmp.Diff([]interface{}{
+		promisedExecuteCall{scheduler.ID(three), hour},
+	}, executor.calls); diff != "" {
+		t.Errorf("unexpected executor calls %s", diff)
+	}
+
+	// the task is not run by its upstream tasks once deleted.
+	if err := coord.TaskDeleted(context.Background(), three); err != nil {
+		t.Fatal(err)
+	}
+	succeed(taskOne, hour.Add(time.Hour))
+	succeed(taskTwo, hour.Add(time.Hour))
+	if len(executor.calls) != 1 {
+		t.Errorf("expected no run of deleted task, got %v", executor.calls)
+	}
+}
+
+func Test_Coordinator_RunSucceeded_History(t *testing.T) {
+	var (
+		one   = influxdb.ID(1)
+		two   = influxdb.ID(2)
+		three = influxdb.ID(3)
+		hour  = time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		taskTwo   = &influxdb.Task{ID: two, Status: "active", Every: "1m"}
+		taskThree = &influxdb.Task{ID: three, Status: "active", Cron: "0 * * * *", DependsOn: []influxdb.ID{one, two}}
+
+		// the run of the first upstream task succeeded before the coordinator
+		// was started.
+		runs = runFinder{
+			one: {
+				{ID: influxdb.ID(101), TaskID: one, ScheduledFor: hour.Add(time.Hour), Status: influxdb.RunFail.String()},
+				{ID: influxdb.ID(100), TaskID: one, ScheduledFor: hour, Status: influxdb.RunSuccess.String()},
+			},
+		}
+		executor = &executorE{}
+		coord    = NewCoordinator(zaptest.NewLogger(t), &schedulerC{}, executor, WithRunFinderOpt(runs))
+	)
+
+	if err := coord.TaskCreated(context.Background(), taskThree); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scheduledFor := range []time.Time{hour, hour.Add(time.Hour)} {
+		run := &influxdb.Run{ID: influxdb.ID(200), TaskID: two, ScheduledFor: scheduledFor}
+		if err := coord.RunSucceeded(context.Background(), taskTwo, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the point whose upstream run failed is not run.
+	if diff := cmp.Diff([]interface{}{
+		promisedExecuteCall{scheduler.ID(three), hour},
+	}, executor.calls); diff != "" {
+		t.Errorf("unexpected executor calls %s", diff)
+	}
+}
+
+type runFinder map[influxdb.ID][]*influxdb.Run
+
+func (f runFinder) FindRuns(_ context.Context, filter influxdb.RunFilter) ([]*influxdb.Run, int, error) {
+	return f[filter.Task], len(f[filter.Task]), nil
+}
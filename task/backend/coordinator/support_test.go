@@ -2,6 +2,7 @@ package coordinator
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/task/backend/executor"
@@ -15,6 +16,11 @@ type (
 		calls []interface{}
 	}
 
+	promisedExecuteCall struct {
+		TaskID       scheduler.ID
+		ScheduledFor time.Time
+	}
+
 	manualRunCall struct {
 		TaskID influxdb.ID
 		RunID  influxdb.ID
@@ -23,6 +29,15 @@ type (
 	cancelCallC struct {
 		RunID influxdb.ID
 	}
+
+	backfillCall struct {
+		TaskID     influxdb.ID
+		BackfillID influxdb.ID
+	}
+
+	cancelBackfillCall struct {
+		BackfillID influxdb.ID
+	}
 )
 
 type (
@@ -94,6 +109,19 @@ func (s *schedulerC) Release(taskID scheduler.ID) error {
 	return nil
 }
 
+func (e *executorE) PromisedExecute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) (executor.Promise, error) {
+	e.calls = append(e.calls, promisedExecuteCall{id, scheduledFor})
+	ctx, cancel := context.WithCancel(ctx)
+	p := promise{
+		done:       make(chan struct{}),
+		ctx:        ctx,
+		cancelFunc: cancel,
+	}
+	close(p.done)
+
+	return &p, nil
+}
+
 func (e *executorE) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
 	e.calls = append(e.calls, manualRunCall{id, runID})
 	ctx, cancel := context.WithCancel(ctx)
@@ -112,3 +140,13 @@ func (e *executorE) Cancel(ctx context.Context, runID influxdb.ID) error {
 	e.calls = append(e.calls, cancelCallC{runID})
 	return nil
 }
+
+func (e *executorE) Backfill(ctx context.Context, task *influxdb.Task, b *influxdb.Backfill) error {
+	e.calls = append(e.calls, backfillCall{task.ID, b.ID})
+	return nil
+}
+
+func (e *executorE) CancelBackfill(ctx context.Context, id influxdb.ID) error {
+	e.calls = append(e.calls, cancelBackfillCall{id})
+	return nil
+}
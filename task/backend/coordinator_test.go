@@ -67,6 +67,54 @@ func Test_NotifyCoordinatorOfCreated(t *testing.T) {
 	}
 }
 
+func Test_FailInterruptedBackfills(t *testing.T) {
+	var (
+		tasks = &taskService{
+			pageOne: []*influxdb.Task{taskOne},
+			otherPages: map[influxdb.ID][]*influxdb.Task{
+				one: []*influxdb.Task{taskTwo, taskThree},
+			},
+		}
+		backfills = backfillFinder{
+			two: {
+				{ID: one, TaskID: two, Status: influxdb.BackfillCompleted},
+				{ID: two, TaskID: two, Status: influxdb.BackfillRunning},
+			},
+			three: {
+				{ID: three, TaskID: three, Status: influxdb.BackfillRunning},
+			},
+		}
+		tcs = &backfillControlService{}
+	)
+
+	if err := FailInterruptedBackfills(context.Background(), tasks, backfills, tcs, zaptest.NewLogger(t)); err != nil {
+		t.Errorf("expected nil, found %q", err)
+	}
+
+	if diff := cmp.Diff([]influxdb.ID{two, three}, tcs.failed); diff != "" {
+		t.Errorf("unexpected failed backfills %v", diff)
+	}
+}
+
+type backfillFinder map[influxdb.ID][]*influxdb.Backfill
+
+func (b backfillFinder) FindBackfills(_ context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, int, error) {
+	return b[taskID], len(b[taskID]), nil
+}
+
+type backfillControlService struct {
+	TaskControlService
+
+	failed []influxdb.ID
+}
+
+func (b *backfillControlService) UpdateBackfill(_ context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error) {
+	if upd.Status != nil && *upd.Status == influxdb.BackfillFailed {
+		b.failed = append(b.failed, id)
+	}
+	return &influxdb.Backfill{ID: id, TaskID: taskID}, nil
+}
+
 type coordinator struct {
 	tasks []*influxdb.Task
 }
@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"go.uber.org/zap"
+)
+
+// Backfill runs the backfill of the task in the background: a run is forced
+// for every point of the schedule of the task in the range of the backfill,
+// with at most the max concurrency of the backfill running at the same time.
+func (e *Executor) Backfill(ctx context.Context, t *influxdb.Task, b *influxdb.Backfill) error {
+	loc, err := t.ScheduleLocation()
+	if err != nil {
+		return err
+	}
+	points, err := scheduler.BetweenIn(t.EffectiveCron(), loc, b.Start, b.Stop, influxdb.MaxBackfillRuns)
+	if err != nil {
+		return err
+	}
+
+	// the backfill outlives the request that created it.
+	actx := icontext.SetAuthorizer(context.Background(), t.Authorization)
+	rctx, cancel := context.WithCancel(actx)
+	e.backfills.Store(b.ID, cancel)
+
+	go func() {
+		defer e.backfills.Delete(b.ID)
+		defer cancel()
+		e.runBackfill(actx, rctx, t, b, points)
+	}()
+	return nil
+}
+
+// CancelBackfill stops running the backfill and cancels its runs in progress.
+func (e *Executor) CancelBackfill(ctx context.Context, id influxdb.ID) error {
+	val, ok := e.backfills.Load(id)
+	if !ok {
+		return nil
+	}
+	val.(context.CancelFunc)()
+	return nil
+}
+
+// runBackfill runs the points of the backfill with rctx, until they are all
+// run or rctx is canceled, and records its progress with ctx.
+func (e *Executor) runBackfill(ctx, rctx context.Context, t *influxdb.Task, b *influxdb.Backfill, points []time.Time) {
+	concurrency := b.MaxConcurrency
+	if concurrency < 1 {
+		concurrency = influxdb.DefaultBackfillConcurrency
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+
+		mu                sync.Mutex
+		succeeded, failed int
+	)
+	record := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+		s, f := succeeded, failed
+		if _, err := e.tcs.UpdateBackfill(ctx, t.ID, b.ID, influxdb.BackfillUpdate{Succeeded: &s, Failed: &f}); err != nil {
+			e.log.Error("Failed to record backfill progress", zap.String("taskID", t.ID.String()), zap.String("backfillID", b.ID.String()), zap.Error(err))
+		}
+	}
+
+points:
+	for _, p := range points {
+		select {
+		case sem <- struct{}{}:
+		case <-rctx.Done():
+			break points
+		}
+
+		wg.Add(1)
+		go func(p time.Time) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			record(e.backfillRun(rctx, t.ID, p))
+		}(p)
+	}
+	wg.Wait()
+
+	if rctx.Err() != nil {
+		// the backfill was canceled.
+		return
+	}
+	completed := influxdb.BackfillCompleted
+	if _, err := e.tcs.UpdateBackfill(ctx, t.ID, b.ID, influxdb.BackfillUpdate{Status: &completed}); err != nil {
+		e.log.Error("Failed to complete backfill", zap.String("taskID", t.ID.String()), zap.String("backfillID", b.ID.String()), zap.Error(err))
+	}
+}
+
+// backfillRun forces a run of the task scheduled for the point and waits for
+// it to finish.
+func (e *Executor) backfillRun(ctx context.Context, taskID influxdb.ID, point time.Time) error {
+	r, err := e.ts.ForceRun(ctx, taskID, point.Unix())
+	if err != nil {
+		return err
+	}
+
+	p, err := e.ManualRun(ctx, taskID, r.ID)
+	if err != nil {
+		return err
+	}
+	<-p.Done()
+	return p.Error()
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -14,11 +15,21 @@ import (
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/backend/scheduler"
+	"github.com/influxdata/influxdb/task/options"
 	"go.uber.org/zap"
 )
 
 var _ scheduler.Executor = (*Executor)(nil)
 
+const (
+	// DefaultRetryBackoff is the delay before the first retry of a failed run,
+	// doubled for every following attempt.
+	DefaultRetryBackoff = 10 * time.Second
+
+	// DefaultMaxRetryBackoff bounds the delay before a retry of a failed run.
+	DefaultMaxRetryBackoff = 10 * time.Minute
+)
+
 type Promise interface {
 	ID() influxdb.ID
 	Cancel(ctx context.Context)
@@ -41,6 +52,10 @@ func MultiLimit(limits ...LimitFunc) LimitFunc {
 // LimitFunc is a function the executor will use to
 type LimitFunc func(*influxdb.Task, *influxdb.Run) error
 
+// RunSucceededFunc is called by the executor with the task and the run of
+// every run that succeeds.
+type RunSucceededFunc func(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error
+
 // NewExecutor creates a new task executor
 func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.AuthorizationService, ts influxdb.TaskService, tcs backend.TaskControlService) (*Executor, *ExecutorMetrics) {
 	e := &Executor{
@@ -54,6 +69,8 @@ func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.Authorizati
 		promiseQueue:    make(chan *promise, 1000),                                //TODO(lh): make this configurable
 		workerLimit:     make(chan struct{}, 100),                                 //TODO(lh): make this configurable
 		limitFunc:       func(*influxdb.Task, *influxdb.Run) error { return nil }, // noop
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
 	}
 
 	e.metrics = NewExecutorMetrics(e)
@@ -85,6 +102,16 @@ type Executor struct {
 
 	limitFunc LimitFunc
 
+	runSucceeded RunSucceededFunc
+
+	// the failed runs are retried after a backoff starting at retryBackoff
+	// and bounded by maxRetryBackoff.
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+
+	// the cancel funcs of the backfills being run, by backfill ID.
+	backfills sync.Map
+
 	// keep a pool of execution workers.
 	workerPool  sync.Pool
 	workerLimit chan struct{}
@@ -95,6 +122,19 @@ func (e *Executor) SetLimitFunc(l LimitFunc) {
 	e.limitFunc = l
 }
 
+// SetRunSucceededFunc sets the func called in the background for every run
+// that succeeds.
+func (e *Executor) SetRunSucceededFunc(f RunSucceededFunc) {
+	e.runSucceeded = f
+}
+
+// SetRetryBackoff sets the delay before the first retry of a failed run, which
+// doubles for every following attempt up to max.
+func (e *Executor) SetRetryBackoff(backoff, max time.Duration) {
+	e.retryBackoff = backoff
+	e.maxRetryBackoff = max
+}
+
 // Execute is a executor to satisfy the needs of tasks
 func (e *Executor) Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error {
 	_, err := e.PromisedExecute(ctx, id, scheduledFor, runAt)
@@ -131,6 +171,46 @@ func (e *Executor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb
 	return p, err
 }
 
+// retryRun executes the queued retry of a failed run.
+func (e *Executor) retryRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (Promise, error) {
+	r, err := e.tcs.StartManualRun(ctx, id, runID)
+	if err != nil {
+		return nil, err
+	}
+	e.tcs.AddRunLog(ctx, id, r.ID, time.Now().UTC(), fmt.Sprintf("Retrying failed run %s, attempt %d", r.RetryOf, r.Attempt))
+	p, err := e.createPromise(ctx, r)
+
+	e.startWorker()
+	e.metrics.retryRunsCounter.WithLabelValues(id.String()).Inc()
+	return p, err
+}
+
+// ResumeRetry schedules the retry of a failed run that was queued before
+// the executor was started, to be executed at its run at time.
+func (e *Executor) ResumeRetry(ctx context.Context, id influxdb.ID, runID influxdb.ID) error {
+	runs, err := e.tcs.ManualRuns(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if run.ID != runID {
+			continue
+		}
+		if !run.RetryOf.Valid() {
+			return influxdb.ErrRunNotFound
+		}
+
+		t, err := e.ts.FindTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		e.scheduleRetry(t, run)
+		return nil
+	}
+	return influxdb.ErrRunNotFound
+}
+
 func (e *Executor) ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (Promise, error) {
 	cr, err := e.tcs.CurrentlyRunning(ctx, id)
 	if err != nil {
@@ -351,9 +431,103 @@ func (w *worker) finish(p *promise, rs influxdb.RunStatus, err error) {
 		w.e.log.Debug("Completed successfully", zap.String("taskID", p.task.ID.String()))
 	}
 
+	// the retry is queued before the run is finished, while it can still be
+	// found to be copied.
+	var retry *influxdb.Run
+	if rs == influxdb.RunFail {
+		retry = w.e.queueRetry(p, err)
+	}
+
 	if _, err := w.e.tcs.FinishRun(p.ctx, p.task.ID, p.run.ID); err != nil {
 		w.e.log.Error("Failed to finish run", zap.String("taskID", p.task.ID.String()), zap.String("runID", p.run.ID.String()), zap.Error(err))
 	}
+
+	if retry != nil {
+		w.e.scheduleRetry(p.task, retry)
+	}
+
+	if rs == influxdb.RunSuccess && w.e.runSucceeded != nil {
+		w.e.notifyRunSucceeded(p.task, p.run)
+	}
+}
+
+// notifyRunSucceeded calls the run succeeded func with the run of the task,
+// outside of the worker as it may execute other runs.
+func (e *Executor) notifyRunSucceeded(t *influxdb.Task, r *influxdb.Run) {
+	go func() {
+		ctx := icontext.SetAuthorizer(context.Background(), t.Authorization)
+		if err := e.runSucceeded(ctx, t, r); err != nil {
+			e.log.Error("Failed to notify of succeeded run", zap.String("taskID", t.ID.String()), zap.String("runID", r.ID.String()), zap.Error(err))
+		}
+	}()
+}
+
+// queueRetry queues the next attempt of the failed run of the promise, when
+// the retry option of the task allows for another attempt, and returns it.
+func (e *Executor) queueRetry(p *promise, err error) *influxdb.Run {
+	if backend.IsUnrecoverable(err) || p.ctx.Err() != nil {
+		// the run can't succeed without changes to the task, or was canceled.
+		return nil
+	}
+	if runAttempt(p.run) >= maxAttempts(p.task) {
+		return nil
+	}
+
+	runAt := time.Now().UTC().Add(e.retryDelay(runAttempt(p.run) + 1))
+	r, err := e.tcs.QueueRetry(p.ctx, p.task.ID, p.run.ID, runAt)
+	if err != nil {
+		e.log.Error("Failed to retry run", zap.String("taskID", p.task.ID.String()), zap.String("runID", p.run.ID.String()), zap.Error(err))
+		return nil
+	}
+	return r
+}
+
+// scheduleRetry executes the queued retry r of a run of the task at its run
+// at time, once its backoff has elapsed.
+func (e *Executor) scheduleRetry(t *influxdb.Task, r *influxdb.Run) {
+	time.AfterFunc(time.Until(r.RunAt), func() {
+		ctx := icontext.SetAuthorizer(context.Background(), t.Authorization)
+		if _, err := e.retryRun(ctx, r.TaskID, r.ID); err != nil {
+			e.log.Error("Failed to execute retry of run", zap.String("taskID", r.TaskID.String()), zap.String("runID", r.ID.String()), zap.Error(err))
+		}
+	})
+}
+
+// retryDelay returns the backoff before the attempt, which doubles for every
+// retry and is jittered by up to half of it so that the retries of runs that
+// failed together are spread out.
+func (e *Executor) retryDelay(attempt int) time.Duration {
+	d := e.retryBackoff
+	for i := 2; i < attempt && d < e.maxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > e.maxRetryBackoff {
+		d = e.maxRetryBackoff
+	}
+	if half := int64(d / 2); half > 0 {
+		d = time.Duration(half + rand.Int63n(half+1))
+	}
+	return d
+}
+
+// maxAttempts returns the number of attempts at a run of the task allowed by
+// its retry option. The option counts the first attempt, so that retry: 3
+// re-queues a failed run at most twice and retry: 1 never does.
+func maxAttempts(t *influxdb.Task) int {
+	opts, err := options.FromScript(t.Flux)
+	if err != nil || opts.Retry == nil {
+		return 1
+	}
+	return int(*opts.Retry)
+}
+
+// runAttempt returns the attempt of the run, counting the runs created
+// before the attempts were recorded as first attempts.
+func runAttempt(r *influxdb.Run) int {
+	if r.Attempt < 1 {
+		return 1
+	}
+	return r.Attempt
 }
 
 func (w *worker) executeQuery(p *promise) {
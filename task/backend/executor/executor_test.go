@@ -69,10 +69,15 @@ func taskExecutorSystem(t *testing.T) tes {
 func TestTaskExecutor(t *testing.T) {
 	t.Run("QuerySuccess", testQuerySuccess)
 	t.Run("QueryFailure", testQueryFailure)
+	t.Run("RetryFailure", testRetryFailure)
 	t.Run("ManualRun", testManualRun)
+	t.Run("Backfill", testBackfill)
+	t.Run("CancelBackfill", testCancelBackfill)
 	t.Run("ResumeRun", testResumingRun)
+	t.Run("ResumeRetry", testResumingRetry)
 	t.Run("WorkerLimit", testWorkerLimit)
 	t.Run("LimitFunc", testLimitFunc)
+	t.Run("RunSucceededFunc", testRunSucceededFunc)
 	t.Run("Metrics", testMetrics)
 	t.Run("IteratorFailure", testIteratorFailure)
 	t.Run("ErrorHandling", testErrorHandling)
@@ -182,6 +187,75 @@ func testQueryFailure(t *testing.T) {
 	}
 }
 
+func testRetryFailure(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+	tes.ex.SetRetryBackoff(time.Millisecond, time.Millisecond)
+
+	script := fmt.Sprintf(`
+option task = {
+			name: %q,
+			every: 1m,
+			retry: 2,
+}
+from(bucket: "one") |> to(bucket: "two", orgID: "0000000000000000")`, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.FailQuery(script, errors.New("blargyblargblarg"))
+	<-promise.Done()
+	if got := promise.Error(); got == nil {
+		t.Fatal("got no error when I should have")
+	}
+
+	// the failed run is retried as the second attempt.
+	tes.svc.WaitForQueryLive(t, script)
+	runs, err := tes.i.CurrentlyRunning(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 retry running, got %d", len(runs))
+	}
+	retry := runs[0]
+	if retry.Attempt != 2 || retry.RetryOf != promise.ID() {
+		t.Fatalf("expected attempt 2 retrying run %s, got attempt %d retrying run %s", promise.ID(), retry.Attempt, retry.RetryOf)
+	}
+	if !retry.ScheduledFor.Equal(time.Unix(123, 0)) {
+		t.Fatalf("expected retry scheduled for %v, got %v", time.Unix(123, 0), retry.ScheduledFor)
+	}
+
+	// the last attempt is left failed.
+	tes.svc.FailQuery(script, errors.New("blargyblargblarg"))
+	for i := 0; ; i++ {
+		if run := tes.tcs.finishedRun(); run != nil && run.ID == retry.ID {
+			break
+		}
+		if i == 100 {
+			t.Fatal("retry did not finish in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	runs, _, err = tes.i.FindRuns(ctx, influxdb.RunFilter{Task: task.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 0 {
+		t.Fatalf("expected no more attempts, got %d runs", len(runs))
+	}
+}
+
 func testManualRun(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -229,6 +303,109 @@ func testManualRun(t *testing.T) {
 	}
 }
 
+func testBackfill(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	b, err := tes.i.BackfillTask(ctx, task.ID, influxdb.BackfillCreate{Start: start, Stop: start.Add(3 * time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Total != 3 {
+		t.Fatalf("expected 3 schedule points, got %d", b.Total)
+	}
+
+	task, err = tes.i.FindTaskByID(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tes.ex.Backfill(ctx, task, b); err != nil {
+		t.Fatal(err)
+	}
+
+	// the points are run one at a time, in order.
+	for i := 0; i < 3; i++ {
+		tes.svc.SucceedQueryAt(t, script, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	b = waitForBackfill(t, tes, b)
+	if b.Status != influxdb.BackfillCompleted {
+		t.Fatalf("expected backfill to be completed, got %s", b.Status)
+	}
+	if b.Succeeded != 3 || b.Failed != 0 {
+		t.Fatalf("expected 3 succeeded runs and no failed run, got %d and %d", b.Succeeded, b.Failed)
+	}
+}
+
+func testCancelBackfill(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	b, err := tes.i.BackfillTask(ctx, task.ID, influxdb.BackfillCreate{Start: start, Stop: start.Add(3 * time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err = tes.i.FindTaskByID(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tes.ex.Backfill(ctx, task, b); err != nil {
+		t.Fatal(err)
+	}
+	tes.svc.SucceedQueryAt(t, script, start)
+
+	if _, err := tes.i.CancelBackfill(ctx, task.ID, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := tes.ex.CancelBackfill(ctx, b.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	b = waitForBackfill(t, tes, b)
+	if b.Status != influxdb.BackfillCanceled {
+		t.Fatalf("expected backfill to be canceled, got %s", b.Status)
+	}
+	if done := b.Succeeded + b.Failed; done >= b.Total {
+		t.Fatalf("expected the backfill to stop before running all of its %d points, ran %d", b.Total, done)
+	}
+}
+
+// waitForBackfill waits for the executor to stop running the backfill and
+// returns it.
+func waitForBackfill(t *testing.T, tes tes, b *influxdb.Backfill) *influxdb.Backfill {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		if _, ok := tes.ex.backfills.Load(b.ID); !ok {
+			b, err := tes.i.FindBackfillByID(context.Background(), b.TaskID, b.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return b
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("backfill did not finish in time")
+	return nil
+}
+
 func testResumingRun(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -272,6 +449,55 @@ func testResumingRun(t *testing.T) {
 	}
 }
 
+func testResumingRetry(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failedRun, err := tes.i.CreateRun(ctx, task.ID, time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAt := time.Now().UTC().Add(20 * time.Millisecond)
+	retry, err := tes.i.QueueRetry(ctx, task.ID, failedRun.ID, runAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.Attempt != 2 || retry.RetryOf != failedRun.ID || !retry.RunAt.Equal(runAt) {
+		t.Fatalf("unexpected queued retry: %+v", retry)
+	}
+	if _, err := tes.i.FinishRun(ctx, task.ID, failedRun.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// only queued retries are resumed.
+	if err := tes.ex.ResumeRetry(ctx, task.ID, failedRun.ID); err != influxdb.ErrRunNotFound {
+		t.Fatalf("expected %v resuming a run that is not a queued retry, got %v", influxdb.ErrRunNotFound, err)
+	}
+	if err := tes.ex.ResumeRetry(ctx, task.ID, retry.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tes.svc.WaitForQueryLive(t, script)
+	if time.Now().Before(runAt) {
+		t.Fatal("retry executed before its run at time")
+	}
+	runs, err := tes.i.CurrentlyRunning(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].ID != retry.ID {
+		t.Fatalf("expected the retry %s running, got %+v", retry.ID, runs)
+	}
+	tes.svc.SucceedQuery(script)
+}
+
 func testWorkerLimit(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -341,6 +567,42 @@ func testLimitFunc(t *testing.T) {
 	}
 }
 
+func testRunSucceededFunc(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	succeeded := make(chan *influxdb.Run, 1)
+	tes.ex.SetRunSucceededFunc(func(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error {
+		succeeded <- run
+		return nil
+	})
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promise, err := tes.ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.SucceedQuery(script)
+	<-promise.Done()
+
+	select {
+	case run := <-succeeded:
+		if run.TaskID != task.ID || !run.ScheduledFor.Equal(time.Unix(123, 0)) {
+			t.Fatalf("unexpected succeeded run: %+v", run)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("run succeeded func was not called")
+	}
+}
+
 func testMetrics(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -530,9 +792,16 @@ func testErrorHandling(t *testing.T) {
 type taskControlService struct {
 	backend.TaskControlService
 
+	mu  sync.Mutex
 	run *influxdb.Run
 }
 
+func (t *taskControlService) finishedRun() *influxdb.Run {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.run
+}
+
 func (t *taskControlService) FinishRun(ctx context.Context, taskID influxdb.ID, runID influxdb.ID) (*influxdb.Run, error) {
 	// ensure auth set on context
 	_, err := icontext.GetAuthorizer(ctx)
@@ -540,6 +809,9 @@ func (t *taskControlService) FinishRun(ctx context.Context, taskID influxdb.ID,
 		panic(err)
 	}
 
-	t.run, err = t.TaskControlService.FinishRun(ctx, taskID, runID)
-	return t.run, err
+	run, err := t.TaskControlService.FinishRun(ctx, taskID, runID)
+	t.mu.Lock()
+	t.run = run
+	t.mu.Unlock()
+	return run, err
 }
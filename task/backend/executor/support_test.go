@@ -101,6 +101,35 @@ func (s *fakeQueryService) SucceedQuery(script string) {
 	delete(s.queries, spec)
 }
 
+// SucceedQueryAt waits for the query of the script run with now to be live
+// and succeeds it.
+func (s *fakeQueryService) SucceedQueryAt(t *testing.T, script string, now time.Time) {
+	t.Helper()
+
+	const attempts = 20
+	ast := makeAST(script)
+	ast.Now = now.UTC()
+	spec := makeASTString(ast)
+	for i := 0; i < attempts; i++ {
+		if i != 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		s.mu.Lock()
+		fq, ok := s.queries[spec]
+		if ok {
+			close(fq.wait)
+			delete(s.queries, spec)
+		}
+		s.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+
+	t.Fatalf("Did not see live query %q with now %v in time", script, now)
+}
+
 // FailQuery closes the running query's Ready channel and sets its error to the given value.
 func (s *fakeQueryService) FailQuery(script string, forced error) {
 	s.mu.Lock()
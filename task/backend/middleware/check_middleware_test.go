@@ -66,6 +66,12 @@ func (p *pipingCoordinator) RunRetried(ctx context.Context, task *influxdb.Task,
 func (p *pipingCoordinator) RunForced(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error {
 	return p.err
 }
+func (p *pipingCoordinator) BackfillCreated(ctx context.Context, task *influxdb.Task, b *influxdb.Backfill) error {
+	return p.err
+}
+func (p *pipingCoordinator) BackfillCancelled(ctx context.Context, id influxdb.ID) error {
+	return p.err
+}
 
 type mockedSvc struct {
 	taskSvc           *mock.TaskService
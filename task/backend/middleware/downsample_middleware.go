@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb"
+)
+
+// CoordinatingDownsamplePolicyService acts as a DownsamplePolicyService decorator that handles coordinating
+// the api request with the required task control actions asynchronously via a message dispatcher
+type CoordinatingDownsamplePolicyService struct {
+	influxdb.DownsamplePolicyService
+	coordinator Coordinator
+	taskService influxdb.TaskService
+}
+
+// NewDownsamplePolicyService constructs a new coordinating downsample policy service
+func NewDownsamplePolicyService(ds influxdb.DownsamplePolicyService, ts influxdb.TaskService, coordinator Coordinator) *CoordinatingDownsamplePolicyService {
+	return &CoordinatingDownsamplePolicyService{
+		DownsamplePolicyService: ds,
+		taskService:             ts,
+		coordinator:             coordinator,
+	}
+}
+
+// CreateDownsamplePolicy creates a downsample policy and publishes the change so its task can be scheduled.
+func (ds *CoordinatingDownsamplePolicyService) CreateDownsamplePolicy(ctx context.Context, p *influxdb.DownsamplePolicy, userID influxdb.ID) error {
+	if err := ds.DownsamplePolicyService.CreateDownsamplePolicy(ctx, p, userID); err != nil {
+		return err
+	}
+
+	t, err := ds.taskService.FindTaskByID(ctx, p.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if err := ds.coordinator.TaskCreated(ctx, t); err != nil {
+		if derr := ds.DownsamplePolicyService.DeleteDownsamplePolicy(ctx, p.ID); derr != nil {
+			return fmt.Errorf("schedule task failed: %s\n\tcleanup also failed: %s", err, derr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDownsamplePolicy updates a downsample policy and publishes the change so the task owner can act on the update
+func (ds *CoordinatingDownsamplePolicyService) UpdateDownsamplePolicy(ctx context.Context, id influxdb.ID, upd influxdb.DownsamplePolicyUpdate) (*influxdb.DownsamplePolicy, error) {
+	from, err := ds.DownsamplePolicyService.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTask, err := ds.taskService.FindTaskByID(ctx, from.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := ds.DownsamplePolicyService.UpdateDownsamplePolicy(ctx, id, upd)
+	if err != nil {
+		return to, err
+	}
+
+	toTask, err := ds.taskService.FindTaskByID(ctx, to.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return to, ds.coordinator.TaskUpdated(ctx, fromTask, toTask)
+}
+
+// DeleteDownsamplePolicy deletes the downsample policy and publishes the change, to allow the task owner to find out about this change faster.
+func (ds *CoordinatingDownsamplePolicyService) DeleteDownsamplePolicy(ctx context.Context, id influxdb.ID) error {
+	p, err := ds.DownsamplePolicyService.FindDownsamplePolicyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := ds.coordinator.TaskDeleted(ctx, p.TaskID); err != nil {
+		return err
+	}
+
+	return ds.DownsamplePolicyService.DeleteDownsamplePolicy(ctx, id)
+}
+
+// CoordinatingBucketService acts as a BucketService decorator that publishes the deletion
+// of the tasks of the downsample policies that are deleted along with a bucket.
+type CoordinatingBucketService struct {
+	influxdb.BucketService
+	downsamplePolicyService influxdb.DownsamplePolicyService
+	coordinator             Coordinator
+}
+
+// NewBucketService constructs a new coordinating bucket service
+func NewBucketService(bs influxdb.BucketService, ds influxdb.DownsamplePolicyService, coordinator Coordinator) *CoordinatingBucketService {
+	return &CoordinatingBucketService{
+		BucketService:           bs,
+		downsamplePolicyService: ds,
+		coordinator:             coordinator,
+	}
+}
+
+// DeleteBucket deletes the bucket and publishes the deletion of the tasks of its downsample policies.
+func (bs *CoordinatingBucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
+	ps, _, err := bs.downsamplePolicyService.FindDownsamplePolicies(ctx, influxdb.DownsamplePolicyFilter{BucketID: &id})
+	if err != nil {
+		return err
+	}
+
+	if err := bs.BucketService.DeleteBucket(ctx, id); err != nil {
+		return err
+	}
+
+	for _, p := range ps {
+		if err := bs.coordinator.TaskDeleted(ctx, p.TaskID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/backend/middleware"
+)
+
+func TestDownsamplePolicyCreate(t *testing.T) {
+	mocks := newMockServices()
+	dsSvc := mock.NewDownsamplePolicyService()
+	dsSvc.CreateDownsamplePolicyFn = func(_ context.Context, p *influxdb.DownsamplePolicy, _ influxdb.ID) error {
+		p.ID = 1
+		p.TaskID = 4
+		return nil
+	}
+	policyService := middleware.NewDownsamplePolicyService(dsSvc, mocks.taskSvc, mocks.pipingCoordinator)
+	ch := mocks.pipingCoordinator.taskCreatedChan()
+
+	p := &influxdb.DownsamplePolicy{}
+	if err := policyService.CreateDownsamplePolicy(context.Background(), p, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case task := <-ch:
+		if task.ID != p.TaskID {
+			t.Fatalf("task sent to coordinator doesn't match expected")
+		}
+	default:
+		t.Fatal("didn't receive task")
+	}
+}
+
+func TestDownsamplePolicyDelete(t *testing.T) {
+	mocks := newMockServices()
+	dsSvc := mock.NewDownsamplePolicyService()
+	dsSvc.FindDownsamplePolicyByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.DownsamplePolicy, error) {
+		return &influxdb.DownsamplePolicy{ID: id, TaskID: 4}, nil
+	}
+	policyService := middleware.NewDownsamplePolicyService(dsSvc, mocks.taskSvc, mocks.pipingCoordinator)
+	ch := mocks.pipingCoordinator.taskDeletedChan()
+
+	if err := policyService.DeleteDownsamplePolicy(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case id := <-ch:
+		if id != 4 {
+			t.Fatalf("task sent to coordinator doesn't match expected")
+		}
+	default:
+		t.Fatal("didn't receive task")
+	}
+}
+
+func TestBucketDeleteWithDownsamplePolicies(t *testing.T) {
+	mocks := newMockServices()
+	dsSvc := mock.NewDownsamplePolicyService()
+	dsSvc.FindDownsamplePoliciesFn = func(_ context.Context, filter influxdb.DownsamplePolicyFilter) ([]*influxdb.DownsamplePolicy, int, error) {
+		return []*influxdb.DownsamplePolicy{{ID: 1, BucketID: *filter.BucketID, TaskID: 4}}, 1, nil
+	}
+	bucketSvc := mock.NewBucketService()
+	bucketService := middleware.NewBucketService(bucketSvc, dsSvc, mocks.pipingCoordinator)
+	ch := mocks.pipingCoordinator.taskDeletedChan()
+
+	if err := bucketService.DeleteBucket(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case id := <-ch:
+		if id != 4 {
+			t.Fatalf("task sent to coordinator doesn't match expected")
+		}
+	default:
+		t.Fatal("didn't receive task")
+	}
+}
@@ -17,6 +17,8 @@ type Coordinator interface {
 	RunCancelled(ctx context.Context, runID influxdb.ID) error
 	RunRetried(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error
 	RunForced(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error
+	BackfillCreated(ctx context.Context, task *influxdb.Task, b *influxdb.Backfill) error
+	BackfillCancelled(ctx context.Context, id influxdb.ID) error
 }
 
 // CoordinatingTaskService acts as a TaskService decorator that handles coordinating the api request
@@ -78,13 +80,30 @@ func (s *CoordinatingTaskService) UpdateTask(ctx context.Context, id influxdb.ID
 	return to, s.coordinator.TaskUpdated(ctx, from, to)
 }
 
+// RollbackTask rolls the task back to a revision and publishes the change like UpdateTask.
+func (s *CoordinatingTaskService) RollbackTask(ctx context.Context, taskID influxdb.ID, revision int, restoreStatus bool) (*influxdb.Task, error) {
+	from, err := s.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.TaskService.RollbackTask(ctx, taskID, revision, restoreStatus)
+	if err != nil {
+		return to, err
+	}
+
+	return to, s.coordinator.TaskUpdated(ctx, from, to)
+}
+
 // DeleteTask delete the task and publishes the change, to allow the task owner to find out about this change faster.
 func (s *CoordinatingTaskService) DeleteTask(ctx context.Context, id influxdb.ID) error {
-	if err := s.coordinator.TaskDeleted(ctx, id); err != nil {
+	// the task is deleted first, as deleting a task that other tasks depend on
+	// is refused and the task must keep running then.
+	if err := s.TaskService.DeleteTask(ctx, id); err != nil {
 		return err
 	}
 
-	return s.TaskService.DeleteTask(ctx, id)
+	return s.coordinator.TaskDeleted(ctx, id)
 }
 
 // CancelRun Cancel the run and publish the cancelation.
@@ -125,3 +144,28 @@ func (s *CoordinatingTaskService) ForceRun(ctx context.Context, taskID influxdb.
 
 	return r, s.coordinator.RunForced(ctx, t, r)
 }
+
+// BackfillTask creates the backfill in the task system and publishes it, to be run.
+func (s *CoordinatingTaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, bc influxdb.BackfillCreate) (*influxdb.Backfill, error) {
+	t, err := s.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.TaskService.BackfillTask(ctx, taskID, bc)
+	if err != nil {
+		return b, err
+	}
+
+	return b, s.coordinator.BackfillCreated(ctx, t, b)
+}
+
+// CancelBackfill cancels the backfill and publishes the cancelation.
+func (s *CoordinatingTaskService) CancelBackfill(ctx context.Context, taskID, id influxdb.ID) (*influxdb.Backfill, error) {
+	b, err := s.TaskService.CancelBackfill(ctx, taskID, id)
+	if err != nil {
+		return b, err
+	}
+
+	return b, s.coordinator.BackfillCancelled(ctx, id)
+}
@@ -37,3 +37,42 @@ func TestReadTable(t *testing.T) {
 		t.Fatalf("got error from iterator %v", itr.Err())
 	}
 }
+
+func TestReadTable_Attempts(t *testing.T) {
+	encoded := []byte(`group,false,false,true,true,false,true,false,false,false,false,false,false,false,false
+#datatype,string,long,dateTime:RFC3339,dateTime:RFC3339,dateTime:RFC3339,string,long,string,string,string,string,string,string,string
+#default,_result,,,,,,,,,,,,,
+,result,table,_start,_stop,_time,taskID,attempt,finishedAt,logs,retryOf,runID,scheduledFor,startedAt,status
+,,0,2019-07-23T20:06:24.369913228Z,2019-07-23T20:11:24.369913228Z,2019-07-23T20:06:30.232988837Z,0432e57782b51000,,2019-07-23T20:06:30.300005674Z,[],,04341baa937a1000,2019-07-23T20:06:30Z,2019-07-23T20:06:30.232988837Z,failed
+,,0,2019-07-23T20:06:24.369913228Z,2019-07-23T20:11:24.369913228Z,2019-07-23T20:06:40.215226536Z,0432e57782b51000,2,2019-07-23T20:06:40.284116882Z,[],04341baa937a1000,04341bb4543a1000,2019-07-23T20:06:30Z,2019-07-23T20:06:40.215226536Z,success`)
+
+	decoder := csv.NewMultiResultDecoder(csv.ResultDecoderConfig{})
+	itr, err := decoder.Decode(ioutil.NopCloser(bytes.NewReader(encoded)))
+	if err != nil {
+		t.Fatalf("got error decoding csv: %v", err)
+	}
+
+	defer itr.Release()
+	re := &runReader{log: zaptest.NewLogger(t)}
+
+	for itr.More() {
+		err := itr.Next().Tables().Do(re.readTable)
+		if err != nil {
+			t.Fatalf("received error in runs table: %v", err)
+		}
+	}
+
+	if itr.Err() != nil {
+		t.Fatalf("got error from iterator %v", itr.Err())
+	}
+
+	if len(re.runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(re.runs))
+	}
+	if first := re.runs[0]; first.Attempt != 0 || first.RetryOf.Valid() {
+		t.Fatalf("expected run without attempt, got attempt %d retrying %s", first.Attempt, first.RetryOf)
+	}
+	if retry := re.runs[1]; retry.Attempt != 2 || retry.RetryOf.String() != "04341baa937a1000" {
+		t.Fatalf("expected attempt 2 retrying 04341baa937a1000, got attempt %d retrying %s", retry.Attempt, retry.RetryOf)
+	}
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -52,7 +53,16 @@ type SchedulableService interface {
 	UpdateLastScheduled(ctx context.Context, id ID, t time.Time) error
 }
 
+// NewSchedule returns the schedule of unparsed in UTC, and lastScheduledAt
+// aligned to the schedule.
 func NewSchedule(unparsed string, lastScheduledAt time.Time) (Schedule, time.Time, error) {
+	return NewScheduleIn(unparsed, time.UTC, lastScheduledAt)
+}
+
+// NewScheduleIn is like NewSchedule, but the cron expressions of the schedule
+// trigger on the wall clock of loc. The @every schedules are not affected by
+// loc.
+func NewScheduleIn(unparsed string, loc *time.Location, lastScheduledAt time.Time) (Schedule, time.Time, error) {
 	lastScheduledAt = lastScheduledAt.UTC().Truncate(time.Second)
 	c, err := cron.ParseUTC(unparsed)
 	if err != nil {
@@ -68,31 +78,200 @@ func NewSchedule(unparsed string, lastScheduledAt time.Time) (Schedule, time.Tim
 		err := every.Parse(everyString)
 		if err != nil {
 			// We cannot align a invalid time
-			return Schedule{c}, lastScheduledAt, nil
+			return Schedule{cron: c}, lastScheduledAt, nil
 		}
 
 		// drop nanoseconds
 		lastScheduledAt = time.Unix(lastScheduledAt.UTC().Unix(), 0).UTC()
 		everyDur, err := every.DurationFrom(lastScheduledAt)
 		if err != nil {
-			return Schedule{c}, lastScheduledAt, nil
+			return Schedule{cron: c}, lastScheduledAt, nil
 		}
 
 		// and align
 		lastScheduledAt = lastScheduledAt.Truncate(everyDur).Truncate(time.Second)
+		return Schedule{cron: c}, lastScheduledAt, nil
 	}
 
-	return Schedule{c}, lastScheduledAt, err
+	if loc == time.UTC {
+		loc = nil
+	}
+	return Schedule{cron: c, loc: loc, hourly: hourly(unparsed)}, lastScheduledAt, err
+}
+
+// hourly returns true if the hour field of the cron expression unparsed is
+// a wildcard or a step, rather than fixed hours.
+func hourly(unparsed string) bool {
+	if strings.HasPrefix(unparsed, "@") {
+		return unparsed == "@hourly"
+	}
+
+	fields := strings.Fields(unparsed)
+	hour := 1
+	if len(fields) > 5 {
+		// the first field is the seconds.
+		hour = 2
+	}
+	if len(fields) <= hour {
+		return false
+	}
+	return strings.ContainsAny(fields[hour], "*?/")
 }
 
 // Schedule is an object a valid schedule of runs
 type Schedule struct {
 	cron cron.Parsed
+	// loc is the location of the wall clock the cron expression triggers on,
+	// UTC when nil.
+	loc *time.Location
+	// hourly is set when the cron expression triggers in every hour or in a
+	// step of hours, rather than in fixed hours.
+	hourly bool
 }
 
 // Next returns the next time after from that a schedule should trigger on.
+//
+// When the schedule has a location, the cron expression triggers on the wall
+// clock of the location. A time skipped by a daylight saving change triggers
+// once, at the change. A time repeated by a daylight saving change triggers
+// on both occurrences when the hours of the schedule are a wildcard or a
+// step, and once, on its first occurrence, when they are fixed.
 func (s Schedule) Next(from time.Time) (time.Time, error) {
-	return cron.Parsed(s.cron).Next(from)
+	if s.loc == nil {
+		return cron.Parsed(s.cron).Next(from)
+	}
+
+	wall := wallClock(from.In(s.loc))
+	for {
+		var err error
+		wall, err = cron.Parsed(s.cron).Next(wall)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if t, ok := s.instant(wall, from); ok {
+			if s.hourly {
+				return s.repeated(from, t)
+			}
+			return t, nil
+		}
+	}
+}
+
+// repeated returns the first time the schedule triggers on in the wall clock
+// repeated by a daylight saving change between from and next, or next if
+// there is no such change or time.
+func (s Schedule) repeated(from, next time.Time) (time.Time, error) {
+	_, before := from.In(s.loc).Zone()
+	_, after := next.In(s.loc).Zone()
+	if after >= before {
+		return next, nil
+	}
+
+	// the change is the first instant at the offset of next.
+	lo, hi := from, next
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, offset := mid.In(s.loc).Zone(); offset == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	// the wall clock goes back to the one shown at the change.
+	change := wallClock(hi.In(s.loc))
+	wall, err := cron.Parsed(s.cron).Next(change.Add(-time.Second))
+	if err != nil {
+		return time.Time{}, err
+	}
+	t := hi.Add(wall.Sub(change))
+	if t.Before(next) && wallClock(t.In(s.loc)).Equal(wall) {
+		return t.UTC(), nil
+	}
+	return next, nil
+}
+
+// instant returns the first instant after from when the wall clock of the
+// location of the schedule shows wall, or the daylight saving change that
+// skips it. It reports false when there is no such instant. A wall clock
+// repeated by a daylight saving change is only shown on its first occurrence
+// unless the schedule is hourly.
+func (s Schedule) instant(wall, from time.Time) (time.Time, bool) {
+	// the instants showing wall are at the offsets of the location around it.
+	var candidates []time.Time
+	for _, at := range []time.Time{wall.Add(-24 * time.Hour), wall, wall.Add(24 * time.Hour)} {
+		_, offset := at.In(s.loc).Zone()
+		candidates = append(candidates, wall.Add(-time.Duration(offset)*time.Second))
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	var shown bool
+	for _, t := range candidates {
+		if !wallClock(t.In(s.loc)).Equal(wall) {
+			continue
+		}
+		if t.After(from) && (!shown || s.hourly) {
+			return t.UTC(), true
+		}
+		shown = true
+	}
+	if shown {
+		// wall was shown before from.
+		return time.Time{}, false
+	}
+
+	// wall is skipped: the change is the first instant showing a later wall
+	// clock, between the first and last candidates.
+	lo, hi := candidates[0], candidates[len(candidates)-1]
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if wallClock(mid.In(s.loc)).Before(wall) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if !hi.After(from) {
+		return time.Time{}, false
+	}
+	return hi.UTC(), true
+}
+
+// wallClock returns the wall clock of t as a time in UTC.
+func wallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// Between returns the times the schedule unparsed triggers on from start,
+// inclusive, to stop, exclusive, stopping after limit times. The @every
+// schedules are aligned as by NewSchedule.
+func Between(unparsed string, start, stop time.Time, limit int) ([]time.Time, error) {
+	return BetweenIn(unparsed, time.UTC, start, stop, limit)
+}
+
+// BetweenIn is like Between, for the schedule unparsed on the wall clock of
+// loc as by NewScheduleIn.
+func BetweenIn(unparsed string, loc *time.Location, start, stop time.Time, limit int) ([]time.Time, error) {
+	// the schedule triggers on whole seconds, after the time it is given.
+	from := start.UTC().Add(time.Second - 1).Truncate(time.Second).Add(-time.Second)
+	s, next, err := NewScheduleIn(unparsed, loc, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var ts []time.Time
+	for len(ts) < limit {
+		next, err = s.Next(next)
+		if err != nil {
+			return nil, err
+		}
+		if !next.Before(stop) {
+			break
+		}
+		ts = append(ts, next)
+	}
+	return ts, nil
 }
 
 // ValidSchedule returns an error if the cron string is invalid.
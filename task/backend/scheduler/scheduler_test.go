@@ -666,3 +666,220 @@ func TestNewSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestBetween(t *testing.T) {
+	start := time.Date(2016, 01, 01, 01, 10, 23, 1234567, time.UTC)
+	tests := []struct {
+		name     string
+		unparsed string
+		start    time.Time
+		stop     time.Time
+		limit    int
+		want     []time.Time
+	}{
+		{
+			name:     "every aligned",
+			unparsed: "@every 1h",
+			start:    start,
+			stop:     start.Add(3 * time.Hour),
+			limit:    10,
+			want: []time.Time{
+				time.Date(2016, 01, 01, 02, 0, 0, 0, time.UTC),
+				time.Date(2016, 01, 01, 03, 0, 0, 0, time.UTC),
+				time.Date(2016, 01, 01, 04, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "start inclusive and stop exclusive",
+			unparsed: "@every 1h",
+			start:    time.Date(2016, 01, 01, 01, 0, 0, 0, time.UTC),
+			stop:     time.Date(2016, 01, 01, 03, 0, 0, 0, time.UTC),
+			limit:    10,
+			want: []time.Time{
+				time.Date(2016, 01, 01, 01, 0, 0, 0, time.UTC),
+				time.Date(2016, 01, 01, 02, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "cron",
+			unparsed: "30 * * * *",
+			start:    start,
+			stop:     start.Add(2 * time.Hour),
+			limit:    10,
+			want: []time.Time{
+				time.Date(2016, 01, 01, 01, 30, 0, 0, time.UTC),
+				time.Date(2016, 01, 01, 02, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "limit",
+			unparsed: "@every 1m",
+			start:    start,
+			stop:     start.Add(time.Hour),
+			limit:    2,
+			want: []time.Time{
+				time.Date(2016, 01, 01, 01, 11, 0, 0, time.UTC),
+				time.Date(2016, 01, 01, 01, 12, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "empty",
+			unparsed: "@every 1h",
+			start:    start,
+			stop:     start.Add(time.Minute),
+			limit:    10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.unparsed, tt.start, tt.stop, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Between() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetweenIn(t *testing.T) {
+	// In New York, the clocks went forward from 02:00 EST to 03:00 EDT on
+	// 2020-03-08, and back from 02:00 EDT to 01:00 EST on 2020-11-01.
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		unparsed string
+		start    time.Time
+		stop     time.Time
+		want     []time.Time
+	}{
+		{
+			name:     "daily on the wall clock",
+			unparsed: "0 9 * * *",
+			start:    time.Date(2020, 03, 07, 0, 0, 0, 0, loc),
+			stop:     time.Date(2020, 03, 10, 0, 0, 0, 0, loc),
+			want: []time.Time{
+				time.Date(2020, 03, 07, 14, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 13, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 9, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "skipped time triggers at the change",
+			unparsed: "30 2 * * *",
+			start:    time.Date(2020, 03, 07, 0, 0, 0, 0, loc),
+			stop:     time.Date(2020, 03, 10, 0, 0, 0, 0, loc),
+			want: []time.Time{
+				time.Date(2020, 03, 07, 7, 30, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 9, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "skipped times trigger once",
+			unparsed: "*/20 * * * *",
+			start:    time.Date(2020, 03, 8, 6, 40, 0, 0, time.UTC),
+			stop:     time.Date(2020, 03, 8, 7, 30, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 03, 8, 6, 40, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 7, 20, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated time triggers once",
+			unparsed: "30 1 * * *",
+			start:    time.Date(2020, 10, 31, 0, 0, 0, 0, loc),
+			stop:     time.Date(2020, 11, 03, 0, 0, 0, 0, loc),
+			want: []time.Time{
+				time.Date(2020, 10, 31, 5, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 5, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 02, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated hour is run twice",
+			unparsed: "30 * * * *",
+			start:    time.Date(2020, 11, 01, 4, 0, 0, 0, time.UTC),
+			stop:     time.Date(2020, 11, 01, 8, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 11, 01, 4, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 5, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 7, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated hour on the hour",
+			unparsed: "0 * * * *",
+			start:    time.Date(2020, 11, 01, 4, 0, 0, 0, time.UTC),
+			stop:     time.Date(2020, 11, 01, 8, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 11, 01, 4, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 5, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated hour every 15 minutes",
+			unparsed: "*/15 * * * *",
+			start:    time.Date(2020, 11, 01, 5, 30, 0, 0, time.UTC),
+			stop:     time.Date(2020, 11, 01, 7, 15, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 11, 01, 5, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 5, 45, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 15, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 30, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 45, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated hour with a step of hours",
+			unparsed: "0 1/2 * * *",
+			start:    time.Date(2020, 11, 01, 4, 0, 0, 0, time.UTC),
+			stop:     time.Date(2020, 11, 01, 9, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 11, 01, 5, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 6, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 01, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "repeated time after its first occurrence",
+			unparsed: "30 1 * * *",
+			start:    time.Date(2020, 11, 01, 6, 20, 0, 0, time.UTC),
+			stop:     time.Date(2020, 11, 01, 7, 0, 0, 0, time.UTC),
+			want:     nil,
+		},
+		{
+			name:     "every is not on the wall clock",
+			unparsed: "@every 1h",
+			start:    time.Date(2020, 03, 8, 6, 0, 0, 0, time.UTC),
+			stop:     time.Date(2020, 03, 8, 9, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2020, 03, 8, 6, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 7, 0, 0, 0, time.UTC),
+				time.Date(2020, 03, 8, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BetweenIn(tt.unparsed, loc, tt.start, tt.stop, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BetweenIn() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,9 @@ type TaskControlService interface {
 	// StartManualRun pulls a manual run from the list and moves it to currently running.
 	StartManualRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
 
+	// QueueRetry adds the next attempt of the run to the manual runs, to be executed at runAt.
+	QueueRetry(ctx context.Context, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error)
+
 	// FinishRun removes runID from the list of running tasks and if its `ScheduledFor` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
 
@@ -28,4 +31,7 @@ type TaskControlService interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+
+	// UpdateBackfill records the progress of a backfill.
+	UpdateBackfill(ctx context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error)
 }
@@ -0,0 +1,108 @@
+// Package downsample generates the flux scripts of the tasks that carry out
+// bucket downsampling policies.
+package downsample
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/notification/flux"
+)
+
+// typesPackage is the flux package of the isType function used to route the
+// tables of each field type to its aggregate.
+const typesPackage = "influxdata/influxdb/types"
+
+// GenerateFlux returns the flux script of the task that carries out the policy.
+func GenerateFlux(p *influxdb.DownsamplePolicy) (string, error) {
+	if err := p.Valid(); err != nil {
+		return "", err
+	}
+	return ast.Format(GenerateFluxAST(p).Files[0]), nil
+}
+
+// GenerateFluxAST returns the flux AST of the task that carries out the policy.
+// Each window of the source bucket is read once and the tables of every field
+// type that has an aggregate are aggregated and written to the destination
+// bucket.
+func GenerateFluxAST(p *influxdb.DownsamplePolicy) *ast.Package {
+	every := p.Every.Node
+
+	data := flux.Pipe(
+		flux.Call(flux.Identifier("from"), flux.Object(
+			flux.Property("bucketID", flux.String(p.BucketID.String())),
+		)),
+		flux.Call(flux.Identifier("range"), flux.Object(
+			flux.Property("start", flux.Negative(&every)),
+		)),
+	)
+	if len(p.TagFilter) > 0 {
+		var pred ast.Expression
+		for _, t := range p.TagFilter {
+			eq := flux.Equal(&ast.MemberExpression{
+				Object:   flux.Identifier("r"),
+				Property: flux.LiteralString(t.Key),
+			}, flux.LiteralString(t.Value))
+			if pred == nil {
+				pred = eq
+			} else {
+				pred = flux.And(pred, eq)
+			}
+		}
+		data = flux.Pipe(data, flux.Call(flux.Identifier("filter"), flux.Object(
+			flux.Property("fn", flux.Function(flux.FunctionParams("r"), pred)),
+		)))
+	}
+
+	body := []ast.Statement{
+		flux.DefineTaskOption(flux.Object(
+			flux.Property("name", flux.String(fmt.Sprintf("Downsample %s to %s", p.BucketID, p.DestinationBucketID))),
+			flux.Property("every", &every),
+		)),
+		flux.DefineVariable("data", data),
+	}
+
+	aggregates := []struct {
+		fluxType  string
+		aggregate string
+	}{
+		{fluxType: "float", aggregate: p.Aggregates.Float},
+		{fluxType: "int", aggregate: p.Aggregates.Integer},
+		{fluxType: "uint", aggregate: p.Aggregates.Unsigned},
+		{fluxType: "string", aggregate: p.Aggregates.String},
+		{fluxType: "bool", aggregate: p.Aggregates.Boolean},
+	}
+	for _, a := range aggregates {
+		if a.aggregate == "" {
+			continue
+		}
+		body = append(body, flux.ExpressionStatement(flux.Pipe(
+			flux.Identifier("data"),
+			flux.Call(flux.Identifier("filter"), flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"),
+					flux.Call(flux.Member("types", "isType"), flux.Object(
+						flux.Property("v", flux.Member("r", "_value")),
+						flux.Property("type", flux.String(a.fluxType)),
+					)),
+				)),
+			)),
+			flux.Call(flux.Identifier("aggregateWindow"), flux.Object(
+				flux.Property("every", &every),
+				flux.Property("fn", flux.Identifier(a.aggregate)),
+				flux.Property("createEmpty", flux.Bool(false)),
+			)),
+			flux.Call(flux.Identifier("to"), flux.Object(
+				flux.Property("bucketID", flux.String(p.DestinationBucketID.String())),
+				flux.Property("orgID", flux.String(p.OrgID.String())),
+			)),
+		)))
+	}
+
+	return &ast.Package{
+		Package: "main",
+		Files: []*ast.File{
+			flux.File("", flux.Imports(typesPackage), body),
+		},
+	}
+}
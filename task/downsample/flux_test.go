@@ -0,0 +1,60 @@
+package downsample_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxdb"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/task/downsample"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestGenerateFlux(t *testing.T) {
+	p := &influxdb.DownsamplePolicy{
+		OrgID:               1,
+		BucketID:            2,
+		DestinationBucketID: 3,
+		Every:               *options.MustParseDuration("1h"),
+		Aggregates:          influxdb.DownsampleAggregates{Float: "mean", String: "last"},
+		TagFilter: []influxdb.Tag{
+			{Key: "host", Value: `a${b}"c`},
+			{Key: "region", Value: "west"},
+		},
+	}
+
+	got, err := downsample.GenerateFlux(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `import "influxdata/influxdb/types"
+
+option task = {name: "Downsample 0000000000000002 to 0000000000000003", every: 1h}
+
+data = from(bucketID: "0000000000000002")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r["host"] == "a\${b}\"c" and r["region"] == "west"))
+
+data
+	|> filter(fn: (r) =>
+		(types.isType(v: r._value, type: "float")))
+	|> aggregateWindow(every: 1h, fn: mean, createEmpty: false)
+	|> to(bucketID: "0000000000000003", orgID: "0000000000000001")
+data
+	|> filter(fn: (r) =>
+		(types.isType(v: r._value, type: "string")))
+	|> aggregateWindow(every: 1h, fn: last, createEmpty: false)
+	|> to(bucketID: "0000000000000003", orgID: "0000000000000001")`
+	if got != want {
+		t.Fatalf("unexpected script:\n%s\n\nwant:\n%s", got, want)
+	}
+
+	if _, err := options.FromScript(got); err != nil {
+		t.Fatalf("script options are invalid: %v", err)
+	}
+	if pkg := parser.ParseSource(got); len(pkg.Files[0].Body) != 4 {
+		t.Fatalf("expected 4 statements in the script, got %d", len(pkg.Files[0].Body))
+	}
+}
@@ -101,6 +101,14 @@ func (e *Executor) Execute(ctx context.Context, id scheduler.ID, scheduledAt tim
 	return nil
 }
 
+func (e *Executor) PromisedExecute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) (executor.Promise, error) {
+	if err := e.Execute(ctx, id, scheduledFor, runAt); err != nil {
+		return nil, err
+	}
+	run := &influxdb.Run{TaskID: influxdb.ID(id), ScheduledFor: scheduledFor, RunAt: runAt}
+	return e.createPromise(ctx, run)
+}
+
 func (e *Executor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
 	run := &influxdb.Run{ID: runID, TaskID: id, StartedAt: time.Now().UTC()}
 	p, err := e.createPromise(ctx, run)
@@ -115,6 +123,14 @@ func (e *Executor) Cancel(context.Context, influxdb.ID) error {
 	return nil
 }
 
+func (e *Executor) Backfill(context.Context, *influxdb.Task, *influxdb.Backfill) error {
+	return nil
+}
+
+func (e *Executor) CancelBackfill(context.Context, influxdb.ID) error {
+	return nil
+}
+
 // FailNextCallToExecute causes the next call to e.Execute to unconditionally return err.
 func (e *Executor) FailNextCallToExecute(err error) {
 	e.mu.Lock()
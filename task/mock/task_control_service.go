@@ -29,6 +29,8 @@ type TaskControlService struct {
 	// Map of task ID to total number of runs created for that task.
 	totalRunsCreated map[influxdb.ID]int
 	finishedRuns     map[influxdb.ID]*influxdb.Run
+	// Map of backfill ID to the progress recorded for it.
+	backfills map[influxdb.ID]*influxdb.Backfill
 }
 
 var _ backend.TaskControlService = (*TaskControlService)(nil)
@@ -40,6 +42,7 @@ func NewTaskControlService() *TaskControlService {
 		tasks:            make(map[influxdb.ID]*influxdb.Task),
 		created:          make(map[string]*influxdb.Run),
 		totalRunsCreated: make(map[influxdb.ID]int),
+		backfills:        make(map[influxdb.ID]*influxdb.Backfill),
 	}
 }
 
@@ -90,6 +93,28 @@ func (t *TaskControlService) StartManualRun(_ context.Context, taskID, runID inf
 	return run, nil
 }
 
+func (t *TaskControlService) QueueRetry(_ context.Context, taskID, runID influxdb.ID, runAt time.Time) (*influxdb.Run, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r, ok := t.runs[taskID][runID]
+	if !ok {
+		return nil, influxdb.ErrRunNotFound
+	}
+	retry := *r
+	if retry.Attempt < 1 {
+		retry.Attempt = 1
+	}
+	retry.Attempt++
+	retry.RetryOf = r.ID
+	retry.ID = idgen.ID()
+	retry.TaskID = taskID
+	retry.RunAt = runAt
+	retry.Status = influxdb.RunScheduled.String()
+	t.manualRuns = append(t.manualRuns, &retry)
+	return &retry, nil
+}
+
 func (d *TaskControlService) FinishRun(_ context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -165,6 +190,42 @@ func (d *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influx
 	return nil
 }
 
+// UpdateBackfill records the progress of a backfill.
+func (d *TaskControlService) UpdateBackfill(ctx context.Context, taskID, id influxdb.ID, upd influxdb.BackfillUpdate) (*influxdb.Backfill, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b, ok := d.backfills[id]
+	if !ok {
+		b = &influxdb.Backfill{ID: id, TaskID: taskID, Status: influxdb.BackfillRunning}
+		d.backfills[id] = b
+	}
+	if upd.Succeeded != nil {
+		b.Succeeded = *upd.Succeeded
+	}
+	if upd.Failed != nil {
+		b.Failed = *upd.Failed
+	}
+	if upd.Status != nil && b.Running() {
+		b.Status = *upd.Status
+	}
+	cp := *b
+	return &cp, nil
+}
+
+// Backfill returns the progress recorded for the backfill, or nil.
+func (d *TaskControlService) Backfill(id influxdb.ID) *influxdb.Backfill {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b, ok := d.backfills[id]
+	if !ok {
+		return nil
+	}
+	cp := *b
+	return &cp
+}
+
 func (d *TaskControlService) CreatedFor(taskID influxdb.ID) []*influxdb.Run {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -37,7 +37,14 @@ type Options struct {
 
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
+	// Retry is the number of attempts at a run before it is left failed.
+	// The failed runs are retried with a backoff.
 	Retry *int64 `json:"retry,omitempty"`
+
+	// Location is the IANA time zone whose wall clock Cron is evaluated on,
+	// UTC when not set. It requires Cron. An empty location in a task update
+	// removes the option.
+	Location *string `json:"location,omitempty"`
 }
 
 // Duration is a time span that supports the same units as the flux parser's time duration, as well as negative length time spans.
@@ -127,6 +134,7 @@ func (o *Options) Clear() {
 	o.Offset = nil
 	o.Concurrency = nil
 	o.Retry = nil
+	o.Location = nil
 }
 
 // IsZero tells us if the options has been zeroed out.
@@ -136,7 +144,8 @@ func (o *Options) IsZero() bool {
 		o.Every.IsZero() &&
 		(o.Offset == nil || o.Offset.IsZero()) &&
 		o.Concurrency == nil &&
-		o.Retry == nil
+		o.Retry == nil &&
+		o.Location == nil
 }
 
 // All the task option names we accept.
@@ -147,6 +156,7 @@ const (
 	optOffset      = "offset"
 	optConcurrency = "concurrency"
 	optRetry       = "retry"
+	optLocation    = "location"
 )
 
 // contains is a helper function to see if an array of strings contains a string
@@ -313,6 +323,13 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = pointer.Int64(retryVal.Int())
 	}
 
+	if locationVal, ok := optObject.Get(optLocation); ok {
+		if err := checkNature(locationVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.Location = pointer.String(locationVal.Str())
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -373,6 +390,16 @@ func (o *Options) Validate() error {
 			errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 		}
 	}
+	if loc := o.LocationName(); loc != "" {
+		if !cronPresent {
+			errs = append(errs, "location option requires the cron option")
+		} else if loc == "Local" {
+			// the schedule must not depend on the time zone of the server.
+			errs = append(errs, "location Local is not supported, use an IANA time zone")
+		} else if _, err := time.LoadLocation(loc); err != nil {
+			errs = append(errs, "location invalid: "+err.Error())
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -381,6 +408,14 @@ func (o *Options) Validate() error {
 	return fmt.Errorf("invalid options: %s", strings.Join(errs, ", "))
 }
 
+// LocationName returns the location option, empty when it is not set.
+func (o *Options) LocationName() string {
+	if o.Location == nil {
+		return ""
+	}
+	return *o.Location
+}
+
 // EffectiveCronString returns the effective cron string of the options.
 // If the cron option was specified, it is returned.
 // If the every option was specified, it is converted into a cron string using "@every".
@@ -414,7 +449,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optLocation:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -423,7 +458,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optLocation}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
@@ -33,6 +33,9 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Retry != nil && *opt.Retry != 0 {
 		taskData = fmt.Sprintf("%s  retry: %d,\n", taskData, *opt.Retry)
 	}
+	if opt.Location != nil {
+		taskData = fmt.Sprintf("%s  location: %q,\n", taskData, *opt.Location)
+	}
 	if body == "" {
 		body = `from(bucket: "test")
     |> range(start:-1h)`
@@ -70,6 +73,8 @@ func TestFromScript(t *testing.T) {
 				Offset:      options.MustParseDuration("-1m")}},
 		{script: scriptGenerator(options.Options{Name: "name1", Every: *(options.MustParseDuration("5s"))}, ""), exp: options.Options{Name: "name1", Every: *(options.MustParseDuration("5s")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
 		{script: scriptGenerator(options.Options{Name: "name2", Cron: "* * * * *"}, ""), exp: options.Options{Name: "name2", Cron: "* * * * *", Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: scriptGenerator(options.Options{Name: "name2", Cron: "0 2 * * *", Location: pointer.String("Europe/Berlin")}, ""), exp: options.Options{Name: "name2", Cron: "0 2 * * *", Location: pointer.String("Europe/Berlin"), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1)}},
+		{script: scriptGenerator(options.Options{Name: "name2", Cron: "0 2 * * *", Location: pointer.String("Mars/Olympus_Mons")}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name3", Every: *(options.MustParseDuration("1h")), Cron: "* * * * *"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name4", Concurrency: pointer.Int64(1000), Every: *(options.MustParseDuration("1h"))}, ""), shouldErr: true},
 		{script: "option task = {\n  name: \"name5\",\n  concurrency: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
@@ -146,7 +151,7 @@ func TestFromScriptWithUnknownOptions(t *testing.T) {
 		t.Errorf("expected error to mention unrecognized options, but it said: %v", err)
 	}
 
-	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry"}
+	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry", "location"}
 	for _, o := range validOpts {
 		if !strings.Contains(msg, o) {
 			t.Errorf("expected error to mention valid option %q but it said: %v", o, err)
@@ -222,6 +227,26 @@ func TestValidate(t *testing.T) {
 		t.Error("expected error for retry too large")
 	}
 
+	*bad = good
+	bad.Location = pointer.String("Nowhere/Special")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for invalid location")
+	}
+
+	*bad = good
+	bad.Location = pointer.String("Local")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for the location of the server")
+	}
+
+	*bad = good
+	bad.Cron = ""
+	bad.Every = *options.MustParseDuration("1h")
+	bad.Location = pointer.String("America/New_York")
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for location without cron")
+	}
+
 	notbad := new(options.Options)
 	*notbad = good
 	notbad.Cron = ""
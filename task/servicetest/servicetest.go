@@ -19,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
 	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/pkg/pointer"
 	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/options"
 )
@@ -98,6 +99,21 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory, testCategory ...s
 					testTaskType(t, sys)
 				})
 
+				t.Run("Task Backfills", func(t *testing.T) {
+					t.Parallel()
+					testBackfills(t, sys)
+				})
+
+				t.Run("Task Dependencies", func(t *testing.T) {
+					t.Parallel()
+					testTaskDependencies(t, sys)
+				})
+
+				t.Run("Task Revisions", func(t *testing.T) {
+					t.Parallel()
+					testTaskRevisions(t, sys)
+				})
+
 			})
 		case "analytical":
 			t.Run("AnalyticalTaskService", func(t *testing.T) {
@@ -697,6 +713,48 @@ from(bucket: "b")
 			t.Fatal("removing offset failed")
 		}
 	})
+	t.Run("update task with cron and location options", func(t *testing.T) {
+		f, err := sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Options: options.Options{Cron: "0 2 * * *", Location: pointer.String("Europe/Berlin")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		savedTask, err := sys.TaskService.FindTaskByID(sys.Ctx, f.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if savedTask.Cron != "0 2 * * *" || savedTask.Every != "" || savedTask.Location != "Europe/Berlin" {
+			t.Fatalf("expected cron in location, got cron %q, every %q, location %q", savedTask.Cron, savedTask.Every, savedTask.Location)
+		}
+		if loc, err := savedTask.ScheduleLocation(); err != nil || loc.String() != "Europe/Berlin" {
+			t.Fatalf("unexpected schedule location %v: %v", loc, err)
+		}
+	})
+	t.Run("update task to the location of the server", func(t *testing.T) {
+		if _, err := sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Options: options.Options{Location: pointer.String("Local")}}); err == nil {
+			t.Fatal("expected error updating the location to the location of the server")
+		}
+	})
+	t.Run("update task and delete location", func(t *testing.T) {
+		f, err := sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Options: options.Options{Location: pointer.String("")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Cron != "0 2 * * *" || f.Location != "" || strings.Contains(f.Flux, "location") {
+			t.Fatalf("expected cron without location, got cron %q, location %q, flux %q", f.Cron, f.Location, f.Flux)
+		}
+	})
+	t.Run("update task from cron in location to every", func(t *testing.T) {
+		if _, err := sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Options: options.Options{Location: pointer.String("Europe/Berlin")}}); err != nil {
+			t.Fatal(err)
+		}
+		f, err := sys.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Options: options.Options{Every: *(options.MustParseDuration("10s"))}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Every != "10s" || f.Cron != "" || f.Location != "" || strings.Contains(f.Flux, "location") {
+			t.Fatalf("expected every without location, got every %q, cron %q, location %q, flux %q", f.Every, f.Cron, f.Location, f.Flux)
+		}
+	})
 
 }
 
@@ -1311,6 +1369,300 @@ func testManualRun(t *testing.T, s *System) {
 	}
 }
 
+func testBackfills(t *testing.T, s *System) {
+	cr := creds(t, s)
+
+	tc := influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           fmt.Sprintf(scriptFmt, 0),
+		OwnerID:        cr.UserID,
+	}
+
+	authorizedCtx := icontext.SetAuthorizer(s.Ctx, cr.Authorizer())
+
+	tsk, err := s.TaskService.CreateTask(authorizedCtx, tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, time.March, 1, 0, 0, 30, 0, time.UTC)
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, bc := range []influxdb.BackfillCreate{
+			{Start: start},
+			{Start: start, Stop: start.Add(-time.Hour)},
+			{Start: start, Stop: start.Add(time.Hour), MaxConcurrency: influxdb.MaxBackfillConcurrency + 1},
+			// no schedule point in the range.
+			{Start: start, Stop: start.Add(time.Second)},
+			// too many schedule points in the range.
+			{Start: start, Stop: start.Add((influxdb.MaxBackfillRuns + 1) * time.Minute)},
+		} {
+			if _, err := s.TaskService.BackfillTask(authorizedCtx, tsk.ID, bc); influxdb.ErrorCode(err) != influxdb.EInvalid {
+				t.Errorf("expected invalid error backfilling %s to %s, got %v", bc.Start, bc.Stop, err)
+			}
+		}
+	})
+
+	b, err := s.TaskService.BackfillTask(authorizedCtx, tsk.ID, influxdb.BackfillCreate{Start: start, Stop: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Total != 60 || b.MaxConcurrency != influxdb.DefaultBackfillConcurrency || b.Status != influxdb.BackfillRunning {
+		t.Fatalf("unexpected backfill created: %+v", b)
+	}
+
+	succeeded, failed := 10, 1
+	if _, err := s.TaskControlService.UpdateBackfill(authorizedCtx, tsk.ID, b.ID, influxdb.BackfillUpdate{Succeeded: &succeeded, Failed: &failed}); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.TaskService.FindBackfillByID(authorizedCtx, tsk.ID, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Succeeded != succeeded || found.Failed != failed {
+		t.Fatalf("expected progress of %d succeeded and %d failed runs, got %d and %d", succeeded, failed, found.Succeeded, found.Failed)
+	}
+
+	canceled, err := s.TaskService.CancelBackfill(authorizedCtx, tsk.ID, b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != influxdb.BackfillCanceled || canceled.FinishedAt == nil {
+		t.Fatalf("expected backfill to be canceled, got %+v", canceled)
+	}
+	if _, err := s.TaskService.CancelBackfill(authorizedCtx, tsk.ID, b.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected conflict canceling a canceled backfill, got %v", err)
+	}
+
+	// the executor completing a canceled backfill leaves it canceled.
+	completed := influxdb.BackfillCompleted
+	updated, err := s.TaskControlService.UpdateBackfill(authorizedCtx, tsk.ID, b.ID, influxdb.BackfillUpdate{Status: &completed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != influxdb.BackfillCanceled {
+		t.Fatalf("expected backfill to stay canceled, got %s", updated.Status)
+	}
+
+	second, err := s.TaskService.BackfillTask(authorizedCtx, tsk.ID, influxdb.BackfillCreate{Start: start, Stop: start.Add(time.Hour), MaxConcurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, n, err := s.TaskService.FindBackfills(authorizedCtx, tsk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || bs[0].ID != second.ID || bs[1].ID != b.ID {
+		t.Fatalf("expected the 2 backfills of the task, the most recent first, got %d", n)
+	}
+
+	if err := s.TaskService.DeleteTask(authorizedCtx, tsk.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.TaskService.FindBackfillByID(authorizedCtx, tsk.ID, second.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected backfill to be deleted with its task, got %v", err)
+	}
+}
+
+func testTaskDependencies(t *testing.T, s *System) {
+	cr := creds(t, s)
+	authorizedCtx := icontext.SetAuthorizer(s.Ctx, cr.Authorizer())
+
+	create := func(dependsOn ...influxdb.ID) (*influxdb.Task, error) {
+		return s.TaskService.CreateTask(authorizedCtx, influxdb.TaskCreate{
+			OrganizationID: cr.OrgID,
+			Flux:           fmt.Sprintf(scriptFmt, 0),
+			OwnerID:        cr.UserID,
+			DependsOn:      dependsOn,
+		})
+	}
+
+	raw, err := create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollup, err := create(raw.ID, raw.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]influxdb.ID{raw.ID}, rollup.DependsOn); diff != "" {
+		t.Fatalf("unexpected upstream tasks of created task: %s", diff)
+	}
+
+	found, err := s.TaskService.FindTaskByID(authorizedCtx, rollup.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]influxdb.ID{raw.ID}, found.DependsOn); diff != "" {
+		t.Fatalf("unexpected upstream tasks of found task: %s", diff)
+	}
+
+	if _, err := create(influxdb.ID(1)); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error depending on a missing task, got %v", err)
+	}
+
+	// the runs of a task every 7m never trigger the schedule points of a task
+	// every minute.
+	sevenMinutes := `option task = {name: "every 7m", every: 7m}
+
+from(bucket:"b")
+	|> to(bucket: "two", orgID: "000000000000000")`
+	sparse, err := s.TaskService.CreateTask(authorizedCtx, influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           sevenMinutes,
+		OwnerID:        cr.UserID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create(sparse.ID); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error depending on a task whose schedule does not align, got %v", err)
+	}
+	if _, err := s.TaskService.UpdateTask(authorizedCtx, raw.ID, influxdb.TaskUpdate{Flux: &sevenMinutes}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error changing the schedule of an upstream task so that it does not align, got %v", err)
+	}
+	if err := s.TaskService.DeleteTask(authorizedCtx, sparse.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// raw -> rollup -> raw
+	if _, err := s.TaskService.UpdateTask(authorizedCtx, raw.ID, influxdb.TaskUpdate{DependsOn: &[]influxdb.ID{rollup.ID}}); err != influxdb.ErrTaskDependencyCycle {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if _, err := s.TaskService.UpdateTask(authorizedCtx, raw.ID, influxdb.TaskUpdate{DependsOn: &[]influxdb.ID{raw.ID}}); err != influxdb.ErrTaskDependencyCycle {
+		t.Fatalf("expected cycle error depending on the task itself, got %v", err)
+	}
+
+	if err := s.TaskService.DeleteTask(authorizedCtx, raw.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected conflict deleting a task other tasks depend on, got %v", err)
+	}
+
+	updated, err := s.TaskService.UpdateTask(authorizedCtx, rollup.ID, influxdb.TaskUpdate{DependsOn: &[]influxdb.ID{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.DependsOn) != 0 {
+		t.Fatalf("expected upstream tasks to be removed, got %v", updated.DependsOn)
+	}
+	if err := s.TaskService.DeleteTask(authorizedCtx, raw.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testTaskRevisions(t *testing.T, s *System) {
+	cr := creds(t, s)
+	authorizedCtx := icontext.SetAuthorizer(s.Ctx, cr.Authorizer())
+
+	task, err := s.TaskService.CreateTask(authorizedCtx, influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           fmt.Sprintf(scriptFmt, 0),
+		OwnerID:        cr.UserID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newFlux := fmt.Sprintf(scriptFmt, 1)
+	inactive := string(influxdb.TaskInactive)
+	if _, err := s.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Flux: &newFlux, Status: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+
+	// updates of the state of the task are not revisions.
+	latestCompleted := time.Now().UTC()
+	if _, err := s.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{LatestCompleted: &latestCompleted}); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, n, err := s.TaskService.FindTaskRevisions(authorizedCtx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 revisions, got %d", n)
+	}
+	if revisions[0].Revision != 2 || revisions[0].Flux != newFlux {
+		t.Fatalf("expected the update as the most recent revision, got %+v", revisions[0])
+	}
+	first := revisions[1]
+	if first.Revision != 1 || first.Flux != task.Flux || first.Status != task.Status || first.Every != task.Every {
+		t.Fatalf("expected the created task as the first revision, got %+v", first)
+	}
+	if first.AuthorID != cr.UserID {
+		t.Fatalf("expected revision authored by %s, got %s", cr.UserID, first.AuthorID)
+	}
+	if first.CreatedAt.IsZero() {
+		t.Fatal("expected revision creation time to be set")
+	}
+
+	found, err := s.TaskService.FindTaskRevision(authorizedCtx, task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(first, found); diff != "" {
+		t.Fatalf("unexpected revision found: %s", diff)
+	}
+
+	rolledBack, err := s.TaskService.RollbackTask(authorizedCtx, task.ID, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Flux != task.Flux {
+		t.Fatalf("expected flux of the first revision after rollback, got %q", rolledBack.Flux)
+	}
+	if rolledBack.Status != inactive {
+		t.Fatalf("expected status to be left %q by the rollback, got %q", inactive, rolledBack.Status)
+	}
+
+	revisions, n, err = s.TaskService.FindTaskRevisions(authorizedCtx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || revisions[0].Revision != 3 || revisions[0].Flux != task.Flux {
+		t.Fatalf("expected the rollback as a new revision, got %d revisions, most recent %+v", n, revisions[0])
+	}
+
+	rolledBack, err = s.TaskService.RollbackTask(authorizedCtx, task.ID, 1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Status != task.Status {
+		t.Fatalf("expected status of the first revision restored, got %q", rolledBack.Status)
+	}
+
+	if _, err := s.TaskService.RollbackTask(authorizedCtx, task.ID, 5, false); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected not found rolling back to a missing revision, got %v", err)
+	}
+
+	// only the most recent revisions are kept.
+	for i := 0; i < influxdb.MaxTaskRevisions; i++ {
+		description := fmt.Sprintf("description #%d", i)
+		if _, err := s.TaskService.UpdateTask(authorizedCtx, task.ID, influxdb.TaskUpdate{Description: &description}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	revisions, n, err = s.TaskService.FindTaskRevisions(authorizedCtx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := 4 + influxdb.MaxTaskRevisions
+	if n != influxdb.MaxTaskRevisions || revisions[0].Revision != latest || revisions[n-1].Revision != latest-influxdb.MaxTaskRevisions+1 {
+		t.Fatalf("expected revisions %d to %d, got %d revisions", latest-influxdb.MaxTaskRevisions+1, latest, n)
+	}
+	if _, err := s.TaskService.FindTaskRevision(authorizedCtx, task.ID, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected the first revision to be deleted, got %v", err)
+	}
+
+	if err := s.TaskService.DeleteTask(authorizedCtx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.TaskService.FindTaskRevision(authorizedCtx, task.ID, 1); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected revisions to be deleted with the task, got %v", err)
+	}
+}
+
 func testRunStorage(t *testing.T, sys *System) {
 	cr := creds(t, sys)
 
@@ -78,6 +78,13 @@ var (
 		Msg:  "run limit is out of bounds, must be between 1 and 500",
 	}
 
+	// ErrTaskDependencyCycle is returned when the upstream tasks of a task
+	// depend on the task.
+	ErrTaskDependencyCycle = &Error{
+		Code: EInvalid,
+		Msg:  "task dependencies form a cycle",
+	}
+
 	// ErrInvalidOwnerID is called when trying to create a task with out a valid ownerID
 	ErrInvalidOwnerID = &Error{
 		Code: EInvalid,
@@ -171,6 +178,24 @@ func ErrRunExecutionError(err error) *Error {
 	}
 }
 
+// ErrInvalidTaskDependency is returned when an upstream task of a task is
+// not a task of its organization.
+func ErrInvalidTaskDependency(id ID, msg string) *Error {
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid upstream task %s: %s", id, msg),
+	}
+}
+
+// ErrTaskHasDependents is returned when deleting a task that other tasks
+// depend on.
+func ErrTaskHasDependents(id ID) *Error {
+	return &Error{
+		Code: EConflict,
+		Msg:  fmt.Sprintf("task %s depends on the task", id),
+	}
+}
+
 func ErrTaskConcurrencyLimitReached(runsInFront int) *Error {
 	return &Error{
 		Code: ETooManyRequests,
@@ -0,0 +1,68 @@
+package influxdb
+
+import "time"
+
+// ops for task revision errors.
+var (
+	OpFindTaskRevisions = "FindTaskRevisions"
+	OpFindTaskRevision  = "FindTaskRevision"
+	OpRollbackTask      = "RollbackTask"
+)
+
+// MaxTaskRevisions is the number of the most recent revisions of a task that
+// are kept, the earlier revisions are deleted.
+const MaxTaskRevisions = 100
+
+// TaskRevision is the state of a task recorded when it was created or updated.
+// The revisions are never updated.
+type TaskRevision struct {
+	TaskID ID `json:"taskID"`
+	// Revision numbers the revisions of a task from 1, and identifies the
+	// revision among the revisions of the task.
+	Revision    int           `json:"revision"`
+	Flux        string        `json:"flux"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Status      string        `json:"status"`
+	Every       string        `json:"every,omitempty"`
+	Cron        string        `json:"cron,omitempty"`
+	Location    string        `json:"location,omitempty"`
+	Offset      time.Duration `json:"offset,omitempty"`
+	DependsOn   []ID          `json:"dependsOn,omitempty"`
+	// AuthorID is the user that created or updated the task.
+	AuthorID  ID        `json:"authorID,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewTaskRevision returns the revision of the task as it is.
+func NewTaskRevision(t *Task) *TaskRevision {
+	return &TaskRevision{
+		TaskID:      t.ID,
+		Flux:        t.Flux,
+		Name:        t.Name,
+		Description: t.Description,
+		Status:      t.Status,
+		Every:       t.Every,
+		Cron:        t.Cron,
+		Location:    t.Location,
+		Offset:      t.Offset,
+		DependsOn:   t.DependsOn,
+	}
+}
+
+// TaskUpdate returns the update restoring the task to the revision. The
+// status of the task is left unchanged unless restoreStatus is set.
+func (r *TaskRevision) TaskUpdate(restoreStatus bool) TaskUpdate {
+	flux, description := r.Flux, r.Description
+	dependsOn := append([]ID{}, r.DependsOn...)
+	upd := TaskUpdate{
+		Flux:        &flux,
+		Description: &description,
+		DependsOn:   &dependsOn,
+	}
+	if restoreStatus {
+		status := r.Status
+		upd.Status = &status
+	}
+	return upd
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/pkg/pointer"
 	_ "github.com/influxdata/influxdb/query/builtin"
 	"github.com/influxdata/influxdb/task/options"
 )
@@ -154,7 +155,48 @@ from(bucket: "x")
 			t.Fatalf(cmp.Diff(*tu.Flux, expscript))
 		}
 	})
+	t.Run("switching from cron in location to every", func(t *testing.T) {
+		tu := &platform.TaskUpdate{}
+		tu.Options.Every = *(options.MustParseDuration("10s"))
+		tu.Options.Offset = &options.Duration{}
+		expscript := `option task = {every: 10s, name: "foo"}
+
+from(bucket: "x")
+	|> range(start: -1h)`
+		if err := tu.UpdateFlux(`option task = {cron: "0 2 * * *", location: "Europe/Berlin", offset: 10s, name: "foo"} from(bucket:"x") |> range(start:-1h)`); err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(*tu.Flux, expscript) {
+			t.Fatalf(cmp.Diff(*tu.Flux, expscript))
+		}
+	})
+	t.Run("delete location", func(t *testing.T) {
+		tu := &platform.TaskUpdate{}
+		tu.Options.Location = pointer.String("")
+		expscript := `option task = {cron: "0 2 * * *", name: "foo"}
 
+from(bucket: "x")
+	|> range(start: -1h)`
+		if err := tu.UpdateFlux(`option task = {cron: "0 2 * * *", location: "Europe/Berlin", name: "foo"} from(bucket:"x") |> range(start:-1h)`); err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(*tu.Flux, expscript) {
+			t.Fatalf(cmp.Diff(*tu.Flux, expscript))
+		}
+	})
+
+}
+
+func TestTask_ScheduleLocation(t *testing.T) {
+	task := &platform.Task{Cron: "0 2 * * *", Location: "Europe/Berlin"}
+	if loc, err := task.ScheduleLocation(); err != nil || loc.String() != "Europe/Berlin" {
+		t.Fatalf("unexpected schedule location %v: %v", loc, err)
+	}
+
+	task.Location = "Local"
+	if _, err := task.ScheduleLocation(); err == nil {
+		t.Fatal("expected error for the location of the server")
+	}
 }
 
 func TestParseRequestStillQueuedError(t *testing.T) {
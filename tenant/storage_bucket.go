@@ -282,6 +282,15 @@ func (s *Store) CreateBucket(ctx context.Context, tx kv.Tx, bucket *influxdb.Buc
 		return err
 	}
 
+	if bucket.Schema != nil {
+		if err := bucket.Schema.Valid(); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpCreateBucket,
+				Err: err,
+			}
+		}
+	}
+
 	bucket.SetCreatedAt(time.Now())
 	bucket.SetUpdatedAt(time.Now())
 	idx, err := tx.Bucket(bucketIndex)
@@ -365,6 +374,19 @@ func (s *Store) UpdateBucket(ctx context.Context, tx kv.Tx, id influxdb.ID, upd
 		bucket.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.Schema != nil {
+		if len(upd.Schema.Measurements) == 0 {
+			bucket.Schema = nil
+		} else if err := upd.Schema.Valid(); err != nil {
+			return nil, &influxdb.Error{
+				Op:  influxdb.OpUpdateBucket,
+				Err: err,
+			}
+		} else {
+			bucket.Schema = upd.Schema
+		}
+	}
+
 	v, err := marshalBucket(bucket)
 	if err != nil {
 		return nil, err
@@ -262,6 +262,41 @@ func TestBucket(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "invalid schema",
+			setup: simpleSetup,
+			update: func(t *testing.T, store *tenant.Store, tx kv.Tx) {
+				invalid := &influxdb.BucketSchema{Measurements: []influxdb.MeasurementSchema{{}}}
+
+				err := store.CreateBucket(context.Background(), tx, &influxdb.Bucket{
+					ID:     influxdb.ID(11),
+					OrgID:  influxdb.ID(1),
+					Name:   "bucket11",
+					Schema: invalid,
+				})
+				if influxdb.ErrorCode(err) != influxdb.EInvalid {
+					t.Fatalf("expected invalid schema error creating bucket, got: %v", err)
+				}
+
+				_, err = store.UpdateBucket(context.Background(), tx, influxdb.ID(3), influxdb.BucketUpdate{Schema: invalid})
+				if influxdb.ErrorCode(err) != influxdb.EInvalid {
+					t.Fatalf("expected invalid schema error updating bucket, got: %v", err)
+				}
+			},
+			results: func(t *testing.T, store *tenant.Store, tx kv.Tx) {
+				buckets, err := store.ListBuckets(context.Background(), tx, tenant.BucketFilter{})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(buckets) != 10 {
+					t.Fatalf("expected 10 buckets got: %d", len(buckets))
+				}
+				if buckets[2].Schema != nil {
+					t.Fatalf("expected bucket without schema got: %+v", buckets[2].Schema)
+				}
+			},
+		},
 		{
 			name:  "delete",
 			setup: simpleSetup,
@@ -46,6 +46,11 @@ type CursorRequest struct {
 	Ascending bool
 	StartTime int64
 	EndTime   int64
+
+	// Limit is the maximum number of points that will be read from the
+	// cursor or zero if all points are read. Engines may use it to avoid
+	// reading blocks that contain no points of the result.
+	Limit int
 }
 
 type CursorIterator interface {
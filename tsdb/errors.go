@@ -2,6 +2,8 @@ package tsdb
 
 import (
 	"fmt"
+
+	"github.com/influxdata/influxdb/models"
 )
 
 // PartialWriteError indicates a write request could only write a portion of the
@@ -12,6 +14,19 @@ type PartialWriteError struct {
 
 	// A sorted slice of series keys that were dropped.
 	DroppedKeys [][]byte
+
+	// The points that were dropped, with the reason each was dropped for.
+	DroppedPoints []DroppedPoint
+}
+
+// DroppedPoint is a point dropped from a write.
+type DroppedPoint struct {
+	Point  models.Point
+	Reason string
+
+	// Conflict is true if the point conflicts with the data already written,
+	// such as a field of another type, rather than being invalid itself.
+	Conflict bool
 }
 
 func (e PartialWriteError) Error() string {
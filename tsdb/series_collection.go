@@ -21,9 +21,10 @@ type SeriesCollection struct {
 	SeriesIDs  []SeriesID
 
 	// Keeps track of invalid entries.
-	Dropped     uint64
-	DroppedKeys [][]byte
-	Reason      string
+	Dropped       uint64
+	DroppedKeys   [][]byte
+	DroppedPoints []DroppedPoint
+	Reason        string
 
 	// Used by the concurrent iterators to stage drops. Inefficient, but should be
 	// very infrequently used.
@@ -34,7 +35,7 @@ type SeriesCollection struct {
 type seriesCollectionState struct {
 	mu     sync.Mutex
 	reason string
-	index  map[int]struct{}
+	index  map[int]string // reason by index
 }
 
 // NewSeriesCollection builds a SeriesCollection from a slice of points. It does some filtering
@@ -205,9 +206,36 @@ func (s *SeriesCollection) InvalidateAll(reason string) {
 	}
 	s.Dropped += uint64(len(s.Keys))
 	s.DroppedKeys = append(s.DroppedKeys, s.Keys...)
+	for _, pt := range s.Points {
+		s.DroppedPoints = append(s.DroppedPoints, DroppedPoint{Point: pt, Reason: reason})
+	}
 	s.Truncate(0)
 }
 
+// Drop records the entry at index as dropped because it is invalid. The first
+// reason recorded is the reason of the collection. It does not remove the entry,
+// which callers filtering the collection skip instead of copying.
+func (s *SeriesCollection) Drop(index int, reason string) {
+	s.drop(index, reason, false)
+}
+
+// DropConflict records the entry at index as dropped because it conflicts with
+// the data already written, like Drop.
+func (s *SeriesCollection) DropConflict(index int, reason string) {
+	s.drop(index, reason, true)
+}
+
+func (s *SeriesCollection) drop(index int, reason string, conflict bool) {
+	if s.Reason == "" {
+		s.Reason = reason
+	}
+	s.Dropped++
+	s.DroppedKeys = append(s.DroppedKeys, s.Keys[index])
+	if index < len(s.Points) {
+		s.DroppedPoints = append(s.DroppedPoints, DroppedPoint{Point: s.Points[index], Reason: reason, Conflict: conflict})
+	}
+}
+
 // ApplyConcurrentDrops will remove all of the dropped values during concurrent iteration. It should
 // not be called concurrently with any calls to Invalid.
 func (s *SeriesCollection) ApplyConcurrentDrops() {
@@ -218,12 +246,17 @@ func (s *SeriesCollection) ApplyConcurrentDrops() {
 
 	length, j := s.Length(), 0
 	for i := 0; i < length; i++ {
-		if _, ok := state.index[i]; ok {
+		if reason, ok := state.index[i]; ok {
 			s.Dropped++
 
 			if i < len(s.Keys) {
 				s.DroppedKeys = append(s.DroppedKeys, s.Keys[i])
 			}
+			// The concurrent iterators drop the entries that conflict with
+			// the series already created.
+			if i < len(s.Points) {
+				s.DroppedPoints = append(s.DroppedPoints, DroppedPoint{Point: s.Points[i], Reason: reason, Conflict: true})
+			}
 
 			continue
 		}
@@ -266,9 +299,11 @@ func (s *SeriesCollection) invalidIndex(index int, reason string) {
 
 	state.mu.Lock()
 	if state.index == nil {
-		state.index = make(map[int]struct{})
+		state.index = make(map[int]string)
+	}
+	if _, ok := state.index[index]; !ok {
+		state.index[index] = reason
 	}
-	state.index[index] = struct{}{}
 	if state.reason == "" {
 		state.reason = reason
 	}
@@ -283,9 +318,10 @@ func (s *SeriesCollection) PartialWriteError() error {
 	}
 	droppedKeys := bytesutil.SortDedup(s.DroppedKeys)
 	return PartialWriteError{
-		Reason:      s.Reason,
-		Dropped:     len(droppedKeys),
-		DroppedKeys: droppedKeys,
+		Reason:        s.Reason,
+		Dropped:       len(droppedKeys),
+		DroppedKeys:   droppedKeys,
+		DroppedPoints: s.DroppedPoints,
 	}
 }
 
@@ -0,0 +1,203 @@
+package tsi1
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// BucketCardinality returns the series cardinality of a bucket broken down by
+// measurement and tag key, with the topN values having the most series for each
+// tag key, or all of them if topN is not positive. As a series has a single
+// value of each of its tag keys, the series of a tag key are the sum of the
+// series of its values.
+func (i *Index) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID, topN int) (*influxdb.BucketCardinality, error) {
+	name := tsdb.EncodeNameSlice(orgID, bucketID)
+	card := &influxdb.BucketCardinality{
+		OrgID:        orgID,
+		BucketID:     bucketID,
+		Measurements: []influxdb.MeasurementCardinality{},
+		TagKeys:      []influxdb.TagKeyCardinality{},
+	}
+
+	keys, err := i.tagKeys(name)
+	if err != nil {
+		return nil, err
+	}
+	live := i.SeriesIDSet()
+
+	for _, key := range keys {
+		// All the measurements are reported, but only the top values of the other keys.
+		limit := topN
+		if bytes.Equal(key, models.MeasurementTagKeyBytes) {
+			limit = 0
+		}
+
+		values, err := i.tagValueCardinalities(ctx, name, key, limit, live)
+		if err != nil {
+			return nil, err
+		}
+
+		// Every series has a single measurement, so the measurements give the
+		// exact number of series of the bucket.
+		if bytes.Equal(key, models.MeasurementTagKeyBytes) {
+			for _, v := range values.values {
+				card.Measurements = append(card.Measurements, influxdb.MeasurementCardinality{
+					Name:   v.Value,
+					Series: v.Series,
+				})
+				card.Series += v.Series
+			}
+			continue
+		}
+
+		// Keys whose series have all been deleted remain until compacted.
+		if values.n == 0 {
+			continue
+		}
+
+		k := string(key)
+		if bytes.Equal(key, models.FieldKeyTagKeyBytes) {
+			k = "_field"
+		}
+		card.TagKeys = append(card.TagKeys, influxdb.TagKeyCardinality{
+			Key:       k,
+			Values:    values.n,
+			Series:    values.series,
+			TopValues: values.top(limit),
+		})
+	}
+
+	sort.SliceStable(card.Measurements, func(i, j int) bool {
+		return card.Measurements[i].Series > card.Measurements[j].Series
+	})
+	sort.SliceStable(card.TagKeys, func(i, j int) bool {
+		return card.TagKeys[i].Values > card.TagKeys[j].Values
+	})
+	return card, nil
+}
+
+// tagKeys returns the tag keys of the measurement name across all partitions.
+func (i *Index) tagKeys(name []byte) ([][]byte, error) {
+	itr, err := i.TagKeyIterator(name)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return nil, nil
+	}
+	defer itr.Close()
+
+	var keys [][]byte
+	for {
+		key, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if key == nil {
+			return keys, nil
+		}
+		keys = append(keys, append([]byte(nil), key...))
+	}
+}
+
+// tagValueCardinalities holds the number of series of the values of a tag key.
+type tagValueCardinalities struct {
+	n      int64 // number of values
+	series int64 // series having the key
+	values []influxdb.TagValueCardinality
+	limit  int // number of values kept, when positive
+}
+
+// add adds the number of series of a value. When the values are limited, only
+// those having the most series are kept, trimming them once twice the limit is
+// reached so that they are only sorted from time to time.
+func (c *tagValueCardinalities) add(value string, series int64) {
+	c.n++
+	c.series += series
+	c.values = append(c.values, influxdb.TagValueCardinality{Value: value, Series: series})
+	if c.limit > 0 && len(c.values) >= 2*c.limit {
+		c.values = c.top(c.limit)
+	}
+}
+
+// top returns the n values having the most series, or all values if n is not positive.
+func (c *tagValueCardinalities) top(n int) []influxdb.TagValueCardinality {
+	sort.SliceStable(c.values, func(i, j int) bool {
+		return c.values[i].Series > c.values[j].Series
+	})
+	if n > 0 && len(c.values) > n {
+		return c.values[:n:n]
+	}
+	return c.values
+}
+
+// tagValueCardinalities counts the series of the values of a tag key, keeping
+// the limit values having the most series, or all of them if limit is not positive.
+// The series of a value are counted from its series ID set, intersected with
+// the series of the index to leave out the deleted ones, rather than one by one.
+func (i *Index) tagValueCardinalities(ctx context.Context, name, key []byte, limit int, live *tsdb.SeriesIDSet) (*tagValueCardinalities, error) {
+	c := &tagValueCardinalities{limit: limit}
+
+	itr, err := i.TagValueIterator(name, key)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return c, nil
+	}
+	defer itr.Close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		value, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if value == nil {
+			return c, nil
+		}
+
+		n, err := i.tagValueSeriesN(name, key, value, live)
+		if err != nil {
+			return nil, err
+		}
+
+		// Values whose series have all been deleted remain in the index files
+		// until they are compacted.
+		if n > 0 {
+			c.add(string(value), n)
+		}
+	}
+}
+
+// tagValueSeriesN returns the number of series of the index live having the tag value.
+func (i *Index) tagValueSeriesN(name, key, value []byte, live *tsdb.SeriesIDSet) (int64, error) {
+	sitr, err := i.tagValueSeriesIDIterator(name, key, value)
+	if err != nil {
+		return 0, err
+	} else if sitr == nil {
+		return 0, nil
+	}
+	defer sitr.Close()
+
+	if ssitr, ok := sitr.(tsdb.SeriesIDSetIterator); ok {
+		return int64(ssitr.SeriesIDSet().And(live).Cardinality()), nil
+	}
+
+	// The series of the log files may not be held as sets.
+	var n int64
+	for {
+		e, err := sitr.Next()
+		if err != nil {
+			return 0, err
+		} else if e.SeriesID.IsZero() {
+			return n, nil
+		} else if live.Contains(e.SeriesID) {
+			n++
+		}
+	}
+}
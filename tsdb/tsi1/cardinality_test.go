@@ -0,0 +1,140 @@
+package tsi1_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsi1"
+)
+
+func TestIndex_BucketCardinality(t *testing.T) {
+	idx := MustOpenIndex(2, tsi1.NewConfig())
+	defer idx.Close()
+
+	name := tsdb.EncodeNameSlice(1, 2)
+	series := func(m, field string, tags ...string) Series {
+		kvs := map[string]string{models.MeasurementTagKey: m, models.FieldKeyTagKey: field}
+		for i := 0; i < len(tags); i += 2 {
+			kvs[tags[i]] = tags[i+1]
+		}
+		return Series{Name: name, Tags: models.NewTags(kvs)}
+	}
+
+	var a []Series
+	for i := 0; i < 5; i++ {
+		a = append(a, series("cpu", "usage", "host", fmt.Sprintf("host%d", i), "region", "west"))
+	}
+	a = append(a,
+		series("cpu", "usage", "host", "host0", "region", "east"),
+		series("mem", "free", "host", "host0"),
+		series("mem", "used", "host", "host0"),
+		// Another bucket is not counted.
+		Series{Name: tsdb.EncodeNameSlice(1, 3), Tags: models.NewTags(map[string]string{
+			models.MeasurementTagKey: "cpu", models.FieldKeyTagKey: "usage", "id": "1",
+		})},
+	)
+	if err := idx.CreateSeriesSliceIfNotExists(a); err != nil {
+		t.Fatal(err)
+	}
+
+	idx.Run(t, func(t *testing.T) {
+		card, err := idx.BucketCardinality(context.Background(), 1, 2, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := &influxdb.BucketCardinality{
+			OrgID:    1,
+			BucketID: 2,
+			Series:   8,
+			Measurements: []influxdb.MeasurementCardinality{
+				{Name: "cpu", Series: 6},
+				{Name: "mem", Series: 2},
+			},
+			TagKeys: []influxdb.TagKeyCardinality{
+				{
+					Key:    "host",
+					Values: 5,
+					Series: 8,
+					TopValues: []influxdb.TagValueCardinality{
+						{Value: "host0", Series: 4},
+						{Value: "host1", Series: 1},
+					},
+				},
+				{
+					Key:    "_field",
+					Values: 3,
+					Series: 8,
+					TopValues: []influxdb.TagValueCardinality{
+						{Value: "usage", Series: 6},
+						{Value: "free", Series: 1},
+					},
+				},
+				{
+					Key:    "region",
+					Values: 2,
+					Series: 6,
+					TopValues: []influxdb.TagValueCardinality{
+						{Value: "west", Series: 5},
+						{Value: "east", Series: 1},
+					},
+				},
+			},
+		}
+		if diff := cmp.Diff(card, exp); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	// Deleted series are not counted.
+	east := series("cpu", "usage", "host", "host0", "region", "east")
+	sid := idx.Index.SeriesFile().SeriesID(east.Name, east.Tags, nil)
+	if err := idx.DropSeries(sid, models.MakeKey(east.Name, east.Tags), true); err != nil {
+		t.Fatal(err)
+	}
+
+	idx.Run(t, func(t *testing.T) {
+		card, err := idx.BucketCardinality(context.Background(), 1, 2, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := &influxdb.BucketCardinality{
+			OrgID:    1,
+			BucketID: 2,
+			Series:   7,
+			Measurements: []influxdb.MeasurementCardinality{
+				{Name: "cpu", Series: 5},
+				{Name: "mem", Series: 2},
+			},
+			TagKeys: []influxdb.TagKeyCardinality{
+				{
+					Key:       "host",
+					Values:    5,
+					Series:    7,
+					TopValues: []influxdb.TagValueCardinality{{Value: "host0", Series: 3}},
+				},
+				{
+					Key:       "_field",
+					Values:    3,
+					Series:    7,
+					TopValues: []influxdb.TagValueCardinality{{Value: "usage", Series: 5}},
+				},
+				{
+					Key:       "region",
+					Values:    1,
+					Series:    5,
+					TopValues: []influxdb.TagValueCardinality{{Value: "west", Series: 5}},
+				},
+			},
+		}
+		if diff := cmp.Diff(card, exp); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
@@ -324,6 +324,127 @@ func (c *floatArrayDescendingCursor) readArrayBlock() *cursors.FloatArray {
 	return values
 }
 
+// floatArrayLimitCursor returns the first point of a series in the order of the
+// cursor. Like the other array cursors, it includes points in [start, end)
+// when ascending and in (start, end] when descending. TSM blocks are
+// located with the index entries of the KeyCursor and read until the point
+// is found, which is typically the leading block of each file.
+type floatArrayLimitCursor struct {
+	cache     Values
+	keyCursor *KeyCursor
+	buf       *cursors.FloatArray
+
+	start, end int64
+	ascending  bool
+	done       bool
+
+	res   *cursors.FloatArray
+	stats cursors.CursorStats
+}
+
+func newFloatArrayLimitCursor() *floatArrayLimitCursor {
+	c := &floatArrayLimitCursor{
+		res: cursors.NewFloatArrayLen(1),
+	}
+	c.buf = cursors.NewFloatArrayLen(MaxPointsPerBlock)
+	return c
+}
+
+func (c *floatArrayLimitCursor) reset(start, end int64, ascending bool, cacheValues Values, tsmKeyCursor *KeyCursor) {
+	c.start, c.end = start, end
+	c.ascending = ascending
+	c.cache = cacheValues
+	c.keyCursor = tsmKeyCursor
+	c.done = false
+}
+
+func (c *floatArrayLimitCursor) Err() error { return nil }
+
+func (c *floatArrayLimitCursor) Close() {
+	if c.keyCursor != nil {
+		c.keyCursor.Close()
+		c.keyCursor = nil
+	}
+	c.cache = nil
+}
+
+func (c *floatArrayLimitCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *floatArrayLimitCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+	if c.done {
+		return c.res
+	}
+	c.done = true
+
+	var (
+		found bool
+		t     int64
+		v     float64
+	)
+
+	if len(c.cache) > 0 {
+		i := c.search(len(c.cache), func(i int) int64 { return c.cache[i].UnixNano() })
+		if i >= 0 && i < len(c.cache) && c.contains(c.cache[i].UnixNano()) {
+			t, v, found = c.cache[i].UnixNano(), c.cache[i].(FloatValue).RawValue(), true
+		}
+	}
+
+	for c.keyCursor != nil {
+		tvals := c.readArrayBlock()
+		if tvals.Len() == 0 {
+			break
+		}
+
+		i := c.search(tvals.Len(), func(i int) int64 { return tvals.Timestamps[i] })
+		if i < 0 || i >= tvals.Len() {
+			// No points of this block are at or beyond the start
+			// of the search, so the point is in a following block.
+			c.keyCursor.Next()
+			continue
+		}
+
+		// Values in the cache replace values in TSM with the same timestamp.
+		if tt := tvals.Timestamps[i]; c.contains(tt) && (!found || (c.ascending && tt < t) || (!c.ascending && tt > t)) {
+			t, v, found = tt, tvals.Values[i], true
+		}
+		break
+	}
+
+	if found {
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, v)
+	}
+	return c.res
+}
+
+// search returns the index of the first of n sorted timestamps in the
+// order of the cursor.
+func (c *floatArrayLimitCursor) search(n int, ts func(i int) int64) int {
+	if c.ascending {
+		return sort.Search(n, func(i int) bool { return ts(i) >= c.start })
+	}
+	return sort.Search(n, func(i int) bool { return ts(i) > c.end }) - 1
+}
+
+func (c *floatArrayLimitCursor) contains(t int64) bool {
+	if c.ascending {
+		return t >= c.start && t < c.end
+	}
+	return t > c.start && t <= c.end
+}
+
+func (c *floatArrayLimitCursor) readArrayBlock() *cursors.FloatArray {
+	values, _ := c.keyCursor.ReadFloatArrayBlock(c.buf)
+
+	c.stats.ScannedValues += len(values.Values)
+
+	c.stats.ScannedBytes += len(values.Values) * 8
+
+	return values
+}
+
 type integerArrayAscendingCursor struct {
 	cache struct {
 		values Values
@@ -634,6 +755,127 @@ func (c *integerArrayDescendingCursor) readArrayBlock() *cursors.IntegerArray {
 	return values
 }
 
+// integerArrayLimitCursor returns the first point of a series in the order of the
+// cursor. Like the other array cursors, it includes points in [start, end)
+// when ascending and in (start, end] when descending. TSM blocks are
+// located with the index entries of the KeyCursor and read until the point
+// is found, which is typically the leading block of each file.
+type integerArrayLimitCursor struct {
+	cache     Values
+	keyCursor *KeyCursor
+	buf       *cursors.IntegerArray
+
+	start, end int64
+	ascending  bool
+	done       bool
+
+	res   *cursors.IntegerArray
+	stats cursors.CursorStats
+}
+
+func newIntegerArrayLimitCursor() *integerArrayLimitCursor {
+	c := &integerArrayLimitCursor{
+		res: cursors.NewIntegerArrayLen(1),
+	}
+	c.buf = cursors.NewIntegerArrayLen(MaxPointsPerBlock)
+	return c
+}
+
+func (c *integerArrayLimitCursor) reset(start, end int64, ascending bool, cacheValues Values, tsmKeyCursor *KeyCursor) {
+	c.start, c.end = start, end
+	c.ascending = ascending
+	c.cache = cacheValues
+	c.keyCursor = tsmKeyCursor
+	c.done = false
+}
+
+func (c *integerArrayLimitCursor) Err() error { return nil }
+
+func (c *integerArrayLimitCursor) Close() {
+	if c.keyCursor != nil {
+		c.keyCursor.Close()
+		c.keyCursor = nil
+	}
+	c.cache = nil
+}
+
+func (c *integerArrayLimitCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *integerArrayLimitCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+	if c.done {
+		return c.res
+	}
+	c.done = true
+
+	var (
+		found bool
+		t     int64
+		v     int64
+	)
+
+	if len(c.cache) > 0 {
+		i := c.search(len(c.cache), func(i int) int64 { return c.cache[i].UnixNano() })
+		if i >= 0 && i < len(c.cache) && c.contains(c.cache[i].UnixNano()) {
+			t, v, found = c.cache[i].UnixNano(), c.cache[i].(IntegerValue).RawValue(), true
+		}
+	}
+
+	for c.keyCursor != nil {
+		tvals := c.readArrayBlock()
+		if tvals.Len() == 0 {
+			break
+		}
+
+		i := c.search(tvals.Len(), func(i int) int64 { return tvals.Timestamps[i] })
+		if i < 0 || i >= tvals.Len() {
+			// No points of this block are at or beyond the start
+			// of the search, so the point is in a following block.
+			c.keyCursor.Next()
+			continue
+		}
+
+		// Values in the cache replace values in TSM with the same timestamp.
+		if tt := tvals.Timestamps[i]; c.contains(tt) && (!found || (c.ascending && tt < t) || (!c.ascending && tt > t)) {
+			t, v, found = tt, tvals.Values[i], true
+		}
+		break
+	}
+
+	if found {
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, v)
+	}
+	return c.res
+}
+
+// search returns the index of the first of n sorted timestamps in the
+// order of the cursor.
+func (c *integerArrayLimitCursor) search(n int, ts func(i int) int64) int {
+	if c.ascending {
+		return sort.Search(n, func(i int) bool { return ts(i) >= c.start })
+	}
+	return sort.Search(n, func(i int) bool { return ts(i) > c.end }) - 1
+}
+
+func (c *integerArrayLimitCursor) contains(t int64) bool {
+	if c.ascending {
+		return t >= c.start && t < c.end
+	}
+	return t > c.start && t <= c.end
+}
+
+func (c *integerArrayLimitCursor) readArrayBlock() *cursors.IntegerArray {
+	values, _ := c.keyCursor.ReadIntegerArrayBlock(c.buf)
+
+	c.stats.ScannedValues += len(values.Values)
+
+	c.stats.ScannedBytes += len(values.Values) * 8
+
+	return values
+}
+
 type unsignedArrayAscendingCursor struct {
 	cache struct {
 		values Values
@@ -944,6 +1186,127 @@ func (c *unsignedArrayDescendingCursor) readArrayBlock() *cursors.UnsignedArray
 	return values
 }
 
+// unsignedArrayLimitCursor returns the first point of a series in the order of the
+// cursor. Like the other array cursors, it includes points in [start, end)
+// when ascending and in (start, end] when descending. TSM blocks are
+// located with the index entries of the KeyCursor and read until the point
+// is found, which is typically the leading block of each file.
+type unsignedArrayLimitCursor struct {
+	cache     Values
+	keyCursor *KeyCursor
+	buf       *cursors.UnsignedArray
+
+	start, end int64
+	ascending  bool
+	done       bool
+
+	res   *cursors.UnsignedArray
+	stats cursors.CursorStats
+}
+
+func newUnsignedArrayLimitCursor() *unsignedArrayLimitCursor {
+	c := &unsignedArrayLimitCursor{
+		res: cursors.NewUnsignedArrayLen(1),
+	}
+	c.buf = cursors.NewUnsignedArrayLen(MaxPointsPerBlock)
+	return c
+}
+
+func (c *unsignedArrayLimitCursor) reset(start, end int64, ascending bool, cacheValues Values, tsmKeyCursor *KeyCursor) {
+	c.start, c.end = start, end
+	c.ascending = ascending
+	c.cache = cacheValues
+	c.keyCursor = tsmKeyCursor
+	c.done = false
+}
+
+func (c *unsignedArrayLimitCursor) Err() error { return nil }
+
+func (c *unsignedArrayLimitCursor) Close() {
+	if c.keyCursor != nil {
+		c.keyCursor.Close()
+		c.keyCursor = nil
+	}
+	c.cache = nil
+}
+
+func (c *unsignedArrayLimitCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *unsignedArrayLimitCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+	if c.done {
+		return c.res
+	}
+	c.done = true
+
+	var (
+		found bool
+		t     int64
+		v     uint64
+	)
+
+	if len(c.cache) > 0 {
+		i := c.search(len(c.cache), func(i int) int64 { return c.cache[i].UnixNano() })
+		if i >= 0 && i < len(c.cache) && c.contains(c.cache[i].UnixNano()) {
+			t, v, found = c.cache[i].UnixNano(), c.cache[i].(UnsignedValue).RawValue(), true
+		}
+	}
+
+	for c.keyCursor != nil {
+		tvals := c.readArrayBlock()
+		if tvals.Len() == 0 {
+			break
+		}
+
+		i := c.search(tvals.Len(), func(i int) int64 { return tvals.Timestamps[i] })
+		if i < 0 || i >= tvals.Len() {
+			// No points of this block are at or beyond the start
+			// of the search, so the point is in a following block.
+			c.keyCursor.Next()
+			continue
+		}
+
+		// Values in the cache replace values in TSM with the same timestamp.
+		if tt := tvals.Timestamps[i]; c.contains(tt) && (!found || (c.ascending && tt < t) || (!c.ascending && tt > t)) {
+			t, v, found = tt, tvals.Values[i], true
+		}
+		break
+	}
+
+	if found {
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, v)
+	}
+	return c.res
+}
+
+// search returns the index of the first of n sorted timestamps in the
+// order of the cursor.
+func (c *unsignedArrayLimitCursor) search(n int, ts func(i int) int64) int {
+	if c.ascending {
+		return sort.Search(n, func(i int) bool { return ts(i) >= c.start })
+	}
+	return sort.Search(n, func(i int) bool { return ts(i) > c.end }) - 1
+}
+
+func (c *unsignedArrayLimitCursor) contains(t int64) bool {
+	if c.ascending {
+		return t >= c.start && t < c.end
+	}
+	return t > c.start && t <= c.end
+}
+
+func (c *unsignedArrayLimitCursor) readArrayBlock() *cursors.UnsignedArray {
+	values, _ := c.keyCursor.ReadUnsignedArrayBlock(c.buf)
+
+	c.stats.ScannedValues += len(values.Values)
+
+	c.stats.ScannedBytes += len(values.Values) * 8
+
+	return values
+}
+
 type stringArrayAscendingCursor struct {
 	cache struct {
 		values Values
@@ -1258,6 +1621,129 @@ func (c *stringArrayDescendingCursor) readArrayBlock() *cursors.StringArray {
 	return values
 }
 
+// stringArrayLimitCursor returns the first point of a series in the order of the
+// cursor. Like the other array cursors, it includes points in [start, end)
+// when ascending and in (start, end] when descending. TSM blocks are
+// located with the index entries of the KeyCursor and read until the point
+// is found, which is typically the leading block of each file.
+type stringArrayLimitCursor struct {
+	cache     Values
+	keyCursor *KeyCursor
+	buf       *cursors.StringArray
+
+	start, end int64
+	ascending  bool
+	done       bool
+
+	res   *cursors.StringArray
+	stats cursors.CursorStats
+}
+
+func newStringArrayLimitCursor() *stringArrayLimitCursor {
+	c := &stringArrayLimitCursor{
+		res: cursors.NewStringArrayLen(1),
+	}
+	c.buf = cursors.NewStringArrayLen(MaxPointsPerBlock)
+	return c
+}
+
+func (c *stringArrayLimitCursor) reset(start, end int64, ascending bool, cacheValues Values, tsmKeyCursor *KeyCursor) {
+	c.start, c.end = start, end
+	c.ascending = ascending
+	c.cache = cacheValues
+	c.keyCursor = tsmKeyCursor
+	c.done = false
+}
+
+func (c *stringArrayLimitCursor) Err() error { return nil }
+
+func (c *stringArrayLimitCursor) Close() {
+	if c.keyCursor != nil {
+		c.keyCursor.Close()
+		c.keyCursor = nil
+	}
+	c.cache = nil
+}
+
+func (c *stringArrayLimitCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *stringArrayLimitCursor) Next() *cursors.StringArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+	if c.done {
+		return c.res
+	}
+	c.done = true
+
+	var (
+		found bool
+		t     int64
+		v     string
+	)
+
+	if len(c.cache) > 0 {
+		i := c.search(len(c.cache), func(i int) int64 { return c.cache[i].UnixNano() })
+		if i >= 0 && i < len(c.cache) && c.contains(c.cache[i].UnixNano()) {
+			t, v, found = c.cache[i].UnixNano(), c.cache[i].(StringValue).RawValue(), true
+		}
+	}
+
+	for c.keyCursor != nil {
+		tvals := c.readArrayBlock()
+		if tvals.Len() == 0 {
+			break
+		}
+
+		i := c.search(tvals.Len(), func(i int) int64 { return tvals.Timestamps[i] })
+		if i < 0 || i >= tvals.Len() {
+			// No points of this block are at or beyond the start
+			// of the search, so the point is in a following block.
+			c.keyCursor.Next()
+			continue
+		}
+
+		// Values in the cache replace values in TSM with the same timestamp.
+		if tt := tvals.Timestamps[i]; c.contains(tt) && (!found || (c.ascending && tt < t) || (!c.ascending && tt > t)) {
+			t, v, found = tt, tvals.Values[i], true
+		}
+		break
+	}
+
+	if found {
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, v)
+	}
+	return c.res
+}
+
+// search returns the index of the first of n sorted timestamps in the
+// order of the cursor.
+func (c *stringArrayLimitCursor) search(n int, ts func(i int) int64) int {
+	if c.ascending {
+		return sort.Search(n, func(i int) bool { return ts(i) >= c.start })
+	}
+	return sort.Search(n, func(i int) bool { return ts(i) > c.end }) - 1
+}
+
+func (c *stringArrayLimitCursor) contains(t int64) bool {
+	if c.ascending {
+		return t >= c.start && t < c.end
+	}
+	return t > c.start && t <= c.end
+}
+
+func (c *stringArrayLimitCursor) readArrayBlock() *cursors.StringArray {
+	values, _ := c.keyCursor.ReadStringArrayBlock(c.buf)
+
+	c.stats.ScannedValues += len(values.Values)
+
+	for _, v := range values.Values {
+		c.stats.ScannedBytes += len(v)
+	}
+
+	return values
+}
+
 type booleanArrayAscendingCursor struct {
 	cache struct {
 		values Values
@@ -1567,3 +2053,124 @@ func (c *booleanArrayDescendingCursor) readArrayBlock() *cursors.BooleanArray {
 
 	return values
 }
+
+// booleanArrayLimitCursor returns the first point of a series in the order of the
+// cursor. Like the other array cursors, it includes points in [start, end)
+// when ascending and in (start, end] when descending. TSM blocks are
+// located with the index entries of the KeyCursor and read until the point
+// is found, which is typically the leading block of each file.
+type booleanArrayLimitCursor struct {
+	cache     Values
+	keyCursor *KeyCursor
+	buf       *cursors.BooleanArray
+
+	start, end int64
+	ascending  bool
+	done       bool
+
+	res   *cursors.BooleanArray
+	stats cursors.CursorStats
+}
+
+func newBooleanArrayLimitCursor() *booleanArrayLimitCursor {
+	c := &booleanArrayLimitCursor{
+		res: cursors.NewBooleanArrayLen(1),
+	}
+	c.buf = cursors.NewBooleanArrayLen(MaxPointsPerBlock)
+	return c
+}
+
+func (c *booleanArrayLimitCursor) reset(start, end int64, ascending bool, cacheValues Values, tsmKeyCursor *KeyCursor) {
+	c.start, c.end = start, end
+	c.ascending = ascending
+	c.cache = cacheValues
+	c.keyCursor = tsmKeyCursor
+	c.done = false
+}
+
+func (c *booleanArrayLimitCursor) Err() error { return nil }
+
+func (c *booleanArrayLimitCursor) Close() {
+	if c.keyCursor != nil {
+		c.keyCursor.Close()
+		c.keyCursor = nil
+	}
+	c.cache = nil
+}
+
+func (c *booleanArrayLimitCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *booleanArrayLimitCursor) Next() *cursors.BooleanArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+	if c.done {
+		return c.res
+	}
+	c.done = true
+
+	var (
+		found bool
+		t     int64
+		v     bool
+	)
+
+	if len(c.cache) > 0 {
+		i := c.search(len(c.cache), func(i int) int64 { return c.cache[i].UnixNano() })
+		if i >= 0 && i < len(c.cache) && c.contains(c.cache[i].UnixNano()) {
+			t, v, found = c.cache[i].UnixNano(), c.cache[i].(BooleanValue).RawValue(), true
+		}
+	}
+
+	for c.keyCursor != nil {
+		tvals := c.readArrayBlock()
+		if tvals.Len() == 0 {
+			break
+		}
+
+		i := c.search(tvals.Len(), func(i int) int64 { return tvals.Timestamps[i] })
+		if i < 0 || i >= tvals.Len() {
+			// No points of this block are at or beyond the start
+			// of the search, so the point is in a following block.
+			c.keyCursor.Next()
+			continue
+		}
+
+		// Values in the cache replace values in TSM with the same timestamp.
+		if tt := tvals.Timestamps[i]; c.contains(tt) && (!found || (c.ascending && tt < t) || (!c.ascending && tt > t)) {
+			t, v, found = tt, tvals.Values[i], true
+		}
+		break
+	}
+
+	if found {
+		c.res.Timestamps = append(c.res.Timestamps, t)
+		c.res.Values = append(c.res.Values, v)
+	}
+	return c.res
+}
+
+// search returns the index of the first of n sorted timestamps in the
+// order of the cursor.
+func (c *booleanArrayLimitCursor) search(n int, ts func(i int) int64) int {
+	if c.ascending {
+		return sort.Search(n, func(i int) bool { return ts(i) >= c.start })
+	}
+	return sort.Search(n, func(i int) bool { return ts(i) > c.end }) - 1
+}
+
+func (c *booleanArrayLimitCursor) contains(t int64) bool {
+	if c.ascending {
+		return t >= c.start && t < c.end
+	}
+	return t > c.start && t <= c.end
+}
+
+func (c *booleanArrayLimitCursor) readArrayBlock() *cursors.BooleanArray {
+	values, _ := c.keyCursor.ReadBooleanArrayBlock(c.buf)
+
+	c.stats.ScannedValues += len(values.Values)
+
+	c.stats.ScannedBytes += len(values.Values) * 1
+
+	return values
+}
@@ -22,6 +22,14 @@ func (q *arrayCursorIterator) buildFloatArrayCursor(ctx context.Context, name []
 
 	q.e.readTracker.AddSeeks(uint64(keyCursor.seekN()))
 
+	if opt.Limit == 1 {
+		if q.limit.Float == nil {
+			q.limit.Float = newFloatArrayLimitCursor()
+		}
+		q.limit.Float.reset(opt.StartTime, opt.EndTime, opt.Ascending, cacheValues, keyCursor)
+		return q.limit.Float
+	}
+
 	if opt.Ascending {
 		if q.asc.Float == nil {
 			q.asc.Float = newFloatArrayAscendingCursor()
@@ -45,6 +53,14 @@ func (q *arrayCursorIterator) buildIntegerArrayCursor(ctx context.Context, name
 
 	q.e.readTracker.AddSeeks(uint64(keyCursor.seekN()))
 
+	if opt.Limit == 1 {
+		if q.limit.Integer == nil {
+			q.limit.Integer = newIntegerArrayLimitCursor()
+		}
+		q.limit.Integer.reset(opt.StartTime, opt.EndTime, opt.Ascending, cacheValues, keyCursor)
+		return q.limit.Integer
+	}
+
 	if opt.Ascending {
 		if q.asc.Integer == nil {
 			q.asc.Integer = newIntegerArrayAscendingCursor()
@@ -68,6 +84,14 @@ func (q *arrayCursorIterator) buildUnsignedArrayCursor(ctx context.Context, name
 
 	q.e.readTracker.AddSeeks(uint64(keyCursor.seekN()))
 
+	if opt.Limit == 1 {
+		if q.limit.Unsigned == nil {
+			q.limit.Unsigned = newUnsignedArrayLimitCursor()
+		}
+		q.limit.Unsigned.reset(opt.StartTime, opt.EndTime, opt.Ascending, cacheValues, keyCursor)
+		return q.limit.Unsigned
+	}
+
 	if opt.Ascending {
 		if q.asc.Unsigned == nil {
 			q.asc.Unsigned = newUnsignedArrayAscendingCursor()
@@ -91,6 +115,14 @@ func (q *arrayCursorIterator) buildStringArrayCursor(ctx context.Context, name [
 
 	q.e.readTracker.AddSeeks(uint64(keyCursor.seekN()))
 
+	if opt.Limit == 1 {
+		if q.limit.String == nil {
+			q.limit.String = newStringArrayLimitCursor()
+		}
+		q.limit.String.reset(opt.StartTime, opt.EndTime, opt.Ascending, cacheValues, keyCursor)
+		return q.limit.String
+	}
+
 	if opt.Ascending {
 		if q.asc.String == nil {
 			q.asc.String = newStringArrayAscendingCursor()
@@ -114,6 +146,14 @@ func (q *arrayCursorIterator) buildBooleanArrayCursor(ctx context.Context, name
 
 	q.e.readTracker.AddSeeks(uint64(keyCursor.seekN()))
 
+	if opt.Limit == 1 {
+		if q.limit.Boolean == nil {
+			q.limit.Boolean = newBooleanArrayLimitCursor()
+		}
+		q.limit.Boolean.reset(opt.StartTime, opt.EndTime, opt.Ascending, cacheValues, keyCursor)
+		return q.limit.Boolean
+	}
+
 	if opt.Ascending {
 		if q.asc.Boolean == nil {
 			q.asc.Boolean = newBooleanArrayAscendingCursor()
@@ -30,6 +30,14 @@ type arrayCursorIterator struct {
 		Boolean  *booleanArrayDescendingCursor
 		String   *stringArrayDescendingCursor
 	}
+
+	limit struct {
+		Float    *floatArrayLimitCursor
+		Integer  *integerArrayLimitCursor
+		Unsigned *unsignedArrayLimitCursor
+		Boolean  *booleanArrayLimitCursor
+		String   *stringArrayLimitCursor
+	}
 }
 
 func (q *arrayCursorIterator) Next(ctx context.Context, r *cursors.CursorRequest) (cursors.Cursor, error) {
@@ -49,6 +57,7 @@ func (q *arrayCursorIterator) Next(ctx context.Context, r *cursors.CursorRequest
 	opt.Ascending = r.Ascending
 	opt.StartTime = r.StartTime
 	opt.EndTime = r.EndTime
+	opt.Limit = r.Limit
 
 	// Return appropriate cursor based on type.
 	switch typ := id.Type(); typ {
@@ -107,5 +116,20 @@ func (q *arrayCursorIterator) Stats() cursors.CursorStats {
 	if cur := q.desc.String; cur != nil {
 		stats.Add(cur.Stats())
 	}
+	if cur := q.limit.Float; cur != nil {
+		stats.Add(cur.Stats())
+	}
+	if cur := q.limit.Integer; cur != nil {
+		stats.Add(cur.Stats())
+	}
+	if cur := q.limit.Unsigned; cur != nil {
+		stats.Add(cur.Stats())
+	}
+	if cur := q.limit.Boolean; cur != nil {
+		stats.Add(cur.Stats())
+	}
+	if cur := q.limit.String; cur != nil {
+		stats.Add(cur.Stats())
+	}
 	return stats
 }
@@ -698,6 +698,10 @@ type Compactor struct {
 	// RateLimit is the limit for disk writes for all concurrent compactions.
 	RateLimit limiter.Rate
 
+	// PartitionDuration, if set, partitions the snapshots of the cache by time,
+	// writing the TSM files of every partition into a directory of its own.
+	PartitionDuration PartitionDurationFunc
+
 	formatFileName FormatFileNameFunc
 	parseFileName  ParseFileNameFunc
 
@@ -842,26 +846,50 @@ func (c *Compactor) WriteSnapshot(ctx context.Context, cache *Cache) ([]string,
 		throttle = false
 	}
 
-	splits := cache.Split(concurrency)
+	type part struct {
+		dir   string
+		cache *Cache
+	}
+
+	var parts []part
+	if c.PartitionDuration != nil {
+		for p, pc := range partitionCache(cache, c.PartitionDuration) {
+			dir := c.Dir
+			if p != (Partition{}) {
+				dir = filepath.Join(c.Dir, p.Dir())
+				if err := os.MkdirAll(dir, 0777); err != nil {
+					return nil, err
+				}
+			}
+			parts = append(parts, part{dir: dir, cache: pc})
+		}
+	} else {
+		for _, sp := range cache.Split(concurrency) {
+			parts = append(parts, part{dir: c.Dir, cache: sp})
+		}
+	}
 
 	type res struct {
 		files []string
 		err   error
 	}
 
-	resC := make(chan res, concurrency)
-	for i := 0; i < concurrency; i++ {
-		go func(sp *Cache) {
-			iter := NewCacheKeyIterator(sp, MaxPointsPerBlock, intC)
-			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
-			resC <- res{files: files, err: err}
+	resC := make(chan res, len(parts))
+	limit := limiter.NewFixed(concurrency)
+	for _, p := range parts {
+		go func(p part) {
+			limit.Take()
+			defer limit.Release()
 
-		}(splits[i])
+			iter := NewCacheKeyIterator(p.cache, MaxPointsPerBlock, intC)
+			files, err := c.writeNewFiles(p.dir, c.FileStore.NextGeneration(), 0, nil, iter, throttle)
+			resC <- res{files: files, err: err}
+		}(p)
 	}
 
 	var err error
-	files := make([]string, 0, concurrency)
-	for i := 0; i < concurrency; i++ {
+	files := make([]string, 0, len(parts))
+	for range parts {
 		result := <-resC
 		if result.err != nil {
 			err = result.err
@@ -946,7 +974,8 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 		return nil, err
 	}
 
-	return c.writeNewFiles(maxGeneration, maxSequence, tsmFiles, tsm, true)
+	// The new files are written alongside the compacted ones, in their partition.
+	return c.writeNewFiles(filepath.Dir(tsmFiles[0]), maxGeneration, maxSequence, tsmFiles, tsm, true)
 }
 
 // CompactFull writes multiple smaller TSM files into 1 or more larger files.
@@ -1025,9 +1054,9 @@ func (c *Compactor) removeTmpFiles(files []string) error {
 	return nil
 }
 
-// writeNewFiles writes from the iterator into new TSM files, rotating
+// writeNewFiles writes from the iterator into new TSM files in dir, rotating
 // to a new file once it has reached the max TSM file size.
-func (c *Compactor) writeNewFiles(generation, sequence int, src []string, iter KeyIterator, throttle bool) ([]string, error) {
+func (c *Compactor) writeNewFiles(dir string, generation, sequence int, src []string, iter KeyIterator, throttle bool) ([]string, error) {
 	// These are the new TSM files written
 	var files []string
 
@@ -1035,7 +1064,7 @@ func (c *Compactor) writeNewFiles(generation, sequence int, src []string, iter K
 		sequence++
 
 		// New TSM files are written to a temp file and renamed when fully completed.
-		fileName := filepath.Join(c.Dir, c.formatFileName(generation, sequence)+"."+TSMFileExtension+"."+TmpTSMFileExtension)
+		fileName := filepath.Join(dir, c.formatFileName(generation, sequence)+"."+TSMFileExtension+"."+TmpTSMFileExtension)
 		statsFileName := StatsFilename(fileName)
 
 		// Write as much as possible to this file
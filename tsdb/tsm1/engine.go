@@ -96,6 +96,14 @@ type noSnapshotter struct{}
 func (noSnapshotter) AcquireSegments(_ context.Context, fn func([]string) error) error    { return fn(nil) }
 func (noSnapshotter) CommitSegments(_ context.Context, _ []string, fn func() error) error { return fn() }
 
+// WithPartitionDurationFunc partitions the TSM data of the engine by time, using fn
+// to determine the duration of the partitions of every bucket.
+func WithPartitionDurationFunc(fn PartitionDurationFunc) EngineOption {
+	return func(e *Engine) {
+		e.Compactor.PartitionDuration = fn
+	}
+}
+
 // WithSnapshotter sets the callbacks for the engine to use when creating snapshots.
 func WithSnapshotter(snapshotter Snapshotter) EngineOption {
 	return func(e *Engine) {
@@ -217,7 +225,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 
 		FileStore: fs,
 		Compactor: c,
-		CompactionPlan: NewDefaultPlanner(fs,
+		CompactionPlan: NewPartitionPlanner(fs,
 			time.Duration(config.Compaction.FullWriteColdDuration)),
 
 		CacheFlushMemorySizeThreshold:  uint64(config.Cache.SnapshotMemorySize),
@@ -1346,6 +1354,12 @@ func (e *Engine) cleanupTempTSMFiles() error {
 		return fmt.Errorf("error getting compaction temp files: %s", err.Error())
 	}
 
+	partitionFiles, err := filepath.Glob(filepath.Join(e.path, partitionGlob, fmt.Sprintf("*.%s", CompactionTempExtension)))
+	if err != nil {
+		return fmt.Errorf("error getting compaction temp files: %s", err.Error())
+	}
+	files = append(files, partitionFiles...)
+
 	for _, f := range files {
 		if err := os.Remove(f); err != nil {
 			return fmt.Errorf("error removing temp compaction files: %v", err)
@@ -115,3 +115,86 @@ func TestEngine_CursorIterator_Stats(t *testing.T) {
 		t.Fatalf("expected %v, got %v", exp, got)
 	}
 }
+
+func TestEngine_CursorIterator_Limit(t *testing.T) {
+	e := MustOpenEngine(t)
+	defer e.Close()
+
+	tags := models.Tags{{Key: []byte("a"), Value: []byte("b")}}
+	newPoints := func(values map[int64]float64) []models.Point {
+		var points []models.Point
+		for ts, v := range values {
+			points = append(points, models.MustNewPoint("cpu", tags, models.Fields{"value": v}, time.Unix(0, ts)))
+		}
+		return points
+	}
+
+	// Write points into TSM and then a few more into the cache, one of which
+	// replaces a value in TSM.
+	tsmPoints := newPoints(map[int64]float64{10: 1, 20: 2, 30: 3, 40: 4, 50: 5})
+	collection := tsdb.NewSeriesCollection(tsmPoints)
+	if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WritePoints(tsmPoints); err != nil {
+		t.Fatal(err)
+	}
+	e.MustWriteSnapshot()
+
+	if err := e.WritePoints(newPoints(map[int64]float64{5: 0.5, 30: 30, 60: 6})); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ascending  bool
+		start, end int64
+		expTimes   []int64
+		expValues  []float64
+	}{
+		{name: "first from cache", ascending: true, start: 0, end: 100, expTimes: []int64{5}, expValues: []float64{0.5}},
+		{name: "first from tsm", ascending: true, start: 6, end: 100, expTimes: []int64{10}, expValues: []float64{1}},
+		{name: "first replaced by cache", ascending: true, start: 21, end: 100, expTimes: []int64{30}, expValues: []float64{30}},
+		{name: "first excludes end", ascending: true, start: 51, end: 60},
+		{name: "last from cache", ascending: false, start: 0, end: 100, expTimes: []int64{60}, expValues: []float64{6}},
+		{name: "last from tsm", ascending: false, start: 0, end: 59, expTimes: []int64{50}, expValues: []float64{5}},
+		{name: "last includes end", ascending: false, start: 0, end: 40, expTimes: []int64{40}, expValues: []float64{4}},
+		{name: "last excludes start", ascending: false, start: 10, end: 10},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursorIterator, err := e.CreateCursorIterator(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cur, err := cursorIterator.Next(ctx, &cursors.CursorRequest{
+				Name:      []byte("cpu"),
+				Tags:      tags,
+				Field:     "value",
+				StartTime: tt.start,
+				EndTime:   tt.end,
+				Ascending: tt.ascending,
+				Limit:     1,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cur.Close()
+
+			fc := cur.(cursors.FloatArrayCursor)
+			a := fc.Next()
+			if got, exp := a.Timestamps, tt.expTimes; len(got) != len(exp) || (len(exp) > 0 && got[0] != exp[0]) {
+				t.Fatalf("unexpected timestamps: got %v, exp %v", got, exp)
+			}
+			if got, exp := a.Values, tt.expValues; len(got) != len(exp) || (len(exp) > 0 && got[0] != exp[0]) {
+				t.Fatalf("unexpected values: got %v, exp %v", got, exp)
+			}
+			if a := fc.Next(); a.Len() != 0 {
+				t.Fatalf("expected cursor to be exhausted, got %d points", a.Len())
+			}
+		})
+	}
+}
@@ -198,8 +198,6 @@ func (e *Engine) DeletePrefixRange(rootCtx context.Context, name []byte, min, ma
 	span.Finish()
 
 	if len(possiblyDead.keys) > 0 {
-		buf := make([]byte, 1024)
-
 		// TODO(jeff): all of these methods have possible errors which opens us to partial
 		// failure scenarios. we need to either ensure that partial errors here are ok or
 		// do something to fix it.
@@ -257,27 +255,36 @@ func (e *Engine) DeletePrefixRange(rootCtx context.Context, name []byte, min, ma
 		// This is the slow path, when not dropping the entire bucket (measurement)
 		span, _ = tracing.StartSpanFromContextWithOperationName(rootCtx, "TSI/SFile Delete keys")
 		span.LogKV("measurement_name", fmt.Sprintf("%x", name), "keys_to_delete", len(possiblyDead.keys))
-		for key := range possiblyDead.keys {
-			// TODO(jeff): ugh reduce copies here
-			keyb := []byte(key)
-			keyb, _ = SeriesAndFieldFromCompositeKey(keyb)
-
-			name, tags := models.ParseKeyBytes(keyb)
-			sid := e.sfile.SeriesID(name, tags, buf)
-			if sid.IsZero() {
-				continue
-			}
-
-			if err := e.index.DropSeries(sid, keyb, true); err != nil {
-				return err
-			}
-
-			if err := e.sfile.DeleteSeriesID(sid); err != nil {
-				return err
-			}
+		if err := e.dropSeriesKeys(possiblyDead.keys); err != nil {
+			return err
 		}
 		span.Finish()
 	}
 
 	return nil
 }
+
+// dropSeriesKeys removes the series of the composite keys from the index and the series file.
+func (e *Engine) dropSeriesKeys(keys map[string]struct{}) error {
+	buf := make([]byte, 1024)
+	for key := range keys {
+		// TODO(jeff): ugh reduce copies here
+		keyb := []byte(key)
+		keyb, _ = SeriesAndFieldFromCompositeKey(keyb)
+
+		name, tags := models.ParseKeyBytes(keyb)
+		sid := e.sfile.SeriesID(name, tags, buf)
+		if sid.IsZero() {
+			continue
+		}
+
+		if err := e.index.DropSeries(sid, keyb, true); err != nil {
+			return err
+		}
+
+		if err := e.sfile.DeleteSeriesID(sid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// DropPartitions removes the time partitions of a bucket that end at or before max,
+// and removes from the index and series file the series of the bucket left without
+// any data. Unlike DeletePrefixRange, whole TSM files are removed and no tombstone
+// is written. It returns the number of bytes reclaimed on disk.
+func (e *Engine) DropPartitions(rootCtx context.Context, orgID, bucketID influxdb.ID, max int64) (int64, error) {
+	span, _ := tracing.StartSpanFromContext(rootCtx)
+	span.LogKV("org_id", orgID, "bucket_id", bucketID, "max", time.Unix(0, max))
+	defer span.Finish()
+
+	bucketDir := filepath.Join(e.path, orgID.String(), bucketID.String())
+	fis, err := ioutil.ReadDir(bucketDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	dirs := make(map[string]struct{})
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+
+		p, err := ParsePartitionDir(filepath.Join(orgID.String(), bucketID.String(), fi.Name()))
+		if err != nil {
+			e.logger.Warn("Skipping unknown partition directory", zap.String("path", filepath.Join(bucketDir, fi.Name())), zap.Error(err))
+			continue
+		}
+		if p.Max <= max {
+			dirs[filepath.Join(bucketDir, fi.Name())] = struct{}{}
+		}
+	}
+	if len(dirs) == 0 {
+		return 0, nil
+	}
+
+	// Ensure that the index does not compact away the series we're going to delete
+	// before we're done with them, and that the files of the partitions are not being
+	// compacted while they are removed.
+	span, _ = tracing.StartSpanFromContextWithOperationName(rootCtx, "disable compactions")
+	e.index.DisableCompactions()
+	defer e.index.EnableCompactions()
+	e.index.Wait()
+
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	e.sfile.DisableCompactions()
+	defer e.sfile.EnableCompactions()
+	span.Finish()
+
+	// Find the files of the expired partitions and keep track of their keys, which
+	// possibly no longer have any data once the files are removed.
+	var (
+		files        []string
+		reclaimed    int64
+		iterErr      error
+		possiblyDead = make(map[string]struct{})
+	)
+	e.FileStore.ForEachFile(func(f TSMFile) bool {
+		if _, ok := dirs[filepath.Dir(f.Path())]; !ok {
+			return true
+		}
+
+		files = append(files, f.Path())
+		reclaimed += int64(f.Size())
+		for _, ts := range f.TombstoneFiles() {
+			reclaimed += int64(ts.Size)
+		}
+		if fi, err := os.Stat(StatsFilename(f.Path())); err == nil {
+			reclaimed += fi.Size()
+		}
+
+		iter := f.Iterator(nil)
+		for iter.Next() {
+			possiblyDead[string(iter.Key())] = struct{}{}
+		}
+		iterErr = iter.Err()
+		return iterErr == nil
+	})
+	if iterErr != nil {
+		return 0, iterErr
+	}
+
+	span, _ = tracing.StartSpanFromContextWithOperationName(rootCtx, "remove partition files")
+	span.LogKV("partitions", len(dirs), "files", len(files), "bytes", reclaimed)
+	if err := e.FileStore.Replace(files, nil); err != nil {
+		return 0, err
+	}
+
+	// A directory still holding files used by running queries is left behind, and
+	// removed by a later call once the files are gone.
+	for dir := range dirs {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			e.logger.Info("Unable to remove partition directory", zap.String("path", dir), zap.Error(err))
+		}
+	}
+	span.Finish()
+
+	if len(possiblyDead) == 0 {
+		return reclaimed, nil
+	}
+
+	// Keep the keys that still have data in other files or in the cache.
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := models.EscapeMeasurement(encoded[:])
+
+	var mu sync.Mutex
+	if err := e.FileStore.Apply(func(r TSMFile) error {
+		iter := r.Iterator(name)
+		for iter.Next() {
+			key := iter.Key()
+			if !bytes.HasPrefix(key, name) {
+				break
+			}
+
+			mu.Lock()
+			delete(possiblyDead, string(key))
+			mu.Unlock()
+		}
+		return iter.Err()
+	}); err != nil {
+		return reclaimed, err
+	}
+
+	// ApplyEntryFn cannot return an error in this invocation.
+	_ = e.Cache.ApplyEntryFn(func(k string, _ *entry) error {
+		delete(possiblyDead, k)
+		return nil
+	})
+
+	span, _ = tracing.StartSpanFromContextWithOperationName(rootCtx, "TSI/SFile Delete keys")
+	span.LogKV("keys_to_delete", len(possiblyDead))
+	defer span.Finish()
+	return reclaimed, e.dropSeriesKeys(possiblyDead)
+}
+
+// HasUnpartitionedData returns true if the TSM files that are not part of any time
+// partition may hold data of the bucket at or before max. Such files are written by
+// an engine that does not partition its data.
+func (e *Engine) HasUnpartitionedData(orgID, bucketID influxdb.ID, max int64) bool {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := models.EscapeMeasurement(encoded[:])
+	root := filepath.Clean(e.path)
+
+	var found bool
+	e.FileStore.ForEachFile(func(f TSMFile) bool {
+		if filepath.Dir(f.Path()) != root {
+			return true
+		}
+		found = f.OverlapsKeyPrefixRange(name, name) && f.OverlapsTimeRange(math.MinInt64, max)
+		return !found
+	})
+	return found
+}
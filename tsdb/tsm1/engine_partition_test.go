@@ -0,0 +1,277 @@
+package tsm1_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+)
+
+func TestParsePartitionDir(t *testing.T) {
+	for _, p := range []tsm1.Partition{
+		{OrgID: 1, BucketID: 2, Min: 0, Max: int64(time.Hour)},
+		{OrgID: 0xaa, BucketID: 0xbb, Min: -int64(time.Hour), Max: 0},
+		{OrgID: 3, BucketID: 4, Min: -2 * int64(time.Hour), Max: -int64(time.Hour)},
+	} {
+		got, err := tsm1.ParsePartitionDir(p.Dir())
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", p.Dir(), err)
+		}
+		if got != p {
+			t.Fatalf("got %+v, expected %+v", got, p)
+		}
+	}
+
+	for _, dir := range []string{
+		"0000000000000001/0000000000000002",
+		"0000000000000001/0000000000000002/0-3600",
+		"0000000000000001/bucket/0_3600",
+		"0000000000000001/0000000000000002/a_b",
+	} {
+		if _, err := tsm1.ParsePartitionDir(dir); err == nil {
+			t.Fatalf("expected an error parsing %s", dir)
+		}
+	}
+}
+
+func TestEngine_DropPartitions(t *testing.T) {
+	const hour = int64(time.Hour)
+	org, bucket, other := influxdb.ID(1), influxdb.ID(2), influxdb.ID(3)
+
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Compactor.PartitionDuration = func(name []byte) time.Duration { return time.Hour }
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	e.MustWritePointsString(org, bucket, `
+cpu,host=A value=1 10
+cpu,host=A value=2 3600000000010
+cpu,host=B value=3 7200000000010
+mem,host=A value=4 20
+`)
+	e.MustWritePointsString(org, other, `
+cpu,host=A value=5 10
+`)
+	e.MustWriteSnapshot()
+
+	for _, p := range []tsm1.Partition{
+		{OrgID: org, BucketID: bucket, Min: 0, Max: hour},
+		{OrgID: org, BucketID: bucket, Min: hour, Max: 2 * hour},
+		{OrgID: org, BucketID: bucket, Min: 2 * hour, Max: 3 * hour},
+		{OrgID: org, BucketID: other, Min: 0, Max: hour},
+	} {
+		if _, err := os.Stat(filepath.Join(e.Path(), p.Dir())); err != nil {
+			t.Fatalf("expected partition %s to exist: %v", p.Dir(), err)
+		}
+	}
+	if got, exp := e.FileStore.Count(), 4; got != exp {
+		t.Fatalf("got %d files, expected %d", got, exp)
+	}
+
+	// Nothing ends before the first partition.
+	if reclaimed, err := e.DropPartitions(context.Background(), org, bucket, hour-1); err != nil {
+		t.Fatal(err)
+	} else if reclaimed != 0 {
+		t.Fatalf("got %d bytes reclaimed, expected none", reclaimed)
+	}
+
+	reclaimed, err := e.DropPartitions(context.Background(), org, bucket, 2*hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reclaimed <= 0 {
+		t.Fatalf("got %d bytes reclaimed, expected some", reclaimed)
+	}
+	if got, exp := e.FileStore.Count(), 2; got != exp {
+		t.Fatalf("got %d files, expected %d", got, exp)
+	}
+	for _, p := range []tsm1.Partition{
+		{OrgID: org, BucketID: bucket, Min: 0, Max: hour},
+		{OrgID: org, BucketID: bucket, Min: hour, Max: 2 * hour},
+	} {
+		if _, err := os.Stat(filepath.Join(e.Path(), p.Dir())); !os.IsNotExist(err) {
+			t.Fatalf("expected partition %s to be removed, got %v", p.Dir(), err)
+		}
+	}
+
+	// The series left without data are removed from the index, the others are kept.
+	var n int
+	itr, err := e.index.MeasurementSeriesIDIterator(tsdb.EncodeNameSlice(org, bucket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		elem, err := itr.Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if elem.SeriesID.IsZero() {
+			break
+		}
+		n++
+	}
+	if err := itr.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := n, 1; got != exp {
+		t.Fatalf("got %d series in the index, expected %d", got, exp)
+	}
+
+	if e.HasUnpartitionedData(org, bucket, 3*hour) {
+		t.Fatal("expected no unpartitioned data")
+	}
+
+	// The files of the partitions are loaded when the engine is reopened.
+	if err := e.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := e.FileStore.Count(), 2; got != exp {
+		t.Fatalf("got %d files after reopening, expected %d", got, exp)
+	}
+}
+
+func TestEngine_SnapshotRestorePartitions(t *testing.T) {
+	const hour = int64(time.Hour)
+	org, bucket := influxdb.ID(1), influxdb.ID(2)
+
+	e, err := NewEngine(tsm1.NewConfig(), t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Compactor.PartitionDuration = func(name []byte) time.Duration { return time.Hour }
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// Every snapshot starts the files of each partition at the same
+	// generation, so their names collide across partitions.
+	e.MustWritePointsString(org, bucket, `
+cpu,host=A value=1 10
+cpu,host=A value=2 3600000000010
+`)
+	e.MustWriteSnapshot()
+
+	_, snapshotDir, snapshotFiles, err := e.FileStore.CreateIncrementalSnapshot(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(snapshotDir)
+	if got, exp := len(snapshotFiles), 2; got != exp {
+		t.Fatalf("got %d snapshot files, expected %d", got, exp)
+	}
+
+	// Restore the snapshot files by name into the directory of another store.
+	restoreDir := MustTempDir()
+	defer os.RemoveAll(restoreDir)
+	for _, f := range snapshotFiles {
+		p := tsm1.Partition{OrgID: org, BucketID: bucket}
+		if dir := filepath.Dir(filepath.FromSlash(f.Name)); dir != "." {
+			if p, err = tsm1.ParsePartitionDir(dir); err != nil {
+				t.Fatalf("snapshot file %s is not in a partition: %v", f.Name, err)
+			}
+		}
+		b, err := ioutil.ReadFile(filepath.Join(snapshotDir, filepath.FromSlash(f.Name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := filepath.Join(restoreDir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(dst, b, 0666); err != nil {
+			t.Fatal(err)
+		}
+		if p.Max-p.Min != hour {
+			t.Fatalf("snapshot file %s is in partition %+v, expected an hour long partition", f.Name, p)
+		}
+	}
+
+	fs := tsm1.NewFileStore(restoreDir)
+	if err := fs.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if got, exp := fs.Count(), e.FileStore.Count(); got != exp {
+		t.Fatalf("got %d restored files, expected %d", got, exp)
+	}
+
+	var restored []string
+	for _, f := range fs.Files() {
+		rel, err := filepath.Rel(restoreDir, f.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored = append(restored, filepath.ToSlash(rel))
+
+		key, _ := f.KeyRange()
+		min, _ := f.TimeRange()
+		exp, err := e.FileStore.Read(key, min)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := fs.Read(key, min)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || len(exp) != 1 || got[0].Value() != exp[0].Value() {
+			t.Fatalf("got values %v of %s, expected %v", got, rel, exp)
+		}
+	}
+	for i, f := range snapshotFiles {
+		if restored[i] != f.Name {
+			t.Fatalf("got restored files %v, expected the files of the snapshot %v", restored, snapshotFiles)
+		}
+	}
+}
+
+func TestFileStore_Open_PartitionGenerations(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	// The partition directory of the older generation sorts after the root
+	// files by path.
+	p := tsm1.Partition{OrgID: 0xaaaaaaaaaaaaaaaa, BucketID: 0xbbbbbbbbbbbbbbbb, Min: 0, Max: int64(time.Hour)}
+	partitionDir := filepath.Join(dir, p.Dir())
+	if err := os.MkdirAll(partitionDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newFiles(partitionDir, keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newFiles(dir,
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(0, 0.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 2.0)}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	// The value of the newer generation wins.
+	buf := make([]tsm1.FloatValue, 1000)
+	c := fs.KeyCursor(context.Background(), []byte("cpu"), 0, true)
+	defer c.Close()
+	values, err := c.ReadFloatBlock(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0].Value() != 2.0 {
+		t.Fatalf("got values %v, expected the value of the newer generation", values)
+	}
+}
@@ -625,7 +625,7 @@ func (f *FileStore) Open(ctx context.Context) error {
 		}
 	}
 
-	files, err := filepath.Glob(filepath.Join(f.dir, fmt.Sprintf("*.%s", TSMFileExtension)))
+	files, err := TSMFiles(f.dir)
 	if err != nil {
 		return err
 	}
@@ -1005,8 +1005,19 @@ func (f *FileStore) replace(oldFiles, newFiles []string, updatedFn func(r []TSMF
 		}
 	}
 
-	if err := fs.SyncDir(f.dir); err != nil {
-		return err
+	// Sync the directories of the files that were added or removed, which include
+	// the ones of time partitions.
+	dirs := map[string]struct{}{f.dir: {}}
+	for _, file := range oldFiles {
+		dirs[filepath.Dir(file)] = struct{}{}
+	}
+	for _, file := range newFiles {
+		dirs[filepath.Dir(file)] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := fs.SyncDir(dir); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
 
 	// Tell the purger about our in-use files we need to remove
@@ -1211,6 +1222,23 @@ func (f *FileStore) locations(key []byte, t int64, ascending bool) []*location {
 // CreateSnapshot creates hardlinks for all tsm and tombstone files
 // in the path provided.
 func (f *FileStore) CreateSnapshot(ctx context.Context) (backupID int, backupDirFullPath string, err error) {
+	backupID, backupDirFullPath, _, err = f.CreateIncrementalSnapshot(ctx, nil)
+	return backupID, backupDirFullPath, err
+}
+
+// SnapshotFile is a tsm or tombstone file of a snapshot.
+type SnapshotFile struct {
+	// Name is the slash-separated path of the file relative to the store
+	// directory, so the files of time partitions keep their directories.
+	Name string
+	Size int64
+}
+
+// CreateIncrementalSnapshot creates hardlinks for the tsm and tombstone files
+// for which include returns true, or all files if include is nil. It returns
+// every file of the store at the time of the snapshot, including those
+// that were not linked.
+func (f *FileStore) CreateIncrementalSnapshot(ctx context.Context, include func(SnapshotFile) bool) (backupID int, backupDirFullPath string, snapshotFiles []SnapshotFile, err error) {
 	span, _ := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
@@ -1239,22 +1267,36 @@ func (f *FileStore) CreateSnapshot(ctx context.Context) (backupID int, backupDir
 	// mutable state.
 	err = os.Mkdir(backupDirFullPath, 0777)
 	if err != nil {
-		return 0, "", err
+		return 0, "", nil, err
+	}
+	link := func(path string, size int64) error {
+		name, err := filepath.Rel(f.dir, path)
+		if err != nil {
+			return err
+		}
+		sf := SnapshotFile{Name: filepath.ToSlash(name), Size: size}
+		snapshotFiles = append(snapshotFiles, sf)
+		if include != nil && !include(sf) {
+			return nil
+		}
+		dst := filepath.Join(backupDirFullPath, name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+			return err
+		}
+		return os.Link(path, dst)
 	}
 	for _, tsmf := range files {
-		newpath := filepath.Join(backupDirFullPath, filepath.Base(tsmf.Path()))
-		if err := os.Link(tsmf.Path(), newpath); err != nil {
-			return 0, "", fmt.Errorf("error creating tsm hard link: %q", err)
+		if err := link(tsmf.Path(), int64(tsmf.Size())); err != nil {
+			return 0, "", nil, fmt.Errorf("error creating tsm hard link: %q", err)
 		}
 		for _, tf := range tsmf.TombstoneFiles() {
-			newpath := filepath.Join(backupDirFullPath, filepath.Base(tf.Path))
-			if err := os.Link(tf.Path, newpath); err != nil {
-				return 0, "", fmt.Errorf("error creating tombstone hard link: %q", err)
+			if err := link(tf.Path, int64(tf.Size)); err != nil {
+				return 0, "", nil, fmt.Errorf("error creating tombstone hard link: %q", err)
 			}
 		}
 	}
 
-	return backupID, backupDirFullPath, nil
+	return backupID, backupDirFullPath, snapshotFiles, nil
 }
 
 func (f *FileStore) InternalBackupPath(backupID int) string {
@@ -1370,7 +1412,7 @@ func (a descLocations) Len() int      { return len(a) }
 func (a descLocations) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a descLocations) Less(i, j int) bool {
 	if a[i].entry.OverlapsTimeRange(a[j].entry.MinTime, a[j].entry.MaxTime) {
-		return a[i].r.Path() < a[j].r.Path()
+		return tsmFileLess(a[i].r.Path(), a[j].r.Path())
 	}
 	return a[i].entry.MaxTime < a[j].entry.MaxTime
 }
@@ -1382,7 +1424,7 @@ func (a ascLocations) Len() int      { return len(a) }
 func (a ascLocations) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a ascLocations) Less(i, j int) bool {
 	if a[i].entry.OverlapsTimeRange(a[j].entry.MinTime, a[j].entry.MaxTime) {
-		return a[i].r.Path() < a[j].r.Path()
+		return tsmFileLess(a[i].r.Path(), a[j].r.Path())
 	}
 	return a[i].entry.MinTime < a[j].entry.MinTime
 }
@@ -1609,5 +1651,15 @@ func (p *purger) purge() {
 type tsmReaders []TSMFile
 
 func (a tsmReaders) Len() int           { return len(a) }
-func (a tsmReaders) Less(i, j int) bool { return a[i].Path() < a[j].Path() }
+func (a tsmReaders) Less(i, j int) bool { return tsmFileLess(a[i].Path(), a[j].Path()) }
 func (a tsmReaders) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// tsmFileLess orders TSM files by generation and sequence, which prefix their
+// names, regardless of the time partition they are in.
+func tsmFileLess(a, b string) bool {
+	ba, bb := filepath.Base(a), filepath.Base(b)
+	if ba != bb {
+		return ba < bb
+	}
+	return a < b
+}
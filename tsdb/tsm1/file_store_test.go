@@ -2754,6 +2754,56 @@ func TestFileStore_CreateSnapshot(t *testing.T) {
 	}
 }
 
+func TestFileStore_CreateIncrementalSnapshot(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	// Setup 3 files
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(1, 2.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(2, 3.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+
+	fs.Replace(nil, files)
+
+	// Exclude the first file of a previous snapshot.
+	_, _, prev, err := fs.CreateIncrementalSnapshot(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(prev), 3; got != exp {
+		t.Fatalf("unexpected number of snapshot files: got %d, exp %d", got, exp)
+	}
+	excluded := prev[0]
+
+	_, s, snapshotFiles, err := fs.CreateIncrementalSnapshot(context.Background(), func(f tsm1.SnapshotFile) bool {
+		return f != excluded
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := len(snapshotFiles), 3; got != exp {
+		t.Fatalf("unexpected number of snapshot files: got %d, exp %d", got, exp)
+	}
+
+	for _, f := range snapshotFiles {
+		_, err := os.Stat(filepath.Join(s, f.Name))
+		if f == excluded && !os.IsNotExist(err) {
+			t.Fatalf("expected excluded file %q not to be linked: %v", f.Name, err)
+		} else if f != excluded && err != nil {
+			t.Fatalf("unable to find file %q: %v", f.Name, err)
+		}
+	}
+}
+
 type mockObserver struct {
 	fileFinishing func(path string) error
 	fileUnlinking func(path string) error
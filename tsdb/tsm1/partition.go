@@ -0,0 +1,301 @@
+package tsm1
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// partitionGlob matches the directories of all partitions, relative to the engine path.
+const partitionGlob = "*/*/*_*"
+
+// TSMFiles returns the paths of the TSM files of the engine directory dir,
+// including the files of its time partitions.
+func TSMFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*."+TSMFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	partitionFiles, err := filepath.Glob(filepath.Join(dir, partitionGlob, "*."+TSMFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	return append(files, partitionFiles...), nil
+}
+
+// PartitionDurationFunc returns the time span of the partitions of the bucket
+// identified by name, the unescaped measurement name of a series key. The data
+// of measurements for which it returns a non-positive duration is not partitioned.
+type PartitionDurationFunc func(name []byte) time.Duration
+
+// A Partition is a time range of the data of a bucket. The TSM files of a partition
+// are stored in a directory of their own, so that the partition can be dropped
+// as a whole once all of its data has expired.
+type Partition struct {
+	OrgID    influxdb.ID
+	BucketID influxdb.ID
+	Min      int64 // inclusive
+	Max      int64 // exclusive
+}
+
+// Dir returns the directory of the partition, relative to the engine path.
+func (p Partition) Dir() string {
+	return filepath.Join(p.OrgID.String(), p.BucketID.String(), fmt.Sprintf("%d_%d", p.Min, p.Max))
+}
+
+// ParsePartitionDir parses the directory of a partition, relative to the engine path.
+func ParsePartitionDir(dir string) (Partition, error) {
+	parts := strings.Split(filepath.ToSlash(dir), "/")
+	if len(parts) != 3 {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly", dir)
+	}
+
+	var p Partition
+	if err := p.OrgID.DecodeFromString(parts[0]); err != nil {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly: %v", dir, err)
+	}
+	if err := p.BucketID.DecodeFromString(parts[1]); err != nil {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly: %v", dir, err)
+	}
+
+	// The bounds are separated by an underscore as either of them may be negative.
+	idx := strings.Index(parts[2][1:], "_") + 1
+	if idx == 0 {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly", dir)
+	}
+	min, err := strconv.ParseInt(parts[2][:idx], 10, 64)
+	if err != nil {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly: %v", dir, err)
+	}
+	max, err := strconv.ParseInt(parts[2][idx+1:], 10, 64)
+	if err != nil {
+		return Partition{}, fmt.Errorf("partition directory %s is named incorrectly: %v", dir, err)
+	}
+	p.Min, p.Max = min, max
+	return p, nil
+}
+
+// partitionRange returns the bounds of the partition of duration d containing t.
+// Partitions are aligned the same way as the shard groups of InfluxDB 1.x.
+func partitionRange(t int64, d time.Duration) (min, max int64) {
+	min = time.Unix(0, t).UTC().Truncate(d).UnixNano()
+	max = min + int64(d)
+	if max < min {
+		max = math.MaxInt64 // overflow
+	}
+	return min, max
+}
+
+// partitionCache splits the cache into one cache per time partition, as determined
+// by durationFn. The values of keys that are not partitioned are returned in the
+// cache of the zero Partition.
+func partitionCache(cache *Cache, durationFn PartitionDurationFunc) map[Partition]*Cache {
+	caches := make(map[Partition]*Cache)
+	write := func(p Partition, key []byte, values Values) {
+		c := caches[p]
+		if c == nil {
+			c = &Cache{store: newRing()}
+			caches[p] = c
+		}
+		// Writing to a ring can only fail on a type conflict, which the values
+		// of a single entry never have.
+		_, _ = c.store.write(key, values)
+	}
+
+	durations := make(map[string]time.Duration)
+	_ = cache.ApplyEntryFn(func(k string, e *entry) error {
+		key := []byte(k)
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		name := models.ParseName(seriesKey)
+
+		d, ok := durations[string(name)]
+		if !ok {
+			if len(name) == len(tsdb.EncodeName(0, 0)) {
+				d = durationFn(name)
+			}
+			durations[string(name)] = d
+		}
+
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		if d <= 0 {
+			write(Partition{}, key, values)
+			return nil
+		}
+
+		p := Partition{}
+		p.OrgID, p.BucketID = tsdb.DecodeNameSlice(name)
+
+		// Write consecutive runs of values falling in the same partition together.
+		start := 0
+		for i, v := range values {
+			if i > start && v.UnixNano() >= p.Min && v.UnixNano() < p.Max {
+				continue
+			}
+			if i > start {
+				write(p, key, values[start:i])
+				start = i
+			}
+			p.Min, p.Max = partitionRange(v.UnixNano(), d)
+		}
+		if start < len(values) {
+			write(p, key, values[start:])
+		}
+		return nil
+	})
+	return caches
+}
+
+// PartitionPlanner implements CompactionPlanner by planning the TSM files of every
+// time partition with a DefaultPlanner of its own, so that no compaction ever
+// combines the files of different partitions. The TSM files that are not part of
+// a partition are planned together, as a partition of their own.
+type PartitionPlanner struct {
+	fileStore         fileStore
+	writeColdDuration time.Duration
+
+	mu       sync.Mutex
+	planners map[string]*DefaultPlanner // by directory
+}
+
+// NewPartitionPlanner returns a planner for the partitions of the file store.
+func NewPartitionPlanner(fs fileStore, writeColdDuration time.Duration) *PartitionPlanner {
+	return &PartitionPlanner{
+		fileStore:         fs,
+		writeColdDuration: writeColdDuration,
+		planners:          make(map[string]*DefaultPlanner),
+	}
+}
+
+// partitionFileStore is the view of a file store limited to the files of a directory.
+type partitionFileStore struct {
+	fileStore
+	dir string
+}
+
+func (fs *partitionFileStore) Stats() []FileStat {
+	var stats []FileStat
+	for _, st := range fs.fileStore.Stats() {
+		if filepath.Dir(st.Path) == fs.dir {
+			stats = append(stats, st)
+		}
+	}
+	return stats
+}
+
+func (p *PartitionPlanner) SetFileStore(fs *FileStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fileStore = fs
+	p.planners = make(map[string]*DefaultPlanner)
+}
+
+// partitionPlanners returns the planners of all directories holding TSM files,
+// creating the ones of new directories and discarding the idle ones of
+// directories that no longer hold any file.
+func (p *PartitionPlanner) partitionPlanners() []*DefaultPlanner {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dirs := make(map[string]struct{})
+	for _, st := range p.fileStore.Stats() {
+		dirs[filepath.Dir(st.Path)] = struct{}{}
+	}
+
+	for dir := range dirs {
+		if _, ok := p.planners[dir]; !ok {
+			p.planners[dir] = NewDefaultPlanner(&partitionFileStore{fileStore: p.fileStore, dir: dir}, p.writeColdDuration)
+		}
+	}
+
+	sorted := make([]string, 0, len(p.planners))
+	for dir, planner := range p.planners {
+		if _, ok := dirs[dir]; !ok {
+			planner.mu.RLock()
+			idle := len(planner.filesInUse) == 0
+			planner.mu.RUnlock()
+			if idle {
+				delete(p.planners, dir)
+				continue
+			}
+		}
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	planners := make([]*DefaultPlanner, 0, len(sorted))
+	for _, dir := range sorted {
+		planners = append(planners, p.planners[dir])
+	}
+	return planners
+}
+
+// Plan returns the full compaction plans of all partitions.
+func (p *PartitionPlanner) Plan(lastWrite time.Time) []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.Plan(lastWrite)...)
+	}
+	return groups
+}
+
+// PlanLevel returns the compaction plans of all partitions for a specific level.
+func (p *PartitionPlanner) PlanLevel(level int) []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.PlanLevel(level)...)
+	}
+	return groups
+}
+
+// PlanOptimize returns the optimize plans of all partitions.
+func (p *PartitionPlanner) PlanOptimize() []CompactionGroup {
+	var groups []CompactionGroup
+	for _, planner := range p.partitionPlanners() {
+		groups = append(groups, planner.PlanOptimize()...)
+	}
+	return groups
+}
+
+// Release releases the files of each compaction group with the planner of its partition.
+func (p *PartitionPlanner) Release(groups []CompactionGroup) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, g := range groups {
+		if len(g) == 0 {
+			continue
+		}
+		if planner, ok := p.planners[filepath.Dir(g[0])]; ok {
+			planner.Release([]CompactionGroup{g})
+		}
+	}
+}
+
+// FullyCompacted returns true if every partition is fully compacted.
+func (p *PartitionPlanner) FullyCompacted() bool {
+	for _, planner := range p.partitionPlanners() {
+		if !planner.FullyCompacted() {
+			return false
+		}
+	}
+	return true
+}
+
+// ForceFull causes the planners of all partitions to return a full compaction
+// plan the next time a plan is requested.
+func (p *PartitionPlanner) ForceFull() {
+	for _, planner := range p.partitionPlanners() {
+		planner.ForceFull()
+	}
+}
@@ -102,7 +102,7 @@ func (r *Report) Run(print bool) (*ReportSummary, error) {
 
 	minTime, maxTime := int64(math.MaxInt64), int64(math.MinInt64)
 
-	files, err := filepath.Glob(filepath.Join(r.Dir, "*.tsm"))
+	files, err := TSMFiles(r.Dir)
 	if err != nil {
 		panic(err) // Only error would be a bad pattern; not runtime related.
 	}
@@ -26,6 +26,11 @@ type Batcher struct {
 	MaxFlushBytes    int                   // MaxFlushBytes is the maximum number of bytes to buffer before flushing
 	MaxFlushInterval time.Duration         // MaxFlushInterval is the maximum amount of time to wait before flushing
 	Service          platform.WriteService // Service receives batches flushed from Batcher.
+
+	// OnFlush, if set, is called after every batch written to Service with the
+	// number of bytes read from the input that have been written so far. A
+	// write that fails can be resumed by skipping that many bytes of the input.
+	OnFlush func(written int64)
 }
 
 // Write reads r in batches and sends to the output.
@@ -98,6 +103,21 @@ func (b *Batcher) write(ctx context.Context, org, bucket platform.ID, lines <-ch
 	buf := make([]byte, 0, maxBytes)
 	r := bytes.NewReader(buf)
 
+	var written int64
+	flush := func() error {
+		r.Reset(buf)
+		timer.Reset(flushInterval)
+		if err := b.Service.Write(ctx, org, bucket, r); err != nil {
+			return err
+		}
+		written += int64(len(buf))
+		if b.OnFlush != nil {
+			b.OnFlush(written)
+		}
+		buf = buf[:0]
+		return nil
+	}
+
 	var line []byte
 	var more = true
 	// if read closes the channel normally, exit the loop
@@ -109,23 +129,17 @@ func (b *Batcher) write(ctx context.Context, org, bucket platform.ID, lines <-ch
 			}
 			// write if we exceed the max lines OR read routine has finished
 			if len(buf) >= maxBytes || (!more && len(buf) > 0) {
-				r.Reset(buf)
-				timer.Reset(flushInterval)
-				if err := b.Service.Write(ctx, org, bucket, r); err != nil {
+				if err := flush(); err != nil {
 					errC <- err
 					return
 				}
-				buf = buf[:0]
 			}
 		case <-timer.C:
 			if len(buf) > 0 {
-				r.Reset(buf)
-				timer.Reset(flushInterval)
-				if err := b.Service.Write(ctx, org, bucket, r); err != nil {
+				if err := flush(); err != nil {
 					errC <- err
 					return
 				}
-				buf = buf[:0]
 			}
 		case <-ctx.Done():
 			errC <- ctx.Err()
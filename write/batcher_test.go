@@ -427,3 +427,35 @@ func TestBatcher_WriteTimeout(t *testing.T) {
 		t.Errorf(" Batcher.Write() with timeout got %s", got)
 	}
 }
+
+func TestBatcher_OnFlush(t *testing.T) {
+	var flushes int
+	svc := &mock.WriteService{
+		WriteF: func(ctx context.Context, org, bucket platform.ID, r io.Reader) error {
+			flushes++
+			if flushes == 3 {
+				return fmt.Errorf("error")
+			}
+			return nil
+		},
+	}
+
+	var written []int64
+	b := &Batcher{
+		MaxFlushBytes: len([]byte("m1,t1=v1 f1=1\n")),
+		Service:       svc,
+		OnFlush: func(n int64) {
+			written = append(written, n)
+		},
+	}
+
+	r := strings.NewReader("m1,t1=v1 f1=1\nm2,t2=v2 f2=2\nm3,t3=v3 f3=3")
+	if err := b.Write(context.Background(), platform.ID(1), platform.ID(2), r); err == nil {
+		t.Fatal("Batcher.Write() expected error")
+	}
+
+	// only the batches written before the failure are reported
+	if want := []int64{14, 28}; !cmp.Equal(written, want) {
+		t.Errorf("Batcher.Write() flushed -got/+want %s", cmp.Diff(written, want))
+	}
+}